@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/audit"
+	"github.com/spiffcs/triage/internal/log"
+)
+
+// defaultAuditLimit is the number of most recent entries shown by triage audit.
+const defaultAuditLimit = 50
+
+// NewCmdAudit creates the audit command.
+func NewCmdAudit() *cobra.Command {
+	var limit int
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the log of changes triage made on GitHub",
+		Long: `Display the local, append-only log of every GitHub mutation triage has
+performed (comments, marking notifications read, ...) with its timestamp,
+target, and outcome. Dry runs are not recorded.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runAudit(limit, format)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", defaultAuditLimit, "Number of most recent entries to show (0 for all)")
+	cmd.Flags().StringVarP(&format, "output", "o", "", "Output format (table, json)")
+
+	return cmd
+}
+
+func runAudit(limit int, format string) error {
+	l, err := audit.NewLog()
+	if err != nil {
+		return fmt.Errorf("failed to access audit log: %w", err)
+	}
+
+	entries, err := l.Entries()
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if entries == nil {
+			entries = []audit.Entry{}
+		}
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No mutations recorded (%s).\n", l.Path())
+		return nil
+	}
+
+	for _, e := range entries {
+		line := fmt.Sprintf("%s  %-7s  %s %s", e.Time.Local().Format("2006-01-02 15:04:05"), e.Outcome, e.Action, e.Target)
+		if e.Detail != "" {
+			line += " (" + e.Detail + ")"
+		}
+		if e.Error != "" {
+			line += ": " + e.Error
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// openAuditLog opens the audit log for recording mutations. Auditing is
+// best-effort: if the log cannot be opened a warning is logged and nil is
+// returned, which disables auditing rather than blocking the command.
+func openAuditLog() *audit.Log {
+	l, err := audit.NewLog()
+	if err != nil {
+		log.Warn("could not open audit log, mutations will not be recorded", "error", err)
+		return nil
+	}
+	return l
+}
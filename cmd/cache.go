@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
 	"github.com/spiffcs/triage/internal/cache"
 )
 
@@ -15,6 +17,7 @@ func NewCmdCache() *cobra.Command {
 	}
 
 	cmd.AddCommand(newCmdCacheClear())
+	cmd.AddCommand(newCmdCachePrune())
 	cmd.AddCommand(newCmdCacheStats())
 
 	return cmd
@@ -29,6 +32,18 @@ func newCmdCacheClear() *cobra.Command {
 	}
 }
 
+// newCmdCachePrune creates the cache prune subcommand.
+func newCmdCachePrune() *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune",
+		Short: "Remove expired entries from the cache",
+		Long: `Remove cached item details past their TTL, then the least recently used
+until the rest fit within cache.max_size_mb. Lists are kept for --offline.
+Unlike cache clear, the next run fetches only what was removed.`,
+		RunE: runCachePrune,
+	}
+}
+
 // newCmdCacheStats creates the cache stats subcommand.
 func newCmdCacheStats() *cobra.Command {
 	return &cobra.Command{
@@ -43,6 +58,7 @@ func runCacheClear(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to access cache: %w", err)
 	}
+	defer c.Close()
 
 	if err := c.Clear(); err != nil {
 		return fmt.Errorf("failed to clear cache: %w", err)
@@ -52,11 +68,36 @@ func runCacheClear(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func runCachePrune(_ *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	c, err := cache.NewCache(cache.WithMaxSize(int64(cfg.GetCacheMaxSizeMB()) << 20))
+	if err != nil {
+		return fmt.Errorf("failed to access cache: %w", err)
+	}
+	defer c.Close()
+
+	result, err := c.Prune(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to prune cache: %w", err)
+	}
+
+	fmt.Printf("Removed %d expired or least recently used item details.\n", result.Details)
+	return nil
+}
+
 func runCacheStats(_ *cobra.Command, _ []string) error {
-	c, err := cache.NewCache()
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	c, err := cache.NewCache(cache.WithMaxSize(int64(cfg.GetCacheMaxSizeMB()) << 20))
 	if err != nil {
 		return fmt.Errorf("failed to access cache: %w", err)
 	}
+	defer c.Close()
 
 	stats, err := c.DetailedStats()
 	if err != nil {
@@ -68,6 +109,7 @@ func runCacheStats(_ *cobra.Command, _ []string) error {
 	fmt.Printf("    Total: %d\n", stats.DetailTotal)
 	fmt.Printf("    Valid: %d\n", stats.DetailValid)
 	fmt.Printf("    Expired: %d\n", stats.DetailTotal-stats.DetailValid)
+	fmt.Printf("    Size: %.1f MB of %d MB\n", float64(stats.DetailSize)/(1<<20), stats.MaxSize>>20)
 
 	// Display stats for each list type with appropriate TTL labels
 	listTypeInfo := []struct {
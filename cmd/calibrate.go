@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// NewCmdCalibrate creates the calibrate command.
+func NewCmdCalibrate(opts *Options) *cobra.Command {
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "calibrate",
+		Short: "Report how scores spread across priorities",
+		Long: `Fetch and score your items, then report how many land at each
+priority, how the scores are distributed, and how many items reach each
+promotion threshold. When too many items are Urgent, or too few rise above
+FYI, new thresholds are suggested for the scoring section of your config.
+
+Scores are reported as raw points, which the thresholds apply to, even
+when scoring.normalize_scores is on.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCalibrate(cmd.Context(), opts, since, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "1w", "Include notifications since (e.g., 1d, 1w, 30d)")
+
+	return cmd
+}
+
+func runCalibrate(ctx context.Context, opts *Options, since string, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	cfg, resolvedStore, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// The thresholds apply to raw scores
+	scoring := config.ScoringOverrides{}
+	if cfg.Scoring != nil {
+		scoring = *cfg.Scoring
+	}
+	raw := false
+	scoring.NormalizeScores = &raw
+	cfg.Scoring = &scoring
+
+	items, err := digestItems(ctx, opts, cfg, resolvedStore, since)
+	if err != nil {
+		return err
+	}
+
+	printCalibration(out, triage.Calibrate(items, cfg.GetScoreWeights()), since)
+	return nil
+}
+
+// printCalibration writes the calibration report.
+func printCalibration(w io.Writer, cal triage.Calibration, since string) {
+	if cal.Total == 0 {
+		fmt.Fprintf(w, "No items in the past %s to calibrate against.\n", since)
+		return
+	}
+
+	fmt.Fprintf(w, "Score distribution of %d items from the past %s:\n\n", cal.Total, since)
+	for _, level := range triage.AllPriorityLevels {
+		n := cal.Counts[level]
+		if n == 0 && level == triage.PriorityArchive {
+			continue
+		}
+		fmt.Fprintf(w, "  %-10s %5d  %3d%%  %s\n", level.Display(), n, cal.Share(n), shareBar(cal.Share(n)))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Scores: median %d, 90th percentile %d, max %d\n", cal.Median, cal.P90, cal.Max)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Promotion thresholds:")
+	for _, t := range cal.Thresholds {
+		fmt.Fprintf(w, "  %-30s %4d  %d items (%d%%) at or above\n", t.Key, t.Value, t.Above, cal.Share(t.Above))
+	}
+
+	fmt.Fprintln(w)
+	if len(cal.Suggestions) == 0 {
+		fmt.Fprintln(w, "The thresholds look reasonable for these items.")
+		return
+	}
+	fmt.Fprintln(w, "Suggested changes:")
+	for _, s := range cal.Suggestions {
+		fmt.Fprintf(w, "  %s: %d -> %d (%s)\n", s.Key, s.Current, s.Suggested, s.Reason)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "To apply them, add to your config:")
+	fmt.Fprintln(w, "  scoring:")
+	for _, s := range cal.Suggestions {
+		fmt.Fprintf(w, "    %s: %d\n", s.Key, s.Suggested)
+	}
+}
+
+// shareBar draws a percentage as a bar of up to 25 characters.
+func shareBar(percent int) string {
+	return strings.Repeat("#", percent/4)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestPrintCalibration(t *testing.T) {
+	tests := []struct {
+		name string
+		cal  triage.Calibration
+		want []string
+	}{
+		{
+			name: "no items",
+			cal:  triage.Calibration{},
+			want: []string{"No items in the past 1w"},
+		},
+		{
+			name: "with suggestion",
+			cal: triage.Calibration{
+				Total:  10,
+				Counts: map[triage.PriorityLevel]int{triage.PriorityUrgent: 8, triage.PriorityFYI: 2},
+				Thresholds: []triage.ThresholdPosition{
+					{Key: "important_promotion_threshold", Value: 100, Above: 8},
+				},
+				Suggestions: []triage.ThresholdSuggestion{
+					{Key: "important_promotion_threshold", Current: 100, Suggested: 201, Reason: "80% of items are Urgent"},
+				},
+			},
+			want: []string{
+				"Score distribution of 10 items",
+				"80%  ####################",
+				"8 items (80%) at or above",
+				"important_promotion_threshold: 100 -> 201",
+				"    important_promotion_threshold: 201",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printCalibration(&buf, tt.cal, "1w")
+			out := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
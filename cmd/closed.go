@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/confirm"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/service"
+	"github.com/spiffcs/triage/internal/setup"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// NewCmdClosed creates the closed command.
+func NewCmdClosed(opts *Options) *cobra.Command {
+	var since string
+	var markRead bool
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "closed",
+		Short: "Show what you were involved in that closed or merged recently",
+		Long: `List the issues and PRs with unread notifications that were closed or
+merged recently and that you took part in: you authored, were assigned,
+commented, reviewed, or were asked for a review or mentioned. Items you only
+watch are left out.
+
+The main list hides closed items, so their notifications pile up. Look
+through these to confirm nothing needs a follow-up, then clear them all
+with --mark-read.`,
+		Example: `  triage closed
+  triage closed --since 2w --mark-read`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runClosed(cmd.Context(), opts, since, markRead, outputFormat, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "1w", "Show items closed since (e.g., 1d, 1w, 30d)")
+	cmd.Flags().BoolVar(&markRead, "mark-read", false, "Mark the listed items' notifications read")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json)")
+
+	return cmd
+}
+
+func runClosed(ctx context.Context, opts *Options, since string, markRead bool, outputFormat string, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	closedSince, err := duration.Parse(since)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	policies, err := confirm.NewPolicies(cfg.GetConfirmations())
+	if err != nil {
+		return err
+	}
+	token := cfg.GetGitHubToken()
+	if token == "" {
+		return setup.TokenMissing()
+	}
+	httpPolicy, err := ghclient.NewHTTPPolicy(cfg.GetHTTP())
+	if err != nil {
+		return err
+	}
+	client, err := ghclient.NewClient(ctx, token,
+		ghclient.WithHTTPPolicy(httpPolicy),
+		ghclient.WithDryRun(opts.DryRun),
+		ghclient.WithAuditLog(openAuditLog()),
+		ghclient.WithProjectStatus(len(cfg.ProjectColumnScores) > 0),
+	)
+	if err != nil {
+		return err
+	}
+	c := openCache(cfg)
+	if c != nil {
+		defer c.Close()
+	}
+	currentUser, err := service.ResolveUser(ctx, client, c, token, opts.RefreshIdentity)
+	if err != nil {
+		return setup.TokenInvalid(err)
+	}
+	// Closing an item updates its notification thread, so notifications
+	// since the window cover everything closed in it
+	svc := service.New(client, c, currentUser, closedSince, service.WithProjectStatus(len(cfg.ProjectColumnScores) > 0))
+
+	traceCtx, endTrace := startTrace(ctx, "closed")
+	fetched, err := svc.UnreadItems(traceCtx, false)
+	if err != nil {
+		endTrace()
+		return err
+	}
+	items := fetched.Items
+	if _, err := svc.Enrich(traceCtx, items, nil); err != nil {
+		log.Warn("some items could not be enriched", "error", err)
+	}
+	endTrace()
+	activity.Apply(items, currentUser, openActivityStore())
+	closed := triage.ClosedSince(items, currentUser, closedSince)
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if closed == nil {
+			closed = []model.Item{}
+		}
+		if err := enc.Encode(closed); err != nil {
+			return err
+		}
+	} else if err := writeClosed(out, closed, since, time.Now()); err != nil {
+		return err
+	}
+
+	if !markRead || len(closed) == 0 {
+		return nil
+	}
+	if policies.Required(confirm.ActionMarkReadBulk, len(closed)) &&
+		!confirmOnTerminal(fmt.Sprintf("Mark %d notifications read?", len(closed))) {
+		fmt.Fprintln(out, "Nothing marked read.")
+		return nil
+	}
+	return markClosedRead(ctx, client, closed, out)
+}
+
+// writeClosed lists the closed items, most recently closed first.
+func writeClosed(w io.Writer, items []model.Item, since string, now time.Time) error {
+	if len(items) == 0 {
+		_, err := fmt.Fprintf(w, "Nothing you were involved in closed in the past %s.\n", since)
+		return err
+	}
+
+	noun := "items"
+	if len(items) == 1 {
+		noun = "item"
+	}
+	if _, err := fmt.Fprintf(w, "%d %s you were involved in closed in the past %s:\n\n", len(items), noun, since); err != nil {
+		return err
+	}
+	for _, n := range items {
+		ref := fmt.Sprintf("%s#%d", n.Repository.FullName, n.Number)
+		title, _ := format.TruncateToWidth(format.Sanitize(n.Subject.Title), 60)
+		age := format.FormatAgo(now.Sub(*triage.ClosedAt(&n)))
+		if _, err := fmt.Fprintf(w, "  %-6s  %-30s  %-60s  %s\n", n.State, ref, title, age); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// markClosedRead marks each item's notification read, carrying on past
+// failures so one bad thread doesn't leave the rest unread.
+func markClosedRead(ctx context.Context, client *ghclient.Client, items []model.Item, out io.Writer) error {
+	marked := 0
+	var errs []error
+	for _, n := range items {
+		err := client.MarkAsRead(ctx, n.ID)
+		switch {
+		case errors.Is(err, ghclient.ErrDryRun):
+			fmt.Fprintln(out, err)
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s#%d: %w", n.Repository.FullName, n.Number, err))
+		default:
+			marked++
+		}
+	}
+	if marked > 0 {
+		fmt.Fprintf(out, "Marked %d notifications read.\n", marked)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestWriteClosed(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	merged := now.Add(-2 * 24 * time.Hour)
+	items := []model.Item{{
+		Number:     12,
+		State:      model.StateMerged,
+		ClosedAt:   &merged,
+		Repository: model.Repository{FullName: "acme/api"},
+		Subject:    model.Subject{Title: "Add pagination"},
+	}}
+
+	var b strings.Builder
+	if err := writeClosed(&b, items, "1w", now); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{"1 item you were involved in closed in the past 1w", "merged", "acme/api#12", "Add pagination", "2d ago"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeClosed() output missing %q:\n%s", want, got)
+		}
+	}
+
+	b.Reset()
+	if err := writeClosed(&b, nil, "1w", now); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != "Nothing you were involved in closed in the past 1w.\n" {
+		t.Errorf("writeClosed() with no items = %q", got)
+	}
+}
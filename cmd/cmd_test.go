@@ -17,6 +17,15 @@ func TestCommands(t *testing.T) {
 		{"NewCmdConfig", func() *cobra.Command { return NewCmdConfig() }, "config"},
 		{"NewCmdCache", func() *cobra.Command { return NewCmdCache() }, "cache"},
 		{"NewCmdVersion", func() *cobra.Command { return NewCmdVersion() }, "version"},
+		{"NewCmdSelfUpdate", func() *cobra.Command { return NewCmdSelfUpdate(&Options{}) }, "self-update"},
+		{"NewCmdEdit", func() *cobra.Command { return NewCmdEdit(&Options{}) }, "edit <owner/repo#number | url>"},
+		{"NewCmdAudit", func() *cobra.Command { return NewCmdAudit() }, "audit"},
+		{"NewCmdDemo", func() *cobra.Command { return NewCmdDemo(&Options{}) }, "demo"},
+		{"NewCmdMigrate", func() *cobra.Command { return NewCmdMigrate(&Options{}) }, "migrate"},
+		{"NewCmdEmail", func() *cobra.Command { return NewCmdEmail(&Options{}) }, "email"},
+		{"NewCmdNotify", func() *cobra.Command { return NewCmdNotify(&Options{}) }, "notify"},
+		{"NewCmdSnapshot", func() *cobra.Command { return NewCmdSnapshot(&Options{}) }, "snapshot"},
+		{"NewCmdMenubar", func() *cobra.Command { return NewCmdMenubar(&Options{}) }, "menubar"},
 	}
 
 	for _, tt := range tests {
@@ -57,6 +66,7 @@ func TestNewOptionsWithOptions(t *testing.T) {
 		WithCPUProfile("cpu.prof"),
 		WithMemProfile("mem.prof"),
 		WithTrace("trace.out"),
+		WithDryRun(true),
 	)
 
 	if opts.Format != "json" {
@@ -77,6 +87,9 @@ func TestNewOptionsWithOptions(t *testing.T) {
 	if opts.MemProfile != "mem.prof" {
 		t.Errorf("expected MemProfile 'mem.prof', got %q", opts.MemProfile)
 	}
+	if !opts.DryRun {
+		t.Error("expected DryRun true")
+	}
 	if opts.Trace != "trace.out" {
 		t.Errorf("expected Trace 'trace.out', got %q", opts.Trace)
 	}
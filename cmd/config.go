@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spiffcs/triage/config"
@@ -17,6 +21,9 @@ const (
 	formatJSON = "json"
 )
 
+// maxPresetSize caps how much of a preset file or URL is read.
+const maxPresetSize = 1 << 20
+
 // NewCmdConfig creates the config command with subcommands.
 func NewCmdConfig() *cobra.Command {
 	var outputFormat string
@@ -29,11 +36,13 @@ func NewCmdConfig() *cobra.Command {
 When run without arguments, shows the current merged configuration.
 
 Subcommands:
-  init      Create a minimal config file
-  path      Show config file locations
-  defaults  Show all default values
-  show      Show current merged config (same as bare 'triage config')
-  set       Set a configuration value`,
+  init           Create a minimal config file
+  path           Show config file locations
+  defaults       Show all default values
+  show           Show current merged config (same as bare 'triage config')
+  set            Set a configuration value
+  export-preset  Print the team settings to share as a preset
+  import-preset  Install a team preset from a file or URL`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runConfigShow(cmd, args, outputFormat)
 		},
@@ -46,6 +55,8 @@ Subcommands:
 	cmd.AddCommand(newCmdConfigDefaults())
 	cmd.AddCommand(newCmdConfigShow())
 	cmd.AddCommand(newCmdConfigSet())
+	cmd.AddCommand(newCmdConfigExportPreset())
+	cmd.AddCommand(newCmdConfigImportPreset())
 
 	return cmd
 }
@@ -78,7 +89,7 @@ func newCmdConfigPath() *cobra.Command {
 	return &cobra.Command{
 		Use:   "path",
 		Short: "Show config file locations",
-		Long:  `Show the paths to global and local config files and indicate which exist.`,
+		Long:  `Show the paths to the team preset and the global and local config files and indicate which exist.`,
 		RunE:  runConfigPath,
 	}
 }
@@ -111,7 +122,7 @@ func newCmdConfigShow() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "show",
 		Short: "Show current merged configuration",
-		Long:  `Show the current configuration after merging defaults, global, and local configs.`,
+		Long:  `Show the current configuration after merging defaults, the team preset, and global and local configs.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runConfigShow(cmd, args, outputFormat)
 		},
@@ -128,12 +139,42 @@ func newCmdConfigSet() *cobra.Command {
 		Use:   "set <key> <value>",
 		Short: "Set a configuration value",
 		Long: `Set a configuration value. Available keys:
-  format      - Default output format (table, json)`,
+  format      - Default output format (table, json, plain, markdown)`,
 		Args: cobra.ExactArgs(2),
 		RunE: runConfigSet,
 	}
 }
 
+// newCmdConfigExportPreset creates the config export-preset subcommand.
+func newCmdConfigExportPreset() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-preset",
+		Short: "Print the team settings to share as a preset",
+		Long: `Print the settings a team shares as a preset: scoring weights, labels,
+bot and dependency authors, orphaned repos, and workspaces. Personal
+settings such as excludes and UI preferences are left out.
+
+Publish the output where teammates can reach it:
+  triage config export-preset > triage-preset.yaml`,
+		Args: cobra.NoArgs,
+		RunE: runConfigExportPreset,
+	}
+}
+
+// newCmdConfigImportPreset creates the config import-preset subcommand.
+func newCmdConfigImportPreset() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import-preset <file|url>",
+		Short: "Install a team preset from a file or URL",
+		Long: `Install a team preset exported with 'triage config export-preset'.
+
+The preset is loaded beneath your global and local config files, so any
+setting you make there still wins. Importing again replaces the preset.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runConfigImportPreset,
+	}
+}
+
 func runConfigInit(global, local bool) error {
 	if global && local {
 		return fmt.Errorf("cannot specify both --global and --local")
@@ -206,6 +247,12 @@ func runConfigPath(_ *cobra.Command, _ []string) error {
 	fmt.Println("Configuration file locations:")
 	fmt.Println()
 
+	presetStatus := "not installed"
+	if paths.PresetExists {
+		presetStatus = "exists"
+	}
+	fmt.Printf("  Preset: %s (%s)\n", paths.PresetPath, presetStatus)
+
 	globalStatus := "not found"
 	if paths.GlobalExists {
 		globalStatus = "exists"
@@ -219,7 +266,7 @@ func runConfigPath(_ *cobra.Command, _ []string) error {
 	fmt.Printf("  Local:  %s (%s)\n", paths.LocalPath, localStatus)
 
 	fmt.Println()
-	fmt.Println("Load order: defaults -> global -> local (local overrides global)")
+	fmt.Println("Load order: defaults -> preset -> global -> local (later files override earlier ones)")
 
 	return nil
 }
@@ -258,22 +305,27 @@ func runConfigShow(_ *cobra.Command, _ []string, format string) error {
 	// Show which config files were loaded (to stderr so stdout is parseable)
 	paths := config.GetConfigPaths()
 	fmt.Fprintln(os.Stderr, "Loaded from:")
+	if paths.PresetExists {
+		fmt.Fprintf(os.Stderr, "  %s\n", paths.PresetPath)
+	}
 	if paths.GlobalExists {
 		fmt.Fprintf(os.Stderr, "  %s\n", paths.GlobalPath)
 	}
 	if paths.LocalExists {
 		fmt.Fprintf(os.Stderr, "  %s\n", paths.LocalPath)
 	}
-	if !paths.GlobalExists && !paths.LocalExists {
+	if !paths.PresetExists && !paths.GlobalExists && !paths.LocalExists {
 		fmt.Fprintln(os.Stderr, "  (defaults only, no config files found)")
 	}
 	fmt.Fprintln(os.Stderr)
 
-	return printConfig(cfg, format)
+	return printConfig(cfg.Redacted(), format)
 }
 
 func runConfigSet(_ *cobra.Command, args []string) error {
-	cfg, err := config.Load()
+	// Only the global file is rewritten, so the preset and local config
+	// aren't copied into it.
+	cfg, err := config.LoadGlobal()
 	if err != nil {
 		return err
 	}
@@ -285,8 +337,8 @@ func runConfigSet(_ *cobra.Command, args []string) error {
 	case "token":
 		return fmt.Errorf("tokens cannot be stored in config files for security reasons. Set the GITHUB_TOKEN environment variable instead")
 	case "format":
-		if value != "table" && value != "json" {
-			return fmt.Errorf("invalid format: %s (must be table or json)", value)
+		if value != "table" && value != "json" && value != "plain" && value != "markdown" {
+			return fmt.Errorf("invalid format: %s (must be table, json, plain, or markdown)", value)
 		}
 		if err := cfg.SetDefaultFormat(value); err != nil {
 			return err
@@ -298,3 +350,75 @@ func runConfigSet(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runConfigExportPreset(_ *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	out, err := cfg.PresetYAML()
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
+
+func runConfigImportPreset(cmd *cobra.Command, args []string) error {
+	source := args[0]
+	data, err := readPreset(cmd.Context(), source)
+	if err != nil {
+		return err
+	}
+	if _, err := config.ImportPreset(data); err != nil {
+		return err
+	}
+
+	paths := config.GetConfigPaths()
+	fmt.Printf("Installed team preset from %s: %s\n", source, paths.PresetPath)
+	fmt.Println("Settings in your global and local config files still take precedence.")
+	return nil
+}
+
+// readPreset reads a preset from an http(s) URL or a local file.
+func readPreset(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open preset: %w", err)
+		}
+		defer f.Close()
+		return readLimited(f, source)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid preset URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch preset: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch preset: GET %s: %s", source, resp.Status)
+	}
+	return readLimited(resp.Body, source)
+}
+
+// readLimited reads r, refusing anything larger than maxPresetSize.
+func readLimited(r io.Reader, source string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxPresetSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preset: %w", err)
+	}
+	if len(data) > maxPresetSize {
+		return nil, fmt.Errorf("preset %s is larger than %d bytes", source, maxPresetSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/fake"
+	"github.com/spiffcs/triage/internal/i18n"
+	"github.com/spiffcs/triage/internal/output"
+	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/tui"
+	triageapi "github.com/spiffcs/triage/pkg/triage"
+)
+
+// NewCmdDemo creates the demo command.
+func NewCmdDemo(opts *Options) *cobra.Command {
+	var seed uint64
+	var count int
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "demo",
+		Short: "Explore triage with generated sample data (no token needed)",
+		Long: `Populate triage with realistic, generated items instead of fetching from
+GitHub. The same --seed always produces the same items, which makes demo
+mode useful for screenshots, documentation, onboarding, and UI development.
+
+Nothing is sent to GitHub and items marked done are forgotten on exit.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDemo(opts, seed, count, format)
+		},
+	}
+
+	cmd.Flags().Uint64Var(&seed, "seed", 1, "Seed for generated items")
+	cmd.Flags().IntVarP(&count, "count", "n", fake.DefaultCount, "Number of items to generate")
+	cmd.Flags().StringVarP(&format, "output", "o", "", "Output format (table, json, jsonl, csv, plain, markdown); default is the interactive TUI")
+
+	return cmd
+}
+
+func runDemo(opts *Options, seed uint64, count int, format string) error {
+	if count <= 0 {
+		return fmt.Errorf("--count must be positive")
+	}
+
+	cfg := config.DefaultConfig()
+	// The demo ignores your config, so the locale comes from the environment
+	i18n.Initialize("")
+	user := fake.DefaultUser
+	items := triageapi.Prioritize(fake.Items(fake.Options{Seed: seed, Count: count, CurrentUser: user}), user, cfg)
+	items, _ = triageapi.Filter(items, cfg)
+
+	truncation, err := output.NewTruncation(cfg.GetTruncation())
+	if err != nil {
+		return err
+	}
+
+	if format == "" && shouldUseTUI(opts) {
+		// Keep "done" marks out of the real resolved store
+		dir, err := os.MkdirTemp("", "triage-demo-")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.RemoveAll(dir) }()
+
+		store, err := resolved.NewStoreFromPath(filepath.Join(dir, "resolved.json"))
+		if err != nil {
+			return err
+		}
+		return tui.RunListUI(items, store, cfg.GetScoreWeights(), user,
+			tui.WithBlockedLabels(cfg.GetBlockedLabels()),
+			tui.WithDependencyAuthors(cfg.GetDependencyAuthors()),
+			tui.WithStatusMarkers(cfg.GetAccessibility().StatusMarkers),
+			tui.WithMinimalRedraw(cfg.GetMinimalRedraw()),
+			tui.WithTruncation(truncation),
+		)
+	}
+
+	formatter := output.NewFormatterWithWeights(output.Format(format), cfg.GetScoreWeights(), user,
+		output.WithStatusMarkers(cfg.GetAccessibility().StatusMarkers),
+		output.WithTruncation(truncation))
+	return formatter.Format(items, os.Stdout)
+}
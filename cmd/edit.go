@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/confirm"
+	"github.com/spiffcs/triage/internal/editor"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/setup"
+)
+
+// NewCmdEdit creates the edit command.
+func NewCmdEdit(opts *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit <owner/repo#number | url>",
+		Short: "Reply to an issue or PR from your editor",
+		Long: `Writes the item's description, metadata, and recent comments to a
+temporary Markdown file and opens it in $VISUAL or $EDITOR.
+
+Any text you add below the reply marker is posted as a comment when the
+editor exits. Leave it empty to cancel without posting.`,
+		Example: `  triage edit spiffcs/triage#42
+  triage edit https://github.com/spiffcs/triage/pull/42`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEdit(cmd, opts, args)
+		},
+	}
+}
+
+func runEdit(cmd *cobra.Command, opts *Options, args []string) error {
+	ctx := cmd.Context()
+
+	owner, repo, number, err := ghclient.ParseItemRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	policies, err := confirm.NewPolicies(cfg.GetConfirmations())
+	if err != nil {
+		return err
+	}
+
+	token := cfg.GetGitHubToken()
+	if token == "" {
+		return setup.TokenMissing()
+	}
+
+	httpPolicy, err := ghclient.NewHTTPPolicy(cfg.GetHTTP())
+	if err != nil {
+		return err
+	}
+	client, err := ghclient.NewClient(ctx, token,
+		ghclient.WithHTTPPolicy(httpPolicy),
+		ghclient.WithDryRun(opts.DryRun),
+		ghclient.WithAuditLog(openAuditLog()),
+	)
+	if err != nil {
+		return err
+	}
+
+	session := editor.NewSession(client)
+	path, err := session.Prepare(ctx, owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	editCmd := editor.Command(path)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return fmt.Errorf("editor exited with error (draft kept at %s): %w", path, err)
+	}
+
+	reply, err := editor.ReadReply(path)
+	if err != nil {
+		return err
+	}
+	if reply != "" && policies.Required(confirm.ActionComment, 1) {
+		question := fmt.Sprintf("Post %d-character comment on %s/%s#%d?", len(reply), owner, repo, number)
+		if !confirm.Ask(os.Stdin, os.Stderr, question) {
+			fmt.Printf("Not posted; draft kept at %s\n", path)
+			return nil
+		}
+	}
+
+	posted, err := session.Submit(ctx, owner, repo, number, path)
+	if errors.Is(err, ghclient.ErrDryRun) {
+		fmt.Println(err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !posted {
+		fmt.Println("No reply written; nothing posted.")
+		return nil
+	}
+	fmt.Printf("Posted comment on %s/%s#%d\n", owner, repo, number)
+	return nil
+}
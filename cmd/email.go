@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/digest"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/setup"
+	"github.com/spiffcs/triage/internal/triage"
+	triageapi "github.com/spiffcs/triage/pkg/triage"
+)
+
+// NewCmdEmail creates the email command.
+func NewCmdEmail(opts *Options) *cobra.Command {
+	var to []string
+	var since string
+	var skipEmpty bool
+
+	cmd := &cobra.Command{
+		Use:   "email",
+		Short: "Email a digest of your prioritized items",
+		Long: `Fetch and prioritize your items, then email them as a digest with a
+plain text (Markdown) part and an HTML part. Meant to run from cron each
+morning:
+
+  0 8 * * 1-5  triage email --to me@example.com --since 1d
+
+Mail is sent through the SMTP server in the email section of your config,
+or through a sendmail binary if email.sendmail is set. With --dry-run the
+message is printed instead of sent. Items you marked done or snoozed are
+left out.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runEmail(cmd.Context(), opts, to, since, skipEmpty, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&to, "to", nil, "Recipient address, repeatable (default: email.to from config)")
+	cmd.Flags().StringVarP(&since, "since", "s", "1w", "Include notifications since (e.g., 1d, 1w, 30d)")
+	cmd.Flags().BoolVar(&skipEmpty, "skip-empty", false, "Send nothing when no items need attention")
+
+	return cmd
+}
+
+func runEmail(ctx context.Context, opts *Options, to []string, since string, skipEmpty bool, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	cfg, resolvedStore, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	settings := cfg.GetEmail()
+	if len(to) == 0 {
+		to = settings.To
+	}
+
+	traceCtx, endTrace := startTrace(ctx, "email")
+	items, err := digestItems(traceCtx, opts, cfg, resolvedStore, since)
+	endTrace()
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 && skipEmpty {
+		log.Info("nothing needs attention, not sending")
+		return nil
+	}
+
+	msg := digest.Message{
+		From:    settings.From,
+		To:      to,
+		Subject: digest.Subject(items),
+		Date:    time.Now(),
+		Text:    digest.Text(items),
+		HTML:    digest.HTML(items),
+	}
+	if opts.DryRun {
+		if err := msg.Validate(); err != nil {
+			return err
+		}
+		data, err := msg.Bytes()
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+	if err := digest.Send(ctx, msg, settings); err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+	log.Info("sent digest", "to", to, "items", len(items))
+	return nil
+}
+
+// digestItems fetches and prioritizes the items for a digest, leaving out
+// those marked done or snoozed. Sources that fail are logged and skipped,
+// since a partial digest is more useful than none; a rejected token is an
+// error.
+func digestItems(ctx context.Context, opts *Options, cfg *config.Config, resolvedStore *resolved.Store, since string) ([]triage.PrioritizedItem, error) {
+	client, err := newDigestClient(ctx, opts, cfg, since)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return runDigest(ctx, client, resolvedStore)
+}
+
+// newDigestClient builds the client that fetches digest items from the
+// past since. Callers checking repeatedly keep one and close it when done.
+func newDigestClient(ctx context.Context, opts *Options, cfg *config.Config, since string) (*triageapi.Client, error) {
+	window, err := duration.ParseDuration(since)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration: %w", err)
+	}
+	token := cfg.GetGitHubToken()
+	if token == "" {
+		return nil, setup.TokenMissing()
+	}
+	return triageapi.New(ctx, token, triageapi.WithConfig(cfg), triageapi.WithSince(window),
+		triageapi.WithRefreshIdentity(opts.RefreshIdentity))
+}
+
+// runDigest is digestItems with a client already built.
+func runDigest(ctx context.Context, client *triageapi.Client, resolvedStore *resolved.Store) ([]triage.PrioritizedItem, error) {
+	items, err := client.Run(ctx)
+	if errors.Is(err, triageapi.ErrUnauthorized) {
+		return nil, err
+	}
+	if err != nil {
+		log.Warn("some items could not be fetched", "error", err)
+	}
+	if resolvedStore != nil {
+		if err := resolvedStore.Rekey(triage.LegacyIDs(items)); err != nil {
+			log.Warn("could not update resolved items", "error", err)
+		}
+		items = triage.FilterResolved(items, resolvedStore)
+	}
+	if snoozes := openSnoozeStore(); snoozes != nil {
+		items = triage.FilterResolved(items, snoozes)
+	}
+	return items, nil
+}
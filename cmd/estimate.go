@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/service"
+)
+
+// apiQuota is the remaining quota for one GitHub rate limit.
+type apiQuota struct {
+	label     string
+	api       service.API
+	remaining int
+	limit     int
+	resetAt   time.Time
+}
+
+// runEstimate prints what a full run would cost and how long it should
+// take, without fetching anything.
+func runEstimate(ctx context.Context, opts *Options) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	svc, ghClient, err := initializeService(ctx, cfg, opts, &listRuntime{})
+	if err != nil {
+		return err
+	}
+	est := svc.Estimate(buildFetchOptions(cfg))
+	if opts.Quick {
+		est.EnrichItems, est.Enrich = 0, ghclient.Cost{}
+	}
+
+	// The rate limit endpoint doesn't count against any quota
+	var quotas []apiQuota
+	limits, err := ghClient.RateLimits(ctx)
+	if err != nil {
+		log.Warn("could not check rate limits", "error", err)
+	} else {
+		if limits.Core != nil {
+			quotas = append(quotas, apiQuota{"Core API", service.APICore, limits.Core.Remaining, limits.Core.Limit, limits.Core.Reset.Time})
+		}
+		if limits.Search != nil {
+			quotas = append(quotas, apiQuota{"Search API", service.APISearch, limits.Search.Remaining, limits.Search.Limit, limits.Search.Reset.Time})
+		}
+		if limits.GraphQL != nil {
+			quotas = append(quotas, apiQuota{"GraphQL", service.APIGraphQL, limits.GraphQL.Remaining, limits.GraphQL.Limit, limits.GraphQL.Reset.Time})
+		}
+	}
+
+	printEstimate(os.Stdout, est, quotas, opts.Since, opts.Quick)
+	return nil
+}
+
+// printEstimate writes the estimate, the quota it needs, and how long the
+// run should take.
+func printEstimate(w io.Writer, est service.Estimate, quotas []apiQuota, since string, quick bool) {
+	if est.Baseline.IsZero() {
+		fmt.Fprintf(w, "Estimated cost of a full run for the past %s (no previous run cached; assuming one page per source):\n\n", since)
+	} else {
+		fmt.Fprintf(w, "Estimated cost of a full run for the past %s (based on the run %s ago):\n\n", since, formatCacheAge(time.Since(est.Baseline)))
+	}
+
+	for _, src := range est.Sources {
+		cost := "cached"
+		if src.Cost.Requests > 0 {
+			cost = describeRequests(src.API, src.Cost.Requests, src.Cost.Points)
+			if src.Cached {
+				cost += " (new items only)"
+			}
+		}
+		fmt.Fprintf(w, "  %-16s %5d items  %s\n", src.Name, src.Items, cost)
+	}
+	switch {
+	case quick:
+		fmt.Fprintf(w, "  %-16s %5s        %s\n", "enrichment", "", "skipped (--quick)")
+	case est.Enrich.Requests > 0:
+		fmt.Fprintf(w, "  %-16s %5d items  %s\n", "enrichment", est.EnrichItems,
+			describeRequests(service.APIGraphQL, est.Enrich.Requests, est.Enrich.Points))
+	default:
+		fmt.Fprintf(w, "  %-16s %5d items  %s\n", "enrichment", est.EnrichItems, "cached")
+	}
+
+	var wait time.Duration
+	var short []string
+	graphqlShort := false
+	if len(quotas) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Quota:")
+		for _, q := range quotas {
+			requests, points := est.Requests(q.api)
+			need, unit := requests, "requests"
+			if q.api == service.APIGraphQL {
+				need, unit = points, "points"
+			}
+			fmt.Fprintf(w, "  %-11s needs %d %s, %d/%d remaining\n", q.label+":", need, unit, q.remaining, q.limit)
+			if need > q.remaining {
+				short = append(short, q.label)
+				wait = max(wait, time.Until(q.resetAt))
+				graphqlShort = graphqlShort || q.api == service.APIGraphQL
+			}
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Estimated time: about %s\n", formatCacheAge(max(est.Duration(), time.Second)))
+	if len(short) > 0 {
+		fmt.Fprintf(w, "Not enough %s quota for a full run; some items would be missing or unenriched. It resets in %s.\n",
+			joinAnd(short), formatCacheAge(max(wait, 0)))
+		if graphqlShort && !quick {
+			fmt.Fprintln(w, "Run with --quick to skip enrichment, or wait for the reset.")
+		}
+	}
+}
+
+// describeRequests describes a number of requests against api, with the
+// GraphQL points they use.
+func describeRequests(api service.API, requests, points int) string {
+	one, many := "request", "requests"
+	switch api {
+	case service.APISearch:
+		one, many = "search request", "search requests"
+	case service.APIGraphQL:
+		one, many = "GraphQL query", "GraphQL queries"
+	}
+	s := fmt.Sprintf("%d %s", requests, many)
+	if requests == 1 {
+		s = fmt.Sprintf("%d %s", requests, one)
+	}
+	if api == service.APIGraphQL {
+		s += fmt.Sprintf(", ~%d points", points)
+	}
+	return s
+}
+
+// joinAnd joins words as "a", "a and b", or "a, b and c".
+func joinAnd(words []string) string {
+	switch len(words) {
+	case 0:
+		return ""
+	case 1:
+		return words[0]
+	default:
+		s := words[0]
+		for _, w := range words[1 : len(words)-1] {
+			s += ", " + w
+		}
+		return s + " and " + words[len(words)-1]
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/service"
+)
+
+func TestPrintEstimate(t *testing.T) {
+	est := service.Estimate{
+		Baseline: time.Now().Add(-10 * time.Minute),
+		Sources: []service.SourceEstimate{
+			{Name: "notifications", API: service.APICore, Items: 150, Cached: true, Cost: ghclient.Cost{Requests: 1, Rounds: 1}},
+			{Name: "review PRs", API: service.APISearch, Items: 4, Cached: true},
+		},
+		EnrichItems:   150,
+		Enrich:        ghclient.EnrichmentCost(50, 100),
+		FetchDuration: 1500 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name      string
+		quotas    []apiQuota
+		wantShort bool
+	}{
+		{"enough quota", []apiQuota{{"GraphQL", service.APIGraphQL, 4000, 5000, time.Now().Add(time.Hour)}}, false},
+		{"GraphQL short", []apiQuota{{"GraphQL", service.APIGraphQL, 2, 5000, time.Now().Add(20 * time.Minute)}}, true},
+		{"quota unknown", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printEstimate(&buf, est, tt.quotas, "1w", false)
+			out := buf.String()
+
+			for _, want := range []string{"based on the run 10m ago", "1 request (new items only)", "6 GraphQL queries, ~10 points", "Estimated time: about 3s"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			if got := strings.Contains(out, "Not enough GraphQL quota"); got != tt.wantShort {
+				t.Errorf("quota warning shown = %v, want %v:\n%s", got, tt.wantShort, out)
+			}
+		})
+	}
+}
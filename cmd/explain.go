@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/setup"
+	"github.com/spiffcs/triage/internal/snooze"
+	"github.com/spiffcs/triage/internal/triage"
+	triageapi "github.com/spiffcs/triage/pkg/triage"
+)
+
+// NewCmdExplain creates the explain command.
+func NewCmdExplain(opts *Options) *cobra.Command {
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "explain <owner/repo#number | url>",
+		Short: "Show how an item's score and priority were worked out",
+		Long: `Fetch your items like triage list does, then print the scoring
+breakdown of one of them: the base score of the reason you were notified,
+every bonus and penalty applied, the resulting score and priority, and for
+each weight whether it is the built-in default, set in your config, or set
+for the repository under repo_overrides.
+
+Only items triage list would score can be explained: notifications from the
+past --since, review requests, and issues and PRs assigned to or authored
+by you.`,
+		Example: `  triage explain spiffcs/triage#42
+  triage explain https://github.com/spiffcs/triage/pull/42 --since 30d`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExplain(cmd.Context(), opts, args[0], since, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "1w", "Include notifications since (e.g., 1d, 1w, 30d)")
+
+	return cmd
+}
+
+func runExplain(ctx context.Context, opts *Options, ref, since string, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	owner, repo, number, err := ghclient.ParseItemRef(ref)
+	if err != nil {
+		return err
+	}
+	key := activity.RefKey(owner+"/"+repo, number)
+
+	window, err := duration.ParseDuration(since)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	token := cfg.GetGitHubToken()
+	if token == "" {
+		return setup.TokenMissing()
+	}
+
+	client, err := triageapi.New(ctx, token, triageapi.WithConfig(cfg), triageapi.WithSince(window),
+		triageapi.WithRefreshIdentity(opts.RefreshIdentity))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	traceCtx, endTrace := startTrace(ctx, "explain")
+	result, err := client.Fetch(traceCtx)
+	if result == nil {
+		endTrace()
+		return err
+	}
+	if result.Unauthorized {
+		endTrace()
+		return errors.Join(triageapi.ErrUnauthorized, err)
+	}
+	if err != nil {
+		log.Warn("some items could not be fetched", "error", err)
+	}
+	if _, err := client.Enrich(traceCtx, result); err != nil {
+		log.Warn("some items could not be enriched", "error", err)
+	}
+	endTrace()
+
+	currentUser := client.CurrentUser()
+	all, _ := result.Merge()
+	activity.Apply(all, currentUser, openActivityStore())
+	snooze.Apply(all, openSnoozeStore(), time.Now())
+
+	for _, item := range all {
+		if item.Key() == key {
+			return writeExplanation(out, triageapi.Explain(item, currentUser, cfg, result.Teams...), cfg)
+		}
+	}
+	return fmt.Errorf("%s is not among your items from the past %s; try a longer --since", key, since)
+}
+
+// writeExplanation prints an item's scoring breakdown, naming where each
+// weight was set in cfg.
+func writeExplanation(w io.Writer, e triage.Explanation, cfg *config.Config) error {
+	repo := e.Item.Repository.FullName
+	var b strings.Builder
+
+	title := e.Item.Key()
+	if e.Item.Subject.Title != "" {
+		title += ": " + format.Sanitize(e.Item.Subject.Title)
+	}
+	fmt.Fprintln(&b, title)
+	fmt.Fprintf(&b, "Reason: %s  Action: %s\n\n", e.Item.Reason, e.ActionNeeded)
+
+	fmt.Fprintf(&b, "  %6s  %-40s  %-40s  %s\n", "Points", "Factor", "Setting", "Source")
+	for _, f := range e.Factors {
+		fmt.Fprintf(&b, "  %+6d  %-40s  %-40s  %s\n",
+			f.Points, format.Sanitize(f.Name), strings.Join(f.Setting, "."), cfg.SettingSource(repo, f.Setting...))
+	}
+	fmt.Fprintf(&b, "  %6s\n", "------")
+	if sum := explanationSum(e.Factors); sum != e.RawScore {
+		fmt.Fprintf(&b, "  %6d  score (%d, raised to 0)\n", e.RawScore, sum)
+	} else {
+		fmt.Fprintf(&b, "  %6d  score\n", e.RawScore)
+	}
+	if e.Score != e.RawScore {
+		fmt.Fprintf(&b, "  %6d  on the 0-100 scale (scoring.normalize_scores)\n", e.Score)
+	}
+
+	fmt.Fprintf(&b, "\nPriority: %s", e.Priority.Display())
+	if e.Priority != e.ScorePriority {
+		fmt.Fprintf(&b, " (raised from %s by a severity label)", e.ScorePriority.Display())
+	}
+	fmt.Fprintln(&b)
+	if e.RepoWeights {
+		fmt.Fprintf(&b, "Weights for %s come from repo_overrides.\n", repo)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// explanationSum adds up the points of factors, before the score is kept
+// from going below zero.
+func explanationSum(factors []triage.ScoreFactor) int {
+	sum := 0
+	for _, f := range factors {
+		sum += f.Points
+	}
+	return sum
+}
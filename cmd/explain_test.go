@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestWriteExplanation(t *testing.T) {
+	bonus := 30
+	cfg := &config.Config{Scoring: &config.ScoringOverrides{HotTopicBonus: &bonus}}
+	e := triage.Explanation{
+		PrioritizedItem: triage.PrioritizedItem{
+			Item: model.Item{
+				Reason:     model.ReasonMention,
+				Number:     12,
+				Repository: model.Repository{FullName: "acme/api"},
+				Subject:    model.Subject{Title: "Fix flaky test"},
+			},
+			Score:        80,
+			Priority:     triage.PriorityUrgent,
+			ActionNeeded: "Respond to mention",
+		},
+		Factors: []triage.ScoreFactor{
+			{Name: "mention notification", Setting: []string{"base_scores", "mention"}, Points: 90},
+			{Name: "hot topic (12 comments)", Setting: []string{"scoring", "hot_topic_bonus"}, Points: 30},
+			{Name: "closed", Setting: []string{"scoring", "closed_state_penalty"}, Points: -30},
+		},
+		RawScore:      90,
+		ScorePriority: triage.PriorityImportant,
+	}
+
+	var out bytes.Buffer
+	if err := writeExplanation(&out, e, cfg); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+
+	for _, want := range []string{
+		"acme/api#12: Fix flaky test\n",
+		"Reason: mention  Action: Respond to mention\n",
+		"     +90  mention notification",
+		"base_scores.mention                       default\n",
+		"scoring.hot_topic_bonus                   config\n",
+		"     -30  closed",
+		"      90  score\n",
+		"      80  on the 0-100 scale",
+		"(raised from " + triage.PriorityImportant.Display() + " by a severity label)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeExplanation() output missing %q:\n%s", want, got)
+		}
+	}
+}
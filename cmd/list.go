@@ -4,24 +4,40 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/activity"
 	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/confirm"
 	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/editor"
 	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/i18n"
 	"github.com/spiffcs/triage/internal/log"
 	"github.com/spiffcs/triage/internal/model"
 	"github.com/spiffcs/triage/internal/output"
+	"github.com/spiffcs/triage/internal/placement"
 	"github.com/spiffcs/triage/internal/resolved"
 	"github.com/spiffcs/triage/internal/service"
 	"github.com/spiffcs/triage/internal/setup"
+	"github.com/spiffcs/triage/internal/slo"
+	"github.com/spiffcs/triage/internal/snapshot"
+	"github.com/spiffcs/triage/internal/snooze"
+	"github.com/spiffcs/triage/internal/telemetry"
 	"github.com/spiffcs/triage/internal/triage"
 	"github.com/spiffcs/triage/internal/tui"
+	triageapi "github.com/spiffcs/triage/pkg/triage"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/term"
 )
 
 // TUI update and progress constants
@@ -34,8 +50,16 @@ const (
 	logThrottlePercent = 5
 )
 
+// defaultSince is the --since window when none is given.
+const defaultSince = "1w"
+
 // sendFetchCompleteEvent formats and sends the fetch completion TUI event.
 func sendFetchCompleteEvent(result *service.FetchResult, err error, sinceLabel string, stats service.FetchStats, events chan tui.Event) {
+	if result.Unauthorized {
+		// The underlying 401 is noise; the re-auth instructions follow the run
+		sendTaskEvent(events, tui.TaskFetch, tui.StatusError, tui.WithError(ghclient.ErrUnauthorized))
+		return
+	}
 	if err != nil {
 		sendTaskEvent(events, tui.TaskFetch, tui.StatusError, tui.WithError(err))
 		return
@@ -45,6 +69,8 @@ func sendFetchCompleteEvent(result *service.FetchResult, err error, sinceLabel s
 	fetchMsg := fmt.Sprintf("for the past %s (%d items)", sinceLabel, totalFetched)
 	if stats.NotifFromCache && stats.NotifNewCount > 0 {
 		fetchMsg = fmt.Sprintf("for the past %s (%d items, %d new)", sinceLabel, totalFetched, stats.NotifNewCount)
+	} else if stats.NotifNotModified {
+		fetchMsg = fmt.Sprintf("for the past %s (%d items, no new notifications)", sinceLabel, totalFetched)
 	} else if stats.NotifFromCache {
 		fetchMsg = fmt.Sprintf("for the past %s (%d items, cached)", sinceLabel, totalFetched)
 	}
@@ -96,8 +122,10 @@ func logFetchStats(result *service.FetchResult, stats service.FetchStats) {
 		"assignedIssues", len(result.AssignedIssues),
 		"assignedPRs", len(result.AssignedPRs),
 		"orphaned", len(result.Orphaned),
+		"upstream", len(result.Upstream),
 		"notifFromCache", stats.NotifFromCache,
 		"notifNewCount", stats.NotifNewCount,
+		"notifNotModified", stats.NotifNotModified,
 		"reviewFromCache", stats.ReviewFromCache,
 		"authoredFromCache", stats.AuthoredFromCache,
 		"assignedFromCache", stats.AssignedFromCache,
@@ -110,17 +138,22 @@ type listRuntime struct {
 	useTUI  bool
 	events  chan tui.Event
 	tuiDone chan error
+	// tuiExited is closed once the TUI stops, e.g. after Ctrl+C.
+	tuiExited chan struct{}
 }
 
 // startTUI initializes and starts the TUI goroutine if TUI mode is enabled.
-func (rt *listRuntime) startTUI() {
+func (rt *listRuntime) startTUI(opts ...tui.ModelOption) {
 	if !rt.useTUI {
 		return
 	}
 	rt.events = make(chan tui.Event, 100)
 	rt.tuiDone = make(chan error, 1)
+	rt.tuiExited = make(chan struct{})
 	go func() {
-		rt.tuiDone <- tui.Run(rt.events)
+		err := tui.Run(rt.events, opts...)
+		close(rt.tuiExited)
+		rt.tuiDone <- err
 	}()
 }
 
@@ -129,6 +162,39 @@ func (rt *listRuntime) close() {
 	closeTUI(rt.events, rt.tuiDone)
 }
 
+// confirm asks question in the TUI, or on the terminal without it, and
+// reports whether the user said yes. Runs with no one to ask, such as cron
+// jobs, go ahead.
+func (rt *listRuntime) confirm(question string) bool {
+	if !rt.useTUI {
+		return confirmOnTerminal(question)
+	}
+
+	// Unlike progress updates, the question must not be dropped
+	reply := make(chan bool, 1)
+	select {
+	case rt.events <- tui.ConfirmEvent{Question: question, Reply: reply}:
+	case <-rt.tuiExited:
+		return false
+	}
+	select {
+	case ok := <-reply:
+		return ok
+	case <-rt.tuiExited:
+		return false
+	}
+}
+
+// confirmOnTerminal asks question on stderr and reads the answer from
+// stdin. Without a terminal to ask on it warns and says yes.
+func confirmOnTerminal(question string) bool {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		log.Warn("continuing without confirmation: stdin is not a terminal", "question", question)
+		return true
+	}
+	return confirm.Ask(os.Stdin, os.Stderr, question)
+}
+
 // sendEvent sends a task event to the TUI channel if it exists.
 func (rt *listRuntime) sendEvent(task tui.TaskID, status tui.TaskStatus, opts ...tui.TaskEventOption) {
 	sendTaskEvent(rt.events, task, status, opts...)
@@ -152,9 +218,26 @@ issue/PR details, and displays them sorted by priority.`,
 
 // addListFlags adds the list-specific flags to a command.
 func addListFlags(cmd *cobra.Command, opts *Options) {
-	cmd.Flags().StringVarP(&opts.Format, "output", "o", "", "Output format (table, json)")
-	cmd.Flags().StringVarP(&opts.Since, "since", "s", "1w", "Show notifications since (e.g., 1w, 30d, 6mo)")
+	cmd.Flags().StringVarP(&opts.Format, "output", "o", "", "Output format (table, json, jsonl, csv, template, plain, markdown)")
+	cmd.Flags().BoolVar(&opts.Envelope, "envelope", false, "With -o json, print an object with the items and an errors array listing sources and items a partial failure left out")
+	cmd.Flags().StringVar(&opts.Fields, "fields", "", "Comma-separated fields to keep in json/csv output; nested paths use dots (e.g. 'score,priority,repo,number,title,url,details.ciStatus')")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Go template rendered per item with -o template (e.g. '{{.Priority}} {{.Repository.FullName}}#{{.Number}} {{.Title}}')")
+	cmd.Flags().StringVarP(&opts.Since, "since", "s", defaultSince, "Show notifications since (e.g., 1w, 30d, 6mo)")
 	cmd.Flags().CountVarP(&opts.Verbosity, "verbose", "v", "Increase verbosity (-v info, -vv debug, -vvv trace)")
+	cmd.Flags().BoolVarP(&opts.Quick, "quick", "q", false, "Skip enrichment and score on notification metadata only (faster, uses no GraphQL quota)")
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Build the list from cached notifications and details without calling GitHub; items from expired cache entries are marked stale")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Only triage this repository (owner/name) or the repos in a workspace (@name); their notifications are fetched on their own instead of filtered from all of them")
+	cmd.Flags().BoolVar(&opts.Participating, "participating", false, "Only fetch notifications for threads you take part in (authored, commented, assigned, mentioned, or asked to review)")
+	cmd.Flags().StringSliceVar(&opts.Reasons, "reason", nil, "Only show items with these reasons; prefix with ! to hide a reason instead (e.g. review_requested,mention or '!subscribed,!ci_activity')")
+	cmd.Flags().StringSliceVar(&opts.ExcludeReasons, "exclude-reason", nil, "Hide items with these reasons (e.g. subscribed,ci_activity)")
+	cmd.Flags().StringArrayVar(&opts.FormFields, "form-field", nil, "Only show issues whose issue form answered a field so, as field=value (e.g. Severity=high); repeat to require several")
+	cmd.Flags().StringSliceVar(&opts.Labels, "label", nil, "Only show items with any of these labels; prefix with ! to hide a label instead (e.g. security or '!wontfix')")
+	cmd.Flags().StringSliceVar(&opts.ExcludeLabels, "exclude-label", nil, "Hide items with any of these labels (e.g. wontfix,duplicate)")
+	cmd.Flags().StringVar(&opts.MinPriority, "min-priority", "", "Only show items at or above this priority (urgent, important, quick-win, notable, fyi, archive)")
+	cmd.Flags().BoolVar(&opts.IncludeArchived, "include-archived", false, "Show items with no activity for longer than scoring.archive_after_days")
+	cmd.Flags().BoolVar(&opts.ShowExcluded, "show-excluded", false, "List the items exclude_repos, exclude_authors, marking done, snoozing, and archiving removed, dimmed below the list with the rule that removed each (table and plain output; skips the interactive list)")
+	cmd.Flags().BoolVar(&opts.RawAge, "raw-age", false, "Age items from their last update, including bot comments and pushes, instead of the last human activity")
+	cmd.Flags().BoolVar(&opts.Estimate, "estimate", false, "Report the API calls, GraphQL points, and time a full run will take, then exit")
 
 	// TUI flag with tri-state: nil = auto, true = force, false = disable
 	cmd.Flags().Var(newTUIFlag(opts), "tui", "Enable/disable TUI progress (default: auto-detect)")
@@ -163,32 +246,101 @@ func addListFlags(cmd *cobra.Command, opts *Options) {
 	cmd.Flags().StringVar(&opts.CPUProfile, "cpuprofile", "", "Write CPU profile to file")
 	cmd.Flags().StringVar(&opts.MemProfile, "memprofile", "", "Write memory profile to file")
 	cmd.Flags().StringVar(&opts.Trace, "trace", "", "Write execution trace to file")
+
+	// Fixture generation: record GitHub API traffic as a replayable cassette
+	cmd.Flags().StringVar(&opts.RecordCassette, "record-cassette", "", "Record GitHub API traffic to a cassette file for tests")
+	_ = cmd.Flags().MarkHidden("record-cassette")
 }
 
 func runList(cmd *cobra.Command, opts *Options) error {
 	ctx := cmd.Context()
 
+	// Fail fast on a bad template rather than after fetching everything
+	if err := validateTemplate(opts); err != nil {
+		return err
+	}
+
+	filters, err := parseListFilters(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Estimate {
+		if opts.Offline {
+			return fmt.Errorf("--estimate reports what a run would fetch, and --offline fetches nothing")
+		}
+		return runEstimate(ctx, opts)
+	}
+
+	// Trace the pipeline, not the time spent in the TUI afterwards
+	ctx, endTrace := startTrace(ctx, "list")
+	defer endTrace()
+
 	// Setup
 	rt, cleanup, err := setupRuntime(opts)
 	if err != nil {
 		return err
 	}
 	defer cleanup()
-	rt.startTUI()
+
+	// The local stores aren't needed until items are fetched, so they load
+	// while the client authenticates
+	loadActivity := inBackground(openActivityStore)
+	loadSnoozes := inBackground(openSnoozeStore)
+	loadReviewHistory := inBackground(openReviewHistory)
 
 	// Load config (separate from service)
+	endConfig := startup.track("config")
 	cfg, resolvedStore, err := loadConfig()
+	endConfig()
 	if err != nil {
-		rt.close()
 		return err
 	}
+	if err := validateFields(opts, cfg.DefaultFormat); err != nil {
+		return err
+	}
+	if f := outputFormat(opts, cfg); opts.ShowExcluded && f != output.FormatTable && f != output.FormatPlain {
+		return fmt.Errorf("--show-excluded works with table and plain output, not %s", f)
+	}
+	// The progress display starts once the config says how it should draw
+	rt.startTUI(tui.WithReducedMotion(cfg.GetMinimalRedraw()))
+	if f := outputFormat(opts, cfg); opts.Envelope && f != output.FormatJSON {
+		rt.close()
+		return fmt.Errorf("--envelope requires -o json")
+	}
+
+	clientOpts := []ghclient.ClientOption{
+		ghclient.WithDryRun(opts.DryRun),
+		ghclient.WithAuditLog(openAuditLog()),
+		ghclient.WithProjectStatus(len(cfg.ProjectColumnScores) > 0),
+	}
+	if opts.RecordCassette != "" {
+		recorder := ghclient.NewRecorder(nil)
+		clientOpts = append(clientOpts, ghclient.WithTransport(recorder))
+		defer func() {
+			if err := recorder.Save(opts.RecordCassette); err != nil {
+				log.Warn("could not save cassette", "path", opts.RecordCassette, "error", err)
+			}
+		}()
+	}
 
 	// Create service (combines auth + data pipeline)
-	svc, err := initializeService(ctx, cfg, opts.Since, rt)
+	svc, ghClient, err := initializeService(ctx, cfg, opts, rt, clientOpts...)
 	if err != nil {
 		rt.close()
 		return err
 	}
+	startup.done()
+
+	// A first run over a long window can list thousands of notifications
+	fetchOpts := buildFetchOptions(cfg)
+	if ok, err := confirmLargeFetch(ctx, svc, cfg, fetchOpts, rt); err != nil || !ok {
+		rt.close()
+		if err == nil {
+			fmt.Fprintln(os.Stderr, "Canceled. A shorter --since fetches less.")
+		}
+		return err
+	}
 
 	// Fetch
 	onProgress := func(completed, total int, source string) {
@@ -205,30 +357,213 @@ func runList(cmd *cobra.Command, opts *Options) error {
 			tui.WithProgress(progress), tui.WithMessage(msg))
 	}
 	fetcher := service.NewFetcher(svc, onProgress)
-	fetchOpts := buildFetchOptions(cfg)
 	result, err := fetcher.FetchAll(ctx, fetchOpts)
-	if err != nil {
+	if err != nil && !result.Unauthorized {
 		log.Warn("some fetches failed", "error", err)
 	}
+	if repos, _ := scopeRepos(opts, cfg); len(repos) > 0 {
+		// Searches span every repository; keep only the ones asked for
+		result.OnlyRepos(repos)
+	}
 	stats := svc.Stats()
 	sendRateLimitEvent(result, rt.events)
 	sendFetchCompleteEvent(result, err, opts.Since, stats, rt.events)
 	logFetchStats(result, stats)
 
+	activityStore := loadActivity()
+	snoozeStore := loadSnoozes()
+
+	// JSON Lines are written as each chunk is enriched and scored,
+	// rather than once the whole run is done
+	var stream *itemStream
+	if outputFormat(opts, cfg) == output.FormatJSONL && !opts.Quick {
+		stream = newItemStream(cfg, svc.CurrentUser(), result.Teams, opts, filters, resolvedStore, activityStore, snoozeStore, os.Stdout)
+	}
+
 	// Enrich
-	runEnrichment(ctx, svc, result, rt)
+	var totals *enrichTotals
+	if opts.Quick {
+		rt.sendEvent(tui.TaskEnrich, tui.StatusSkipped, tui.WithMessage("quick mode"))
+	} else {
+		totals = runEnrichment(ctx, svc, result, rt, stream)
+	}
+
+	// A rejected token stops the run early. Everything fetched so far is
+	// still shown, followed by a single re-auth message.
+	if result.Unauthorized {
+		defer fmt.Fprintf(os.Stderr, "\n%v\n", setup.TokenRejected())
+	}
+
+	// Repos the token can't read are kept as locked rows; list them once.
+	if repos := result.InaccessibleRepos(); len(repos) > 0 {
+		defer fmt.Fprintf(os.Stderr, "\n%v\n", setup.ReposInaccessible(repos))
+	}
+
+	// Searches past GitHub's result cap that couldn't be split are incomplete.
+	if searches := result.Truncated; len(searches) > 0 {
+		defer fmt.Fprintf(os.Stderr, "\n%v\n", setup.SearchTruncated(searches))
+	}
+
+	// Orgs enforcing SAML SSO were skipped; say where to authorize the token.
+	ssoOrgs := ssoRequired(ghClient)
+	if len(ssoOrgs) > 0 {
+		defer fmt.Fprintf(os.Stderr, "\n%v\n", setup.SSORequired(ssoOrgs))
+	}
 
 	// Process
-	items := processResults(result, cfg, svc.CurrentUser(), rt.events)
-	if len(items) == 0 {
+	reviewHistory := loadReviewHistory()
+	_, span := telemetry.Start(ctx, "score")
+	items, excluded := processResults(result, cfg, svc.CurrentUser(), opts, activityStore, snoozeStore, reviewHistory, rt.events)
+	span.SetAttributes(attribute.Int("triage.items", len(items)))
+	telemetry.End(span, nil)
+	if resolvedStore != nil {
+		if err := resolvedStore.Rekey(triage.LegacyIDs(items)); err != nil {
+			log.Warn("could not update resolved items", "error", err)
+		}
+	}
+
+	// Compare against the previous run before filtering, so changing
+	// filters between runs doesn't read as items appearing or vanishing.
+	// Quick runs lack review states and would skew the next comparison,
+	// offline runs show nothing new to compare, and machine-readable output
+	// has no place for the summary.
+	var delta string
+	var resurfaced map[string]bool
+	if f := outputFormat(opts, cfg); !opts.Quick && !opts.Offline && (f == output.FormatTable || f == output.FormatPlain) {
+		delta, resurfaced = runDelta(items, resolvedStore, deltaScope(opts, cfg), time.Now())
+	}
+
+	items, archived := filters.apply(items)
+	excluded = append(excluded, archived...)
+	if stream != nil {
+		// Write what no chunk did: items that needed no enriching, and
+		// unenriched ones kept because the run was cut short
+		rt.close()
+		endTrace()
+		return stream.finish(items)
+	}
+	if len(items) == 0 && len(excluded) == 0 && !opts.Envelope {
 		rt.close()
 		fmt.Println("No unread notifications, pending reviews, or open PRs found.")
 		return nil
 	}
 
+	// Teammates offered when delegating from the interactive list
+	var roster []string
+	if ghClient != nil && opts.Snapshot == nil && shouldUseTUI(opts) && outputFormat(opts, cfg) == output.FormatTable {
+		roster = delegationRoster(ctx, svc, cfg.GetDelegation())
+	}
+
 	// Output
 	rt.close()
-	return renderOutput(items, opts, cfg, svc.CurrentUser(), resolvedStore, stats)
+	endTrace()
+	var runErrs []output.RunError
+	if opts.Envelope {
+		runErrs = runErrors(result, totals, ssoOrgs)
+	}
+	return renderOutput(items, excluded, runErrs, opts, cfg, svc.CurrentUser(), result.Teams, roster, resolvedStore, activityStore, snoozeStore, reviewHistory, stats, ghClient, delta, resurfaced)
+}
+
+// runDelta summarizes what changed since the previous run of the same
+// scope and saves this run's state for the next one. The summary is empty
+// on the first run or when nothing changed. resurfaced holds the resolved
+// items that came back since the previous run.
+func runDelta(items []triage.PrioritizedItem, resolvedStore *resolved.Store, scope string, now time.Time) (summary string, resurfaced map[string]bool) {
+	store, err := snapshot.NewStore(scope)
+	if err != nil {
+		log.Warn("could not open run snapshot", "error", err)
+		return "", nil
+	}
+	prev, takenAt, ok, err := store.Load()
+	if err != nil {
+		log.Debug("could not load run snapshot, starting fresh", "error", err)
+	}
+	prev.Rekey(triage.LegacyIDs(items))
+	cur := snapshot.Capture(items, resolvedStore)
+	if err := store.Save(cur, now); err != nil {
+		log.Warn("could not save run snapshot", "error", err)
+	}
+	resurfaced = snapshot.Resurfaced(prev, cur)
+	if !ok {
+		return "", resurfaced
+	}
+	d := snapshot.Compare(prev, cur)
+	if d.Empty() {
+		return "", resurfaced
+	}
+	return fmt.Sprintf("Since your last run %s ago: %s", formatCacheAge(now.Sub(takenAt)), d), resurfaced
+}
+
+// deltaScope describes what a run fetched, for keeping its snapshot apart
+// from runs that fetched something else: items missing from a run limited
+// to one repo, a shorter --since, or --participating weren't resolved, just
+// not fetched. It is empty for runs with the default scope.
+func deltaScope(opts *Options, cfg *config.Config) string {
+	var parts []string
+	if repos, _ := scopeRepos(opts, cfg); len(repos) > 0 {
+		sorted := slices.Clone(repos)
+		slices.Sort(sorted)
+		parts = append(parts, "repos="+strings.Join(sorted, ","))
+	}
+	if opts.Since != defaultSince {
+		parts = append(parts, "since="+opts.Since)
+	}
+	if opts.Participating {
+		parts = append(parts, "participating")
+	}
+	return strings.Join(parts, ";")
+}
+
+// outputFormat is the format from -o, falling back to the configured default.
+func outputFormat(opts *Options, cfg *config.Config) output.Format {
+	if opts.Format != "" {
+		return output.Format(opts.Format)
+	}
+	if cfg.DefaultFormat != "" {
+		return output.Format(cfg.DefaultFormat)
+	}
+	return output.FormatTable
+}
+
+// parseReasonFlags combines --reason and --exclude-reason into the reasons
+// to keep and to drop.
+func parseReasonFlags(opts *Options) (include, exclude []model.ItemReason, err error) {
+	values := slices.Clone(opts.Reasons)
+	for _, r := range opts.ExcludeReasons {
+		values = append(values, "!"+strings.TrimPrefix(strings.TrimSpace(r), "!"))
+	}
+	return triage.ParseReasons(values)
+}
+
+// validateFields rejects --fields with output formats that cannot honour it.
+// defaultFormat is the configured format used when -o is not given.
+func validateFields(opts *Options, defaultFormat string) error {
+	if opts.Fields == "" {
+		return nil
+	}
+	format := opts.Format
+	if format == "" {
+		format = defaultFormat
+	}
+	switch output.Format(format) {
+	case output.FormatJSON, output.FormatJSONL, output.FormatCSV:
+		return nil
+	default:
+		return fmt.Errorf("--fields requires -o json, -o jsonl, or -o csv")
+	}
+}
+
+// validateTemplate checks the --template flag. Passing --template alone
+// implies the template output format.
+func validateTemplate(opts *Options) error {
+	if opts.Template != "" && opts.Format == "" {
+		opts.Format = string(output.FormatTemplate)
+	}
+	if output.Format(opts.Format) != output.FormatTemplate {
+		return nil
+	}
+	_, err := output.NewTemplateFormatter(opts.Template)
+	return err
 }
 
 // setupRuntime creates the runtime struct and returns a cleanup function for profiling.
@@ -251,102 +586,438 @@ func setupRuntime(opts *Options) (*listRuntime, func(), error) {
 	return rt, profiler.Stop, nil
 }
 
+// openActivityStore opens the store of locally recorded interactions. Without
+// it the You column only reflects comments and reviews.
+func openActivityStore() *activity.Store {
+	store, err := activity.NewStore()
+	if err != nil {
+		log.Warn("could not load activity store", "error", err)
+		return nil
+	}
+	return store
+}
+
+// openPlacementStore opens the store of panes items were moved to in the
+// TUI. Without it items can't be moved.
+func openPlacementStore() *placement.Store {
+	store, err := placement.NewStore()
+	if err != nil {
+		log.Warn("could not load moved items", "error", err)
+		return nil
+	}
+	return store
+}
+
+// delegationRoster returns the teammates offered when delegating: the
+// configured roster and the members of the configured teams, without the
+// current user, sorted and without duplicates.
+func delegationRoster(ctx context.Context, svc *service.ItemService, settings config.DelegationSettings) []string {
+	seen := map[string]bool{strings.ToLower(svc.CurrentUser()): true}
+	var roster []string
+	for _, login := range append(slices.Clone(settings.Roster), svc.TeamRoster(ctx, settings.Teams)...) {
+		login = strings.TrimPrefix(strings.TrimSpace(login), "@")
+		if key := strings.ToLower(login); login != "" && !seen[key] {
+			seen[key] = true
+			roster = append(roster, login)
+		}
+	}
+	slices.SortFunc(roster, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return roster
+}
+
+// openSnoozeStore opens the store of items snoozed in the TUI and reminders
+// set with triage remind. Without it nothing is snoozed and no reminders
+// come due.
+func openSnoozeStore() *snooze.Store {
+	store, err := snooze.NewStore()
+	if err != nil {
+		log.Warn("could not load snoozes and reminders", "error", err)
+		return nil
+	}
+	return store
+}
+
+// openReviewHistory opens the history of review response times used for
+// the review SLO.
+func openReviewHistory() *slo.Store {
+	store, err := slo.NewStore()
+	if err != nil {
+		log.Warn("could not load review history", "error", err)
+		return nil
+	}
+	return store
+}
+
+// openCache opens the cache of item details and lists, limited to the
+// configured size. Without it every run fetches everything again.
+func openCache(cfg *config.Config) *cache.Cache {
+	c, err := cache.NewCache(cache.WithMaxSize(int64(cfg.GetCacheMaxSizeMB()) << 20))
+	if err != nil {
+		log.Warn("failed to initialize cache", "error", err)
+		return nil
+	}
+	return c
+}
+
 // loadConfig loads configuration and resolved store.
 func loadConfig() (*config.Config, *resolved.Store, error) {
+	// The resolved store doesn't depend on the config; read both at once
+	loadResolved := inBackground(func() *resolved.Store {
+		store, err := resolved.NewStore()
+		if err != nil {
+			log.Warn("could not load resolved store", "error", err)
+		}
+		return store
+	})
+
 	cfg, err := config.Load()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load config: %w", err)
 	}
+	i18n.Initialize(cfg.Locale)
+	warnUnknownWorkspaces(cfg)
+	warnInvalidSeverityLabels(cfg)
 
-	resolvedStore, err := resolved.NewStore()
-	if err != nil {
-		log.Warn("could not load resolved store", "error", err)
+	return cfg, loadResolved(), nil
+}
+
+// scopeRepos returns the repositories --repo names: owner/name, or every
+// repo in an @workspace. It is nil when --repo isn't set.
+func scopeRepos(opts *Options, cfg *config.Config) ([]string, error) {
+	if opts.Repo == "" {
+		return nil, nil
+	}
+	repos, unknown := cfg.ExpandRepos([]string{opts.Repo})
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown workspace %q in --repo; define it under workspaces in the config", unknown[0])
+	}
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("workspace %q in --repo has no repos", opts.Repo)
 	}
+	for _, repo := range repos {
+		if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid --repo %q, want owner/name or @workspace", repo)
+		}
+	}
+	return repos, nil
+}
 
-	return cfg, resolvedStore, nil
+// warnUnknownWorkspaces warns about "@name" entries in exclude_repos and
+// orphaned.repos naming workspaces that aren't configured.
+func warnUnknownWorkspaces(cfg *config.Config) {
+	repos := cfg.ExcludeRepos
+	if cfg.Orphaned != nil {
+		repos = append(slices.Clone(repos), cfg.Orphaned.Repos...)
+	}
+	if _, unknown := cfg.ExpandRepos(repos); len(unknown) > 0 {
+		log.Warn("config refers to workspaces that aren't defined", "workspaces", strings.Join(unknown, ", "))
+	}
+}
+
+// warnInvalidSeverityLabels warns about severity_labels entries naming a
+// priority that doesn't exist; they are ignored.
+func warnInvalidSeverityLabels(cfg *config.Config) {
+	for label, priority := range cfg.SeverityLabels {
+		if _, err := triage.ParsePriority(priority); err != nil {
+			log.Warn("ignoring severity label", "label", label, "error", err)
+		}
+	}
 }
 
 // initializeService creates the ItemService with user context.
-func initializeService(ctx context.Context, cfg *config.Config, sinceStr string, rt *listRuntime) (*service.ItemService, error) {
-	since, err := duration.Parse(sinceStr)
+// The underlying GitHub client is also returned for write operations
+// (such as replying from the TUI) that sit outside the read pipeline.
+func initializeService(ctx context.Context, cfg *config.Config, opts *Options, rt *listRuntime, clientOpts ...ghclient.ClientOption) (*service.ItemService, *ghclient.Client, error) {
+	since, err := duration.Parse(opts.Since)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid duration: %w", err)
+	}
+
+	repos, err := scopeRepos(opts, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("invalid duration: %w", err)
+		return nil, nil, err
+	}
+
+	if opts.Offline {
+		svc, err := initializeOfflineService(cfg, opts, rt, since, repos)
+		return svc, nil, err
 	}
 
-	log.Info("fetching notifications", "since", sinceStr)
+	log.Info("fetching notifications", "since", opts.Since, "participating", opts.Participating, "repo", opts.Repo)
 
 	token := cfg.GetGitHubToken()
 	if token == "" {
-		return nil, setup.TokenMissing()
+		return nil, nil, setup.TokenMissing()
 	}
 
-	ghClient, err := ghclient.NewClient(ctx, token)
+	// The cache opens while the client is set up; resolving the user
+	// needs both
+	loadCache := inBackground(func() *cache.Cache {
+		defer startup.track("cache")()
+		return openCache(cfg)
+	})
+
+	endClient := startup.track("client")
+	policy, err := ghclient.NewHTTPPolicy(cfg.GetHTTP())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	clientOpts = append(clientOpts, ghclient.WithHTTPPolicy(policy))
+	ghClient, err := ghclient.NewClient(ctx, token, clientOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	endClient()
+
+	c := loadCache()
 
 	rt.sendEvent(tui.TaskAuth, tui.StatusRunning)
-	currentUser, err := ghClient.AuthenticatedUser(ctx)
+	endAuth := startup.track("auth")
+	currentUser, err := service.ResolveUser(ctx, ghClient, c, token, opts.RefreshIdentity)
+	endAuth()
 	if err != nil {
 		rt.sendEvent(tui.TaskAuth, tui.StatusError, tui.WithError(err))
-		return nil, setup.TokenInvalid(err)
+		return nil, nil, setup.TokenInvalid(err)
 	}
 	rt.sendEvent(tui.TaskAuth, tui.StatusComplete, tui.WithMessage(currentUser))
 
-	c, cacheErr := cache.NewCache()
-	if cacheErr != nil {
-		log.Warn("failed to initialize cache", "error", cacheErr)
-	}
+	return service.New(ghClient, c, currentUser, since,
+		service.WithScoreWeights(cfg.GetScoreWeights()),
+		service.WithParticipating(opts.Participating),
+		service.WithRepos(repos...),
+		service.WithRefreshIdentity(opts.RefreshIdentity),
+		service.WithProjectStatus(len(cfg.ProjectColumnScores) > 0),
+	), ghClient, nil
+}
+
+// initializeOfflineService creates a service that builds the list from the
+// cache alone. The login is the one last cached for the token, however old,
+// since looking it up would reach GitHub.
+func initializeOfflineService(cfg *config.Config, opts *Options, rt *listRuntime, since time.Time, repos []string) (*service.ItemService, error) {
+	log.Info("building the list from the cache", "since", opts.Since, "participating", opts.Participating, "repo", opts.Repo)
 
-	return service.New(ghClient, c, currentUser, since), nil
+	c := openCache(cfg)
+	if c == nil {
+		return nil, fmt.Errorf("--offline needs the cache, which could not be opened")
+	}
+	login, _, ok := c.Login(cfg.GetGitHubToken())
+	if !ok || login == "" {
+		_ = c.Close()
+		return nil, fmt.Errorf("nothing is cached for this token to show offline; run triage online first")
+	}
+	rt.sendEvent(tui.TaskAuth, tui.StatusSkipped, tui.WithMessage("offline as "+login))
+
+	return service.New(nil, c, login, since,
+		service.WithScoreWeights(cfg.GetScoreWeights()),
+		service.WithParticipating(opts.Participating),
+		service.WithRepos(repos...),
+		service.WithOffline(true),
+	), nil
 }
 
 // buildFetchOptions constructs service.FetchOptions from config.
 func buildFetchOptions(cfg *config.Config) service.FetchOptions {
-	var orphanedRepos []string
-	var staleDays, consecutiveComments, maxItemsPerRepo int
-	if cfg.Orphaned != nil {
-		orphanedRepos = cfg.Orphaned.Repos
-		staleDays = cfg.Orphaned.StaleDays
-		consecutiveComments = cfg.Orphaned.ConsecutiveAuthorComments
-		maxItemsPerRepo = cfg.Orphaned.MaxItemsPerRepo
+	return triageapi.NewFetchOptions(cfg)
+}
+
+// confirmLargeFetch asks before listing more notifications than the
+// large_fetch confirmation policy allows, and reports whether to go ahead.
+// Counting takes one request, and none when the cached list is reused.
+func confirmLargeFetch(ctx context.Context, svc *service.ItemService, cfg *config.Config, fetchOpts service.FetchOptions, rt *listRuntime) (bool, error) {
+	policies, err := confirm.NewPolicies(cfg.GetConfirmations())
+	if err != nil {
+		return false, err
 	}
+	count, err := svc.NotificationCount(ctx, fetchOpts.IncludeReadNotifications)
+	if err != nil {
+		// The fetch itself reports the failure
+		log.Debug("could not count notifications", "error", err)
+		return true, nil
+	}
+	if count == 0 || !policies.Required(confirm.ActionLargeFetch, count) {
+		return true, nil
+	}
+	return rt.confirm(fmt.Sprintf("This will fetch ~%s notifications. Continue?", groupDigits(count))), nil
+}
 
-	return service.FetchOptions{
-		OrphanedRepos:            orphanedRepos,
-		StaleDays:                staleDays,
-		ConsecutiveComments:      consecutiveComments,
-		IncludeReadNotifications: cfg.IncludeReadNotifications,
-		MaxItemsPerRepo:          maxItemsPerRepo,
+// groupDigits formats n with thousands separators, e.g. 4,800.
+func groupDigits(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0 && s[i-1] != '-'; i -= 3 {
+		s = s[:i] + "," + s[i:]
 	}
+	return s
 }
 
 // runEnrichment enriches all fetched items and sends TUI events.
-func runEnrichment(ctx context.Context, svc *service.ItemService, result *service.FetchResult, rt *listRuntime) {
+func runEnrichment(ctx context.Context, svc *service.ItemService, result *service.FetchResult, rt *listRuntime, stream *itemStream) *enrichTotals {
+	if result.Unauthorized {
+		rt.sendEvent(tui.TaskEnrich, tui.StatusError, tui.WithError(ghclient.ErrUnauthorized))
+		return nil
+	}
 	rt.sendEvent(tui.TaskEnrich, tui.StatusRunning)
 
 	totalToEnrich := len(result.Notifications) + len(result.ReviewPRs) + len(result.AuthoredPRs)
-	var totalCacheHits int64
-	var totalCompleted int64
-	var totalFailed int64
+	totals := &enrichTotals{errors: make(map[ghclient.ItemErrorKind]int)}
 
 	if totalToEnrich > 0 {
-		enrichItems(ctx, svc, result.Notifications, result.ReviewPRs, result.AuthoredPRs, rt.useTUI, rt.events, totalToEnrich, &totalCompleted, &totalCacheHits, &totalFailed)
+		enrichItems(ctx, svc, result.Notifications, result.ReviewPRs, result.AuthoredPRs, rt.useTUI, rt.events, totalToEnrich, totals, stream)
+	}
+
+	if svc.Unauthorized() {
+		result.Unauthorized = true
+		rt.sendEvent(tui.TaskEnrich, tui.StatusError, tui.WithError(ghclient.ErrUnauthorized))
+		return totals
+	}
+	if failed := totals.failed - int64(totals.errors[ghclient.ItemErrorSSO]); failed > 0 {
+		log.Warn("some items could not be enriched", "failed", failed, "total", totalToEnrich, "reasons", describeEnrichErrors(totals.errors))
+	}
+	rt.sendEvent(tui.TaskEnrich, tui.StatusComplete, tui.WithMessage(totals.message(totalToEnrich)))
+	return totals
+}
+
+// enrichTotals accumulates enrichment outcomes across sources. The
+// counters are updated atomically; errors is guarded by mu.
+type enrichTotals struct {
+	completed    int64
+	cacheHits    int64
+	failed       int64
+	inaccessible int64
+
+	mu     sync.Mutex
+	errors map[ghclient.ItemErrorKind]int
+}
+
+// add records the outcome of enriching one source.
+func (t *enrichTotals) add(result service.EnrichResult) {
+	atomic.AddInt64(&t.cacheHits, int64(result.CacheHits))
+	atomic.AddInt64(&t.failed, int64(result.Failed))
+	atomic.AddInt64(&t.inaccessible, int64(result.Inaccessible))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for kind, n := range ghclient.CountErrors(result.Errors) {
+		t.errors[kind] += n
+	}
+}
+
+// message builds the enrich-complete message, e.g.
+// "118/130 (40 cached), 2 no access, 3 failed (2 not found, 1 GraphQL error)".
+func (t *enrichTotals) message(total int) string {
+	msg := fmt.Sprintf("%d/%d", t.completed, total)
+	if t.cacheHits > 0 {
+		msg = fmt.Sprintf("%d/%d (%d cached)", t.completed, total, t.cacheHits)
+	}
+	// Items in SSO-protected orgs aren't failures; the user is told how to
+	// authorize the token once the run ends.
+	sso := int64(t.errors[ghclient.ItemErrorSSO])
+	if sso > 0 {
+		msg += fmt.Sprintf(", %d need SSO", sso)
+	}
+	if t.inaccessible > 0 {
+		msg += fmt.Sprintf(", %d no access", t.inaccessible)
+	}
+	if failed := t.failed - sso; failed > 0 {
+		msg += fmt.Sprintf(", %d failed", failed)
+		if reasons := describeEnrichErrors(t.errors); reasons != "" {
+			msg += " (" + reasons + ")"
+		}
+	}
+	return msg
+}
+
+// enrichErrorLabels names the failure kinds shown in the enrich-complete
+// message, in display order. SSO and no-access items are reported
+// separately.
+var enrichErrorLabels = []struct {
+	kind             ghclient.ItemErrorKind
+	singular, plural string
+}{
+	{ghclient.ItemErrorNotFound, "not found", "not found"},
+	{ghclient.ItemErrorGraphQL, "GraphQL error", "GraphQL errors"},
+	{ghclient.ItemErrorRequest, "request failed", "requests failed"},
+	{ghclient.ItemErrorRateLimited, "rate limited", "rate limited"},
+	{ghclient.ItemErrorMissing, "no data", "no data"},
+	{ghclient.ItemErrorSkipped, "skipped", "skipped"},
+}
+
+// describeEnrichErrors summarizes failure counts, e.g. "2 not found, 1 GraphQL error".
+func describeEnrichErrors(counts map[ghclient.ItemErrorKind]int) string {
+	var parts []string
+	for _, l := range enrichErrorLabels {
+		switch n := counts[l.kind]; {
+		case n == 1:
+			parts = append(parts, "1 "+l.singular)
+		case n > 1:
+			parts = append(parts, fmt.Sprintf("%d %s", n, l.plural))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ssoRequired returns the orgs that rejected ghClient's token for SAML
+// SSO. Offline runs have no client, and so none.
+func ssoRequired(ghClient *ghclient.Client) []ghclient.SSOOrg {
+	if ghClient == nil {
+		return nil
+	}
+	return ghClient.SSORequired()
+}
+
+// runErrors lists what a partial failure left out of the run, for the
+// errors array of enveloped JSON output. totals is nil when nothing was
+// enriched; ssoOrgs are the orgs that rejected the token for SSO.
+func runErrors(result *service.FetchResult, totals *enrichTotals, ssoOrgs []ghclient.SSOOrg) []output.RunError {
+	var errs []output.RunError
+	for _, source := range slices.Sorted(maps.Keys(result.Failed)) {
+		errs = append(errs, output.RunError{Source: source, Message: result.Failed[source].Error()})
+	}
+	if result.RateLimited {
+		errs = append(errs, output.RunError{Source: "rate limit", Message: ghclient.ErrRateLimited.Error()})
+	}
+	if result.Unauthorized {
+		errs = append(errs, output.RunError{Source: "auth", Message: ghclient.ErrUnauthorized.Error()})
 	}
 
-	enrichCompleteMsg := fmt.Sprintf("%d/%d", totalCompleted, totalToEnrich)
-	if totalCacheHits > 0 {
-		enrichCompleteMsg = fmt.Sprintf("%d/%d (%d cached)", totalCompleted, totalToEnrich, totalCacheHits)
+	var sso, inaccessible int64
+	if totals != nil {
+		sso = int64(totals.errors[ghclient.ItemErrorSSO])
+		inaccessible = totals.inaccessible
+		if failed := totals.failed - sso; failed > 0 {
+			msg := "items could not be enriched"
+			if reasons := describeEnrichErrors(totals.errors); reasons != "" {
+				msg += ": " + reasons
+			}
+			errs = append(errs, output.RunError{Source: "enrichment", Message: msg, Count: int(failed)})
+		}
 	}
-	if totalFailed > 0 {
-		enrichCompleteMsg += fmt.Sprintf(", %d failed", totalFailed)
-		log.Warn("some items could not be enriched", "failed", totalFailed, "total", totalToEnrich)
+	if len(ssoOrgs) > 0 {
+		names := make([]string, len(ssoOrgs))
+		for i, org := range ssoOrgs {
+			names[i] = org.Org
+		}
+		errs = append(errs, output.RunError{Source: "sso", Message: "SAML SSO authorization required: " + strings.Join(names, ", "), Count: int(sso)})
 	}
-	rt.sendEvent(tui.TaskEnrich, tui.StatusComplete, tui.WithMessage(enrichCompleteMsg))
+	if repos := result.InaccessibleRepos(); len(repos) > 0 {
+		errs = append(errs, output.RunError{Source: "repositories", Message: "token cannot read: " + strings.Join(repos, ", "), Count: int(inaccessible)})
+	}
+	if searches := result.Truncated; len(searches) > 0 {
+		queries := make([]string, len(searches))
+		missing := 0
+		for i, search := range searches {
+			queries[i] = search.Query
+			missing += search.Missing
+		}
+		errs = append(errs, output.RunError{Source: "search", Message: "results past the search cap: " + strings.Join(queries, "; "), Count: missing})
+	}
+	return errs
 }
 
-// processResults merges, prioritizes, and filters the fetched data.
-func processResults(result *service.FetchResult, cfg *config.Config, currentUser string, events chan tui.Event) []triage.PrioritizedItem {
+// processResults merges, prioritizes, and filters the fetched data. In
+// quick mode nothing was enriched, so unenriched items are kept.
+func processResults(result *service.FetchResult, cfg *config.Config, currentUser string, opts *Options, activityStore *activity.Store, snoozeStore *snooze.Store, reviewHistory *slo.Store, events chan tui.Event) ([]triage.PrioritizedItem, []triage.Exclusion) {
 	// Merge all additional data sources into a single deduplicated list
 	merged, mergeStats := result.Merge()
 	if mergeStats.ReviewPRsAdded > 0 {
@@ -364,9 +1035,19 @@ func processResults(result *service.FetchResult, cfg *config.Config, currentUser
 	if mergeStats.OrphanedAdded > 0 {
 		log.Info("orphaned contributions", "count", mergeStats.OrphanedAdded)
 	}
+	if mergeStats.UpstreamAdded > 0 {
+		log.Info("upstream blockers resolved", "count", mergeStats.UpstreamAdded)
+	}
 
 	if len(merged) == 0 {
-		return nil
+		return nil, nil
+	}
+	annotateItems(merged, cfg, currentUser, opts, activityStore, snoozeStore)
+	// Record finished reviews before filtering drops merged and closed PRs
+	if reviewHistory != nil {
+		if err := reviewHistory.Record(merged, currentUser, time.Now()); err != nil {
+			log.Warn("could not save review history", "error", err)
+		}
 	}
 
 	weights := cfg.GetScoreWeights()
@@ -385,49 +1066,220 @@ func processResults(result *service.FetchResult, cfg *config.Config, currentUser
 	}
 	log.Debug("items before prioritization", "total", len(merged), "withDetails", withDetails, "withoutDetails", withoutDetails)
 
-	engine := triage.NewEngine(currentUser, weights, quickWinLabels)
+	engine := triage.NewEngine(currentUser, weights, quickWinLabels, triage.WithTeams(result.Teams))
 	items := engine.Prioritize(merged)
-	items = applyFilters(items, cfg)
+	var excluded []triage.Exclusion
+	if opts.ShowExcluded {
+		excluded = configExclusions(items, cfg)
+	}
+	if result.Unauthorized || opts.Quick {
+		// Keep items the token was rejected before enriching, or that
+		// quick mode never enriched
+		items = triageapi.FilterPartial(items, cfg)
+	} else {
+		items = applyFilters(items, cfg)
+	}
 
 	sendTaskEvent(events, tui.TaskProcess, tui.StatusComplete, tui.WithCount(len(items)))
-	return items
+	return items, excluded
+}
+
+// annotateItems records local interactions, snoozes, and (unless --raw-age)
+// the last human activity on merged items, ahead of scoring.
+func annotateItems(merged []model.Item, cfg *config.Config, currentUser string, opts *Options, activityStore *activity.Store, snoozeStore *snooze.Store) {
+	activity.Apply(merged, currentUser, activityStore)
+	snooze.Apply(merged, snoozeStore, time.Now())
+	if !opts.RawAge {
+		activity.ApplyHuman(merged, cfg.GetBotAuthors())
+	}
+}
+
+// listFilters are the filters runList applies to scored items from its
+// flags.
+type listFilters struct {
+	includeReasons  []model.ItemReason
+	excludeReasons  []model.ItemReason
+	formFields      []triage.FormFieldFilter
+	labels          triage.LabelFilter
+	includeArchived bool
+	minPriority     triage.PriorityLevel
+}
+
+// parseListFilters parses the filter flags in opts.
+func parseListFilters(opts *Options) (listFilters, error) {
+	var f listFilters
+	var err error
+	if f.includeReasons, f.excludeReasons, err = parseReasonFlags(opts); err != nil {
+		return f, err
+	}
+	if f.formFields, err = triage.ParseFormFieldFilters(opts.FormFields); err != nil {
+		return f, err
+	}
+	f.labels = triage.ParseLabelFilter(opts.Labels, opts.ExcludeLabels)
+	f.includeArchived = opts.IncludeArchived
+	if opts.MinPriority != "" {
+		if f.minPriority, err = triage.ParsePriority(opts.MinPriority); err != nil {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// apply filters items, returning the archived ones separately for
+// --show-excluded.
+func (f listFilters) apply(items []triage.PrioritizedItem) ([]triage.PrioritizedItem, []triage.Exclusion) {
+	items = triage.FilterByReason(items, f.includeReasons, f.excludeReasons)
+	items = triage.FilterByFormFields(items, f.formFields)
+	items = triage.FilterByLabels(items, f.labels)
+	var archived []triage.Exclusion
+	if !f.includeArchived {
+		items, archived = triage.Exclude(items, triage.Archived)
+	}
+	if f.minPriority != "" {
+		items = triage.FilterByMinPriority(items, f.minPriority)
+	}
+	return items, archived
+}
+
+// hideItems removes items marked done or snoozed, and flags resolved items
+// that came back, for output other than the TUI (which hides them itself).
+func hideItems(items []triage.PrioritizedItem, resolvedStore *resolved.Store, snoozeStore *snooze.Store) ([]triage.PrioritizedItem, []triage.Exclusion) {
+	var hidden []triage.Exclusion
+	if resolvedStore != nil {
+		var done []triage.Exclusion
+		items, done = triage.Exclude(items, triage.Hidden(resolvedStore, "marked done"))
+		items = triage.MarkResurfaced(items, resolvedStore)
+		hidden = append(hidden, done...)
+	}
+	if snoozeStore != nil {
+		var snoozed []triage.Exclusion
+		items, snoozed = triage.Exclude(items, triage.Hidden(snoozeStore, "snoozed"))
+		hidden = append(hidden, snoozed...)
+	}
+	return items, hidden
+}
+
+// configExclusions returns the open items exclude_authors and
+// exclude_repos remove, for --show-excluded.
+func configExclusions(items []triage.PrioritizedItem, cfg *config.Config) []triage.Exclusion {
+	repos, _ := cfg.ExpandRepos(cfg.ExcludeRepos)
+	open := triage.FilterOutClosed(triage.FilterOutMerged(items))
+	_, excluded := triage.Exclude(open, triage.ExcludedAuthors(cfg.ExcludeAuthors), triage.ExcludedRepos(repos))
+	return excluded
 }
 
 // renderOutput determines the format and outputs the results.
-func renderOutput(items []triage.PrioritizedItem, opts *Options, cfg *config.Config, currentUser string, resolvedStore *resolved.Store, stats service.FetchStats) error {
-	format := output.Format(opts.Format)
-	if format == "" {
-		format = output.Format(cfg.DefaultFormat)
+func renderOutput(items []triage.PrioritizedItem, excluded []triage.Exclusion, runErrs []output.RunError, opts *Options, cfg *config.Config, currentUser string, teams, roster []string, resolvedStore *resolved.Store, activityStore *activity.Store, snoozeStore *snooze.Store, reviewHistory *slo.Store, stats service.FetchStats, ghClient *ghclient.Client, delta string, resurfaced map[string]bool) error {
+	format := outputFormat(opts, cfg)
+
+	truncation, err := output.NewTruncation(cfg.GetTruncation())
+	if err != nil {
+		return err
 	}
 
-	// If running in a TTY with table format, launch interactive UI
-	if shouldUseTUI(opts) && (format == "" || format == output.FormatTable) {
+	// If running in a TTY with table format, launch interactive UI. A
+	// snapshot renders the same UI's first frame instead.
+	if opts.Snapshot != nil || (shouldUseTUI(opts) && format == output.FormatTable && !opts.ShowExcluded) {
+		policies, err := confirm.NewPolicies(cfg.GetConfirmations())
+		if err != nil {
+			return err
+		}
+		reviewSLO, err := slo.NewPolicy(cfg.GetReviewSLO())
+		if err != nil {
+			return err
+		}
 		weights := cfg.GetScoreWeights()
 		blockedLabels := cfg.GetBlockedLabels()
 		tuiOpts := []tui.ListOption{
 			tui.WithConfig(cfg),
 			tui.WithBlockedLabels(blockedLabels),
 			tui.WithDependencyAuthors(cfg.GetDependencyAuthors()),
+			tui.WithConfirmations(policies),
+			tui.WithQuickMode(opts.Quick),
+			tui.WithStatusMarkers(cfg.GetAccessibility().StatusMarkers),
+			tui.WithMinimalRedraw(cfg.GetMinimalRedraw()),
+			tui.WithTruncation(truncation),
+			tui.WithActivityStore(activityStore),
+			tui.WithSnoozeStore(snoozeStore),
+			tui.WithPlacementStore(openPlacementStore()),
+			tui.WithRunDelta(delta),
+			tui.WithRescoring(teams),
+			tui.WithConfigReload(config.Load, config.FilePaths()...),
+		}
+		if cfg.UI != nil && cfg.UI.PinResurfaced != nil && *cfg.UI.PinResurfaced {
+			tuiOpts = append(tuiOpts, tui.WithPinned(resurfaced))
+		}
+		// Offline there is no client, so replying, previews, delegating,
+		// and marking read on GitHub are unavailable
+		if ghClient != nil {
+			handoff, err := tui.ParseHandoff(cfg.GetDelegation().Comment)
+			if err != nil {
+				return fmt.Errorf("delegation.comment: %w", err)
+			}
+			tuiOpts = append(tuiOpts,
+				tui.WithEditor(editor.NewSession(ghClient)),
+				tui.WithPreviewer(ghClient),
+				tui.WithDelegation(ghClient, roster, handoff))
+			if cfg.SyncReadOnDone {
+				tuiOpts = append(tuiOpts, tui.WithReadSync(ghClient))
+			}
+		}
+		if reviewHistory != nil {
+			tuiOpts = append(tuiOpts, tui.WithReviewSLO(reviewSLO, reviewHistory.Stats(reviewSLO.Window, time.Now())))
 		}
 		if stats.AnyFromCache() {
-			tuiOpts = append(tuiOpts, tui.WithCacheStatus(
-				fmt.Sprintf("Showing cached data from %s ago", formatCacheAge(stats.CacheAge())),
-			))
+			status := fmt.Sprintf("Showing cached data from %s ago", formatCacheAge(stats.CacheAge()))
+			if opts.Offline {
+				status = "Offline: " + status
+			}
+			tuiOpts = append(tuiOpts, tui.WithCacheStatus(status))
+		}
+		if opts.Snapshot != nil {
+			s := opts.Snapshot
+			frame := tui.Snapshot(items, resolvedStore, weights, currentUser, s.Width, s.Height, s.Format == snapshotANSI, tuiOpts...)
+			_, err := fmt.Fprintln(os.Stdout, frame)
+			return err
 		}
 		return tui.RunListUI(items, resolvedStore, weights, currentUser, tuiOpts...)
 	}
 
 	// Filter out resolved items for non-TUI output (TUI handles this internally)
-	if resolvedStore != nil {
-		items = triage.FilterResolved(items, resolvedStore)
+	items, hidden := hideItems(items, resolvedStore, snoozeStore)
+	excluded = append(excluded, hidden...)
+
+	if format == output.FormatTemplate {
+		formatter, err := output.NewTemplateFormatter(opts.Template)
+		if err != nil {
+			return err
+		}
+		return formatter.Format(items, os.Stdout)
+	}
+
+	if delta != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", delta)
 	}
 
 	weights := cfg.GetScoreWeights()
-	formatter := output.NewFormatterWithWeights(format, weights, currentUser)
-	return formatter.Format(items, os.Stdout)
+	formatterOpts := []output.FormatterOption{
+		output.WithFields(output.ParseFields(opts.Fields)),
+		output.WithQuickMode(opts.Quick),
+		output.WithStatusMarkers(cfg.GetAccessibility().StatusMarkers),
+		output.WithTruncation(truncation),
+	}
+	if opts.Envelope {
+		formatterOpts = append(formatterOpts, output.WithEnvelope(runErrs))
+	}
+	formatter := output.NewFormatterWithWeights(format, weights, currentUser, formatterOpts...)
+	if err := formatter.Format(items, os.Stdout); err != nil {
+		return err
+	}
+	if opts.ShowExcluded {
+		return output.FormatExcluded(excluded, os.Stdout, format == output.FormatPlain)
+	}
+	return nil
 }
 
-// enrichItems enriches notifications and PRs concurrently using the ItemService.
+// enrichItems enriches notifications and PRs using the ItemService.
 func enrichItems(
 	ctx context.Context,
 	svc *service.ItemService,
@@ -435,7 +1287,8 @@ func enrichItems(
 	useTUI bool,
 	events chan tui.Event,
 	totalToEnrich int,
-	totalCompleted, totalCacheHits, totalFailed *int64,
+	totals *enrichTotals,
+	stream *itemStream,
 ) {
 	// Progress callback using atomic counter for concurrent updates
 	var lastLogPercent int64 = -1
@@ -443,8 +1296,8 @@ func enrichItems(
 	tuiThrottle := int64(tuiUpdateInterval)
 
 	onProgress := func(delta int, _ int) {
-		completed := atomic.AddInt64(totalCompleted, int64(delta))
-		cacheHits := atomic.LoadInt64(totalCacheHits)
+		completed := atomic.AddInt64(&totals.completed, int64(delta))
+		cacheHits := atomic.LoadInt64(&totals.cacheHits)
 
 		if useTUI {
 			// Throttle TUI updates to every 50ms for smooth progress without overhead
@@ -473,46 +1326,19 @@ func enrichItems(
 		}
 	}
 
-	// Enrich all three sources concurrently
-	var enrichWg sync.WaitGroup
-
-	// Enrich notifications
-	if len(notifications) > 0 {
-		enrichWg.Go(func() {
-			result, err := svc.Enrich(ctx, notifications, onProgress)
-			if err != nil {
-				log.Warn("some notifications could not be enriched", "error", err)
-			}
-			atomic.AddInt64(totalCacheHits, int64(result.CacheHits))
-			atomic.AddInt64(totalFailed, int64(result.Failed))
-		})
+	// Enrich all three sources in one pass so the most important items
+	// across them are enriched first if the GraphQL quota runs short
+	var result service.EnrichResult
+	var err error
+	if stream != nil {
+		result, err = svc.EnrichStream(ctx, ghclient.EnrichRoundSize, onProgress, stream.writeChunk, notifications, reviewPRs, authoredPRs)
+	} else {
+		result, err = svc.EnrichAll(ctx, onProgress, notifications, reviewPRs, authoredPRs)
 	}
-
-	// Enrich review-requested PRs
-	if len(reviewPRs) > 0 {
-		enrichWg.Go(func() {
-			result, err := svc.Enrich(ctx, reviewPRs, onProgress)
-			if err != nil {
-				log.Warn("some review PRs could not be enriched", "error", err)
-			}
-			atomic.AddInt64(totalCacheHits, int64(result.CacheHits))
-			atomic.AddInt64(totalFailed, int64(result.Failed))
-		})
+	if err != nil && !ghclient.IsAuthError(err) {
+		log.Warn("some items could not be enriched", "error", err)
 	}
-
-	// Enrich authored PRs
-	if len(authoredPRs) > 0 {
-		enrichWg.Go(func() {
-			result, err := svc.Enrich(ctx, authoredPRs, onProgress)
-			if err != nil {
-				log.Warn("some authored PRs could not be enriched", "error", err)
-			}
-			atomic.AddInt64(totalCacheHits, int64(result.CacheHits))
-			atomic.AddInt64(totalFailed, int64(result.Failed))
-		})
-	}
-
-	enrichWg.Wait()
+	totals.add(result)
 
 	if !useTUI {
 		log.ProgressDone()
@@ -521,27 +1347,10 @@ func enrichItems(
 
 // applyFilters applies all configured filters to the items.
 func applyFilters(items []triage.PrioritizedItem, cfg *config.Config) []triage.PrioritizedItem {
-	// Filter out merged and closed items by default
-	items = triage.FilterOutMerged(items)
-	items = triage.FilterOutClosed(items)
-
-	// Filter out unenriched (inaccessible) items - always applied
-	var unenrichedCount int
-	items, unenrichedCount = triage.FilterOutUnenriched(items)
+	items, unenrichedCount := triageapi.Filter(items, cfg)
 	if unenrichedCount > 0 {
 		log.Warn("items could not be enriched (may be deleted or inaccessible)", "dropped", unenrichedCount)
 	}
-
-	// Filter out excluded authors (bots like dependabot, renovate, etc.)
-	if len(cfg.ExcludeAuthors) > 0 {
-		items = triage.FilterByExcludedAuthors(items, cfg.ExcludeAuthors)
-	}
-
-	// Filter out excluded repositories
-	if len(cfg.ExcludeRepos) > 0 {
-		items = triage.FilterByExcludedRepos(items, cfg.ExcludeRepos)
-	}
-
 	return items
 }
 
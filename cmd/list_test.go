@@ -1,8 +1,20 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/output"
+	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/service"
+	"github.com/spiffcs/triage/internal/triage"
 )
 
 func TestFormatCacheAge(t *testing.T) {
@@ -26,3 +38,331 @@ func TestFormatCacheAge(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		template   string
+		wantFormat string
+		wantErr    bool
+	}{
+		{"no template", "", "", "", false},
+		{"json ignores template", "json", "", "json", false},
+		{"template flag implies format", "", "{{.Number}}", "template", false},
+		{"template format without template", "template", "", "template", true},
+		{"malformed template", "template", "{{.Number", "template", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions(WithFormat(tt.format), WithTemplate(tt.template))
+			err := validateTemplate(opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if opts.Format != tt.wantFormat {
+				t.Errorf("Format = %q, want %q", opts.Format, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        string
+		defaultFormat string
+		fields        string
+		wantErr       bool
+	}{
+		{"no fields", "", "table", "", false},
+		{"json", "json", "table", "score,title", false},
+		{"csv", "csv", "table", "score,title", false},
+		{"default format json", "", "json", "score", false},
+		{"table", "table", "table", "score", true},
+		{"template", "template", "table", "score", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions(WithFormat(tt.format), WithFields(tt.fields))
+			err := validateFields(opts, tt.defaultFormat)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseReasonFlags(t *testing.T) {
+	opts := NewOptions(WithReasons("review_requested,!subscribed"), WithExcludeReasons("ci_activity", "!state_change"))
+	include, exclude, err := parseReasonFlags(opts)
+	if err != nil {
+		t.Fatalf("parseReasonFlags() error: %v", err)
+	}
+	wantExclude := []model.ItemReason{model.ReasonSubscribed, model.ReasonCIActivity, model.ReasonStateChange}
+	if !slices.Equal(include, []model.ItemReason{model.ReasonReviewRequested}) || !slices.Equal(exclude, wantExclude) {
+		t.Errorf("parseReasonFlags() = %v, %v; want [review_requested], %v", include, exclude, wantExclude)
+	}
+
+	if _, _, err := parseReasonFlags(NewOptions(WithExcludeReasons("subscibed"))); err == nil {
+		t.Error("parseReasonFlags() accepted an unknown reason")
+	}
+}
+
+func TestScopeRepos(t *testing.T) {
+	cfg := &config.Config{Workspaces: map[string][]string{
+		"platform": {"org/a", "org/b"},
+		"empty":    {},
+		"bad":      {"org"},
+	}}
+
+	tests := []struct {
+		repo    string
+		want    []string
+		wantErr bool
+	}{
+		{repo: "", want: nil},
+		{repo: "org/x", want: []string{"org/x"}},
+		{repo: "@platform", want: []string{"org/a", "org/b"}},
+		{repo: "@nope", wantErr: true},
+		{repo: "@empty", wantErr: true},
+		{repo: "@bad", wantErr: true},
+		{repo: "org", wantErr: true},
+		{repo: "org/x/y", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := scopeRepos(NewOptions(WithRepo(tt.repo)), cfg)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("scopeRepos(%q) error = %v, wantErr %v", tt.repo, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("scopeRepos(%q) = %v, want %v", tt.repo, got, tt.want)
+		}
+	}
+}
+
+func TestEnrichTotalsMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals *enrichTotals
+		want   string
+	}{
+		{
+			name:   "all enriched",
+			totals: &enrichTotals{completed: 10, cacheHits: 4},
+			want:   "10/10 (4 cached)",
+		},
+		{
+			name: "failures broken down by kind",
+			totals: &enrichTotals{completed: 10, failed: 3, errors: map[ghclient.ItemErrorKind]int{
+				ghclient.ItemErrorNotFound: 2,
+				ghclient.ItemErrorGraphQL:  1,
+			}},
+			want: "10/10, 3 failed (2 not found, 1 GraphQL error)",
+		},
+		{
+			name: "sso and no access are not failures",
+			totals: &enrichTotals{completed: 10, failed: 2, inaccessible: 1, errors: map[ghclient.ItemErrorKind]int{
+				ghclient.ItemErrorSSO: 2,
+			}},
+			want: "10/10, 2 need SSO, 1 no access",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.totals.message(10); got != tt.want {
+				t.Errorf("message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *service.FetchResult
+		totals *enrichTotals
+		sso    []ghclient.SSOOrg
+		want   []output.RunError
+	}{
+		{
+			name:   "complete run",
+			result: &service.FetchResult{},
+			totals: &enrichTotals{completed: 10},
+		},
+		{
+			name: "failed sources sorted by name",
+			result: &service.FetchResult{Failed: map[string]error{
+				"review PRs":    errors.New("timeout"),
+				"notifications": errors.New("bad gateway"),
+			}},
+			want: []output.RunError{
+				{Source: "notifications", Message: "bad gateway"},
+				{Source: "review PRs", Message: "timeout"},
+			},
+		},
+		{
+			name:   "rejected token",
+			result: &service.FetchResult{Unauthorized: true},
+			want:   []output.RunError{{Source: "auth", Message: "GitHub token rejected"}},
+		},
+		{
+			name:   "enrichment failures without sso items",
+			result: &service.FetchResult{},
+			totals: &enrichTotals{completed: 10, failed: 3, errors: map[ghclient.ItemErrorKind]int{
+				ghclient.ItemErrorNotFound: 2,
+				ghclient.ItemErrorSSO:      1,
+			}},
+			want: []output.RunError{{Source: "enrichment", Message: "items could not be enriched: 2 not found", Count: 2}},
+		},
+		{
+			name:   "sso orgs",
+			result: &service.FetchResult{},
+			totals: &enrichTotals{completed: 10, failed: 2, errors: map[ghclient.ItemErrorKind]int{ghclient.ItemErrorSSO: 2}},
+			sso:    []ghclient.SSOOrg{{Org: "acme"}, {Org: "widgets"}},
+			want:   []output.RunError{{Source: "sso", Message: "SAML SSO authorization required: acme, widgets", Count: 2}},
+		},
+		{
+			name: "inaccessible repositories",
+			result: &service.FetchResult{Notifications: []model.Item{
+				{Inaccessible: true, Repository: model.Repository{FullName: "org/b"}},
+				{Inaccessible: true, Repository: model.Repository{FullName: "org/a"}},
+			}},
+			totals: &enrichTotals{completed: 0, inaccessible: 2},
+			want:   []output.RunError{{Source: "repositories", Message: "token cannot read: org/a, org/b", Count: 2}},
+		},
+		{
+			name: "truncated searches",
+			result: &service.FetchResult{Truncated: []ghclient.TruncatedSearch{
+				{Query: "is:open author:me", Missing: 40},
+				{Query: "is:open review-requested:me", Missing: 2},
+			}},
+			want: []output.RunError{{Source: "search", Message: "results past the search cap: is:open author:me; is:open review-requested:me", Count: 42}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runErrors(tt.result, tt.totals, tt.sso); !slices.Equal(got, tt.want) {
+				t.Errorf("runErrors() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupDigits(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{4800, "4,800"},
+		{1234567, "1,234,567"},
+		{-4800, "-4,800"},
+	}
+
+	for _, tt := range tests {
+		if got := groupDigits(tt.n); got != tt.want {
+			t.Errorf("groupDigits(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestInitializeOfflineService(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GITHUB_TOKEN", "token")
+	cfg := config.DefaultConfig()
+	opts := NewOptions(WithOffline(true))
+	since := time.Now().Add(-24 * time.Hour)
+
+	// Without a previous online run there is no login to score for
+	if _, err := initializeOfflineService(cfg, opts, &listRuntime{}, since, nil); err == nil {
+		t.Fatal("initializeOfflineService() with nothing cached = nil error, want one")
+	}
+
+	c := openCache(cfg)
+	if err := c.SetLogin("token", "octocat"); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Close()
+	svc, err := initializeOfflineService(cfg, opts, &listRuntime{}, since, nil)
+	if err != nil {
+		t.Fatalf("initializeOfflineService() error = %v", err)
+	}
+	if svc.CurrentUser() != "octocat" {
+		t.Errorf("CurrentUser() = %q, want the cached login", svc.CurrentUser())
+	}
+}
+
+func TestDelegationRoster(t *testing.T) {
+	svc := service.New(nil, nil, "octocat", time.Time{})
+	settings := config.DelegationSettings{Roster: []string{"carol", "@Alice", "OctoCat", "", "alice", "bob"}}
+
+	want := []string{"Alice", "bob", "carol"}
+	if got := delegationRoster(context.Background(), svc, settings); !slices.Equal(got, want) {
+		t.Errorf("delegationRoster() = %v, want %v", got, want)
+	}
+}
+
+func TestDeltaScope(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"default", Options{Since: defaultSince}, ""},
+		{"repo", Options{Since: defaultSince, Repo: "acme/api"}, "repos=acme/api"},
+		{"since and participating", Options{Since: "30d", Participating: true}, "since=30d;participating"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deltaScope(&tt.opts, &config.Config{}); got != tt.want {
+				t.Errorf("deltaScope() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunDeltaScopedRunKeepsResurfaced(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	resolvedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	store, err := resolved.NewStoreFromPath(filepath.Join(t.TempDir(), "resolved.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Resolve("acme/api#1", resolvedAt); err != nil {
+		t.Fatal(err)
+	}
+	back := triage.PrioritizedItem{Item: model.Item{
+		Number:     1,
+		UpdatedAt:  resolvedAt.Add(time.Hour),
+		Repository: model.Repository{FullName: "acme/api"},
+	}}
+	other := triage.PrioritizedItem{Item: model.Item{
+		Number:     2,
+		UpdatedAt:  resolvedAt,
+		Repository: model.Repository{FullName: "acme/web"},
+	}}
+
+	now := resolvedAt.Add(2 * time.Hour)
+	if _, resurfaced := runDelta([]triage.PrioritizedItem{back, other}, store, "", now); !resurfaced["acme/api#1"] {
+		t.Fatalf("first run resurfaced = %v, want acme/api#1", resurfaced)
+	}
+	// A run limited to acme/web doesn't see acme/api#1
+	runDelta([]triage.PrioritizedItem{other}, store, "repos=acme/web", now.Add(time.Minute))
+
+	// Nothing new happened to it since the last full run
+	if _, resurfaced := runDelta([]triage.PrioritizedItem{back, other}, store, "", now.Add(2*time.Minute)); len(resurfaced) != 0 {
+		t.Errorf("full run after a scoped one resurfaced = %v, want none", resurfaced)
+	}
+}
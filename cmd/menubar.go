@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/menubar"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// NewCmdMenubar creates the menubar command.
+func NewCmdMenubar(opts *Options) *cobra.Command {
+	var since string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "menubar",
+		Short: "Print your prioritized items as an xbar or SwiftBar plugin",
+		Long: `Fetch and prioritize your items, then print them in the plugin format of
+xbar and SwiftBar: the number of urgent and total items in the menu bar,
+and the top items in its dropdown, each opening on GitHub. Items you
+marked done or snoozed are left out.
+
+Save a script like this in the plugin folder, naming it for how often it
+refreshes, e.g. triage.10m.sh:
+
+  #!/bin/sh
+  exec /usr/local/bin/triage menubar
+
+When items can't be fetched, the bar shows ⚠ and the dropdown the error.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runMenubar(cmd.Context(), opts, since, limit, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "1w", "Include notifications since (e.g., 1d, 1w, 30d)")
+	cmd.Flags().IntVar(&limit, "limit", 10, "Items listed in the dropdown (0 for all)")
+
+	return cmd
+}
+
+func runMenubar(ctx context.Context, opts *Options, since string, limit int, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+	if limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	items, err := menubarItems(ctx, opts, since)
+	if err != nil {
+		// The plugin shows only what is printed, so the error goes there
+		log.Error("could not fetch items", "error", err)
+		_, err = io.WriteString(out, menubar.Failed(err))
+		return err
+	}
+
+	command, err := os.Executable()
+	if err != nil {
+		log.Debug("could not find the triage binary", "error", err)
+	}
+	_, err = io.WriteString(out, menubar.Render(items, menubar.Options{Limit: limit, Command: command}))
+	return err
+}
+
+// menubarItems fetches the items for the menu bar, leaving out those
+// marked done or snoozed.
+func menubarItems(ctx context.Context, opts *Options, since string) ([]triage.PrioritizedItem, error) {
+	cfg, resolvedStore, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	traceCtx, endTrace := startTrace(ctx, "menubar")
+	items, err := digestItems(traceCtx, opts, cfg, resolvedStore, since)
+	endTrace()
+	return items, err
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/migrate"
+	"github.com/spiffcs/triage/internal/resolved"
+)
+
+// NewCmdMigrate creates the migrate command.
+func NewCmdMigrate(opts *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade config, cache, and stored state to the current format",
+		Long: `Upgrade files written by older versions of triage to the formats this
+version expects. Migrations also run automatically on startup; use this
+command with --dry-run to preview what would change.
+
+The config files and resolved store are backed up next to the original
+(e.g. config.yaml.v0.bak) before they are rewritten. Cache entries from
+older formats are removed rather than converted.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runMigrate(opts, os.Stdout)
+		},
+	}
+}
+
+func runMigrate(opts *Options, out io.Writer) error {
+	targets, closeTargets := migrationTargets()
+	defer closeTargets()
+	plans, err := migrate.Run(targets, opts.DryRun)
+	printPlans(out, plans, opts.DryRun)
+	if err != nil {
+		return err
+	}
+	if len(plans) == 0 {
+		fmt.Fprintln(out, "Everything is up to date")
+	}
+	return nil
+}
+
+// runStartupMigrations upgrades stored files before a command runs. A
+// failed migration is reported but does not stop the command; the stores
+// fall back to reading older formats where they can.
+func runStartupMigrations(opts *Options) {
+	if opts.DryRun {
+		return
+	}
+	defer startup.track("migrate")()
+	targets, closeTargets := migrationTargets()
+	defer closeTargets()
+	plans, err := migrate.Run(targets, false)
+	for _, p := range plans {
+		msg := fmt.Sprintf("Migrated %s to format v%d", p.Target, p.To)
+		if p.Backup != "" {
+			msg += fmt.Sprintf(" (backup: %s)", p.Backup)
+		}
+		fmt.Fprintln(os.Stderr, msg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: migration failed: %v\nRun 'triage migrate --dry-run' for details.\n", err)
+	}
+}
+
+// migrationTargets lists every persisted format triage knows how to
+// upgrade, and a func closing the ones holding files open. Targets whose
+// location cannot be determined are skipped.
+func migrationTargets() ([]migrate.Target, func()) {
+	targets := config.MigrationTargets()
+	if t, err := resolved.MigrationTarget(); err == nil {
+		targets = append(targets, t)
+	}
+	c, err := cache.NewCache()
+	if err != nil {
+		return targets, func() {}
+	}
+	return append(targets, c.MigrationTarget()), func() { _ = c.Close() }
+}
+
+func printPlans(out io.Writer, plans []migrate.Plan, dryRun bool) {
+	prefix := ""
+	if dryRun {
+		prefix = "[dry-run] would migrate "
+	}
+	for _, p := range plans {
+		fmt.Fprintf(out, "%s%s: v%d -> v%d (%s)\n", prefix, p.Target, p.From, p.To, p.Path)
+		for _, s := range p.Steps {
+			fmt.Fprintf(out, "  - %s\n", s)
+		}
+		if p.Backup != "" {
+			fmt.Fprintf(out, "  backup: %s\n", p.Backup)
+		}
+	}
+}
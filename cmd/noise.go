@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/service"
+	"github.com/spiffcs/triage/internal/setup"
+	"github.com/spiffcs/triage/internal/triage"
+	"golang.org/x/term"
+)
+
+// noiseRows is the number of repos, reasons, and authors listed in each
+// section of the noise report.
+const noiseRows = 10
+
+// NewCmdNoise creates the noise command.
+func NewCmdNoise(opts *Options) *cobra.Command {
+	var since string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "noise",
+		Short: "Report which notifications you dismiss without acting on",
+		Long: `Fetch your notifications, read and unread, and report which repos,
+reasons, and authors send the ones you never act on.
+
+A notification was acted on when you authored the item, or commented on,
+reviewed, opened, or replied to it. It was dismissed when, without that,
+you marked it done in triage or read it on GitHub. Notifications still
+waiting count toward neither.
+
+Repos and authors with at least 5 notifications, 90% or more of them
+dismissed and none acted on, are suggested for exclude_repos and
+exclude_authors.
+
+Run in a terminal, it then offers to stop each suggested repo's
+notifications on GitHub: u unwatches it, leaving only threads you take
+part in or are mentioned in, and i ignores it entirely.`,
+		Example: `  triage noise
+  triage noise --since 90d -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runNoise(cmd.Context(), opts, since, outputFormat, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "30d", "Include notifications since (e.g., 1w, 30d, 90d)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json)")
+
+	return cmd
+}
+
+func runNoise(ctx context.Context, opts *Options, since, outputFormat string, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	window, err := duration.Parse(since)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	cfg, resolvedStore, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	token := cfg.GetGitHubToken()
+	if token == "" {
+		return setup.TokenMissing()
+	}
+	httpPolicy, err := ghclient.NewHTTPPolicy(cfg.GetHTTP())
+	if err != nil {
+		return err
+	}
+	client, err := ghclient.NewClient(ctx, token,
+		ghclient.WithHTTPPolicy(httpPolicy),
+		ghclient.WithDryRun(opts.DryRun),
+		ghclient.WithAuditLog(openAuditLog()),
+		ghclient.WithProjectStatus(len(cfg.ProjectColumnScores) > 0),
+	)
+	if err != nil {
+		return err
+	}
+	c := openCache(cfg)
+	if c != nil {
+		defer c.Close()
+	}
+	currentUser, err := service.ResolveUser(ctx, client, c, token, opts.RefreshIdentity)
+	if err != nil {
+		return setup.TokenInvalid(err)
+	}
+	svc := service.New(client, c, currentUser, window, service.WithProjectStatus(len(cfg.ProjectColumnScores) > 0))
+
+	traceCtx, endTrace := startTrace(ctx, "noise")
+	fetched, err := svc.UnreadItems(traceCtx, true)
+	if err != nil {
+		endTrace()
+		return err
+	}
+	items := fetched.Items
+	if _, err := svc.Enrich(traceCtx, items, nil); err != nil {
+		log.Warn("some items could not be enriched", "error", err)
+	}
+	endTrace()
+	activity.Apply(items, currentUser, openActivityStore())
+
+	noiseOpts := triage.NoiseOptions{
+		CurrentUser:     currentUser,
+		ExcludedAuthors: cfg.ExcludeAuthors,
+	}
+	noiseOpts.ExcludedRepos, _ = cfg.ExpandRepos(cfg.ExcludeRepos)
+	if resolvedStore != nil {
+		noiseOpts.Resolved = resolvedStore
+	}
+	report := triage.Noise(items, noiseOpts)
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	if err := writeNoise(out, report, since); err != nil {
+		return err
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil
+	}
+	return offerUnwatch(ctx, os.Stdin, out, report.Suggestions, client)
+}
+
+// repoWatcher changes which notifications a repository sends.
+type repoWatcher interface {
+	Unwatch(ctx context.Context, owner, repo string) error
+	IgnoreRepo(ctx context.Context, owner, repo string) error
+}
+
+// offerUnwatch asks, for each repo suggested for exclusion, whether to
+// unwatch or ignore it on GitHub, one keypress per repo with no Enter
+// needed. Excluding a repo only hides it in triage; this stops the
+// notifications at the source.
+func offerUnwatch(ctx context.Context, in io.Reader, out io.Writer, suggestions []triage.NoiseSuggestion, watcher repoWatcher) error {
+	var repos []triage.NoiseSuggestion
+	for _, s := range suggestions {
+		if s.Key == "exclude_repos" {
+			repos = append(repos, s)
+		}
+	}
+	if len(repos) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Stop these repos' notifications on GitHub?")
+	fmt.Fprintln(out, "  u: unwatch (participating and @mentions only)   i: ignore (no notifications)   q: stop   any other key: skip")
+	var errs []error
+	for _, s := range repos {
+		owner, repo, ok := strings.Cut(s.Value, "/")
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(out, "  %s (%d from watching) [u/i/N/q]: ", format.Sanitize(s.Value), s.Watching)
+		key, readErr := readKey(in)
+		if readErr != nil {
+			fmt.Fprintln(out)
+			return errors.Join(errs...)
+		}
+
+		var err error
+		switch key {
+		case 'u', 'U':
+			fmt.Fprintln(out, "u")
+			if err = watcher.Unwatch(ctx, owner, repo); err == nil {
+				fmt.Fprintf(out, "  Unwatched %s.\n", s.Value)
+			}
+		case 'i', 'I':
+			fmt.Fprintln(out, "i")
+			if err = watcher.IgnoreRepo(ctx, owner, repo); err == nil {
+				fmt.Fprintf(out, "  Ignored %s.\n", s.Value)
+			}
+		case 'q', 'Q', ctrlC, ctrlD:
+			fmt.Fprintln(out, "q")
+			return errors.Join(errs...)
+		default:
+			fmt.Fprintln(out)
+		}
+		switch {
+		case errors.Is(err, ghclient.ErrDryRun):
+			fmt.Fprintf(out, "  %v\n", err)
+		case err != nil:
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ctrlC and ctrlD are the bytes a terminal in raw mode reads for Ctrl+C and
+// Ctrl+D, which stop the prompt like q.
+const (
+	ctrlC = 0x03
+	ctrlD = 0x04
+)
+
+// readKey reads a single keypress from in. A terminal is put in raw mode
+// for the read, so the key counts without Enter; the bytes a key such as
+// an arrow sends are read together and only the first is returned.
+func readKey(in io.Reader) (byte, error) {
+	buf := make([]byte, 1, 16)
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = term.Restore(int(f.Fd()), state) }()
+		buf = buf[:cap(buf)]
+	}
+	n, err := in.Read(buf)
+	if n == 0 {
+		if err == nil {
+			err = io.EOF
+		}
+		return 0, err
+	}
+	return buf[0], nil
+}
+
+// writeNoise prints the noise report: totals, the repos, reasons, and
+// authors dismissed most, and suggested exclusions.
+func writeNoise(w io.Writer, r triage.NoiseReport, since string) error {
+	if r.Total == 0 {
+		_, err := fmt.Fprintf(w, "No notifications in the past %s.\n", since)
+		return err
+	}
+
+	fmt.Fprintf(w, "Of %d notifications from the past %s, you acted on %d, dismissed %d without acting, and left %d waiting.\n",
+		r.Total, since, r.Acted, r.Dismissed, r.Total-r.Acted-r.Dismissed)
+
+	for _, section := range []struct {
+		title string
+		stats []triage.NoiseStat
+	}{
+		{"Repository", r.Repos},
+		{"Reason", r.Reasons},
+		{"Author", r.Authors},
+	} {
+		if len(section.stats) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n  %-40s  %6s  %6s  %10s\n", section.title, "Total", "Acted", "Dismissed")
+		for _, s := range section.stats[:min(len(section.stats), noiseRows)] {
+			name, _ := format.TruncateToWidth(format.Sanitize(s.Name), 40)
+			fmt.Fprintf(w, "  %-40s  %6d  %6d  %5d %3d%%\n", name, s.Total, s.Acted, s.Dismissed, s.DismissedShare())
+		}
+	}
+
+	fmt.Fprintln(w)
+	if len(r.Suggestions) == 0 {
+		_, err := fmt.Fprintln(w, "No repo or author is dismissed often enough to suggest excluding it.")
+		return err
+	}
+	fmt.Fprintln(w, "Suggested exclusions:")
+	for _, s := range r.Suggestions {
+		fmt.Fprintf(w, "  %s: %s (%s)\n", s.Key, format.Sanitize(s.Value), s.Reason)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "To apply them, add to your config:")
+	key := ""
+	for _, s := range r.Suggestions {
+		if s.Key != key {
+			key = s.Key
+			fmt.Fprintf(w, "  %s:\n", key)
+		}
+		fmt.Fprintf(w, "    - %s\n", format.Sanitize(s.Value))
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestWriteNoise(t *testing.T) {
+	r := triage.NoiseReport{
+		Total:     12,
+		Acted:     3,
+		Dismissed: 8,
+		Repos: []triage.NoiseStat{
+			{Name: "acme/infra", Total: 6, Dismissed: 6},
+			{Name: "acme/api", Total: 6, Acted: 3, Dismissed: 2},
+		},
+		Reasons: []triage.NoiseStat{{Name: "subscribed", Total: 12, Acted: 3, Dismissed: 8}},
+		Suggestions: []triage.NoiseSuggestion{
+			{Key: "exclude_repos", Value: "acme/infra", Reason: "6 of 6 notifications dismissed, none acted on"},
+			{Key: "exclude_authors", Value: "renovate[bot]", Reason: "6 of 6 notifications dismissed, none acted on"},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := writeNoise(&out, r, "30d"); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+
+	for _, want := range []string{
+		"Of 12 notifications from the past 30d, you acted on 3, dismissed 8 without acting, and left 1 waiting.\n",
+		"  Repository                                 Total   Acted   Dismissed\n",
+		"  acme/infra                                     6       0      6 100%\n",
+		"  acme/api                                       6       3      2  33%\n",
+		"  exclude_repos: acme/infra (6 of 6 notifications dismissed, none acted on)\n",
+		"  exclude_repos:\n    - acme/infra\n  exclude_authors:\n    - renovate[bot]\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeNoise() output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Author") {
+		t.Errorf("writeNoise() should skip empty sections:\n%s", got)
+	}
+
+	out.Reset()
+	if err := writeNoise(&out, triage.NoiseReport{}, "30d"); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != "No notifications in the past 30d.\n" {
+		t.Errorf("writeNoise() with no notifications = %q", got)
+	}
+}
+
+// fakeWatcher records the repos unwatched and ignored.
+type fakeWatcher struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeWatcher) Unwatch(_ context.Context, owner, repo string) error {
+	f.calls = append(f.calls, "unwatch "+owner+"/"+repo)
+	return f.err
+}
+
+func (f *fakeWatcher) IgnoreRepo(_ context.Context, owner, repo string) error {
+	f.calls = append(f.calls, "ignore "+owner+"/"+repo)
+	return f.err
+}
+
+func TestOfferUnwatch(t *testing.T) {
+	suggestions := []triage.NoiseSuggestion{
+		{Key: "exclude_repos", Value: "acme/infra", Watching: 6},
+		{Key: "exclude_authors", Value: "renovate[bot]"},
+		{Key: "exclude_repos", Value: "acme/docs", Watching: 2},
+		{Key: "exclude_repos", Value: "acme/site"},
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		err       error
+		wantCalls []string
+		wantOut   string
+		wantErr   bool
+	}{
+		{
+			name:      "unwatch and ignore",
+			input:     "uI\r",
+			wantCalls: []string{"unwatch acme/infra", "ignore acme/docs"},
+			wantOut:   "  acme/infra (6 from watching) [u/i/N/q]: u\n  Unwatched acme/infra.\n",
+		},
+		{
+			name:      "other keys skip",
+			input:     "xi",
+			wantCalls: []string{"ignore acme/docs"},
+		},
+		{
+			name:      "stop",
+			input:     "qu",
+			wantCalls: nil,
+		},
+		{
+			name:      "ctrl+c stops",
+			input:     "\x03u",
+			wantCalls: nil,
+		},
+		{
+			name:      "end of input skips the rest",
+			input:     "i",
+			wantCalls: []string{"ignore acme/infra"},
+		},
+		{
+			name:      "failures carry on",
+			input:     "uu",
+			err:       errors.New("forbidden"),
+			wantCalls: []string{"unwatch acme/infra", "unwatch acme/docs"},
+			wantErr:   true,
+		},
+		{
+			name:      "dry run",
+			input:     "u",
+			err:       fmt.Errorf("%w: would unwatch acme/infra", ghclient.ErrDryRun),
+			wantCalls: []string{"unwatch acme/infra"},
+			wantOut:   "  dry run: would unwatch acme/infra\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher := &fakeWatcher{err: tt.err}
+			var out bytes.Buffer
+			err := offerUnwatch(context.Background(), strings.NewReader(tt.input), &out, suggestions, watcher)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("offerUnwatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(watcher.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", watcher.calls, tt.wantCalls)
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Errorf("output missing %q:\n%s", tt.wantOut, out.String())
+			}
+		})
+	}
+
+	// Nothing to offer without a suggested repo
+	var out bytes.Buffer
+	if err := offerUnwatch(context.Background(), strings.NewReader("u"), &out, suggestions[1:2], &fakeWatcher{}); err != nil || out.Len() != 0 {
+		t.Errorf("offerUnwatch() with no repos = %v, %q", err, out.String())
+	}
+}
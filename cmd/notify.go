@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/desktop"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/notify"
+	"github.com/spiffcs/triage/internal/snapshot"
+	"github.com/spiffcs/triage/internal/triage"
+	triageapi "github.com/spiffcs/triage/pkg/triage"
+)
+
+// NewCmdNotify creates the notify command.
+func NewCmdNotify(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Post a digest of your prioritized items to chat services",
+		Long: `Post the same digest triage email sends to the chat services configured
+in the notifiers section of your config: Discord, Matrix, and Microsoft
+Teams, or show desktop notifications as items become urgent.`,
+	}
+	cmd.AddCommand(newCmdNotifySend(opts))
+	cmd.AddCommand(newCmdNotifyDesktop(opts))
+	return cmd
+}
+
+func newCmdNotifySend(opts *Options) *cobra.Command {
+	var since string
+	var only []string
+	var skipEmpty bool
+
+	cmd := &cobra.Command{
+		Use:   "send",
+		Short: "Post the digest to every configured notifier",
+		Long: `Fetch and prioritize your items, then post the digest to every configured
+notifier. A notifier that fails does not stop the others. With --dry-run
+the digest is printed instead of posted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runNotifySend(cmd.Context(), opts, since, only, skipEmpty, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "1w", "Include notifications since (e.g., 1d, 1w, 30d)")
+	cmd.Flags().StringSliceVar(&only, "only", nil, "Post only to these notifiers (discord, matrix, teams)")
+	cmd.Flags().BoolVar(&skipEmpty, "skip-empty", false, "Post nothing when no items need attention")
+
+	return cmd
+}
+
+func runNotifySend(ctx context.Context, opts *Options, since string, only []string, skipEmpty bool, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	cfg, resolvedStore, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	notifiers, err := notify.FromSettings(cfg.GetNotifiers())
+	if err != nil {
+		return err
+	}
+	notifiers, err = selectNotifiers(notifiers, only)
+	if err != nil {
+		return err
+	}
+
+	traceCtx, endTrace := startTrace(ctx, "notify send")
+	items, err := digestItems(traceCtx, opts, cfg, resolvedStore, since)
+	endTrace()
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 && skipEmpty {
+		log.Info("nothing needs attention, not posting")
+		return nil
+	}
+
+	d := notify.NewDigest(items)
+	if opts.DryRun {
+		for _, n := range notifiers {
+			if _, err := fmt.Fprintf(out, "would post to %s\n", n.Name()); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(out, "\n%s\n\n%s", d.Subject, d.Text)
+		return err
+	}
+
+	var errs []error
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, d); err != nil {
+			errs = append(errs, fmt.Errorf("failed to post to %s: %w", n.Name(), err))
+			continue
+		}
+		log.Info("posted digest", "notifier", n.Name(), "items", len(items))
+	}
+	return errors.Join(errs...)
+}
+
+// selectNotifiers keeps the notifiers named in only, or all of them when
+// only is empty. Naming one that is not configured is an error.
+func selectNotifiers(notifiers []notify.Notifier, only []string) ([]notify.Notifier, error) {
+	if len(notifiers) == 0 {
+		return nil, errors.New("no notifiers configured; add a notifiers section to your config")
+	}
+	if len(only) == 0 {
+		return notifiers, nil
+	}
+	var selected []notify.Notifier
+	for _, name := range only {
+		i := slices.IndexFunc(notifiers, func(n notify.Notifier) bool { return n.Name() == name })
+		if i < 0 {
+			return nil, fmt.Errorf("notifier %q is not configured", name)
+		}
+		selected = append(selected, notifiers[i])
+	}
+	return selected, nil
+}
+
+func newCmdNotifyDesktop(opts *Options) *cobra.Command {
+	var since string
+	var every time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "desktop",
+		Short: "Show desktop notifications for items that became urgent",
+		Long: `Fetch and prioritize your items, then show a desktop notification for each
+item that reached notifications.min_priority (default: urgent) since the
+last check. Nothing is shown during notifications.quiet_hours; items that
+cross then are notified once they end.
+
+Run it from cron or launchd, or keep it running with --every. With
+--dry-run the notifications are printed instead of shown.`,
+		Example: `  triage notify desktop --every 10m
+  */10 * * * *  triage notify desktop`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runNotifyDesktop(cmd.Context(), opts, since, every, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "1w", "Include notifications since (e.g., 1d, 1w, 30d)")
+	cmd.Flags().DurationVar(&every, "every", 0, "Keep running, checking at this interval (e.g., 10m)")
+
+	return cmd
+}
+
+func runNotifyDesktop(ctx context.Context, opts *Options, since string, every time.Duration, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	cfg, resolvedStore, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	policy, err := desktop.NewPolicy(cfg.GetNotifications())
+	if err != nil {
+		return err
+	}
+	store, err := desktop.NewStateStore()
+	if err != nil {
+		return fmt.Errorf("failed to open notification state: %w", err)
+	}
+	prev, _, _, err := store.Load()
+	if err != nil {
+		log.Warn("could not load notification state, starting fresh", "error", err)
+	}
+
+	notifier := desktop.New()
+	send := func(n desktop.Notification) error {
+		if opts.DryRun {
+			_, err := fmt.Fprintf(out, "would notify: %s: %s\n", n.Title, n.Body)
+			return err
+		}
+		return notifier.Notify(ctx, n)
+	}
+
+	// One client serves every check, so its cache and rate limit state
+	// carry over. It is built on the first check that can.
+	var client *triageapi.Client
+	defer func() {
+		if client != nil {
+			_ = client.Close()
+		}
+	}()
+	for {
+		traceCtx, endTrace := startTrace(ctx, "notify desktop")
+		var items []triage.PrioritizedItem
+		var err error
+		if client == nil {
+			client, err = newDigestClient(traceCtx, opts, cfg, since)
+		}
+		if err == nil {
+			items, err = runDigest(traceCtx, client, resolvedStore)
+		}
+		endTrace()
+		if errors.Is(err, triageapi.ErrUnauthorized) || (err != nil && every == 0) {
+			return err
+		}
+		if err != nil {
+			log.Warn("check failed, trying again next time", "error", err)
+		} else {
+			next, err := checkDesktop(policy, prev, items, time.Now(), send)
+			if err != nil {
+				log.Warn("could not show notification", "error", err)
+			}
+			if next != nil {
+				prev = next
+				// A dry run leaves the items to notify about for real
+				if !opts.DryRun {
+					if err := store.Save(next, time.Now()); err != nil {
+						log.Warn("could not save notification state", "error", err)
+					}
+				}
+			}
+		}
+
+		if every <= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(every):
+		}
+	}
+}
+
+// checkDesktop notifies about the items that crossed into a notifying
+// priority since prev and returns the state to compare the next check
+// with. During quiet hours, or when a notification can't be shown, it
+// returns nil so the items are notified on a later check.
+func checkDesktop(policy desktop.Policy, prev snapshot.State, items []triage.PrioritizedItem, now time.Time, send func(desktop.Notification) error) (snapshot.State, error) {
+	if policy.Quiet(now) {
+		log.Info("quiet hours, holding notifications")
+		return nil, nil
+	}
+	crossed := policy.Crossed(prev, items)
+	for _, n := range desktop.Notifications(crossed) {
+		if err := send(n); err != nil {
+			return nil, err
+		}
+	}
+	if len(crossed) > 0 {
+		log.Info("sent desktop notifications", "items", len(crossed))
+	}
+	return snapshot.Capture(items, nil), nil
+}
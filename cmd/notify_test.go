@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/desktop"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/notify"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+type namedNotifier string
+
+func (n namedNotifier) Name() string                                { return string(n) }
+func (n namedNotifier) Notify(context.Context, notify.Digest) error { return nil }
+
+func TestSelectNotifiers(t *testing.T) {
+	all := []notify.Notifier{namedNotifier("discord"), namedNotifier("matrix"), namedNotifier("teams")}
+
+	tests := []struct {
+		name      string
+		notifiers []notify.Notifier
+		only      []string
+		want      string
+		wantErr   string
+	}{
+		{"all by default", all, nil, "discord,matrix,teams", ""},
+		{"only some", all, []string{"teams", "discord"}, "teams,discord", ""},
+		{"not configured", all[:1], []string{"matrix"}, "", `"matrix" is not configured`},
+		{"none configured", nil, nil, "", "no notifiers configured"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectNotifiers(tt.notifiers, tt.only)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("selectNotifiers() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, n := range got {
+				names = append(names, n.Name())
+			}
+			if strings.Join(names, ",") != tt.want {
+				t.Errorf("selectNotifiers() = %v, want %s", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDesktop(t *testing.T) {
+	policy, err := desktop.NewPolicy(config.NotificationSettings{MinPriority: "urgent", QuietHours: "22:00-08:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := []triage.PrioritizedItem{{
+		Item:     model.Item{Number: 7, Repository: model.Repository{FullName: "acme/api"}, Subject: model.Subject{Title: "Outage"}},
+		Priority: triage.PriorityUrgent,
+	}}
+	noon := time.Date(2026, 10, 10, 12, 0, 0, 0, time.Local)
+
+	var sent []desktop.Notification
+	send := func(n desktop.Notification) error {
+		sent = append(sent, n)
+		return nil
+	}
+
+	// Held during quiet hours
+	next, err := checkDesktop(policy, nil, items, noon.Add(11*time.Hour), send)
+	if err != nil || next != nil || len(sent) != 0 {
+		t.Fatalf("quiet hours: state %v, %d sent, error %v; want nothing", next, len(sent), err)
+	}
+
+	next, err = checkDesktop(policy, nil, items, noon, send)
+	if err != nil || len(sent) != 1 || sent[0].Title != "acme/api#7 is Urgent" {
+		t.Fatalf("checkDesktop() sent %+v, error %v; want one notification", sent, err)
+	}
+
+	// Still urgent on the next check: nothing new
+	if _, err := checkDesktop(policy, next, items, noon, send); err != nil || len(sent) != 1 {
+		t.Errorf("second check sent %d notifications, want none more", len(sent)-1)
+	}
+
+	// A notification that can't be shown is tried again next time
+	failing := func(desktop.Notification) error { return errors.New("no notification daemon") }
+	if next, err := checkDesktop(policy, nil, items, noon, failing); err == nil || next != nil {
+		t.Errorf("failed send: state %v, error %v; want no state and the error", next, err)
+	}
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/browser"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/setup"
+	"github.com/spiffcs/triage/internal/snooze"
+	"github.com/spiffcs/triage/internal/triage"
+	triageapi "github.com/spiffcs/triage/pkg/triage"
+)
+
+// NewCmdOpen creates the open command.
+func NewCmdOpen(opts *Options) *cobra.Command {
+	var since string
+	var printURL bool
+
+	cmd := &cobra.Command{
+		Use:   "open <n>",
+		Short: "Open the n-th ranked item in your browser",
+		Long: `Rank your items like triage list does and open the n-th one in your
+browser, without starting the TUI. triage open 1 opens the item at the top
+of the list.
+
+Items are ranked as the list shows them: by priority, then score, leaving
+out items marked done, snoozed, or archived. Fetched lists come from the
+cache when it is fresh, so running this right after triage list ranks the
+same items.`,
+		Example: `  triage open 1
+  triage open 3 --since 30d
+  triage open 1 --print`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid item number %q: must be 1 or more", args[0])
+			}
+			open := browser.Open
+			if printURL {
+				open = func(url string) error {
+					_, err := fmt.Fprintln(os.Stdout, url)
+					return err
+				}
+			}
+			return runOpen(cmd.Context(), opts, n, since, open, os.Stderr)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "1w", "Include notifications since (e.g., 1d, 1w, 30d)")
+	cmd.Flags().BoolVar(&printURL, "print", false, "Print the item's URL instead of opening it")
+
+	return cmd
+}
+
+func runOpen(ctx context.Context, opts *Options, n int, since string, open func(url string) error, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	window, err := duration.ParseDuration(since)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	cfg, resolvedStore, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	token := cfg.GetGitHubToken()
+	if token == "" {
+		return setup.TokenMissing()
+	}
+
+	client, err := triageapi.New(ctx, token, triageapi.WithConfig(cfg), triageapi.WithSince(window),
+		triageapi.WithRefreshIdentity(opts.RefreshIdentity))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	traceCtx, endTrace := startTrace(ctx, "open")
+	result, err := client.Fetch(traceCtx)
+	if result == nil {
+		endTrace()
+		return err
+	}
+	if result.Unauthorized {
+		endTrace()
+		return errors.Join(triageapi.ErrUnauthorized, err)
+	}
+	if err != nil {
+		log.Warn("some items could not be fetched", "error", err)
+	}
+	if _, err := client.Enrich(traceCtx, result); err != nil {
+		log.Warn("some items could not be enriched", "error", err)
+	}
+	endTrace()
+
+	currentUser := client.CurrentUser()
+	all, _ := result.Merge()
+	activityStore := openActivityStore()
+	snoozeStore := openSnoozeStore()
+	// Score as list does, so the n-th item is the one list shows n-th
+	annotateItems(all, cfg, currentUser, opts, activityStore, snoozeStore)
+	engine := triage.NewEngine(currentUser, cfg.GetScoreWeights(), cfg.GetQuickWinLabels(), triage.WithTeams(result.Teams))
+	items, _ := triageapi.Filter(engine.Prioritize(all), cfg)
+	item, err := rankedItem(items, n, resolvedStore, snoozeStore)
+	if err != nil {
+		return fmt.Errorf("%w from the past %s", err, since)
+	}
+
+	url := item.HTMLURL
+	if url == "" {
+		url = item.Repository.HTMLURL
+	}
+	if url == "" {
+		return fmt.Errorf("%s has no URL to open", item.Key())
+	}
+
+	fmt.Fprintf(out, "%d. %s: %s\n", n, item.Key(), format.Sanitize(item.Subject.Title))
+	if err := open(url); err != nil {
+		return err
+	}
+	if activityStore != nil {
+		// A failed write only loses the local record
+		_ = activityStore.Record(activity.Key(&item.Item), time.Now())
+	}
+	return nil
+}
+
+// rankedItem returns the n-th (from 1) of the scored items in the order
+// triage list shows them, leaving out archived items and the ones marked
+// done or snoozed.
+func rankedItem(items []triage.PrioritizedItem, n int, resolvedStore *resolved.Store, snoozeStore *snooze.Store) (triage.PrioritizedItem, error) {
+	items = triageapi.FilterOutArchived(items)
+	items, _ = hideItems(items, resolvedStore, snoozeStore)
+	if n > len(items) {
+		if len(items) == 0 {
+			return triage.PrioritizedItem{}, errors.New("no items to open")
+		}
+		return triage.PrioritizedItem{}, fmt.Errorf("there is no item %d; %d are ranked", n, len(items))
+	}
+	return items[n-1], nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestRankedItem(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	item := func(number int, priority triage.PriorityLevel) triage.PrioritizedItem {
+		return triage.PrioritizedItem{
+			Item: model.Item{
+				Number:     number,
+				UpdatedAt:  updated,
+				Repository: model.Repository{FullName: "acme/api"},
+			},
+			Priority: priority,
+		}
+	}
+	items := []triage.PrioritizedItem{
+		item(1, triage.PriorityUrgent),
+		item(2, triage.PriorityImportant),
+		item(3, triage.PriorityImportant),
+		item(4, triage.PriorityArchive),
+	}
+
+	store, err := resolved.NewStoreFromPath(filepath.Join(t.TempDir(), "resolved.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Resolve("acme/api#2", updated); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		n          int
+		wantNumber int
+		wantErr    bool
+	}{
+		{name: "top item", n: 1, wantNumber: 1},
+		{name: "skips items marked done", n: 2, wantNumber: 3},
+		{name: "skips archived items", n: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rankedItem(items, tt.n, store, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rankedItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Number != tt.wantNumber {
+				t.Errorf("rankedItem() = #%d, want #%d", got.Number, tt.wantNumber)
+			}
+		})
+	}
+
+	if _, err := rankedItem(nil, 1, nil, nil); err == nil {
+		t.Error("rankedItem() of no items should fail")
+	}
+}
@@ -3,14 +3,63 @@ package cmd
 // Options holds the shared command-line options for the triage CLI.
 type Options struct {
 	Format    string
+	Envelope  bool   // Wrap JSON output in an object with an errors array
+	Template  string // Go text/template used with the template output format
+	Fields    string // Comma-separated fields kept in JSON/CSV output
 	Since     string
 	Verbosity int
 	TUI       *bool // nil = auto-detect, true = force TUI, false = disable TUI
+	DryRun    bool  // Print mutating operations instead of performing them
+	// RefreshIdentity looks up the authenticated user and their teams
+	// instead of using the cached ones.
+	RefreshIdentity bool
+	Estimate        bool // Report the cost of a run instead of running it
+	Quick           bool // Skip enrichment and score on notification metadata only
+	Offline         bool // Build the list from the cache without calling GitHub
+	RawAge          bool // Age items from updatedAt, counting bot activity
+
+	// Participating limits notifications to threads the user takes part in.
+	Participating bool
+	// Repo limits the run to one repository (owner/repo).
+	Repo string
+
+	// Reasons keeps (or, prefixed with "!", drops) items by notification
+	// reason; ExcludeReasons drops items by reason.
+	Reasons        []string
+	ExcludeReasons []string
+	// FormFields keeps items whose issue form answers match, each as
+	// "field=value".
+	FormFields []string
+	// Labels keeps (or, prefixed with "!", drops) items by label;
+	// ExcludeLabels drops items by label.
+	Labels        []string
+	ExcludeLabels []string
+	// MinPriority keeps items at or above this priority level.
+	MinPriority string
+	// IncludeArchived keeps items in the Archive priority, which are
+	// otherwise dropped.
+	IncludeArchived bool
+	// ShowExcluded lists the items config exclusions, done and snoozed
+	// state, and archiving removed, below the list.
+	ShowExcluded bool
+	// Snapshot prints one frame of the list UI instead of running it, when
+	// set by the snapshot command.
+	Snapshot *SnapshotOptions
 
 	// Profiling options
 	CPUProfile string // Write CPU profile to file
 	MemProfile string // Write memory profile to file
 	Trace      string // Write execution trace to file
+
+	// RecordCassette records GitHub API traffic to this file for test fixtures
+	RecordCassette string
+}
+
+// SnapshotOptions is how the snapshot command renders the list UI's frame.
+type SnapshotOptions struct {
+	Format string // ansi or plain
+	Width  int
+	Height int
 }
 
 // Option is a functional option for configuring Options.
@@ -27,13 +76,35 @@ func NewOptions(opts ...Option) *Options {
 	return o
 }
 
-// WithFormat sets the output format (table, json).
+// WithFormat sets the output format (table, json, jsonl, csv, template, plain, markdown).
 func WithFormat(format string) Option {
 	return func(o *Options) {
 		o.Format = format
 	}
 }
 
+// WithEnvelope wraps JSON output in an object holding the items and the
+// errors that left the run incomplete.
+func WithEnvelope(envelope bool) Option {
+	return func(o *Options) {
+		o.Envelope = envelope
+	}
+}
+
+// WithTemplate sets the Go text/template used by the template output format.
+func WithTemplate(tmpl string) Option {
+	return func(o *Options) {
+		o.Template = tmpl
+	}
+}
+
+// WithFields sets the comma-separated fields kept in JSON/CSV output.
+func WithFields(fields string) Option {
+	return func(o *Options) {
+		o.Fields = fields
+	}
+}
+
 // WithSince sets the time window for notifications (e.g., "1w", "30d", "6mo").
 func WithSince(since string) Option {
 	return func(o *Options) {
@@ -75,3 +146,123 @@ func WithTrace(path string) Option {
 		o.Trace = path
 	}
 }
+
+// WithDryRun enables dry-run mode, where mutating operations are reported but not performed.
+func WithDryRun(dryRun bool) Option {
+	return func(o *Options) {
+		o.DryRun = dryRun
+	}
+}
+
+// WithRefreshIdentity looks up the authenticated user and their teams
+// instead of using the cached ones.
+func WithRefreshIdentity(refresh bool) Option {
+	return func(o *Options) {
+		o.RefreshIdentity = refresh
+	}
+}
+
+// WithEstimate reports what a run would cost instead of running it.
+func WithEstimate(estimate bool) Option {
+	return func(o *Options) {
+		o.Estimate = estimate
+	}
+}
+
+// WithQuick skips enrichment, scoring items on notification metadata only.
+func WithQuick(quick bool) Option {
+	return func(o *Options) {
+		o.Quick = quick
+	}
+}
+
+// WithOffline builds the list from the cache without calling GitHub.
+func WithOffline(offline bool) Option {
+	return func(o *Options) {
+		o.Offline = offline
+	}
+}
+
+// WithRawAge ages items from their raw updatedAt, so bot comments and
+// pushes count as activity.
+func WithRawAge(raw bool) Option {
+	return func(o *Options) {
+		o.RawAge = raw
+	}
+}
+
+// WithParticipating limits notifications to threads the user takes part
+// in.
+func WithParticipating(participating bool) Option {
+	return func(o *Options) {
+		o.Participating = participating
+	}
+}
+
+// WithRepo limits the run to one repository (owner/repo).
+func WithRepo(repo string) Option {
+	return func(o *Options) {
+		o.Repo = repo
+	}
+}
+
+// WithReasons keeps only items with the given reasons; reasons prefixed
+// with "!" are dropped instead.
+func WithReasons(reasons ...string) Option {
+	return func(o *Options) {
+		o.Reasons = reasons
+	}
+}
+
+// WithExcludeReasons drops items with the given reasons.
+func WithExcludeReasons(reasons ...string) Option {
+	return func(o *Options) {
+		o.ExcludeReasons = reasons
+	}
+}
+
+// WithFormFields keeps items whose issue form answers match each
+// "field=value" filter.
+func WithFormFields(filters ...string) Option {
+	return func(o *Options) {
+		o.FormFields = filters
+	}
+}
+
+// WithLabels keeps only items with any of the given labels; labels
+// prefixed with "!" are dropped instead.
+func WithLabels(labels ...string) Option {
+	return func(o *Options) {
+		o.Labels = labels
+	}
+}
+
+// WithExcludeLabels drops items with any of the given labels.
+func WithExcludeLabels(labels ...string) Option {
+	return func(o *Options) {
+		o.ExcludeLabels = labels
+	}
+}
+
+// WithMinPriority keeps only items at or above the given priority level.
+func WithMinPriority(priority string) Option {
+	return func(o *Options) {
+		o.MinPriority = priority
+	}
+}
+
+// WithIncludeArchived keeps items that had no activity for longer than the
+// configured archive horizon.
+func WithIncludeArchived(include bool) Option {
+	return func(o *Options) {
+		o.IncludeArchived = include
+	}
+}
+
+// WithShowExcluded lists the items filters removed below the list, with
+// the rule that removed each.
+func WithShowExcluded(show bool) Option {
+	return func(o *Options) {
+		o.ShowExcluded = show
+	}
+}
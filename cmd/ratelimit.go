@@ -44,7 +44,11 @@ func runRateLimitStatus(cmd *cobra.Command, args []string) error {
 		return setup.TokenMissing()
 	}
 
-	client, err := ghclient.NewClient(ctx, token)
+	policy, err := ghclient.NewHTTPPolicy(cfg.GetHTTP())
+	if err != nil {
+		return err
+	}
+	client, err := ghclient.NewClient(ctx, token, ghclient.WithHTTPPolicy(policy))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/setup"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// releaseReport is what triage release shows for one base branch.
+type releaseReport struct {
+	Base          string       `json:"base"`
+	Open          []model.Item `json:"open"`
+	NeedsBackport []model.Item `json:"needsBackport"`
+}
+
+// NewCmdRelease creates the release command.
+func NewCmdRelease(opts *Options) *cobra.Command {
+	var base string
+	var since string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Show the open PRs targeting a release branch",
+		Long: `List every open PR targeting a release branch across your repos, with its
+CI status, review state, and whether it can merge, to see what stands
+between you and cutting the release.
+
+PRs merged into other branches since --since are checked for backports: a
+PR labeled for a backport (release.backport_labels in the config, or a label
+naming the branch) that no PR targeting the branch refers to is listed as
+needing one.
+
+Repos come from --repo, or every repo in your workspaces.`,
+		Example: `  triage release --base release/1.2
+  triage release --base release/1.2 --repo @platform --since 2w`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRelease(cmd.Context(), opts, base, since, outputFormat, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&base, "base", "", "Release branch the PRs target (e.g., release/1.2)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Only check this repository (owner/name) or the repos in a workspace (@name)")
+	cmd.Flags().StringVarP(&since, "since", "s", "30d", "Check PRs merged since for missing backports (e.g., 2w, 30d)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json)")
+	_ = cmd.MarkFlagRequired("base")
+
+	return cmd
+}
+
+func runRelease(ctx context.Context, opts *Options, base, since, outputFormat string, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	window, err := duration.Parse(since)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	cfg, _, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	repos, err := releaseRepos(opts, cfg)
+	if err != nil {
+		return err
+	}
+	token := cfg.GetGitHubToken()
+	if token == "" {
+		return setup.TokenMissing()
+	}
+	httpPolicy, err := ghclient.NewHTTPPolicy(cfg.GetHTTP())
+	if err != nil {
+		return err
+	}
+	client, err := ghclient.NewClient(ctx, token,
+		ghclient.WithHTTPPolicy(httpPolicy),
+		ghclient.WithDryRun(opts.DryRun),
+		ghclient.WithAuditLog(openAuditLog()),
+	)
+	if err != nil {
+		return err
+	}
+
+	report := releaseReport{Base: base, Open: []model.Item{}, NeedsBackport: []model.Item{}}
+	backportLabels := cfg.GetRelease().BackportLabels
+	traceCtx, endTrace := startTrace(ctx, "release")
+	for _, repo := range repos {
+		prs, err := client.ListReleasePRs(traceCtx, repo, base, window)
+		if err != nil {
+			endTrace()
+			return err
+		}
+		report.Open = append(report.Open, prs.Open...)
+		report.NeedsBackport = append(report.NeedsBackport, triage.NeedsBackport(prs.Merged, prs.Referenced, base, backportLabels)...)
+	}
+	if _, err := client.EnrichItemsGraphQL(traceCtx, report.Open, client.Token(), nil); err != nil {
+		log.Warn("some PRs could not be enriched", "error", err)
+	}
+	endTrace()
+
+	sort.SliceStable(report.Open, func(i, j int) bool {
+		return report.Open[i].UpdatedAt.After(report.Open[j].UpdatedAt)
+	})
+	sort.SliceStable(report.NeedsBackport, func(i, j int) bool {
+		return report.NeedsBackport[i].UpdatedAt.After(report.NeedsBackport[j].UpdatedAt)
+	})
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return writeRelease(out, report, time.Now())
+}
+
+// releaseRepos returns the repos --repo names, or every repo in the
+// configured workspaces.
+func releaseRepos(opts *Options, cfg *config.Config) ([]string, error) {
+	if opts.Repo != "" {
+		return scopeRepos(opts, cfg)
+	}
+	var workspaces []string
+	for name := range cfg.Workspaces {
+		workspaces = append(workspaces, "@"+name)
+	}
+	sort.Strings(workspaces)
+	repos, _ := cfg.ExpandRepos(workspaces)
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repos to check; pass --repo or define workspaces in the config")
+	}
+	return repos, nil
+}
+
+// writeRelease lists the open PRs targeting the branch, then the merged
+// PRs that may need a backport to it.
+func writeRelease(w io.Writer, report releaseReport, now time.Time) error {
+	if len(report.Open) == 0 {
+		if _, err := fmt.Fprintf(w, "No open PRs target %s.\n\n", report.Base); err != nil {
+			return err
+		}
+	} else {
+		noun := "PRs target"
+		if len(report.Open) == 1 {
+			noun = "PR targets"
+		}
+		if _, err := fmt.Fprintf(w, "%d open %s %s:\n\n", len(report.Open), noun, report.Base); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "  %-30s  %-8s  %-17s  %-9s  %s\n", "PR", "CI", "REVIEW", "MERGEABLE", "TITLE"); err != nil {
+			return err
+		}
+		for _, n := range report.Open {
+			ref := fmt.Sprintf("%s#%d", n.Repository.FullName, n.Number)
+			ci, review, mergeable := releaseStatus(&n)
+			title, _ := format.TruncateToWidth(format.Sanitize(n.Subject.Title), 60)
+			if _, err := fmt.Fprintf(w, "  %-30s  %-8s  %-17s  %-9s  %s\n", ref, ci, review, mergeable, title); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	if len(report.NeedsBackport) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "May need a backport to %s:\n\n", report.Base); err != nil {
+		return err
+	}
+	for _, n := range report.NeedsBackport {
+		ref := fmt.Sprintf("%s#%d", n.Repository.FullName, n.Number)
+		title, _ := format.TruncateToWidth(format.Sanitize(n.Subject.Title), 60)
+		age := ""
+		if at := triage.ClosedAt(&n); at != nil {
+			age = "merged " + format.FormatAgo(now.Sub(*at))
+		}
+		if _, err := fmt.Fprintf(w, "  %-30s  %-60s  %s\n", ref, title, age); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// releaseStatus returns the CI status, review state, and mergeability of
+// a PR for display, with "-" for what isn't known.
+func releaseStatus(n *model.Item) (ci, review, mergeable string) {
+	ci, review, mergeable = "-", "-", "-"
+	pr := n.PRDetails()
+	if pr == nil {
+		return ci, review, mergeable
+	}
+	if pr.CIStatus != "" {
+		ci = pr.CIStatus
+	}
+	if pr.ReviewState != "" {
+		review = pr.ReviewState
+	}
+	switch {
+	case pr.Draft:
+		mergeable = "draft"
+	case pr.Mergeable:
+		mergeable = "yes"
+	case pr.ChangedFiles > 0:
+		// Only enrichment fills in the changed files, so mergeability is known
+		mergeable = "no"
+	}
+	return ci, review, mergeable
+}
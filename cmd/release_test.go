@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestWriteRelease(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	merged := now.Add(-3 * 24 * time.Hour)
+	report := releaseReport{
+		Base: "release/1.2",
+		Open: []model.Item{
+			{
+				Number:     40,
+				Repository: model.Repository{FullName: "acme/api"},
+				Subject:    model.Subject{Title: "Backport retry fix"},
+				Type:       model.ItemTypePullRequest,
+				Details:    &model.PRDetails{CIStatus: "failure", ReviewState: "approved", ChangedFiles: 2},
+			},
+			{
+				Number:     41,
+				Repository: model.Repository{FullName: "acme/web"},
+				Subject:    model.Subject{Title: "Bump version"},
+				Type:       model.ItemTypePullRequest,
+				Details:    &model.PRDetails{Draft: true},
+			},
+		},
+		NeedsBackport: []model.Item{{
+			Number:     12,
+			State:      model.StateMerged,
+			ClosedAt:   &merged,
+			Repository: model.Repository{FullName: "acme/api"},
+			Subject:    model.Subject{Title: "Fix token refresh"},
+		}},
+	}
+
+	var b strings.Builder
+	if err := writeRelease(&b, report, now); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"2 open PRs target release/1.2",
+		"acme/api#40", "failure", "approved", "no", "Backport retry fix",
+		"acme/web#41", "draft",
+		"May need a backport to release/1.2",
+		"acme/api#12", "Fix token refresh", "merged 3d ago",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeRelease() output missing %q:\n%s", want, got)
+		}
+	}
+
+	b.Reset()
+	if err := writeRelease(&b, releaseReport{Base: "release/1.2"}, now); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != "No open PRs target release/1.2.\n\n" {
+		t.Errorf("writeRelease() with nothing to report = %q", got)
+	}
+}
+
+func TestReleaseRepos(t *testing.T) {
+	cfg := &config.Config{Workspaces: map[string][]string{
+		"web":      {"acme/web", "acme/api"},
+		"platform": {"acme/api", "acme/infra"},
+	}}
+
+	got, err := releaseRepos(&Options{}, cfg)
+	if err != nil {
+		t.Fatalf("releaseRepos() error: %v", err)
+	}
+	if want := "acme/api,acme/infra,acme/web"; strings.Join(got, ",") != want {
+		t.Errorf("releaseRepos() = %v, want %s", got, want)
+	}
+
+	got, err = releaseRepos(&Options{Repo: "acme/cli"}, cfg)
+	if err != nil || strings.Join(got, ",") != "acme/cli" {
+		t.Errorf("releaseRepos() with --repo = %v, %v; want [acme/cli]", got, err)
+	}
+
+	if _, err := releaseRepos(&Options{}, &config.Config{}); err == nil {
+		t.Error("releaseRepos() with no repos should fail")
+	}
+}
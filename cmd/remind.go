@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/snooze"
+)
+
+// NewCmdRemind creates the remind command.
+func NewCmdRemind() *cobra.Command {
+	var in, note string
+	var clearReminder bool
+
+	cmd := &cobra.Command{
+		Use:   "remind [owner/repo#number | url]",
+		Short: "Set a reminder to come back to an issue or PR",
+		Long: `Set a reminder on an issue or PR. Once it is due, the item is listed with
+a ⏰ badge and the note, its score is raised by scoring.reminder_bonus, and it
+is shown even if you marked it done. Marking it done in the TUI clears the
+reminder.
+
+Without an item, lists the reminders that are set.`,
+		Example: `  triage remind spiffcs/triage#42 --in 2d --note "ping after release"
+  triage remind https://github.com/spiffcs/triage/pull/42 --clear
+  triage remind`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := snooze.NewStore()
+			if err != nil {
+				return fmt.Errorf("failed to open reminders: %w", err)
+			}
+			if len(args) == 0 {
+				printReminders(os.Stdout, store.Reminders(), time.Now())
+				return nil
+			}
+			return runRemind(os.Stdout, store, args[0], in, note, clearReminder, time.Now())
+		},
+	}
+
+	cmd.Flags().StringVar(&in, "in", "", "When to be reminded (e.g., 4h, 2d, 1w)")
+	cmd.Flags().StringVar(&note, "note", "", "Note shown with the item when the reminder is due")
+	cmd.Flags().BoolVar(&clearReminder, "clear", false, "Remove the reminder on the item")
+	cmd.MarkFlagsMutuallyExclusive("in", "clear")
+	cmd.MarkFlagsMutuallyExclusive("note", "clear")
+
+	return cmd
+}
+
+// runRemind sets or clears the reminder on the item ref refers to.
+func runRemind(w io.Writer, store *snooze.Store, ref, in, note string, clearReminder bool, now time.Time) error {
+	owner, repo, number, err := ghclient.ParseItemRef(ref)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	key := activity.RefKey(owner+"/"+repo, number)
+
+	if clearReminder {
+		found, err := store.Clear(key)
+		if err != nil {
+			return fmt.Errorf("failed to clear reminder: %w", err)
+		}
+		if !found {
+			fmt.Fprintf(w, "No reminder set on %s\n", name)
+			return nil
+		}
+		fmt.Fprintf(w, "Cleared the reminder on %s\n", name)
+		return nil
+	}
+
+	if in == "" {
+		return errors.New("--in is required, e.g. --in 2d")
+	}
+	d, err := duration.ParseDuration(in)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("--in must be in the future, got %s", in)
+	}
+
+	at := now.Add(d)
+	if err := store.Remind(key, at, note); err != nil {
+		return fmt.Errorf("failed to save reminder: %w", err)
+	}
+	fmt.Fprintf(w, "Reminder set on %s for %s (in %s)\n", name, at.Local().Format("Mon Jan 2 15:04"), format.FormatAge(d))
+	return nil
+}
+
+// printReminders lists reminders with when each is due.
+func printReminders(w io.Writer, reminders []snooze.Entry, now time.Time) {
+	if len(reminders) == 0 {
+		fmt.Fprintln(w, "No reminders set.")
+		return
+	}
+	for _, r := range reminders {
+		when := "due"
+		if r.At.After(now) {
+			when = "in " + format.FormatAge(r.At.Sub(now))
+		}
+		line := fmt.Sprintf("%-40s %-8s", r.Key, when)
+		if r.Note != "" {
+			line += "  " + r.Note
+		}
+		fmt.Fprintln(w, line)
+	}
+}
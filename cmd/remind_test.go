@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/snooze"
+)
+
+func TestRunRemind(t *testing.T) {
+	store, err := snooze.NewStoreFromPath(filepath.Join(t.TempDir(), "snooze.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)
+
+	var out bytes.Buffer
+	if err := runRemind(&out, store, "https://github.com/Acme/API/pull/42", "2d", "ping after release", false, now); err != nil {
+		t.Fatalf("runRemind() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Reminder set on Acme/API#42") {
+		t.Errorf("output = %q", out.String())
+	}
+	// Keyed like the item, so it comes due on acme/api#42 however it was named
+	r, ok := store.Due("acme/api#42", now.Add(48*time.Hour))
+	if !ok || r.Note != "ping after release" {
+		t.Fatalf("Due() = %+v, %v; want the reminder two days on", r, ok)
+	}
+
+	out.Reset()
+	printReminders(&out, store.Reminders(), now.Add(24*time.Hour))
+	if got := out.String(); !strings.Contains(got, "acme/api#42") || !strings.Contains(got, "in 1d") || !strings.Contains(got, "ping after release") {
+		t.Errorf("printReminders() = %q", got)
+	}
+
+	out.Reset()
+	if err := runRemind(&out, store, "acme/api#42", "", "", true, now); err != nil {
+		t.Fatalf("runRemind(--clear) error = %v", err)
+	}
+	if _, ok := store.Due("acme/api#42", now.Add(72*time.Hour)); ok {
+		t.Error("--clear left the reminder")
+	}
+
+	for _, tt := range []struct {
+		name, ref, in, want string
+	}{
+		{"no time", "acme/api#42", "", "--in is required"},
+		{"bad time", "acme/api#42", "soon", "invalid duration"},
+		{"bad item", "acme/api", "2d", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runRemind(&out, store, tt.ref, tt.in, "", false, now)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("runRemind() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
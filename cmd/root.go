@@ -15,6 +15,12 @@ func New(options ...Option) *cobra.Command {
 		Short: "GitHub notification triage manager",
 		Long: `A CLI tool that analyzes your GitHub notifications to help you
 triage your work. It uses heuristics to score notifications.`,
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			// migrate and demo manage (or avoid) stored state themselves
+			if cmd.Name() != "migrate" && cmd.Name() != "demo" {
+				runStartupMigrations(opts)
+			}
+		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runList(cmd, opts)
 		},
@@ -26,12 +32,33 @@ triage your work. It uses heuristics to score notifications.`,
 	// Add list flags to root command so `triage` and `triage list` work identically
 	addListFlags(rootCmd, opts)
 
+	// Global flags honored by every command that changes state on GitHub
+	rootCmd.PersistentFlags().BoolVar(&opts.DryRun, "dry-run", opts.DryRun, "Print mutating operations instead of performing them")
+	rootCmd.PersistentFlags().BoolVar(&opts.RefreshIdentity, "refresh-identity", opts.RefreshIdentity, "Look up your GitHub login and teams again instead of using the cached ones")
+
 	// Register subcommands
 	rootCmd.AddCommand(NewCmdList(opts))
 	rootCmd.AddCommand(NewCmdConfig())
 	rootCmd.AddCommand(NewCmdCache())
 	rootCmd.AddCommand(NewCmdVersion())
+	rootCmd.AddCommand(NewCmdSelfUpdate(opts))
 	rootCmd.AddCommand(NewCmdRateLimit())
+	rootCmd.AddCommand(NewCmdEdit(opts))
+	rootCmd.AddCommand(NewCmdAudit())
+	rootCmd.AddCommand(NewCmdDemo(opts))
+	rootCmd.AddCommand(NewCmdMigrate(opts))
+	rootCmd.AddCommand(NewCmdEmail(opts))
+	rootCmd.AddCommand(NewCmdNotify(opts))
+	rootCmd.AddCommand(NewCmdClosed(opts))
+	rootCmd.AddCommand(NewCmdCalibrate(opts))
+	rootCmd.AddCommand(NewCmdRemind())
+	rootCmd.AddCommand(NewCmdStandup(opts))
+	rootCmd.AddCommand(NewCmdRelease(opts))
+	rootCmd.AddCommand(NewCmdExplain(opts))
+	rootCmd.AddCommand(NewCmdOpen(opts))
+	rootCmd.AddCommand(NewCmdNoise(opts))
+	rootCmd.AddCommand(NewCmdSnapshot(opts))
+	rootCmd.AddCommand(NewCmdMenubar(opts))
 
 	return rootCmd
 }
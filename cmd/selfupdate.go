@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/confirm"
+	"github.com/spiffcs/triage/internal/update"
+)
+
+// NewCmdSelfUpdate creates the self-update command.
+func NewCmdSelfUpdate(opts *Options) *cobra.Command {
+	var yes, force, verify bool
+
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update triage to the latest release",
+		Long: `Download the latest triage release from GitHub, verify it against the
+published checksums, and replace the running binary in place.
+
+Binaries installed with Homebrew or scoop are left alone; update those with
+your package manager. Set self_update.enabled: false in the config file to
+disable this command entirely. Use --verify-signature (or
+self_update.verify_signature: true) to also verify the checksums with cosign.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSelfUpdate(cmd, opts, yes, force, verify)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+	cmd.Flags().BoolVar(&force, "force", false, "Update even when running a development build")
+	cmd.Flags().BoolVar(&verify, "verify-signature", false, "Verify the release signature with cosign")
+
+	return cmd
+}
+
+func runSelfUpdate(cmd *cobra.Command, opts *Options, yes, force, verify bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	settings := cfg.GetSelfUpdate()
+	if !settings.Enabled {
+		return errors.New("self-update is disabled in the config (self_update.enabled: false)")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating triage binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if pm := update.PackageManager(exe); pm != "" {
+		return fmt.Errorf("triage was installed with %s; update it with: %s", pm, update.UpgradeHint(pm))
+	}
+
+	if update.IsDevelopment(version) && !force {
+		return fmt.Errorf("running a development build (%s); use --force to replace it with the latest release", version)
+	}
+
+	var updaterOpts []update.Option
+	if verify || settings.VerifySignature {
+		updaterOpts = append(updaterOpts, update.WithSignatureVerification(os.Getenv("COSIGN_BINARY")))
+	}
+	updater := update.New(updaterOpts...)
+
+	ctx := cmd.Context()
+	rel, err := updater.Latest(ctx)
+	if err != nil {
+		return err
+	}
+	if !force && !update.IsNewer(version, rel.Version) {
+		fmt.Printf("triage %s is up to date\n", version)
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Printf("[dry-run] would update %s from %s to %s\n", exe, version, rel.Tag)
+		return nil
+	}
+	if !yes && !confirm.Ask(os.Stdin, os.Stderr, fmt.Sprintf("Update triage %s to %s?", version, rel.Tag)) {
+		return errors.New("update cancelled")
+	}
+
+	fmt.Fprintf(os.Stderr, "Downloading triage %s...\n", rel.Tag)
+	binary, err := updater.Download(ctx, rel)
+	if err != nil {
+		return err
+	}
+	if err := update.Replace(exe, binary); err != nil {
+		return fmt.Errorf("replacing %s: %w", exe, err)
+	}
+
+	fmt.Printf("Updated triage to %s\n", rel.Tag)
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/output"
+)
+
+// Snapshot output formats
+const (
+	snapshotANSI  = "ansi"
+	snapshotPlain = "plain"
+)
+
+// NewCmdSnapshot creates the snapshot command.
+func NewCmdSnapshot(opts *Options) *cobra.Command {
+	snapshot := &SnapshotOptions{Format: snapshotANSI, Width: 120, Height: 40}
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Print one frame of the interactive list, for sharing",
+		Long: `Fetch and score your notifications like list, then print the frame the
+interactive list would show first, at a fixed size, and exit. Redirect it
+to a file to share in chat, or view it over a connection too slow or
+restricted for the interactive list.
+
+  triage snapshot --format ansi > out.txt
+
+ansi keeps the list's colors as escape codes, which "less -R" and most
+terminals show; plain drops them.`,
+		Example: `  triage snapshot > triage.ansi
+  triage snapshot --format plain --width 100 --repo org/app`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateSnapshot(snapshot); err != nil {
+				return err
+			}
+			// The frame is the output, so nothing else may draw on stdout
+			noTUI := false
+			opts.TUI = &noTUI
+			opts.Format = string(output.FormatTable)
+			opts.ShowExcluded = false
+			opts.Snapshot = snapshot
+			return runList(cmd, opts)
+		},
+	}
+
+	addListFlags(cmd, opts)
+	// The frame is always the list UI, so list's other outputs don't apply
+	for _, name := range []string{"output", "envelope", "fields", "template", "tui", "show-excluded", "estimate"} {
+		_ = cmd.Flags().MarkHidden(name)
+	}
+
+	cmd.Flags().StringVar(&snapshot.Format, "format", snapshot.Format, "Frame format (ansi, plain)")
+	cmd.Flags().IntVar(&snapshot.Width, "width", snapshot.Width, "Frame width in columns")
+	cmd.Flags().IntVar(&snapshot.Height, "height", snapshot.Height, "Frame height in rows")
+
+	return cmd
+}
+
+// validateSnapshot checks the snapshot flags before anything is fetched.
+func validateSnapshot(s *SnapshotOptions) error {
+	switch s.Format {
+	case snapshotANSI, snapshotPlain:
+	default:
+		return fmt.Errorf("unknown --format %q: want %s or %s", s.Format, snapshotANSI, snapshotPlain)
+	}
+	if s.Width <= 0 || s.Height <= 0 {
+		return fmt.Errorf("--width and --height must be positive")
+	}
+	return nil
+}
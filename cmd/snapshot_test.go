@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestValidateSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    SnapshotOptions
+		wantErr bool
+	}{
+		{"ansi", SnapshotOptions{Format: "ansi", Width: 120, Height: 40}, false},
+		{"plain", SnapshotOptions{Format: "plain", Width: 80, Height: 24}, false},
+		{"unknown format", SnapshotOptions{Format: "html", Width: 120, Height: 40}, true},
+		{"no width", SnapshotOptions{Format: "ansi", Width: 0, Height: 40}, true},
+		{"negative height", SnapshotOptions{Format: "ansi", Width: 120, Height: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSnapshot(&tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/setup"
+	"github.com/spiffcs/triage/internal/snooze"
+	"github.com/spiffcs/triage/internal/triage"
+	triageapi "github.com/spiffcs/triage/pkg/triage"
+)
+
+// standupFetchWindow is how far back notifications are fetched for the
+// Doing and Blocked sections, which aren't limited by --since.
+const standupFetchWindow = 7 * 24 * time.Hour
+
+// NewCmdStandup creates the standup command.
+func NewCmdStandup(opts *Options) *cobra.Command {
+	var since string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "standup",
+		Short: "Summarize what you did, are doing, and are blocked on",
+		Long: `Print a short standup update, ready to paste into chat:
+
+  Did      items you were involved in that closed or merged, and items you
+           marked done, since --since
+  Doing    your top open items: assigned to you, or urgent
+  Blocked  open items assigned to you that are blocked
+
+--since defaults to one day; on a Monday, use --since 3d to cover the
+weekend.`,
+		Example: `  triage standup
+  triage standup --since 3d | pbcopy`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStandup(cmd.Context(), opts, since, limit, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&since, "since", "s", "1d", "Include work finished since (e.g., 1d, 3d)")
+	cmd.Flags().IntVar(&limit, "limit", 5, "Maximum number of items under Doing")
+
+	return cmd
+}
+
+func runStandup(ctx context.Context, opts *Options, since string, limit int, out io.Writer) error {
+	log.Initialize(opts.Verbosity, os.Stderr)
+
+	window, err := duration.ParseDuration(since)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	cfg, resolvedStore, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	token := cfg.GetGitHubToken()
+	if token == "" {
+		return setup.TokenMissing()
+	}
+
+	client, err := triageapi.New(ctx, token, triageapi.WithConfig(cfg), triageapi.WithSince(max(window, standupFetchWindow)),
+		triageapi.WithRefreshIdentity(opts.RefreshIdentity))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	traceCtx, endTrace := startTrace(ctx, "standup")
+	result, err := client.Fetch(traceCtx)
+	if result == nil {
+		endTrace()
+		return err
+	}
+	if result.Unauthorized {
+		endTrace()
+		return errors.Join(triageapi.ErrUnauthorized, err)
+	}
+	if err != nil {
+		log.Warn("some items could not be fetched", "error", err)
+	}
+	if _, err := client.Enrich(traceCtx, result); err != nil {
+		log.Warn("some items could not be enriched", "error", err)
+	}
+	endTrace()
+
+	currentUser := client.CurrentUser()
+	all, _ := result.Merge()
+	activity.Apply(all, currentUser, openActivityStore())
+	snoozeStore := openSnoozeStore()
+	snooze.Apply(all, snoozeStore, time.Now())
+
+	open, _ := triageapi.Filter(triageapi.Prioritize(all, currentUser, cfg, result.Teams...), cfg)
+	open = triageapi.FilterOutArchived(open)
+	var marked map[string]time.Time
+	if resolvedStore != nil {
+		open = triage.FilterResolved(open, resolvedStore)
+		marked = resolvedStore.ResolvedSince(time.Now().Add(-window))
+	}
+	if snoozeStore != nil {
+		open = triage.FilterResolved(open, snoozeStore)
+	}
+
+	standup := triage.BuildStandup(open, all, marked, currentUser, cfg.GetBlockedLabels(), time.Now().Add(-window), limit)
+	return writeStandup(out, standup)
+}
+
+// writeStandup prints the standup as plain text for pasting into chat.
+func writeStandup(w io.Writer, s triage.Standup) error {
+	var did, doing, blocked []string
+	for _, e := range s.Did {
+		did = append(did, standupLine(strings.ToUpper(e.Outcome[:1])+e.Outcome[1:]+" "+e.Key, e.Title))
+	}
+	for _, item := range s.Doing {
+		doing = append(doing, standupLine(item.Key(), item.Subject.Title))
+	}
+	for _, item := range s.Blocked {
+		line := standupLine(item.Key(), item.Subject.Title)
+		if len(item.BlockedBy) > 0 {
+			line += fmt.Sprintf(" (blocked by %s)", strings.Join(item.BlockedBy, ", "))
+		}
+		blocked = append(blocked, line)
+	}
+
+	for i, section := range []struct {
+		name  string
+		lines []string
+	}{
+		{"Did", did},
+		{"Doing", doing},
+		{"Blocked", blocked},
+	} {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s:\n", section.name); err != nil {
+			return err
+		}
+		if len(section.lines) == 0 {
+			section.lines = []string{"Nothing"}
+		}
+		for _, line := range section.lines {
+			if _, err := fmt.Fprintf(w, "- %s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// standupLine joins an item's label and title, leaving the title out when
+// it isn't known.
+func standupLine(label, title string) string {
+	if title == "" {
+		return label
+	}
+	return label + ": " + format.Sanitize(title)
+}
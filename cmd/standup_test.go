@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestWriteStandup(t *testing.T) {
+	item := func(number int, title string, blockedBy ...string) triage.PrioritizedItem {
+		return triage.PrioritizedItem{Item: model.Item{
+			Number:     number,
+			Repository: model.Repository{FullName: "acme/api"},
+			Subject:    model.Subject{Title: title},
+			BlockedBy:  blockedBy,
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		standup triage.Standup
+		want    string
+	}{
+		{
+			name: "empty",
+			want: "Did:\n- Nothing\n\nDoing:\n- Nothing\n\nBlocked:\n- Nothing\n",
+		},
+		{
+			name: "all sections",
+			standup: triage.Standup{
+				Did: []triage.StandupEntry{
+					{Key: "acme/api#1", Title: "Add standup", Outcome: "merged", At: time.Now()},
+					{Key: "acme/api#9", Outcome: "done", At: time.Now()},
+				},
+				Doing:   []triage.PrioritizedItem{item(2, "Fix flaky test")},
+				Blocked: []triage.PrioritizedItem{item(3, "Ship v2", "acme/api#4", "acme/web#5")},
+			},
+			want: "Did:\n- Merged acme/api#1: Add standup\n- Done acme/api#9\n\n" +
+				"Doing:\n- acme/api#2: Fix flaky test\n\n" +
+				"Blocked:\n- acme/api#3: Ship v2 (blocked by acme/api#4, acme/web#5)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := writeStandup(&out, tt.standup); err != nil {
+				t.Fatal(err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("writeStandup() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spiffcs/triage/internal/log"
+)
+
+// startup times this process's startup, from when the package loaded to
+// when fetching begins.
+var startup = newStartupTimer(time.Now())
+
+// startupTimer records how long each step of startup takes, for the
+// breakdown logged at -vv. Steps that run concurrently overlap, so they can
+// add up to more than the total.
+type startupTimer struct {
+	start time.Time
+
+	mu    sync.Mutex
+	steps []startupStep
+}
+
+type startupStep struct {
+	name string
+	took time.Duration
+}
+
+func newStartupTimer(start time.Time) *startupTimer {
+	return &startupTimer{start: start}
+}
+
+// track starts timing step and returns the func that ends it.
+func (t *startupTimer) track(step string) func() {
+	began := time.Now()
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.steps = append(t.steps, startupStep{name: step, took: time.Since(began)})
+	}
+}
+
+// args returns the time from the start to now and each step's duration, in
+// the order they finished, as log key-value pairs.
+func (t *startupTimer) args(now time.Time) []any {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	args := []any{"total", now.Sub(t.start).Round(time.Millisecond)}
+	for _, s := range t.steps {
+		args = append(args, s.name, s.took.Round(time.Millisecond))
+	}
+	return args
+}
+
+// done logs the startup breakdown.
+func (t *startupTimer) done() {
+	log.Debug("startup timing", t.args(time.Now())...)
+}
+
+// inBackground runs open in its own goroutine and returns a func that waits
+// for its result, so startup work that doesn't depend on anything else
+// overlaps with the work that does.
+func inBackground[T any](open func() T) func() T {
+	done := make(chan struct{})
+	var v T
+	go func() {
+		defer close(done)
+		v = open()
+	}()
+	return func() T {
+		<-done
+		return v
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartupTimerArgs(t *testing.T) {
+	start := time.Now().Add(-250 * time.Millisecond)
+	timer := newStartupTimer(start)
+	timer.track("config")()
+	timer.track("auth")()
+
+	args := timer.args(start.Add(250 * time.Millisecond))
+	if len(args) != 6 {
+		t.Fatalf("args() = %v, want total and two steps", args)
+	}
+	if args[0] != "total" || args[1] != 250*time.Millisecond {
+		t.Errorf("args() total = %v %v, want total 250ms", args[0], args[1])
+	}
+	if args[2] != "config" || args[4] != "auth" {
+		t.Errorf("args() steps = %v, %v; want config then auth", args[2], args[4])
+	}
+}
+
+func TestInBackground(t *testing.T) {
+	release := make(chan struct{})
+	wait := inBackground(func() int {
+		<-release
+		return 42
+	})
+
+	// The work runs while the caller does something else
+	close(release)
+	if got := wait(); got != 42 {
+		t.Errorf("wait() = %d, want 42", got)
+	}
+	if got := wait(); got != 42 {
+		t.Errorf("second wait() = %d, want the same result", got)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/output"
+	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/service"
+	"github.com/spiffcs/triage/internal/snooze"
+	"github.com/spiffcs/triage/internal/triage"
+	triageapi "github.com/spiffcs/triage/pkg/triage"
+)
+
+// itemStream writes JSON Lines while enrichment is still running. Each
+// chunk of enriched items is merged, scored, and filtered as the full list
+// would be, then written straight away, so lines come out in priority order
+// within a chunk but not across the whole run. Every item is written once;
+// finish writes the ones no chunk did, such as assigned issues, which need
+// no enriching.
+type itemStream struct {
+	engine        *triage.Engine
+	cfg           *config.Config
+	currentUser   string
+	opts          *Options
+	filters       listFilters
+	resolvedStore *resolved.Store
+	activityStore *activity.Store
+	snoozeStore   *snooze.Store
+	formatter     output.Formatter
+	w             io.Writer
+
+	written map[string]bool
+	err     error // first write error; nothing more is written after it
+}
+
+// newItemStream creates an itemStream writing to w.
+func newItemStream(cfg *config.Config, currentUser string, teams []string, opts *Options, filters listFilters, resolvedStore *resolved.Store, activityStore *activity.Store, snoozeStore *snooze.Store, w io.Writer) *itemStream {
+	weights := cfg.GetScoreWeights()
+	return &itemStream{
+		engine:        triage.NewEngine(currentUser, weights, cfg.GetQuickWinLabels(), triage.WithTeams(teams)),
+		cfg:           cfg,
+		currentUser:   currentUser,
+		opts:          opts,
+		filters:       filters,
+		resolvedStore: resolvedStore,
+		activityStore: activityStore,
+		snoozeStore:   snoozeStore,
+		formatter:     output.NewFormatterWithWeights(output.FormatJSONL, weights, currentUser, output.WithFields(output.ParseFields(opts.Fields))),
+		w:             w,
+		written:       make(map[string]bool),
+	}
+}
+
+// writeChunk writes the items finished in one chunk of enrichment: the
+// notifications, review requests, and authored PRs, in the order
+// runEnrichment passes them.
+func (s *itemStream) writeChunk(done [][]model.Item) {
+	chunk := &service.FetchResult{Notifications: done[0], ReviewPRs: done[1], AuthoredPRs: done[2]}
+	merged, _ := chunk.Merge()
+	if len(merged) == 0 {
+		return
+	}
+	annotateItems(merged, s.cfg, s.currentUser, s.opts, s.activityStore, s.snoozeStore)
+
+	// Items that couldn't be enriched are left to finish, which keeps
+	// them if the run was cut short
+	items, _ := triageapi.Filter(s.engine.Prioritize(merged), s.cfg)
+	items, _ = s.filters.apply(items)
+	s.write(items)
+}
+
+// finish writes the items of the final list that no chunk wrote, and
+// returns the first error writing any of them.
+func (s *itemStream) finish(items []triage.PrioritizedItem) error {
+	s.write(items)
+	return s.err
+}
+
+// write writes the items not yet written, leaving out ones marked done or
+// snoozed.
+func (s *itemStream) write(items []triage.PrioritizedItem) {
+	if s.err != nil {
+		return
+	}
+	items, _ = hideItems(items, s.resolvedStore, s.snoozeStore)
+
+	var fresh []triage.PrioritizedItem
+	for _, item := range items {
+		if key := item.Key(); !s.written[key] {
+			s.written[key] = true
+			fresh = append(fresh, item)
+		}
+	}
+	if len(fresh) > 0 {
+		s.err = s.formatter.Format(fresh, s.w)
+	}
+}
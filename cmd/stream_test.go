@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestItemStream(t *testing.T) {
+	item := func(number int, details bool) model.Item {
+		it := model.Item{
+			ID:         fmt.Sprintf("thread-%d", number),
+			Number:     number,
+			Reason:     model.ReasonMention,
+			State:      model.StateOpen,
+			UpdatedAt:  time.Now(),
+			Repository: model.Repository{FullName: "acme/api"},
+			Subject:    model.Subject{Type: model.SubjectIssue, Title: "item"},
+		}
+		if details {
+			it.Details = &model.IssueDetails{}
+		}
+		return it
+	}
+
+	var buf bytes.Buffer
+	cfg := &config.Config{}
+	opts := &Options{RawAge: true, Fields: "number"}
+	s := newItemStream(cfg, "me", nil, opts, listFilters{includeArchived: true}, nil, nil, nil, &buf)
+
+	// #2 wasn't enriched, so it waits for the final list
+	s.writeChunk([][]model.Item{{item(1, true), item(2, false)}, nil, nil})
+	if got := buf.String(); got != `{"number":1}`+"\n" {
+		t.Fatalf("after the chunk wrote %q, want only #1", got)
+	}
+
+	final := triage.NewEngine("me", cfg.GetScoreWeights(), nil).Prioritize([]model.Item{item(1, true), item(2, false), item(3, true)})
+	if err := s.finish(final); err != nil {
+		t.Fatalf("finish() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != `{"number":1}` {
+		t.Errorf("wrote %q, want #1 once, then #2 and #3", lines)
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/telemetry"
+)
+
+// traceFlushTimeout bounds how long exiting waits for spans to be exported.
+const traceFlushTimeout = 5 * time.Second
+
+// startTrace begins the root span of a command, exporting it when an OTLP
+// endpoint is configured in the environment. The returned function ends
+// the span and flushes it; only the first call has any effect, so it can
+// be called once the pipeline finishes and deferred as well.
+func startTrace(ctx context.Context, command string) (context.Context, func()) {
+	shutdown, err := telemetry.Setup(ctx, version)
+	if err != nil {
+		log.Warn("could not set up tracing", "error", err)
+		return ctx, func() {}
+	}
+	ctx, span := telemetry.Start(ctx, "triage "+command)
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			span.End()
+			flushCtx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+			defer cancel()
+			if err := shutdown(flushCtx); err != nil {
+				log.Warn("could not export traces", "error", err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/spiffcs/triage/internal/output"
 	"github.com/spiffcs/triage/internal/tui"
 )
 
@@ -56,6 +57,15 @@ func shouldUseTUI(opts *Options) bool {
 	if opts.Verbosity > 0 {
 		return false
 	}
+	// Plain output is for screen readers, which can't follow redrawn
+	// progress or an interactive list
+	if output.Format(opts.Format) == output.FormatPlain {
+		return false
+	}
+	// JSON Lines go to stdout while enrichment is still running
+	if output.Format(opts.Format) == output.FormatJSONL {
+		return false
+	}
 	if opts.TUI != nil {
 		return *opts.TUI
 	}
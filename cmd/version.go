@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/spiffcs/triage/internal/update"
 )
 
 // Version information, set via ldflags
@@ -28,13 +31,58 @@ func SetVersionInfo(v, c, d string) {
 
 // NewCmdVersion creates the version command.
 func NewCmdVersion() *cobra.Command {
-	return &cobra.Command{
+	var check bool
+
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print version information",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Printf("triage %s\n", version)
 			fmt.Printf("  commit: %s\n", commit)
 			fmt.Printf("  built:  %s\n", date)
+			if !check {
+				return nil
+			}
+			return runVersionCheck(cmd)
 		},
 	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "Check GitHub for a newer release")
+
+	return cmd
+}
+
+func runVersionCheck(cmd *cobra.Command) error {
+	rel, err := update.New().Latest(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	switch {
+	case update.IsDevelopment(version):
+		fmt.Printf("Development build; latest release is %s\n", rel.Tag)
+	case update.IsNewer(version, rel.Version):
+		fmt.Printf("A newer release is available: %s\n", rel.Tag)
+		fmt.Printf("  %s\n", rel.URL)
+		fmt.Printf("Update with: %s\n", upgradeCommand())
+	default:
+		fmt.Println("You are running the latest release")
+	}
+	return nil
+}
+
+// upgradeCommand returns how this binary should be updated: through its
+// package manager when it has one, otherwise with self-update.
+func upgradeCommand() string {
+	exe, err := os.Executable()
+	if err == nil {
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		if pm := update.PackageManager(exe); pm != "" {
+			return update.UpgradeHint(pm)
+		}
+	}
+	return "triage self-update"
 }
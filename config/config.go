@@ -12,21 +12,65 @@ import (
 
 // Config represents the application configuration
 type Config struct {
+	Version                  int       `yaml:"version,omitempty"` // File format version; see Version
 	DefaultFormat            string    `yaml:"default_format,omitempty"`
+	Locale                   string    `yaml:"locale,omitempty"` // e.g. "de"; empty uses LANG
 	ExcludeRepos             []string  `yaml:"exclude_repos,omitempty"`
 	ExcludeAuthors           []string  `yaml:"exclude_authors,omitempty"`
 	DependencyAuthors        []string  `yaml:"dependency_authors,omitempty"`
+	BotAuthors               []string  `yaml:"bot_authors,omitempty"`
 	QuickWinLabels           []string  `yaml:"quick_win_labels,omitempty"`
 	BlockedLabels            *[]string `yaml:"blocked_labels,omitempty"`
 	IncludeReadNotifications bool      `yaml:"include_read_notifications,omitempty"`
+	// SyncReadOnDone marks an item's notification read on GitHub when it
+	// is marked done with d in the TUI
+	SyncReadOnDone bool `yaml:"sync_read_on_done,omitempty"`
+
+	// LabelScores adds to the score of items carrying a label, e.g.
+	// security: 40 or chore: -20
+	LabelScores map[string]int `yaml:"label_scores,omitempty"`
+
+	// ProjectColumnScores adds to the score of items in a column of a
+	// project board, by its Status, e.g. "In Review": 30. Setting it
+	// fetches project boards, which needs the read:project scope.
+	ProjectColumnScores map[string]int `yaml:"project_column_scores,omitempty"`
+
+	// SeverityLabels sets the lowest priority of items carrying a label,
+	// e.g. P0: urgent, applied after scoring
+	SeverityLabels map[string]string `yaml:"severity_labels,omitempty"`
+
+	// Workspaces name groups of repos, e.g. platform: [org/a, org/b], that
+	// --repo, exclude_repos, and orphaned.repos accept as "@platform"
+	Workspaces map[string][]string `yaml:"workspaces,omitempty"`
+
+	// RepoOverrides replaces scoring weights for the items of one repo, e.g.
+	// org/critical-repo: {base_scores: {mention: 200}}
+	RepoOverrides map[string]*RepoOverride `yaml:"repo_overrides,omitempty"`
+
+	// IssueForms picks the issue form fields kept, and the points their
+	// answers add, per repo, e.g. org/app: {fields: [Severity]}
+	IssueForms map[string]*IssueFormConfig `yaml:"issue_forms,omitempty"`
 
 	// Top-level config sections
-	BaseScores *BaseScoreOverrides `yaml:"base_scores,omitempty"`
-	Scoring    *ScoringOverrides   `yaml:"scoring,omitempty"`
-	PR         *PROverrides        `yaml:"pr,omitempty"`
-	Urgency    *UrgencyOverrides   `yaml:"urgency,omitempty"`
-	Orphaned   *OrphanedConfig     `yaml:"orphaned,omitempty"`
-	UI         *UIPreferences      `yaml:"ui,omitempty"`
+	BaseScores    *BaseScoreOverrides     `yaml:"base_scores,omitempty"`
+	Scoring       *ScoringOverrides       `yaml:"scoring,omitempty"`
+	PR            *PROverrides            `yaml:"pr,omitempty"`
+	Urgency       *UrgencyOverrides       `yaml:"urgency,omitempty"`
+	Orphaned      *OrphanedConfig         `yaml:"orphaned,omitempty"`
+	Upstream      []UpstreamConfig        `yaml:"upstream,omitempty"`
+	Confirmations *ConfirmationOverrides  `yaml:"confirmations,omitempty"`
+	ReviewSLO     *ReviewSLOOverrides     `yaml:"review_slo,omitempty"`
+	Accessibility *AccessibilityOverrides `yaml:"accessibility,omitempty"`
+	Truncation    *TruncationOverrides    `yaml:"truncation,omitempty"`
+	UI            *UIPreferences          `yaml:"ui,omitempty"`
+	SelfUpdate    *SelfUpdateOverrides    `yaml:"self_update,omitempty"`
+	Email         *EmailOverrides         `yaml:"email,omitempty"`
+	Notifiers     *NotifierOverrides      `yaml:"notifiers,omitempty"`
+	Notifications *NotificationOverrides  `yaml:"notifications,omitempty"`
+	Release       *ReleaseOverrides       `yaml:"release,omitempty"`
+	Delegation    *DelegationOverrides    `yaml:"delegation,omitempty"`
+	HTTP          *HTTPOverrides          `yaml:"http,omitempty"`
+	Cache         *CacheOverrides         `yaml:"cache,omitempty"`
 }
 
 // UIPreferences stores user interface preferences like sort settings
@@ -41,6 +85,40 @@ type UIPreferences struct {
 	BlockedSortDesc      *bool  `yaml:"blocked_sort_desc,omitempty"`
 	DependabotSortColumn string `yaml:"dependabot_sort_column,omitempty"`
 	DependabotSortDesc   *bool  `yaml:"dependabot_sort_desc,omitempty"`
+	LaterSortColumn      string `yaml:"later_sort_column,omitempty"`
+	LaterSortDesc        *bool  `yaml:"later_sort_desc,omitempty"`
+	// ClusterRelated groups related items into one expandable row
+	ClusterRelated *bool `yaml:"cluster_related,omitempty"`
+	// PinResurfaced keeps resolved items that came back since the last run
+	// at the top of their pane
+	PinResurfaced *bool `yaml:"pin_resurfaced,omitempty"`
+	// MinimalRedraw turns off animations and redraws the screen less
+	// often, for slow connections such as SSH
+	MinimalRedraw *bool `yaml:"minimal_redraw,omitempty"`
+}
+
+// GetMinimalRedraw reports whether the TUI should keep redraws to a
+// minimum; it is off unless configured.
+func (c *Config) GetMinimalRedraw() bool {
+	return c.UI != nil && c.UI.MinimalRedraw != nil && *c.UI.MinimalRedraw
+}
+
+// RepoOverride holds the scoring sections that can be set for one
+// repository. Anything left unset keeps the value from the top level.
+type RepoOverride struct {
+	BaseScores *BaseScoreOverrides `yaml:"base_scores,omitempty"`
+	Scoring    *ScoringOverrides   `yaml:"scoring,omitempty"`
+	PR         *PROverrides        `yaml:"pr,omitempty"`
+}
+
+// IssueFormConfig configures the issue form fields of one repository.
+type IssueFormConfig struct {
+	// Fields are the labels of the form fields kept on the repo's items,
+	// for --fields and --form-field
+	Fields []string `yaml:"fields,omitempty"`
+	// Scores adds points to items by field and answer, e.g.
+	// Severity: {critical: 50, low: -10}
+	Scores map[string]map[string]int `yaml:"scores,omitempty"`
 }
 
 // OrphanedConfig configures orphaned contribution detection
@@ -49,33 +127,678 @@ type OrphanedConfig struct {
 	StaleDays                 int      `yaml:"stale_days,omitempty"`                  // Default: 7
 	ConsecutiveAuthorComments int      `yaml:"consecutive_author_comments,omitempty"` // Default: 2
 	MaxItemsPerRepo           int      `yaml:"max_items_per_repo,omitempty"`          // Default: 100
+
+	// Rules picks the conditions that make a contribution orphaned, and
+	// RepoRules changes them for one repo, e.g. org/app: {no_assignee: true}
+	Rules     *OrphanedRuleOverrides            `yaml:"rules,omitempty"`
+	RepoRules map[string]*OrphanedRuleOverrides `yaml:"repo_rules,omitempty"`
+	// TriageLabels mark a contribution as triaged for the no_triage_label rule
+	TriageLabels []string `yaml:"triage_labels,omitempty"`
+	// Team lists logins counted as the team besides the repo's members,
+	// owners, and collaborators
+	Team []string `yaml:"team,omitempty"`
+	// Teams are GitHub teams, as "org/team-slug", whose members count as
+	// the team too; membership is looked up once a day
+	Teams []string `yaml:"teams,omitempty"`
+}
+
+// OrphanedRuleOverrides toggles the conditions that make a contribution
+// orphaned. A contribution is orphaned when it meets every enabled one.
+type OrphanedRuleOverrides struct {
+	ExternalAuthor *bool `yaml:"external_author,omitempty"`  // Author is outside the team
+	NoTeamResponse *bool `yaml:"no_team_response,omitempty"` // Team quiet for stale_days, or author comments unanswered
+	NoAssignee     *bool `yaml:"no_assignee,omitempty"`      // Nobody is assigned
+	NoTriageLabel  *bool `yaml:"no_triage_label,omitempty"`  // None of triage_labels, or no labels when unset
+}
+
+// OrphanedRuleSettings holds the resolved orphaned contribution conditions.
+type OrphanedRuleSettings struct {
+	ExternalAuthor bool
+	NoTeamResponse bool
+	NoAssignee     bool
+	NoTriageLabel  bool
+}
+
+// DefaultOrphanedRuleSettings returns the built-in conditions: external
+// contributions the team hasn't responded to.
+func DefaultOrphanedRuleSettings() OrphanedRuleSettings {
+	return OrphanedRuleSettings{
+		ExternalAuthor: true,
+		NoTeamResponse: true,
+	}
+}
+
+// GetOrphanedRules returns the orphaned contribution conditions for repo
+// ("owner/repo"): its repo_rules over the orphaned rules over the defaults.
+func (c *Config) GetOrphanedRules(repo string) OrphanedRuleSettings {
+	settings := DefaultOrphanedRuleSettings()
+	if c.Orphaned == nil {
+		return settings
+	}
+	settings.apply(c.Orphaned.Rules)
+	for name, rules := range c.Orphaned.RepoRules {
+		if strings.EqualFold(name, repo) {
+			settings.apply(rules)
+		}
+	}
+	return settings
+}
+
+// apply sets the conditions o configures.
+func (s *OrphanedRuleSettings) apply(o *OrphanedRuleOverrides) {
+	if o == nil {
+		return
+	}
+	if o.ExternalAuthor != nil {
+		s.ExternalAuthor = *o.ExternalAuthor
+	}
+	if o.NoTeamResponse != nil {
+		s.NoTeamResponse = *o.NoTeamResponse
+	}
+	if o.NoAssignee != nil {
+		s.NoAssignee = *o.NoAssignee
+	}
+	if o.NoTriageLabel != nil {
+		s.NoTriageLabel = *o.NoTriageLabel
+	}
+}
+
+// UpstreamConfig declares that work in one repository is waiting on
+// another. Set one of Release, After, or Number; when the wait is over the
+// work is raised in the list.
+type UpstreamConfig struct {
+	Repo    string `yaml:"repo"`              // Repository being waited on, e.g. acme/lib
+	Blocks  string `yaml:"blocks"`            // Your repository that is waiting, e.g. acme/app
+	Release string `yaml:"release,omitempty"` // A specific release tag
+	After   string `yaml:"after,omitempty"`   // Any release after this tag
+	Number  int    `yaml:"number,omitempty"`  // An issue or PR closing
+}
+
+// validate reports an upstream entry that doesn't set exactly one of
+// release, after, and number, since it's unclear what it waits on.
+func (u UpstreamConfig) validate() error {
+	set := 0
+	for _, ok := range []bool{u.Release != "", u.After != "", u.Number > 0} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("upstream %s: set exactly one of release, after, or number", u.Repo)
+	}
+	return nil
+}
+
+// ConfirmationOverrides controls which actions ask for confirmation before
+// running. Each value is "always", "never", or a threshold such as ">10"
+// (also written "when >10 items") that prompts only when the action affects
+// more than that many items.
+type ConfirmationOverrides struct {
+	MarkReadBulk *string `yaml:"mark_read_bulk,omitempty"`
+	Comment      *string `yaml:"comment,omitempty"`
+	Delegate     *string `yaml:"delegate,omitempty"`
+	LargeFetch   *string `yaml:"large_fetch,omitempty"`
+}
+
+// ConfirmationSettings holds the resolved confirmation policy for each action.
+type ConfirmationSettings struct {
+	MarkReadBulk string
+	Comment      string
+	Delegate     string
+	LargeFetch   string
+}
+
+// DefaultConfirmationSettings returns the built-in confirmation policies.
+func DefaultConfirmationSettings() ConfirmationSettings {
+	return ConfirmationSettings{
+		MarkReadBulk: ">10",
+		Comment:      "never",
+		Delegate:     "never",
+		LargeFetch:   ">2000",
+	}
+}
+
+// GetConfirmations returns the confirmation policies, using defaults for
+// any action that is not configured.
+func (c *Config) GetConfirmations() ConfirmationSettings {
+	settings := DefaultConfirmationSettings()
+	if c.Confirmations == nil {
+		return settings
+	}
+	if c.Confirmations.MarkReadBulk != nil {
+		settings.MarkReadBulk = *c.Confirmations.MarkReadBulk
+	}
+	if c.Confirmations.Comment != nil {
+		settings.Comment = *c.Confirmations.Comment
+	}
+	if c.Confirmations.Delegate != nil {
+		settings.Delegate = *c.Confirmations.Delegate
+	}
+	if c.Confirmations.LargeFetch != nil {
+		settings.LargeFetch = *c.Confirmations.LargeFetch
+	}
+	return settings
+}
+
+// ReviewSLOOverrides sets a personal target for how soon you review PRs
+// after being asked. Durations use the same units as --since, e.g. "24h"
+// or "2d"; a target of "off" disables SLO tracking.
+type ReviewSLOOverrides struct {
+	Target *string `yaml:"target,omitempty"`
+	// Window is how far back response times count towards p50/p90.
+	Window *string `yaml:"window,omitempty"`
+}
+
+// ReviewSLOSettings holds the resolved review SLO settings.
+type ReviewSLOSettings struct {
+	Target string
+	Window string
+}
+
+// DefaultReviewSLOSettings returns the built-in review SLO: a review within
+// a day, measured over the last 30 days.
+func DefaultReviewSLOSettings() ReviewSLOSettings {
+	return ReviewSLOSettings{
+		Target: "24h",
+		Window: "30d",
+	}
+}
+
+// GetReviewSLO returns the review SLO settings, using defaults for any
+// value that is not configured.
+func (c *Config) GetReviewSLO() ReviewSLOSettings {
+	settings := DefaultReviewSLOSettings()
+	if c.ReviewSLO == nil {
+		return settings
+	}
+	if c.ReviewSLO.Target != nil {
+		settings.Target = *c.ReviewSLO.Target
+	}
+	if c.ReviewSLO.Window != nil {
+		settings.Window = *c.ReviewSLO.Window
+	}
+	return settings
+}
+
+// AccessibilityOverrides adjusts how triage presents information for
+// users who can't rely on color alone.
+type AccessibilityOverrides struct {
+	// StatusMarkers adds letter and shape markers to priorities and CI
+	// status, which are otherwise told apart only by color.
+	StatusMarkers *bool `yaml:"status_markers,omitempty"`
+}
+
+// AccessibilitySettings holds the resolved accessibility settings.
+type AccessibilitySettings struct {
+	StatusMarkers bool
+}
+
+// GetAccessibility returns the accessibility settings; everything is off
+// unless configured.
+func (c *Config) GetAccessibility() AccessibilitySettings {
+	var settings AccessibilitySettings
+	if c.Accessibility == nil {
+		return settings
+	}
+	if c.Accessibility.StatusMarkers != nil {
+		settings.StatusMarkers = *c.Accessibility.StatusMarkers
+	}
+	return settings
+}
+
+// TruncationOverrides chooses how each column shortens values that are too
+// wide: "end" keeps the start, "middle" keeps both ends.
+type TruncationOverrides struct {
+	Repository *string `yaml:"repository,omitempty"`
+	Author     *string `yaml:"author,omitempty"`
+}
+
+// TruncationSettings holds the resolved truncation mode for each column.
+type TruncationSettings struct {
+	Repository string
+	Author     string
+}
+
+// DefaultTruncationSettings returns the built-in truncation modes. Both
+// columns truncate in the middle, since the repository name and the
+// "[bot]" suffix of bot logins are at the end.
+func DefaultTruncationSettings() TruncationSettings {
+	return TruncationSettings{
+		Repository: "middle",
+		Author:     "middle",
+	}
+}
+
+// GetTruncation returns the truncation modes, using defaults for any column
+// that is not configured.
+func (c *Config) GetTruncation() TruncationSettings {
+	settings := DefaultTruncationSettings()
+	if c.Truncation == nil {
+		return settings
+	}
+	if c.Truncation.Repository != nil {
+		settings.Repository = *c.Truncation.Repository
+	}
+	if c.Truncation.Author != nil {
+		settings.Author = *c.Truncation.Author
+	}
+	return settings
+}
+
+// SelfUpdateOverrides controls the self-update command. Users who install
+// triage through a package manager, or whose binaries are managed centrally,
+// can disable it.
+type SelfUpdateOverrides struct {
+	Enabled         *bool `yaml:"enabled,omitempty"`
+	VerifySignature *bool `yaml:"verify_signature,omitempty"`
+}
+
+// SelfUpdateSettings holds the resolved self-update settings.
+type SelfUpdateSettings struct {
+	Enabled         bool
+	VerifySignature bool
+}
+
+// DefaultSelfUpdateSettings returns the built-in self-update settings.
+// Signature verification is off by default because it requires cosign.
+func DefaultSelfUpdateSettings() SelfUpdateSettings {
+	return SelfUpdateSettings{
+		Enabled:         true,
+		VerifySignature: false,
+	}
+}
+
+// GetSelfUpdate returns the self-update settings, using defaults for any
+// value that is not configured.
+func (c *Config) GetSelfUpdate() SelfUpdateSettings {
+	settings := DefaultSelfUpdateSettings()
+	if c.SelfUpdate == nil {
+		return settings
+	}
+	if c.SelfUpdate.Enabled != nil {
+		settings.Enabled = *c.SelfUpdate.Enabled
+	}
+	if c.SelfUpdate.VerifySignature != nil {
+		settings.VerifySignature = *c.SelfUpdate.VerifySignature
+	}
+	return settings
+}
+
+// EmailOverrides configures how the email command delivers its digest.
+// The SMTP password is never stored in the config; it is read from the
+// environment variable named by PasswordEnv.
+type EmailOverrides struct {
+	From        *string   `yaml:"from,omitempty"`
+	To          *[]string `yaml:"to,omitempty"`
+	SMTPHost    *string   `yaml:"smtp_host,omitempty"`
+	SMTPPort    *int      `yaml:"smtp_port,omitempty"`
+	Username    *string   `yaml:"username,omitempty"`
+	PasswordEnv *string   `yaml:"password_env,omitempty"`
+	// Sendmail is the path of a sendmail-compatible binary. When set, it is
+	// used instead of SMTP.
+	Sendmail *string `yaml:"sendmail,omitempty"`
+}
+
+// EmailSettings holds the resolved email settings.
+type EmailSettings struct {
+	From        string
+	To          []string
+	SMTPHost    string
+	SMTPPort    int
+	Username    string
+	PasswordEnv string
+	Sendmail    string
+}
+
+// DefaultEmailSettings returns the built-in email settings. Nothing can be
+// sent until a sender and either an SMTP host or sendmail are configured.
+func DefaultEmailSettings() EmailSettings {
+	return EmailSettings{
+		SMTPPort:    587,
+		PasswordEnv: "TRIAGE_SMTP_PASSWORD",
+	}
+}
+
+// GetEmail returns the email settings, using defaults for any value that
+// is not configured.
+func (c *Config) GetEmail() EmailSettings {
+	settings := DefaultEmailSettings()
+	if c.Email == nil {
+		return settings
+	}
+	if c.Email.From != nil {
+		settings.From = *c.Email.From
+	}
+	if c.Email.To != nil {
+		settings.To = *c.Email.To
+	}
+	if c.Email.SMTPHost != nil {
+		settings.SMTPHost = *c.Email.SMTPHost
+	}
+	if c.Email.SMTPPort != nil {
+		settings.SMTPPort = *c.Email.SMTPPort
+	}
+	if c.Email.Username != nil {
+		settings.Username = *c.Email.Username
+	}
+	if c.Email.PasswordEnv != nil {
+		settings.PasswordEnv = *c.Email.PasswordEnv
+	}
+	if c.Email.Sendmail != nil {
+		settings.Sendmail = *c.Email.Sendmail
+	}
+	return settings
+}
+
+// HTTPOverrides controls timeouts and retries for requests to GitHub.
+// Durations use Go syntax, such as 30s or 500ms.
+type HTTPOverrides struct {
+	Timeout         *string `yaml:"timeout,omitempty"`
+	MaxRetries      *int    `yaml:"max_retries,omitempty"`
+	RetryBackoff    *string `yaml:"retry_backoff,omitempty"`
+	RetryMaxBackoff *string `yaml:"retry_max_backoff,omitempty"`
+}
+
+// HTTPSettings holds the resolved HTTP settings.
+type HTTPSettings struct {
+	// Timeout bounds each attempt, not the request with its retries.
+	Timeout    string
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry; each later retry
+	// waits twice as long, up to RetryMaxBackoff.
+	RetryBackoff    string
+	RetryMaxBackoff string
+}
+
+// DefaultHTTPSettings returns the built-in HTTP settings.
+func DefaultHTTPSettings() HTTPSettings {
+	return HTTPSettings{
+		Timeout:         "30s",
+		MaxRetries:      2,
+		RetryBackoff:    "1s",
+		RetryMaxBackoff: "10s",
+	}
+}
+
+// GetHTTP returns the HTTP settings, using defaults for any value that is
+// not configured.
+func (c *Config) GetHTTP() HTTPSettings {
+	settings := DefaultHTTPSettings()
+	if c.HTTP == nil {
+		return settings
+	}
+	if c.HTTP.Timeout != nil {
+		settings.Timeout = *c.HTTP.Timeout
+	}
+	if c.HTTP.MaxRetries != nil {
+		settings.MaxRetries = *c.HTTP.MaxRetries
+	}
+	if c.HTTP.RetryBackoff != nil {
+		settings.RetryBackoff = *c.HTTP.RetryBackoff
+	}
+	if c.HTTP.RetryMaxBackoff != nil {
+		settings.RetryMaxBackoff = *c.HTTP.RetryMaxBackoff
+	}
+	return settings
+}
+
+// CacheOverrides controls the local cache of item details and lists.
+type CacheOverrides struct {
+	MaxSizeMB *int `yaml:"max_size_mb,omitempty"`
+}
+
+// DefaultCacheMaxSizeMB is the built-in limit on cached item details.
+const DefaultCacheMaxSizeMB = 256
+
+// GetCacheMaxSizeMB returns the most megabytes of item details the cache
+// keeps before evicting the least recently used. Values below 1, which would
+// evict everything, use the default.
+func (c *Config) GetCacheMaxSizeMB() int {
+	if c.Cache == nil || c.Cache.MaxSizeMB == nil || *c.Cache.MaxSizeMB < 1 {
+		return DefaultCacheMaxSizeMB
+	}
+	return *c.Cache.MaxSizeMB
+}
+
+// NotifierOverrides configures the chat services that triage notify send
+// posts the digest to. A service is used when it is configured; a local
+// config replaces a service's global settings as a whole.
+type NotifierOverrides struct {
+	Discord *DiscordNotifier `yaml:"discord,omitempty"`
+	Matrix  *MatrixNotifier  `yaml:"matrix,omitempty"`
+	Teams   *TeamsNotifier   `yaml:"teams,omitempty"`
+}
+
+// DiscordNotifier posts to a Discord channel webhook. Anyone with the
+// URL can post to the channel, so it is best kept in the environment
+// variable named by WebhookURLEnv, which is used when set.
+type DiscordNotifier struct {
+	WebhookURL    string `yaml:"webhook_url,omitempty"`
+	WebhookURLEnv string `yaml:"webhook_url_env,omitempty"`
+}
+
+// MatrixNotifier posts to a Matrix room as the user whose access token is
+// in the environment variable named by TokenEnv.
+type MatrixNotifier struct {
+	Homeserver string `yaml:"homeserver,omitempty"`
+	RoomID     string `yaml:"room_id,omitempty"`
+	TokenEnv   string `yaml:"token_env,omitempty"` // Default: TRIAGE_MATRIX_TOKEN
+}
+
+// TeamsNotifier posts to a Microsoft Teams incoming webhook or workflow.
+// Like Discord's, the URL is a secret; WebhookURLEnv names the environment
+// variable holding it and is used when set.
+type TeamsNotifier struct {
+	WebhookURL    string `yaml:"webhook_url,omitempty"`
+	WebhookURLEnv string `yaml:"webhook_url_env,omitempty"`
+}
+
+// NotifierSettings holds the resolved notifiers; a nil field means the
+// service is not configured.
+type NotifierSettings struct {
+	Discord *DiscordNotifier
+	Matrix  *MatrixNotifier
+	Teams   *TeamsNotifier
+}
+
+// DefaultMatrixTokenEnv is the environment variable holding the Matrix
+// access token when token_env is not set.
+const DefaultMatrixTokenEnv = "TRIAGE_MATRIX_TOKEN"
+
+// GetNotifiers returns the configured notifiers, filling in defaults.
+func (c *Config) GetNotifiers() NotifierSettings {
+	var settings NotifierSettings
+	if c.Notifiers == nil {
+		return settings
+	}
+	settings.Discord = c.Notifiers.Discord
+	settings.Teams = c.Notifiers.Teams
+	if c.Notifiers.Matrix != nil {
+		matrix := *c.Notifiers.Matrix
+		if matrix.TokenEnv == "" {
+			matrix.TokenEnv = DefaultMatrixTokenEnv
+		}
+		settings.Matrix = &matrix
+	}
+	return settings
+}
+
+// redacted stands in for secrets in Redacted.
+const redacted = "REDACTED"
+
+// Redacted returns a copy of c with secrets stored in it, the notifier
+// webhook URLs, replaced, for display. c is not modified.
+func (c *Config) Redacted() *Config {
+	out := *c
+	if c.Notifiers == nil {
+		return &out
+	}
+	notifiers := *c.Notifiers
+	if d := notifiers.Discord; d != nil && d.WebhookURL != "" {
+		discord := *d
+		discord.WebhookURL = redacted
+		notifiers.Discord = &discord
+	}
+	if t := notifiers.Teams; t != nil && t.WebhookURL != "" {
+		teams := *t
+		teams.WebhookURL = redacted
+		notifiers.Teams = &teams
+	}
+	out.Notifiers = &notifiers
+	return &out
+}
+
+// NotificationOverrides configures the desktop notifications triage notify
+// desktop sends when items become pressing.
+type NotificationOverrides struct {
+	// MinPriority is the lowest priority that notifies: urgent, important,
+	// quick-win, or notable
+	MinPriority *string `yaml:"min_priority,omitempty"`
+	// QuietHours is a local time range, such as 22:00-08:00, in which
+	// nothing is sent. Items that cross in it notify once it ends.
+	QuietHours *string `yaml:"quiet_hours,omitempty"`
+}
+
+// NotificationSettings holds the resolved desktop notification settings.
+type NotificationSettings struct {
+	MinPriority string
+	QuietHours  string // empty for none
+}
+
+// DefaultNotificationSettings returns the built-in desktop notification
+// settings: notify for urgent items, at any hour.
+func DefaultNotificationSettings() NotificationSettings {
+	return NotificationSettings{
+		MinPriority: "urgent",
+	}
+}
+
+// GetNotifications returns the desktop notification settings, using
+// defaults for any value that is not configured.
+func (c *Config) GetNotifications() NotificationSettings {
+	settings := DefaultNotificationSettings()
+	if c.Notifications == nil {
+		return settings
+	}
+	if c.Notifications.MinPriority != nil {
+		settings.MinPriority = *c.Notifications.MinPriority
+	}
+	if c.Notifications.QuietHours != nil {
+		settings.QuietHours = *c.Notifications.QuietHours
+	}
+	return settings
+}
+
+// ReleaseOverrides configures triage release.
+type ReleaseOverrides struct {
+	// BackportLabels mark merged PRs that need a backport to release
+	// branches. Labels naming the branch, such as "backport release/1.2",
+	// always do.
+	BackportLabels *[]string `yaml:"backport_labels,omitempty"`
+}
+
+// ReleaseSettings holds the resolved release settings.
+type ReleaseSettings struct {
+	BackportLabels []string
+}
+
+// DefaultReleaseSettings returns the built-in release settings.
+func DefaultReleaseSettings() ReleaseSettings {
+	return ReleaseSettings{
+		BackportLabels: []string{"backport", "needs-backport"},
+	}
+}
+
+// GetRelease returns the release settings, using defaults for any value
+// that is not configured.
+func (c *Config) GetRelease() ReleaseSettings {
+	settings := DefaultReleaseSettings()
+	if c.Release == nil {
+		return settings
+	}
+	if c.Release.BackportLabels != nil {
+		settings.BackportLabels = *c.Release.BackportLabels
+	}
+	return settings
+}
+
+// DelegationOverrides configures handing items off to a teammate from the
+// TUI.
+type DelegationOverrides struct {
+	// Roster lists the logins offered as teammates
+	Roster *[]string `yaml:"roster,omitempty"`
+	// Teams are GitHub teams, as "org/team-slug", whose members are
+	// offered too; membership is looked up once a day
+	Teams *[]string `yaml:"teams,omitempty"`
+	// Comment is a Go template posted on the item when it is handed off,
+	// e.g. "@{{.To}} could you take this one?"; empty posts nothing
+	Comment *string `yaml:"comment,omitempty"`
+}
+
+// DelegationSettings holds the resolved delegation settings.
+type DelegationSettings struct {
+	Roster  []string
+	Teams   []string
+	Comment string
+}
+
+// GetDelegation returns the delegation settings. Nothing is configured by
+// default: no teammates are offered and no comment is posted.
+func (c *Config) GetDelegation() DelegationSettings {
+	var settings DelegationSettings
+	if c.Delegation == nil {
+		return settings
+	}
+	if c.Delegation.Roster != nil {
+		settings.Roster = *c.Delegation.Roster
+	}
+	if c.Delegation.Teams != nil {
+		settings.Teams = *c.Delegation.Teams
+	}
+	if c.Delegation.Comment != nil {
+		settings.Comment = *c.Delegation.Comment
+	}
+	return settings
 }
 
 // BaseScoreOverrides allows customizing base scores for notification reasons
 type BaseScoreOverrides struct {
-	ReviewRequested *int `yaml:"review_requested,omitempty"`
-	Mention         *int `yaml:"mention,omitempty"`
-	TeamMention     *int `yaml:"team_mention,omitempty"`
-	Author          *int `yaml:"author,omitempty"`
-	Assign          *int `yaml:"assign,omitempty"`
-	Comment         *int `yaml:"comment,omitempty"`
-	StateChange     *int `yaml:"state_change,omitempty"`
-	Subscribed      *int `yaml:"subscribed,omitempty"`
-	CIActivity      *int `yaml:"ci_activity,omitempty"`
+	ReviewRequested     *int `yaml:"review_requested,omitempty"`
+	TeamReviewRequested *int `yaml:"team_review_requested,omitempty"`
+	Mention             *int `yaml:"mention,omitempty"`
+	TeamMention         *int `yaml:"team_mention,omitempty"`
+	Author              *int `yaml:"author,omitempty"`
+	Assign              *int `yaml:"assign,omitempty"`
+	Comment             *int `yaml:"comment,omitempty"`
+	StateChange         *int `yaml:"state_change,omitempty"`
+	Subscribed          *int `yaml:"subscribed,omitempty"`
+	CIActivity          *int `yaml:"ci_activity,omitempty"`
 }
 
 // ScoringOverrides - general scoring modifiers
 type ScoringOverrides struct {
-	OldUnreadBonus              *int `yaml:"old_unread_bonus,omitempty"`
-	MaxAgeBonus                 *int `yaml:"max_age_bonus,omitempty"`
-	HotTopicBonus               *int `yaml:"hot_topic_bonus,omitempty"`
-	HotTopicThreshold           *int `yaml:"hot_topic_threshold,omitempty"`
-	FYIPromotionThreshold       *int `yaml:"fyi_promotion_threshold,omitempty"`
-	NotablePromotionThreshold   *int `yaml:"notable_promotion_threshold,omitempty"`
-	ImportantPromotionThreshold *int `yaml:"important_promotion_threshold,omitempty"`
-	OpenStateBonus              *int `yaml:"open_state_bonus,omitempty"`
-	ClosedStatePenalty          *int `yaml:"closed_state_penalty,omitempty"`
-	LowHangingBonus             *int `yaml:"low_hanging_bonus,omitempty"`
+	OldUnreadBonus              *int  `yaml:"old_unread_bonus,omitempty"`
+	MaxAgeBonus                 *int  `yaml:"max_age_bonus,omitempty"`
+	HotTopicBonus               *int  `yaml:"hot_topic_bonus,omitempty"`
+	HotTopicThreshold           *int  `yaml:"hot_topic_threshold,omitempty"`
+	HotTopicVelocityBonus       *int  `yaml:"hot_topic_velocity_bonus,omitempty"`
+	HotTopicVelocityThreshold   *int  `yaml:"hot_topic_velocity_threshold,omitempty"`
+	FYIPromotionThreshold       *int  `yaml:"fyi_promotion_threshold,omitempty"`
+	NotablePromotionThreshold   *int  `yaml:"notable_promotion_threshold,omitempty"`
+	ImportantPromotionThreshold *int  `yaml:"important_promotion_threshold,omitempty"`
+	OpenStateBonus              *int  `yaml:"open_state_bonus,omitempty"`
+	ClosedStatePenalty          *int  `yaml:"closed_state_penalty,omitempty"`
+	LowHangingBonus             *int  `yaml:"low_hanging_bonus,omitempty"`
+	ReminderBonus               *int  `yaml:"reminder_bonus,omitempty"`
+	TeamReviewBonus             *int  `yaml:"team_review_bonus,omitempty"`
+	CodeOwnersBonus             *int  `yaml:"codeowners_bonus,omitempty"`
+	CodeOwnersPenalty           *int  `yaml:"codeowners_penalty,omitempty"`
+	BlockedPenalty              *int  `yaml:"blocked_penalty,omitempty"`
+	UnblocksBonus               *int  `yaml:"unblocks_bonus,omitempty"`
+	MilestoneDueSoonBonus       *int  `yaml:"milestone_due_soon_bonus,omitempty"`
+	MilestoneDueSoonDays        *int  `yaml:"milestone_due_soon_days,omitempty"`
+	ArchiveAfterDays            *int  `yaml:"archive_after_days,omitempty"`
+	NormalizeScores             *bool `yaml:"normalize_scores,omitempty"`
 }
 
 // PROverrides - PR-specific settings
@@ -91,10 +814,14 @@ type PROverrides struct {
 	DraftPenalty          *int `yaml:"draft_penalty,omitempty"`
 	SmallMaxFiles         *int `yaml:"small_max_files,omitempty"`
 	SmallMaxLines         *int `yaml:"small_max_lines,omitempty"`
-	SizeXS                *int `yaml:"size_xs,omitempty"`
-	SizeS                 *int `yaml:"size_s,omitempty"`
-	SizeM                 *int `yaml:"size_m,omitempty"`
-	SizeL                 *int `yaml:"size_l,omitempty"`
+	// Quick wins detected from the changed paths
+	QuickWinDocsOnly        *bool `yaml:"quick_win_docs_only,omitempty"`
+	QuickWinTestsOnly       *bool `yaml:"quick_win_tests_only,omitempty"`
+	QuickWinDependencyBumps *bool `yaml:"quick_win_dependency_bumps,omitempty"`
+	SizeXS                  *int  `yaml:"size_xs,omitempty"`
+	SizeS                   *int  `yaml:"size_s,omitempty"`
+	SizeM                   *int  `yaml:"size_m,omitempty"`
+	SizeL                   *int  `yaml:"size_l,omitempty"`
 }
 
 // UrgencyOverrides allows disabling specific urgency triggers
@@ -108,19 +835,32 @@ type UrgencyOverrides struct {
 // ScoreWeights defines the complete set of scoring weights
 type ScoreWeights struct {
 	ReviewRequested int
-	Mention         int
-	TeamMention     int
-	Author          int
-	Assign          int
-	Comment         int
-	Subscribed      int
-	StateChange     int
-	CIActivity      int
+	// TeamReviewRequested replaces ReviewRequested when the request reached
+	// the user only through a team
+	TeamReviewRequested int
+	Mention             int
+	TeamMention         int
+	Author              int
+	Assign              int
+	Comment             int
+	Subscribed          int
+	StateChange         int
+	CIActivity          int
 
 	OldUnreadBonus              int
 	HotTopicBonus               int
 	HotTopicThreshold           int
+	HotTopicVelocityBonus       int
+	HotTopicVelocityThreshold   int // Comments in the last 48 hours
 	LowHangingBonus             int
+	ReminderBonus               int // Items with a due reminder (triage remind)
+	TeamReviewBonus             int // PRs asking one of the user's teams for a review
+	CodeOwnersBonus             int // Team review requests changing code the user owns
+	CodeOwnersPenalty           int // Team review requests changing none of it
+	BlockedPenalty              int // Items waiting on an open dependency
+	UnblocksBonus               int // Items that others listed are waiting on
+	MilestoneDueSoonBonus       int // Items whose milestone is due soon or overdue
+	MilestoneDueSoonDays        int // How soon a milestone counts as due soon
 	OpenStateBonus              int
 	ClosedStatePenalty          int
 	FYIPromotionThreshold       int
@@ -141,16 +881,49 @@ type ScoreWeights struct {
 	// General scoring
 	MaxAgeBonus int
 
+	// ArchiveAfterDays moves items with no activity for this many days to
+	// the Archive priority; 0 never archives
+	ArchiveAfterDays int
+
+	// NormalizeScores reports scores on a 0-100 scale anchored at the
+	// promotion thresholds instead of as raw points
+	NormalizeScores bool
+
 	// Low-hanging fruit detection
 	SmallPRMaxFiles int
 	SmallPRMaxLines int
 
+	// PRs that only touch docs, only touch tests, or only bump
+	// dependencies are quick wins
+	DocsOnlyPRIsQuickWin     bool
+	TestsOnlyPRIsQuickWin    bool
+	DependencyBumpIsQuickWin bool
+
 	// PR size thresholds for T-shirt sizing
 	PRSizeXS int // <= this = XS
 	PRSizeS  int // <= this = S
 	PRSizeM  int // <= this = M
 	PRSizeL  int // > M and <= this = L (> this = XL)
 
+	// LabelScores maps a label, lowercased, to the points it adds
+	LabelScores map[string]int
+
+	// ProjectColumnScores maps a project board column, lowercased, to the
+	// points it adds
+	ProjectColumnScores map[string]int
+
+	// FormFieldScores maps a repository, field label, and answer, all
+	// lowercased, to the points the answer adds
+	FormFieldScores map[string]map[string]map[string]int
+
+	// RepoWeights maps a repository, lowercased, to the weights its items
+	// are scored with when repo_overrides configures it
+	RepoWeights map[string]ScoreWeights
+
+	// SeverityFloors maps a label, lowercased, to the lowest priority an
+	// item carrying it can have
+	SeverityFloors map[string]string
+
 	// Urgency trigger settings
 	ReviewRequestedIsUrgent     bool
 	MentionIsUrgent             bool
@@ -161,20 +934,31 @@ type ScoreWeights struct {
 // DefaultScoreWeights returns the default scoring weights
 func DefaultScoreWeights() ScoreWeights {
 	return ScoreWeights{
-		ReviewRequested: 100,
-		Mention:         90,
-		TeamMention:     85,
-		Author:          70,
-		Assign:          60,
-		Comment:         30,
-		StateChange:     25,
-		Subscribed:      10,
-		CIActivity:      5,
+		ReviewRequested:     100,
+		TeamReviewRequested: 75,
+		Mention:             90,
+		TeamMention:         85,
+		Author:              70,
+		Assign:              60,
+		Comment:             30,
+		StateChange:         25,
+		Subscribed:          10,
+		CIActivity:          5,
 
 		OldUnreadBonus:              2,
 		HotTopicBonus:               15,
 		HotTopicThreshold:           7,
+		HotTopicVelocityBonus:       20,
+		HotTopicVelocityThreshold:   4,
 		LowHangingBonus:             20,
+		ReminderBonus:               50,
+		TeamReviewBonus:             20,
+		CodeOwnersBonus:             20,
+		CodeOwnersPenalty:           -20,
+		BlockedPenalty:              -25,
+		UnblocksBonus:               20,
+		MilestoneDueSoonBonus:       25,
+		MilestoneDueSoonDays:        7,
 		OpenStateBonus:              10,
 		ClosedStatePenalty:          -30,
 		FYIPromotionThreshold:       35,  // FYI → Notable
@@ -199,6 +983,10 @@ func DefaultScoreWeights() ScoreWeights {
 		SmallPRMaxFiles: 5,
 		SmallPRMaxLines: 100,
 
+		DocsOnlyPRIsQuickWin:     true,
+		TestsOnlyPRIsQuickWin:    true,
+		DependencyBumpIsQuickWin: true,
+
 		// PR size thresholds for T-shirt sizing
 		PRSizeXS: 10,
 		PRSizeS:  50,
@@ -216,144 +1004,255 @@ func DefaultScoreWeights() ScoreWeights {
 // GetScoreWeights returns score weights with user overrides merged with defaults
 func (c *Config) GetScoreWeights() ScoreWeights {
 	weights := DefaultScoreWeights()
+	weights.applyBaseScores(c.BaseScores)
+	weights.applyScoring(c.Scoring)
+	weights.applyPR(c.PR)
 
-	// Apply base score overrides
-	if c.BaseScores != nil {
-		bs := c.BaseScores
-		if bs.ReviewRequested != nil {
-			weights.ReviewRequested = *bs.ReviewRequested
-		}
-		if bs.Mention != nil {
-			weights.Mention = *bs.Mention
-		}
-		if bs.TeamMention != nil {
-			weights.TeamMention = *bs.TeamMention
-		}
-		if bs.Author != nil {
-			weights.Author = *bs.Author
-		}
-		if bs.Assign != nil {
-			weights.Assign = *bs.Assign
-		}
-		if bs.Comment != nil {
-			weights.Comment = *bs.Comment
+	// Apply urgency overrides
+	if c.Urgency != nil {
+		u := c.Urgency
+		if u.ReviewRequested != nil {
+			weights.ReviewRequestedIsUrgent = *u.ReviewRequested
 		}
-		if bs.StateChange != nil {
-			weights.StateChange = *bs.StateChange
+		if u.Mention != nil {
+			weights.MentionIsUrgent = *u.Mention
 		}
-		if bs.Subscribed != nil {
-			weights.Subscribed = *bs.Subscribed
+		if u.ApprovedMergeablePR != nil {
+			weights.ApprovedMergeablePRIsUrgent = *u.ApprovedMergeablePR
 		}
-		if bs.CIActivity != nil {
-			weights.CIActivity = *bs.CIActivity
+		if u.ChangesRequestedPR != nil {
+			weights.ChangesRequestedPRIsUrgent = *u.ChangesRequestedPR
 		}
 	}
 
-	// Apply scoring overrides
-	if c.Scoring != nil {
-		s := c.Scoring
-		if s.OldUnreadBonus != nil {
-			weights.OldUnreadBonus = *s.OldUnreadBonus
-		}
-		if s.MaxAgeBonus != nil {
-			weights.MaxAgeBonus = *s.MaxAgeBonus
-		}
-		if s.HotTopicBonus != nil {
-			weights.HotTopicBonus = *s.HotTopicBonus
-		}
-		if s.HotTopicThreshold != nil {
-			weights.HotTopicThreshold = *s.HotTopicThreshold
-		}
-		if s.FYIPromotionThreshold != nil {
-			weights.FYIPromotionThreshold = *s.FYIPromotionThreshold
-		}
-		if s.NotablePromotionThreshold != nil {
-			weights.NotablePromotionThreshold = *s.NotablePromotionThreshold
-		}
-		if s.ImportantPromotionThreshold != nil {
-			weights.ImportantPromotionThreshold = *s.ImportantPromotionThreshold
-		}
-		if s.OpenStateBonus != nil {
-			weights.OpenStateBonus = *s.OpenStateBonus
-		}
-		if s.ClosedStatePenalty != nil {
-			weights.ClosedStatePenalty = *s.ClosedStatePenalty
-		}
-		if s.LowHangingBonus != nil {
-			weights.LowHangingBonus = *s.LowHangingBonus
+	if len(c.LabelScores) > 0 {
+		weights.LabelScores = make(map[string]int, len(c.LabelScores))
+		for label, points := range c.LabelScores {
+			weights.LabelScores[strings.ToLower(label)] += points
 		}
 	}
 
-	// Apply PR-specific overrides
-	if c.PR != nil {
-		pr := c.PR
-		if pr.ApprovedBonus != nil {
-			weights.ApprovedPRBonus = *pr.ApprovedBonus
-		}
-		if pr.MergeableBonus != nil {
-			weights.MergeablePRBonus = *pr.MergeableBonus
-		}
-		if pr.ChangesRequestedBonus != nil {
-			weights.ChangesRequestedBonus = *pr.ChangesRequestedBonus
+	if len(c.ProjectColumnScores) > 0 {
+		weights.ProjectColumnScores = make(map[string]int, len(c.ProjectColumnScores))
+		for column, points := range c.ProjectColumnScores {
+			weights.ProjectColumnScores[strings.ToLower(column)] += points
 		}
-		if pr.ReviewCommentBonus != nil {
-			weights.ReviewCommentBonus = *pr.ReviewCommentBonus
-		}
-		if pr.ReviewCommentMaxBonus != nil {
-			weights.ReviewCommentMaxBonus = *pr.ReviewCommentMaxBonus
-		}
-		if pr.StaleThresholdDays != nil {
-			weights.StalePRThresholdDays = *pr.StaleThresholdDays
-		}
-		if pr.StaleBonusPerDay != nil {
-			weights.StalePRBonusPerDay = *pr.StaleBonusPerDay
-		}
-		if pr.StaleMaxBonus != nil {
-			weights.StalePRMaxBonus = *pr.StaleMaxBonus
-		}
-		if pr.DraftPenalty != nil {
-			weights.DraftPRPenalty = *pr.DraftPenalty
-		}
-		if pr.SmallMaxFiles != nil {
-			weights.SmallPRMaxFiles = *pr.SmallMaxFiles
-		}
-		if pr.SmallMaxLines != nil {
-			weights.SmallPRMaxLines = *pr.SmallMaxLines
-		}
-		if pr.SizeXS != nil {
-			weights.PRSizeXS = *pr.SizeXS
-		}
-		if pr.SizeS != nil {
-			weights.PRSizeS = *pr.SizeS
-		}
-		if pr.SizeM != nil {
-			weights.PRSizeM = *pr.SizeM
-		}
-		if pr.SizeL != nil {
-			weights.PRSizeL = *pr.SizeL
+	}
+
+	if len(c.SeverityLabels) > 0 {
+		weights.SeverityFloors = make(map[string]string, len(c.SeverityLabels))
+		for label, priority := range c.SeverityLabels {
+			weights.SeverityFloors[strings.ToLower(label)] = priority
 		}
 	}
 
-	// Apply urgency overrides
-	if c.Urgency != nil {
-		u := c.Urgency
-		if u.ReviewRequested != nil {
-			weights.ReviewRequestedIsUrgent = *u.ReviewRequested
+	for repo, form := range c.IssueForms {
+		if form == nil || len(form.Scores) == 0 {
+			continue
 		}
-		if u.Mention != nil {
-			weights.MentionIsUrgent = *u.Mention
+		if weights.FormFieldScores == nil {
+			weights.FormFieldScores = make(map[string]map[string]map[string]int)
 		}
-		if u.ApprovedMergeablePR != nil {
-			weights.ApprovedMergeablePRIsUrgent = *u.ApprovedMergeablePR
+		fields := make(map[string]map[string]int, len(form.Scores))
+		for field, answers := range form.Scores {
+			points := make(map[string]int, len(answers))
+			for answer, p := range answers {
+				points[strings.ToLower(answer)] = p
+			}
+			fields[strings.ToLower(field)] = points
 		}
-		if u.ChangesRequestedPR != nil {
-			weights.ChangesRequestedPRIsUrgent = *u.ChangesRequestedPR
+		weights.FormFieldScores[strings.ToLower(repo)] = fields
+	}
+
+	if len(c.RepoOverrides) > 0 {
+		repoWeights := make(map[string]ScoreWeights, len(c.RepoOverrides))
+		for repo, override := range c.RepoOverrides {
+			if override == nil {
+				continue
+			}
+			w := weights
+			w.applyBaseScores(override.BaseScores)
+			w.applyScoring(override.Scoring)
+			w.applyPR(override.PR)
+			repoWeights[strings.ToLower(repo)] = w
 		}
+		weights.RepoWeights = repoWeights
 	}
 
 	return weights
 }
 
+// applyBaseScores sets the base scores bs configures.
+func (w *ScoreWeights) applyBaseScores(bs *BaseScoreOverrides) {
+	if bs == nil {
+		return
+	}
+	if bs.ReviewRequested != nil {
+		w.ReviewRequested = *bs.ReviewRequested
+	}
+	if bs.TeamReviewRequested != nil {
+		w.TeamReviewRequested = *bs.TeamReviewRequested
+	}
+	if bs.Mention != nil {
+		w.Mention = *bs.Mention
+	}
+	if bs.TeamMention != nil {
+		w.TeamMention = *bs.TeamMention
+	}
+	if bs.Author != nil {
+		w.Author = *bs.Author
+	}
+	if bs.Assign != nil {
+		w.Assign = *bs.Assign
+	}
+	if bs.Comment != nil {
+		w.Comment = *bs.Comment
+	}
+	if bs.StateChange != nil {
+		w.StateChange = *bs.StateChange
+	}
+	if bs.Subscribed != nil {
+		w.Subscribed = *bs.Subscribed
+	}
+	if bs.CIActivity != nil {
+		w.CIActivity = *bs.CIActivity
+	}
+}
+
+// applyScoring sets the scoring values s configures.
+func (w *ScoreWeights) applyScoring(s *ScoringOverrides) {
+	if s == nil {
+		return
+	}
+	if s.OldUnreadBonus != nil {
+		w.OldUnreadBonus = *s.OldUnreadBonus
+	}
+	if s.MaxAgeBonus != nil {
+		w.MaxAgeBonus = *s.MaxAgeBonus
+	}
+	if s.ArchiveAfterDays != nil {
+		w.ArchiveAfterDays = *s.ArchiveAfterDays
+	}
+	if s.NormalizeScores != nil {
+		w.NormalizeScores = *s.NormalizeScores
+	}
+	if s.HotTopicBonus != nil {
+		w.HotTopicBonus = *s.HotTopicBonus
+	}
+	if s.HotTopicThreshold != nil {
+		w.HotTopicThreshold = *s.HotTopicThreshold
+	}
+	if s.HotTopicVelocityBonus != nil {
+		w.HotTopicVelocityBonus = *s.HotTopicVelocityBonus
+	}
+	if s.HotTopicVelocityThreshold != nil {
+		w.HotTopicVelocityThreshold = *s.HotTopicVelocityThreshold
+	}
+	if s.FYIPromotionThreshold != nil {
+		w.FYIPromotionThreshold = *s.FYIPromotionThreshold
+	}
+	if s.NotablePromotionThreshold != nil {
+		w.NotablePromotionThreshold = *s.NotablePromotionThreshold
+	}
+	if s.ImportantPromotionThreshold != nil {
+		w.ImportantPromotionThreshold = *s.ImportantPromotionThreshold
+	}
+	if s.OpenStateBonus != nil {
+		w.OpenStateBonus = *s.OpenStateBonus
+	}
+	if s.ClosedStatePenalty != nil {
+		w.ClosedStatePenalty = *s.ClosedStatePenalty
+	}
+	if s.LowHangingBonus != nil {
+		w.LowHangingBonus = *s.LowHangingBonus
+	}
+	if s.ReminderBonus != nil {
+		w.ReminderBonus = *s.ReminderBonus
+	}
+	if s.TeamReviewBonus != nil {
+		w.TeamReviewBonus = *s.TeamReviewBonus
+	}
+	if s.CodeOwnersBonus != nil {
+		w.CodeOwnersBonus = *s.CodeOwnersBonus
+	}
+	if s.CodeOwnersPenalty != nil {
+		w.CodeOwnersPenalty = *s.CodeOwnersPenalty
+	}
+	if s.BlockedPenalty != nil {
+		w.BlockedPenalty = *s.BlockedPenalty
+	}
+	if s.UnblocksBonus != nil {
+		w.UnblocksBonus = *s.UnblocksBonus
+	}
+	if s.MilestoneDueSoonBonus != nil {
+		w.MilestoneDueSoonBonus = *s.MilestoneDueSoonBonus
+	}
+	if s.MilestoneDueSoonDays != nil {
+		w.MilestoneDueSoonDays = *s.MilestoneDueSoonDays
+	}
+}
+
+// applyPR sets the PR scoring values pr configures.
+func (w *ScoreWeights) applyPR(pr *PROverrides) {
+	if pr == nil {
+		return
+	}
+	if pr.ApprovedBonus != nil {
+		w.ApprovedPRBonus = *pr.ApprovedBonus
+	}
+	if pr.MergeableBonus != nil {
+		w.MergeablePRBonus = *pr.MergeableBonus
+	}
+	if pr.ChangesRequestedBonus != nil {
+		w.ChangesRequestedBonus = *pr.ChangesRequestedBonus
+	}
+	if pr.ReviewCommentBonus != nil {
+		w.ReviewCommentBonus = *pr.ReviewCommentBonus
+	}
+	if pr.ReviewCommentMaxBonus != nil {
+		w.ReviewCommentMaxBonus = *pr.ReviewCommentMaxBonus
+	}
+	if pr.StaleThresholdDays != nil {
+		w.StalePRThresholdDays = *pr.StaleThresholdDays
+	}
+	if pr.StaleBonusPerDay != nil {
+		w.StalePRBonusPerDay = *pr.StaleBonusPerDay
+	}
+	if pr.StaleMaxBonus != nil {
+		w.StalePRMaxBonus = *pr.StaleMaxBonus
+	}
+	if pr.DraftPenalty != nil {
+		w.DraftPRPenalty = *pr.DraftPenalty
+	}
+	if pr.SmallMaxFiles != nil {
+		w.SmallPRMaxFiles = *pr.SmallMaxFiles
+	}
+	if pr.SmallMaxLines != nil {
+		w.SmallPRMaxLines = *pr.SmallMaxLines
+	}
+	if pr.QuickWinDocsOnly != nil {
+		w.DocsOnlyPRIsQuickWin = *pr.QuickWinDocsOnly
+	}
+	if pr.QuickWinTestsOnly != nil {
+		w.TestsOnlyPRIsQuickWin = *pr.QuickWinTestsOnly
+	}
+	if pr.QuickWinDependencyBumps != nil {
+		w.DependencyBumpIsQuickWin = *pr.QuickWinDependencyBumps
+	}
+	if pr.SizeXS != nil {
+		w.PRSizeXS = *pr.SizeXS
+	}
+	if pr.SizeS != nil {
+		w.PRSizeS = *pr.SizeS
+	}
+	if pr.SizeM != nil {
+		w.PRSizeM = *pr.SizeM
+	}
+	if pr.SizeL != nil {
+		w.PRSizeL = *pr.SizeL
+	}
+}
+
 // xdgConfigDir returns the XDG-style config directory ($XDG_CONFIG_HOME/triage,
 // or $HOME/.config/triage when XDG_CONFIG_HOME is unset). Returns "" when
 // neither env var is available.
@@ -416,27 +1315,33 @@ func localConfigPath() string {
 	return ".triage.yaml"
 }
 
+// FilePaths returns the files Load reads, in the order it merges them.
+// Files that don't exist are included, so callers can notice them appear.
+func FilePaths() []string {
+	return []string{presetPath(), configPath(), localConfigPath()}
+}
+
 // Load loads the configuration from disk.
-// It first loads the global config from XDG config directory, then merges
-// any local .triage.yaml config on top (local values take precedence).
+// It first loads any installed team preset, merges the global config from
+// the XDG config directory on top, then merges any local .triage.yaml
+// config on top of that (later files take precedence).
 func Load() (*Config, error) {
 	// Start with defaults
 	cfg := &Config{
 		DefaultFormat: "table",
 	}
 
-	// Load global config if it exists
-	globalPath := configPath()
-	if _, err := os.Stat(globalPath); err == nil {
-		data, err := os.ReadFile(globalPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read global config file: %w", err)
-		}
+	// Load the team preset if one is installed
+	if err := loadFile(presetPath(), cfg); err != nil {
+		return nil, fmt.Errorf("failed to load team preset: %w", err)
+	}
 
-		if err := yaml.Unmarshal(data, cfg); err != nil {
-			return nil, fmt.Errorf("failed to parse global config file: %w", err)
-		}
+	// Load global config if it exists and merge on top
+	var globalCfg Config
+	if err := loadFile(configPath(), &globalCfg); err != nil {
+		return nil, fmt.Errorf("failed to load global config file: %w", err)
 	}
+	cfg = mergeConfig(cfg, &globalCfg)
 
 	// Load local config if it exists and merge on top
 	localPath := localConfigPath()
@@ -459,6 +1364,12 @@ func Load() (*Config, error) {
 		cfg.DefaultFormat = "table"
 	}
 
+	for _, u := range cfg.Upstream {
+		if err := u.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -473,6 +1384,11 @@ func mergeConfig(global, local *Config) *Config {
 	} else {
 		result.DefaultFormat = global.DefaultFormat
 	}
+	if local.Locale != "" {
+		result.Locale = local.Locale
+	} else {
+		result.Locale = global.Locale
+	}
 
 	// Merge arrays (local replaces if non-empty)
 	if len(local.ExcludeRepos) > 0 {
@@ -493,12 +1409,25 @@ func mergeConfig(global, local *Config) *Config {
 		result.DependencyAuthors = global.DependencyAuthors
 	}
 
+	if len(local.BotAuthors) > 0 {
+		result.BotAuthors = local.BotAuthors
+	} else {
+		result.BotAuthors = global.BotAuthors
+	}
+
 	if len(local.QuickWinLabels) > 0 {
 		result.QuickWinLabels = local.QuickWinLabels
 	} else {
 		result.QuickWinLabels = global.QuickWinLabels
 	}
 
+	// Merge Upstream (local replaces global)
+	if len(local.Upstream) > 0 {
+		result.Upstream = local.Upstream
+	} else {
+		result.Upstream = global.Upstream
+	}
+
 	// Merge BlockedLabels (pointer semantics: local non-nil overrides global)
 	if local.BlockedLabels != nil {
 		result.BlockedLabels = local.BlockedLabels
@@ -506,14 +1435,105 @@ func mergeConfig(global, local *Config) *Config {
 		result.BlockedLabels = global.BlockedLabels
 	}
 
+	// Merge LabelScores (local wins per label)
+	if len(global.LabelScores) > 0 || len(local.LabelScores) > 0 {
+		result.LabelScores = make(map[string]int, len(global.LabelScores)+len(local.LabelScores))
+		for label, points := range global.LabelScores {
+			result.LabelScores[label] = points
+		}
+		for label, points := range local.LabelScores {
+			result.LabelScores[label] = points
+		}
+	}
+
+	// Merge ProjectColumnScores (local wins per column)
+	if len(global.ProjectColumnScores) > 0 || len(local.ProjectColumnScores) > 0 {
+		result.ProjectColumnScores = make(map[string]int, len(global.ProjectColumnScores)+len(local.ProjectColumnScores))
+		for column, points := range global.ProjectColumnScores {
+			result.ProjectColumnScores[column] = points
+		}
+		for column, points := range local.ProjectColumnScores {
+			result.ProjectColumnScores[column] = points
+		}
+	}
+
+	// Merge SeverityLabels (local wins per label)
+	if len(global.SeverityLabels) > 0 || len(local.SeverityLabels) > 0 {
+		result.SeverityLabels = make(map[string]string, len(global.SeverityLabels)+len(local.SeverityLabels))
+		for label, priority := range global.SeverityLabels {
+			result.SeverityLabels[label] = priority
+		}
+		for label, priority := range local.SeverityLabels {
+			result.SeverityLabels[label] = priority
+		}
+	}
+
+	// Merge Workspaces (local wins per workspace)
+	if len(global.Workspaces) > 0 || len(local.Workspaces) > 0 {
+		result.Workspaces = make(map[string][]string, len(global.Workspaces)+len(local.Workspaces))
+		for name, repos := range global.Workspaces {
+			result.Workspaces[name] = repos
+		}
+		for name, repos := range local.Workspaces {
+			result.Workspaces[name] = repos
+		}
+	}
+
+	// Merge RepoOverrides (local wins per section of each repo)
+	if len(global.RepoOverrides) > 0 || len(local.RepoOverrides) > 0 {
+		result.RepoOverrides = make(map[string]*RepoOverride, len(global.RepoOverrides)+len(local.RepoOverrides))
+		for repo, override := range global.RepoOverrides {
+			result.RepoOverrides[repo] = override
+		}
+		for repo, override := range local.RepoOverrides {
+			g := result.RepoOverrides[repo]
+			if g == nil || override == nil {
+				if override != nil {
+					result.RepoOverrides[repo] = override
+				}
+				continue
+			}
+			result.RepoOverrides[repo] = &RepoOverride{
+				BaseScores: mergePointerStruct(g.BaseScores, override.BaseScores),
+				Scoring:    mergePointerStruct(g.Scoring, override.Scoring),
+				PR:         mergePointerStruct(g.PR, override.PR),
+			}
+		}
+	}
+
+	// Merge IssueForms (local wins per repo)
+	if len(global.IssueForms) > 0 || len(local.IssueForms) > 0 {
+		result.IssueForms = make(map[string]*IssueFormConfig, len(global.IssueForms)+len(local.IssueForms))
+		for repo, form := range global.IssueForms {
+			result.IssueForms[repo] = form
+		}
+		for repo, form := range local.IssueForms {
+			result.IssueForms[repo] = form
+		}
+	}
+
 	// Merge IncludeReadNotifications (local wins if true)
 	result.IncludeReadNotifications = local.IncludeReadNotifications || global.IncludeReadNotifications
+	// Merge SyncReadOnDone (local wins if true)
+	result.SyncReadOnDone = local.SyncReadOnDone || global.SyncReadOnDone
 
 	// Merge pointer struct sections
 	result.BaseScores = mergePointerStruct(global.BaseScores, local.BaseScores)
 	result.Scoring = mergePointerStruct(global.Scoring, local.Scoring)
 	result.PR = mergePointerStruct(global.PR, local.PR)
 	result.Urgency = mergePointerStruct(global.Urgency, local.Urgency)
+	result.Confirmations = mergePointerStruct(global.Confirmations, local.Confirmations)
+	result.ReviewSLO = mergePointerStruct(global.ReviewSLO, local.ReviewSLO)
+	result.Accessibility = mergePointerStruct(global.Accessibility, local.Accessibility)
+	result.Truncation = mergePointerStruct(global.Truncation, local.Truncation)
+	result.SelfUpdate = mergePointerStruct(global.SelfUpdate, local.SelfUpdate)
+	result.Email = mergePointerStruct(global.Email, local.Email)
+	result.Notifiers = mergePointerStruct(global.Notifiers, local.Notifiers)
+	result.Notifications = mergePointerStruct(global.Notifications, local.Notifications)
+	result.Release = mergePointerStruct(global.Release, local.Release)
+	result.Delegation = mergePointerStruct(global.Delegation, local.Delegation)
+	result.HTTP = mergePointerStruct(global.HTTP, local.HTTP)
+	result.Cache = mergePointerStruct(global.Cache, local.Cache)
 
 	// Merge Orphaned
 	result.Orphaned = mergeOrphanedConfig(global.Orphaned, local.Orphaned)
@@ -580,6 +1600,10 @@ func mergeOrphanedConfig(global, local *OrphanedConfig) *OrphanedConfig {
 		result.StaleDays = global.StaleDays
 		result.ConsecutiveAuthorComments = global.ConsecutiveAuthorComments
 		result.MaxItemsPerRepo = global.MaxItemsPerRepo
+		result.Rules = global.Rules
+		result.TriageLabels = global.TriageLabels
+		result.Team = global.Team
+		result.Teams = global.Teams
 	}
 
 	if local != nil {
@@ -597,11 +1621,41 @@ func mergeOrphanedConfig(global, local *OrphanedConfig) *OrphanedConfig {
 		if local.MaxItemsPerRepo > 0 {
 			result.MaxItemsPerRepo = local.MaxItemsPerRepo
 		}
+		result.Rules = mergePointerStruct(result.Rules, local.Rules)
+		if len(local.TriageLabels) > 0 {
+			result.TriageLabels = local.TriageLabels
+		}
+		if len(local.Team) > 0 {
+			result.Team = local.Team
+		}
+		if len(local.Teams) > 0 {
+			result.Teams = local.Teams
+		}
+	}
+
+	// Repo rules merge per repo, local values winning
+	var globalRules, localRules map[string]*OrphanedRuleOverrides
+	if global != nil {
+		globalRules = global.RepoRules
+	}
+	if local != nil {
+		localRules = local.RepoRules
+	}
+	if len(globalRules) > 0 || len(localRules) > 0 {
+		result.RepoRules = make(map[string]*OrphanedRuleOverrides, len(globalRules)+len(localRules))
+		for repo, rules := range globalRules {
+			result.RepoRules[repo] = rules
+		}
+		for repo, rules := range localRules {
+			result.RepoRules[repo] = mergePointerStruct(result.RepoRules[repo], rules)
+		}
 	}
 
 	// Return nil if effectively empty
 	if len(result.Repos) == 0 && result.StaleDays == 0 &&
-		result.ConsecutiveAuthorComments == 0 && result.MaxItemsPerRepo == 0 {
+		result.ConsecutiveAuthorComments == 0 && result.MaxItemsPerRepo == 0 &&
+		result.Rules == nil && len(result.RepoRules) == 0 &&
+		len(result.TriageLabels) == 0 && len(result.Team) == 0 && len(result.Teams) == 0 {
 		return nil
 	}
 
@@ -625,6 +1679,11 @@ func mergeUIPreferences(global, local *UIPreferences) *UIPreferences {
 		result.BlockedSortDesc = global.BlockedSortDesc
 		result.DependabotSortColumn = global.DependabotSortColumn
 		result.DependabotSortDesc = global.DependabotSortDesc
+		result.LaterSortColumn = global.LaterSortColumn
+		result.LaterSortDesc = global.LaterSortDesc
+		result.ClusterRelated = global.ClusterRelated
+		result.PinResurfaced = global.PinResurfaced
+		result.MinimalRedraw = global.MinimalRedraw
 	}
 
 	if local != nil {
@@ -658,6 +1717,21 @@ func mergeUIPreferences(global, local *UIPreferences) *UIPreferences {
 		if local.DependabotSortDesc != nil {
 			result.DependabotSortDesc = local.DependabotSortDesc
 		}
+		if local.LaterSortColumn != "" {
+			result.LaterSortColumn = local.LaterSortColumn
+		}
+		if local.LaterSortDesc != nil {
+			result.LaterSortDesc = local.LaterSortDesc
+		}
+		if local.ClusterRelated != nil {
+			result.ClusterRelated = local.ClusterRelated
+		}
+		if local.PinResurfaced != nil {
+			result.PinResurfaced = local.PinResurfaced
+		}
+		if local.MinimalRedraw != nil {
+			result.MinimalRedraw = local.MinimalRedraw
+		}
 	}
 
 	// Return nil if effectively empty
@@ -665,7 +1739,10 @@ func mergeUIPreferences(global, local *UIPreferences) *UIPreferences {
 		result.OrphanedSortColumn == "" && result.OrphanedSortDesc == nil &&
 		result.AssignedSortColumn == "" && result.AssignedSortDesc == nil &&
 		result.BlockedSortColumn == "" && result.BlockedSortDesc == nil &&
-		result.DependabotSortColumn == "" && result.DependabotSortDesc == nil {
+		result.DependabotSortColumn == "" && result.DependabotSortDesc == nil &&
+		result.LaterSortColumn == "" && result.LaterSortDesc == nil &&
+		result.ClusterRelated == nil && result.PinResurfaced == nil &&
+		result.MinimalRedraw == nil {
 		return nil
 	}
 
@@ -675,22 +1752,65 @@ func mergeUIPreferences(global, local *UIPreferences) *UIPreferences {
 // SaveUIPreferences saves only the UI preferences section to the config file.
 // It loads the existing config, updates only the UI preferences, and saves.
 func (c *Config) SaveUIPreferences() error {
+	return updateConfigFile(configPath(), func(existing *Config) {
+		existing.UI = c.UI
+	})
+}
+
+// SaveScoring saves the base_scores, scoring, and urgency values c sets on
+// top of the values already in the file they are read from, and returns
+// that file's path. That is the local .triage.yaml when it sets any of those
+// sections, since it wins over the global config, and the global config
+// otherwise. The team preset is never written; the global config already
+// wins over it. Values c leaves unset, and the rest of the file, are kept.
+func (c *Config) SaveScoring() (string, error) {
+	path := scoringPath()
+	err := updateConfigFile(path, func(existing *Config) {
+		existing.BaseScores = mergePointerStruct(existing.BaseScores, c.BaseScores)
+		existing.Scoring = mergePointerStruct(existing.Scoring, c.Scoring)
+		existing.Urgency = mergePointerStruct(existing.Urgency, c.Urgency)
+	})
+	return path, err
+}
+
+// scoringPath returns the config file SaveScoring writes to.
+func scoringPath() string {
+	var local Config
+	if err := loadFile(localConfigPath(), &local); err == nil &&
+		(local.BaseScores != nil || local.Scoring != nil || local.Urgency != nil) {
+		return localConfigPath()
+	}
+	return configPath()
+}
+
+// WithScoring returns a copy of c with the base_scores, scoring, and urgency
+// values edits sets applied on top of its own.
+func (c *Config) WithScoring(edits *Config) *Config {
+	merged := *c
+	merged.BaseScores = mergePointerStruct(c.BaseScores, edits.BaseScores)
+	merged.Scoring = mergePointerStruct(c.Scoring, edits.Scoring)
+	merged.Urgency = mergePointerStruct(c.Urgency, edits.Urgency)
+	return &merged
+}
+
+// updateConfigFile loads the config file at path, applies update to it, and
+// writes it back, preserving the settings update doesn't touch.
+func updateConfigFile(path string, update func(existing *Config)) error {
 	// Read existing config file to preserve other settings
-	globalPath := configPath()
 	var existing Config
 
-	if data, err := os.ReadFile(globalPath); err == nil {
+	if data, err := os.ReadFile(path); err == nil {
 		if err := yaml.Unmarshal(data, &existing); err != nil {
 			return fmt.Errorf("failed to parse existing config: %w", err)
 		}
+	} else {
+		existing.Version = Version
 	}
 
-	// Update only the UI section
-	existing.UI = c.UI
+	update(&existing)
 
 	// Write back
-	configDir := defaultConfigDir()
-	if err := os.MkdirAll(configDir, 0700); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
@@ -699,7 +1819,7 @@ func (c *Config) SaveUIPreferences() error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(globalPath, data, 0600); err != nil {
+	if err := os.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
@@ -715,6 +1835,8 @@ func (c *Config) save() error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	// The whole config is rewritten in the current format
+	c.Version = Version
 	data, err := yaml.Marshal(c)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
@@ -756,6 +1878,18 @@ func DefaultQuickWinLabels() []string {
 	}
 }
 
+// GetIssueFormFields returns the issue form fields kept for each
+// repository, keyed by lowercased full name.
+func (c *Config) GetIssueFormFields() map[string][]string {
+	fields := make(map[string][]string, len(c.IssueForms))
+	for repo, form := range c.IssueForms {
+		if form != nil && len(form.Fields) > 0 {
+			fields[strings.ToLower(repo)] = form.Fields
+		}
+	}
+	return fields
+}
+
 // GetQuickWinLabels returns the quick win labels, using defaults if not configured
 func (c *Config) GetQuickWinLabels() []string {
 	if len(c.QuickWinLabels) > 0 {
@@ -773,6 +1907,35 @@ func (c *Config) GetBlockedLabels() []string {
 	return *c.BlockedLabels // returns empty slice if explicitly disabled
 }
 
+// ExpandRepos replaces each "@name" entry in repos with the repos in that
+// workspace, dropping duplicates. Workspaces that aren't configured are
+// left out of expanded and returned in unknown.
+func (c *Config) ExpandRepos(repos []string) (expanded, unknown []string) {
+	seen := make(map[string]bool)
+	add := func(repo string) {
+		if key := strings.ToLower(repo); !seen[key] {
+			seen[key] = true
+			expanded = append(expanded, repo)
+		}
+	}
+	for _, repo := range repos {
+		name, isWorkspace := strings.CutPrefix(repo, "@")
+		if !isWorkspace {
+			add(repo)
+			continue
+		}
+		members, ok := c.Workspaces[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		for _, member := range members {
+			add(member)
+		}
+	}
+	return expanded, unknown
+}
+
 // DefaultDependencyAuthors returns the always-on list of authors whose PRs are
 // routed to the Deps pane. Configured authors are added on top of these; the
 // defaults cannot be removed to preserve safe, known-good dependency bot routing.
@@ -785,15 +1948,27 @@ func DefaultDependencyAuthors() []string {
 // GetDependencyAuthors returns the combined list of dependency-bot authors:
 // the built-in defaults plus any authors added via config.
 func (c *Config) GetDependencyAuthors() []string {
-	authors := DefaultDependencyAuthors()
-	if len(c.DependencyAuthors) == 0 {
+	return appendAuthors(DefaultDependencyAuthors(), c.DependencyAuthors)
+}
+
+// GetBotAuthors returns the authors whose comments, reviews, and commits
+// don't count as human activity: the dependency-bot authors plus any added
+// via bot_authors. GitHub App accounts ("name[bot]") are bots regardless.
+func (c *Config) GetBotAuthors() []string {
+	return appendAuthors(c.GetDependencyAuthors(), c.BotAuthors)
+}
+
+// appendAuthors adds the extra authors not already in authors, ignoring
+// case and blank entries.
+func appendAuthors(authors, extra []string) []string {
+	if len(extra) == 0 {
 		return authors
 	}
-	seen := make(map[string]bool, len(authors)+len(c.DependencyAuthors))
+	seen := make(map[string]bool, len(authors)+len(extra))
 	for _, a := range authors {
 		seen[strings.ToLower(a)] = true
 	}
-	for _, a := range c.DependencyAuthors {
+	for _, a := range extra {
 		key := strings.ToLower(strings.TrimSpace(a))
 		if key == "" || seen[key] {
 			continue
@@ -810,52 +1985,71 @@ func DefaultConfig() *Config {
 	weights := DefaultScoreWeights()
 	labels := DefaultQuickWinLabels()
 	blockedLabels := []string{"blocked"}
+	confirmations := DefaultConfirmationSettings()
+	selfUpdate := DefaultSelfUpdateSettings()
+	orphanedRules := DefaultOrphanedRuleSettings()
 
 	return &Config{
+		Version:        Version,
 		DefaultFormat:  "table",
 		ExcludeRepos:   []string{},
 		ExcludeAuthors: []string{},
 		QuickWinLabels: labels,
 		BlockedLabels:  &blockedLabels,
 		BaseScores: &BaseScoreOverrides{
-			ReviewRequested: &weights.ReviewRequested,
-			Mention:         &weights.Mention,
-			TeamMention:     &weights.TeamMention,
-			Author:          &weights.Author,
-			Assign:          &weights.Assign,
-			Comment:         &weights.Comment,
-			StateChange:     &weights.StateChange,
-			Subscribed:      &weights.Subscribed,
-			CIActivity:      &weights.CIActivity,
+			ReviewRequested:     &weights.ReviewRequested,
+			TeamReviewRequested: &weights.TeamReviewRequested,
+			Mention:             &weights.Mention,
+			TeamMention:         &weights.TeamMention,
+			Author:              &weights.Author,
+			Assign:              &weights.Assign,
+			Comment:             &weights.Comment,
+			StateChange:         &weights.StateChange,
+			Subscribed:          &weights.Subscribed,
+			CIActivity:          &weights.CIActivity,
 		},
 		Scoring: &ScoringOverrides{
 			OldUnreadBonus:              &weights.OldUnreadBonus,
 			MaxAgeBonus:                 &weights.MaxAgeBonus,
 			HotTopicBonus:               &weights.HotTopicBonus,
 			HotTopicThreshold:           &weights.HotTopicThreshold,
+			HotTopicVelocityBonus:       &weights.HotTopicVelocityBonus,
+			HotTopicVelocityThreshold:   &weights.HotTopicVelocityThreshold,
 			FYIPromotionThreshold:       &weights.FYIPromotionThreshold,
 			NotablePromotionThreshold:   &weights.NotablePromotionThreshold,
 			ImportantPromotionThreshold: &weights.ImportantPromotionThreshold,
 			OpenStateBonus:              &weights.OpenStateBonus,
 			ClosedStatePenalty:          &weights.ClosedStatePenalty,
 			LowHangingBonus:             &weights.LowHangingBonus,
+			ReminderBonus:               &weights.ReminderBonus,
+			TeamReviewBonus:             &weights.TeamReviewBonus,
+			CodeOwnersBonus:             &weights.CodeOwnersBonus,
+			CodeOwnersPenalty:           &weights.CodeOwnersPenalty,
+			BlockedPenalty:              &weights.BlockedPenalty,
+			UnblocksBonus:               &weights.UnblocksBonus,
+			MilestoneDueSoonBonus:       &weights.MilestoneDueSoonBonus,
+			MilestoneDueSoonDays:        &weights.MilestoneDueSoonDays,
+			ArchiveAfterDays:            &weights.ArchiveAfterDays,
 		},
 		PR: &PROverrides{
-			ApprovedBonus:         &weights.ApprovedPRBonus,
-			MergeableBonus:        &weights.MergeablePRBonus,
-			ChangesRequestedBonus: &weights.ChangesRequestedBonus,
-			ReviewCommentBonus:    &weights.ReviewCommentBonus,
-			ReviewCommentMaxBonus: &weights.ReviewCommentMaxBonus,
-			StaleThresholdDays:    &weights.StalePRThresholdDays,
-			StaleBonusPerDay:      &weights.StalePRBonusPerDay,
-			StaleMaxBonus:         &weights.StalePRMaxBonus,
-			DraftPenalty:          &weights.DraftPRPenalty,
-			SmallMaxFiles:         &weights.SmallPRMaxFiles,
-			SmallMaxLines:         &weights.SmallPRMaxLines,
-			SizeXS:                &weights.PRSizeXS,
-			SizeS:                 &weights.PRSizeS,
-			SizeM:                 &weights.PRSizeM,
-			SizeL:                 &weights.PRSizeL,
+			ApprovedBonus:           &weights.ApprovedPRBonus,
+			MergeableBonus:          &weights.MergeablePRBonus,
+			ChangesRequestedBonus:   &weights.ChangesRequestedBonus,
+			ReviewCommentBonus:      &weights.ReviewCommentBonus,
+			ReviewCommentMaxBonus:   &weights.ReviewCommentMaxBonus,
+			StaleThresholdDays:      &weights.StalePRThresholdDays,
+			StaleBonusPerDay:        &weights.StalePRBonusPerDay,
+			StaleMaxBonus:           &weights.StalePRMaxBonus,
+			DraftPenalty:            &weights.DraftPRPenalty,
+			SmallMaxFiles:           &weights.SmallPRMaxFiles,
+			SmallMaxLines:           &weights.SmallPRMaxLines,
+			QuickWinDocsOnly:        &weights.DocsOnlyPRIsQuickWin,
+			QuickWinTestsOnly:       &weights.TestsOnlyPRIsQuickWin,
+			QuickWinDependencyBumps: &weights.DependencyBumpIsQuickWin,
+			SizeXS:                  &weights.PRSizeXS,
+			SizeS:                   &weights.PRSizeS,
+			SizeM:                   &weights.PRSizeM,
+			SizeL:                   &weights.PRSizeL,
 		},
 		Urgency: &UrgencyOverrides{
 			ReviewRequested:     &weights.ReviewRequestedIsUrgent,
@@ -868,8 +2062,68 @@ func DefaultConfig() *Config {
 			StaleDays:                 7,
 			ConsecutiveAuthorComments: 2,
 			MaxItemsPerRepo:           100,
+			Rules: &OrphanedRuleOverrides{
+				ExternalAuthor: &orphanedRules.ExternalAuthor,
+				NoTeamResponse: &orphanedRules.NoTeamResponse,
+				NoAssignee:     &orphanedRules.NoAssignee,
+				NoTriageLabel:  &orphanedRules.NoTriageLabel,
+			},
+		},
+		Confirmations: &ConfirmationOverrides{
+			MarkReadBulk: &confirmations.MarkReadBulk,
+			Comment:      &confirmations.Comment,
 		},
+		SelfUpdate: &SelfUpdateOverrides{
+			Enabled:         &selfUpdate.Enabled,
+			VerifySignature: &selfUpdate.VerifySignature,
+		},
+	}
+}
+
+// SettingSource reports where the setting at path, such as
+// ("scoring", "hot_topic_bonus"), comes from for the items of repo:
+// "repo_overrides.<repo>" when that repo's override sets it, "config" when
+// a config file or the team preset does, and "default" otherwise.
+func (c *Config) SettingSource(repo string, path ...string) string {
+	for name, override := range c.RepoOverrides {
+		if override != nil && strings.EqualFold(name, repo) && hasYAMLPath(override, path) {
+			return "repo_overrides." + name
+		}
 	}
+	if hasYAMLPath(c, path) {
+		return "config"
+	}
+	return "default"
+}
+
+// hasYAMLPath reports whether v, as YAML, sets the value at path. Keys
+// match case-insensitively, like repo names and labels.
+func hasYAMLPath(v any, path []string) bool {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return false
+	}
+	var node any
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return false
+	}
+	for _, key := range path {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return false
+		}
+		found := false
+		for k, child := range m {
+			if strings.EqualFold(k, key) {
+				node, found = child, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 // ToYAML returns the config as a YAML string
@@ -883,14 +2137,18 @@ func (c *Config) ToYAML() (string, error) {
 
 // ConfigPathInfo contains information about config file paths
 type ConfigPathInfo struct {
+	PresetPath   string
+	PresetExists bool
 	GlobalPath   string
 	GlobalExists bool
 	LocalPath    string
 	LocalExists  bool
 }
 
-// GetConfigPaths returns path info for both global and local configs
+// GetConfigPaths returns path info for the team preset and the global and
+// local configs
 func GetConfigPaths() ConfigPathInfo {
+	presetPath := presetPath()
 	globalPath := configPath()
 	localPath := localConfigPath()
 
@@ -900,10 +2158,13 @@ func GetConfigPaths() ConfigPathInfo {
 		absLocalPath = localPath
 	}
 
+	_, presetErr := os.Stat(presetPath)
 	_, globalErr := os.Stat(globalPath)
 	_, localErr := os.Stat(localPath)
 
 	return ConfigPathInfo{
+		PresetPath:   presetPath,
+		PresetExists: presetErr == nil,
 		GlobalPath:   globalPath,
 		GlobalExists: globalErr == nil,
 		LocalPath:    absLocalPath,
@@ -916,13 +2177,29 @@ func MinimalConfig() string {
 	return `# Triage configuration file
 # See: triage config defaults  (for all available options)
 
-# Output format: table or json
+# Config file format (managed by triage migrate; do not edit)
+version: 1
+
+# Output format: table, json, plain (labeled lines for screen readers), or
+# markdown (a task list grouped by priority)
 default_format: table
 
+# Language for column headers and priority names: en, de, or es
+# (default: taken from LC_ALL, LC_MESSAGES, or LANG)
+# locale: de
+
 # Exclude noisy repositories (optional)
 # exclude_repos:
 #   - owner/noisy-repo
 
+# Named groups of repos (optional)
+# Use them as --repo @platform, or as @platform in exclude_repos and orphaned.repos.
+# workspaces:
+#   platform:
+#     - myorg/api
+#     - myorg/web
+#     - myorg/infra
+
 # Exclude bot authors (optional)
 # exclude_authors:
 #   - dependabot[bot]
@@ -934,22 +2211,86 @@ default_format: table
 #   - renovate[bot]
 #   - anchore-oss-update-bot
 
+# Authors whose comments, reviews, and commits don't reset an item's Age.
+# GitHub App accounts (name[bot]) and dependency_authors are always bots.
+# bot_authors:
+#   - ci-helper
+
 # Include read notifications (default: false)
 # When true, fetches all notifications including already-read ones.
 # Useful for seeing dependabot PRs you may have dismissed.
 # include_read_notifications: false
 
+# Mark notifications read on GitHub when marking them done in the TUI
+# (default: false). D marks an item done without touching GitHub.
+# sync_read_on_done: false
+
 # Blocked labels - items with these labels appear in the Blocked pane (optional)
 # Default: ["blocked"]. Set to empty list to disable the Blocked pane.
 # blocked_labels:
 #   - blocked
 #   - on-hold
 
+# Points added to items with a label; negative values lower the score (optional)
+# label_scores:
+#   security: 40
+#   p0: 60
+#   chore: -20
+
+# Points added to items in a column of a project board, matched against the
+# board's Status field (optional). Needs the read:project token scope.
+# project_column_scores:
+#   In Review: 30
+#   Backlog: -10
+
+# Lowest priority for items carrying a severity label, applied after scoring (optional)
+# Priorities: urgent, important, quick-win, notable, fyi
+# severity_labels:
+#   P0: urgent
+#   sev1: urgent
+#   P1: important
+
 # Override scoring weights (optional)
 # base_scores:
 #   review_requested: 100
+#   team_review_requested: 75           # Requested from one of your teams, not you
 #   mention: 90
 
+# Archive items with no activity for this many days (default: 0, never)
+# Archived items are hidden unless you pass --include-archived.
+# scoring:
+#   archive_after_days: 180
+#   normalize_scores: true              # Show scores on a 0-100 scale; see 'triage calibrate'
+#   reminder_bonus: 50                  # Added once a reminder from 'triage remind' is due
+#   team_review_bonus: 20               # PRs asking one of your teams for a review
+#   codeowners_bonus: 20                # ...that change files CODEOWNERS gives you or your teams
+#   codeowners_penalty: -20             # ...that change none of them
+#   blocked_penalty: -25                # Waiting on an open dependency or the PR it is stacked on
+#   unblocks_bonus: 20                  # Other items in your list are waiting on it
+#   milestone_due_soon_bonus: 25        # The milestone is due within milestone_due_soon_days, or overdue
+#   milestone_due_soon_days: 7
+
+# Issue form fields (optional)
+# Answers to issue forms are read from issue bodies. Keep the fields you
+# want per repo, for --fields formFields.<label> and --form-field, and
+# score their answers.
+# issue_forms:
+#   myorg/app:
+#     fields: [Version, Severity]
+#     scores:
+#       Severity:
+#         critical: 50
+#         low: -10
+
+# Per-repository scoring, layered over the settings above (optional)
+# Each repo takes base_scores, scoring, and pr sections.
+# repo_overrides:
+#   myorg/critical-repo:
+#     base_scores:
+#       mention: 200
+#     pr:
+#       stale_threshold_days: 2
+
 # Orphaned contribution detection
 # Requires repos to be specified - no auto-discovery
 # orphaned:
@@ -959,6 +2300,63 @@ default_format: table
 #   stale_days: 7                       # Days without team response
 #   consecutive_author_comments: 2      # Consecutive unanswered comments
 #   max_items_per_repo: 100             # Limit per repository
+#   rules:                              # A contribution is orphaned when it meets every enabled rule
+#     external_author: true             # Author isn't a member, owner, collaborator, or in team
+#     no_team_response: true            # No team response in stale_days, or unanswered author comments
+#     no_assignee: false                # Nobody is assigned
+#     no_triage_label: false            # None of triage_labels (or no labels at all when unset)
+#   repo_rules:                         # Rules for one repository, over the ones above
+#     myorg/repo2:
+#       no_assignee: true
+#   triage_labels: [triaged, accepted]  # Labels that mean someone triaged it
+#   team: [alice, bob]                  # Logins counted as the team, besides members and collaborators
+#   teams: [myorg/maintainers]          # GitHub teams (org/team-slug) counted as the team; needs read:org
+
+# Work waiting on another repository (optional)
+# The work is raised in your list once the upstream release ships or the
+# issue or PR closes. Set one of release, after, or number per entry.
+# upstream:
+#   - repo: acme/lib                    # Repository you're waiting on
+#     blocks: acme/app                  # Your repository that is waiting
+#     release: v2.0.0                   # A specific release tag
+#   - repo: acme/sdk
+#     blocks: acme/app
+#     after: v1.4.0                     # Any release after this tag
+#   - repo: acme/api
+#     blocks: acme/web
+#     number: 412                       # An issue or PR closing
+
+# Confirmation prompts for actions that change things on GitHub, and for large fetches
+# Values: always, never, or a threshold like ">10" (prompt when more items are affected)
+# confirmations:
+#   mark_read_bulk: ">10"
+#   comment: never
+#   delegate: never                     # Assigning items to a teammate with A in the TUI
+#   large_fetch: ">2000"                # Notifications listed, e.g. with --since 1y
+
+# Desktop notifications from 'triage notify desktop' (optional)
+# notifications:
+#   min_priority: urgent                # Notify when an item reaches this priority or higher
+#   quiet_hours: "22:00-08:00"          # Local times to hold notifications until
+
+# Release branches ('triage release')
+# Merged PRs with one of these labels, or a label naming the branch, need a backport.
+# release:
+#   backport_labels: [backport, needs-backport]
+
+# Handing items off to a teammate with A in the TUI (optional)
+# The comment is a Go template with .To, .From, .Title, .Ref, and .URL.
+# delegation:
+#   roster: [alice, bob]                # Logins offered as teammates
+#   teams: [myorg/maintainers]          # GitHub teams whose members are offered too; needs read:org
+#   comment: "@{{.To}} could you take this one? Handing it off from my queue."
+
+# Self-update (triage self-update)
+# Disable when triage is installed by a package manager or managed centrally.
+# verify_signature requires cosign on PATH.
+# self_update:
+#   enabled: true
+#   verify_signature: false
 
 # See README.md for full configuration options
 `
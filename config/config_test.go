@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -19,6 +20,7 @@ func TestDefaultScoreWeights(t *testing.T) {
 		want int
 	}{
 		{"ReviewRequested", weights.ReviewRequested, 100},
+		{"TeamReviewRequested", weights.TeamReviewRequested, 75},
 		{"Mention", weights.Mention, 90},
 		{"TeamMention", weights.TeamMention, 85},
 		{"Author", weights.Author, 70},
@@ -29,7 +31,17 @@ func TestDefaultScoreWeights(t *testing.T) {
 		{"CIActivity", weights.CIActivity, 5},
 		{"OldUnreadBonus", weights.OldUnreadBonus, 2},
 		{"HotTopicBonus", weights.HotTopicBonus, 15},
+		{"HotTopicVelocityBonus", weights.HotTopicVelocityBonus, 20},
+		{"HotTopicVelocityThreshold", weights.HotTopicVelocityThreshold, 4},
 		{"LowHangingBonus", weights.LowHangingBonus, 20},
+		{"ReminderBonus", weights.ReminderBonus, 50},
+		{"TeamReviewBonus", weights.TeamReviewBonus, 20},
+		{"CodeOwnersBonus", weights.CodeOwnersBonus, 20},
+		{"CodeOwnersPenalty", weights.CodeOwnersPenalty, -20},
+		{"BlockedPenalty", weights.BlockedPenalty, -25},
+		{"UnblocksBonus", weights.UnblocksBonus, 20},
+		{"MilestoneDueSoonBonus", weights.MilestoneDueSoonBonus, 25},
+		{"MilestoneDueSoonDays", weights.MilestoneDueSoonDays, 7},
 		{"OpenStateBonus", weights.OpenStateBonus, 10},
 		{"ClosedStatePenalty", weights.ClosedStatePenalty, -30},
 		// New authored PR modifiers
@@ -44,6 +56,7 @@ func TestDefaultScoreWeights(t *testing.T) {
 		{"DraftPRPenalty", weights.DraftPRPenalty, -25},
 		// General scoring
 		{"MaxAgeBonus", weights.MaxAgeBonus, 30},
+		{"ArchiveAfterDays", weights.ArchiveAfterDays, 0},
 		// Low-hanging fruit detection
 		{"SmallPRMaxFiles", weights.SmallPRMaxFiles, 5},
 		{"SmallPRMaxLines", weights.SmallPRMaxLines, 100},
@@ -58,6 +71,14 @@ func TestDefaultScoreWeights(t *testing.T) {
 	}
 }
 
+func TestDefaultScoreWeightsQuickWinKinds(t *testing.T) {
+	weights := DefaultScoreWeights()
+	if !weights.DocsOnlyPRIsQuickWin || !weights.TestsOnlyPRIsQuickWin || !weights.DependencyBumpIsQuickWin {
+		t.Errorf("DefaultScoreWeights() quick win kinds = %v/%v/%v, want all enabled",
+			weights.DocsOnlyPRIsQuickWin, weights.TestsOnlyPRIsQuickWin, weights.DependencyBumpIsQuickWin)
+	}
+}
+
 func TestGetScoreWeights(t *testing.T) {
 	t.Run("returns defaults when no overrides", func(t *testing.T) {
 		cfg := &Config{}
@@ -109,15 +130,28 @@ func TestGetScoreWeights(t *testing.T) {
 		}
 	})
 
+	t.Run("enables score normalization", func(t *testing.T) {
+		normalize := true
+		cfg := &Config{Scoring: &ScoringOverrides{NormalizeScores: &normalize}}
+		if !cfg.GetScoreWeights().NormalizeScores {
+			t.Error("GetScoreWeights().NormalizeScores = false, want true")
+		}
+		if (&Config{}).GetScoreWeights().NormalizeScores {
+			t.Error("GetScoreWeights().NormalizeScores = true by default, want false")
+		}
+	})
+
 	t.Run("merges PR overrides", func(t *testing.T) {
 		approvedBonus := 50
 		staleDays := 5
 		smallFiles := 10
+		testsOnly := false
 		cfg := &Config{
 			PR: &PROverrides{
 				ApprovedBonus:      &approvedBonus,
 				StaleThresholdDays: &staleDays,
 				SmallMaxFiles:      &smallFiles,
+				QuickWinTestsOnly:  &testsOnly,
 			},
 		}
 		weights := cfg.GetScoreWeights()
@@ -132,6 +166,9 @@ func TestGetScoreWeights(t *testing.T) {
 		if weights.SmallPRMaxFiles != 10 {
 			t.Errorf("GetScoreWeights().SmallPRMaxFiles = %d, want 10", weights.SmallPRMaxFiles)
 		}
+		if weights.TestsOnlyPRIsQuickWin {
+			t.Error("GetScoreWeights().TestsOnlyPRIsQuickWin = true, want false")
+		}
 
 		// Default values preserved
 		if weights.MergeablePRBonus != 15 {
@@ -180,6 +217,453 @@ func TestGetQuickWinLabels(t *testing.T) {
 	})
 }
 
+func TestGetConfirmations(t *testing.T) {
+	t.Run("returns defaults when not configured", func(t *testing.T) {
+		cfg := &Config{}
+		got := cfg.GetConfirmations()
+		if got != DefaultConfirmationSettings() {
+			t.Errorf("GetConfirmations() = %+v, want defaults %+v", got, DefaultConfirmationSettings())
+		}
+	})
+
+	t.Run("overrides only configured actions", func(t *testing.T) {
+		always := "always"
+		cfg := &Config{
+			Confirmations: &ConfirmationOverrides{Comment: &always},
+		}
+		got := cfg.GetConfirmations()
+		if got.Comment != "always" {
+			t.Errorf("GetConfirmations().Comment = %q, want 'always'", got.Comment)
+		}
+		if got.Delegate != DefaultConfirmationSettings().Delegate {
+			t.Errorf("GetConfirmations().Delegate = %q, want default", got.Delegate)
+		}
+	})
+
+	t.Run("local overrides merge with global", func(t *testing.T) {
+		always, threshold, fetch := "always", ">5", ">500"
+		global := &Config{Confirmations: &ConfirmationOverrides{Delegate: &always, LargeFetch: &fetch}}
+		local := &Config{Confirmations: &ConfirmationOverrides{MarkReadBulk: &threshold}}
+
+		got := mergeConfig(global, local).GetConfirmations()
+		if got.Delegate != "always" || got.MarkReadBulk != ">5" || got.LargeFetch != ">500" {
+			t.Errorf("merged confirmations = %+v, want delegate=always mark_read_bulk=>5 large_fetch=>500", got)
+		}
+	})
+}
+
+func TestGetReviewSLO(t *testing.T) {
+	if got := (&Config{}).GetReviewSLO(); got != DefaultReviewSLOSettings() {
+		t.Errorf("GetReviewSLO() = %+v, want defaults %+v", got, DefaultReviewSLOSettings())
+	}
+
+	target, window := "4h", "2w"
+	global := &Config{ReviewSLO: &ReviewSLOOverrides{Target: &target}}
+	local := &Config{ReviewSLO: &ReviewSLOOverrides{Window: &window}}
+	got := mergeConfig(global, local).GetReviewSLO()
+	if got.Target != "4h" || got.Window != "2w" {
+		t.Errorf("merged review SLO = %+v, want target=4h window=2w", got)
+	}
+}
+
+func TestGetAccessibility(t *testing.T) {
+	if got := (&Config{}).GetAccessibility(); got.StatusMarkers {
+		t.Error("GetAccessibility() enables status markers by default")
+	}
+
+	on := true
+	local := &Config{Accessibility: &AccessibilityOverrides{StatusMarkers: &on}}
+	if got := mergeConfig(&Config{}, local).GetAccessibility(); !got.StatusMarkers {
+		t.Error("merged GetAccessibility() dropped status_markers from the local config")
+	}
+}
+
+func TestGetMinimalRedraw(t *testing.T) {
+	if (&Config{}).GetMinimalRedraw() {
+		t.Error("GetMinimalRedraw() is on by default")
+	}
+
+	on, off := true, false
+	global := &Config{UI: &UIPreferences{MinimalRedraw: &on}}
+	if !mergeConfig(global, &Config{}).GetMinimalRedraw() {
+		t.Error("merged GetMinimalRedraw() dropped minimal_redraw from the global config")
+	}
+	local := &Config{UI: &UIPreferences{MinimalRedraw: &off}}
+	if mergeConfig(global, local).GetMinimalRedraw() {
+		t.Error("merged GetMinimalRedraw() ignored the local config turning it off")
+	}
+}
+
+func TestGetTruncation(t *testing.T) {
+	if got := (&Config{}).GetTruncation(); got != DefaultTruncationSettings() {
+		t.Errorf("GetTruncation() = %+v, want defaults", got)
+	}
+
+	end := "end"
+	local := &Config{Truncation: &TruncationOverrides{Author: &end}}
+	got := mergeConfig(&Config{}, local).GetTruncation()
+	if got.Author != "end" || got.Repository != "middle" {
+		t.Errorf("merged GetTruncation() = %+v, want author=end repository=middle", got)
+	}
+}
+
+func TestGetSelfUpdate(t *testing.T) {
+	disabled := false
+	verify := true
+
+	tests := []struct {
+		name   string
+		global *SelfUpdateOverrides
+		local  *SelfUpdateOverrides
+		want   SelfUpdateSettings
+	}{
+		{"defaults", nil, nil, DefaultSelfUpdateSettings()},
+		{"global opt-out", &SelfUpdateOverrides{Enabled: &disabled}, nil, SelfUpdateSettings{Enabled: false}},
+		{
+			"local adds verification",
+			&SelfUpdateOverrides{Enabled: &disabled},
+			&SelfUpdateOverrides{VerifySignature: &verify},
+			SelfUpdateSettings{Enabled: false, VerifySignature: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{SelfUpdate: tt.global}, &Config{SelfUpdate: tt.local}).GetSelfUpdate()
+			if got != tt.want {
+				t.Errorf("GetSelfUpdate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEmail(t *testing.T) {
+	host := "smtp.example.com"
+	port := 465
+	to := []string{"me@example.com"}
+	sendmail := "/usr/sbin/sendmail"
+
+	tests := []struct {
+		name   string
+		global *EmailOverrides
+		local  *EmailOverrides
+		want   EmailSettings
+	}{
+		{"defaults", nil, nil, DefaultEmailSettings()},
+		{
+			"global smtp",
+			&EmailOverrides{SMTPHost: &host, SMTPPort: &port, To: &to},
+			nil,
+			EmailSettings{SMTPHost: host, SMTPPort: 465, To: to, PasswordEnv: "TRIAGE_SMTP_PASSWORD"},
+		},
+		{
+			"local adds sendmail",
+			&EmailOverrides{SMTPHost: &host},
+			&EmailOverrides{Sendmail: &sendmail},
+			EmailSettings{SMTPHost: host, SMTPPort: 587, PasswordEnv: "TRIAGE_SMTP_PASSWORD", Sendmail: sendmail},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{Email: tt.global}, &Config{Email: tt.local}).GetEmail()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetEmail() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetHTTP(t *testing.T) {
+	timeout := "1m"
+	noRetries := 0
+	backoff := "250ms"
+
+	tests := []struct {
+		name   string
+		global *HTTPOverrides
+		local  *HTTPOverrides
+		want   HTTPSettings
+	}{
+		{"defaults", nil, nil, DefaultHTTPSettings()},
+		{
+			"global timeout",
+			&HTTPOverrides{Timeout: &timeout},
+			nil,
+			HTTPSettings{Timeout: "1m", MaxRetries: 2, RetryBackoff: "1s", RetryMaxBackoff: "10s"},
+		},
+		{
+			"local disables retries",
+			&HTTPOverrides{RetryBackoff: &backoff},
+			&HTTPOverrides{MaxRetries: &noRetries},
+			HTTPSettings{Timeout: "30s", MaxRetries: 0, RetryBackoff: "250ms", RetryMaxBackoff: "10s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{HTTP: tt.global}, &Config{HTTP: tt.local}).GetHTTP()
+			if got != tt.want {
+				t.Errorf("GetHTTP() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCacheMaxSizeMB(t *testing.T) {
+	small, zero := 64, 0
+
+	tests := []struct {
+		name   string
+		global *CacheOverrides
+		local  *CacheOverrides
+		want   int
+	}{
+		{"default", nil, nil, DefaultCacheMaxSizeMB},
+		{"global limit", &CacheOverrides{MaxSizeMB: &small}, nil, 64},
+		{"zero uses the default", &CacheOverrides{MaxSizeMB: &small}, &CacheOverrides{MaxSizeMB: &zero}, DefaultCacheMaxSizeMB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{Cache: tt.global}, &Config{Cache: tt.local}).GetCacheMaxSizeMB()
+			if got != tt.want {
+				t.Errorf("GetCacheMaxSizeMB() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNotifications(t *testing.T) {
+	important := "important"
+	quiet := "22:00-08:00"
+
+	tests := []struct {
+		name   string
+		global *NotificationOverrides
+		local  *NotificationOverrides
+		want   NotificationSettings
+	}{
+		{"defaults", nil, nil, DefaultNotificationSettings()},
+		{
+			"global quiet hours",
+			&NotificationOverrides{QuietHours: &quiet},
+			nil,
+			NotificationSettings{MinPriority: "urgent", QuietHours: quiet},
+		},
+		{
+			"local lowers the priority",
+			&NotificationOverrides{QuietHours: &quiet},
+			&NotificationOverrides{MinPriority: &important},
+			NotificationSettings{MinPriority: important, QuietHours: quiet},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{Notifications: tt.global}, &Config{Notifications: tt.local}).GetNotifications()
+			if got != tt.want {
+				t.Errorf("GetNotifications() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRelease(t *testing.T) {
+	labels := []string{"cherry-pick"}
+
+	tests := []struct {
+		name   string
+		global *ReleaseOverrides
+		local  *ReleaseOverrides
+		want   ReleaseSettings
+	}{
+		{"defaults", nil, nil, DefaultReleaseSettings()},
+		{"local labels", nil, &ReleaseOverrides{BackportLabels: &labels}, ReleaseSettings{BackportLabels: labels}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{Release: tt.global}, &Config{Release: tt.local}).GetRelease()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRelease() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDelegation(t *testing.T) {
+	roster, teams, comment := []string{"alice"}, []string{"org/maintainers"}, "@{{.To}} over to you"
+
+	tests := []struct {
+		name   string
+		global *DelegationOverrides
+		local  *DelegationOverrides
+		want   DelegationSettings
+	}{
+		{"unset", nil, nil, DelegationSettings{}},
+		{
+			"local comment over global roster",
+			&DelegationOverrides{Roster: &roster, Teams: &teams},
+			&DelegationOverrides{Comment: &comment},
+			DelegationSettings{Roster: roster, Teams: teams, Comment: comment},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{Delegation: tt.global}, &Config{Delegation: tt.local}).GetDelegation()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDelegation() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetOrphanedRules(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name   string
+		global *OrphanedConfig
+		local  *OrphanedConfig
+		repo   string
+		want   OrphanedRuleSettings
+	}{
+		{"defaults", nil, nil, "acme/api", DefaultOrphanedRuleSettings()},
+		{
+			"global rules",
+			&OrphanedConfig{Rules: &OrphanedRuleOverrides{NoAssignee: &yes}},
+			nil,
+			"acme/api",
+			OrphanedRuleSettings{ExternalAuthor: true, NoTeamResponse: true, NoAssignee: true},
+		},
+		{
+			"local rule merges with global",
+			&OrphanedConfig{Rules: &OrphanedRuleOverrides{NoAssignee: &yes}},
+			&OrphanedConfig{Rules: &OrphanedRuleOverrides{ExternalAuthor: &no}},
+			"acme/api",
+			OrphanedRuleSettings{NoTeamResponse: true, NoAssignee: true},
+		},
+		{
+			"repo rules apply to their repo",
+			&OrphanedConfig{RepoRules: map[string]*OrphanedRuleOverrides{"Acme/Web": {NoTriageLabel: &yes, NoTeamResponse: &no}}},
+			&OrphanedConfig{RepoRules: map[string]*OrphanedRuleOverrides{"Acme/Web": {NoAssignee: &yes}}},
+			"acme/web",
+			OrphanedRuleSettings{ExternalAuthor: true, NoAssignee: true, NoTriageLabel: true},
+		},
+		{
+			"repo rules leave other repos alone",
+			&OrphanedConfig{RepoRules: map[string]*OrphanedRuleOverrides{"acme/web": {NoAssignee: &yes}}},
+			nil,
+			"acme/api",
+			DefaultOrphanedRuleSettings(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{Orphaned: tt.global}, &Config{Orphaned: tt.local}).GetOrphanedRules(tt.repo)
+			if got != tt.want {
+				t.Errorf("GetOrphanedRules(%q) = %+v, want %+v", tt.repo, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingSource(t *testing.T) {
+	bonus, mention := 30, 200
+	cfg := &Config{
+		Scoring:     &ScoringOverrides{HotTopicBonus: &bonus},
+		LabelScores: map[string]int{"Security": 40},
+		RepoOverrides: map[string]*RepoOverride{
+			"acme/api": {BaseScores: &BaseScoreOverrides{Mention: &mention}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		repo string
+		path []string
+		want string
+	}{
+		{"default", "acme/web", []string{"scoring", "reminder_bonus"}, "default"},
+		{"config", "acme/web", []string{"scoring", "hot_topic_bonus"}, "config"},
+		{"label score", "acme/web", []string{"label_scores", "security"}, "config"},
+		{"repo override", "Acme/API", []string{"base_scores", "mention"}, "repo_overrides.acme/api"},
+		{"other repo", "acme/web", []string{"base_scores", "mention"}, "default"},
+		{"section unset in repo override", "acme/api", []string{"scoring", "hot_topic_bonus"}, "config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.SettingSource(tt.repo, tt.path...); got != tt.want {
+				t.Errorf("SettingSource(%q, %v) = %q, want %q", tt.repo, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNotifiers(t *testing.T) {
+	discord := &DiscordNotifier{WebhookURL: "https://discord.com/api/webhooks/1/a"}
+	teams := &TeamsNotifier{WebhookURL: "https://example.webhook.office.com/x"}
+	matrix := &MatrixNotifier{Homeserver: "https://matrix.org", RoomID: "!room:matrix.org"}
+
+	tests := []struct {
+		name   string
+		global *NotifierOverrides
+		local  *NotifierOverrides
+		want   NotifierSettings
+	}{
+		{"none", nil, nil, NotifierSettings{}},
+		{
+			"local adds a service",
+			&NotifierOverrides{Discord: discord},
+			&NotifierOverrides{Teams: teams},
+			NotifierSettings{Discord: discord, Teams: teams},
+		},
+		{
+			"matrix token default",
+			&NotifierOverrides{Matrix: matrix},
+			nil,
+			NotifierSettings{Matrix: &MatrixNotifier{Homeserver: "https://matrix.org", RoomID: "!room:matrix.org", TokenEnv: DefaultMatrixTokenEnv}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeConfig(&Config{Notifiers: tt.global}, &Config{Notifiers: tt.local}).GetNotifiers()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetNotifiers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if matrix.TokenEnv != "" {
+		t.Error("GetNotifiers() modified the configured Matrix notifier")
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{Notifiers: &NotifierOverrides{
+		Discord: &DiscordNotifier{WebhookURL: "https://discord.com/api/webhooks/1/a"},
+		Teams:   &TeamsNotifier{WebhookURLEnv: "TEAMS_URL"},
+	}}
+
+	got := cfg.Redacted()
+	if got.Notifiers.Discord.WebhookURL != redacted {
+		t.Errorf("Redacted() Discord webhook_url = %q, want %q", got.Notifiers.Discord.WebhookURL, redacted)
+	}
+	if got.Notifiers.Teams.WebhookURL != "" || got.Notifiers.Teams.WebhookURLEnv != "TEAMS_URL" {
+		t.Errorf("Redacted() Teams = %+v, want the env var name kept", got.Notifiers.Teams)
+	}
+	if cfg.Notifiers.Discord.WebhookURL != "https://discord.com/api/webhooks/1/a" {
+		t.Error("Redacted() modified the config")
+	}
+	if (&Config{}).Redacted().Notifiers != nil {
+		t.Error("Redacted() added notifiers")
+	}
+}
+
 func TestGetDependencyAuthors(t *testing.T) {
 	contains := func(list []string, want string) bool {
 		for _, a := range list {
@@ -224,6 +708,17 @@ func TestGetDependencyAuthors(t *testing.T) {
 	})
 }
 
+func TestGetBotAuthors(t *testing.T) {
+	cfg := &Config{
+		DependencyAuthors: []string{"renovate[bot]"},
+		BotAuthors:        []string{"ci-helper", "Renovate[bot]"},
+	}
+	want := []string{"dependabot[bot]", "renovate[bot]", "ci-helper"}
+	if got := cfg.GetBotAuthors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetBotAuthors() = %v, want %v", got, want)
+	}
+}
+
 func TestDefaultQuickWinLabels(t *testing.T) {
 	labels := DefaultQuickWinLabels()
 
@@ -316,6 +811,98 @@ func TestDefaultConfigDir_XDG(t *testing.T) {
 	})
 }
 
+func TestSaveScoring(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+	existing := `base_scores:
+  mention: 50
+  author: 70
+scoring:
+  hot_topic_bonus: 5
+exclude_repos:
+  - acme/infra
+`
+	if err := SaveTo(configPath(), existing); err != nil {
+		t.Fatal(err)
+	}
+
+	mention, important, urgent := 80, 300, false
+	edits := &Config{
+		BaseScores: &BaseScoreOverrides{Mention: &mention},
+		Scoring:    &ScoringOverrides{ImportantPromotionThreshold: &important},
+		Urgency:    &UrgencyOverrides{Mention: &urgent},
+	}
+	path, err := edits.SaveScoring()
+	if err != nil {
+		t.Fatalf("SaveScoring() error = %v", err)
+	}
+	if path != configPath() {
+		t.Errorf("SaveScoring() wrote %q, want the global config %q", path, configPath())
+	}
+
+	cfg, err := LoadGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := cfg.GetScoreWeights()
+	if w.Mention != 80 || w.Author != 70 || w.HotTopicBonus != 5 || w.ImportantPromotionThreshold != 300 || w.MentionIsUrgent {
+		t.Errorf("saved weights = mention %d, author %d, hot topic %d, important %d, mention urgent %v",
+			w.Mention, w.Author, w.HotTopicBonus, w.ImportantPromotionThreshold, w.MentionIsUrgent)
+	}
+	if !reflect.DeepEqual(cfg.ExcludeRepos, []string{"acme/infra"}) {
+		t.Errorf("ExcludeRepos = %v, want the existing setting kept", cfg.ExcludeRepos)
+	}
+}
+
+func TestSaveScoringLocal(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name  string
+		local string
+		want  string
+	}{
+		{"local sets scoring", "scoring:\n  hot_topic_bonus: 5\n", localConfigPath()},
+		{"local sets urgency", "urgency:\n  mention: true\n", localConfigPath()},
+		{"local sets other settings", "exclude_repos:\n  - acme/infra\n", configPath()},
+		{"no local file", "", configPath()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(localConfigPath())
+			os.Remove(configPath())
+			if tt.local != "" {
+				if err := os.WriteFile(localConfigPath(), []byte(tt.local), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			mention := 80
+			edits := &Config{BaseScores: &BaseScoreOverrides{Mention: &mention}}
+			path, err := edits.SaveScoring()
+			if err != nil {
+				t.Fatalf("SaveScoring() error = %v", err)
+			}
+			if path != tt.want {
+				t.Errorf("SaveScoring() wrote %q, want %q", path, tt.want)
+			}
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cfg.GetScoreWeights().Mention; got != 80 {
+				t.Errorf("loaded mention = %d, want the saved 80", got)
+			}
+		})
+	}
+}
+
 func TestDefaultConfigDir_CrossPlatform(t *testing.T) {
 	got := defaultConfigDir()
 
@@ -382,6 +969,29 @@ quick_win_labels:
 	})
 }
 
+func TestUpstreamConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		u       UpstreamConfig
+		wantErr bool
+	}{
+		{"release", UpstreamConfig{Repo: "acme/lib", Release: "v2.0.0"}, false},
+		{"after", UpstreamConfig{Repo: "acme/lib", After: "v1.4.0"}, false},
+		{"number", UpstreamConfig{Repo: "acme/lib", Number: 12}, false},
+		{"none", UpstreamConfig{Repo: "acme/lib"}, true},
+		{"release and after", UpstreamConfig{Repo: "acme/lib", Release: "v2.0.0", After: "v1.4.0"}, true},
+		{"after and number", UpstreamConfig{Repo: "acme/lib", After: "v1.4.0", Number: 12}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.u.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestGetConfigPaths(t *testing.T) {
 	paths := GetConfigPaths()
 
@@ -580,6 +1190,202 @@ func TestMergeConfig(t *testing.T) {
 			t.Errorf("mergeConfig().QuickWinLabels = %v, want ['global-label']", result.QuickWinLabels)
 		}
 	})
+
+	t.Run("local project column scores override global per column", func(t *testing.T) {
+		global := &Config{ProjectColumnScores: map[string]int{"In Review": 30, "Backlog": -10}}
+		local := &Config{ProjectColumnScores: map[string]int{"In Review": 50}}
+
+		result := mergeConfig(global, local)
+
+		want := map[string]int{"In Review": 50, "Backlog": -10}
+		if !reflect.DeepEqual(result.ProjectColumnScores, want) {
+			t.Errorf("mergeConfig().ProjectColumnScores = %v, want %v", result.ProjectColumnScores, want)
+		}
+	})
+
+	t.Run("local label scores override global per label", func(t *testing.T) {
+		global := &Config{LabelScores: map[string]int{"security": 40, "chore": -20}}
+		local := &Config{LabelScores: map[string]int{"security": 60}}
+
+		result := mergeConfig(global, local)
+
+		want := map[string]int{"security": 60, "chore": -20}
+		if !reflect.DeepEqual(result.LabelScores, want) {
+			t.Errorf("mergeConfig().LabelScores = %v, want %v", result.LabelScores, want)
+		}
+		if global.LabelScores["security"] != 40 {
+			t.Error("mergeConfig() modified the global label scores")
+		}
+	})
+
+	t.Run("local severity labels override global per label", func(t *testing.T) {
+		global := &Config{SeverityLabels: map[string]string{"P0": "urgent", "P1": "important"}}
+		local := &Config{SeverityLabels: map[string]string{"P1": "urgent"}}
+
+		result := mergeConfig(global, local)
+
+		want := map[string]string{"P0": "urgent", "P1": "urgent"}
+		if !reflect.DeepEqual(result.SeverityLabels, want) {
+			t.Errorf("mergeConfig().SeverityLabels = %v, want %v", result.SeverityLabels, want)
+		}
+	})
+
+	t.Run("local repo overrides merge per section", func(t *testing.T) {
+		mention, author, stale := 200, 150, 2
+		global := &Config{RepoOverrides: map[string]*RepoOverride{
+			"org/a": {BaseScores: &BaseScoreOverrides{Mention: &mention}},
+			"org/b": {PR: &PROverrides{StaleThresholdDays: &stale}},
+		}}
+		local := &Config{RepoOverrides: map[string]*RepoOverride{
+			"org/a": {BaseScores: &BaseScoreOverrides{Author: &author}},
+		}}
+
+		result := mergeConfig(global, local)
+
+		a := result.RepoOverrides["org/a"]
+		if a == nil || a.BaseScores == nil || a.BaseScores.Mention == nil || *a.BaseScores.Mention != 200 ||
+			a.BaseScores.Author == nil || *a.BaseScores.Author != 150 {
+			t.Errorf("mergeConfig().RepoOverrides[org/a] = %+v, want mention from global and author from local", a)
+		}
+		if result.RepoOverrides["org/b"] != global.RepoOverrides["org/b"] {
+			t.Error("mergeConfig() dropped a repo only the global config overrides")
+		}
+		if global.RepoOverrides["org/a"].BaseScores.Author != nil {
+			t.Error("mergeConfig() modified the global repo overrides")
+		}
+	})
+
+	t.Run("local workspaces override global per name", func(t *testing.T) {
+		global := &Config{Workspaces: map[string][]string{"platform": {"org/a"}, "web": {"org/w"}}}
+		local := &Config{Workspaces: map[string][]string{"platform": {"org/b"}}}
+
+		result := mergeConfig(global, local)
+
+		want := map[string][]string{"platform": {"org/b"}, "web": {"org/w"}}
+		if !reflect.DeepEqual(result.Workspaces, want) {
+			t.Errorf("mergeConfig().Workspaces = %v, want %v", result.Workspaces, want)
+		}
+	})
+}
+
+func TestExpandRepos(t *testing.T) {
+	cfg := &Config{Workspaces: map[string][]string{
+		"platform": {"org/a", "org/b"},
+		"core":     {"org/B", "org/c"},
+	}}
+
+	tests := []struct {
+		name        string
+		repos       []string
+		want        []string
+		wantUnknown []string
+	}{
+		{"plain repos", []string{"org/x"}, []string{"org/x"}, nil},
+		{"workspace", []string{"@platform"}, []string{"org/a", "org/b"}, nil},
+		{"overlapping workspaces", []string{"@platform", "@core", "org/a"}, []string{"org/a", "org/b", "org/c"}, nil},
+		{"unknown workspace", []string{"@nope", "org/x"}, []string{"org/x"}, []string{"nope"}},
+		{"empty", nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unknown := cfg.ExpandRepos(tt.repos)
+			if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(unknown, tt.wantUnknown) {
+				t.Errorf("ExpandRepos(%v) = %v, %v; want %v, %v", tt.repos, got, unknown, tt.want, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestGetScoreWeightsLabelScores(t *testing.T) {
+	var cfg Config
+	if err := yaml.Unmarshal([]byte("label_scores:\n  Security: +40\n  chore: -20\n"), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	got := cfg.GetScoreWeights().LabelScores
+	want := map[string]int{"security": 40, "chore": -20}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetScoreWeights().LabelScores = %v, want %v", got, want)
+	}
+	if DefaultScoreWeights().LabelScores != nil {
+		t.Error("DefaultScoreWeights().LabelScores should be empty")
+	}
+}
+
+func TestGetScoreWeightsSeverityFloors(t *testing.T) {
+	var cfg Config
+	if err := yaml.Unmarshal([]byte("severity_labels:\n  P0: urgent\n  sev2: important\n"), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	got := cfg.GetScoreWeights().SeverityFloors
+	want := map[string]string{"p0": "urgent", "sev2": "important"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetScoreWeights().SeverityFloors = %v, want %v", got, want)
+	}
+}
+
+func TestGetScoreWeightsRepoOverrides(t *testing.T) {
+	var cfg Config
+	data := `
+base_scores:
+  mention: 90
+  author: 70
+repo_overrides:
+  Org/Critical:
+    base_scores:
+      mention: 200
+    pr:
+      draft_penalty: 0
+`
+	if err := yaml.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	weights := cfg.GetScoreWeights()
+	if weights.Mention != 90 {
+		t.Errorf("GetScoreWeights().Mention = %d, want 90", weights.Mention)
+	}
+	repo, ok := weights.RepoWeights["org/critical"]
+	if !ok {
+		t.Fatalf("GetScoreWeights().RepoWeights = %v, want weights for org/critical", weights.RepoWeights)
+	}
+	if repo.Mention != 200 || repo.DraftPRPenalty != 0 {
+		t.Errorf("repo weights Mention = %d, DraftPRPenalty = %d; want the repo's overrides", repo.Mention, repo.DraftPRPenalty)
+	}
+	if repo.Author != 70 {
+		t.Errorf("repo weights Author = %d, want 70 from the top level", repo.Author)
+	}
+	if repo.RepoWeights != nil {
+		t.Error("repo weights should not nest other repos' weights")
+	}
+}
+
+func TestIssueForms(t *testing.T) {
+	var cfg Config
+	data := `
+issue_forms:
+  Acme/App:
+    fields: [Version, Severity]
+    scores:
+      Severity:
+        Critical: 50
+  acme/web:
+    fields: [Browser]
+`
+	if err := yaml.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	wantFields := map[string][]string{"acme/app": {"Version", "Severity"}, "acme/web": {"Browser"}}
+	if got := cfg.GetIssueFormFields(); !reflect.DeepEqual(got, wantFields) {
+		t.Errorf("GetIssueFormFields() = %v, want %v", got, wantFields)
+	}
+	wantScores := map[string]map[string]map[string]int{"acme/app": {"severity": {"critical": 50}}}
+	if got := cfg.GetScoreWeights().FormFieldScores; !reflect.DeepEqual(got, wantScores) {
+		t.Errorf("GetScoreWeights().FormFieldScores = %v, want %v", got, wantScores)
+	}
 }
 
 func TestDefaultScoreWeightsUrgency(t *testing.T) {
@@ -714,3 +1520,142 @@ func TestMergePointerStruct(t *testing.T) {
 		}
 	})
 }
+
+func TestSetVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "after header comments",
+			in:   "# Triage config\n\ndefault_format: json\n",
+			want: "# Triage config\n\nversion: 1\n\ndefault_format: json\n",
+		},
+		{
+			name: "no comments",
+			in:   "exclude_repos:\n  - a/b\n",
+			want: "version: 1\n\nexclude_repos:\n  - a/b\n",
+		},
+		{
+			name: "only comments",
+			in:   "# nothing set",
+			want: "# nothing set\nversion: 1\n",
+		},
+		{
+			name: "empty",
+			in:   "",
+			want: "version: 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(setVersion([]byte(tt.in), 1))
+			if got != tt.want {
+				t.Errorf("setVersion() = %q, want %q", got, tt.want)
+			}
+			v, err := detectVersion([]byte(got))
+			if err != nil || v != 1 {
+				t.Errorf("detectVersion() = %d, %v; want 1", v, err)
+			}
+		})
+	}
+}
+
+func TestPreset(t *testing.T) {
+	blocked := []string{"blocked"}
+	cfg := &Config{
+		DefaultFormat:  "json",
+		ExcludeRepos:   []string{"me/dotfiles"},
+		ExcludeAuthors: []string{"someone"},
+		QuickWinLabels: []string{"easy"},
+		BlockedLabels:  &blocked,
+		LabelScores:    map[string]int{"security": 40},
+		Workspaces:     map[string][]string{"platform": {"acme/api"}},
+		Orphaned:       &OrphanedConfig{Repos: []string{"acme/api"}},
+		UI:             &UIPreferences{QueueSortColumn: "age"},
+	}
+
+	preset := cfg.Preset()
+	if preset.Version != Version {
+		t.Errorf("Preset().Version = %d, want %d", preset.Version, Version)
+	}
+	if preset.DefaultFormat != "" || preset.ExcludeRepos != nil || preset.ExcludeAuthors != nil || preset.UI != nil {
+		t.Errorf("Preset() kept personal settings: %+v", preset)
+	}
+	if !reflect.DeepEqual(preset.QuickWinLabels, cfg.QuickWinLabels) ||
+		preset.BlockedLabels != cfg.BlockedLabels ||
+		!reflect.DeepEqual(preset.LabelScores, cfg.LabelScores) ||
+		!reflect.DeepEqual(preset.Workspaces, cfg.Workspaces) ||
+		preset.Orphaned != cfg.Orphaned {
+		t.Errorf("Preset() dropped team settings: %+v", preset)
+	}
+}
+
+func TestLoad_PresetLayering(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+	original, _ := os.Getwd()
+	defer os.Chdir(original)
+	os.Chdir(t.TempDir())
+
+	preset := `default_format: json
+exclude_repos:
+  - lead/dotfiles
+quick_win_labels:
+  - team-easy
+label_scores:
+  security: 40
+  docs: -5
+`
+	installed, err := ImportPreset([]byte(preset))
+	if err != nil {
+		t.Fatalf("ImportPreset() error = %v", err)
+	}
+	if installed.DefaultFormat != "" || installed.ExcludeRepos != nil {
+		t.Errorf("ImportPreset() kept personal settings: %+v", installed)
+	}
+	if !GetConfigPaths().PresetExists {
+		t.Error("GetConfigPaths().PresetExists = false after ImportPreset")
+	}
+
+	global := "label_scores:\n  docs: 0\n"
+	if err := SaveTo(configPath(), global); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(".triage.yaml", []byte("quick_win_labels:\n  - local-easy\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DefaultFormat != "table" || cfg.ExcludeRepos != nil {
+		t.Errorf("Load() picked up personal settings from the preset: format %q, excludes %v", cfg.DefaultFormat, cfg.ExcludeRepos)
+	}
+	if want := map[string]int{"security": 40, "docs": 0}; !reflect.DeepEqual(cfg.LabelScores, want) {
+		t.Errorf("Load().LabelScores = %v, want %v", cfg.LabelScores, want)
+	}
+	if want := []string{"local-easy"}; !reflect.DeepEqual(cfg.QuickWinLabels, want) {
+		t.Errorf("Load().QuickWinLabels = %v, want %v", cfg.QuickWinLabels, want)
+	}
+
+	// Saving the global file leaves the preset's settings out of it
+	globalCfg, err := LoadGlobal()
+	if err != nil {
+		t.Fatalf("LoadGlobal() error = %v", err)
+	}
+	if err := globalCfg.SetDefaultFormat("plain"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(configPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "security") || strings.Contains(string(data), "local-easy") {
+		t.Errorf("global config after SetDefaultFormat = %s, want only its own settings", data)
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spiffcs/triage/internal/migrate"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the current config file format. Increment it, and add a
+// migration step below, whenever a key is renamed or its meaning changes.
+const Version = 1
+
+// migrationSteps upgrade a config file one format version at a time.
+var migrationSteps = []migrate.Step{
+	{
+		To:          1,
+		Description: "add format version field",
+		Apply: func(data []byte) ([]byte, error) {
+			return setVersion(data, 1), nil
+		},
+	},
+}
+
+// MigrationTargets returns the team preset and the global and local config
+// files as migration targets. Missing files are skipped when the migration runs.
+func MigrationTargets() []migrate.Target {
+	return []migrate.Target{
+		migrate.NewFile("team preset", presetPath(), detectVersion, migrationSteps...),
+		migrate.NewFile("config", configPath(), detectVersion, migrationSteps...),
+		migrate.NewFile("local config", localConfigPath(), detectVersion, migrationSteps...),
+	}
+}
+
+// detectVersion reads the format version of a config file. Files written
+// before versioning have no version key and report 0.
+func detectVersion(data []byte) (int, error) {
+	var v struct {
+		Version int `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return 0, err
+	}
+	return v.Version, nil
+}
+
+// setVersion inserts a top-level version key ahead of the first setting,
+// leaving the user's comments and formatting untouched. It must only be
+// called on files that have no version key yet.
+func setVersion(data []byte, version int) []byte {
+	line := []byte(fmt.Sprintf("version: %d\n", version))
+	lines := bytes.SplitAfter(data, []byte("\n"))
+
+	var out bytes.Buffer
+	inserted := false
+	for _, l := range lines {
+		trimmed := bytes.TrimSpace(l)
+		if !inserted && len(trimmed) > 0 && trimmed[0] != '#' && !bytes.Equal(trimmed, []byte("---")) {
+			out.Write(line)
+			out.WriteString("\n")
+			inserted = true
+		}
+		out.Write(l)
+	}
+	if !inserted {
+		if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+			out.WriteString("\n")
+		}
+		out.Write(line)
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// presetPath returns the path to the installed team preset.
+func presetPath() string {
+	return filepath.Join(defaultConfigDir(), "preset.yaml")
+}
+
+// Preset returns the settings in c a team shares: scoring weights and
+// their per-repo overrides, labels, issue form fields, bot and dependency
+// authors, orphaned detection, and workspaces. Personal
+// settings such as excludes, UI preferences, and notifiers are left out.
+func (c *Config) Preset() *Config {
+	return &Config{
+		Version:             Version,
+		DependencyAuthors:   c.DependencyAuthors,
+		BotAuthors:          c.BotAuthors,
+		QuickWinLabels:      c.QuickWinLabels,
+		BlockedLabels:       c.BlockedLabels,
+		LabelScores:         c.LabelScores,
+		ProjectColumnScores: c.ProjectColumnScores,
+		SeverityLabels:      c.SeverityLabels,
+		Workspaces:          c.Workspaces,
+		RepoOverrides:       c.RepoOverrides,
+		IssueForms:          c.IssueForms,
+		BaseScores:          c.BaseScores,
+		Scoring:             c.Scoring,
+		PR:                  c.PR,
+		Urgency:             c.Urgency,
+		Orphaned:            c.Orphaned,
+	}
+}
+
+// PresetYAML renders the team preset of c, with a header explaining how
+// to install it.
+func (c *Config) PresetYAML() (string, error) {
+	data, err := yaml.Marshal(c.Preset())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal preset: %w", err)
+	}
+	return "# Triage team preset\n" +
+		"# Install with: triage config import-preset <file or URL>\n" +
+		"# Personal config files are layered on top of it.\n\n" + string(data), nil
+}
+
+// ImportPreset installs data as the team preset, which is loaded beneath
+// the global and local config files. Settings that aren't part of a preset
+// are dropped. It returns the preset as installed.
+func ImportPreset(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse preset: %w", err)
+	}
+	preset := cfg.Preset()
+
+	out, err := yaml.Marshal(preset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal preset: %w", err)
+	}
+	if err := os.MkdirAll(defaultConfigDir(), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(presetPath(), out, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write preset: %w", err)
+	}
+	return preset, nil
+}
+
+// LoadGlobal loads only the global config file, without the preset or a
+// local config merged in, for changing and saving it.
+func LoadGlobal() (*Config, error) {
+	cfg := &Config{}
+	if err := loadFile(configPath(), cfg); err != nil {
+		return nil, fmt.Errorf("failed to load global config file: %w", err)
+	}
+	return cfg, nil
+}
+
+// loadFile parses the config file at path into cfg. A missing file leaves
+// cfg unchanged.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return yaml.Unmarshal(data, cfg)
+}
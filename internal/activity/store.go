@@ -0,0 +1,196 @@
+// Package activity tracks when the current user last interacted with each
+// item. GitHub reports comments and reviews; opening an item or replying
+// from triage is recorded locally, since GitHub doesn't expose page views.
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// Version is the current on-disk format of the activity store.
+const Version = 1
+
+const storeName = "activity.json"
+
+// storeFile is the on-disk layout of the activity store.
+type storeFile struct {
+	Version int                  `json:"version"`
+	Entries map[string]time.Time `json:"entries"`
+}
+
+// Store records when the user last opened or replied to items from triage,
+// keyed by Key.
+type Store struct {
+	path    string
+	entries map[string]time.Time
+	mu      sync.RWMutex
+}
+
+// NewStore opens the activity store in the user cache directory.
+func NewStore() (*Store, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreFromPath(filepath.Join(cacheDir, "triage", storeName))
+}
+
+// NewStoreFromPath opens the activity store at the given file path.
+func NewStoreFromPath(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		path:    path,
+		entries: make(map[string]time.Time),
+	}
+	if err := s.load(); err != nil {
+		log.Debug("could not load activity store, starting fresh", "error", err)
+	}
+	return s, nil
+}
+
+// load reads the entries from disk
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Version != Version {
+		return fmt.Errorf("unsupported activity store version %d", file.Version)
+	}
+	if file.Entries != nil {
+		s.entries = file.Entries
+	}
+	return nil
+}
+
+// save writes the entries to disk
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(storeFile{Version: Version, Entries: s.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Record notes that the user interacted with the item identified by key at
+// the given time. Earlier times than the one recorded are ignored.
+func (s *Store) Record(key string, at time.Time) error {
+	if key == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !at.After(s.entries[key]) {
+		return nil
+	}
+	s.entries[key] = at
+	return s.save()
+}
+
+// Last returns when the user last interacted with the item identified by
+// key from triage.
+func (s *Store) Last(key string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	at, ok := s.entries[key]
+	return at, ok
+}
+
+// Key is the item's model.Item.Key, so a PR seen both as a notification
+// and a review request shares one entry. It returns "" for items without
+// a number.
+func Key(item *model.Item) string {
+	if key := item.Key(); key != item.ID {
+		return key
+	}
+	return ""
+}
+
+// RefKey is Key for the issue or PR number in the repository fullName
+// ("owner/repo").
+func RefKey(fullName string, number int) string {
+	if number <= 0 || fullName == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s#%d", strings.ToLower(fullName), number)
+}
+
+// Apply sets LastInteractionAt on each item to the later of the user's
+// last comment or review seen by enrichment and the last time they opened
+// or replied to it from triage. store may be nil.
+func Apply(items []model.Item, login string, store *Store) {
+	for i := range items {
+		item := &items[i]
+		var last time.Time
+		for who, at := range item.ActivityBy {
+			if strings.EqualFold(who, login) && at.After(last) {
+				last = at
+			}
+		}
+		if store != nil {
+			if at, ok := store.Last(Key(item)); ok && at.After(last) {
+				last = at
+			}
+		}
+		if last.IsZero() {
+			item.LastInteractionAt = nil
+			continue
+		}
+		item.LastInteractionAt = &last
+	}
+}
+
+// ApplyHuman sets LastHumanActivityAt on each enriched item to its latest
+// comment, review, or push by someone not in bots, or to when it was opened
+// if nobody else has acted since. GitHub App accounts ("name[bot]") are
+// always bots. Unenriched items are left to fall back on UpdatedAt.
+func ApplyHuman(items []model.Item, bots []string) {
+	for i := range items {
+		item := &items[i]
+		if item.CreatedAt.IsZero() {
+			item.LastHumanActivityAt = nil
+			continue
+		}
+		last := item.CreatedAt
+		for who, at := range item.ActivityBy {
+			if !isBot(who, bots) && at.After(last) {
+				last = at
+			}
+		}
+		item.LastHumanActivityAt = &last
+	}
+}
+
+// isBot reports whether login is a GitHub App or one of bots.
+func isBot(login string, bots []string) bool {
+	if strings.HasSuffix(strings.ToLower(login), "[bot]") {
+		return true
+	}
+	for _, b := range bots {
+		if strings.EqualFold(login, b) {
+			return true
+		}
+	}
+	return false
+}
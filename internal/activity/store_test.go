@@ -0,0 +1,137 @@
+package activity
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestStoreRecordPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "activity.json")
+	store, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatalf("NewStoreFromPath() error: %v", err)
+	}
+
+	opened := time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)
+	if err := store.Record("acme/api#12", opened); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	// An older time doesn't move the record back
+	if err := store.Record("acme/api#12", opened.Add(-time.Hour)); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+
+	reopened, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatalf("NewStoreFromPath() error: %v", err)
+	}
+	if got, ok := reopened.Last("acme/api#12"); !ok || !got.Equal(opened) {
+		t.Errorf("Last() = %v, %v; want %v, true", got, ok, opened)
+	}
+	if _, ok := reopened.Last("acme/api#13"); ok {
+		t.Error("Last() found an item that was never recorded")
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		item model.Item
+		want string
+	}{
+		{
+			name: "enriched",
+			item: model.Item{Number: 12, Repository: model.Repository{FullName: "Acme/API"}},
+			want: "acme/api#12",
+		},
+		{
+			name: "number from subject URL",
+			item: model.Item{
+				Repository: model.Repository{FullName: "acme/api"},
+				Subject:    model.Subject{URL: "https://api.github.com/repos/acme/api/pulls/15"},
+			},
+			want: "acme/api#15",
+		},
+		{
+			name: "no number",
+			item: model.Item{Repository: model.Repository{FullName: "acme/api"}},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Key(&tt.item); got != tt.want {
+				t.Errorf("Key() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	store, err := NewStoreFromPath(filepath.Join(t.TempDir(), "activity.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	commented := time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC)
+	opened := time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+	if err := store.Record("acme/api#2", opened); err != nil {
+		t.Fatal(err)
+	}
+
+	items := []model.Item{
+		// Commented on GitHub only; logins match case-insensitively
+		{Number: 1, Repository: model.Repository{FullName: "acme/api"},
+			ActivityBy: map[string]time.Time{"Octocat": commented, "hubot": opened}},
+		// Opened from triage after commenting
+		{Number: 2, Repository: model.Repository{FullName: "acme/api"},
+			ActivityBy: map[string]time.Time{"octocat": commented}},
+		// Only someone else has been active
+		{Number: 3, Repository: model.Repository{FullName: "acme/api"},
+			ActivityBy: map[string]time.Time{"hubot": opened}},
+	}
+	Apply(items, "octocat", store)
+
+	want := []*time.Time{&commented, &opened, nil}
+	for i, w := range want {
+		got := items[i].LastInteractionAt
+		switch {
+		case w == nil && got != nil:
+			t.Errorf("items[%d].LastInteractionAt = %v, want nil", i, *got)
+		case w != nil && (got == nil || !got.Equal(*w)):
+			t.Errorf("items[%d].LastInteractionAt = %v, want %v", i, got, *w)
+		}
+	}
+}
+
+func TestApplyHuman(t *testing.T) {
+	opened := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	commented := time.Date(2026, 10, 3, 9, 0, 0, 0, time.UTC)
+	botted := time.Date(2026, 10, 9, 9, 0, 0, 0, time.UTC)
+
+	items := []model.Item{
+		// A human comment, then bots
+		{CreatedAt: opened, UpdatedAt: botted, ActivityBy: map[string]time.Time{
+			"monalisa": commented, "github-actions[bot]": botted, "CI-Helper": botted,
+		}},
+		// Only bots since it was opened
+		{CreatedAt: opened, UpdatedAt: botted, ActivityBy: map[string]time.Time{"dependabot[bot]": botted}},
+		// Not enriched
+		{UpdatedAt: botted},
+	}
+	ApplyHuman(items, []string{"ci-helper"})
+
+	want := []*time.Time{&commented, &opened, nil}
+	for i, w := range want {
+		got := items[i].LastHumanActivityAt
+		switch {
+		case w == nil && got != nil:
+			t.Errorf("items[%d].LastHumanActivityAt = %v, want nil", i, *got)
+		case w != nil && (got == nil || !got.Equal(*w)):
+			t.Errorf("items[%d].LastHumanActivityAt = %v, want %v", i, got, *w)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Package audit records every GitHub mutation triage performs in a local,
+// append-only log so accidental actions can be reconstructed later.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Outcome is the result of an audited mutation.
+type Outcome string
+
+const (
+	// OutcomeSuccess means the mutation was accepted by GitHub.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure means the mutation was attempted and returned an error.
+	OutcomeFailure Outcome = "failure"
+)
+
+// Entry is a single audited mutation.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Target  string    `json:"target"`
+	Detail  string    `json:"detail,omitempty"`
+	Outcome Outcome   `json:"outcome"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Log is an append-only JSON Lines file of audit entries.
+// It is safe for concurrent use.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewLogFromPath creates an audit log at the given file path.
+func NewLogFromPath(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return &Log{path: path}, nil
+}
+
+// NewLog creates the audit log in the user's cache directory.
+func NewLog() (*Log, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewLogFromPath(filepath.Join(cacheDir, "triage", "audit.jsonl"))
+}
+
+// Path returns the location of the log file.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record appends an entry to the log. A zero Time is set to now.
+func (l *Log) Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Entries returns all entries in the log, oldest first. Lines that cannot
+// be parsed (e.g. a partial write) are skipped rather than failing the read.
+func (l *Log) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestLog(t *testing.T) *Log {
+	t.Helper()
+	l, err := NewLogFromPath(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestRecordAndEntries(t *testing.T) {
+	l := newTestLog(t)
+
+	entries, err := l.Entries()
+	if err != nil {
+		t.Fatalf("Entries on missing file: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries, got %d", len(entries))
+	}
+
+	first := Entry{Action: "comment on", Target: "owner/repo#1", Outcome: OutcomeSuccess}
+	second := Entry{Action: "mark as read", Target: "notification 9", Outcome: OutcomeFailure, Error: "boom"}
+	for _, e := range []Entry{first, second} {
+		if err := l.Record(e); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	entries, err = l.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Target != "owner/repo#1" || entries[1].Error != "boom" {
+		t.Errorf("entries out of order or incomplete: %+v", entries)
+	}
+	if entries[0].Time.IsZero() {
+		t.Error("expected Record to stamp zero Time")
+	}
+}
+
+func TestRecordPreservesTime(t *testing.T) {
+	l := newTestLog(t)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := l.Record(Entry{Time: ts, Action: "comment on", Outcome: OutcomeSuccess}); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := l.Entries()
+	if !entries[0].Time.Equal(ts) {
+		t.Errorf("expected time %v, got %v", ts, entries[0].Time)
+	}
+}
+
+func TestEntriesSkipsCorruptLines(t *testing.T) {
+	l := newTestLog(t)
+	if err := l.Record(Entry{Action: "comment on", Outcome: OutcomeSuccess}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(l.Path(), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{not json\n")
+	f.Close()
+	if err := l.Record(Entry{Action: "mark as read", Outcome: OutcomeSuccess}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := l.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected corrupt line to be skipped leaving 2 entries, got %d", len(entries))
+	}
+}
+
+func TestRecordConcurrent(t *testing.T) {
+	l := newTestLog(t)
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = l.Record(Entry{Action: "comment on", Outcome: OutcomeSuccess})
+		}()
+	}
+	wg.Wait()
+
+	entries, err := l.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 20 {
+		t.Errorf("expected 20 entries, got %d", len(entries))
+	}
+}
@@ -0,0 +1,35 @@
+// Package browser opens URLs in the default web browser.
+package browser
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Open opens url in the default browser, without waiting for the browser
+// to exit.
+func Open(url string) error {
+	name, args, err := command(runtime.GOOS, url)
+	if err != nil {
+		return err
+	}
+	if err := exec.Command(name, args...).Start(); err != nil {
+		return fmt.Errorf("opening %s: %w", url, err)
+	}
+	return nil
+}
+
+// command returns the program and arguments that open url on goos.
+func command(goos, url string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "xdg-open", []string{url}, nil
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}, nil
+	default:
+		return "", nil, fmt.Errorf("opening a browser is not supported on %s", goos)
+	}
+}
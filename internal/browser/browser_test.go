@@ -0,0 +1,34 @@
+package browser
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	const url = "https://github.com/spiffcs/triage/pull/42"
+	tests := []struct {
+		goos     string
+		wantName string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{goos: "darwin", wantName: "open", wantArgs: []string{url}},
+		{goos: "linux", wantName: "xdg-open", wantArgs: []string{url}},
+		{goos: "freebsd", wantName: "xdg-open", wantArgs: []string{url}},
+		{goos: "windows", wantName: "rundll32", wantArgs: []string{"url.dll,FileProtocolHandler", url}},
+		{goos: "plan9", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args, err := command(tt.goos, url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("command() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if name != tt.wantName || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("command() = %s %v, want %s %v", name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -2,15 +2,20 @@
 package cache
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/spiffcs/triage/internal/log"
 	"github.com/spiffcs/triage/internal/model"
+
+	// Registers the pure Go "sqlite" driver, so builds need no cgo
+	_ "modernc.org/sqlite"
 )
 
 // Key uniquely identifies an item in the cache.
@@ -21,118 +26,404 @@ type Key struct {
 	Number       int
 }
 
-// Cache stores notification details to avoid repeated API calls
+// Cache stores notification details to avoid repeated API calls. Item
+// details and fetched lists live in an SQLite database, so lookups are
+// indexed, writes are atomic, and statistics don't read every entry. Team
+// memberships, identity, and inaccessible repositories are small JSON
+// files beside it.
 type Cache struct {
-	dir string
+	dir     string
+	db      *sql.DB
+	maxSize int64
+
+	// mu guards size, the bytes of item details this process believes are
+	// cached, or -1 until Set first counts them. Set keeps it current so
+	// writes don't sum the table; evict recounts, since another process
+	// may share the database.
+	mu   sync.Mutex
+	size int64
+
+	// codeOwnersMu serializes updates to the CODEOWNERS file, which
+	// lookups running together each read, change, and write back.
+	codeOwnersMu sync.Mutex
 }
 
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithMaxSize sets the most bytes of item details kept before the least
+// recently used are evicted. The default is DefaultMaxSize.
+func WithMaxSize(bytes int64) Option {
+	return func(c *Cache) {
+		if bytes > 0 {
+			c.maxSize = bytes
+		}
+	}
+}
+
+// dbFile is the SQLite database holding item details and lists.
+const dbFile = "cache.db"
+
+// DefaultMaxSize is the most bytes of item details kept unless WithMaxSize
+// sets another limit.
+const DefaultMaxSize = 256 << 20
+
+// schemaVersion identifies the table layout, stored as the database's
+// user_version. A database with another layout is recreated, since cached
+// entries can always be fetched again.
+const schemaVersion = 1
+
+// schema creates the cache tables. Times are Unix nanoseconds; expires_at
+// is when an entry's TTL runs out, so each entry carries its own, and
+// accessed_at is when Get last returned it, for eviction.
+const schema = `
+DROP TABLE IF EXISTS details;
+DROP TABLE IF EXISTS lists;
+CREATE TABLE details (
+	repo         TEXT    NOT NULL,
+	subject_type TEXT    NOT NULL,
+	number       INTEGER NOT NULL,
+	updated_at   INTEGER NOT NULL,
+	cached_at    INTEGER NOT NULL,
+	accessed_at  INTEGER NOT NULL,
+	expires_at   INTEGER NOT NULL,
+	version      INTEGER NOT NULL,
+	size         INTEGER NOT NULL,
+	data         BLOB    NOT NULL,
+	PRIMARY KEY (repo, subject_type, number)
+);
+CREATE INDEX details_accessed_at ON details (accessed_at);
+CREATE INDEX details_expires_at ON details (expires_at);
+CREATE TABLE lists (
+	username   TEXT    NOT NULL,
+	list_type  TEXT    NOT NULL,
+	cached_at  INTEGER NOT NULL,
+	expires_at INTEGER NOT NULL,
+	version    INTEGER NOT NULL,
+	items      INTEGER NOT NULL,
+	data       BLOB    NOT NULL,
+	PRIMARY KEY (username, list_type)
+);`
+
 // NewCache creates a new cache instance
-func NewCache() (*Cache, error) {
+func NewCache(opts ...Option) (*Cache, error) {
 	cacheDir, err := os.UserCacheDir()
 	if err != nil {
 		return nil, err
 	}
+	return openCache(filepath.Join(cacheDir, "triage", "details"), opts...)
+}
 
-	cacheDir = filepath.Join(cacheDir, "triage", "details")
-	if err := os.MkdirAll(cacheDir, 0700); err != nil {
+// openCache opens the cache in dir, creating the directory and database
+// as needed. Nothing is evicted on open, so commands that don't know the
+// configured limit can't shrink the cache; the limit applies on Set and
+// Prune.
+func openCache(dir string, opts ...Option) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
 		return nil, fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
-	return &Cache{dir: cacheDir}, nil
+	// Create the file first so the database isn't world-readable
+	path := filepath.Join(dir, dbFile)
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache database: %w", err)
+	}
+	_ = f.Close()
+
+	// WAL and a busy timeout let a second triage (e.g. notify desktop
+	// alongside the TUI) use the cache without failing on locks
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(wal)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	if err := initSchema(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize cache database: %w", err)
+	}
+
+	c := &Cache{dir: dir, db: db, maxSize: DefaultMaxSize, size: -1}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
-// cacheKeyString generates a file name for a cache key.
-// The "/" in RepoFullName is replaced with "~" (not valid in GitHub
-// owner/repo names) to avoid collisions like "a/b_c" vs "a_b/c".
-func (c *Cache) cacheKeyString(key Key) string {
-	safeName := strings.ReplaceAll(key.RepoFullName, "/", "~")
-	return fmt.Sprintf("%s_%s_%d.json",
-		safeName,
-		key.SubjectType,
-		key.Number,
-	)
+// initSchema creates the tables unless the database already has the
+// current layout.
+func initSchema(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version == schemaVersion {
+		return nil
+	}
+	_, err := db.Exec(schema + fmt.Sprintf("PRAGMA user_version = %d;", schemaVersion))
+	return err
+}
+
+// Close closes the cache database.
+func (c *Cache) Close() error {
+	return c.db.Close()
 }
 
 // Get retrieves cached item data for an item.
 // The caller provides the cache key and the item's updated time for invalidation.
 func (c *Cache) Get(key Key, updatedAt time.Time) (*model.Item, bool) {
-	if key.Number == 0 {
+	item, fresh, ok := c.Peek(key, updatedAt)
+	if !ok || !fresh {
 		return nil, false
 	}
-
-	keyStr := c.cacheKeyString(key)
-	path := filepath.Join(c.dir, keyStr)
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, false
+	if _, err := c.db.Exec(
+		`UPDATE details SET accessed_at = ? WHERE repo = ? AND subject_type = ? AND number = ?`,
+		time.Now().UnixNano(), key.RepoFullName, string(key.SubjectType), key.Number,
+	); err != nil {
+		log.Debug("cache access time not recorded", "repo", key.RepoFullName, "number", key.Number, "error", err)
 	}
+	return item, true
+}
 
-	var entry DetailsCacheEntry
-	if err := json.Unmarshal(data, &entry); err != nil {
-		return nil, false
+// Peek retrieves cached item data for an item however old it is, for runs
+// that can't fetch it again. fresh reports whether Get would return it:
+// the entry is within its TTL and the item wasn't updated after it.
+func (c *Cache) Peek(key Key, updatedAt time.Time) (item *model.Item, fresh bool, ok bool) {
+	if key.Number == 0 {
+		return nil, false, false
 	}
 
-	// Invalidate if cache version doesn't match (format/schema changed)
-	if entry.Version != Version {
-		log.Debug("cache version mismatch", "cached", entry.Version, "current", Version, "key", keyStr)
-		return nil, false
+	var data []byte
+	var version int
+	var entryUpdatedAt, expiresAt int64
+	err := c.db.QueryRow(
+		`SELECT data, version, updated_at, expires_at FROM details WHERE repo = ? AND subject_type = ? AND number = ?`,
+		key.RepoFullName, string(key.SubjectType), key.Number,
+	).Scan(&data, &version, &entryUpdatedAt, &expiresAt)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Debug("cache read failed", "repo", key.RepoFullName, "number", key.Number, "error", err)
+		}
+		return nil, false, false
 	}
 
-	// Invalidate if item was updated after cache
-	if updatedAt.After(entry.UpdatedAt) {
-		return nil, false
+	// Invalidate if cache version doesn't match (format/schema changed)
+	if version != Version {
+		log.Debug("cache version mismatch", "cached", version, "current", Version, "repo", key.RepoFullName, "number", key.Number)
+		return nil, false, false
 	}
 
-	// Also invalidate if cache is too old
-	if time.Since(entry.CachedAt) > DetailCacheTTL {
-		return nil, false
+	item = new(model.Item)
+	if err := json.Unmarshal(data, item); err != nil {
+		return nil, false, false
 	}
 
-	return &entry.Item, true
+	// Stale if the item was updated after it was cached, or the cache is
+	// too old
+	fresh = !updatedAt.After(time.Unix(0, entryUpdatedAt)) && time.Now().UnixNano() <= expiresAt
+	return item, fresh, true
 }
 
-// Set caches item data for an item.
+// Set caches item data for an item, evicting the least recently used
+// details if the cache has grown past its size limit.
 // The caller provides the cache key and the item's updated time.
 func (c *Cache) Set(key Key, updatedAt time.Time, item *model.Item) error {
 	if key.Number == 0 || item == nil {
 		return nil
 	}
 
-	entry := DetailsCacheEntry{
-		Item:      *item,
-		CachedAt:  time.Now(),
-		UpdatedAt: updatedAt,
-		Version:   Version,
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
 	}
 
-	data, err := json.Marshal(entry)
+	// The replaced entry's size, to keep the running total
+	var replaced int64
+	err = c.db.QueryRow(
+		`SELECT size FROM details WHERE repo = ? AND subject_type = ? AND number = ?`,
+		key.RepoFullName, string(key.SubjectType), key.Number,
+	).Scan(&replaced)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	now := time.Now()
+	_, err = c.db.Exec(
+		`INSERT OR REPLACE INTO details (repo, subject_type, number, updated_at, cached_at, accessed_at, expires_at, version, size, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		key.RepoFullName, string(key.SubjectType), key.Number,
+		updatedAt.UnixNano(), now.UnixNano(), now.UnixNano(), now.Add(DetailCacheTTL).UnixNano(), Version, len(data), data,
+	)
 	if err != nil {
 		return err
 	}
 
-	keyStr := c.cacheKeyString(key)
-	path := filepath.Join(c.dir, keyStr)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size < 0 {
+		if c.size, err = c.detailsSize(); err != nil {
+			c.size = -1
+			return err
+		}
+	} else {
+		c.size += int64(len(data)) - replaced
+	}
+	if c.size <= c.maxSize {
+		return nil
+	}
+	_, err = c.evict(now)
+	return err
+}
 
-	return os.WriteFile(path, data, 0600)
+// PruneResult counts the entries Prune removed.
+type PruneResult struct {
+	Details int
+}
+
+// Prune removes item details past their TTL, which Get no longer returns,
+// then evicts the least recently used past the size limit, and gives their
+// space back. Lists are kept, expired or not: there is one per type, and
+// offline runs read the last one through LastList. So are team
+// memberships and identity, which lookups fall back to when GitHub can't
+// be reached.
+func (c *Cache) Prune(now time.Time) (PruneResult, error) {
+	var result PruneResult
+	res, err := c.db.Exec(`DELETE FROM details WHERE expires_at < ?`, now.UnixNano())
+	if err != nil {
+		return result, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return result, err
+	}
+	result.Details = int(n)
+
+	c.mu.Lock()
+	evicted, err := c.evict(now)
+	c.mu.Unlock()
+	if err != nil {
+		return result, err
+	}
+	result.Details += evicted
+
+	if result.Details > 0 {
+		if _, err := c.db.Exec("VACUUM"); err != nil {
+			log.Debug("cache vacuum failed", "error", err)
+		}
+	}
+	return result, nil
 }
 
 // Clear removes all cached entries
 func (c *Cache) Clear() error {
-	entries, err := os.ReadDir(c.dir)
+	tx, err := c.db.Begin()
 	if err != nil {
 		return err
 	}
-
-	for _, entry := range entries {
-		if err := os.Remove(filepath.Join(c.dir, entry.Name())); err != nil {
+	defer func() { _ = tx.Rollback() }()
+	for _, table := range []string{"details", "lists"} {
+		if _, err := tx.Exec("DELETE FROM " + table); err != nil {
 			return err
 		}
 	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.size = 0
+	c.mu.Unlock()
+	// Give the space back rather than keeping it for future entries
+	if _, err := c.db.Exec("VACUUM"); err != nil {
+		log.Debug("cache vacuum failed", "error", err)
+	}
 
+	for _, name := range []string{inaccessibleFile, teamMembersFile, identityFile, codeOwnersFile} {
+		if err := os.Remove(filepath.Join(c.dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	return nil
 }
 
+// evict keeps item details within the cache's size limit. Once past it,
+// details past their TTL go first, then the least recently used until the
+// rest fit. Lists are kept: there are few, and LastList uses expired ones.
+// It returns how many details it removed. The bytes cached are recounted,
+// since other processes may have changed them, and left in c.size; the
+// caller holds c.mu.
+func (c *Cache) evict(now time.Time) (int, error) {
+	c.size = -1
+	total, err := c.detailsSize()
+	if err != nil {
+		return 0, err
+	}
+	if total <= c.maxSize {
+		c.size = total
+		return 0, nil
+	}
+
+	res, err := c.db.Exec(`DELETE FROM details WHERE expires_at < ?`, now.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	evicted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if total, err = c.detailsSize(); err != nil {
+		return int(evicted), err
+	}
+	if total <= c.maxSize {
+		c.size = total
+		return int(evicted), nil
+	}
+
+	// Find the least recent access to keep, most recent first
+	rows, err := c.db.Query(`SELECT accessed_at, size FROM details ORDER BY accessed_at DESC`)
+	if err != nil {
+		return int(evicted), err
+	}
+	var kept int64
+	cutoff := int64(-1)
+	for rows.Next() {
+		var accessedAt, size int64
+		if err := rows.Scan(&accessedAt, &size); err != nil {
+			_ = rows.Close()
+			return int(evicted), err
+		}
+		if kept+size > c.maxSize {
+			cutoff = accessedAt
+			break
+		}
+		kept += size
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return int(evicted), err
+	}
+	if cutoff < 0 {
+		c.size = total
+		return int(evicted), nil
+	}
+
+	if res, err = c.db.Exec(`DELETE FROM details WHERE accessed_at <= ?`, cutoff); err != nil {
+		return int(evicted), err
+	}
+	if n, err := res.RowsAffected(); err == nil {
+		log.Debug("evicted cached details past the size limit", "entries", n, "maxSize", c.maxSize)
+		evicted += n
+	}
+	c.size = kept
+	return int(evicted), nil
+}
+
+// detailsSize returns the bytes of item details cached.
+func (c *Cache) detailsSize() (int64, error) {
+	var total int64
+	err := c.db.QueryRow(`SELECT COALESCE(SUM(size), 0) FROM details`).Scan(&total)
+	return total, err
+}
+
 // Stats returns cache statistics
 func (c *Cache) Stats() (total int, validCount int, err error) {
 	stats, err := c.DetailedStats()
@@ -161,29 +452,11 @@ func TTLForListType(listType ListType) time.Duration {
 	}
 }
 
-// listCacheKey generates a cache key for a list
-func (c *Cache) listCacheKey(username string, listType ListType) string {
-	return fmt.Sprintf("list_%s_%s.json", listType, username)
-}
-
 // GetList retrieves a cached list.
 // Returns the entry and true if found and valid, nil and false otherwise.
 func (c *Cache) GetList(username string, listType ListType, opts ListOptions) (*ListCacheEntry, bool) {
-	key := c.listCacheKey(username, listType)
-	path := filepath.Join(c.dir, key)
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, false
-	}
-
-	var entry ListCacheEntry
-	if err := json.Unmarshal(data, &entry); err != nil {
-		return nil, false
-	}
-
-	// Check version
-	if entry.Version != Version {
+	entry, ok := c.LastList(username, listType)
+	if !ok {
 		return nil, false
 	}
 
@@ -206,13 +479,48 @@ func (c *Cache) GetList(username string, listType ListType, opts ListOptions) (*
 		}
 	}
 
-	if listType == ListTypeOrphaned {
+	// A participating-only, unread-only, or single-repo listing can't
+	// stand in for a full one, nor the reverse
+	if listType == ListTypeNotifications &&
+		(opts.Participating != entry.Participating || opts.IncludeRead != entry.IncludeRead) {
+		return nil, false
+	}
+
+	// Orphaned contributions found under other rules or another team
+	// aren't the ones asked for
+	if listType == ListTypeOrphaned && opts.Settings != entry.Settings {
+		return nil, false
+	}
+
+	if listType == ListTypeOrphaned || listType == ListTypeNotifications {
 		// Invalidate if repos don't match
 		if !stringSlicesEqual(opts.Repos, entry.Repos) {
 			return nil, false
 		}
 	}
 
+	return entry, true
+}
+
+// LastList retrieves the most recently cached list regardless of its age,
+// e.g. to gauge how large the next fetch will be.
+func (c *Cache) LastList(username string, listType ListType) (*ListCacheEntry, bool) {
+	var data []byte
+	err := c.db.QueryRow(
+		`SELECT data FROM lists WHERE username = ? AND list_type = ? AND version = ?`,
+		username, string(listType), Version,
+	).Scan(&data)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Debug("cache read failed", "list", listType, "error", err)
+		}
+		return nil, false
+	}
+
+	var entry ListCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
 	return &entry, true
 }
 
@@ -235,21 +543,20 @@ func (c *Cache) SetList(username string, listType ListType, entry *ListCacheEntr
 		return err
 	}
 
-	key := c.listCacheKey(username, listType)
-	path := filepath.Join(c.dir, key)
-
-	return os.WriteFile(path, data, 0600)
+	_, err = c.db.Exec(
+		`INSERT OR REPLACE INTO lists (username, list_type, cached_at, expires_at, version, items, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		username, string(listType), entry.CachedAt.UnixNano(), entry.CachedAt.Add(TTLForListType(listType)).UnixNano(),
+		entry.Version, len(entry.Items), data,
+	)
+	return err
 }
 
 // DetailedStats returns detailed cache statistics broken down by type
 func (c *Cache) DetailedStats() (*CacheStats, error) {
-	entries, err := os.ReadDir(c.dir)
-	if err != nil {
-		return nil, err
-	}
-
 	stats := &CacheStats{
 		ListStats: make(map[ListType]ListStats),
+		MaxSize:   c.maxSize,
 	}
 
 	// Initialize all list types
@@ -257,62 +564,34 @@ func (c *Cache) DetailedStats() (*CacheStats, error) {
 		stats.ListStats[lt] = ListStats{}
 	}
 
-	now := time.Now()
+	now := time.Now().UnixNano()
 
-	for _, entry := range entries {
-		path := filepath.Join(c.dir, entry.Name())
-		data, err := os.ReadFile(path)
-		if err != nil {
-			continue
-		}
+	err := c.db.QueryRow(
+		`SELECT COUNT(*), COALESCE(SUM(expires_at >= ?), 0), COALESCE(SUM(size), 0) FROM details`, now,
+	).Scan(&stats.DetailTotal, &stats.DetailValid, &stats.DetailSize)
+	if err != nil {
+		return nil, err
+	}
 
-		name := entry.Name()
-
-		// Check if it's a list cache entry (starts with "list_")
-		if strings.HasPrefix(name, "list_") {
-			// Parse the list type from the filename: list_{type}_{username}.json
-			rest := strings.TrimPrefix(name, "list_")
-			// Find the list type by checking prefixes
-			var matchedType ListType
-			for _, lt := range AllListTypes() {
-				prefix := string(lt) + "_"
-				if strings.HasPrefix(rest, prefix) {
-					matchedType = lt
-					break
-				}
-			}
-
-			if matchedType == "" {
-				continue // Unknown list type
-			}
-
-			var listEntry ListCacheEntry
-			if err := json.Unmarshal(data, &listEntry); err != nil {
-				continue
-			}
-
-			ls := stats.ListStats[matchedType]
-			ls.Total++
-			ls.Items += len(listEntry.Items)
-			ttl := TTLForListType(matchedType)
-			if now.Sub(listEntry.CachedAt) <= ttl {
-				ls.Valid++
-			}
-			stats.ListStats[matchedType] = ls
-		} else {
-			// Everything else is detail cache entries
-			stats.DetailTotal++
-			var cacheEntry DetailsCacheEntry
-			if err := json.Unmarshal(data, &cacheEntry); err != nil {
-				continue
-			}
-			if now.Sub(cacheEntry.CachedAt) <= DetailCacheTTL {
-				stats.DetailValid++
-			}
+	rows, err := c.db.Query(
+		`SELECT list_type, COUNT(*), COALESCE(SUM(expires_at >= ?), 0), COALESCE(SUM(items), 0) FROM lists GROUP BY list_type`, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var listType string
+		var ls ListStats
+		if err := rows.Scan(&listType, &ls.Total, &ls.Valid, &ls.Items); err != nil {
+			return nil, err
 		}
+		if _, known := stats.ListStats[ListType(listType)]; !known {
+			continue // Unknown list type
+		}
+		stats.ListStats[ListType(listType)] = ls
 	}
-
-	return stats, nil
+	return stats, rows.Err()
 }
 
 // stringSlicesEqual checks if two string slices contain the same elements (order-insensitive)
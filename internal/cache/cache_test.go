@@ -1,45 +1,327 @@
 package cache
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/spiffcs/triage/internal/model"
 )
 
-func TestCacheKeyStringNoCollision(t *testing.T) {
-	c := &Cache{dir: "/tmp/test"}
+// newTestCache opens a cache in a temporary directory.
+func newTestCache(t *testing.T) *Cache {
+	t.Helper()
+	c, err := openCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("openCache() error: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestGetSet(t *testing.T) {
+	c := newTestCache(t)
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	key := Key{RepoFullName: "owner/repo", SubjectType: model.SubjectPullRequest, Number: 7}
+	item := &model.Item{ID: "7", Number: 7, Type: model.ItemTypePullRequest, Details: &model.PRDetails{Additions: 12}}
 
-	// These two keys must produce different filenames.
-	// With "/" → "_" replacement they would both be "a_b_c_PullRequest_1.json".
-	keyA := Key{RepoFullName: "a/b_c", SubjectType: model.SubjectPullRequest, Number: 1}
-	keyB := Key{RepoFullName: "a_b/c", SubjectType: model.SubjectPullRequest, Number: 1}
+	if _, ok := c.Get(key, updated); ok {
+		t.Fatal("Get() on empty cache should miss")
+	}
+	if err := c.Set(key, updated, item); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
 
-	if c.cacheKeyString(keyA) == c.cacheKeyString(keyB) {
-		t.Errorf("cache keys must not collide:\n  keyA=%q\n  keyB=%q\n  both=%q",
-			keyA.RepoFullName, keyB.RepoFullName, c.cacheKeyString(keyA))
+	got, ok := c.Get(key, updated)
+	if !ok {
+		t.Fatal("Get() missed a cached item")
+	}
+	if pr, isPR := got.Details.(*model.PRDetails); !isPR || pr.Additions != 12 {
+		t.Errorf("Get() details = %#v, want the cached PR details", got.Details)
+	}
+
+	// Keys differing only in where the owner ends must not collide
+	other := Key{RepoFullName: "owner/re", SubjectType: model.SubjectPullRequest, Number: 7}
+	if _, ok := c.Get(other, updated); ok {
+		t.Error("Get() returned an entry for a different repository")
+	}
+	issue := Key{RepoFullName: "owner/repo", SubjectType: model.SubjectIssue, Number: 7}
+	if _, ok := c.Get(issue, updated); ok {
+		t.Error("Get() returned a PR's entry for an issue")
+	}
+
+	// An item updated since it was cached is fetched again
+	if _, ok := c.Get(key, updated.Add(time.Minute)); ok {
+		t.Error("Get() returned an entry older than the item")
 	}
 }
 
-func TestCacheKeyStringDeterministic(t *testing.T) {
-	c := &Cache{dir: "/tmp/test"}
+func TestPeek(t *testing.T) {
+	c := newTestCache(t)
+	now := time.Now()
+	key := Key{RepoFullName: "owner/repo", SubjectType: model.SubjectIssue, Number: 3}
+	if _, _, ok := c.Peek(key, now); ok {
+		t.Fatal("Peek() on empty cache should miss")
+	}
+	if err := c.Set(key, now, &model.Item{ID: "3"}); err != nil {
+		t.Fatal(err)
+	}
 
-	key := Key{RepoFullName: "owner/repo", SubjectType: model.SubjectIssue, Number: 42}
-	a := c.cacheKeyString(key)
-	b := c.cacheKeyString(key)
+	if _, fresh, ok := c.Peek(key, now); !ok || !fresh {
+		t.Errorf("Peek() = fresh %v, ok %v; want a fresh hit", fresh, ok)
+	}
+	// Entries Get no longer returns are still there, marked stale
+	if item, fresh, ok := c.Peek(key, now.Add(time.Minute)); !ok || fresh || item.ID != "3" {
+		t.Errorf("Peek() of an item updated since = %v, fresh %v, ok %v; want a stale hit", item, fresh, ok)
+	}
+	if _, err := c.db.Exec(`UPDATE details SET expires_at = ?`, now.Add(-time.Minute).UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+	if _, fresh, ok := c.Peek(key, now); !ok || fresh {
+		t.Errorf("Peek() past the TTL = fresh %v, ok %v; want a stale hit", fresh, ok)
+	}
+	if _, ok := c.Get(key, now); ok {
+		t.Error("Get() returned an entry past its TTL")
+	}
+}
 
-	if a != b {
-		t.Errorf("cache key not deterministic: %q != %q", a, b)
+func TestEvictOnWrite(t *testing.T) {
+	item := func(n int) *model.Item { return &model.Item{ID: "x", Number: n} }
+	data, err := json.Marshal(item(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Room for three entries
+	c, err := openCache(t.TempDir(), WithMaxSize(int64(3*len(data))))
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer c.Close()
+
+	updated := time.Now()
+	key := func(n int) Key { return Key{RepoFullName: "owner/repo", SubjectType: model.SubjectIssue, Number: n} }
+	set := func(n int) {
+		t.Helper()
+		if err := c.Set(key(n), updated, item(n)); err != nil {
+			t.Fatalf("Set(%d) error: %v", n, err)
+		}
+	}
+	kept := func(want ...int) {
+		t.Helper()
+		var got []int
+		rows, err := c.db.Query(`SELECT number FROM details ORDER BY number`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var n int
+			if err := rows.Scan(&n); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, n)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("kept entries %v, want %v", got, want)
+		}
+	}
+
+	for n := 1; n <= 3; n++ {
+		set(n)
+		if _, err := c.db.Exec(`UPDATE details SET accessed_at = ? WHERE number = ?`,
+			updated.Add(-time.Duration(4-n)*time.Hour).UnixNano(), n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Reading entry 1 makes entry 2 the least recently used
+	if _, ok := c.Get(key(1), updated); !ok {
+		t.Fatal("Get(1) missed")
+	}
+	set(4)
+	kept(1, 3, 4)
+
+	// An expired entry goes before any valid one
+	if _, err := c.db.Exec(`UPDATE details SET expires_at = ? WHERE number = 4`, updated.Add(-time.Minute).UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+	set(5)
+	kept(1, 3, 5)
 }
 
-func TestCacheKeyStringFormat(t *testing.T) {
-	c := &Cache{dir: "/tmp/test"}
+func TestOpenKeepsDetailsPastLimit(t *testing.T) {
+	dir := t.TempDir()
+	c, err := openCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for n := 1; n <= 3; n++ {
+		if err := c.Set(Key{RepoFullName: "owner/repo", SubjectType: model.SubjectIssue, Number: n}, now, &model.Item{ID: "x"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = c.Close()
+
+	// A command opening the cache with a smaller limit doesn't shrink it
+	c, err = openCache(dir, WithMaxSize(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if total, _, err := c.Stats(); err != nil || total != 3 {
+		t.Fatalf("Stats() after reopening = %d total, %v; want 3", total, err)
+	}
 
-	key := Key{RepoFullName: "owner/repo", SubjectType: model.SubjectPullRequest, Number: 7}
-	got := c.cacheKeyString(key)
-	want := "owner~repo_PullRequest_7.json"
+	// Prune applies it
+	got, err := c.Prune(now)
+	if err != nil {
+		t.Fatalf("Prune() error: %v", err)
+	}
+	if want := (PruneResult{Details: 3}); got != want {
+		t.Errorf("Prune() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	c := newTestCache(t)
+	now := time.Now()
+	for n := 1; n <= 2; n++ {
+		if err := c.Set(Key{RepoFullName: "owner/repo", SubjectType: model.SubjectIssue, Number: n}, now, &model.Item{ID: "x"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.db.Exec(`UPDATE details SET expires_at = ? WHERE number = 1`, now.Add(-time.Minute).UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetList("me", ListTypeAuthored, &ListCacheEntry{CachedAt: now.Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetList("me", ListTypeOrphaned, &ListCacheEntry{CachedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetTeamMembers("acme/maintainers", []string{"alice"}); err != nil {
+		t.Fatal(err)
+	}
 
-	if got != want {
-		t.Errorf("cacheKeyString = %q, want %q", got, want)
+	got, err := c.Prune(now)
+	if err != nil {
+		t.Fatalf("Prune() error: %v", err)
+	}
+	if want := (PruneResult{Details: 1}); got != want {
+		t.Errorf("Prune() = %+v, want %+v", got, want)
+	}
+	if total, valid, err := c.Stats(); err != nil || total != 3 || valid != 2 {
+		t.Errorf("Stats() after Prune() = %d total, %d valid, %v; want 3 total, 2 valid", total, valid, err)
+	}
+	// Offline runs read the last list however old it is
+	if _, ok := c.LastList("me", ListTypeAuthored); !ok {
+		t.Error("Prune() removed an expired list")
+	}
+	if _, _, ok := c.TeamMembers("acme/maintainers"); !ok {
+		t.Error("Prune() removed team members")
+	}
+}
+
+func TestDetailedStatsAndClear(t *testing.T) {
+	c := newTestCache(t)
+	key := Key{RepoFullName: "owner/repo", SubjectType: model.SubjectIssue, Number: 1}
+	if err := c.Set(key, time.Now(), &model.Item{ID: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetList("me", ListTypeAuthored, &ListCacheEntry{Items: []model.Item{{ID: "1"}, {ID: "2"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetList("me", ListTypeOrphaned, &ListCacheEntry{CachedAt: time.Now().Add(-2 * OrphanedCacheTTL)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetTeamMembers("acme/maintainers", []string{"alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := c.DetailedStats()
+	if err != nil {
+		t.Fatalf("DetailedStats() error: %v", err)
+	}
+	if stats.DetailTotal != 1 || stats.DetailValid != 1 {
+		t.Errorf("details = %d total, %d valid; want 1 and 1", stats.DetailTotal, stats.DetailValid)
+	}
+	if got := stats.ListStats[ListTypeAuthored]; got != (ListStats{Total: 1, Valid: 1, Items: 2}) {
+		t.Errorf("authored list stats = %+v", got)
+	}
+	if got := stats.ListStats[ListTypeOrphaned]; got != (ListStats{Total: 1, Valid: 0}) {
+		t.Errorf("expired orphaned list stats = %+v", got)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear() error: %v", err)
+	}
+	if total, _, err := c.Stats(); err != nil || total != 0 {
+		t.Errorf("Stats() after Clear() = %d, %v; want 0", total, err)
+	}
+	if _, _, ok := c.TeamMembers("acme/maintainers"); ok {
+		t.Error("Clear() kept team members")
+	}
+}
+
+func TestGetListNotificationScope(t *testing.T) {
+	c := newTestCache(t)
+	since := time.Now().Add(-24 * time.Hour)
+
+	if err := c.SetList("me", ListTypeNotifications, &ListCacheEntry{
+		CachedAt:      time.Now(),
+		SinceTime:     since,
+		Participating: true,
+		Version:       Version,
+	}); err != nil {
+		t.Fatalf("SetList() error: %v", err)
+	}
+
+	if _, ok := c.GetList("me", ListTypeNotifications, ListOptions{SinceTime: since, Participating: true}); !ok {
+		t.Error("GetList() missed a participating listing for a participating run")
+	}
+	if _, ok := c.GetList("me", ListTypeNotifications, ListOptions{SinceTime: since}); ok {
+		t.Error("GetList() reused a participating listing for a full run")
+	}
+	if _, ok := c.GetList("me", ListTypeNotifications, ListOptions{SinceTime: since, Participating: true, IncludeRead: true}); ok {
+		t.Error("GetList() reused an unread-only listing for a run including read notifications")
+	}
+
+	if err := c.SetList("me", ListTypeNotifications, &ListCacheEntry{
+		CachedAt:  time.Now(),
+		SinceTime: since,
+		Repos:     []string{"acme/api"},
+		Version:   Version,
+	}); err != nil {
+		t.Fatalf("SetList() error: %v", err)
+	}
+	if _, ok := c.GetList("me", ListTypeNotifications, ListOptions{SinceTime: since, Repos: []string{"acme/api"}}); !ok {
+		t.Error("GetList() missed a listing of the same repo")
+	}
+	if _, ok := c.GetList("me", ListTypeNotifications, ListOptions{SinceTime: since}); ok {
+		t.Error("GetList() reused a single-repo listing for a full run")
+	}
+}
+
+func TestGetListOrphanedSettings(t *testing.T) {
+	c := newTestCache(t)
+	since := time.Now().Add(-24 * time.Hour)
+	repos := []string{"acme/api"}
+
+	if err := c.SetList("me", ListTypeOrphaned, &ListCacheEntry{
+		CachedAt:  time.Now(),
+		SinceTime: since,
+		Repos:     repos,
+		Settings:  "a",
+		Version:   Version,
+	}); err != nil {
+		t.Fatalf("SetList() error: %v", err)
+	}
+
+	if _, ok := c.GetList("me", ListTypeOrphaned, ListOptions{SinceTime: since, Repos: repos, Settings: "a"}); !ok {
+		t.Error("GetList() missed a listing found under the same settings")
+	}
+	if _, ok := c.GetList("me", ListTypeOrphaned, ListOptions{SinceTime: since, Repos: repos, Settings: "b"}); ok {
+		t.Error("GetList() reused a listing found under other settings")
 	}
 }
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CodeOwnersTTL is how long a repository's CODEOWNERS file is used before
+// it is looked up again.
+const CodeOwnersTTL = 24 * time.Hour
+
+// codeOwnersFile records the CODEOWNERS files of repositories whose PRs
+// asked one of the user's teams for a review.
+const codeOwnersFile = "codeowners.json"
+
+// codeOwnersEntry is the on-disk form of the CODEOWNERS cache.
+type codeOwnersEntry struct {
+	Repos   map[string]codeOwnersFileEntry `json:"repos"` // lowercased owner/repo -> file
+	Version int                            `json:"version"`
+}
+
+// codeOwnersFileEntry is a repository's CODEOWNERS file when it was looked
+// up; Text is empty when the repository has none.
+type codeOwnersFileEntry struct {
+	Text      string    `json:"text"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// CodeOwners returns the cached CODEOWNERS file of repo ("owner/repo") and
+// when it was looked up. Expired files are returned too, so a failed lookup
+// can fall back to them; callers compare fetchedAt with CodeOwnersTTL.
+func (c *Cache) CodeOwners(repo string) (text string, fetchedAt time.Time, ok bool) {
+	file, ok := c.readCodeOwners().Repos[strings.ToLower(repo)]
+	return file.Text, file.FetchedAt, ok
+}
+
+// SetCodeOwners records the CODEOWNERS file of repo, looked up now.
+func (c *Cache) SetCodeOwners(repo, text string) error {
+	c.codeOwnersMu.Lock()
+	defer c.codeOwnersMu.Unlock()
+	entry := c.readCodeOwners()
+	entry.Repos[strings.ToLower(repo)] = codeOwnersFileEntry{Text: text, FetchedAt: time.Now()}
+	entry.Version = Version
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, codeOwnersFile), data, 0600)
+}
+
+// readCodeOwners loads the CODEOWNERS cache. Missing, unreadable, or
+// outdated files are treated as empty.
+func (c *Cache) readCodeOwners() codeOwnersEntry {
+	entry := codeOwnersEntry{Repos: make(map[string]codeOwnersFileEntry)}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, codeOwnersFile))
+	if err != nil {
+		return entry
+	}
+	var stored codeOwnersEntry
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != Version || stored.Repos == nil {
+		return entry
+	}
+	return stored
+}
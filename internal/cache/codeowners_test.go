@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCodeOwners(t *testing.T) {
+	c := newTestCache(t)
+
+	if _, _, ok := c.CodeOwners("acme/app"); ok {
+		t.Fatal("CodeOwners() on empty cache should miss")
+	}
+
+	before := time.Now()
+	if err := c.SetCodeOwners("Acme/App", "* @acme/core\n"); err != nil {
+		t.Fatalf("SetCodeOwners() error: %v", err)
+	}
+	if err := c.SetCodeOwners("acme/docs", ""); err != nil {
+		t.Fatalf("SetCodeOwners() error: %v", err)
+	}
+
+	text, fetchedAt, ok := c.CodeOwners("acme/app")
+	if !ok || text != "* @acme/core\n" {
+		t.Errorf("CodeOwners() = %q, %v; want the stored file", text, ok)
+	}
+	if fetchedAt.Before(before) {
+		t.Errorf("fetchedAt = %v, want the time of SetCodeOwners", fetchedAt)
+	}
+	if _, _, ok := c.CodeOwners("acme/docs"); !ok {
+		t.Error("a repository without CODEOWNERS should still be cached")
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear() error: %v", err)
+	}
+	if _, _, ok := c.CodeOwners("acme/app"); ok {
+		t.Error("Clear() should remove cached CODEOWNERS files")
+	}
+}
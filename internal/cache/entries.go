@@ -8,7 +8,7 @@ import (
 
 // Version should be incremented when the cache format changes
 // or when enrichment data structure changes to invalidate old entries
-const Version = 4
+const Version = 13
 
 // Cache TTL constants
 const (
@@ -55,8 +55,11 @@ func AllListTypes() []ListType {
 
 // ListOptions contains optional parameters for list cache operations
 type ListOptions struct {
-	SinceTime time.Time // For notifications/orphaned
-	Repos     []string  // For orphaned validation
+	SinceTime     time.Time // For notifications/orphaned
+	Repos         []string  // For orphaned and notifications validation
+	Participating bool      // For notifications validation
+	IncludeRead   bool      // For notifications validation
+	Settings      string    // For orphaned: fingerprint of the rules and team
 }
 
 // ListCacheEntry stores a cached list of items with context
@@ -65,17 +68,24 @@ type ListCacheEntry struct {
 	CachedAt      time.Time    `json:"cachedAt"`
 	LastFetchTime time.Time    `json:"lastFetchTime"`   // For incremental updates
 	SinceTime     time.Time    `json:"sinceTime"`       // Time constraint used
-	Repos         []string     `json:"repos,omitempty"` // For orphaned validation
+	Repos         []string     `json:"repos,omitempty"` // For orphaned and notifications validation
+	Participating bool         `json:"participating,omitempty"`
+	IncludeRead   bool         `json:"includeRead,omitempty"`
+	Settings      string       `json:"settings,omitempty"`
 	Version       int          `json:"version"`
+
+	// Validators are the conditional-request validators of the last
+	// notification listing, keyed by repository ("" for all), so the next
+	// poll can ask only for changes
+	Validators map[string]Validators `json:"validators,omitempty"`
 }
 
-// DetailsCacheEntry caches enrichment data for an item (PR or Issue)
-// Note: Details is stored as raw JSON to handle the polymorphic Details interface
-type DetailsCacheEntry struct {
-	Item      model.Item `json:"item"` // Full item with promoted fields and Details
-	CachedAt  time.Time  `json:"cachedAt"`
-	UpdatedAt time.Time  `json:"updatedAt"` // model.Item's UpdatedAt for invalidation
-	Version   int        `json:"version"`   // Cache version for invalidation
+// Validators are the ETag and Last-Modified GitHub returned for a listing,
+// and the since the listing asked for.
+type Validators struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Since        time.Time `json:"since"`
 }
 
 // CacheStats contains detailed cache statistics
@@ -83,6 +93,8 @@ type CacheStats struct {
 	// Details cache (enrichment data for individual items)
 	DetailTotal int
 	DetailValid int
+	DetailSize  int64 // bytes of item details
+	MaxSize     int64 // bytes of item details kept before evicting
 
 	// List caches by type
 	ListStats map[ListType]ListStats
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// IdentityTTL is how long the login a token authenticates as, and the
+// teams that login belongs to, are used before they are looked up again.
+// Logins rarely change and team changes only adjust scores, so the lookups
+// are kept for a week; --refresh-identity looks them up sooner.
+const IdentityTTL = 7 * 24 * time.Hour
+
+// identityFile records the login each token authenticates as and the
+// teams of each login.
+const identityFile = "identity.json"
+
+// identityEntry is the on-disk form of the identity cache. Tokens are
+// stored only as a hash, never as themselves.
+type identityEntry struct {
+	Logins  map[string]cachedLogin `json:"logins"` // token hash -> login
+	Teams   map[string]cachedTeams `json:"teams"`  // lowercased login -> teams
+	Version int                    `json:"version"`
+}
+
+// cachedLogin is the login a token authenticated as when it was looked up.
+type cachedLogin struct {
+	Login     string    `json:"login"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// cachedTeams is the teams a login belonged to when they were looked up.
+type cachedTeams struct {
+	Teams     []string  `json:"teams"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// Login returns the cached login token authenticates as and when it was
+// looked up. Expired logins are returned too; callers compare fetchedAt
+// with IdentityTTL.
+func (c *Cache) Login(token string) (login string, fetchedAt time.Time, ok bool) {
+	cached, ok := c.readIdentity().Logins[tokenHash(token)]
+	return cached.Login, cached.FetchedAt, ok
+}
+
+// SetLogin records that token authenticates as login, looked up now.
+func (c *Cache) SetLogin(token, login string) error {
+	entry := c.readIdentity()
+	entry.Logins[tokenHash(token)] = cachedLogin{Login: login, FetchedAt: time.Now()}
+	return c.writeIdentity(entry)
+}
+
+// UserTeams returns the cached teams ("org/team-slug") login belongs to and
+// when they were looked up. Expired teams are returned too, so a failed
+// lookup can fall back to them; callers compare fetchedAt with IdentityTTL.
+func (c *Cache) UserTeams(login string) (teams []string, fetchedAt time.Time, ok bool) {
+	cached, ok := c.readIdentity().Teams[strings.ToLower(login)]
+	return cached.Teams, cached.FetchedAt, ok
+}
+
+// SetUserTeams records the teams login belongs to, looked up now.
+func (c *Cache) SetUserTeams(login string, teams []string) error {
+	entry := c.readIdentity()
+	entry.Teams[strings.ToLower(login)] = cachedTeams{Teams: teams, FetchedAt: time.Now()}
+	return c.writeIdentity(entry)
+}
+
+// readIdentity loads the identity cache. Missing, unreadable, or outdated
+// files are treated as empty.
+func (c *Cache) readIdentity() identityEntry {
+	entry := identityEntry{
+		Logins: make(map[string]cachedLogin),
+		Teams:  make(map[string]cachedTeams),
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, identityFile))
+	if err != nil {
+		return entry
+	}
+	var stored identityEntry
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != Version {
+		return entry
+	}
+	if stored.Logins != nil {
+		entry.Logins = stored.Logins
+	}
+	if stored.Teams != nil {
+		entry.Teams = stored.Teams
+	}
+	return entry
+}
+
+func (c *Cache) writeIdentity(entry identityEntry) error {
+	entry.Version = Version
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, identityFile), data, 0600)
+}
+
+// tokenHash identifies a token in the identity cache without storing it.
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
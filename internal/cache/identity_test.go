@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdentity(t *testing.T) {
+	c := newTestCache(t)
+
+	if _, _, ok := c.Login("ghp_secret"); ok {
+		t.Fatal("Login() on empty cache should miss")
+	}
+	if _, _, ok := c.UserTeams("octocat"); ok {
+		t.Fatal("UserTeams() on empty cache should miss")
+	}
+
+	before := time.Now()
+	if err := c.SetLogin("ghp_secret", "octocat"); err != nil {
+		t.Fatalf("SetLogin() error: %v", err)
+	}
+	if err := c.SetUserTeams("OctoCat", []string{"acme/maintainers"}); err != nil {
+		t.Fatalf("SetUserTeams() error: %v", err)
+	}
+
+	login, fetchedAt, ok := c.Login("ghp_secret")
+	if !ok || login != "octocat" {
+		t.Errorf("Login() = %q, %v; want octocat", login, ok)
+	}
+	if fetchedAt.Before(before) {
+		t.Errorf("fetchedAt = %v, want the time of SetLogin", fetchedAt)
+	}
+	if _, _, ok := c.Login("ghp_other"); ok {
+		t.Error("Login() of another token should miss")
+	}
+	teams, _, ok := c.UserTeams("octocat")
+	if !ok || !reflect.DeepEqual(teams, []string{"acme/maintainers"}) {
+		t.Errorf("UserTeams() = %v, %v; want acme/maintainers", teams, ok)
+	}
+
+	// The token itself is never written to disk
+	data, err := os.ReadFile(filepath.Join(c.dir, identityFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "ghp_secret") {
+		t.Errorf("identity cache contains the token: %s", data)
+	}
+
+	// The identity survives cache migrations and isn't counted as a detail entry
+	if plan, err := c.MigrationTarget().Plan(); err != nil || plan != nil {
+		t.Errorf("MigrationTarget().Plan() = %v, %v; want nothing to migrate", plan, err)
+	}
+	stats, err := c.DetailedStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.DetailTotal != 0 {
+		t.Errorf("DetailTotal = %d, want 0", stats.DetailTotal)
+	}
+}
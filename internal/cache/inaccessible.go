@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// InaccessibleRepoTTL is how long a repository the token couldn't read is
+// skipped during enrichment before it is tried again. It is short, since a
+// not-found may be transient or access may be granted soon after.
+const InaccessibleRepoTTL = time.Hour
+
+// inaccessibleFile records repositories that failed enrichment because
+// the token lacks read access.
+const inaccessibleFile = "inaccessible_repos.json"
+
+// inaccessibleEntry is the on-disk form of the failure-tracking cache.
+type inaccessibleEntry struct {
+	Repos   map[string]time.Time `json:"repos"` // full name -> when access failed
+	Version int                  `json:"version"`
+}
+
+// InaccessibleRepos returns the repositories that failed with no read
+// access within the last InaccessibleRepoTTL, keyed by full name.
+func (c *Cache) InaccessibleRepos() map[string]bool {
+	entry := c.readInaccessible()
+	repos := make(map[string]bool, len(entry.Repos))
+	for repo, failedAt := range entry.Repos {
+		if time.Since(failedAt) <= InaccessibleRepoTTL {
+			repos[repo] = true
+		}
+	}
+	return repos
+}
+
+// UpdateInaccessible records that the token couldn't read the repositories
+// in failed, so they are not retried until InaccessibleRepoTTL has passed,
+// and forgets any earlier failure of those in readable, which it just read.
+// Expired records are dropped.
+func (c *Cache) UpdateInaccessible(failed, readable []string) error {
+	entry := c.readInaccessible()
+	now := time.Now()
+	changed := len(failed) > 0
+	for repo, failedAt := range entry.Repos {
+		if now.Sub(failedAt) > InaccessibleRepoTTL || slices.Contains(readable, repo) {
+			delete(entry.Repos, repo)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	for _, repo := range failed {
+		entry.Repos[repo] = now
+	}
+	entry.Version = Version
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, inaccessibleFile), data, 0600)
+}
+
+// readInaccessible loads the failure-tracking cache. Missing, unreadable,
+// or outdated files are treated as empty.
+func (c *Cache) readInaccessible() inaccessibleEntry {
+	entry := inaccessibleEntry{Repos: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, inaccessibleFile))
+	if err != nil {
+		return entry
+	}
+	var stored inaccessibleEntry
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != Version || stored.Repos == nil {
+		return entry
+	}
+	return stored
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInaccessibleRepos(t *testing.T) {
+	c := newTestCache(t)
+
+	if got := c.InaccessibleRepos(); len(got) != 0 {
+		t.Fatalf("InaccessibleRepos() on empty cache = %v, want none", got)
+	}
+
+	// Seed an expired record, then mark a fresh one
+	expired := inaccessibleEntry{
+		Repos:   map[string]time.Time{"old/repo": time.Now().Add(-InaccessibleRepoTTL - time.Hour)},
+		Version: Version,
+	}
+	data, _ := json.Marshal(expired)
+	if err := os.WriteFile(filepath.Join(c.dir, inaccessibleFile), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateInaccessible([]string{"acme/private", "acme/moved"}, nil); err != nil {
+		t.Fatalf("UpdateInaccessible() error: %v", err)
+	}
+
+	got := c.InaccessibleRepos()
+	if !got["acme/private"] || !got["acme/moved"] || got["old/repo"] || len(got) != 2 {
+		t.Errorf("InaccessibleRepos() = %v, want acme/private and acme/moved", got)
+	}
+
+	// A repository read again is tried again at once
+	if err := c.UpdateInaccessible(nil, []string{"acme/moved"}); err != nil {
+		t.Fatalf("UpdateInaccessible() error: %v", err)
+	}
+	if got := c.InaccessibleRepos(); !got["acme/private"] || len(got) != 1 {
+		t.Errorf("InaccessibleRepos() = %v, want only acme/private", got)
+	}
+
+	// The record survives cache migrations and isn't counted as a detail entry
+	if plan, err := c.MigrationTarget().Plan(); err != nil || plan != nil {
+		t.Errorf("MigrationTarget().Plan() = %v, %v; want nothing to migrate", plan, err)
+	}
+	stats, err := c.DetailedStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.DetailTotal != 0 {
+		t.Errorf("DetailTotal = %d, want 0", stats.DetailTotal)
+	}
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/migrate"
+)
+
+// MigrationTarget returns the cache as a migration target. Cached entries
+// are disposable, so instead of converting entries from older formats
+// (which Get and GetList already ignore) the migration removes them, and
+// no backup is kept. That includes the one-file-per-entry JSON written
+// before the cache moved to SQLite.
+func (c *Cache) MigrationTarget() migrate.Target {
+	return &cacheTarget{dir: c.dir, db: c.db}
+}
+
+type cacheTarget struct {
+	dir string
+	db  *sql.DB
+}
+
+func (t *cacheTarget) Name() string {
+	return "cache"
+}
+
+func (t *cacheTarget) Plan() (*migrate.Plan, error) {
+	stale, oldest, err := t.staleEntries()
+	if err != nil {
+		return nil, err
+	}
+	rows, rowsOldest, err := t.staleRows()
+	if err != nil {
+		return nil, err
+	}
+	if len(stale)+rows == 0 {
+		return nil, nil
+	}
+	if rows > 0 {
+		oldest = min(oldest, rowsOldest)
+	}
+	return &migrate.Plan{
+		Target: t.Name(),
+		Path:   t.dir,
+		From:   oldest,
+		To:     Version,
+		Steps:  []string{fmt.Sprintf("remove %d entries written by older cache formats", len(stale)+rows)},
+	}, nil
+}
+
+func (t *cacheTarget) Apply(_ *migrate.Plan) error {
+	stale, _, err := t.staleEntries()
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("cache: %w", err)
+		}
+	}
+	for _, table := range []string{"details", "lists"} {
+		if _, err := t.db.Exec("DELETE FROM "+table+" WHERE version < ?", Version); err != nil {
+			return fmt.Errorf("cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// staleRows counts database entries older than Version and returns the
+// oldest version among them.
+func (t *cacheTarget) staleRows() (int, int, error) {
+	var count int
+	var oldest sql.NullInt64
+	err := t.db.QueryRow(
+		`SELECT COUNT(*), MIN(version) FROM (
+			SELECT version FROM details WHERE version < ?1
+			UNION ALL
+			SELECT version FROM lists WHERE version < ?1
+		)`, Version,
+	).Scan(&count, &oldest)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cache: %w", err)
+	}
+	return count, int(oldest.Int64), nil
+}
+
+// staleEntries returns cache files older than Version, including every
+// entry of the flat-file cache, and the oldest version found. Entries from
+// newer versions are left for the build that wrote them.
+func (t *cacheTarget) staleEntries() ([]string, int, error) {
+	entries, err := os.ReadDir(t.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, fmt.Errorf("cache: %w", err)
+	}
+
+	var stale []string
+	oldest := Version
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(t.dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var probe struct {
+			Version int `json:"version"`
+		}
+		// Unreadable entries are stale too; Get treats them as misses
+		_ = json.Unmarshal(data, &probe)
+		if probe.Version < Version {
+			stale = append(stale, path)
+			oldest = min(oldest, probe.Version)
+		}
+	}
+	return stale, oldest, nil
+}
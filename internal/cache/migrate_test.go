@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrationTarget(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]int{
+		"current.json": Version,
+		"old.json":     Version - 1,
+		"older.json":   Version - 2,
+		"newer.json":   Version + 1,
+	}
+	for name, v := range files {
+		data := fmt.Sprintf(`{"version": %d}`, v)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := openCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	// A details row from before the last format change
+	if _, err := c.db.Exec(`INSERT INTO details (repo, subject_type, number, updated_at, cached_at, accessed_at, expires_at, version, size, data)
+		VALUES ('owner/repo', 'Issue', 1, 0, 0, 0, 0, ?, 2, '{}')`, Version-1); err != nil {
+		t.Fatal(err)
+	}
+
+	target := c.MigrationTarget()
+	plan, err := target.Plan()
+	if err != nil || plan == nil {
+		t.Fatalf("Plan() = %v, %v; want a pending migration", plan, err)
+	}
+	if plan.From != Version-2 || plan.To != Version {
+		t.Errorf("plan = v%d -> v%d, want v%d -> v%d", plan.From, plan.To, Version-2, Version)
+	}
+	if plan.Backup != "" {
+		t.Errorf("plan.Backup = %q, want none for disposable cache", plan.Backup)
+	}
+
+	if err := target.Apply(plan); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	for name, v := range files {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if kept := err == nil; kept != (v >= Version) {
+			t.Errorf("%s (v%d) kept = %v", name, v, kept)
+		}
+	}
+
+	var rows int
+	if err := c.db.QueryRow(`SELECT COUNT(*) FROM details`).Scan(&rows); err != nil || rows != 0 {
+		t.Errorf("details rows after migration = %d, %v; want 0", rows, err)
+	}
+
+	if plan, err := target.Plan(); err != nil || plan != nil {
+		t.Errorf("Plan() after migration = %v, %v; want nothing pending", plan, err)
+	}
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TeamMembersTTL is how long a team's membership is used before it is
+// looked up again.
+const TeamMembersTTL = 24 * time.Hour
+
+// teamMembersFile records the members of the teams named in the orphaned
+// config.
+const teamMembersFile = "team_members.json"
+
+// teamMembersEntry is the on-disk form of the team membership cache.
+type teamMembersEntry struct {
+	Teams   map[string]teamRoster `json:"teams"` // lowercased org/team-slug -> roster
+	Version int                   `json:"version"`
+}
+
+// teamRoster is a team's members when they were looked up.
+type teamRoster struct {
+	Members   []string  `json:"members"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// TeamMembers returns the cached members of team ("org/team-slug") and when
+// they were looked up. Expired rosters are returned too, so a failed lookup
+// can fall back to them; callers compare fetchedAt with TeamMembersTTL.
+func (c *Cache) TeamMembers(team string) (members []string, fetchedAt time.Time, ok bool) {
+	roster, ok := c.readTeamMembers().Teams[strings.ToLower(team)]
+	return roster.Members, roster.FetchedAt, ok
+}
+
+// SetTeamMembers records the members of team, looked up now.
+func (c *Cache) SetTeamMembers(team string, members []string) error {
+	entry := c.readTeamMembers()
+	entry.Teams[strings.ToLower(team)] = teamRoster{Members: members, FetchedAt: time.Now()}
+	entry.Version = Version
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, teamMembersFile), data, 0600)
+}
+
+// readTeamMembers loads the team membership cache. Missing, unreadable, or
+// outdated files are treated as empty.
+func (c *Cache) readTeamMembers() teamMembersEntry {
+	entry := teamMembersEntry{Teams: make(map[string]teamRoster)}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, teamMembersFile))
+	if err != nil {
+		return entry
+	}
+	var stored teamMembersEntry
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != Version || stored.Teams == nil {
+		return entry
+	}
+	return stored
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTeamMembers(t *testing.T) {
+	c := newTestCache(t)
+
+	if _, _, ok := c.TeamMembers("acme/maintainers"); ok {
+		t.Fatal("TeamMembers() on empty cache should miss")
+	}
+
+	before := time.Now()
+	if err := c.SetTeamMembers("Acme/Maintainers", []string{"alice", "bob"}); err != nil {
+		t.Fatalf("SetTeamMembers() error: %v", err)
+	}
+	if err := c.SetTeamMembers("acme/release", nil); err != nil {
+		t.Fatalf("SetTeamMembers() error: %v", err)
+	}
+
+	members, fetchedAt, ok := c.TeamMembers("acme/maintainers")
+	if !ok || !reflect.DeepEqual(members, []string{"alice", "bob"}) {
+		t.Errorf("TeamMembers() = %v, %v; want alice and bob", members, ok)
+	}
+	if fetchedAt.Before(before) {
+		t.Errorf("fetchedAt = %v, want the time of SetTeamMembers", fetchedAt)
+	}
+	if _, _, ok := c.TeamMembers("acme/release"); !ok {
+		t.Error("an empty team should still be cached")
+	}
+
+	// The roster survives cache migrations and isn't counted as a detail entry
+	if plan, err := c.MigrationTarget().Plan(); err != nil || plan != nil {
+		t.Errorf("MigrationTarget().Plan() = %v, %v; want nothing to migrate", plan, err)
+	}
+	stats, err := c.DetailedStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.DetailTotal != 0 {
+		t.Errorf("DetailTotal = %d, want 0", stats.DetailTotal)
+	}
+}
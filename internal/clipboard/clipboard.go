@@ -0,0 +1,70 @@
+// Package clipboard copies text to the system clipboard.
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Copy puts text on the clipboard with the platform's copy command. Over
+// SSH, or where there is no copy command, it asks the terminal to instead
+// with an OSC 52 escape sequence written to term, which most terminal
+// emulators honor and which reaches the clipboard of the machine the user
+// sits at.
+func Copy(text string, term io.Writer) error {
+	if !remote(os.Getenv) {
+		for _, c := range commands(runtime.GOOS, os.Getenv) {
+			if _, err := exec.LookPath(c[0]); err != nil {
+				continue
+			}
+			cmd := exec.Command(c[0], c[1:]...)
+			cmd.Stdin = strings.NewReader(text)
+			if err := cmd.Run(); err == nil {
+				return nil
+			}
+		}
+	}
+	if _, err := io.WriteString(term, osc52(text)); err != nil {
+		return fmt.Errorf("copying to the clipboard: %w", err)
+	}
+	return nil
+}
+
+// remote reports whether this is an SSH session, where copy commands would
+// fill the remote machine's clipboard rather than the user's.
+func remote(getenv func(string) string) bool {
+	return getenv("SSH_TTY") != "" || getenv("SSH_CONNECTION") != ""
+}
+
+// commands returns the programs, with their arguments, that copy stdin to
+// the clipboard on goos, in order of preference.
+func commands(goos string, getenv func(string) string) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		var cmds [][]string
+		if getenv("WAYLAND_DISPLAY") != "" {
+			cmds = append(cmds, []string{"wl-copy"})
+		}
+		if getenv("DISPLAY") != "" {
+			cmds = append(cmds, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+		}
+		return cmds
+	default:
+		return nil
+	}
+}
+
+// osc52 returns the escape sequence asking the terminal to put text on the
+// clipboard.
+func osc52(text string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+}
@@ -0,0 +1,55 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCommands(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want [][]string
+	}{
+		{"darwin", "darwin", nil, [][]string{{"pbcopy"}}},
+		{"windows", "windows", nil, [][]string{{"clip"}}},
+		{"wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, [][]string{{"wl-copy"}}},
+		{"x11", "linux", map[string]string{"DISPLAY": ":0"}, [][]string{{"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}},
+		{"no display", "linux", nil, nil},
+		{"unsupported", "plan9", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := commands(tt.goos, env(tt.env))
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("commands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyOverSSH(t *testing.T) {
+	t.Setenv("SSH_TTY", "/dev/pts/0")
+	var term bytes.Buffer
+	if err := Copy("hello", &term); err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+
+	// The terminal is asked to copy, in base64 so any text survives
+	seq := term.String()
+	encoded, ok := strings.CutPrefix(seq, "\x1b]52;c;")
+	if !ok || !strings.HasSuffix(encoded, "\a") {
+		t.Fatalf("Copy() wrote %q, want an OSC 52 sequence", seq)
+	}
+	if text, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(encoded, "\a")); err != nil || string(text) != "hello" {
+		t.Errorf("OSC 52 payload = %q, %v; want hello", text, err)
+	}
+}
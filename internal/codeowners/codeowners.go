@@ -0,0 +1,136 @@
+// Package codeowners reads CODEOWNERS files and tells who owns a path, the
+// way GitHub does when it requests reviews: the last rule whose pattern
+// matches the path decides, even when it lists no owners.
+package codeowners
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Rule is a line of a CODEOWNERS file: a gitignore-style pattern and the
+// owners of the paths it matches, as written ("@login", "@org/team-slug",
+// or an email address). A rule without owners leaves its paths unowned.
+type Rule struct {
+	Pattern string
+	Owners  []string
+
+	re *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Rules []Rule
+}
+
+// Parse reads a CODEOWNERS file. Lines GitHub ignores are skipped: blank
+// lines, comments, and patterns using the negation or character ranges it
+// doesn't support.
+func Parse(text string) *File {
+	f := &File{}
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+		if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+			continue
+		}
+
+		var owners []string
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+		re, err := regexp.Compile(patternRegexp(pattern))
+		if err != nil {
+			continue
+		}
+		f.Rules = append(f.Rules, Rule{Pattern: pattern, Owners: owners, re: re})
+	}
+	return f
+}
+
+// Owners returns the owners of path, relative to the repository root, as
+// listed by the last rule matching it. ok is false when no rule does.
+func (f *File) Owners(path string) (owners []string, ok bool) {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].re.MatchString(path) {
+			return f.Rules[i].Owners, true
+		}
+	}
+	return nil, false
+}
+
+// OwnedBy reports whether path is owned by one of who, given as logins or
+// "org/team-slug" with or without the leading @. Names compare
+// case-insensitively.
+func (f *File) OwnedBy(path string, who []string) bool {
+	owners, _ := f.Owners(path)
+	for _, owner := range owners {
+		for _, w := range who {
+			if strings.EqualFold(strings.TrimPrefix(owner, "@"), strings.TrimPrefix(w, "@")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// patternRegexp translates a CODEOWNERS pattern to a regular expression
+// matching the paths it covers. As in .gitignore, a pattern with a slash
+// before its end is anchored at the root and one without matches at any
+// depth, "*" stays within a directory and "**" crosses them, and a
+// pattern naming a directory covers everything in it. Unlike .gitignore,
+// "dir/*" covers only the files directly in dir, as GitHub documents.
+func patternRegexp(pattern string) string {
+	dir := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for pattern != "" {
+		switch {
+		case strings.HasPrefix(pattern, "**/"):
+			b.WriteString("(?:.*/)?")
+			pattern = pattern[3:]
+		case strings.HasPrefix(pattern, "**"):
+			b.WriteString(".*")
+			pattern = pattern[2:]
+		case pattern[0] == '*':
+			b.WriteString("[^/]*")
+			pattern = pattern[1:]
+		case pattern[0] == '?':
+			b.WriteString("[^/]")
+			pattern = pattern[1:]
+		default:
+			end := strings.IndexAny(pattern, "*?")
+			if end < 0 {
+				end = len(pattern)
+			}
+			b.WriteString(regexp.QuoteMeta(pattern[:end]))
+			pattern = pattern[end:]
+		}
+	}
+
+	switch {
+	case dir:
+		b.WriteString("/")
+	case strings.HasSuffix(b.String(), "/[^/]*"):
+		// "dir/*" stops at the files in dir
+		b.WriteString("$")
+	default:
+		// The pattern may name a directory, covering what's in it
+		b.WriteString("(?:/|$)")
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package codeowners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOwners(t *testing.T) {
+	f := Parse(`# Owners of everything not listed below
+*       @acme/everyone
+
+*.js    @acme/frontend   # inline comment
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+/scripts/ @acme/ops @alice
+**/testdata @acme/qa
+/vendor/**/LICENSE @acme/legal
+/generated/
+\#notes @bob
+!negated @ignored
+[Bb]uild @ignored
+`)
+
+	tests := []struct {
+		path   string
+		want   []string
+		wantOK bool
+	}{
+		{"README.md", []string{"@acme/everyone"}, true},
+		{"web/app.js", []string{"@acme/frontend"}, true},
+		{"build/logs/today.txt", []string{"@doctocat"}, true},
+		{"src/build/logs/today.txt", []string{"@acme/everyone"}, true},
+		{"docs/intro.md", []string{"docs@example.com"}, true},
+		{"docs/guides/intro.md", []string{"@acme/everyone"}, true},
+		{"apps/web/main.go", []string{"@octocat"}, true},
+		{"src/apps/main.go", []string{"@octocat"}, true},
+		{"scripts/release.sh", []string{"@acme/ops", "@alice"}, true},
+		{"pkg/triage/testdata/run.json", []string{"@acme/qa"}, true},
+		{"vendor/github.com/x/LICENSE", []string{"@acme/legal"}, true},
+		{"generated/api.go", nil, true},
+		{"#notes", []string{"@bob"}, true},
+		{"/README.md", []string{"@acme/everyone"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := f.Owners(tt.path)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Owners(%q) = %v, %v; want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if len(f.Rules) != 10 {
+		t.Errorf("len(Rules) = %d, want 10 (unsupported patterns skipped)", len(f.Rules))
+	}
+}
+
+func TestOwnersNoMatch(t *testing.T) {
+	f := Parse("/docs/ @acme/writers\n")
+	if owners, ok := f.Owners("main.go"); ok || owners != nil {
+		t.Errorf("Owners() = %v, %v; want no rule", owners, ok)
+	}
+}
+
+func TestOwnedBy(t *testing.T) {
+	f := Parse("* @acme/everyone\n/api/ @Acme/API @alice\n/generated/\n")
+
+	tests := []struct {
+		name string
+		path string
+		who  []string
+		want bool
+	}{
+		{"team", "api/server.go", []string{"bob", "acme/api"}, true},
+		{"login", "api/server.go", []string{"alice"}, true},
+		{"with @", "api/server.go", []string{"@ALICE"}, true},
+		{"someone else's", "api/server.go", []string{"bob", "acme/everyone"}, false},
+		{"catch-all", "main.go", []string{"acme/everyone"}, true},
+		{"unowned", "generated/api.go", []string{"acme/everyone"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.OwnedBy(tt.path, tt.who); got != tt.want {
+				t.Errorf("OwnedBy(%q, %v) = %v, want %v", tt.path, tt.who, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Package confirm decides which actions need user confirmation and provides
+// the shared prompt used by both the CLI and the TUI.
+package confirm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spiffcs/triage/config"
+)
+
+// Action identifies an operation subject to a confirmation policy.
+type Action string
+
+const (
+	// ActionMarkReadBulk is marking several notifications read at once.
+	ActionMarkReadBulk Action = "mark_read_bulk"
+	// ActionComment is posting a comment.
+	ActionComment Action = "comment"
+	// ActionDelegate is assigning items to a teammate.
+	ActionDelegate Action = "delegate"
+	// ActionLargeFetch is listing a large number of notifications, such as
+	// on a first run with a long --since.
+	ActionLargeFetch Action = "large_fetch"
+)
+
+// Policy decides whether an action affecting a number of items needs
+// confirmation.
+type Policy struct {
+	always    bool
+	never     bool
+	threshold int // prompt when count > threshold (used when neither always nor never)
+}
+
+// ParsePolicy parses a policy string: "always", "never", or a threshold
+// written ">N" or "when >N items".
+func ParsePolicy(s string) (Policy, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	switch v {
+	case "always":
+		return Policy{always: true}, nil
+	case "never":
+		return Policy{never: true}, nil
+	}
+
+	v = strings.TrimSpace(strings.TrimPrefix(v, "when"))
+	v = strings.TrimSpace(strings.TrimSuffix(v, "items"))
+	if rest, ok := strings.CutPrefix(v, ">"); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(rest))
+		if err == nil && n >= 0 {
+			return Policy{threshold: n}, nil
+		}
+	}
+	return Policy{}, fmt.Errorf("invalid confirmation policy %q: expected always, never, or >N", s)
+}
+
+// Required reports whether an action affecting count items needs confirmation.
+func (p Policy) Required(count int) bool {
+	switch {
+	case p.always:
+		return true
+	case p.never:
+		return false
+	default:
+		return count > p.threshold
+	}
+}
+
+// Policies holds the confirmation policy for every action.
+type Policies struct {
+	byAction map[Action]Policy
+}
+
+// NewPolicies parses the configured confirmation settings.
+func NewPolicies(settings config.ConfirmationSettings) (*Policies, error) {
+	raw := map[Action]string{
+		ActionMarkReadBulk: settings.MarkReadBulk,
+		ActionComment:      settings.Comment,
+		ActionDelegate:     settings.Delegate,
+		ActionLargeFetch:   settings.LargeFetch,
+	}
+	p := &Policies{byAction: make(map[Action]Policy, len(raw))}
+	for action, value := range raw {
+		policy, err := ParsePolicy(value)
+		if err != nil {
+			return nil, fmt.Errorf("confirmations.%s: %w", action, err)
+		}
+		p.byAction[action] = policy
+	}
+	return p, nil
+}
+
+// Required reports whether action affecting count items needs confirmation.
+// Unknown actions, and a nil Policies, always require confirmation so that
+// a missing policy fails safe.
+func (p *Policies) Required(action Action, count int) bool {
+	if p == nil {
+		return true
+	}
+	policy, ok := p.byAction[action]
+	if !ok {
+		return true
+	}
+	return policy.Required(count)
+}
+
+// Ask writes question to out and reads a yes/no answer from in.
+// Only "y" or "yes" (case-insensitive) confirm; anything else, including
+// EOF, declines.
+func Ask(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	return IsYes(answer)
+}
+
+// IsYes reports whether answer is an affirmative response.
+func IsYes(answer string) bool {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
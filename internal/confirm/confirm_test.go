@@ -0,0 +1,103 @@
+package confirm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/config"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		counts  map[int]bool // count -> want Required
+		wantErr bool
+	}{
+		{input: "always", counts: map[int]bool{0: true, 1: true, 50: true}},
+		{input: "Never", counts: map[int]bool{0: false, 1: false, 50: false}},
+		{input: ">10", counts: map[int]bool{1: false, 10: false, 11: true}},
+		{input: "when >10 items", counts: map[int]bool{10: false, 11: true}},
+		{input: " > 0 ", counts: map[int]bool{0: false, 1: true}},
+		{input: "sometimes", wantErr: true},
+		{input: ">-1", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p, err := ParsePolicy(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for count, want := range tt.counts {
+				if got := p.Required(count); got != want {
+					t.Errorf("Required(%d) = %v, want %v", count, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	p, err := NewPolicies(config.DefaultConfirmationSettings())
+	if err != nil {
+		t.Fatalf("NewPolicies(defaults): %v", err)
+	}
+
+	if p.Required(ActionComment, 1) {
+		t.Error("comment should never require confirmation by default")
+	}
+	if p.Required(ActionDelegate, 5) {
+		t.Error("delegate should never require confirmation by default")
+	}
+	if p.Required(ActionMarkReadBulk, 10) || !p.Required(ActionMarkReadBulk, 11) {
+		t.Error("mark_read_bulk should require confirmation only above 10 items by default")
+	}
+	if p.Required(ActionLargeFetch, 2000) || !p.Required(ActionLargeFetch, 2001) {
+		t.Error("large_fetch should require confirmation only above 2000 items by default")
+	}
+	if !p.Required(Action("unknown"), 1) {
+		t.Error("unknown actions should fail safe and require confirmation")
+	}
+
+	var nilPolicies *Policies
+	if !nilPolicies.Required(ActionComment, 1) {
+		t.Error("nil policies should fail safe and require confirmation")
+	}
+
+	bad := config.DefaultConfirmationSettings()
+	bad.Comment = "maybe"
+	if _, err := NewPolicies(bad); err == nil || !strings.Contains(err.Error(), "confirmations.comment") {
+		t.Errorf("expected error naming the bad key, got %v", err)
+	}
+}
+
+func TestAsk(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if got := Ask(strings.NewReader(tt.input), &out, "Proceed?"); got != tt.want {
+			t.Errorf("Ask(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if out.String() != "Proceed? [y/N]: " {
+			t.Errorf("unexpected prompt %q", out.String())
+		}
+	}
+}
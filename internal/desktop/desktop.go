@@ -0,0 +1,140 @@
+// Package desktop sends operating system notifications for items that
+// become pressing: through osascript on macOS, notify-send on Linux, and a
+// PowerShell toast on Windows.
+package desktop
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/snapshot"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// maxItemNotifications is the most items notified one by one; more are
+// summarized in a single notification.
+const maxItemNotifications = 3
+
+// Notification is one desktop notification.
+type Notification struct {
+	Title string
+	Body  string
+}
+
+// Notifications builds the notifications for items that crossed into a
+// notifying priority.
+func Notifications(items []triage.PrioritizedItem) []Notification {
+	if len(items) <= maxItemNotifications {
+		notes := make([]Notification, 0, len(items))
+		for _, item := range items {
+			notes = append(notes, Notification{
+				Title: fmt.Sprintf("%s is %s", item.Key(), item.Priority.Display()),
+				Body:  item.Subject.Title,
+			})
+		}
+		return notes
+	}
+
+	keys := make([]string, 0, maxItemNotifications)
+	for _, item := range items[:maxItemNotifications] {
+		keys = append(keys, item.Key())
+	}
+	return []Notification{{
+		Title: fmt.Sprintf("%d items need attention", len(items)),
+		Body:  fmt.Sprintf("%s and %d more", strings.Join(keys, ", "), len(items)-maxItemNotifications),
+	}}
+}
+
+// Notifier shows notifications on the desktop.
+type Notifier struct {
+	goos string
+	run  func(ctx context.Context, c command) error
+}
+
+// New returns a Notifier for the running operating system.
+func New() *Notifier {
+	return &Notifier{goos: runtime.GOOS, run: runCommand}
+}
+
+// command is a program to run, with extra environment variables.
+type command struct {
+	name string
+	args []string
+	env  []string
+}
+
+// windowsToast shows a toast with the title and body passed in the
+// environment, so neither needs quoting for PowerShell.
+const windowsToast = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode($env:TRIAGE_NOTIFY_TITLE)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode($env:TRIAGE_NOTIFY_BODY)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('triage').Show([Windows.UI.Notifications.ToastNotification]::new($template))`
+
+// commandFor returns the command that shows n on goos.
+func commandFor(goos string, n Notification) (command, error) {
+	switch goos {
+	case "darwin":
+		// Passed as arguments so the text needs no AppleScript quoting
+		return command{name: "osascript", args: []string{
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			n.Title, n.Body,
+		}}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return command{name: "notify-send", args: []string{"--app-name=triage", "--", n.Title, n.Body}}, nil
+	case "windows":
+		return command{
+			name: "powershell",
+			args: []string{"-NoProfile", "-NonInteractive", "-Command", windowsToast},
+			env:  []string{"TRIAGE_NOTIFY_TITLE=" + n.Title, "TRIAGE_NOTIFY_BODY=" + n.Body},
+		}, nil
+	default:
+		return command{}, fmt.Errorf("desktop notifications are not supported on %s", goos)
+	}
+}
+
+// Notify shows n.
+func (d *Notifier) Notify(ctx context.Context, n Notification) error {
+	c, err := commandFor(d.goos, n)
+	if err != nil {
+		return err
+	}
+	return d.run(ctx, c)
+}
+
+// runCommand runs c, including its output in the error when it fails.
+func runCommand(ctx context.Context, c command) error {
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.name, c.args...)
+	if len(c.env) > 0 {
+		cmd.Env = append(os.Environ(), c.env...)
+	}
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		if output.Len() > 0 {
+			return fmt.Errorf("%s failed: %w: %s", c.name, err, bytes.TrimSpace(output.Bytes()))
+		}
+		return fmt.Errorf("%s failed: %w", c.name, err)
+	}
+	return nil
+}
+
+// NewStateStore opens the store of what was last notified about, kept
+// apart from the list's run snapshot so the two don't reset each other.
+func NewStateStore() (*snapshot.Store, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.NewStoreFromPath(filepath.Join(cacheDir, "triage", "desktop-notify.json"))
+}
@@ -0,0 +1,76 @@
+package desktop
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func urgentItem(number int) triage.PrioritizedItem {
+	return triage.PrioritizedItem{
+		Item: model.Item{
+			Number:     number,
+			Repository: model.Repository{FullName: "acme/api"},
+			Subject:    model.Subject{Title: "Outage"},
+		},
+		Priority: triage.PriorityUrgent,
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	notes := Notifications([]triage.PrioritizedItem{urgentItem(1)})
+	if len(notes) != 1 || notes[0].Title != "acme/api#1 is Urgent" || notes[0].Body != "Outage" {
+		t.Errorf("Notifications(1 item) = %+v", notes)
+	}
+
+	items := []triage.PrioritizedItem{urgentItem(1), urgentItem(2), urgentItem(3), urgentItem(4), urgentItem(5)}
+	notes = Notifications(items)
+	want := Notification{Title: "5 items need attention", Body: "acme/api#1, acme/api#2, acme/api#3 and 2 more"}
+	if len(notes) != 1 || notes[0] != want {
+		t.Errorf("Notifications(5 items) = %+v, want one summary %+v", notes, want)
+	}
+}
+
+func TestNotify(t *testing.T) {
+	n := Notification{Title: `acme/api#1 is "Urgent"`, Body: "it's down"}
+	tests := []struct {
+		goos     string
+		wantName string
+		wantArg  string // an argument or environment variable that carries the text as is
+		wantErr  bool
+	}{
+		{goos: "darwin", wantName: "osascript", wantArg: n.Title},
+		{goos: "linux", wantName: "notify-send", wantArg: n.Body},
+		{goos: "windows", wantName: "powershell", wantArg: "TRIAGE_NOTIFY_TITLE=" + n.Title},
+		{goos: "plan9", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			var ran command
+			d := &Notifier{goos: tt.goos, run: func(_ context.Context, c command) error {
+				ran = c
+				return nil
+			}}
+			err := d.Notify(context.Background(), n)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.goos) {
+					t.Errorf("Notify() error = %v, want unsupported %s", err, tt.goos)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+			if ran.name != tt.wantName {
+				t.Errorf("ran %q, want %q", ran.name, tt.wantName)
+			}
+			if !slices.Contains(ran.args, tt.wantArg) && !slices.Contains(ran.env, tt.wantArg) {
+				t.Errorf("command %+v doesn't pass %q", ran, tt.wantArg)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package desktop
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/snapshot"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// Policy decides which items notify, and when.
+type Policy struct {
+	// MinPriority is the lowest priority that notifies
+	MinPriority triage.PriorityLevel
+
+	// Quiet hours as minutes since local midnight; equal when there are none
+	quietStart, quietEnd int
+}
+
+// NewPolicy validates settings and returns the policy they describe.
+func NewPolicy(settings config.NotificationSettings) (Policy, error) {
+	minPriority, err := triage.ParsePriority(settings.MinPriority)
+	if err != nil {
+		return Policy{}, fmt.Errorf("invalid notifications min_priority: %w", err)
+	}
+	p := Policy{MinPriority: minPriority}
+	if settings.QuietHours == "" {
+		return p, nil
+	}
+
+	start, end, ok := strings.Cut(settings.QuietHours, "-")
+	if ok {
+		p.quietStart, err = parseClock(start)
+	}
+	if ok && err == nil {
+		p.quietEnd, err = parseClock(end)
+	}
+	if !ok || err != nil {
+		return Policy{}, fmt.Errorf("invalid notifications quiet_hours %q: expected a range such as 22:00-08:00", settings.QuietHours)
+	}
+	return p, nil
+}
+
+// parseClock parses a time of day such as 08:00 into minutes since midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Quiet reports whether now falls in the quiet hours. A range that ends
+// before it starts runs past midnight.
+func (p Policy) Quiet(now time.Time) bool {
+	minute := now.Hour()*60 + now.Minute()
+	if p.quietStart <= p.quietEnd {
+		return minute >= p.quietStart && minute < p.quietEnd
+	}
+	return minute >= p.quietStart || minute < p.quietEnd
+}
+
+// Crossed returns the items at MinPriority or above that weren't in prev,
+// the state when notifications were last sent.
+func (p Policy) Crossed(prev snapshot.State, items []triage.PrioritizedItem) []triage.PrioritizedItem {
+	var crossed []triage.PrioritizedItem
+	for _, item := range items {
+		if !p.notifies(item.Priority) {
+			continue
+		}
+		if e, seen := prev[item.Key()]; seen && p.notifies(e.Priority) {
+			continue
+		}
+		crossed = append(crossed, item)
+	}
+	return crossed
+}
+
+// notifies reports whether level is at MinPriority or above.
+func (p Policy) notifies(level triage.PriorityLevel) bool {
+	return level.Rank() <= p.MinPriority.Rank()
+}
@@ -0,0 +1,93 @@
+package desktop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/snapshot"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings config.NotificationSettings
+		wantErr  bool
+	}{
+		{"defaults", config.DefaultNotificationSettings(), false},
+		{"quiet hours", config.NotificationSettings{MinPriority: "important", QuietHours: "22:00-08:00"}, false},
+		{"unknown priority", config.NotificationSettings{MinPriority: "critical"}, true},
+		{"no range", config.NotificationSettings{MinPriority: "urgent", QuietHours: "22:00"}, true},
+		{"bad time", config.NotificationSettings{MinPriority: "urgent", QuietHours: "10pm-8am"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPolicy(tt.settings); (err != nil) != tt.wantErr {
+				t.Errorf("NewPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuiet(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 10, hour, minute, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name       string
+		quietHours string
+		now        time.Time
+		want       bool
+	}{
+		{"none", "", at(23, 0), false},
+		{"overnight, late", "22:00-08:00", at(23, 30), true},
+		{"overnight, early", "22:00-08:00", at(7, 59), true},
+		{"overnight, at the end", "22:00-08:00", at(8, 0), false},
+		{"overnight, daytime", "22:00-08:00", at(12, 0), false},
+		{"same day", "12:00-13:00", at(12, 30), true},
+		{"same day, outside", "12:00-13:00", at(13, 30), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPolicy(config.NotificationSettings{MinPriority: "urgent", QuietHours: tt.quietHours})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := p.Quiet(tt.now); got != tt.want {
+				t.Errorf("Quiet(%s) = %v, want %v", tt.now.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrossed(t *testing.T) {
+	p, err := NewPolicy(config.NotificationSettings{MinPriority: "important"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := func(number int, priority triage.PriorityLevel) triage.PrioritizedItem {
+		i := urgentItem(number)
+		i.Priority = priority
+		return i
+	}
+	items := []triage.PrioritizedItem{
+		item(1, triage.PriorityUrgent),    // was FYI
+		item(2, triage.PriorityUrgent),    // was already important
+		item(3, triage.PriorityImportant), // new
+		item(4, triage.PriorityQuickWin),  // below important
+		item(5, triage.PriorityNotable),   // new, below important
+	}
+	prev := snapshot.Capture([]triage.PrioritizedItem{
+		item(1, triage.PriorityFYI),
+		item(2, triage.PriorityImportant),
+	}, nil)
+
+	var got []int
+	for _, i := range p.Crossed(prev, items) {
+		got = append(got, i.Number)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("Crossed() = %v, want [1 3]", got)
+	}
+}
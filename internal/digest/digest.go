@@ -0,0 +1,118 @@
+// Package digest renders prioritized items as an email report and delivers
+// it over SMTP or through a local sendmail binary.
+package digest
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// group is the items of one priority, in the order they were given.
+type group struct {
+	priority triage.PriorityLevel
+	items    []triage.PrioritizedItem
+}
+
+// groupByPriority splits items by priority, most pressing first, leaving
+// out priorities with no items.
+func groupByPriority(items []triage.PrioritizedItem) []group {
+	var groups []group
+	for _, p := range triage.AllPriorityLevels {
+		g := group{priority: p}
+		for _, item := range items {
+			if item.Priority == p {
+				g.items = append(g.items, item)
+			}
+		}
+		if len(g.items) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// Subject summarizes the digest in a line that reads well in an inbox.
+func Subject(items []triage.PrioritizedItem) string {
+	if len(items) == 0 {
+		return "Triage: nothing needs your attention"
+	}
+	urgent := 0
+	for _, item := range items {
+		if item.Priority == triage.PriorityUrgent {
+			urgent++
+		}
+	}
+	if urgent == 0 {
+		return fmt.Sprintf("Triage: %s", plural(len(items), "item"))
+	}
+	return fmt.Sprintf("Triage: %d urgent, %s", urgent, plural(len(items), "item"))
+}
+
+// Text renders the digest as Markdown, which doubles as the plain text
+// part of the email.
+func Text(items []triage.PrioritizedItem) string {
+	var b strings.Builder
+	if len(items) == 0 {
+		b.WriteString("Nothing needs your attention.\n")
+		return b.String()
+	}
+	for i, g := range groupByPriority(items) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s (%d)\n\n", g.priority.Display(), len(g.items))
+		for _, item := range g.items {
+			ref := format.ItemRef(item.Repository.FullName, item.Number)
+			fmt.Fprintf(&b, "- %s %s\n", ref, format.Sanitize(item.Subject.Title))
+			if action := format.Sanitize(item.ActionNeeded); action != "" {
+				fmt.Fprintf(&b, "  %s\n", action)
+			}
+			if url := format.ItemURL(item.HTMLURL, item.Repository.HTMLURL); url != "" {
+				fmt.Fprintf(&b, "  %s\n", url)
+			}
+		}
+	}
+	return b.String()
+}
+
+// HTML renders the digest as a self-contained HTML document.
+func HTML(items []triage.PrioritizedItem) string {
+	return "<!DOCTYPE html>\n<html><body>\n" + HTMLBody(items) + "</body></html>\n"
+}
+
+// HTMLBody renders the digest as an HTML fragment, for embedding in a
+// message that supplies its own document.
+func HTMLBody(items []triage.PrioritizedItem) string {
+	var b strings.Builder
+	if len(items) == 0 {
+		b.WriteString("<p>Nothing needs your attention.</p>\n")
+	}
+	for _, g := range groupByPriority(items) {
+		fmt.Fprintf(&b, "<h2>%s (%d)</h2>\n<ul>\n", html.EscapeString(g.priority.Display()), len(g.items))
+		for _, item := range g.items {
+			title := html.EscapeString(format.Sanitize(item.Subject.Title))
+			if url := format.ItemURL(item.HTMLURL, item.Repository.HTMLURL); url != "" {
+				title = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), title)
+			}
+			ref := format.ItemRef(item.Repository.FullName, item.Number)
+			fmt.Fprintf(&b, "<li>%s %s", html.EscapeString(ref), title)
+			if action := format.Sanitize(item.ActionNeeded); action != "" {
+				fmt.Fprintf(&b, "<br><small>%s</small>", html.EscapeString(action))
+			}
+			b.WriteString("</li>\n")
+		}
+		b.WriteString("</ul>\n")
+	}
+	return b.String()
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
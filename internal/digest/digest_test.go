@@ -0,0 +1,78 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func digestItems() []triage.PrioritizedItem {
+	return []triage.PrioritizedItem{
+		{Priority: triage.PriorityUrgent, ActionNeeded: "Review requested", Item: model.Item{
+			Number:     12,
+			HTMLURL:    "https://github.com/acme/api/pull/12",
+			Subject:    model.Subject{Title: "Add <pagination>"},
+			Repository: model.Repository{FullName: "acme/api"},
+		}},
+		{Priority: triage.PriorityFYI, Item: model.Item{
+			Subject:    model.Subject{Title: "Flaky test\x1b[2J"},
+			Repository: model.Repository{FullName: "acme/web", HTMLURL: "https://github.com/acme/web"},
+		}},
+	}
+}
+
+func TestSubject(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []triage.PrioritizedItem
+		want  string
+	}{
+		{"empty", nil, "Triage: nothing needs your attention"},
+		{"urgent", digestItems(), "Triage: 1 urgent, 2 items"},
+		{"no urgent", digestItems()[1:], "Triage: 1 item"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Subject(tt.items); got != tt.want {
+				t.Errorf("Subject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	want := "## Urgent (1)\n\n" +
+		"- acme/api#12 Add <pagination>\n" +
+		"  Review requested\n" +
+		"  https://github.com/acme/api/pull/12\n" +
+		"\n" +
+		"## FYI (1)\n\n" +
+		"- acme/web Flaky test\n" +
+		"  https://github.com/acme/web\n"
+	if got := Text(digestItems()); got != want {
+		t.Errorf("Text() =\n%s\nwant\n%s", got, want)
+	}
+	if got := Text(nil); got != "Nothing needs your attention.\n" {
+		t.Errorf("Text(nil) = %q", got)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	got := HTML(digestItems())
+	for _, want := range []string{
+		"<h2>Urgent (1)</h2>",
+		`acme/api#12 <a href="https://github.com/acme/api/pull/12">Add &lt;pagination&gt;</a>`,
+		"<small>Review requested</small>",
+		"<h2>FYI (1)</h2>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "\x1b") {
+		t.Errorf("HTML() kept an escape sequence:\n%q", got)
+	}
+}
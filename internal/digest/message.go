@@ -0,0 +1,87 @@
+package digest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Message is an email carrying the digest as both plain text and HTML.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Date    time.Time
+	Text    string
+	HTML    string
+}
+
+// Validate reports whether the message has a valid sender and at least one
+// valid recipient.
+func (m Message) Validate() error {
+	if m.From == "" {
+		return errors.New("no sender address; set email.from in your config")
+	}
+	if _, err := mail.ParseAddress(m.From); err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", m.From, err)
+	}
+	if len(m.To) == 0 {
+		return errors.New("no recipients; pass --to or set email.to in your config")
+	}
+	for _, to := range m.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("invalid recipient address %q: %w", to, err)
+		}
+	}
+	return nil
+}
+
+// Bytes encodes the message as a multipart/alternative MIME message, ready
+// to hand to an SMTP server or sendmail.
+func (m Message) Bytes() ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&msg, "%s: %s\r\n", key, value)
+	}
+	header("From", m.From)
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", m.Date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": mw.Boundary()}))
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,152 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+)
+
+func testMessage() Message {
+	return Message{
+		From:    "Triage <triage@example.com>",
+		To:      []string{"me@example.com"},
+		Subject: "Triage: 1 urgent, 2 items",
+		Date:    time.Date(2024, 3, 4, 7, 0, 0, 0, time.UTC),
+		Text:    "## Urgent (1)\n\n- acme/api#12 Überarbeitung\n",
+		HTML:    "<h2>Urgent (1)</h2>\n",
+	}
+}
+
+func TestMessageValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Message)
+		wantErr string
+	}{
+		{"valid", func(*Message) {}, ""},
+		{"no sender", func(m *Message) { m.From = "" }, "no sender"},
+		{"bad sender", func(m *Message) { m.From = "not an address" }, "invalid sender"},
+		{"no recipients", func(m *Message) { m.To = nil }, "no recipients"},
+		{"bad recipient", func(m *Message) { m.To = []string{"me@example.com", "nope"} }, "invalid recipient"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testMessage()
+			tt.modify(&m)
+			err := m.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMessageBytes(t *testing.T) {
+	data, err := testMessage().Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if got := msg.Header.Get("To"); got != "me@example.com" {
+		t.Errorf("To = %q", got)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "Triage: 1 urgent, 2 items" {
+		t.Errorf("Subject = %q, %v", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	want := []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", testMessage().Text},
+		{"text/html; charset=utf-8", testMessage().HTML},
+	}
+	for _, w := range want {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("NextPart() error = %v", err)
+		}
+		if got := part.Header.Get("Content-Type"); got != w.contentType {
+			t.Errorf("part Content-Type = %q, want %q", got, w.contentType)
+		}
+		// The multipart reader undoes the quoted-printable encoding, which
+		// sends line breaks as CRLF
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.ReplaceAll(string(body), "\r\n", "\n"); got != w.body {
+			t.Errorf("part body = %q, want %q", got, w.body)
+		}
+	}
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Errorf("expected two parts, NextPart() error = %v", err)
+	}
+}
+
+func TestSendSendmail(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake sendmail is a shell script")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "sendmail")
+	body := "#!/bin/sh\necho \"$@\" > " + out + ".args\ncat > " + out + "\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := config.DefaultEmailSettings()
+	settings.Sendmail = script
+	if err := Send(context.Background(), testMessage(), settings); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	args, err := os.ReadFile(out + ".args")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "-i -f triage@example.com -- me@example.com" {
+		t.Errorf("sendmail args = %q", got)
+	}
+	sent, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := testMessage().Bytes()
+	// The boundary is random, so compare everything up to it
+	if header, _, _ := bytes.Cut(want, []byte("boundary=")); !bytes.HasPrefix(sent, header) {
+		t.Errorf("sendmail received:\n%s", sent)
+	}
+}
+
+func TestSendRequiresTransport(t *testing.T) {
+	err := Send(context.Background(), testMessage(), config.DefaultEmailSettings())
+	if err == nil || !strings.Contains(err.Error(), "no mail transport") {
+		t.Errorf("Send() = %v, want missing transport error", err)
+	}
+}
@@ -0,0 +1,136 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/spiffcs/triage/config"
+)
+
+// implicitTLSPort is the SMTP submission port that expects TLS from the
+// first byte rather than upgrading with STARTTLS.
+const implicitTLSPort = 465
+
+// Send delivers msg with sendmail when one is configured, and over SMTP
+// otherwise.
+func Send(ctx context.Context, msg Message, settings config.EmailSettings) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+	if settings.Sendmail != "" {
+		return sendmail(ctx, settings.Sendmail, msg, data)
+	}
+	if settings.SMTPHost == "" {
+		return errors.New("no mail transport; set email.smtp_host or email.sendmail in your config")
+	}
+	return sendSMTP(msg, data, settings)
+}
+
+// sendmail pipes the message to a sendmail-compatible binary.
+func sendmail(ctx context.Context, path string, msg Message, data []byte) error {
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return err
+	}
+	args := []string{"-i", "-f", from.Address, "--"}
+	for _, to := range msg.To {
+		addr, err := mail.ParseAddress(to)
+		if err != nil {
+			return err
+		}
+		args = append(args, addr.Address)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return fmt.Errorf("sendmail failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return fmt.Errorf("sendmail failed: %w", err)
+	}
+	return nil
+}
+
+// sendSMTP submits the message to the configured SMTP server. Port 465
+// uses implicit TLS; any other port upgrades with STARTTLS when the server
+// offers it.
+func sendSMTP(msg Message, data []byte, settings config.EmailSettings) error {
+	addr := net.JoinHostPort(settings.SMTPHost, strconv.Itoa(settings.SMTPPort))
+
+	var auth smtp.Auth
+	if settings.Username != "" {
+		password := os.Getenv(settings.PasswordEnv)
+		if password == "" {
+			return fmt.Errorf("email.username is set but $%s is empty", settings.PasswordEnv)
+		}
+		auth = smtp.PlainAuth("", settings.Username, password, settings.SMTPHost)
+	}
+
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return err
+	}
+	to := make([]string, len(msg.To))
+	for i, t := range msg.To {
+		addr, err := mail.ParseAddress(t)
+		if err != nil {
+			return err
+		}
+		to[i] = addr.Address
+	}
+
+	if settings.SMTPPort != implicitTLSPort {
+		return smtp.SendMail(addr, auth, from.Address, to, data)
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: settings.SMTPHost})
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	c, err := smtp.NewClient(conn, settings.SMTPHost)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func() { _ = c.Close() }()
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -9,15 +9,23 @@ import (
 // Parse parses human-readable durations like "1w", "30d", "6mo".
 // It returns the time that is the given duration in the past from now.
 func Parse(s string) (time.Time, error) {
-	now := time.Now()
+	d, err := ParseDuration(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-d), nil
+}
 
+// ParseDuration parses human-readable durations like "1w", "30d", "6mo"
+// into a time.Duration.
+func ParseDuration(s string) (time.Duration, error) {
 	// Handle common patterns
 	var d time.Duration
 	var n int
 	var unit string
 
 	if _, err := fmt.Sscanf(s, "%d%s", &n, &unit); err != nil {
-		return time.Time{}, fmt.Errorf("invalid duration format: %s (use e.g., 1w, 30d, 6mo)", s)
+		return 0, fmt.Errorf("invalid duration format: %s (use e.g., 1w, 30d, 6mo)", s)
 	}
 
 	switch unit {
@@ -34,8 +42,8 @@ func Parse(s string) (time.Time, error) {
 	case "y", "yr", "yrs", "year", "years":
 		d = time.Duration(n) * 365 * 24 * time.Hour
 	default:
-		return time.Time{}, fmt.Errorf("unknown duration unit: %s", unit)
+		return 0, fmt.Errorf("unknown duration unit: %s", unit)
 	}
 
-	return now.Add(-d), nil
+	return d, nil
 }
@@ -0,0 +1,181 @@
+// Package editor implements the offline reply workflow: an item is rendered
+// to a temporary Markdown buffer, opened in the user's editor, and any text
+// written below the reply marker is posted back as a comment.
+package editor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// Marker separates the read-only context from the reply. Everything after
+// the marker line is posted as a comment; everything before it is ignored.
+const Marker = "<!-- triage: write your reply below this line; everything above it is ignored -->"
+
+// DefaultMaxComments is the number of recent comments included in a buffer.
+const DefaultMaxComments = 10
+
+// defaultEditor is used when neither $VISUAL nor $EDITOR is set.
+const defaultEditor = "vi"
+
+// ThreadClient is the subset of the GitHub client the editor workflow needs.
+type ThreadClient interface {
+	GetThread(ctx context.Context, owner, repo string, number, maxComments int) (*model.Thread, error)
+	CreateComment(ctx context.Context, owner, repo string, number int, body string) error
+}
+
+// Session prepares edit buffers and submits replies for items.
+type Session struct {
+	client      ThreadClient
+	maxComments int
+}
+
+// NewSession creates a Session backed by client.
+func NewSession(client ThreadClient) *Session {
+	return &Session{
+		client:      client,
+		maxComments: DefaultMaxComments,
+	}
+}
+
+// Prepare fetches the thread and writes it to a temporary Markdown file,
+// returning the file path. The caller should pass the path to Submit once
+// the editor exits.
+func (s *Session) Prepare(ctx context.Context, owner, repo string, number int) (string, error) {
+	thread, err := s.client.GetThread(ctx, owner, repo, number, s.maxComments)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp("", fmt.Sprintf("triage-%s-%s-%d-*.md", owner, repo, number))
+	if err != nil {
+		return "", fmt.Errorf("failed to create edit buffer: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(Render(thread)); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write edit buffer: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// Submit reads the buffer at path and posts any reply text as a comment.
+// It reports whether a comment was posted. The buffer is removed only after
+// a successful post (or when there is nothing to post) so a failed reply
+// can be recovered from disk.
+func (s *Session) Submit(ctx context.Context, owner, repo string, number int, path string) (bool, error) {
+	reply, err := ReadReply(path)
+	if err != nil {
+		return false, err
+	}
+	if reply == "" {
+		_ = os.Remove(path)
+		return false, nil
+	}
+
+	if err := s.client.CreateComment(ctx, owner, repo, number, reply); err != nil {
+		return false, fmt.Errorf("%w (draft kept at %s)", err, path)
+	}
+	_ = os.Remove(path)
+	return true, nil
+}
+
+// Render formats a thread as a Markdown buffer ending with the reply marker.
+func Render(t *model.Thread) string {
+	var b strings.Builder
+
+	kind := "Issue"
+	if t.IsPR {
+		kind = "Pull Request"
+	}
+
+	fmt.Fprintf(&b, "# %s#%d: %s\n\n", t.Repository, t.Number, t.Title)
+	fmt.Fprintf(&b, "- Type: %s\n", kind)
+	fmt.Fprintf(&b, "- State: %s\n", t.State)
+	fmt.Fprintf(&b, "- Author: @%s\n", t.Author)
+	if !t.CreatedAt.IsZero() {
+		fmt.Fprintf(&b, "- Opened: %s\n", age(t.CreatedAt))
+	}
+	if len(t.Labels) > 0 {
+		fmt.Fprintf(&b, "- Labels: %s\n", strings.Join(t.Labels, ", "))
+	}
+	if t.HTMLURL != "" {
+		fmt.Fprintf(&b, "- URL: %s\n", t.HTMLURL)
+	}
+
+	b.WriteString("\n## Description\n\n")
+	if body := strings.TrimSpace(t.Body); body != "" {
+		b.WriteString(body)
+	} else {
+		b.WriteString("_No description provided._")
+	}
+	b.WriteString("\n")
+
+	if len(t.Comments) > 0 {
+		fmt.Fprintf(&b, "\n## Recent comments (%d)\n", len(t.Comments))
+		for _, c := range t.Comments {
+			fmt.Fprintf(&b, "\n### @%s, %s\n\n", c.Author, age(c.CreatedAt))
+			b.WriteString(strings.TrimSpace(c.Body))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(Marker)
+	b.WriteString("\n\n")
+
+	return b.String()
+}
+
+// age formats a timestamp relative to now for display in the buffer.
+func age(t time.Time) string {
+	s := format.FormatAge(time.Since(t))
+	if s == "now" {
+		return "just now"
+	}
+	return s + " ago"
+}
+
+// ReadReply reads the buffer at path and returns its reply text, if any.
+func ReadReply(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edit buffer: %w", err)
+	}
+	return ExtractReply(string(data)), nil
+}
+
+// ExtractReply returns the trimmed text following the last reply marker.
+// If the marker was deleted the buffer is treated as having no reply, so a
+// mangled buffer never posts the quoted context by accident.
+func ExtractReply(content string) string {
+	idx := strings.LastIndex(content, Marker)
+	if idx < 0 {
+		return ""
+	}
+	return strings.TrimSpace(content[idx+len(Marker):])
+}
+
+// Command returns an *exec.Cmd that opens path in the user's editor,
+// preferring $VISUAL, then $EDITOR, then vi. Editor values may include
+// arguments (e.g. "code --wait").
+func Command(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{defaultEditor}
+	}
+	args := append(fields[1:], path)
+	return exec.Command(fields[0], args...)
+}
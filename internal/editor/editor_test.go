@@ -0,0 +1,165 @@
+package editor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+type fakeClient struct {
+	thread    *model.Thread
+	posted    []string
+	createErr error
+}
+
+func (f *fakeClient) GetThread(_ context.Context, _, _ string, _, _ int) (*model.Thread, error) {
+	return f.thread, nil
+}
+
+func (f *fakeClient) CreateComment(_ context.Context, _, _ string, _ int, body string) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	f.posted = append(f.posted, body)
+	return nil
+}
+
+func testThread() *model.Thread {
+	return &model.Thread{
+		Repository: "owner/repo",
+		Number:     42,
+		Title:      "Fix the thing",
+		Body:       "It is broken.",
+		Author:     "alice",
+		State:      "open",
+		HTMLURL:    "https://github.com/owner/repo/issues/42",
+		Labels:     []string{"bug"},
+		CreatedAt:  time.Now().Add(-48 * time.Hour),
+		Comments: []model.Comment{
+			{Author: "bob", Body: "Can reproduce.", CreatedAt: time.Now().Add(-time.Hour)},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	out := Render(testThread())
+
+	for _, want := range []string{
+		"# owner/repo#42: Fix the thing",
+		"- Type: Issue",
+		"- Author: @alice",
+		"- Labels: bug",
+		"It is broken.",
+		"### @bob, 1h ago",
+		"Can reproduce.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered buffer missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(strings.TrimSpace(out), Marker) {
+		t.Errorf("rendered buffer should end with the reply marker:\n%s", out)
+	}
+	if got := ExtractReply(out); got != "" {
+		t.Errorf("untouched buffer should have no reply, got %q", got)
+	}
+}
+
+func TestExtractReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no marker", "just some text", ""},
+		{"empty reply", "context\n" + Marker + "\n\n   \n", ""},
+		{"reply", "context\n" + Marker + "\n\nLGTM, thanks!\n", "LGTM, thanks!"},
+		{"multiline reply", Marker + "\nline one\n\nline two\n", "line one\n\nline two"},
+		{"quoted marker in body", "body mentions " + Marker + "\nmore\n" + Marker + "\nreal reply", "real reply"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractReply(tt.content); got != tt.want {
+				t.Errorf("ExtractReply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionRoundTrip(t *testing.T) {
+	client := &fakeClient{thread: testThread()}
+	s := NewSession(client)
+	ctx := context.Background()
+
+	path, err := s.Prepare(ctx, "owner", "repo", 42)
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+
+	// Simulate the user typing a reply below the marker.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("On it.\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	posted, err := s.Submit(ctx, "owner", "repo", 42, path)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if !posted || len(client.posted) != 1 || client.posted[0] != "On it." {
+		t.Errorf("expected reply %q to be posted, got posted=%v %q", "On it.", posted, client.posted)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected buffer to be removed after posting")
+	}
+}
+
+func TestSessionSubmitKeepsDraftOnError(t *testing.T) {
+	client := &fakeClient{thread: testThread(), createErr: errors.New("boom")}
+	s := NewSession(client)
+	ctx := context.Background()
+
+	path, err := s.Prepare(ctx, "owner", "repo", 42)
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Remove(path) })
+
+	if err := os.WriteFile(path, []byte(Marker+"\ndraft reply\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Submit(ctx, "owner", "repo", 42, path); err == nil {
+		t.Fatal("expected error from Submit")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected draft to be kept after failed post: %v", err)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+
+	cmd := Command("/tmp/buffer.md")
+	want := []string{"code", "--wait", "/tmp/buffer.md"}
+	if strings.Join(cmd.Args, " ") != strings.Join(want, " ") {
+		t.Errorf("Command args = %v, want %v", cmd.Args, want)
+	}
+
+	t.Setenv("EDITOR", "")
+	cmd = Command("/tmp/buffer.md")
+	if cmd.Args[0] != defaultEditor {
+		t.Errorf("expected fallback editor %q, got %q", defaultEditor, cmd.Args[0])
+	}
+}
@@ -0,0 +1,315 @@
+// Package fake generates realistic, deterministic work items for demos,
+// screenshots, and tests. The same Options always produce the same items,
+// so no GitHub token or network access is needed.
+package fake
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"path"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// DefaultUser is the login generated items are triaged for.
+const DefaultUser = "octocat"
+
+// depsAuthor is the dependency bot recognized by the TUI's Deps pane by default.
+const depsAuthor = "dependabot[bot]"
+
+// DefaultCount is the number of items generated when Options.Count is zero.
+const DefaultCount = 40
+
+// Options configures item generation.
+type Options struct {
+	Seed        uint64    // Same seed, same items
+	Count       int       // Number of items (default: DefaultCount)
+	Now         time.Time // Reference time for ages (default: time.Now())
+	CurrentUser string    // Login items are generated for (default: DefaultUser)
+}
+
+var (
+	repos = []string{
+		"acme/api", "acme/web", "acme/cli", "acme/infra",
+		"widgets/core", "widgets/docs", "widgets/sdk-go",
+	}
+	teammates = []string{"monalisa", "hubot", "mona", "codercat", "jetpackcat", "spacecat"}
+	outsiders = []string{"newcomer", "drive-by-dev", "first-timer", "weekend-hacker"}
+
+	prTitles = []string{
+		"Add pagination to list endpoint",
+		"Migrate storage layer to the v2 client",
+		"Fix race in cache invalidation",
+		"Support custom headers in webhook delivery",
+		"Refactor config loading into its own package",
+		"Speed up startup by lazy-loading plugins",
+		"Handle 502s from the upstream gracefully",
+		"Add dark mode to the settings page",
+		"Remove deprecated v1 routes",
+		"Instrument request latency with histograms",
+	}
+	issueTitles = []string{
+		"Login page crashes on Safari",
+		"Document the release process",
+		"Flaky test: TestServerShutdown",
+		"Memory usage grows unbounded under load",
+		"Support Windows line endings in import",
+		"Error message is unclear when token expires",
+		"Add a --quiet flag",
+		"Broken link in the getting started guide",
+		"Timeouts when syncing large repositories",
+		"Proposal: plugin API v2",
+	}
+	depsTitles = []string{
+		"Bump golang.org/x/net from 0.20.0 to 0.23.0",
+		"Bump github.com/spf13/cobra from 1.8.0 to 1.10.2",
+		"Bump actions/checkout from 3 to 4",
+		"Bump lodash from 4.17.20 to 4.17.21",
+	}
+	labelPool = []string{"bug", "enhancement", "documentation", "good first issue", "help wanted", "performance"}
+	ciStates  = []string{model.CIStatusSuccess, model.CIStatusSuccess, model.CIStatusFailure, model.CIStatusPending, ""}
+)
+
+// kind is the shape of a generated item, which decides its reason,
+// author, assignment, and therefore the TUI pane it lands in.
+type kind int
+
+const (
+	kindReview kind = iota
+	kindMention
+	kindAuthored
+	kindAssigned
+	kindSubscribed
+	kindTeamMention
+	kindDeps
+	kindOrphaned
+)
+
+// kindWeights sets how often each kind is generated (out of 100).
+var kindWeights = []struct {
+	kind   kind
+	weight int
+}{
+	{kindReview, 20},
+	{kindMention, 12},
+	{kindAuthored, 14},
+	{kindAssigned, 16},
+	{kindSubscribed, 14},
+	{kindTeamMention, 6},
+	{kindDeps, 9},
+	{kindOrphaned, 9},
+}
+
+// Items generates opts.Count items. Items are not scored; run them through
+// the triage engine to prioritize them.
+func Items(opts Options) []model.Item {
+	if opts.Count <= 0 {
+		opts.Count = DefaultCount
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.CurrentUser == "" {
+		opts.CurrentUser = DefaultUser
+	}
+
+	g := &generator{
+		rng:  rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x5eed)),
+		opts: opts,
+		used: make(map[string]bool),
+	}
+
+	items := make([]model.Item, 0, opts.Count)
+	for i := 0; i < opts.Count; i++ {
+		items = append(items, g.item(i))
+	}
+	return items
+}
+
+type generator struct {
+	rng  *rand.Rand
+	opts Options
+	used map[string]bool // repo#number already generated
+}
+
+func (g *generator) item(i int) model.Item {
+	k := g.kind()
+	repo := pick(g.rng, repos)
+	number := g.number(repo)
+	updated := g.age()
+
+	isPR := k == kindReview || k == kindAuthored || k == kindDeps || g.rng.IntN(2) == 0
+	item := model.Item{
+		ID:         fmt.Sprintf("fake-%d", i+1),
+		Unread:     true,
+		UpdatedAt:  updated,
+		CreatedAt:  updated.Add(-time.Duration(1+g.rng.IntN(30)) * 24 * time.Hour),
+		Repository: repository(repo),
+		Number:     number,
+		State:      model.StateOpen,
+		Author:     pick(g.rng, teammates),
+	}
+	if g.rng.IntN(3) == 0 {
+		item.Labels = []string{pick(g.rng, labelPool)}
+	}
+	item.CommentCount = g.rng.IntN(8)
+	if g.rng.IntN(8) == 0 {
+		item.CommentCount += 10 + g.rng.IntN(20) // the occasional hot topic
+	}
+
+	switch k {
+	case kindReview:
+		item.Reason = model.ReasonReviewRequested
+	case kindMention:
+		item.Reason = model.ReasonMention
+	case kindAuthored:
+		item.Reason = model.ReasonAuthor
+		item.Author = g.opts.CurrentUser
+	case kindAssigned:
+		item.Reason = model.ReasonAssign
+		item.Assignees = []string{g.opts.CurrentUser}
+		if g.rng.IntN(4) == 0 {
+			item.Labels = append(item.Labels, "blocked")
+		}
+	case kindSubscribed:
+		item.Reason = pick(g.rng, []model.ItemReason{model.ReasonSubscribed, model.ReasonComment, model.ReasonStateChange})
+		if g.rng.IntN(3) == 0 {
+			item.Assignees = []string{pick(g.rng, teammates)}
+		}
+	case kindTeamMention:
+		item.Reason = model.ReasonTeamMention
+	case kindDeps:
+		item.Reason = model.ReasonSubscribed
+		item.Author = depsAuthor
+	case kindOrphaned:
+		item.Reason = model.ReasonOrphaned
+		item.Author = pick(g.rng, outsiders)
+		item.AuthorAssociation = "CONTRIBUTOR"
+		item.ConsecutiveAuthorComments = 2 + g.rng.IntN(3)
+		if g.rng.IntN(2) == 0 {
+			last := updated.Add(-time.Duration(7+g.rng.IntN(30)) * 24 * time.Hour)
+			item.LastTeamActivityAt = &last
+		}
+	}
+
+	if isPR {
+		g.fillPR(&item, k)
+	} else {
+		g.fillIssue(&item)
+	}
+	return item
+}
+
+func (g *generator) fillPR(item *model.Item, k kind) {
+	title := pick(g.rng, prTitles)
+	if k == kindDeps {
+		title = pick(g.rng, depsTitles)
+	}
+	repo := item.Repository.FullName
+	item.Type = model.ItemTypePullRequest
+	item.HTMLURL = fmt.Sprintf("https://github.com/%s/pull/%d", repo, item.Number)
+	item.Subject = model.Subject{
+		Title: title,
+		URL:   fmt.Sprintf("https://api.github.com/repos/%s/pulls/%d", repo, item.Number),
+		Type:  model.SubjectPullRequest,
+	}
+	item.URL = item.Subject.URL
+
+	pr := &model.PRDetails{
+		Additions:    g.size(),
+		Deletions:    g.size() / 3,
+		ChangedFiles: 1 + g.rng.IntN(25),
+		CIStatus:     pick(g.rng, ciStates),
+		ReviewState:  pick(g.rng, []string{model.ReviewStatePending, model.ReviewStatePending, model.ReviewStateApproved, model.ReviewStateChangesRequested}),
+		Draft:        g.rng.IntN(10) == 0,
+	}
+	if k == kindDeps {
+		pr.Additions, pr.Deletions, pr.ChangedFiles = 2+g.rng.IntN(10), 2+g.rng.IntN(10), 2
+	}
+	pr.Mergeable = pr.ReviewState == model.ReviewStateApproved && g.rng.IntN(4) != 0
+	if pr.ReviewState != model.ReviewStatePending {
+		pr.LatestReviewer = pick(g.rng, teammates)
+		pr.ReviewComments = g.rng.IntN(6)
+	}
+	if k == kindReview {
+		pr.RequestedReviewers = []string{g.opts.CurrentUser}
+	}
+	item.Details = pr
+}
+
+func (g *generator) fillIssue(item *model.Item) {
+	repo := item.Repository.FullName
+	item.Type = model.ItemTypeIssue
+	item.HTMLURL = fmt.Sprintf("https://github.com/%s/issues/%d", repo, item.Number)
+	item.Subject = model.Subject{
+		Title: pick(g.rng, issueTitles),
+		URL:   fmt.Sprintf("https://api.github.com/repos/%s/issues/%d", repo, item.Number),
+		Type:  model.SubjectIssue,
+	}
+	item.URL = item.Subject.URL
+
+	lastCommenter := item.Author
+	if item.CommentCount > 0 {
+		lastCommenter = pick(g.rng, append([]string{g.opts.CurrentUser}, teammates...))
+	}
+	item.Details = &model.IssueDetails{LastCommenter: lastCommenter}
+}
+
+func (g *generator) kind() kind {
+	n := g.rng.IntN(100)
+	for _, kw := range kindWeights {
+		if n < kw.weight {
+			return kw.kind
+		}
+		n -= kw.weight
+	}
+	return kindSubscribed
+}
+
+// number returns an issue/PR number not yet used in repo.
+func (g *generator) number(repo string) int {
+	for {
+		n := 1 + g.rng.IntN(2000)
+		key := fmt.Sprintf("%s#%d", repo, n)
+		if !g.used[key] {
+			g.used[key] = true
+			return n
+		}
+	}
+}
+
+// age returns an update time before Now. Offsets sit in the middle of an
+// hour or day so rendered ages don't flip between runs of a test.
+func (g *generator) age() time.Time {
+	if g.rng.IntN(2) == 0 {
+		return g.opts.Now.Add(-time.Duration(1+g.rng.IntN(20))*time.Hour - 30*time.Minute)
+	}
+	return g.opts.Now.Add(-time.Duration(1+g.rng.IntN(21))*24*time.Hour - 12*time.Hour)
+}
+
+// size returns a line count skewed towards small changes.
+func (g *generator) size() int {
+	switch g.rng.IntN(4) {
+	case 0:
+		return 1 + g.rng.IntN(20)
+	case 1:
+		return 20 + g.rng.IntN(100)
+	case 2:
+		return 120 + g.rng.IntN(400)
+	default:
+		return 500 + g.rng.IntN(1500)
+	}
+}
+
+func repository(fullName string) model.Repository {
+	return model.Repository{
+		Name:     path.Base(fullName),
+		FullName: fullName,
+		HTMLURL:  "https://github.com/" + fullName,
+	}
+}
+
+func pick[T any](rng *rand.Rand, from []T) T {
+	return from[rng.IntN(len(from))]
+}
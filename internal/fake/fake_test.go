@@ -0,0 +1,78 @@
+package fake
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestItemsDeterministic(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	a := Items(Options{Seed: 42, Now: now})
+	b := Items(Options{Seed: 42, Now: now})
+	if !reflect.DeepEqual(a, b) {
+		t.Error("same seed should generate identical items")
+	}
+
+	c := Items(Options{Seed: 43, Now: now})
+	if reflect.DeepEqual(a, c) {
+		t.Error("different seeds should generate different items")
+	}
+}
+
+func TestItems(t *testing.T) {
+	now := time.Now()
+	items := Items(Options{Seed: 1, Count: 200, Now: now})
+	if len(items) != 200 {
+		t.Fatalf("Items() returned %d items, want 200", len(items))
+	}
+
+	refs := make(map[string]bool)
+	reasons := make(map[model.ItemReason]bool)
+	var assigned, deps int
+	for _, item := range items {
+		ref := item.Subject.URL
+		if refs[ref] {
+			t.Errorf("duplicate item %s", ref)
+		}
+		refs[ref] = true
+		reasons[item.Reason] = true
+
+		if item.Details == nil {
+			t.Errorf("%s has no details", item.ID)
+		}
+		if item.IsPR() != (item.Subject.Type == model.SubjectPullRequest) {
+			t.Errorf("%s type %s does not match subject %s", item.ID, item.Type, item.Subject.Type)
+		}
+		if !item.UpdatedAt.Before(now) {
+			t.Errorf("%s updated in the future", item.ID)
+		}
+		for _, a := range item.Assignees {
+			if a == DefaultUser {
+				assigned++
+			}
+		}
+		if item.Author == depsAuthor {
+			deps++
+		}
+	}
+
+	for _, r := range []model.ItemReason{model.ReasonReviewRequested, model.ReasonMention, model.ReasonAuthor, model.ReasonAssign, model.ReasonOrphaned} {
+		if !reasons[r] {
+			t.Errorf("no items generated with reason %s", r)
+		}
+	}
+	if assigned == 0 || deps == 0 {
+		t.Errorf("expected assigned and dependency items, got assigned=%d deps=%d", assigned, deps)
+	}
+}
+
+func TestItemsDefaults(t *testing.T) {
+	items := Items(Options{})
+	if len(items) != DefaultCount {
+		t.Errorf("Items() returned %d items, want %d", len(items), DefaultCount)
+	}
+}
@@ -28,3 +28,41 @@ func FormatAge(d time.Duration) string {
 	months := days / 30
 	return fmt.Sprintf("%dmo", months)
 }
+
+// FormatAgo formats how long ago something happened, e.g. "5d ago", or
+// "just now" under a minute.
+func FormatAgo(d time.Duration) string {
+	if d < time.Minute {
+		return "just now"
+	}
+	return FormatAge(d) + " ago"
+}
+
+// FormatAgeWords formats a duration with the unit spelled out, e.g.
+// "1 hour" or "3 weeks", for output read aloud by screen readers. It uses
+// the same units and rounding as FormatAge.
+func FormatAgeWords(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+	if d < time.Minute {
+		return "less than a minute"
+	}
+	if d < time.Hour {
+		return plural(int(d.Minutes()), "minute")
+	}
+	if d < 24*time.Hour {
+		return plural(int(d.Hours()), "hour")
+	}
+	days := int(d.Hours() / 24)
+	if days < 7 {
+		return plural(days, "day")
+	}
+	if days < 30 {
+		return plural(days/7, "week")
+	}
+	return plural(days/30, "month")
+}
@@ -54,3 +54,40 @@ func TestFormatAge(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatAgo(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{30 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{5 * 24 * time.Hour, "5d ago"},
+		{90 * 24 * time.Hour, "3mo ago"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatAgo(tt.duration); got != tt.expected {
+			t.Errorf("FormatAgo(%v) = %q, want %q", tt.duration, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatAgeWords(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{30 * time.Second, "less than a minute"},
+		{time.Minute, "1 minute"},
+		{5 * time.Hour, "5 hours"},
+		{24 * time.Hour, "1 day"},
+		{14 * 24 * time.Hour, "2 weeks"},
+		{95 * 24 * time.Hour, "3 months"},
+	}
+	for _, tt := range tests {
+		if got := FormatAgeWords(tt.duration); got != tt.want {
+			t.Errorf("FormatAgeWords(%v) = %q, want %q", tt.duration, got, tt.want)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package format
 
+import "strings"
+
 // AssignedOptions contains the fields needed to determine the assigned user.
 type AssignedOptions struct {
 	Assignees          []string
@@ -18,14 +20,32 @@ func Assigned(input AssignedOptions) string {
 	return ""
 }
 
-// TruncateUsername truncates a username to fit within maxWidth.
-// If truncation is needed, an ellipsis is added.
+// TruncateUsername truncates a username to fit within maxWidth display
+// columns. If truncation is needed, an ellipsis is added.
 func TruncateUsername(username string, maxWidth int) string {
-	if len(username) <= maxWidth {
+	if DisplayWidth(username) <= maxWidth {
 		return username
 	}
-	if maxWidth <= 1 {
-		return username[:maxWidth]
+	if maxWidth <= 0 {
+		return ""
+	}
+
+	// Leave a column for the ellipsis unless there is only one
+	target := maxWidth - 1
+	if maxWidth == 1 {
+		target = 1
+	}
+	var b strings.Builder
+	width := 0
+	for _, c := range splitCells(StripAnsi(username)) {
+		if width+c.width > target {
+			break
+		}
+		b.WriteString(c.text)
+		width += c.width
+	}
+	if maxWidth == 1 {
+		return b.String()
 	}
-	return username[:maxWidth-1] + "\u2026" // ellipsis character
+	return b.String() + "\u2026" // ellipsis character
 }
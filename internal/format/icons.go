@@ -10,6 +10,14 @@ const (
 	IconHotTopic
 	// IconQuickWin indicates a quick win (lightning emoji).
 	IconQuickWin
+	// IconLocked indicates the token can't read the item's repository (lock emoji).
+	IconLocked
+	// IconResurfaced indicates a resolved item that has new activity (repeat emoji).
+	IconResurfaced
+	// IconReminder indicates a reminder set on the item is due (alarm clock emoji).
+	IconReminder
+	// IconStale indicates an offline run showed the item from an expired cache entry (hourglass emoji).
+	IconStale
 )
 
 // IconOptions contains the fields needed to determine which icon to display.
@@ -20,12 +28,33 @@ type IconOptions struct {
 	LastCommenter     string
 	CurrentUser       string
 	IsQuickWin        bool
+	Inaccessible      bool
+	Resurfaced        bool
+	ReminderDue       bool
+	Stale             bool
 }
 
 // Icon decides which icon (if any) should be displayed for an item.
+// Locked takes precedence since nothing else is known about such items,
+// then Stale, since what else is shown may be out of date.
+// A due reminder comes next, since the user asked to be reminded, then
+// Resurfaced, since the item was thought done.
 // Hot topic (fire) takes precedence over quick win (lightning).
 // For issues, hot topic is suppressed if the current user was the last commenter.
 func Icon(input IconOptions) IconType {
+	if input.Inaccessible {
+		return IconLocked
+	}
+	if input.Stale {
+		return IconStale
+	}
+	if input.ReminderDue {
+		return IconReminder
+	}
+	if input.Resurfaced {
+		return IconResurfaced
+	}
+
 	// Check for hot topic first (fire takes precedence over quick win)
 	if input.HotTopicThreshold > 0 && input.CommentCount > input.HotTopicThreshold {
 		// Suppress for issues where current user was last commenter
@@ -43,6 +72,21 @@ func Icon(input IconOptions) IconType {
 	return IconNone
 }
 
+// ChainStatus prefixes a status column of visible width width with
+// ChainIcon, returning it and its new width.
+func ChainStatus(status string, width int) (string, int) {
+	return ChainIcon + " " + status, width + DisplayWidth(ChainIcon) + 1
+}
+
+// ReminderTitle prefixes title with the note of a due reminder, e.g.
+// "[ping after release] title", so the note survives truncation.
+func ReminderTitle(title, note string) string {
+	if note == "" {
+		return title
+	}
+	return "[" + note + "] " + title
+}
+
 // Icon strings for display (renderers can apply their own styling)
 const (
 	// HotTopicIcon is the fire emoji for hot topics.
@@ -52,6 +96,22 @@ const (
 	// Using U+26A1 + U+FE0F to force emoji presentation for consistent 2-column width.
 	QuickWinIcon = "\u26A1\uFE0F" // ⚡️
 
+	// LockedIcon is the lock emoji for items in repositories the token can't read.
+	LockedIcon = "\U0001F512" // 🔒
+
+	// ResurfacedIcon is the repeat emoji for resolved items with new activity.
+	ResurfacedIcon = "\U0001F501" // 🔁
+
+	// ReminderIcon is the alarm clock emoji for items with a due reminder.
+	ReminderIcon = "\u23F0" // ⏰
+
+	// StaleIcon is the hourglass emoji for items shown from expired cache entries.
+	StaleIcon = "\u231B" // ⌛
+
+	// ChainIcon is the chains emoji for items blocked by, or blocking, others.
+	// Using U+26D3 + U+FE0F to force emoji presentation for consistent 2-column width.
+	ChainIcon = "\u26D3\uFE0F" // ⛓️
+
 	// IconWidth is the display width reserved for the icon column (emoji=2 + space=1).
 	IconWidth = 3
 )
@@ -74,6 +74,63 @@ func TestIcon(t *testing.T) {
 			},
 			expected: IconHotTopic,
 		},
+		{
+			name: "locked takes precedence over everything",
+			input: IconOptions{
+				CommentCount:      10,
+				HotTopicThreshold: 5,
+				IsQuickWin:        true,
+				Inaccessible:      true,
+			},
+			expected: IconLocked,
+		},
+		{
+			name: "resurfaced takes precedence over hot topic",
+			input: IconOptions{
+				CommentCount:      10,
+				HotTopicThreshold: 5,
+				IsPR:              true,
+				Resurfaced:        true,
+			},
+			expected: IconResurfaced,
+		},
+		{
+			name: "locked takes precedence over resurfaced",
+			input: IconOptions{
+				Inaccessible: true,
+				Resurfaced:   true,
+			},
+			expected: IconLocked,
+		},
+		{
+			name: "stale over due reminder and hot topic",
+			input: IconOptions{
+				CommentCount:      10,
+				HotTopicThreshold: 5,
+				ReminderDue:       true,
+				Stale:             true,
+			},
+			expected: IconStale,
+		},
+		{
+			name: "locked takes precedence over stale",
+			input: IconOptions{
+				Inaccessible: true,
+				Stale:        true,
+			},
+			expected: IconLocked,
+		},
+		{
+			name: "due reminder over resurfaced and hot topic",
+			input: IconOptions{
+				CommentCount:      10,
+				HotTopicThreshold: 5,
+				IsPR:              true,
+				Resurfaced:        true,
+				ReminderDue:       true,
+			},
+			expected: IconReminder,
+		},
 		{
 			name: "below threshold shows no icon",
 			input: IconOptions{
@@ -111,6 +168,25 @@ func TestIcon(t *testing.T) {
 	}
 }
 
+func TestReminderTitle(t *testing.T) {
+	if got := ReminderTitle("Fix login", "ping after release"); got != "[ping after release] Fix login" {
+		t.Errorf("ReminderTitle() = %q", got)
+	}
+	if got := ReminderTitle("Fix login", ""); got != "Fix login" {
+		t.Errorf("ReminderTitle() without a note = %q, want the title", got)
+	}
+}
+
+func TestChainStatus(t *testing.T) {
+	status, width := ChainStatus("* REVIEW", 8)
+	if status != "⛓\uFE0F * REVIEW" || width != 11 {
+		t.Errorf("ChainStatus() = %q, %d, want %q, 11", status, width, "⛓\uFE0F * REVIEW")
+	}
+	if width != DisplayWidth(status) {
+		t.Errorf("ChainStatus() width = %d, want DisplayWidth %d", width, DisplayWidth(status))
+	}
+}
+
 func TestIconConstants(t *testing.T) {
 	// Verify icon constants are set correctly
 	if HotTopicIcon != "🔥" {
@@ -121,6 +197,14 @@ func TestIconConstants(t *testing.T) {
 		t.Errorf("QuickWinIcon = %q, want ⚡️", QuickWinIcon)
 	}
 
+	if LockedIcon != "🔒" {
+		t.Errorf("LockedIcon = %q, want 🔒", LockedIcon)
+	}
+
+	if w := DisplayWidth(ReminderIcon); w != 2 {
+		t.Errorf("DisplayWidth(ReminderIcon) = %d, want 2", w)
+	}
+
 	if IconWidth != 3 {
 		t.Errorf("IconWidth = %d, want 3", IconWidth)
 	}
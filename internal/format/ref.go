@@ -0,0 +1,22 @@
+package format
+
+import "fmt"
+
+// ItemRef returns the short "owner/repo#123" form of an item in repo, or
+// the repository alone for items without a number.
+func ItemRef(repo string, number int) string {
+	ref := Sanitize(repo)
+	if number > 0 {
+		ref += fmt.Sprintf("#%d", number)
+	}
+	return ref
+}
+
+// ItemURL returns the web URL of an item, falling back to its
+// repository's when the item has none.
+func ItemURL(itemURL, repoURL string) string {
+	if itemURL != "" {
+		return Sanitize(itemURL)
+	}
+	return Sanitize(repoURL)
+}
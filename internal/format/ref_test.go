@@ -0,0 +1,43 @@
+package format
+
+import "testing"
+
+func TestItemRef(t *testing.T) {
+	tests := []struct {
+		name   string
+		repo   string
+		number int
+		want   string
+	}{
+		{"numbered", "acme/api", 12, "acme/api#12"},
+		{"no number", "acme/api", 0, "acme/api"},
+		{"control characters", "acme/\x1b[31mapi", 3, "acme/api#3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ItemRef(tt.repo, tt.number); got != tt.want {
+				t.Errorf("ItemRef(%q, %d) = %q, want %q", tt.repo, tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		itemURL string
+		repoURL string
+		want    string
+	}{
+		{"item", "https://github.com/acme/api/pull/1", "https://github.com/acme/api", "https://github.com/acme/api/pull/1"},
+		{"repository fallback", "", "https://github.com/acme/api", "https://github.com/acme/api"},
+		{"neither", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ItemURL(tt.itemURL, tt.repoURL); got != tt.want {
+				t.Errorf("ItemURL(%q, %q) = %q, want %q", tt.itemURL, tt.repoURL, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package format
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Sanitize makes text from GitHub, such as titles, labels, and logins,
+// safe to print to a terminal. It removes escape sequences, control
+// characters, and bidirectional overrides, which could otherwise recolor
+// or clear the screen, move the cursor to spoof other rows, or reorder the
+// text. Whitespace controls such as newlines and tabs become spaces so an
+// item stays on one row, and invalid UTF-8 becomes U+FFFD.
+func Sanitize(s string) string {
+	if isClean(s) {
+		return s
+	}
+	s = strings.ToValidUTF8(s, "�")
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '\x1b':
+			i += escapeLength(s[i:])
+			continue
+		case r == '\t' || r == '\n' || r == '\r' || r == '\v' || r == '\f':
+			b.WriteByte(' ')
+		case IsTerminalControl(r):
+			// dropped
+		default:
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// IsTerminalControl reports whether r is a control character or
+// bidirectional formatting character that Sanitize removes.
+func IsTerminalControl(r rune) bool {
+	switch {
+	case r < 0x20, r == 0x7f: // C0 controls and DEL
+		return true
+	case r >= 0x80 && r <= 0x9f: // C1 controls, e.g. 8-bit CSI
+		return true
+	case r >= 0x202a && r <= 0x202e: // bidi embeddings and overrides
+		return true
+	case r >= 0x2066 && r <= 0x2069: // bidi isolates
+		return true
+	}
+	return false
+}
+
+// isClean reports whether s needs no sanitizing, which is the common case.
+func isClean(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if IsTerminalControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeLength returns the length of the escape sequence at the start of
+// s, which begins with ESC: a CSI sequence ("\x1b[31m"), a string sequence
+// such as OSC ("\x1b]8;;url\x1b\\"), or a two-character escape. An
+// unterminated sequence runs to the end of s.
+func escapeLength(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		// Parameters and intermediates, then a final byte in 0x40-0x7e
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']', 'P', 'X', '^', '_':
+		// Ends at BEL or ST (ESC \)
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default:
+		_, size := utf8.DecodeRuneInString(s[1:])
+		return 1 + size
+	}
+}
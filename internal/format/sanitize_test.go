@@ -0,0 +1,53 @@
+package format
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain", "Fix the build", "Fix the build"},
+		{"wide characters kept", "修复 🔥 café", "修复 🔥 café"},
+		{"color codes", "\x1b[31mred\x1b[0m title", "red title"},
+		{"clear screen", "\x1b[2J\x1b[Hspoofed", "spoofed"},
+		{"hyperlink", "\x1b]8;;https://evil.example\x1b\\click\x1b]8;;\x1b\\", "click"},
+		{"osc with bel", "\x1b]0;title\afix", "fix"},
+		{"unterminated csi", "fix\x1b[31", "fix"},
+		{"two character escape", "a\x1bcb", "ab"},
+		{"newlines become spaces", "line one\nline two\r\n", "line one line two  "},
+		{"tabs become spaces", "a\tb", "a b"},
+		{"c0 controls", "bell\a back\b", "bell back"},
+		{"c1 csi", "a\u009b31mb", "a31mb"},
+		{"bidi override", "txt.\u202eexe.pdf", "txt.exe.pdf"},
+		{"bidi isolate", "\u2067abc\u2069", "abc"},
+		{"invalid utf-8", "a\xffb", "a�b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.input); got != tt.expected {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func FuzzSanitize(f *testing.F) {
+	for _, s := range wideFixtures {
+		f.Add(s)
+	}
+	f.Add("\x1b[2J\x1b]8;;x\a\u009b\u202e\n\x1b")
+	f.Fuzz(func(t *testing.T, s string) {
+		got := Sanitize(s)
+		for _, r := range got {
+			if IsTerminalControl(r) {
+				t.Fatalf("Sanitize(%q) = %q, still contains %U", s, got, r)
+			}
+		}
+		if again := Sanitize(got); again != got {
+			t.Errorf("Sanitize is not idempotent: %q then %q", got, again)
+		}
+	})
+}
@@ -2,6 +2,7 @@
 package format
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"unicode/utf8"
@@ -44,7 +45,8 @@ func DisplayWidth(s string) int {
 
 // TruncateToWidth truncates a string to fit within maxWidth display columns.
 // It handles ANSI escape sequences by preserving them in the output.
-// Returns the truncated string and its visible width.
+// Returns the truncated string and its visible width, which can be less
+// than maxWidth when a wide character doesn't fit.
 // If truncation occurs, "..." is appended. An ANSI reset code is only added
 // if the input contained ANSI sequences.
 func TruncateToWidth(s string, maxWidth int) (string, int) {
@@ -55,11 +57,16 @@ func TruncateToWidth(s string, maxWidth int) (string, int) {
 		return s, width
 	}
 
+	// Too narrow for anything but (part of) the ellipsis
+	if maxWidth < 3 {
+		if maxWidth < 0 {
+			maxWidth = 0
+		}
+		return strings.Repeat(".", maxWidth), maxWidth
+	}
+
 	// Need to truncate - leave room for "..."
 	targetWidth := maxWidth - 3
-	if targetWidth < 0 {
-		targetWidth = 0
-	}
 
 	// Find all ANSI sequences and their positions in the original string
 	matches := ansiRegex.FindAllStringIndex(s, -1)
@@ -123,7 +130,8 @@ func TruncateToWidth(s string, maxWidth int) (string, int) {
 		result.WriteString("\033[0m")
 	}
 
-	return result.String(), maxWidth
+	// A wide character that didn't fit can leave the result a column short
+	return result.String(), visibleWidth + 3
 }
 
 // PadRight pads a string with spaces to reach the target visible width.
@@ -133,3 +141,117 @@ func PadRight(s string, visibleWidth, targetWidth int) string {
 	}
 	return s + strings.Repeat(" ", targetWidth-visibleWidth)
 }
+
+// Truncation selects which part of a string is dropped when it is too wide.
+type Truncation int
+
+const (
+	// TruncateEnd keeps the start of the string, e.g. "spiffcs/very-lon...".
+	TruncateEnd Truncation = iota
+	// TruncateMiddle keeps both ends, e.g. "spiffcs/...-repository-name",
+	// which suits repositories whose name is the useful part.
+	TruncateMiddle
+)
+
+// String returns the config name of the truncation mode.
+func (t Truncation) String() string {
+	if t == TruncateMiddle {
+		return "middle"
+	}
+	return "end"
+}
+
+// ParseTruncation parses a truncation mode from config: "end" or "middle".
+func ParseTruncation(s string) (Truncation, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "end":
+		return TruncateEnd, nil
+	case "middle":
+		return TruncateMiddle, nil
+	default:
+		return TruncateEnd, fmt.Errorf("invalid truncation %q: expected end or middle", s)
+	}
+}
+
+// Truncate truncates a string to fit within maxWidth display columns using
+// the given mode. Returns the truncated string and its visible width.
+func Truncate(s string, maxWidth int, mode Truncation) (string, int) {
+	if mode == TruncateMiddle {
+		return TruncateMiddleToWidth(s, maxWidth)
+	}
+	return TruncateToWidth(s, maxWidth)
+}
+
+// TruncateMiddleToWidth truncates a string to fit within maxWidth display columns
+// by replacing its middle with "...", keeping the start and the end. The
+// end gets the extra column when the space is uneven. ANSI sequences are
+// removed from strings that need truncating. Returns the truncated string
+// and its visible width.
+func TruncateMiddleToWidth(s string, maxWidth int) (string, int) {
+	width := DisplayWidth(s)
+	if width <= maxWidth {
+		return s, width
+	}
+	// Too narrow to show anything on both sides of the ellipsis
+	if maxWidth < 5 {
+		return TruncateToWidth(s, maxWidth)
+	}
+
+	cells := splitCells(StripAnsi(s))
+	available := maxWidth - 3
+	tailWidth := (available + 1) / 2
+	headWidth := available - tailWidth
+
+	var head strings.Builder
+	used := 0
+	for _, c := range cells {
+		if used+c.width > headWidth {
+			break
+		}
+		head.WriteString(c.text)
+		used += c.width
+	}
+
+	// Collect the tail backwards, then give any space the head could not
+	// use (next to a wide character) to the tail
+	tailWidth = available - used
+	start := len(cells)
+	tailUsed := 0
+	for start > 0 && tailUsed+cells[start-1].width <= tailWidth {
+		start--
+		tailUsed += cells[start].width
+	}
+	var tail strings.Builder
+	for _, c := range cells[start:] {
+		tail.WriteString(c.text)
+	}
+
+	return head.String() + "..." + tail.String(), used + 3 + tailUsed
+}
+
+// cell is one displayed character: a rune, or an emoji with its
+// variation selector.
+type cell struct {
+	text  string
+	width int
+}
+
+// splitCells splits plain text into displayed characters, measuring them
+// the same way as DisplayWidth.
+func splitCells(s string) []cell {
+	runes := []rune(s)
+	cells := make([]cell, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if i+1 < len(runes) && runes[i+1] == '\uFE0F' {
+			cells = append(cells, cell{text: string(runes[i : i+2]), width: 2})
+			i++
+			continue
+		}
+		if r == '\uFE0F' {
+			continue
+		}
+		cells = append(cells, cell{text: string(r), width: runewidth.RuneWidth(r)})
+	}
+	return cells
+}
@@ -0,0 +1,85 @@
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+// wideFixtures mixes CJK text, emoji (with and without variation
+// selectors), combining marks, and ANSI colors, which all take a different
+// number of columns than bytes or runes.
+var wideFixtures = []string{
+	"",
+	"hello world",
+	"修复登录页面的布局问题",
+	"日本語のタイトル: fix build",
+	"🔥 hot fix for 🐛",
+	"⚠️ breaking change ❤️",
+	"café naïve résumé",
+	"é́ combining",
+	"한국어 제목과 English",
+	"\x1b[31m红色\x1b[0m text",
+	"org/非常に長いリポジトリ名-with-suffix",
+}
+
+func FuzzTruncateToWidth(f *testing.F) {
+	for _, s := range wideFixtures {
+		for _, w := range []int{0, 2, 3, 5, 10, 40} {
+			f.Add(s, w)
+		}
+	}
+	f.Fuzz(func(t *testing.T, s string, maxWidth int) {
+		checkTruncation(t, "TruncateToWidth", s, maxWidth, TruncateToWidth)
+	})
+}
+
+func FuzzTruncateMiddleToWidth(f *testing.F) {
+	for _, s := range wideFixtures {
+		for _, w := range []int{0, 4, 5, 8, 11, 26} {
+			f.Add(s, w)
+		}
+	}
+	f.Fuzz(func(t *testing.T, s string, maxWidth int) {
+		checkTruncation(t, "TruncateMiddleToWidth", s, maxWidth, TruncateMiddleToWidth)
+	})
+}
+
+func FuzzTruncateUsername(f *testing.F) {
+	for _, s := range wideFixtures {
+		for _, w := range []int{0, 1, 2, 12} {
+			f.Add(s, w)
+		}
+	}
+	f.Fuzz(func(t *testing.T, s string, maxWidth int) {
+		if maxWidth > 200 || strings.Contains(StripAnsi(s), "\x1b") {
+			t.Skip()
+		}
+		got := TruncateUsername(s, maxWidth)
+		if w := DisplayWidth(got); w > max(maxWidth, 0) && got != s {
+			t.Errorf("TruncateUsername(%q, %d) = %q, %d columns wide", s, maxWidth, got, w)
+		}
+	})
+}
+
+// checkTruncation checks that a truncated string fits, that the reported
+// width is its real width, and that strings which fit are left alone.
+func checkTruncation(t *testing.T, name, s string, maxWidth int, truncate func(string, int) (string, int)) {
+	t.Helper()
+	// Stray escape bytes outside complete ANSI sequences are not text
+	if maxWidth > 200 || strings.Contains(StripAnsi(s), "\x1b") {
+		t.Skip()
+	}
+	got, width := truncate(s, maxWidth)
+	if real := DisplayWidth(got); real != width {
+		t.Errorf("%s(%q, %d) = %q, reported width %d, real width %d", name, s, maxWidth, got, width, real)
+	}
+	if DisplayWidth(s) <= maxWidth {
+		if got != s {
+			t.Errorf("%s(%q, %d) = %q, want the string unchanged", name, s, maxWidth, got)
+		}
+		return
+	}
+	if width > max(maxWidth, 0) {
+		t.Errorf("%s(%q, %d) = %q, %d columns wide", name, s, maxWidth, got, width)
+	}
+}
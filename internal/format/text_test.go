@@ -106,3 +106,57 @@ func TestPadRight(t *testing.T) {
 		})
 	}
 }
+
+func TestTruncateMiddleToWidth(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		maxWidth      int
+		expectedStr   string
+		expectedWidth int
+	}{
+		{"no truncation needed", "spiffcs/triage", 20, "spiffcs/triage", 14},
+		{"keeps both ends", "org/very-long-repository-name", 20, "org/very...tory-name", 20},
+		{"tail gets odd column", "abcdefghij", 8, "ab...hij", 8},
+		{"wide characters", "日本語のリポジトリ名", 11, "日本...リ名", 11},
+		{"strips ansi", "\x1b[31mabcdefghij\x1b[0m", 8, "ab...hij", 8},
+		{"too narrow falls back to end", "abcdefghij", 4, "a...", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStr, gotWidth := TruncateMiddleToWidth(tt.input, tt.maxWidth)
+			if gotStr != tt.expectedStr {
+				t.Errorf("TruncateMiddleToWidth(%q, %d) string = %q, want %q", tt.input, tt.maxWidth, gotStr, tt.expectedStr)
+			}
+			if gotWidth != tt.expectedWidth {
+				t.Errorf("TruncateMiddleToWidth(%q, %d) width = %d, want %d", tt.input, tt.maxWidth, gotWidth, tt.expectedWidth)
+			}
+		})
+	}
+}
+
+func TestParseTruncation(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Truncation
+		wantErr bool
+	}{
+		{"end", TruncateEnd, false},
+		{"middle", TruncateMiddle, false},
+		{" Middle ", TruncateMiddle, false},
+		{"start", TruncateEnd, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTruncation(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTruncation(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTruncation(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
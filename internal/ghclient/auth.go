@@ -0,0 +1,50 @@
+package ghclient
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+// ErrUnauthorized is returned once GitHub has rejected the token (HTTP 401),
+// typically because it was revoked or expired partway through a run. After
+// the first rejection every further request of the same Client fails fast
+// with this error instead of reaching GitHub.
+var ErrUnauthorized = errors.New("GitHub token rejected")
+
+// authState records whether GitHub has rejected a client's token.
+type authState struct {
+	rejected atomic.Bool
+}
+
+// isRejected reports whether a 401 response was recorded.
+func (s *authState) isRejected() bool {
+	return s != nil && s.rejected.Load()
+}
+
+// reject records a 401 response.
+func (s *authState) reject() {
+	if s != nil {
+		s.rejected.Store(true)
+	}
+}
+
+// Unauthorized reports whether GitHub has rejected the client's token. The
+// rejection is kept per Client, so a client built later, such as for the
+// next refresh with a renewed token, tries again.
+func (c *Client) Unauthorized() bool {
+	return c.auth.isRejected()
+}
+
+// IsAuthError reports whether err was caused by GitHub rejecting the token,
+// either directly (a 401 response) or because an earlier request was
+// rejected.
+func IsAuthError(err error) bool {
+	if errors.Is(err, ErrUnauthorized) {
+		return true
+	}
+	var ghErr *gh.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusUnauthorized
+}
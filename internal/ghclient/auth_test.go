@@ -0,0 +1,88 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestIsAuthError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"sentinel", ErrUnauthorized, true},
+		{"wrapped sentinel", fmt.Errorf("notifications: %w", ErrUnauthorized), true},
+		{"401 response", &gh.ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnauthorized}}, true},
+		{"404 response", &gh.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}, false},
+		{"rate limited", ErrRateLimited, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAuthError(tt.err); got != tt.want {
+				t.Errorf("IsAuthError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokedTokenStopsRequests(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+	}))
+	defer srv.Close()
+
+	c := &Client{graphqlHTTP: srv.Client()}
+	rt := &rateLimitTransport{base: http.DefaultTransport, auth: &c.auth}
+	client := &http.Client{Transport: rt}
+
+	// The first 401 is passed through so callers see GitHub's message
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("first request error = %v, want the 401 response", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", resp.StatusCode)
+	}
+	if !c.Unauthorized() {
+		t.Fatal("expected the 401 to be recorded")
+	}
+
+	// Later requests fail fast without reaching GitHub
+	if _, err := client.Get(srv.URL); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("second request error = %v, want ErrUnauthorized", err)
+	}
+	if _, _, err := c.executeGraphQL(context.Background(), graphqlQuery{Query: "{}"}, "token"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GraphQL error = %v, want ErrUnauthorized", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+
+	// A client built later, e.g. with a renewed token, tries again
+	fresh := &Client{}
+	if fresh.Unauthorized() {
+		t.Error("a new client should not inherit the rejection")
+	}
+	freshHTTP := &http.Client{Transport: &rateLimitTransport{base: http.DefaultTransport, auth: &fresh.auth}}
+	if resp, err := freshHTTP.Get(srv.URL); err == nil {
+		_ = resp.Body.Close()
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server saw %d requests, want the new client's to reach it", got)
+	}
+}
@@ -0,0 +1,115 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dependencyPattern matches "depends on" and "blocked by" followed by an
+// issue reference: "#123", "owner/repo#123", or a github.com issue or pull
+// request URL.
+var dependencyPattern = regexp.MustCompile(`(?i)\b(?:depends\s+on|blocked\s+by)\s*:?\s+` +
+	`(?:https://github\.com/([\w.-]+/[\w.-]+)/(?:issues|pull)/(\d+)|([\w.-]+/[\w.-]+)?#(\d+))`)
+
+// dependencyRefs returns the issues and PRs a description says it depends
+// on, as "owner/repo#123", in order of first mention. Bare "#123"
+// references are taken to be in repo.
+func dependencyRefs(body, repo string) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	for _, m := range dependencyPattern.FindAllStringSubmatch(body, -1) {
+		ref := repo
+		number := m[4]
+		switch {
+		case m[1] != "":
+			ref, number = m[1], m[2]
+		case m[3] != "":
+			ref = m[3]
+		}
+		ref += "#" + number
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// splitRef splits "owner/repo#123" into its parts.
+func splitRef(ref string) (owner, repo string, number int, ok bool) {
+	fullName, num, found := strings.Cut(ref, "#")
+	if !found {
+		return "", "", 0, false
+	}
+	owner, repo, found = strings.Cut(fullName, "/")
+	if !found || owner == "" || repo == "" {
+		return "", "", 0, false
+	}
+	number, err := strconv.Atoi(num)
+	if err != nil {
+		return "", "", 0, false
+	}
+	return owner, repo, number, true
+}
+
+// IssueStates looks up whether the issues and PRs in refs ("owner/repo#123")
+// are open, closed, or merged. References that can't be read or don't exist
+// are left out of the result.
+func (c *Client) IssueStates(ctx context.Context, refs []string, token string) (map[string]string, error) {
+	states := make(map[string]string, len(refs))
+	var items []BatchItem
+	var itemRefs []string
+	for _, ref := range refs {
+		owner, repo, number, ok := splitRef(ref)
+		if !ok {
+			continue
+		}
+		items = append(items, BatchItem{Owner: owner, Repo: repo, Number: number})
+		itemRefs = append(itemRefs, ref)
+	}
+
+	for start := 0; start < len(items); start += graphqlBatchSize {
+		end := min(start+graphqlBatchSize, len(items))
+		batch := items[start:end]
+		for i := range batch {
+			batch[i].Alias = fmt.Sprintf("ref%d", i)
+		}
+
+		query := c.queries.BuildStateBatchQuery(batch)
+		// Unreadable references come back as null with an error; the
+		// rest of the batch is still usable.
+		respData, _, err := c.executeGraphQL(ctx, query, token)
+		if err != nil {
+			return states, err
+		}
+		if err := parseStateResponse(respData, itemRefs[start:end], states); err != nil {
+			return states, err
+		}
+	}
+	return states, nil
+}
+
+// parseStateResponse records the state of each reference in refs, aliased
+// in order as ref0, ref1, and so on.
+func parseStateResponse(data json.RawMessage, refs []string, states map[string]string) error {
+	var rawData map[string]*struct {
+		IssueOrPullRequest *struct {
+			State string `json:"state"`
+		} `json:"issueOrPullRequest"`
+	}
+	if err := json.Unmarshal(data, &rawData); err != nil {
+		return fmt.Errorf("failed to parse state response data: %w", err)
+	}
+	for i, ref := range refs {
+		repo := rawData[fmt.Sprintf("ref%d", i)]
+		if repo == nil || repo.IssueOrPullRequest == nil || repo.IssueOrPullRequest.State == "" {
+			continue
+		}
+		states[ref] = strings.ToLower(repo.IssueOrPullRequest.State)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDependencyRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"none", "Fixes #3", nil},
+		{"same repo", "Depends on #12", []string{"acme/api#12"}},
+		{"blocked by", "This is blocked by #7.", []string{"acme/api#7"}},
+		{"colon", "Depends on: #12", []string{"acme/api#12"}},
+		{"other repo", "depends on acme/web#40", []string{"acme/web#40"}},
+		{"url", "Depends on https://github.com/acme/web/pull/41", []string{"acme/web#41"}},
+		{"several and duplicates", "Depends on #1\nDepends on #2\nblocked by #1", []string{"acme/api#1", "acme/api#2"}},
+		{"no keyword", "Related to #5, see #6", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependencyRefs(tt.body, "acme/api"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dependencyRefs(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitRef(t *testing.T) {
+	owner, repo, number, ok := splitRef("acme/api#12")
+	if !ok || owner != "acme" || repo != "api" || number != 12 {
+		t.Errorf("splitRef() = %q, %q, %d, %v", owner, repo, number, ok)
+	}
+	for _, bad := range []string{"acme/api", "acme#12", "/api#12", "acme/api#x"} {
+		if _, _, _, ok := splitRef(bad); ok {
+			t.Errorf("splitRef(%q) ok, want rejected", bad)
+		}
+	}
+}
+
+func TestParseStateResponse(t *testing.T) {
+	data := json.RawMessage(`{
+		"ref0": {"issueOrPullRequest": {"state": "OPEN"}},
+		"ref1": {"issueOrPullRequest": {"state": "MERGED"}},
+		"ref2": null,
+		"ref3": {"issueOrPullRequest": null}
+	}`)
+	states := make(map[string]string)
+	if err := parseStateResponse(data, []string{"a/b#1", "a/b#2", "a/b#3", "a/b#4"}, states); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"a/b#1": "open", "a/b#2": "merged"}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("states = %v, want %v", states, want)
+	}
+}
+
+func TestParseBlockedBy(t *testing.T) {
+	issues, err := parseIssueResponse(json.RawMessage(`{
+		"issue0": {"issue": {
+			"number": 1,
+			"blockedBy": {"nodes": [
+				{"number": 5, "state": "OPEN", "repository": {"nameWithOwner": "acme/web"}},
+				{"number": 6, "state": "CLOSED", "repository": {"nameWithOwner": "acme/web"}}
+			]}
+		}}
+	}`), []enrichmentItem{{index: 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := issues[0].BlockedBy, []string{"acme/web#5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("issue BlockedBy = %v, want %v", got, want)
+	}
+
+	prs, err := parsePRResponse(json.RawMessage(`{
+		"pr0": {"pullRequest": {"number": 2, "body": "Depends on #1"}}
+	}`), []enrichmentItem{{index: 0, owner: "acme", repo: "web", isPR: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := prs[0].BlockedBy, []string{"acme/web#1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PR BlockedBy = %v, want %v", got, want)
+	}
+}
+
+func TestParseStackedPR(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   string
+		want []string
+	}{
+		{
+			name: "stacked on an open PR",
+			pr:   `{"number": 4, "baseRefName": "feature-a", "baseRef": {"associatedPullRequests": {"nodes": [{"number": 3}]}}, "repository": {"defaultBranchRef": {"name": "main"}}}`,
+			want: []string{"acme/web#3"},
+		},
+		{
+			name: "also named in the description",
+			pr:   `{"number": 4, "body": "Depends on #3", "baseRefName": "feature-a", "baseRef": {"associatedPullRequests": {"nodes": [{"number": 3}]}}, "repository": {"defaultBranchRef": {"name": "main"}}}`,
+			want: []string{"acme/web#3"},
+		},
+		{
+			name: "onto the default branch",
+			pr:   `{"number": 4, "baseRefName": "main", "baseRef": {"associatedPullRequests": {"nodes": [{"number": 9}]}}, "repository": {"defaultBranchRef": {"name": "main"}}}`,
+		},
+		{
+			name: "base branch without a PR",
+			pr:   `{"number": 4, "baseRefName": "release/1.2", "baseRef": {"associatedPullRequests": {"nodes": []}}, "repository": {"defaultBranchRef": {"name": "main"}}}`,
+		},
+		{
+			name: "base branch deleted",
+			pr:   `{"number": 4, "baseRefName": "feature-a", "baseRef": null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prs, err := parsePRResponse(json.RawMessage(`{"pr0": {"pullRequest": `+tt.pr+`}}`),
+				[]enrichmentItem{{index: 0, owner: "acme", repo: "web", isPR: true}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := prs[0].BlockedBy; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BlockedBy = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spiffcs/triage/internal/log"
+)
+
+const (
+	// lowBudgetBatchSize is the batch size used when the GraphQL quota is
+	// nearly spent. It keeps each PR query within 100 connections, which
+	// GitHub charges a single point, so the points left enrich as many
+	// items as they can.
+	lowBudgetBatchSize = 100 / (prConnectionsPerItem + projectConnectionsPerItem)
+	// lowBudgetConcurrency is how many batches are in flight at once when
+	// the quota is nearly spent, so each response's remaining count is
+	// seen before much more is spent.
+	lowBudgetConcurrency = 2
+)
+
+// errBudgetExhausted fails the batches not sent because the GraphQL points
+// left would not cover them.
+var errBudgetExhausted = fmt.Errorf("%w: GraphQL points left would not cover the batch", ErrRateLimited)
+
+// rateLimitData is the rateLimit field selected alongside each batch
+// query, reporting what the query cost and what is left.
+type rateLimitData struct {
+	Cost      int       `json:"cost"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+// recordRateLimit updates the GraphQL quota from the rateLimit field of a
+// response's data, when the query selected it.
+func (c *Client) recordRateLimit(data json.RawMessage) {
+	var resp struct {
+		RateLimit *rateLimitData `json:"rateLimit"`
+	}
+	if len(data) == 0 || json.Unmarshal(data, &resp) != nil || resp.RateLimit == nil {
+		return
+	}
+	rl := resp.RateLimit
+	log.Debug("GraphQL query cost", "cost", rl.Cost, "remaining", rl.Remaining, "limit", rl.Limit)
+	if rl.Limit > 0 {
+		c.graphqlLimit.Update(rl.Remaining, rl.Limit, rl.ResetAt)
+	}
+}
+
+// graphQLRemaining returns the GraphQL points left, or -1 when no response
+// has reported them since the quota last reset.
+func (c *Client) graphQLRemaining() int {
+	remaining, limit, resetAt, _ := c.graphqlLimit.Status()
+	if limit <= 0 || (!resetAt.IsZero() && time.Now().After(resetAt)) {
+		return -1
+	}
+	return remaining
+}
+
+// graphQLBudget asks GitHub how many GraphQL points are left, returning
+// -1 when it can't tell. It asks once per client: a server that doesn't
+// report its quota, such as GitHub Enterprise Server with rate limiting
+// off, isn't asked before every round of batches.
+func (c *Client) graphQLBudget(ctx context.Context, token string) int {
+	if c.budgetChecked.Swap(true) {
+		return -1
+	}
+	if _, _, err := c.executeGraphQL(ctx, c.queries.BuildRateLimitQuery(), token); err != nil && !errors.Is(err, ErrRateLimited) {
+		log.Debug("failed to check the GraphQL budget", "error", err)
+	}
+	return c.graphQLRemaining()
+}
+
+// enrichPlan is how EnrichItemsGraphQL batches its queries.
+type enrichPlan struct {
+	batchSize   int
+	concurrency int
+}
+
+// planEnrichment picks the batch size and concurrency for enriching prs
+// pull requests and issues issues with remaining points left. Unless the
+// points cover the whole run with RateLimitLowWatermark to spare, batches
+// shrink to the most items per point and fewer are sent at once.
+// remaining is negative when unknown.
+func planEnrichment(prs, issues, remaining int) enrichPlan {
+	if remaining < 0 || remaining >= EnrichmentCost(prs, issues).Points+RateLimitLowWatermark {
+		return enrichPlan{batchSize: graphqlBatchSize, concurrency: maxConcurrentBatches}
+	}
+	return enrichPlan{batchSize: lowBudgetBatchSize, concurrency: lowBudgetConcurrency}
+}
+
+// batchCost estimates the GraphQL points a batch uses.
+func batchCost(batch []enrichmentItem) int {
+	var prs, issues int
+	for _, item := range batch {
+		if item.isPR {
+			prs++
+		} else {
+			issues++
+		}
+	}
+	return EnrichmentCost(prs, issues).Points
+}
+
+// pointBudget is the GraphQL points an enrichment may still spend. Each
+// batch takes its estimated cost before it is sent, so concurrent batches
+// can't together overdraw the quota.
+type pointBudget struct {
+	mu        sync.Mutex
+	remaining int // negative when unknown
+}
+
+// take reserves points, reporting false when fewer than that are left.
+// observed is the remaining count most recently reported by GitHub, which
+// lowers the budget when queries cost more than estimated.
+func (b *pointBudget) take(points, observed int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if observed >= 0 && (b.remaining < 0 || observed < b.remaining) {
+		b.remaining = observed
+	}
+	if b.remaining < 0 {
+		return true
+	}
+	if points > b.remaining {
+		return false
+	}
+	b.remaining -= points
+	return true
+}
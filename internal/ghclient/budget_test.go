@@ -0,0 +1,140 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestPlanEnrichment(t *testing.T) {
+	defaults := enrichPlan{batchSize: graphqlBatchSize, concurrency: maxConcurrentBatches}
+	low := enrichPlan{batchSize: lowBudgetBatchSize, concurrency: lowBudgetConcurrency}
+	tests := []struct {
+		name      string
+		remaining int
+		want      enrichPlan
+	}{
+		{"unknown", -1, defaults},
+		{"plenty", 5000, defaults},
+		{"just enough to spare", EnrichmentCost(100, 100).Points + RateLimitLowWatermark, defaults},
+		{"close to exhaustion", 50, low},
+		{"exhausted", 0, low},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := planEnrichment(100, 100, tt.remaining); got != tt.want {
+				t.Errorf("planEnrichment(100, 100, %d) = %+v, want %+v", tt.remaining, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPointBudget(t *testing.T) {
+	unknown := &pointBudget{remaining: -1}
+	if !unknown.take(100, -1) {
+		t.Error("take() with an unknown quota = false, want every batch sent")
+	}
+
+	b := &pointBudget{remaining: 3}
+	if !b.take(2, -1) {
+		t.Fatal("take(2) of 3 = false, want true")
+	}
+	if b.take(2, -1) {
+		t.Error("take(2) of 1 = true, want false")
+	}
+	// GitHub reporting less than expected lowers the budget
+	b = &pointBudget{remaining: 3}
+	if b.take(1, 0) {
+		t.Error("take(1) after GitHub reported 0 left = true, want false")
+	}
+}
+
+func TestRecordRateLimit(t *testing.T) {
+	c := &Client{}
+	if got := c.graphQLRemaining(); got != -1 {
+		t.Errorf("graphQLRemaining() before any response = %d, want -1", got)
+	}
+	c.recordRateLimit(json.RawMessage(`{"pr0": null}`))
+	if got := c.graphQLRemaining(); got != -1 {
+		t.Errorf("graphQLRemaining() after a response without rateLimit = %d, want -1", got)
+	}
+
+	resetAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	c.recordRateLimit(json.RawMessage(`{"rateLimit": {"cost": 3, "limit": 5000, "remaining": 4210, "resetAt": "` + resetAt + `"}}`))
+	if got := c.graphQLRemaining(); got != 4210 {
+		t.Errorf("graphQLRemaining() = %d, want 4210", got)
+	}
+
+	// Once the quota resets, the old count no longer applies
+	c.recordRateLimit(json.RawMessage(`{"rateLimit": {"cost": 1, "limit": 5000, "remaining": 12, "resetAt": "2026-01-01T00:00:00Z"}}`))
+	if got := c.graphQLRemaining(); got != -1 {
+		t.Errorf("graphQLRemaining() after the reset time = %d, want -1", got)
+	}
+}
+
+func TestEnrichWithinGraphQLBudget(t *testing.T) {
+	// Two points are left, and each batch costs one
+	resetAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	var remaining, budgetChecks, batches atomic.Int32
+	remaining.Store(2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		cost := 1
+		if strings.HasPrefix(req.Query, "query RateLimit") {
+			budgetChecks.Add(1)
+			cost = 0
+		} else {
+			batches.Add(1)
+		}
+		left := remaining.Add(int32(-cost))
+		_, _ = fmt.Fprintf(w, `{"data": {"rateLimit": {"cost": %d, "limit": 5000, "remaining": %d, "resetAt": %q}}}`, cost, left, resetAt)
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	c := &Client{
+		queries:     mustLoadQueries(t),
+		graphqlHTTP: &http.Client{Transport: redirectTransport{target: target}},
+	}
+	items := make([]model.Item, 3*lowBudgetBatchSize)
+	for i := range items {
+		items[i] = model.Item{
+			ID:         strconv.Itoa(i),
+			Repository: model.Repository{FullName: "org/repo"},
+			Subject:    model.Subject{Type: model.SubjectPullRequest, URL: "https://api.github.com/repos/org/repo/pulls/" + strconv.Itoa(i+1)},
+		}
+	}
+
+	report, err := c.EnrichItemsGraphQL(context.Background(), items, "token", nil)
+	if err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if budgetChecks.Load() != 1 {
+		t.Errorf("budget checks = %d, want 1", budgetChecks.Load())
+	}
+	if batches.Load() != 2 {
+		t.Errorf("batches sent = %d, want the 2 the budget covers", batches.Load())
+	}
+	// The last batch is reported, not silently dropped
+	if got := CountErrors(report.Errors)[ItemErrorRateLimited]; got != lowBudgetBatchSize {
+		t.Errorf("rate limited items = %d, want %d", got, lowBudgetBatchSize)
+	}
+	for _, e := range report.Errors {
+		if e.Kind == ItemErrorRateLimited && e.Index < 2*lowBudgetBatchSize {
+			t.Errorf("item %d was left out; want the last batch, the lowest-priority items, left out", e.Index)
+		}
+	}
+}
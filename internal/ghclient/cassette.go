@@ -0,0 +1,265 @@
+package ghclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// volatileParams are query parameters ignored when matching a request
+// against a cassette, because they change on every run (e.g. "since" is
+// derived from the current time).
+var volatileParams = []string{"since", "before"}
+
+// recordedHeaders are the response headers kept in a cassette. Everything
+// else is dropped so cassettes stay small and never capture cookies or
+// other per-session values. Request headers (including Authorization) are
+// never recorded.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Link",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+}
+
+// Cassette is a recorded sequence of GitHub API interactions used to replay
+// realistic responses in tests without network access or a token.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request/response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request. Bodies are compared verbatim, which
+// distinguishes GraphQL queries sent to the same endpoint.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response replayed for a matching request.
+type RecordedResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body"`
+}
+
+// LoadCassette reads a cassette from path.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating parent directories as needed.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating cassette directory: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Recorder is an http.RoundTripper that captures every request/response
+// passing through it into a Cassette.
+type Recorder struct {
+	base     http.RoundTripper
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder creates a Recorder that forwards requests to base
+// (http.DefaultTransport if nil).
+func NewRecorder(base http.RoundTripper) *Recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Recorder{base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string][]string)
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Values(h); len(v) > 0 {
+			headers[h] = v
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   reqBody,
+		},
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: headers,
+			Body:    respBody,
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Cassette returns a snapshot of the interactions recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes the recorded interactions to path.
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// errNoInteraction is returned for a request the cassette has no answer
+// for. Retrying such a request cannot help.
+var errNoInteraction = errors.New("cassette: no recorded interaction")
+
+// Replayer is an http.RoundTripper that serves responses from a Cassette
+// instead of the network. Each interaction is replayed at most once, in
+// recorded order among interactions matching the same request, so
+// paginated and repeated calls behave as they did when recorded.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer creates a Replayer serving c.
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{
+		cassette: c,
+		used:     make([]bool, len(c.Interactions)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper. Requests without a recorded
+// interaction fail rather than reaching the network.
+func (p *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	key := matchKey(req.Method, req.URL)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, in := range p.cassette.Interactions {
+		if p.used[i] || in.Request.Body != body {
+			continue
+		}
+		u, err := url.Parse(in.Request.URL)
+		if err != nil || matchKey(in.Request.Method, u) != key {
+			continue
+		}
+		p.used[i] = true
+		return in.Response.toHTTP(req), nil
+	}
+	return nil, fmt.Errorf("%w for %s", errNoInteraction, key)
+}
+
+// Unused returns the requests that were recorded but never replayed,
+// which usually means the code under test stopped making a call.
+func (p *Replayer) Unused() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var unused []string
+	for i, in := range p.cassette.Interactions {
+		if !p.used[i] {
+			unused = append(unused, in.Request.Method+" "+in.Request.URL)
+		}
+	}
+	return unused
+}
+
+func (r RecordedResponse) toHTTP(req *http.Request) *http.Response {
+	header := make(http.Header)
+	for k, v := range r.Headers {
+		header[http.CanonicalHeaderKey(k)] = v
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// matchKey normalizes a request for matching: the method, host, path, and
+// query with volatile parameters removed (url.Values.Encode sorts keys).
+func matchKey(method string, u *url.URL) string {
+	q := u.Query()
+	for _, p := range volatileParams {
+		q.Del(p)
+	}
+	key := method + " " + u.Host + u.Path
+	if encoded := q.Encode(); encoded != "" {
+		key += "?" + encoded
+	}
+	return key
+}
+
+// readBody drains *body and replaces it with an equivalent reader so the
+// request or response can still be consumed downstream.
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return "", fmt.Errorf("reading body: %w", err)
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
@@ -0,0 +1,115 @@
+package ghclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		body, _ := io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, `{"path":"`+r.URL.Path+`","body":"`+string(body)+`"}`)
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(nil)
+	client := &http.Client{Transport: recorder}
+
+	get, _ := http.NewRequest("GET", server.URL+"/notifications?per_page=100&since=2026-01-01T00:00:00Z", nil)
+	get.Header.Set("Authorization", "Bearer secret-token")
+	post, _ := http.NewRequest("POST", server.URL+"/graphql", strings.NewReader("query-a"))
+	for _, req := range []*http.Request{get, post} {
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("recording %s: %v", req.URL, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("LoadCassette() error = %v", err)
+	}
+	if len(cassette.Interactions) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(cassette.Interactions))
+	}
+	for _, in := range cassette.Interactions {
+		if _, ok := in.Response.Headers["Set-Cookie"]; ok {
+			t.Error("cassette should not record Set-Cookie")
+		}
+	}
+
+	// Replay with the server gone: nothing may touch the network.
+	server.Close()
+	replayer := NewReplayer(cassette)
+	client = &http.Client{Transport: replayer}
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		body     string
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "volatile params are ignored",
+			method:   "GET",
+			url:      server.URL + "/notifications?since=2026-02-02T00:00:00Z&per_page=100",
+			wantBody: `{"path":"/notifications","body":""}`,
+		},
+		{
+			name:    "body distinguishes posts",
+			method:  "POST",
+			url:     server.URL + "/graphql",
+			body:    "query-b",
+			wantErr: true,
+		},
+		{
+			name:     "matching post",
+			method:   "POST",
+			url:      server.URL + "/graphql",
+			body:     "query-a",
+			wantBody: `{"path":"/graphql","body":"query-a"}`,
+		},
+		{
+			name:    "interactions replay once",
+			method:  "POST",
+			url:     server.URL + "/graphql",
+			body:    "query-a",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			resp, err := client.Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+			got, _ := io.ReadAll(resp.Body)
+			if string(got) != tt.wantBody {
+				t.Errorf("body = %s, want %s", got, tt.wantBody)
+			}
+		})
+	}
+
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("Unused() = %v, want none", unused)
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/audit"
 	"github.com/spiffcs/triage/internal/log"
 	"github.com/spiffcs/triage/internal/model"
 	"golang.org/x/oauth2"
@@ -17,6 +19,10 @@ import (
 // rateLimitTransport wraps an http.RoundTripper to handle GitHub rate limits
 type rateLimitTransport struct {
 	base http.RoundTripper
+	// auth records whether GitHub rejected the client's token.
+	auth *authState
+	// sso records the orgs that rejected the client's token for SSO.
+	sso *ssoState
 }
 
 func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -25,6 +31,11 @@ func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error
 		return nil, ErrRateLimited
 	}
 
+	// Don't keep sending a token GitHub has already rejected
+	if t.auth.isRejected() {
+		return nil, ErrUnauthorized
+	}
+
 	resp, err := t.base.RoundTrip(req)
 	if err != nil {
 		return resp, err
@@ -41,6 +52,16 @@ func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error
 		log.Debug("rate limit low", "remaining", remaining, "resets_at", resetAt.Format(time.RFC3339))
 	}
 
+	// Record a rejected token. The response itself is passed through so the
+	// caller still sees GitHub's error message.
+	if resp.StatusCode == http.StatusUnauthorized {
+		t.auth.reject()
+	}
+
+	// Record orgs that require SSO authorization for this token. The
+	// response is passed through; callers skip those repos.
+	t.sso.recordResponse(req, resp)
+
 	// Handle rate limit responses (403 with rate limit exceeded or 429)
 	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
 		if resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.StatusCode == http.StatusTooManyRequests {
@@ -87,33 +108,84 @@ type Client struct {
 	// token is intentionally unexported. NEVER add String(), MarshalJSON(),
 	// or any method that could expose this value in logs or serialized output.
 	token string
+	// dryRun skips all mutating API calls (see mutate).
+	dryRun bool
+	// auditLog records every mutating API call; nil disables auditing.
+	auditLog *audit.Log
+	// transport overrides the HTTP transport (see WithTransport).
+	transport http.RoundTripper
+	// graphqlHTTP sends GraphQL requests.
+	graphqlHTTP *http.Client
+	// httpPolicy sets timeouts and retries for REST and GraphQL requests.
+	httpPolicy HTTPPolicy
+	// schema records GraphQL fields the server lacks (see executeForSchema).
+	schema schemaState
+	// budgetChecked is set once the GraphQL quota has been asked for (see
+	// graphQLBudget).
+	budgetChecked atomic.Bool
+	// projectStatus fetches the project boards items are on (see
+	// WithProjectStatus).
+	projectStatus bool
+	// auth records whether GitHub rejected token (see Unauthorized).
+	auth authState
+	// sso records the orgs that rejected token for SSO (see SSORequired).
+	sso ssoState
+	// graphqlLimit tracks the GraphQL quota of token, which GitHub
+	// accounts separately from the REST quota.
+	graphqlLimit RateLimitState
+	// truncation records searches cut short by the result cap (see
+	// TruncatedSearches).
+	truncation truncationState
 }
 
+// ClientOption is a functional option for configuring a Client.
+type ClientOption func(*Client)
+
 // NewClient creates a new GitHub client using a personal access token.
 // Callers are responsible for validating that token is non-empty before calling.
-func NewClient(ctx context.Context, token string) (*Client, error) {
+func NewClient(ctx context.Context, token string, opts ...ClientOption) (*Client, error) {
+	q, err := loadQueries()
+	if err != nil {
+		return nil, fmt.Errorf("loading graphql queries: %w", err)
+	}
+
+	c := &Client{
+		queries:    q,
+		token:      token,
+		httpPolicy: DefaultHTTPPolicy(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// REST and GraphQL requests share the timeout and retry policy
+	restBase, graphqlBase := http.DefaultTransport, http.RoundTripper(graphqlTransport)
+	if c.transport != nil {
+		restBase, graphqlBase = c.transport, c.transport
+	}
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := &http.Client{Transport: &oauth2.Transport{Source: ts, Base: newRetryTransport(restBase, c.httpPolicy)}}
+	c.graphqlHTTP = &http.Client{Transport: newRetryTransport(graphqlBase, c.httpPolicy)}
 
 	// Wrap transport with rate limit handling
 	tc.Transport = &rateLimitTransport{
 		base: tc.Transport,
+		auth: &c.auth,
+		sso:  &c.sso,
 	}
 
-	q, err := loadQueries()
-	if err != nil {
-		return nil, fmt.Errorf("loading graphql queries: %w", err)
-	}
-
-	client := gh.NewClient(tc)
+	c.client = gh.NewClient(tc)
+	return c, nil
+}
 
-	return &Client{
-		client:  client,
-		queries: q,
-		token:   token,
-	}, nil
+// WithTransport sends all REST and GraphQL traffic through rt instead of
+// the default transport, e.g. a Recorder or Replayer.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = rt
+	}
 }
 
 // AuthenticatedUser returns the authenticated user's login
@@ -143,37 +215,17 @@ func (c *Client) Token() string {
 func (c *Client) ListReviewRequestedPRs(ctx context.Context, username string) ([]model.Item, error) {
 	query := fmt.Sprintf("is:pr is:open review-requested:%s", username)
 
-	opts := &gh.SearchOptions{
-		Sort:  "updated",
-		Order: "desc",
-		ListOptions: gh.ListOptions{
-			PerPage: 100,
-		},
-	}
-
-	var items []model.Item
-
-	for {
-		result, resp, err := c.client.Search.Issues(ctx, query, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to search for review-requested PRs: %w", err)
-		}
-
-		for _, issue := range result.Issues {
-			items = append(items, issueToItem(issue,
-				fmt.Sprintf("review-requested-%d", issue.GetID()),
-				model.ReasonReviewRequested,
-				model.SubjectPullRequest,
-				&model.PRDetails{ReviewState: "pending"},
-			))
-		}
-
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+	items, err := c.searchAll(ctx, query, func(issue *gh.Issue) model.Item {
+		return issueToItem(issue,
+			fmt.Sprintf("review-requested-%d", issue.GetID()),
+			model.ReasonReviewRequested,
+			model.SubjectPullRequest,
+			&model.PRDetails{ReviewState: "pending"},
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for review-requested PRs: %w", err)
 	}
-
 	return items, nil
 }
 
@@ -249,37 +301,17 @@ func issueToItem(issue *gh.Issue, id string, reason model.ItemReason, subjectTyp
 func (c *Client) ListAssignedIssues(ctx context.Context, username string) ([]model.Item, error) {
 	query := fmt.Sprintf("is:issue is:open assignee:%s", username)
 
-	opts := &gh.SearchOptions{
-		Sort:  "updated",
-		Order: "desc",
-		ListOptions: gh.ListOptions{
-			PerPage: 100,
-		},
-	}
-
-	var items []model.Item
-
-	for {
-		result, resp, err := c.client.Search.Issues(ctx, query, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to search for assigned issues: %w", err)
-		}
-
-		for _, issue := range result.Issues {
-			items = append(items, issueToItem(issue,
-				fmt.Sprintf("assigned-%d", issue.GetID()),
-				model.ReasonAssign,
-				model.SubjectIssue,
-				&model.IssueDetails{},
-			))
-		}
-
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+	items, err := c.searchAll(ctx, query, func(issue *gh.Issue) model.Item {
+		return issueToItem(issue,
+			fmt.Sprintf("assigned-%d", issue.GetID()),
+			model.ReasonAssign,
+			model.SubjectIssue,
+			&model.IssueDetails{},
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for assigned issues: %w", err)
 	}
-
 	return items, nil
 }
 
@@ -287,37 +319,17 @@ func (c *Client) ListAssignedIssues(ctx context.Context, username string) ([]mod
 func (c *Client) ListAssignedPRs(ctx context.Context, username string) ([]model.Item, error) {
 	query := fmt.Sprintf("is:pr is:open assignee:%s", username)
 
-	opts := &gh.SearchOptions{
-		Sort:  "updated",
-		Order: "desc",
-		ListOptions: gh.ListOptions{
-			PerPage: 100,
-		},
-	}
-
-	var items []model.Item
-
-	for {
-		result, resp, err := c.client.Search.Issues(ctx, query, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to search for assigned PRs: %w", err)
-		}
-
-		for _, issue := range result.Issues {
-			items = append(items, issueToItem(issue,
-				fmt.Sprintf("assigned-pr-%d", issue.GetID()),
-				model.ReasonAssign,
-				model.SubjectPullRequest,
-				&model.PRDetails{},
-			))
-		}
-
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+	items, err := c.searchAll(ctx, query, func(issue *gh.Issue) model.Item {
+		return issueToItem(issue,
+			fmt.Sprintf("assigned-pr-%d", issue.GetID()),
+			model.ReasonAssign,
+			model.SubjectPullRequest,
+			&model.PRDetails{},
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for assigned PRs: %w", err)
 	}
-
 	return items, nil
 }
 
@@ -325,36 +337,16 @@ func (c *Client) ListAssignedPRs(ctx context.Context, username string) ([]model.
 func (c *Client) ListAuthoredPRs(ctx context.Context, username string) ([]model.Item, error) {
 	query := fmt.Sprintf("is:pr is:open author:%s", username)
 
-	opts := &gh.SearchOptions{
-		Sort:  "updated",
-		Order: "desc",
-		ListOptions: gh.ListOptions{
-			PerPage: 100,
-		},
-	}
-
-	var items []model.Item
-
-	for {
-		result, resp, err := c.client.Search.Issues(ctx, query, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to search for authored PRs: %w", err)
-		}
-
-		for _, issue := range result.Issues {
-			items = append(items, issueToItem(issue,
-				fmt.Sprintf("authored-%d", issue.GetID()),
-				model.ReasonAuthor,
-				model.SubjectPullRequest,
-				&model.PRDetails{Draft: issue.GetDraft()},
-			))
-		}
-
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+	items, err := c.searchAll(ctx, query, func(issue *gh.Issue) model.Item {
+		return issueToItem(issue,
+			fmt.Sprintf("authored-%d", issue.GetID()),
+			model.ReasonAuthor,
+			model.SubjectPullRequest,
+			&model.PRDetails{Draft: issue.GetDraft()},
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for authored PRs: %w", err)
 	}
-
 	return items, nil
 }
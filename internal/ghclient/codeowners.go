@@ -0,0 +1,52 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CodeOwners returns the CODEOWNERS file on owner/repo's default branch,
+// from the first place GitHub looks for it: .github/, the root, then
+// docs/. It returns an empty string when the repository has none.
+func (c *Client) CodeOwners(ctx context.Context, owner, repo string) (string, error) {
+	query := c.queries.BuildCodeOwnersQuery(owner, repo)
+	respData, _, err := c.executeGraphQL(ctx, query, c.token)
+	if err != nil {
+		return "", err
+	}
+	text, err := parseCodeOwnersResponse(respData)
+	if err != nil {
+		return "", fmt.Errorf("%s/%s: %w", owner, repo, err)
+	}
+	return text, nil
+}
+
+// parseCodeOwnersResponse returns the first CODEOWNERS file found in a
+// CODEOWNERS query response.
+func parseCodeOwnersResponse(data json.RawMessage) (string, error) {
+	type blob struct {
+		Text *string `json:"text"`
+	}
+	var resp struct {
+		Repository *struct {
+			GitHub *blob `json:"github"`
+			Root   *blob `json:"root"`
+			Docs   *blob `json:"docs"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse CODEOWNERS response: %w", err)
+	}
+	if resp.Repository == nil {
+		return "", errors.New("repository not found")
+	}
+
+	for _, b := range []*blob{resp.Repository.GitHub, resp.Repository.Root, resp.Repository.Docs} {
+		if b != nil && b.Text != nil {
+			return *b.Text, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,52 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCodeOwnersResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "in .github",
+			data: `{"repository": {"github": {"text": "* @acme/core\n"}, "root": {"text": "* @someone\n"}, "docs": null}}`,
+			want: "* @acme/core\n",
+		},
+		{
+			name: "in docs",
+			data: `{"repository": {"github": null, "root": null, "docs": {"text": "/docs/ @acme/writers\n"}}}`,
+			want: "/docs/ @acme/writers\n",
+		},
+		{
+			name: "a directory named CODEOWNERS",
+			data: `{"repository": {"github": {}, "root": {"text": "* @acme/core\n"}, "docs": null}}`,
+			want: "* @acme/core\n",
+		},
+		{
+			name: "none",
+			data: `{"repository": {"github": null, "root": null, "docs": null}}`,
+		},
+		{
+			name:    "unknown repository",
+			data:    `{"repository": null}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCodeOwnersResponse(json.RawMessage(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCodeOwnersResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseCodeOwnersResponse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
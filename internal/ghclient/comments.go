@@ -0,0 +1,135 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// GetThread fetches an issue or pull request along with its most recent
+// comments (oldest first). maxComments caps how many comments are returned;
+// values <= 0 return no comments.
+func (c *Client) GetThread(ctx context.Context, owner, repo string, number, maxComments int) (*model.Thread, error) {
+	issue, _, err := c.client.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s#%d: %w", owner, repo, number, err)
+	}
+
+	var labels []string
+	for _, label := range issue.Labels {
+		labels = append(labels, label.GetName())
+	}
+
+	thread := &model.Thread{
+		Repository: owner + "/" + repo,
+		Number:     issue.GetNumber(),
+		Title:      issue.GetTitle(),
+		Body:       issue.GetBody(),
+		Author:     issue.GetUser().GetLogin(),
+		State:      issue.GetState(),
+		HTMLURL:    issue.GetHTMLURL(),
+		Labels:     labels,
+		IsPR:       issue.IsPullRequest(),
+		CreatedAt:  issue.GetCreatedAt().Time,
+	}
+
+	if maxComments <= 0 || issue.GetComments() == 0 {
+		return thread, nil
+	}
+
+	// The issue comments endpoint only lists oldest-first, so jump straight
+	// to the last page(s) to collect the newest window.
+	const perPage = 100
+	page := (issue.GetComments() + perPage - 1) / perPage
+	var comments []*gh.IssueComment
+	for page > 0 && len(comments) < maxComments {
+		opts := &gh.IssueListCommentsOptions{
+			ListOptions: gh.ListOptions{PerPage: perPage, Page: page},
+		}
+		batch, _, err := c.client.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments for %s/%s#%d: %w", owner, repo, number, err)
+		}
+		comments = append(batch, comments...)
+		page--
+	}
+	if len(comments) > maxComments {
+		comments = comments[len(comments)-maxComments:]
+	}
+
+	for _, comment := range comments {
+		thread.Comments = append(thread.Comments, model.Comment{
+			Author:    comment.GetUser().GetLogin(),
+			Body:      comment.GetBody(),
+			CreatedAt: comment.GetCreatedAt().Time,
+		})
+	}
+
+	return thread, nil
+}
+
+// CreateComment posts a new comment on an issue or pull request.
+func (c *Client) CreateComment(ctx context.Context, owner, repo string, number int, body string) error {
+	target := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	detail := fmt.Sprintf("%d characters", len(body))
+	return c.mutate("comment on", target, detail, func() error {
+		_, _, err := c.client.Issues.CreateComment(ctx, owner, repo, number, &gh.IssueComment{
+			Body: gh.String(body),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to comment on %s: %w", target, err)
+		}
+		return nil
+	})
+}
+
+// AddAssignees assigns logins to an issue or pull request, keeping anyone
+// already assigned.
+func (c *Client) AddAssignees(ctx context.Context, owner, repo string, number int, logins []string) error {
+	target := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	detail := strings.Join(logins, ", ")
+	return c.mutate("assign", target, detail, func() error {
+		_, _, err := c.client.Issues.AddAssignees(ctx, owner, repo, number, logins)
+		if err != nil {
+			return fmt.Errorf("failed to assign %s to %s: %w", detail, target, err)
+		}
+		return nil
+	})
+}
+
+// ParseItemRef parses an item reference into its owner, repo, and number.
+// Accepted forms are "owner/repo#123" and GitHub web URLs such as
+// "https://github.com/owner/repo/pull/123" or ".../issues/123".
+func ParseItemRef(ref string) (owner, repo string, number int, err error) {
+	ref = strings.TrimSpace(ref)
+
+	if rest, ok := strings.CutPrefix(ref, "https://github.com/"); ok {
+		parts := strings.Split(strings.Trim(rest, "/"), "/")
+		if len(parts) < 4 || (parts[2] != "pull" && parts[2] != "issues") {
+			return "", "", 0, fmt.Errorf("invalid item URL %q: expected https://github.com/owner/repo/(pull|issues)/N", ref)
+		}
+		number, err = strconv.Atoi(parts[3])
+		if err != nil || number <= 0 {
+			return "", "", 0, fmt.Errorf("invalid item number in %q", ref)
+		}
+		return parts[0], parts[1], number, nil
+	}
+
+	repoPart, numPart, ok := strings.Cut(ref, "#")
+	if !ok {
+		return "", "", 0, fmt.Errorf("invalid item reference %q: expected owner/repo#N or a GitHub URL", ref)
+	}
+	owner, repo, ok = strings.Cut(repoPart, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", 0, fmt.Errorf("invalid repository in %q: expected owner/repo", ref)
+	}
+	number, err = strconv.Atoi(numPart)
+	if err != nil || number <= 0 {
+		return "", "", 0, fmt.Errorf("invalid item number in %q", ref)
+	}
+	return owner, repo, number, nil
+}
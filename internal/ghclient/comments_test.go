@@ -0,0 +1,45 @@
+package ghclient
+
+import "testing"
+
+func TestParseItemRef(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref        string
+		wantOwner  string
+		wantRepo   string
+		wantNumber int
+		wantErr    bool
+	}{
+		{"short form", "owner/repo#42", "owner", "repo", 42, false},
+		{"short form with whitespace", "  owner/repo#7 ", "owner", "repo", 7, false},
+		{"pull URL", "https://github.com/owner/repo/pull/123", "owner", "repo", 123, false},
+		{"issue URL", "https://github.com/owner/repo/issues/9", "owner", "repo", 9, false},
+		{"URL with fragment path", "https://github.com/owner/repo/pull/5/files", "owner", "repo", 5, false},
+		{"missing number", "owner/repo", "", "", 0, true},
+		{"missing repo", "owner#1", "", "", 0, true},
+		{"non-numeric", "owner/repo#abc", "", "", 0, true},
+		{"zero", "owner/repo#0", "", "", 0, true},
+		{"nested repo", "a/b/c#1", "", "", 0, true},
+		{"unsupported URL", "https://github.com/owner/repo/commit/abc", "", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, repo, number, err := ParseItemRef(tt.ref)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tt.ref)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if owner != tt.wantOwner || repo != tt.wantRepo || number != tt.wantNumber {
+				t.Errorf("ParseItemRef(%q) = %s/%s#%d, want %s/%s#%d",
+					tt.ref, owner, repo, number, tt.wantOwner, tt.wantRepo, tt.wantNumber)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package ghclient
+
+// GitHub charges a GraphQL query roughly one rate-limit point per 100
+// connections it asks for, with a minimum of one point. These are the
+// connections each query requests, used to estimate run cost.
+const (
+	// prConnectionsPerItem counts the connections in pr_fields.graphql.
+	prConnectionsPerItem = 10
+	// issueConnectionsPerItem counts the connections in
+	// issue_fields.graphql.
+	issueConnectionsPerItem = 4
+	// projectConnectionsPerItem counts the projectItems connection, left
+	// out of the counts above since it is only fetched when asked for (see
+	// WithProjectStatus).
+	projectConnectionsPerItem = 1
+	// orphanedQueryConnections counts the connections in orphaned.graphql:
+	// 50 issues and 50 PRs, each with their nested connections.
+	orphanedQueryConnections = 2 + 50*3 + 50*5
+)
+
+// Cost estimates the GitHub API usage of part of a run.
+type Cost struct {
+	// Requests is the number of HTTP requests sent.
+	Requests int
+	// Points is the GraphQL rate-limit points used; 0 for REST.
+	Points int
+	// Rounds is the number of requests that must happen one after another
+	// once the concurrency limit is taken into account.
+	Rounds int
+}
+
+// restPageSize is the page size used for REST list and search calls.
+const restPageSize = 100
+
+// NotificationsCost estimates the REST cost of listing items
+// notifications. Pages after the first are fetched concurrently, at most
+// maxConcurrentPages at a time.
+func NotificationsCost(items int) Cost {
+	pages := max(1, ceilDiv(items, restPageSize))
+	return Cost{Requests: pages, Rounds: 1 + ceilDiv(pages-1, maxConcurrentPages)}
+}
+
+// SearchCost estimates the search API cost of a search returning items
+// results. Result pages are fetched one after another.
+func SearchCost(items int) Cost {
+	pages := max(1, ceilDiv(items, restPageSize))
+	return Cost{Requests: pages, Rounds: pages}
+}
+
+// EnrichmentCost estimates the GraphQL cost of enriching prs pull requests
+// and issues issues with EnrichItemsGraphQL.
+func EnrichmentCost(prs, issues int) Cost {
+	batches := ceilDiv(prs+issues, graphqlBatchSize)
+	return Cost{
+		Requests: ceilDiv(prs, graphqlBatchSize) + ceilDiv(issues, graphqlBatchSize),
+		Points:   batchPoints(prs, prConnectionsPerItem) + batchPoints(issues, issueConnectionsPerItem),
+		Rounds:   ceilDiv(batches, maxConcurrentBatches),
+	}
+}
+
+// OrphanedCost estimates the GraphQL cost of checking repos repositories
+// for orphaned contributions.
+func OrphanedCost(repos int) Cost {
+	return Cost{
+		Requests: repos,
+		Points:   repos * queryPoints(orphanedQueryConnections),
+		Rounds:   ceilDiv(repos, maxConcurrentOrphanedFetches),
+	}
+}
+
+// batchPoints returns the points used by queries for n items of one kind,
+// sent graphqlBatchSize at a time.
+func batchPoints(n, connectionsPerItem int) int {
+	points := (n / graphqlBatchSize) * queryPoints(graphqlBatchSize*connectionsPerItem)
+	if rem := n % graphqlBatchSize; rem > 0 {
+		points += queryPoints(rem * connectionsPerItem)
+	}
+	return points
+}
+
+// queryPoints returns the points charged for a query requesting the given
+// number of connections.
+func queryPoints(connections int) int {
+	return max(1, ceilDiv(connections, 100))
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,41 @@
+package ghclient
+
+import "testing"
+
+func TestEnrichmentCost(t *testing.T) {
+	tests := []struct {
+		name        string
+		prs, issues int
+		want        Cost
+	}{
+		{"nothing", 0, 0, Cost{}},
+		{"one issue", 0, 1, Cost{Requests: 1, Points: 1, Rounds: 1}},
+		{"full PR batch", 25, 0, Cost{Requests: 1, Points: 3, Rounds: 1}},
+		{"mixed", 30, 10, Cost{Requests: 3, Points: 5, Rounds: 1}},
+		{"more batches than workers", 0, 25 * (maxConcurrentBatches + 1), Cost{Requests: maxConcurrentBatches + 1, Points: maxConcurrentBatches + 1, Rounds: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EnrichmentCost(tt.prs, tt.issues); got != tt.want {
+				t.Errorf("EnrichmentCost(%d, %d) = %+v, want %+v", tt.prs, tt.issues, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListCosts(t *testing.T) {
+	if got, want := NotificationsCost(0), (Cost{Requests: 1, Rounds: 1}); got != want {
+		t.Errorf("NotificationsCost(0) = %+v, want %+v", got, want)
+	}
+	// Notification pages after the first are fetched together, a few at a time
+	if got, want := NotificationsCost(450), (Cost{Requests: 5, Rounds: 2}); got != want {
+		t.Errorf("NotificationsCost(450) = %+v, want %+v", got, want)
+	}
+	if got, want := NotificationsCost(4800), (Cost{Requests: 48, Rounds: 13}); got != want {
+		t.Errorf("NotificationsCost(4800) = %+v, want %+v", got, want)
+	}
+	if got, want := SearchCost(250), (Cost{Requests: 3, Rounds: 3}); got != want {
+		t.Errorf("SearchCost(250) = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,21 @@
+package ghclient
+
+import "errors"
+
+// ErrDryRun is returned by mutating operations when the client is in
+// dry-run mode. The wrapped message describes what would have been done.
+var ErrDryRun = errors.New("dry run")
+
+// WithDryRun puts the client in dry-run mode: read operations behave
+// normally, while every mutating operation is skipped and returns an
+// error wrapping ErrDryRun.
+func WithDryRun(dryRun bool) ClientOption {
+	return func(c *Client) {
+		c.dryRun = dryRun
+	}
+}
+
+// DryRun reports whether the client is in dry-run mode.
+func (c *Client) DryRun() bool {
+	return c.dryRun
+}
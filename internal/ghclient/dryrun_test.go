@@ -0,0 +1,50 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDryRunSkipsMutations(t *testing.T) {
+	// The underlying go-github client is nil: any mutation that reaches the
+	// network would panic, so these calls prove the guard short-circuits.
+	c := &Client{}
+	WithDryRun(true)(c)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		call       func() error
+		wantAction string
+	}{
+		{
+			name:       "mark as read",
+			call:       func() error { return c.MarkAsRead(ctx, "123") },
+			wantAction: "would mark as read notification 123",
+		},
+		{
+			name:       "create comment",
+			call:       func() error { return c.CreateComment(ctx, "owner", "repo", 7, "hello") },
+			wantAction: "would comment on owner/repo#7 (5 characters)",
+		},
+		{
+			name:       "add assignees",
+			call:       func() error { return c.AddAssignees(ctx, "owner", "repo", 7, []string{"alice", "bob"}) },
+			wantAction: "would assign owner/repo#7 (alice, bob)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, ErrDryRun) {
+				t.Fatalf("expected ErrDryRun, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantAction) {
+				t.Errorf("expected error to describe %q, got %q", tt.wantAction, err.Error())
+			}
+		})
+	}
+}
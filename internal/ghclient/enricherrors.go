@@ -0,0 +1,151 @@
+package ghclient
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ItemErrorKind classifies why an item came back from enrichment without
+// details.
+type ItemErrorKind string
+
+const (
+	// ItemErrorNotFound means the PR or issue no longer exists.
+	ItemErrorNotFound ItemErrorKind = "not_found"
+	// ItemErrorNoAccess means the token can't read the repository. The item
+	// is also marked Inaccessible.
+	ItemErrorNoAccess ItemErrorKind = "no_access"
+	// ItemErrorSSO means the repository's org requires SAML SSO
+	// authorization for the token (see SSORequired).
+	ItemErrorSSO ItemErrorKind = "sso"
+	// ItemErrorGraphQL is any other error GitHub reported for the item.
+	ItemErrorGraphQL ItemErrorKind = "graphql"
+	// ItemErrorRequest means the whole batch request containing the item
+	// failed.
+	ItemErrorRequest ItemErrorKind = "request"
+	// ItemErrorRateLimited means the GraphQL quota ran out before the
+	// item's batch was sent.
+	ItemErrorRateLimited ItemErrorKind = "rate_limited"
+	// ItemErrorMissing means GitHub returned neither data nor an error.
+	ItemErrorMissing ItemErrorKind = "missing"
+	// ItemErrorSkipped means the item couldn't be queried at all, e.g. its
+	// URL has no issue number.
+	ItemErrorSkipped ItemErrorKind = "skipped"
+)
+
+// ItemError explains why a single item was not enriched.
+type ItemError struct {
+	// Index is the item's position in the slice passed to
+	// EnrichItemsGraphQL.
+	Index   int
+	Kind    ItemErrorKind
+	Message string
+}
+
+func (e ItemError) Error() string {
+	return fmt.Sprintf("item %d: %s: %s", e.Index, e.Kind, e.Message)
+}
+
+// EnrichReport is the outcome of EnrichItemsGraphQL.
+type EnrichReport struct {
+	Enriched int
+	// Errors has one entry per item that was not enriched, ordered by
+	// Index. Items lost to a rejected token are not listed; the returned
+	// error reports that instead.
+	Errors []ItemError
+}
+
+// CountErrors returns how many item errors there are of each kind.
+func CountErrors(errs []ItemError) map[ItemErrorKind]int {
+	counts := make(map[ItemErrorKind]int)
+	for _, e := range errs {
+		counts[e.Kind]++
+	}
+	return counts
+}
+
+// classifyItems returns an ItemError for every item in a batch that has no
+// result, using the GraphQL errors GitHub returned for its alias. Items
+// are aliased as prefix followed by their position in the batch.
+func classifyItems(errs []graphqlError, items []enrichmentItem, prefix string, hasResult func(index int) bool) []ItemError {
+	byAlias := make(map[string]graphqlError, len(errs))
+	for _, e := range errs {
+		if len(e.Path) == 0 {
+			continue
+		}
+		if alias, ok := e.Path[0].(string); ok {
+			if _, seen := byAlias[alias]; !seen {
+				byAlias[alias] = e
+			}
+		}
+	}
+
+	var itemErrs []ItemError
+	for i, item := range items {
+		if hasResult(item.index) {
+			continue
+		}
+		e, ok := byAlias[prefix+strconv.Itoa(i)]
+		if !ok {
+			itemErrs = append(itemErrs, ItemError{Index: item.index, Kind: ItemErrorMissing, Message: "no data returned"})
+			continue
+		}
+		itemErrs = append(itemErrs, ItemError{Index: item.index, Kind: classifyGraphQLError(e), Message: e.Message})
+	}
+	return itemErrs
+}
+
+// classifyGraphQLError maps a per-alias GraphQL error to an ItemErrorKind.
+func classifyGraphQLError(e graphqlError) ItemErrorKind {
+	switch {
+	case isSAMLFailure(e):
+		return ItemErrorSSO
+	case isRepoNotFound(e):
+		return ItemErrorNoAccess
+	case e.Type == "NOT_FOUND":
+		return ItemErrorNotFound
+	case e.Type == "RATE_LIMITED":
+		return ItemErrorRateLimited
+	default:
+		return ItemErrorGraphQL
+	}
+}
+
+// isRepoNotFound reports whether a GraphQL error says a queried repository
+// doesn't exist, which is also how GitHub answers when the token can't read
+// it. Only the alias itself is in the path; a missing PR or issue inside a
+// readable repository has a longer path.
+func isRepoNotFound(e graphqlError) bool {
+	return e.Type == "NOT_FOUND" && len(e.Path) == 1
+}
+
+// requestFailed returns an ItemError for every item in a batch whose
+// request failed outright.
+func requestFailed(items []enrichmentItem, err error) []ItemError {
+	kind := ItemErrorRequest
+	if errors.Is(err, ErrRateLimited) {
+		kind = ItemErrorRateLimited
+	}
+	itemErrs := make([]ItemError, len(items))
+	for i, item := range items {
+		itemErrs[i] = ItemError{Index: item.index, Kind: kind, Message: err.Error()}
+	}
+	return itemErrs
+}
+
+// sortItemErrors orders errors by item index.
+func sortItemErrors(errs []ItemError) {
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Index < errs[j].Index })
+}
+
+// hasAliasPath reports whether a GraphQL error is attributed to a query
+// alias, as opposed to the query as a whole.
+func hasAliasPath(e graphqlError) bool {
+	if len(e.Path) == 0 {
+		return false
+	}
+	alias, ok := e.Path[0].(string)
+	return ok && alias != ""
+}
@@ -0,0 +1,130 @@
+package ghclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestClassifyGraphQLError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  graphqlError
+		want ItemErrorKind
+	}{
+		{"saml", graphqlError{Type: "FORBIDDEN", Path: []any{"pr0"}, Message: "Resource protected by organization SAML enforcement."}, ItemErrorSSO},
+		{"repository not found", graphqlError{Type: "NOT_FOUND", Path: []any{"pr0"}}, ItemErrorNoAccess},
+		{"pull request not found", graphqlError{Type: "NOT_FOUND", Path: []any{"pr0", "pullRequest"}}, ItemErrorNotFound},
+		{"other", graphqlError{Type: "SERVICE_UNAVAILABLE", Path: []any{"pr0"}}, ItemErrorGraphQL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyGraphQLError(tt.err); got != tt.want {
+				t.Errorf("classifyGraphQLError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichReportsPerItemErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"data": {
+				"issue0": {"issue": {"number": 1, "state": "OPEN"}},
+				"issue1": {"issue": null},
+				"issue2": null
+			},
+			"errors": [
+				{"type": "NOT_FOUND", "path": ["issue1", "issue"], "message": "Could not resolve to an Issue with the number of 2."},
+				{"type": "INTERNAL", "path": ["issue2"], "message": "Something went wrong"}
+			]
+		}`))
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	c := &Client{
+		queries:     mustLoadQueries(t),
+		graphqlHTTP: &http.Client{Transport: redirectTransport{target: target}},
+	}
+	issue := func(n string) model.Item {
+		return model.Item{ID: n, Repository: model.Repository{FullName: "org/repo"}, Subject: model.Subject{Type: model.SubjectIssue, URL: "https://api.github.com/repos/org/repo/issues/" + n}}
+	}
+	items := []model.Item{
+		{ID: "bad", Repository: model.Repository{FullName: "org/repo"}, Subject: model.Subject{Type: model.SubjectIssue}},
+		issue("1"), issue("2"), issue("3"),
+	}
+
+	report, err := c.EnrichItemsGraphQL(context.Background(), items, "token", nil)
+	if err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if report.Enriched != 1 {
+		t.Errorf("Enriched = %d, want 1", report.Enriched)
+	}
+
+	want := []ItemError{
+		{Index: 0, Kind: ItemErrorSkipped},
+		{Index: 2, Kind: ItemErrorNotFound},
+		{Index: 3, Kind: ItemErrorGraphQL},
+	}
+	if len(report.Errors) != len(want) {
+		t.Fatalf("Errors = %v, want %d entries", report.Errors, len(want))
+	}
+	for i, w := range want {
+		if got := report.Errors[i]; got.Index != w.Index || got.Kind != w.Kind {
+			t.Errorf("Errors[%d] = %v, want index %d kind %s", i, got, w.Index, w.Kind)
+		}
+	}
+}
+
+func TestEnrichStopsWhenGraphQLQuotaRunsOut(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	c := &Client{
+		queries:     mustLoadQueries(t),
+		graphqlHTTP: &http.Client{Transport: redirectTransport{target: target}},
+	}
+	items := []model.Item{
+		{ID: "1", Repository: model.Repository{FullName: "org/repo"}, Subject: model.Subject{Type: model.SubjectIssue, URL: "https://api.github.com/repos/org/repo/issues/1"}},
+		{ID: "2", Repository: model.Repository{FullName: "org/repo"}, Subject: model.Subject{Type: model.SubjectPullRequest, URL: "https://api.github.com/repos/org/repo/pulls/2"}},
+	}
+
+	report, err := c.EnrichItemsGraphQL(context.Background(), items, "token", nil)
+	if err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if got := CountErrors(report.Errors)[ItemErrorRateLimited]; got != len(items) {
+		t.Errorf("rate limited items = %d, want %d (errors: %v)", got, len(items), report.Errors)
+	}
+	if !c.graphqlLimit.IsLimited() {
+		t.Error("expected the GraphQL quota to be marked exhausted")
+	}
+
+	// Once the quota is gone, further batches are not sent
+	sent := requests.Load()
+	if _, err := c.EnrichItemsGraphQL(context.Background(), items[:1], "token", nil); err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if requests.Load() != sent {
+		t.Errorf("requests = %d after the quota ran out, want %d", requests.Load(), sent)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,6 +16,8 @@ import (
 
 	"github.com/spiffcs/triage/internal/log"
 	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -23,22 +27,32 @@ const (
 	graphqlBatchSize = 25
 	// Maximum concurrent batch requests to avoid rate limiting
 	maxConcurrentBatches = 12
+
+	// EnrichRoundSize is how many items one round of concurrent batches
+	// enriches. Enriching in chunks of this size yields results early
+	// without sending fewer batches at once.
+	EnrichRoundSize = graphqlBatchSize * maxConcurrentBatches
 )
 
-// graphqlHTTPClient is a configured HTTP client optimized for GraphQL requests
-// with connection pooling and keep-alive for reduced latency.
+// graphqlTransport pools connections for GraphQL requests, which are sent
+// in concurrent batches, to reduce latency.
+var graphqlTransport = &http.Transport{
+	Proxy:               http.ProxyFromEnvironment,
+	MaxIdleConns:        20,
+	MaxIdleConnsPerHost: 20,
+	IdleConnTimeout:     30 * time.Second,
+}
+
+// graphqlHTTPClient sends GraphQL requests for clients built without
+// NewClient.
 var graphqlHTTPClient = &http.Client{
-	Transport: &http.Transport{
-		MaxIdleConns:        20,
-		MaxIdleConnsPerHost: 20,
-		IdleConnTimeout:     30 * time.Second,
-	},
-	Timeout: 30 * time.Second,
+	Transport: newRetryTransport(graphqlTransport, DefaultHTTPPolicy()),
 }
 
 // graphqlRequest represents a GraphQL request payload.
 type graphqlRequest struct {
-	Query string `json:"query"`
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
 }
 
 // graphqlResponse represents a generic GraphQL response.
@@ -48,17 +62,28 @@ type graphqlResponse struct {
 }
 
 type graphqlError struct {
-	Message string `json:"message"`
-	Type    string `json:"type"`
+	Message    string `json:"message"`
+	Type       string `json:"type"`
+	Path       []any  `json:"path"`
+	Extensions struct {
+		SAMLFailure bool `json:"saml_failure"`
+		// Code, TypeName, and FieldName describe query validation
+		// errors, such as a field the schema doesn't have
+		Code      string `json:"code"`
+		TypeName  string `json:"typeName"`
+		FieldName string `json:"fieldName"`
+	} `json:"extensions"`
 }
 
 // PRGraphQLResult contains the GraphQL response for a pull request.
 type PRGraphQLResult struct {
-	Number             int
-	State              string
-	Additions          int
-	Deletions          int
-	ChangedFiles       int
+	Number       int
+	State        string
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+	// Files are the paths the PR changes, up to the first 100.
+	Files              []string
 	IsDraft            bool
 	Mergeable          string
 	CreatedAt          time.Time
@@ -72,7 +97,29 @@ type PRGraphQLResult struct {
 	CIStatus           string
 	CommentCount       int
 	RequestedReviewers []string
+	RequestedTeams     []string
 	LatestReviewer     string
+	// Activity is when each participant last commented or reviewed, among
+	// the most recent comments and each reviewer's latest review.
+	Activity map[string]time.Time
+	// CommentTimes are when the most recent human comments were posted,
+	// oldest first.
+	CommentTimes []time.Time
+	// ReviewEvents are the recent review requests to users and submitted
+	// reviews, oldest first.
+	ReviewEvents []model.ReviewEvent
+	// LinkedIssues are the issues the PR closes, as "owner/repo#123".
+	LinkedIssues []string
+	// BlockedBy are the issues and PRs the description says the PR
+	// depends on, and the open PR it is stacked on, as "owner/repo#123".
+	// The state of those from the description is not known.
+	BlockedBy []string
+	// Milestone is the PR's milestone, if any.
+	Milestone *model.Milestone
+	// Projects are the project boards the PR is on, when fetched.
+	Projects []model.ProjectItem
+	// ProjectsFetched is set when the query asked for Projects.
+	ProjectsFetched bool
 }
 
 // IssueGraphQLResult contains the GraphQL response for an issue.
@@ -87,6 +134,22 @@ type IssueGraphQLResult struct {
 	Labels        []string
 	CommentCount  int
 	LastCommenter string
+	// Activity is when each participant last commented, among the most
+	// recent comments.
+	Activity map[string]time.Time
+	// CommentTimes are when the most recent human comments were posted,
+	// oldest first.
+	CommentTimes []time.Time
+	// BlockedBy are the open issues blocking this one, as "owner/repo#123".
+	BlockedBy []string
+	// FormFields are the short answers in an issue form body, by label.
+	FormFields map[string]string
+	// Milestone is the issue's milestone, if any.
+	Milestone *model.Milestone
+	// Projects are the project boards the issue is on, when fetched.
+	Projects []model.ProjectItem
+	// ProjectsFetched is set when the query asked for Projects.
+	ProjectsFetched bool
 }
 
 // enrichmentItem tracks what we need to enrich.
@@ -106,30 +169,37 @@ type batchResult struct {
 	issueResults map[int]*IssueGraphQLResult
 	prErr        error
 	issueErr     error
+	// itemErrs explains each item in the batch that has no result.
+	itemErrs []ItemError
 }
 
 // EnrichItemsGraphQL enriches items using GraphQL batch queries.
-// Returns the number of successfully enriched items.
-func (c *Client) EnrichItemsGraphQL(ctx context.Context, items []model.Item, token string, onProgress func(completed, total int)) (int, error) {
+// The report has the number of successfully enriched items and why each
+// of the others was not enriched.
+func (c *Client) EnrichItemsGraphQL(ctx context.Context, items []model.Item, token string, onProgress func(completed, total int)) (EnrichReport, error) {
 	// Separate PRs and Issues, and identify items that need enrichment
 	var enrichItems []enrichmentItem
+	var report EnrichReport
 
 	for i := range items {
 		n := &items[i]
 		if n.Subject.URL == "" {
 			log.Debug("skipping item without model.Subject.URL", "id", n.ID)
+			report.Errors = append(report.Errors, ItemError{Index: i, Kind: ItemErrorSkipped, Message: "no subject URL"})
 			continue
 		}
 
 		number, err := ExtractIssueNumber(n.Subject.URL)
 		if err != nil {
 			log.Debug("failed to extract issue number", "url", n.Subject.URL, "error", err)
+			report.Errors = append(report.Errors, ItemError{Index: i, Kind: ItemErrorSkipped, Message: err.Error()})
 			continue
 		}
 
 		parts := strings.Split(n.Repository.FullName, "/")
 		if len(parts) != 2 {
 			log.Debug("invalid repository name", "fullName", n.Repository.FullName)
+			report.Errors = append(report.Errors, ItemError{Index: i, Kind: ItemErrorSkipped, Message: "invalid repository name " + n.Repository.FullName})
 			continue
 		}
 
@@ -144,7 +214,7 @@ func (c *Client) EnrichItemsGraphQL(ctx context.Context, items []model.Item, tok
 
 	if len(enrichItems) == 0 {
 		log.Debug("no items to enrich via GraphQL")
-		return 0, nil
+		return report, nil
 	}
 
 	log.Debug("enriching items via GraphQL", "total", len(enrichItems))
@@ -152,35 +222,69 @@ func (c *Client) EnrichItemsGraphQL(ctx context.Context, items []model.Item, tok
 	total := len(enrichItems)
 	enriched := 0
 
+	// Size the batches to the GraphQL points left, asking GitHub when no
+	// earlier response said
+	remaining := c.graphQLRemaining()
+	if remaining < 0 {
+		remaining = c.graphQLBudget(ctx, token)
+	}
+	prs := 0
+	for _, item := range enrichItems {
+		if item.isPR {
+			prs++
+		}
+	}
+	plan := planEnrichment(prs, total-prs, remaining)
+	if plan.batchSize != graphqlBatchSize {
+		log.Info("GraphQL quota is low; enriching in smaller batches",
+			"remaining", remaining, "estimatedCost", EnrichmentCost(prs, total-prs).Points)
+	}
+	budget := &pointBudget{remaining: remaining}
+
 	// Create batch jobs
 	var batches [][]enrichmentItem
-	for batchStart := 0; batchStart < len(enrichItems); batchStart += graphqlBatchSize {
-		batchEnd := batchStart + graphqlBatchSize
+	for batchStart := 0; batchStart < len(enrichItems); batchStart += plan.batchSize {
+		batchEnd := batchStart + plan.batchSize
 		if batchEnd > len(enrichItems) {
 			batchEnd = len(enrichItems)
 		}
 		batches = append(batches, enrichItems[batchStart:batchEnd])
 	}
 
-	log.Debug("processing batches concurrently", "batches", len(batches), "maxConcurrent", maxConcurrentBatches)
+	log.Debug("processing batches concurrently", "batches", len(batches), "batchSize", plan.batchSize, "maxConcurrent", plan.concurrency)
+
+	// Workers take batches in order, so items the caller put first are
+	// enriched first. If the GraphQL quota runs out partway, the batches
+	// still queued fail fast instead of displacing earlier ones.
+	jobs := make(chan int, len(batches))
+	for batchIdx := range batches {
+		jobs <- batchIdx
+	}
+	close(jobs)
 
-	// Process batches with semaphore-limited concurrency
-	sem := make(chan struct{}, maxConcurrentBatches)
 	results := make(chan batchResult, len(batches))
 	var wg sync.WaitGroup
 
-	for batchIdx, batch := range batches {
-		wg.Add(1)
-		go func(idx int, b []enrichmentItem) {
-			defer wg.Done()
-			sem <- struct{}{}        // Acquire semaphore
-			defer func() { <-sem }() // Release semaphore
-
-			result := c.processBatch(ctx, b, token)
-			result.batchIdx = idx
-			result.batchSize = len(b)
-			results <- result
-		}(batchIdx, batch)
+	for range min(plan.concurrency, len(batches)) {
+		wg.Go(func() {
+			for idx := range jobs {
+				// A batch the points left won't cover is not sent, so
+				// it can't fail partway or starve the batches after it
+				var result batchResult
+				if budget.take(batchCost(batches[idx]), c.graphQLRemaining()) {
+					result = c.processBatch(ctx, batches[idx], token)
+				} else {
+					result = batchResult{
+						prErr:    errBudgetExhausted,
+						issueErr: errBudgetExhausted,
+						itemErrs: requestFailed(batches[idx], errBudgetExhausted),
+					}
+				}
+				result.batchIdx = idx
+				result.batchSize = len(batches[idx])
+				results <- result
+			}
+		})
 	}
 
 	// Close results channel when all workers done
@@ -189,12 +293,27 @@ func (c *Client) EnrichItemsGraphQL(ctx context.Context, items []model.Item, tok
 		close(results)
 	}()
 
-	// Collect results and apply to notifications
+	// Collect results and apply to notifications. A rejected token or an
+	// exhausted quota fails every remaining batch the same way, so each is
+	// reported once rather than logged per batch.
+	var authErr error
+	rateLimited := 0
 	for result := range results {
 		itemsProcessed := 0
 
+		for _, e := range result.itemErrs {
+			if e.Kind == ItemErrorNoAccess {
+				items[e.Index].Inaccessible = true
+			}
+		}
+		report.Errors = append(report.Errors, result.itemErrs...)
+
 		// Apply PR results
-		if result.prErr != nil {
+		if IsAuthError(result.prErr) {
+			authErr = result.prErr
+		} else if errors.Is(result.prErr, ErrRateLimited) {
+			rateLimited++
+		} else if result.prErr != nil {
 			log.Warn("GraphQL PR enrichment failed", "batch", result.batchIdx, "error", result.prErr)
 		} else if result.prResults != nil {
 			for idx, prResult := range result.prResults {
@@ -215,7 +334,11 @@ func (c *Client) EnrichItemsGraphQL(ctx context.Context, items []model.Item, tok
 		}
 
 		// Apply Issue results
-		if result.issueErr != nil {
+		if IsAuthError(result.issueErr) {
+			authErr = result.issueErr
+		} else if errors.Is(result.issueErr, ErrRateLimited) {
+			rateLimited++
+		} else if result.issueErr != nil {
 			log.Warn("GraphQL Issue enrichment failed", "batch", result.batchIdx, "error", result.issueErr)
 		} else if result.issueResults != nil {
 			for idx, issueResult := range result.issueResults {
@@ -236,11 +359,25 @@ func (c *Client) EnrichItemsGraphQL(ctx context.Context, items []model.Item, tok
 		}
 	}
 
-	return enriched, nil
+	if rateLimited > 0 {
+		log.Warn("GraphQL rate limit reached; the lowest-priority items were not enriched",
+			"notEnriched", CountErrors(report.Errors)[ItemErrorRateLimited])
+	}
+
+	report.Enriched = enriched
+	sortItemErrors(report.Errors)
+	if authErr != nil {
+		return report, ErrUnauthorized
+	}
+	return report, nil
 }
 
 // processBatch processes a single batch, fetching PRs and Issues in parallel.
 func (c *Client) processBatch(ctx context.Context, batch []enrichmentItem, token string) batchResult {
+	if c.Unauthorized() {
+		return batchResult{prErr: ErrUnauthorized, issueErr: ErrUnauthorized}
+	}
+
 	var prItems, issueItems []enrichmentItem
 	for _, item := range batch {
 		if item.isPR {
@@ -249,11 +386,15 @@ func (c *Client) processBatch(ctx context.Context, batch []enrichmentItem, token
 			issueItems = append(issueItems, item)
 		}
 	}
+	ctx, span := telemetry.Start(ctx, "graphql batch",
+		attribute.Int("triage.batch.prs", len(prItems)),
+		attribute.Int("triage.batch.issues", len(issueItems)))
 
 	var wg sync.WaitGroup
 	var prResults map[int]*PRGraphQLResult
 	var issueResults map[int]*IssueGraphQLResult
 	var prErr, issueErr error
+	var prItemErrs, issueItemErrs []ItemError
 
 	// Fetch PRs and Issues in parallel within this batch
 	if len(prItems) > 0 {
@@ -261,7 +402,7 @@ func (c *Client) processBatch(ctx context.Context, batch []enrichmentItem, token
 		go func() {
 			defer wg.Done()
 			log.Debug("enriching PRs via GraphQL", "count", len(prItems))
-			prResults, prErr = c.batchEnrichPRs(ctx, prItems, token)
+			prResults, prItemErrs, prErr = c.batchEnrichPRs(ctx, prItems, token)
 			if prErr == nil {
 				log.Debug("GraphQL PR enrichment returned", "results", len(prResults))
 			}
@@ -272,27 +413,39 @@ func (c *Client) processBatch(ctx context.Context, batch []enrichmentItem, token
 		go func() {
 			defer wg.Done()
 			log.Debug("enriching Issues via GraphQL", "count", len(issueItems))
-			issueResults, issueErr = c.batchEnrichIssues(ctx, issueItems, token)
+			issueResults, issueItemErrs, issueErr = c.batchEnrichIssues(ctx, issueItems, token)
 		}()
 	}
 	wg.Wait()
 
+	// A failed request leaves every item it covered without details
+	if prErr != nil && !IsAuthError(prErr) {
+		prItemErrs = requestFailed(prItems, prErr)
+	}
+	if issueErr != nil && !IsAuthError(issueErr) {
+		issueItemErrs = requestFailed(issueItems, issueErr)
+	}
+	span.SetAttributes(attribute.Int("triage.batch.failed_items", len(prItemErrs)+len(issueItemErrs)))
+	telemetry.End(span, errors.Join(prErr, issueErr))
+
 	return batchResult{
 		prResults:    prResults,
 		issueResults: issueResults,
 		prErr:        prErr,
 		issueErr:     issueErr,
+		itemErrs:     append(prItemErrs, issueItemErrs...),
 	}
 }
 
 // batchEnrichPRs fetches PR details for multiple items in a single GraphQL query.
-func (c *Client) batchEnrichPRs(ctx context.Context, items []enrichmentItem, token string) (map[int]*PRGraphQLResult, error) {
+func (c *Client) batchEnrichPRs(ctx context.Context, items []enrichmentItem, token string) (map[int]*PRGraphQLResult, []ItemError, error) {
 	if len(items) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// Convert enrichmentItem to BatchItem for query building
 	batchItems := make([]BatchItem, len(items))
+	owners := make(map[string]string, len(items))
 	for i, item := range items {
 		batchItems[i] = BatchItem{
 			Alias:  fmt.Sprintf("pr%d", i),
@@ -300,28 +453,39 @@ func (c *Client) batchEnrichPRs(ctx context.Context, items []enrichmentItem, tok
 			Repo:   item.repo,
 			Number: item.number,
 		}
+		owners[batchItems[i].Alias] = item.owner
 	}
 
-	query, err := c.queries.BuildPRBatchQuery(batchItems)
+	respData, gqlErrs, err := c.executeForSchema(ctx, token, func(gaps schemaGaps) (graphqlQuery, error) {
+		query, err := c.queries.BuildPRBatchQuery(batchItems, c.queryGaps(gaps))
+		if err != nil {
+			return query, fmt.Errorf("failed to build PR query: %w", err)
+		}
+		return query, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to build PR query: %w", err)
+		return nil, nil, err
 	}
-	respData, err := c.executeGraphQL(ctx, query, token)
+	// Items in orgs the token isn't SSO-authorized for come back as null
+	// with an error; they stay unenriched without failing the batch.
+	c.sso.recordSAMLFailures(gqlErrs, owners)
+
+	results, err := parsePRResponse(respData, items)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	return parsePRResponse(respData, items)
+	return results, classifyItems(gqlErrs, items, "pr", func(index int) bool { return results[index] != nil }), nil
 }
 
 // batchEnrichIssues fetches Issue details for multiple items in a single GraphQL query.
-func (c *Client) batchEnrichIssues(ctx context.Context, items []enrichmentItem, token string) (map[int]*IssueGraphQLResult, error) {
+func (c *Client) batchEnrichIssues(ctx context.Context, items []enrichmentItem, token string) (map[int]*IssueGraphQLResult, []ItemError, error) {
 	if len(items) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// Convert enrichmentItem to BatchItem for query building
 	batchItems := make([]BatchItem, len(items))
+	owners := make(map[string]string, len(items))
 	for i, item := range items {
 		batchItems[i] = BatchItem{
 			Alias:  fmt.Sprintf("issue%d", i),
@@ -329,64 +493,112 @@ func (c *Client) batchEnrichIssues(ctx context.Context, items []enrichmentItem,
 			Repo:   item.repo,
 			Number: item.number,
 		}
+		owners[batchItems[i].Alias] = item.owner
 	}
 
-	query, err := c.queries.BuildIssueBatchQuery(batchItems)
+	respData, gqlErrs, err := c.executeForSchema(ctx, token, func(gaps schemaGaps) (graphqlQuery, error) {
+		query, err := c.queries.BuildIssueBatchQuery(batchItems, c.queryGaps(gaps))
+		if err != nil {
+			return query, fmt.Errorf("failed to build Issue query: %w", err)
+		}
+		return query, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to build Issue query: %w", err)
+		return nil, nil, err
 	}
-	respData, err := c.executeGraphQL(ctx, query, token)
+	// Items in orgs the token isn't SSO-authorized for come back as null
+	// with an error; they stay unenriched without failing the batch.
+	c.sso.recordSAMLFailures(gqlErrs, owners)
+
+	results, err := parseIssueResponse(respData, items)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	return parseIssueResponse(respData, items)
+	return results, classifyItems(gqlErrs, items, "issue", func(index int) bool { return results[index] != nil }), nil
 }
 
-// executeGraphQL executes a GraphQL query against GitHub's API.
-func (c *Client) executeGraphQL(ctx context.Context, query string, token string) (json.RawMessage, error) {
-	reqBody := graphqlRequest{Query: query}
+// executeGraphQL executes a GraphQL query against GitHub's API. Errors
+// reported alongside partial data are returned so callers can attribute
+// them to the items they queried.
+func (c *Client) executeGraphQL(ctx context.Context, query graphqlQuery, token string) (json.RawMessage, []graphqlError, error) {
+	if c.Unauthorized() {
+		return nil, nil, ErrUnauthorized
+	}
+	if c.graphqlLimit.IsLimited() {
+		return nil, nil, ErrRateLimited
+	}
+
+	reqBody := graphqlRequest{Query: query.Query, Variables: query.Variables}
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal GraphQL request: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal GraphQL request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", graphqlEndpoint, bytes.NewReader(bodyBytes))
+	// Queries change nothing, so a failed one can safely be sent again
+	req, err := http.NewRequestWithContext(withRetryable(ctx), "POST", graphqlEndpoint, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create GraphQL request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := graphqlHTTPClient.Do(req)
+	httpClient := c.graphqlHTTP
+	if httpClient == nil {
+		httpClient = graphqlHTTPClient
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("GraphQL request failed: %w", err)
+		return nil, nil, fmt.Errorf("GraphQL request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read GraphQL response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read GraphQL response: %w", err)
+	}
+
+	c.sso.recordResponse(req, resp)
+	if resp.StatusCode == http.StatusUnauthorized {
+		c.auth.reject()
+		return nil, nil, ErrUnauthorized
 	}
 
+	// GraphQL has its own quota, tracked separately from the REST one
+	remaining, limit, resetAt := parseRateLimitHeaders(resp)
+	if remaining >= 0 && limit > 0 {
+		c.graphqlLimit.Update(remaining, limit, resetAt)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0") {
+		c.graphqlLimit.SetLimited(true, resetAt)
+		return nil, nil, ErrRateLimited
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GraphQL request failed with status %d", resp.StatusCode)
+		return nil, nil, fmt.Errorf("GraphQL request failed with status %d", resp.StatusCode)
 	}
 
 	var gqlResp graphqlResponse
 	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
-		return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
 	}
-
-	if len(gqlResp.Errors) > 0 {
-		// Log errors but don't fail - some items might still be valid
-		for _, e := range gqlResp.Errors {
-			log.Warn("GraphQL error", "message", e.Message, "type", e.Type)
+	c.recordRateLimit(gqlResp.Data)
+
+	// Don't fail - other aliases might still be valid. Errors for a single
+	// alias are returned to the caller, which accounts for them per item.
+	for _, e := range gqlResp.Errors {
+		if e.Type == "RATE_LIMITED" && !hasAliasPath(e) {
+			c.graphqlLimit.SetLimited(true, resetAt)
+			return nil, nil, ErrRateLimited
 		}
+		if hasAliasPath(e) {
+			log.Debug("GraphQL item error", "path", e.Path, "type", e.Type, "message", e.Message)
+			continue
+		}
+		log.Warn("GraphQL error", "message", e.Message, "type", e.Type)
 	}
 
-	return gqlResp.Data, nil
+	return gqlResp.Data, gqlResp.Errors, nil
 }
 
 // parsePRResponse parses the GraphQL response for PRs.
@@ -444,6 +656,10 @@ func parsePRResponse(data json.RawMessage, items []enrichmentItem) (map[int]*PRG
 			result.State = "merged"
 		}
 
+		for _, f := range pr.Files.Nodes {
+			result.Files = append(result.Files, f.Path)
+		}
+
 		// Parse assignees
 		for _, a := range pr.Assignees.Nodes {
 			if a.Login != "" {
@@ -461,11 +677,16 @@ func parsePRResponse(data json.RawMessage, items []enrichmentItem) (map[int]*PRG
 		// Parse requested reviewers
 		for _, rr := range pr.ReviewRequests.Nodes {
 			if rr.RequestedReviewer != nil {
-				// User has login, Team has name
+				// User has login, Team has name and org/slug
 				if rr.RequestedReviewer.Login != "" {
 					result.RequestedReviewers = append(result.RequestedReviewers, rr.RequestedReviewer.Login)
 				} else if rr.RequestedReviewer.Name != "" {
 					result.RequestedReviewers = append(result.RequestedReviewers, rr.RequestedReviewer.Name)
+					team := rr.RequestedReviewer.CombinedSlug
+					if team == "" {
+						team = rr.RequestedReviewer.Name
+					}
+					result.RequestedTeams = append(result.RequestedTeams, team)
 				}
 			}
 		}
@@ -480,9 +701,51 @@ func parsePRResponse(data json.RawMessage, items []enrichmentItem) (map[int]*PRG
 						latestTime = review.SubmittedAt
 						result.LatestReviewer = review.Author.Login
 					}
+					result.Activity = recordActivity(result.Activity, review.Author.activityLogin(), review.SubmittedAt)
 				}
 			}
 		}
+		for _, c := range pr.Comments.Nodes {
+			if c.Author != nil {
+				result.Activity = recordActivity(result.Activity, c.Author.activityLogin(), c.CreatedAt)
+			}
+		}
+		result.CommentTimes = pr.Comments.humanCommentTimes()
+		// Commits without a linked GitHub account have no user
+		for _, c := range pr.Commits.Nodes {
+			if a := c.Commit.Author; a != nil && a.User != nil {
+				result.Activity = recordActivity(result.Activity, a.User.Login, c.Commit.CommittedDate)
+			}
+		}
+
+		// Review requests to teams have no login and are skipped; pending
+		// reviews have no submittedAt
+		for _, e := range pr.TimelineItems.Nodes {
+			switch {
+			case e.RequestedReviewer != nil && e.RequestedReviewer.Login != "":
+				result.ReviewEvents = append(result.ReviewEvents, model.ReviewEvent{
+					Kind: model.ReviewEventRequested, Login: e.RequestedReviewer.Login, At: e.CreatedAt,
+				})
+			case e.Author != nil && e.Author.Login != "" && e.SubmittedAt != nil:
+				result.ReviewEvents = append(result.ReviewEvents, model.ReviewEvent{
+					Kind: model.ReviewEventReviewed, Login: e.Author.Login, At: *e.SubmittedAt,
+				})
+			}
+		}
+
+		for _, ref := range pr.ClosingIssuesReferences.Nodes {
+			result.LinkedIssues = append(result.LinkedIssues,
+				fmt.Sprintf("%s#%d", ref.Repository.NameWithOwner, ref.Number))
+		}
+		result.BlockedBy = dependencyRefs(pr.Body, item.owner+"/"+item.repo)
+		if parent := pr.stackedOn(); parent > 0 {
+			if ref := fmt.Sprintf("%s/%s#%d", item.owner, item.repo, parent); !slices.Contains(result.BlockedBy, ref) {
+				result.BlockedBy = append(result.BlockedBy, ref)
+			}
+		}
+		result.Milestone = pr.Milestone.model()
+		result.Projects = pr.ProjectItems.model()
+		result.ProjectsFetched = pr.ProjectItems != nil
 
 		// Map reviewDecision to our review state format
 		result.ReviewState = mapReviewDecision(pr.ReviewDecision)
@@ -498,17 +761,40 @@ func parsePRResponse(data json.RawMessage, items []enrichmentItem) (map[int]*PRG
 
 // prGraphQLData represents the PR data from GraphQL response.
 type prGraphQLData struct {
-	Number       int        `json:"number"`
-	State        string     `json:"state"`
-	Additions    int        `json:"additions"`
-	Deletions    int        `json:"deletions"`
-	ChangedFiles int        `json:"changedFiles"`
-	IsDraft      bool       `json:"isDraft"`
-	Mergeable    string     `json:"mergeable"`
-	CreatedAt    time.Time  `json:"createdAt"`
-	UpdatedAt    time.Time  `json:"updatedAt"`
-	ClosedAt     *time.Time `json:"closedAt"`
-	MergedAt     *time.Time `json:"mergedAt"`
+	Number       int    `json:"number"`
+	State        string `json:"state"`
+	Body         string `json:"body"`
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
+	ChangedFiles int    `json:"changedFiles"`
+	Files        struct {
+		Nodes []struct {
+			Path string `json:"path"`
+		} `json:"nodes"`
+	} `json:"files"`
+	IsDraft     bool   `json:"isDraft"`
+	Mergeable   string `json:"mergeable"`
+	BaseRefName string `json:"baseRefName"`
+	// BaseRef lists the open PR whose head is this PR's base branch, when
+	// the PR is stacked on another
+	BaseRef *struct {
+		AssociatedPullRequests struct {
+			Nodes []struct {
+				Number int `json:"number"`
+			} `json:"nodes"`
+		} `json:"associatedPullRequests"`
+	} `json:"baseRef"`
+	Repository struct {
+		DefaultBranchRef *struct {
+			Name string `json:"name"`
+		} `json:"defaultBranchRef"`
+	} `json:"repository"`
+	Milestone    *milestoneData    `json:"milestone"`
+	ProjectItems *projectItemsData `json:"projectItems"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+	ClosedAt     *time.Time        `json:"closedAt"`
+	MergedAt     *time.Time        `json:"mergedAt"`
 	Author       *struct {
 		Login string `json:"login"`
 	} `json:"author"`
@@ -530,33 +816,63 @@ type prGraphQLData struct {
 	} `json:"reviewRequests"`
 	LatestReviews struct {
 		Nodes []struct {
-			Author *struct {
-				Login string `json:"login"`
-			} `json:"author"`
+			Author      *actor    `json:"author"`
 			SubmittedAt time.Time `json:"submittedAt"`
 		} `json:"nodes"`
 	} `json:"latestReviews"`
 	Commits struct {
-		Nodes []struct {
-			Commit struct {
-				StatusCheckRollup *struct {
-					State string `json:"state"`
-				} `json:"statusCheckRollup"`
-			} `json:"commit"`
-		} `json:"nodes"`
+		Nodes []commitNode `json:"nodes"`
 	} `json:"commits"`
-	Comments struct {
-		TotalCount int `json:"totalCount"`
-	} `json:"comments"`
+	Comments      commentConnection `json:"comments"`
 	ReviewThreads struct {
 		TotalCount int `json:"totalCount"`
 	} `json:"reviewThreads"`
+	ClosingIssuesReferences struct {
+		Nodes []struct {
+			Number     int `json:"number"`
+			Repository struct {
+				NameWithOwner string `json:"nameWithOwner"`
+			} `json:"repository"`
+		} `json:"nodes"`
+	} `json:"closingIssuesReferences"`
+	// TimelineItems holds ReviewRequestedEvent and PullRequestReview nodes;
+	// only the fields of the node's own type are set.
+	TimelineItems struct {
+		Nodes []struct {
+			CreatedAt         time.Time `json:"createdAt"`
+			RequestedReviewer *struct {
+				Login string `json:"login"`
+			} `json:"requestedReviewer"`
+			Author *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			SubmittedAt *time.Time `json:"submittedAt"`
+		} `json:"nodes"`
+	} `json:"timelineItems"`
+}
+
+// stackedOn returns the number of the open PR this one is stacked on: the
+// PR whose head branch is this PR's base. PRs onto the default branch
+// aren't stacked, even when a PR is open from it, such as to a release
+// branch. It returns 0 when the PR isn't stacked.
+func (pr *prGraphQLData) stackedOn() int {
+	if pr.BaseRef == nil || len(pr.BaseRef.AssociatedPullRequests.Nodes) == 0 {
+		return 0
+	}
+	if d := pr.Repository.DefaultBranchRef; d != nil && d.Name == pr.BaseRefName {
+		return 0
+	}
+	if parent := pr.BaseRef.AssociatedPullRequests.Nodes[0].Number; parent != pr.Number {
+		return parent
+	}
+	return 0
 }
 
 // requestedReviewer can be either a User or a Team
 type requestedReviewer struct {
-	Login string `json:"login"` // For User
-	Name  string `json:"name"`  // For Team
+	Login        string `json:"login"`        // For User
+	Name         string `json:"name"`         // For Team
+	CombinedSlug string `json:"combinedSlug"` // For Team, as org/team-slug
 }
 
 // parseIssueResponse parses the GraphQL response for Issues.
@@ -617,9 +933,26 @@ func parseIssueResponse(data json.RawMessage, items []enrichmentItem) (map[int]*
 			}
 		}
 
-		// Get last commenter
-		if len(issue.Comments.Nodes) > 0 && issue.Comments.Nodes[0].Author != nil {
-			result.LastCommenter = issue.Comments.Nodes[0].Author.Login
+		// Get last commenter; comments come oldest first
+		if n := len(issue.Comments.Nodes); n > 0 && issue.Comments.Nodes[n-1].Author != nil {
+			result.LastCommenter = issue.Comments.Nodes[n-1].Author.Login
+		}
+		for _, c := range issue.Comments.Nodes {
+			if c.Author != nil {
+				result.Activity = recordActivity(result.Activity, c.Author.activityLogin(), c.CreatedAt)
+			}
+		}
+		result.CommentTimes = issue.Comments.humanCommentTimes()
+		result.FormFields = formFields(issue.Body)
+		result.Milestone = issue.Milestone.model()
+		result.Projects = issue.ProjectItems.model()
+		result.ProjectsFetched = issue.ProjectItems != nil
+
+		for _, b := range issue.BlockedBy.Nodes {
+			if strings.EqualFold(b.State, "OPEN") {
+				result.BlockedBy = append(result.BlockedBy,
+					fmt.Sprintf("%s#%d", b.Repository.NameWithOwner, b.Number))
+			}
 		}
 
 		results[item.index] = result
@@ -635,6 +968,7 @@ type issueGraphQLData struct {
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt"`
 	ClosedAt  *time.Time `json:"closedAt"`
+	Body      string     `json:"body"`
 	Author    *struct {
 		Login string `json:"login"`
 	} `json:"author"`
@@ -648,14 +982,86 @@ type issueGraphQLData struct {
 			Name string `json:"name"`
 		} `json:"nodes"`
 	} `json:"labels"`
-	Comments struct {
-		TotalCount int `json:"totalCount"`
-		Nodes      []struct {
-			Author *struct {
-				Login string `json:"login"`
-			} `json:"author"`
+	BlockedBy struct {
+		Nodes []struct {
+			Number     int    `json:"number"`
+			State      string `json:"state"`
+			Repository struct {
+				NameWithOwner string `json:"nameWithOwner"`
+			} `json:"repository"`
 		} `json:"nodes"`
-	} `json:"comments"`
+	} `json:"blockedBy"`
+	Milestone    *milestoneData    `json:"milestone"`
+	ProjectItems *projectItemsData `json:"projectItems"`
+	Comments     commentConnection `json:"comments"`
+}
+
+// commentConnection is the most recent comments on an issue or PR, oldest
+// first, with the total count.
+type commentConnection struct {
+	TotalCount int `json:"totalCount"`
+	Nodes      []struct {
+		Author    *actor    `json:"author"`
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"nodes"`
+}
+
+// humanCommentTimes returns when the comments were posted, oldest first,
+// leaving out those by bots so CI chatter doesn't read as discussion.
+func (c commentConnection) humanCommentTimes() []time.Time {
+	var times []time.Time
+	for _, n := range c.Nodes {
+		if n.Author != nil && n.Author.Typename == "Bot" {
+			continue
+		}
+		times = append(times, n.CreatedAt)
+	}
+	return times
+}
+
+// actor is the author of a comment or review.
+type actor struct {
+	Typename string `json:"__typename"`
+	Login    string `json:"login"`
+}
+
+// activityLogin is the login activity is recorded under. GraphQL drops the
+// "[bot]" suffix REST and config use for GitHub Apps, so it is put back.
+func (a *actor) activityLogin() string {
+	if a.Typename == "Bot" && a.Login != "" && !strings.HasSuffix(a.Login, "[bot]") {
+		return a.Login + "[bot]"
+	}
+	return a.Login
+}
+
+// commitNode is the head commit of a PR.
+type commitNode struct {
+	Commit struct {
+		CommittedDate time.Time `json:"committedDate"`
+		Author        *struct {
+			User *struct {
+				Login string `json:"login"`
+			} `json:"user"`
+		} `json:"author"`
+		StatusCheckRollup *struct {
+			State string `json:"state"`
+		} `json:"statusCheckRollup"`
+	} `json:"commit"`
+}
+
+// recordActivity notes that login was active at t, keeping the latest time
+// per login. It allocates the map on first use.
+func recordActivity(activity map[string]time.Time, login string, t time.Time) map[string]time.Time {
+	if login == "" || t.IsZero() {
+		return activity
+	}
+	if activity == nil {
+		activity = make(map[string]time.Time)
+	}
+	if t.After(activity[login]) {
+		activity[login] = t
+	}
+	return activity
 }
 
 // mapReviewDecision converts GitHub's reviewDecision enum to our internal format.
@@ -673,13 +1079,7 @@ func mapReviewDecision(decision string) string {
 }
 
 // getCIStatusFromCommits extracts CI status from the commit's status check rollup.
-func getCIStatusFromCommits(commits []struct {
-	Commit struct {
-		StatusCheckRollup *struct {
-			State string `json:"state"`
-		} `json:"statusCheckRollup"`
-	} `json:"commit"`
-}) string {
+func getCIStatusFromCommits(commits []commitNode) string {
 	if len(commits) == 0 {
 		return ""
 	}
@@ -714,6 +1114,12 @@ func applyPRResult(n *model.Item, result *PRGraphQLResult) {
 	n.Assignees = result.Assignees
 	n.Labels = result.Labels
 	n.CommentCount = result.CommentCount
+	n.ActivityBy = result.Activity
+	n.CommentTimes = result.CommentTimes
+	n.BlockedBy = result.BlockedBy
+	n.Milestone = result.Milestone
+	n.Projects = result.Projects
+	n.ProjectsFetched = result.ProjectsFetched
 
 	// Set HTMLURL if not already set
 	if n.HTMLURL == "" && n.Repository.FullName != "" {
@@ -727,12 +1133,16 @@ func applyPRResult(n *model.Item, result *PRGraphQLResult) {
 		Additions:          result.Additions,
 		Deletions:          result.Deletions,
 		ChangedFiles:       result.ChangedFiles,
+		Files:              result.Files,
 		ReviewState:        result.ReviewState,
 		Mergeable:          result.Mergeable == "MERGEABLE",
 		CIStatus:           result.CIStatus,
 		Draft:              result.IsDraft,
 		RequestedReviewers: result.RequestedReviewers,
+		RequestedTeams:     result.RequestedTeams,
 		LatestReviewer:     result.LatestReviewer,
+		ReviewEvents:       result.ReviewEvents,
+		LinkedIssues:       result.LinkedIssues,
 	}
 
 	// Update state to "merged" if merged
@@ -753,6 +1163,13 @@ func applyIssueResult(n *model.Item, result *IssueGraphQLResult) {
 	n.Assignees = result.Assignees
 	n.Labels = result.Labels
 	n.CommentCount = result.CommentCount
+	n.ActivityBy = result.Activity
+	n.CommentTimes = result.CommentTimes
+	n.BlockedBy = result.BlockedBy
+	n.FormFields = result.FormFields
+	n.Milestone = result.Milestone
+	n.Projects = result.Projects
+	n.ProjectsFetched = result.ProjectsFetched
 
 	// Set HTMLURL if not already set
 	if n.HTMLURL == "" && n.Repository.FullName != "" {
@@ -0,0 +1,136 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestParseActivity(t *testing.T) {
+	data := json.RawMessage(`{
+		"pr0": {"pullRequest": {
+			"number": 1,
+			"latestReviews": {"nodes": [{"author": {"login": "octocat"}, "submittedAt": "2026-10-03T00:00:00Z"}]},
+			"comments": {"totalCount": 2, "nodes": [
+				{"author": {"login": "octocat"}, "createdAt": "2026-10-05T00:00:00Z"},
+				{"author": {"__typename": "Bot", "login": "dependabot"}, "createdAt": "2026-10-06T00:00:00Z"}
+			]},
+			"commits": {"nodes": [{"commit": {"committedDate": "2026-10-07T00:00:00Z", "author": {"user": {"login": "monalisa"}}}}]}
+		}}
+	}`)
+	prs, err := parsePRResponse(data, []enrichmentItem{{index: 0, isPR: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]time.Time{
+		"octocat":         time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		"dependabot[bot]": time.Date(2026, 10, 6, 0, 0, 0, 0, time.UTC),
+		"monalisa":        time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC),
+	}
+	if got := prs[0].Activity; !maps.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("PR Activity = %v, want %v", got, want)
+	}
+	if got := prs[0].CommentTimes; len(got) != 1 || !got[0].Equal(want["octocat"]) {
+		t.Errorf("PR CommentTimes = %v, want only the human comment", got)
+	}
+
+	data = json.RawMessage(`{
+		"issue0": {"issue": {
+			"number": 2,
+			"comments": {"totalCount": 5, "nodes": [
+				{"author": {"login": "hubot"}, "createdAt": "2026-10-01T00:00:00Z"},
+				{"author": {"login": "monalisa"}, "createdAt": "2026-10-02T00:00:00Z"}
+			]}
+		}}
+	}`)
+	issues, err := parseIssueResponse(data, []enrichmentItem{{index: 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := issues[0].LastCommenter; got != "monalisa" {
+		t.Errorf("LastCommenter = %q, want the newest comment's author", got)
+	}
+	if got := issues[0].Activity; len(got) != 2 {
+		t.Errorf("issue Activity = %v, want both commenters", got)
+	}
+}
+
+func TestParseReviewEvents(t *testing.T) {
+	data := json.RawMessage(`{
+		"pr0": {"pullRequest": {
+			"number": 1,
+			"timelineItems": {"nodes": [
+				{"createdAt": "2026-10-01T09:00:00Z", "requestedReviewer": {"login": "octocat"}},
+				{"createdAt": "2026-10-01T09:00:00Z", "requestedReviewer": {}},
+				{"author": {"login": "octocat"}, "submittedAt": null},
+				{"author": {"login": "octocat"}, "submittedAt": "2026-10-02T15:00:00Z"}
+			]}
+		}}
+	}`)
+	prs, err := parsePRResponse(data, []enrichmentItem{{index: 0, isPR: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []model.ReviewEvent{
+		{Kind: model.ReviewEventRequested, Login: "octocat", At: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)},
+		{Kind: model.ReviewEventReviewed, Login: "octocat", At: time.Date(2026, 10, 2, 15, 0, 0, 0, time.UTC)},
+	}
+	got := prs[0].ReviewEvents
+	if len(got) != len(want) {
+		t.Fatalf("ReviewEvents = %v, want %v (team requests and pending reviews skipped)", got, want)
+	}
+	for i := range want {
+		if got[i].Kind != want[i].Kind || got[i].Login != want[i].Login || !got[i].At.Equal(want[i].At) {
+			t.Errorf("ReviewEvents[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseLinkedIssues(t *testing.T) {
+	data := json.RawMessage(`{
+		"pr0": {"pullRequest": {
+			"number": 1,
+			"closingIssuesReferences": {"nodes": [
+				{"number": 12, "repository": {"nameWithOwner": "acme/api"}},
+				{"number": 3, "repository": {"nameWithOwner": "acme/docs"}}
+			]}
+		}}
+	}`)
+	prs, err := parsePRResponse(data, []enrichmentItem{{index: 0, isPR: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"acme/api#12", "acme/docs#3"}
+	if got := prs[0].LinkedIssues; !reflect.DeepEqual(got, want) {
+		t.Errorf("LinkedIssues = %v, want %v", got, want)
+	}
+}
+
+func TestParseRequestedTeams(t *testing.T) {
+	data := json.RawMessage(`{
+		"pr0": {"pullRequest": {
+			"number": 1,
+			"reviewRequests": {"nodes": [
+				{"requestedReviewer": {"login": "octocat"}},
+				{"requestedReviewer": {"name": "Platform", "combinedSlug": "acme/platform"}}
+			]}
+		}}
+	}`)
+	prs, err := parsePRResponse(data, []enrichmentItem{{index: 0, isPR: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := prs[0].RequestedReviewers, []string{"octocat", "Platform"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RequestedReviewers = %v, want %v", got, want)
+	}
+	if got, want := prs[0].RequestedTeams, []string{"acme/platform"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RequestedTeams = %v, want %v", got, want)
+	}
+}
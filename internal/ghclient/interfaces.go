@@ -14,22 +14,43 @@ import (
 type GitHubFetcher interface {
 	// Authentication
 	AuthenticatedUser(ctx context.Context) (string, error)
+	// Whether GitHub has rejected the token since the fetcher was created
+	Unauthorized() bool
 
 	// Notifications
 	ListUnreadNotifications(ctx context.Context, since time.Time) ([]model.Item, error)
 	ListAllNotifications(ctx context.Context, since time.Time) ([]model.Item, error)
+	ListNotifications(ctx context.Context, opts NotificationOptions) ([]model.Item, error)
+	CountNotifications(ctx context.Context, opts NotificationOptions) (int, error)
 
 	// Search operations
 	ListReviewRequestedPRs(ctx context.Context, username string) ([]model.Item, error)
 	ListAuthoredPRs(ctx context.Context, username string) ([]model.Item, error)
 	ListAssignedIssues(ctx context.Context, username string) ([]model.Item, error)
 	ListAssignedPRs(ctx context.Context, username string) ([]model.Item, error)
+	// Searches cut short by GitHub's result cap since the last call
+	TruncatedSearches() []TruncatedSearch
 
 	// Orphaned contributions
 	ListOrphanedContributions(ctx context.Context, opts OrphanedSearchOptions) ([]model.Item, error)
 
+	// Upstream releases, issues, and PRs that work is waiting on
+	ListResolvedUpstreams(ctx context.Context, watches []UpstreamWatch) ([]model.Item, error)
+
 	// GraphQL enrichment (used by Enricher)
-	EnrichItemsGraphQL(ctx context.Context, items []model.Item, token string, onProgress func(completed, total int)) (int, error)
+	EnrichItemsGraphQL(ctx context.Context, items []model.Item, token string, onProgress func(completed, total int)) (EnrichReport, error)
+
+	// Issue and PR states, to tell whether blockers are still open
+	IssueStates(ctx context.Context, refs []string, token string) (map[string]string, error)
+
+	// Teams the user belongs to, as "org/team-slug"
+	UserTeams(ctx context.Context, login string) ([]string, error)
+
+	// Members of a team, as lowercased logins
+	TeamMembers(ctx context.Context, org, slug string) ([]string, error)
+
+	// The CODEOWNERS file on a repository's default branch, empty if none
+	CodeOwners(ctx context.Context, owner, repo string) (string, error)
 
 	// Token access (needed for GraphQL operations)
 	Token() string
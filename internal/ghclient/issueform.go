@@ -0,0 +1,44 @@
+package ghclient
+
+import (
+	"strings"
+)
+
+// maxFormFieldLength is the longest answer kept from an issue form. Longer
+// answers, like multi-line ones, are free text rather than triage data.
+const maxFormFieldLength = 100
+
+// noResponse is what GitHub renders for an issue form field left empty.
+const noResponse = "_No response_"
+
+// formFields returns the answers in the body of an issue created from an
+// issue form, which GitHub renders as a "### Label" heading followed by the
+// answer. Only short, single-line answers are kept, such as those to
+// dropdowns and inputs.
+func formFields(body string) map[string]string {
+	var fields map[string]string
+	var label string
+	var answer []string
+	flush := func() {
+		value := strings.TrimSpace(strings.Join(answer, "\n"))
+		if label == "" || value == "" || value == noResponse ||
+			strings.Contains(value, "\n") || len(value) > maxFormFieldLength {
+			return
+		}
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		fields[label] = value
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if heading, ok := strings.CutPrefix(line, "### "); ok {
+			flush()
+			label, answer = strings.TrimSpace(heading), nil
+			continue
+		}
+		answer = append(answer, line)
+	}
+	flush()
+	return fields
+}
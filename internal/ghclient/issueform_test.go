@@ -0,0 +1,38 @@
+package ghclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormFields(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want map[string]string
+	}{
+		{
+			name: "issue form",
+			body: "### Version\r\n\r\nv1.4.2\r\n\r\n### Severity\r\n\r\nHigh\r\n\r\n### What happened?\r\n\r\nIt crashed.\r\nTwice.\r\n\r\n### Logs\r\n\r\n_No response_\r\n",
+			want: map[string]string{"Version": "v1.4.2", "Severity": "High"},
+		},
+		{
+			name: "free text",
+			body: "The export fails when the name has a slash.",
+			want: nil,
+		},
+		{
+			name: "long answer",
+			body: "### Summary\n\n" + string(make([]byte, maxFormFieldLength+1)),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formFields(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("formFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
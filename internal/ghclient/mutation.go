@@ -0,0 +1,51 @@
+package ghclient
+
+import (
+	"fmt"
+
+	"github.com/spiffcs/triage/internal/audit"
+	"github.com/spiffcs/triage/internal/log"
+)
+
+// WithAuditLog records every mutating API call in l.
+func WithAuditLog(l *audit.Log) ClientOption {
+	return func(c *Client) {
+		c.auditLog = l
+	}
+}
+
+// mutate runs fn, a call that changes state on GitHub. Every mutating
+// method must go through mutate so dry-run mode and the audit log apply
+// uniformly. action and target describe the call (e.g. "comment on",
+// "owner/repo#42"); detail is optional extra context.
+func (c *Client) mutate(action, target, detail string, fn func() error) error {
+	description := action + " " + target
+	if detail != "" {
+		description += " (" + detail + ")"
+	}
+
+	if c.dryRun {
+		log.Info("dry run: skipping mutation", "action", action, "target", target)
+		return fmt.Errorf("%w: would %s", ErrDryRun, description)
+	}
+
+	err := fn()
+
+	if c.auditLog != nil {
+		entry := audit.Entry{
+			Action:  action,
+			Target:  target,
+			Detail:  detail,
+			Outcome: audit.OutcomeSuccess,
+		}
+		if err != nil {
+			entry.Outcome = audit.OutcomeFailure
+			entry.Error = err.Error()
+		}
+		if recordErr := c.auditLog.Record(entry); recordErr != nil {
+			log.Warn("failed to record audit entry", "action", description, "error", recordErr)
+		}
+	}
+
+	return err
+}
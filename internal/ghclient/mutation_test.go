@@ -0,0 +1,66 @@
+package ghclient
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/audit"
+)
+
+func TestMutateRecordsAuditEntries(t *testing.T) {
+	l, err := audit.NewLogFromPath(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{}
+	WithAuditLog(l)(c)
+
+	if err := c.mutate("comment on", "owner/repo#1", "3 characters", func() error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	failErr := errors.New("forbidden")
+	if err := c.mutate("mark as read", "notification 2", "", func() error { return failErr }); !errors.Is(err, failErr) {
+		t.Fatalf("expected mutation error to be returned, got %v", err)
+	}
+
+	entries, err := l.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(entries))
+	}
+	if entries[0].Outcome != audit.OutcomeSuccess || entries[0].Target != "owner/repo#1" || entries[0].Detail != "3 characters" {
+		t.Errorf("unexpected success entry: %+v", entries[0])
+	}
+	if entries[1].Outcome != audit.OutcomeFailure || entries[1].Error != "forbidden" {
+		t.Errorf("unexpected failure entry: %+v", entries[1])
+	}
+}
+
+func TestMutateDryRunSkipsCallAndAudit(t *testing.T) {
+	l, err := audit.NewLogFromPath(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{}
+	WithDryRun(true)(c)
+	WithAuditLog(l)(c)
+
+	called := false
+	err = c.mutate("comment on", "owner/repo#1", "", func() error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("expected ErrDryRun, got %v", err)
+	}
+	if called {
+		t.Error("mutation should not run in dry-run mode")
+	}
+	entries, _ := l.Entries()
+	if len(entries) != 0 {
+		t.Errorf("dry runs should not be audited, got %d entries", len(entries))
+	}
+}
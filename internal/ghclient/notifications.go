@@ -3,12 +3,15 @@ package ghclient
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	gh "github.com/google/go-github/v57/github"
+	"github.com/google/go-querystring/query"
 	"github.com/spiffcs/triage/internal/model"
+	"golang.org/x/sync/errgroup"
 )
 
 // NotificationOptions configures notification fetching
@@ -16,30 +19,159 @@ type NotificationOptions struct {
 	All           bool                // Include read notifications
 	Since         time.Time           // Only notifications updated after this time
 	Participating bool                // Only participating notifications
+	Repo          string              // List one repo's notifications (owner/repo) through the repository endpoint
 	Repos         []string            // Filter to specific repos (owner/repo format)
 	Types         []model.SubjectType // Filter to specific subject types
 }
 
-// ListNotifications fetches notifications with optional filtering.
-// Uses parallel fetching when multiple pages are detected.
-func (c *Client) ListNotifications(ctx context.Context, opts NotificationOptions) ([]model.Item, error) {
+// maxConcurrentPages bounds how many notification pages are fetched at
+// once. A long --since can span hundreds of pages; fetching them a few at a
+// time keeps the request burst and the raw pages held in memory small.
+const maxConcurrentPages = 4
+
+// PageProgressFunc is told how many pages of a notification listing have
+// been fetched so far, out of total (0 when the total is unknown).
+type PageProgressFunc func(fetched, total int)
+
+// pageProgressKey carries a PageProgressFunc in a request context.
+type pageProgressKey struct{}
+
+// WithPageProgress returns a context whose notification listings report
+// their page progress to fn.
+func WithPageProgress(ctx context.Context, fn PageProgressFunc) context.Context {
+	return context.WithValue(ctx, pageProgressKey{}, fn)
+}
+
+// pageProgress returns the PageProgressFunc in ctx, or a no-op.
+func pageProgress(ctx context.Context) PageProgressFunc {
+	if fn, ok := ctx.Value(pageProgressKey{}).(PageProgressFunc); ok && fn != nil {
+		return fn
+	}
+	return func(int, int) {}
+}
+
+// Conditional makes a notification listing a conditional request, so a
+// poll that finds nothing new costs no rate limit. Set it from the last
+// listing's validators: If-Modified-Since is sent with LastModified, and
+// If-None-Match with ETag when the listing asks for the same since as the
+// one the ETag was returned for. When GitHub answers 304 Not Modified the
+// listing returns no items and NotModified is set; otherwise the fields
+// are replaced with the validators of the new listing.
+type Conditional struct {
+	ETag         string
+	LastModified string
+	Since        time.Time // The since the ETag was returned for
+	NotModified  bool
+}
+
+// conditionalKey carries a *Conditional in a request context.
+type conditionalKey struct{}
+
+// WithConditional returns a context whose notification listings are
+// conditional on cond, and record the response in it.
+func WithConditional(ctx context.Context, cond *Conditional) context.Context {
+	return context.WithValue(ctx, conditionalKey{}, cond)
+}
+
+// conditional returns the *Conditional in ctx, or nil.
+func conditional(ctx context.Context) *Conditional {
+	cond, _ := ctx.Value(conditionalKey{}).(*Conditional)
+	return cond
+}
+
+// notificationListOptions builds the REST options for one page of a listing.
+func notificationListOptions(opts NotificationOptions, page int) *gh.NotificationListOptions {
 	listOpts := &gh.NotificationListOptions{
 		All:           opts.All,
 		Participating: opts.Participating,
 		ListOptions: gh.ListOptions{
-			PerPage: 100,
+			PerPage: restPageSize,
+			Page:    page,
 		},
 	}
-
 	if !opts.Since.IsZero() {
 		listOpts.Since = opts.Since
 	}
+	return listOpts
+}
+
+// listNotificationPage fetches one page of notifications, through the
+// repository endpoint when opts.Repo is set.
+func (c *Client) listNotificationPage(ctx context.Context, opts NotificationOptions, listOpts *gh.NotificationListOptions) ([]*gh.Notification, *gh.Response, error) {
+	if opts.Repo == "" {
+		return c.client.Activity.ListNotifications(ctx, listOpts)
+	}
+	owner, repo, ok := strings.Cut(opts.Repo, "/")
+	if !ok || owner == "" || repo == "" {
+		return nil, nil, fmt.Errorf("invalid repository %q, want owner/repo", opts.Repo)
+	}
+	return c.client.Activity.ListRepositoryNotifications(ctx, owner, repo, listOpts)
+}
+
+// firstNotificationPage fetches the first page of a listing, as a
+// conditional request when ctx carries validators for it. Only the first
+// page is conditional: GitHub reports one Last-Modified for the listing.
+func (c *Client) firstNotificationPage(ctx context.Context, opts NotificationOptions) ([]*gh.Notification, *gh.Response, error) {
+	listOpts := notificationListOptions(opts, 0)
+	cond := conditional(ctx)
+	if cond == nil || (cond.ETag == "" && cond.LastModified == "") {
+		return c.listNotificationPage(ctx, opts, listOpts)
+	}
+
+	path := "notifications"
+	if opts.Repo != "" {
+		owner, repo, ok := strings.Cut(opts.Repo, "/")
+		if !ok || owner == "" || repo == "" {
+			return nil, nil, fmt.Errorf("invalid repository %q, want owner/repo", opts.Repo)
+		}
+		path = fmt.Sprintf("repos/%s/%s/notifications", owner, repo)
+	}
+	// The same query go-github would send, so the listing is unchanged
+	values, err := query.Values(listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := c.client.NewRequest(http.MethodGet, path+"?"+values.Encode(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cond.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cond.LastModified)
+	}
+	// An ETag only matches the URL it came from
+	if cond.ETag != "" && cond.Since.Equal(opts.Since) {
+		req.Header.Set("If-None-Match", cond.ETag)
+	}
+
+	var notifications []*gh.Notification
+	resp, err := c.client.Do(ctx, req, &notifications)
+	return notifications, resp, err
+}
+
+// ListNotifications fetches notifications with optional filtering.
+// Once the first page reveals how many there are, the rest are fetched in
+// parallel, at most maxConcurrentPages at a time. See Conditional for
+// polling without spending rate limit.
+func (c *Client) ListNotifications(ctx context.Context, opts NotificationOptions) ([]model.Item, error) {
+	onPage := pageProgress(ctx)
 
 	// Fetch first page to get pagination info
-	notifications, resp, err := c.client.Activity.ListNotifications(ctx, listOpts)
+	notifications, resp, err := c.firstNotificationPage(ctx, opts)
+	cond := conditional(ctx)
+	if cond != nil && resp != nil && resp.StatusCode == http.StatusNotModified {
+		cond.NotModified = true
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to list notifications: %w", err)
 	}
+	if cond != nil {
+		*cond = Conditional{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Since:        opts.Since,
+		}
+	}
 
 	// Convert first page results
 	allItems := filterNotifications(notifications, opts)
@@ -55,66 +187,59 @@ func (c *Client) ListNotifications(ctx context.Context, opts NotificationOptions
 		// Fallback to sequential if we can't determine last page
 		return c.listNotificationsSequential(ctx, opts, allItems, resp.NextPage)
 	}
+	onPage(1, lastPage)
 
-	// Fetch remaining pages in parallel
-	type pageResult struct {
-		page  int
-		items []model.Item
-		err   error
-	}
-
-	numPages := lastPage - 1 // pages 2 through lastPage
-	results := make(chan pageResult, numPages)
+	// Fetch remaining pages, keeping each page's items in page order
+	pages := make([][]model.Item, lastPage+1)
+	var mu sync.Mutex
+	fetched := 1
 
-	var wg sync.WaitGroup
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentPages)
 	for page := 2; page <= lastPage; page++ {
-		wg.Add(1)
-		go func(p int) {
-			defer wg.Done()
-			pageOpts := &gh.NotificationListOptions{
-				All:           opts.All,
-				Participating: opts.Participating,
-				ListOptions: gh.ListOptions{
-					PerPage: 100,
-					Page:    p,
-				},
-			}
-			if !opts.Since.IsZero() {
-				pageOpts.Since = opts.Since
-			}
-
-			notifs, _, err := c.client.Activity.ListNotifications(ctx, pageOpts)
+		g.Go(func() error {
+			notifs, _, err := c.listNotificationPage(gctx, opts, notificationListOptions(opts, page))
 			if err != nil {
-				results <- pageResult{page: p, err: err}
-				return
+				return fmt.Errorf("failed to list items page %d: %w", page, err)
 			}
-			results <- pageResult{page: p, items: filterNotifications(notifs, opts)}
-		}(page)
+			items := filterNotifications(notifs, opts)
+
+			mu.Lock()
+			defer mu.Unlock()
+			pages[page] = items
+			fetched++
+			onPage(fetched, lastPage)
+			return nil
+		})
 	}
-
-	// Close results channel when all goroutines complete
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	// Collect results
-	pageResults := make([]pageResult, 0, numPages)
-	for result := range results {
-		if result.err != nil {
-			return nil, fmt.Errorf("failed to list items page %d: %w", result.page, result.err)
-		}
-		pageResults = append(pageResults, result)
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
-	// Combine all results (order doesn't matter for items)
-	for _, pr := range pageResults {
-		allItems = append(allItems, pr.items...)
+	for _, items := range pages[2:] {
+		allItems = append(allItems, items...)
 	}
-
 	return allItems, nil
 }
 
+// CountNotifications returns about how many notifications a listing with
+// opts would return, using a single one-item request. The count includes
+// notification types triage ignores, so it is an upper bound.
+func (c *Client) CountNotifications(ctx context.Context, opts NotificationOptions) (int, error) {
+	listOpts := notificationListOptions(opts, 0)
+	listOpts.PerPage = 1
+
+	notifications, resp, err := c.listNotificationPage(ctx, opts, listOpts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	// With one notification per page, the last page number is the count
+	if resp.LastPage > 0 {
+		return resp.LastPage, nil
+	}
+	return len(notifications), nil
+}
+
 // filterNotifications applies type and repo filters to raw notifications
 func filterNotifications(notifications []*gh.Notification, opts NotificationOptions) []model.Item {
 	var result []model.Item
@@ -139,27 +264,17 @@ func filterNotifications(notifications []*gh.Notification, opts NotificationOpti
 // listNotificationsSequential fetches remaining pages sequentially (fallback)
 func (c *Client) listNotificationsSequential(ctx context.Context, opts NotificationOptions, existing []model.Item, startPage int) ([]model.Item, error) {
 	allItems := existing
-
-	listOpts := &gh.NotificationListOptions{
-		All:           opts.All,
-		Participating: opts.Participating,
-		ListOptions: gh.ListOptions{
-			PerPage: 100,
-			Page:    startPage,
-		},
-	}
-
-	if !opts.Since.IsZero() {
-		listOpts.Since = opts.Since
-	}
+	onPage := pageProgress(ctx)
+	listOpts := notificationListOptions(opts, startPage)
 
 	for {
-		notifications, resp, err := c.client.Activity.ListNotifications(ctx, listOpts)
+		notifications, resp, err := c.listNotificationPage(ctx, opts, listOpts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list items: %w", err)
 		}
 
 		allItems = append(allItems, filterNotifications(notifications, opts)...)
+		onPage(listOpts.Page, 0)
 
 		if resp.NextPage == 0 {
 			break
@@ -190,11 +305,13 @@ func (c *Client) ListAllNotifications(ctx context.Context, since time.Time) ([]m
 
 // MarkAsRead marks a notification as read
 func (c *Client) MarkAsRead(ctx context.Context, notificationID string) error {
-	_, err := c.client.Activity.MarkThreadRead(ctx, notificationID)
-	if err != nil {
-		return fmt.Errorf("failed to mark notification as read: %w", err)
-	}
-	return nil
+	return c.mutate("mark as read", "notification "+notificationID, "", func() error {
+		_, err := c.client.Activity.MarkThreadRead(ctx, notificationID)
+		if err != nil {
+			return fmt.Errorf("failed to mark notification as read: %w", err)
+		}
+		return nil
+	})
 }
 
 // convertNotification converts a GitHub API notification to our model type
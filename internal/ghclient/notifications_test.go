@@ -0,0 +1,214 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+// notificationServer serves total notifications, one per page regardless of
+// per_page, with the Link headers GitHub sends. It records the most pages
+// requested at once, returned by the second result.
+func notificationServer(t *testing.T, total int) (*Client, func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		// Give concurrent requests time to overlap
+		time.Sleep(5 * time.Millisecond)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		if page < total {
+			next, last := *r.URL, *r.URL
+			q := r.URL.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = q.Encode()
+			q.Set("page", strconv.Itoa(total))
+			last.RawQuery = q.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, next.String(), last.String()))
+		}
+		fmt.Fprintf(w, `[{"id":"%d","subject":{"type":"Issue","title":"page %d"},"repository":{"full_name":"acme/api"}}]`, page, page)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return &Client{client: client}, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return peak
+	}
+}
+
+func TestListNotificationsPages(t *testing.T) {
+	c, peak := notificationServer(t, 12)
+
+	var progress [][2]int
+	ctx := WithPageProgress(context.Background(), func(fetched, total int) {
+		progress = append(progress, [2]int{fetched, total})
+	})
+	items, err := c.ListUnreadNotifications(ctx, time.Time{})
+	if err != nil {
+		t.Fatalf("ListUnreadNotifications() error = %v", err)
+	}
+
+	if len(items) != 12 {
+		t.Fatalf("got %d items, want 12", len(items))
+	}
+	for i, item := range items {
+		if want := strconv.Itoa(i + 1); item.ID != want {
+			t.Errorf("items[%d].ID = %s, want pages in order", i, item.ID)
+		}
+	}
+	if got := peak(); got > maxConcurrentPages {
+		t.Errorf("fetched %d pages at once, want at most %d", got, maxConcurrentPages)
+	}
+	if len(progress) != 12 || progress[0] != [2]int{1, 12} || progress[11] != [2]int{12, 12} {
+		t.Errorf("progress = %v, want 1/12 through 12/12", progress)
+	}
+}
+
+func TestCountNotifications(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+	}{
+		{"many", 4800},
+		{"one", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := notificationServer(t, tt.total)
+			got, err := c.CountNotifications(context.Background(), NotificationOptions{Since: time.Now().Add(-time.Hour)})
+			if err != nil {
+				t.Fatalf("CountNotifications() error = %v", err)
+			}
+			if got != tt.total {
+				t.Errorf("CountNotifications() = %d, want %d", got, tt.total)
+			}
+		})
+	}
+}
+
+func TestListNotificationsScope(t *testing.T) {
+	var paths, participating []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		participating = append(participating, r.URL.Query().Get("participating"))
+		mu.Unlock()
+		fmt.Fprint(w, `[{"id":"1","subject":{"type":"Issue","title":"t"},"repository":{"full_name":"acme/api"}}]`)
+	}))
+	t.Cleanup(srv.Close)
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{client: client}
+
+	tests := []struct {
+		name              string
+		opts              NotificationOptions
+		wantPath          string
+		wantParticipating string
+	}{
+		{"all repos", NotificationOptions{}, "/notifications", ""},
+		{"participating", NotificationOptions{Participating: true}, "/notifications", "true"},
+		{"one repo", NotificationOptions{Repo: "acme/api"}, "/repos/acme/api/notifications", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, participating = nil, nil
+			items, err := c.ListNotifications(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("ListNotifications() error = %v", err)
+			}
+			if len(items) != 1 {
+				t.Errorf("got %d items, want 1", len(items))
+			}
+			if len(paths) != 1 || paths[0] != tt.wantPath || participating[0] != tt.wantParticipating {
+				t.Errorf("requested %v (participating=%v), want %s (participating=%q)", paths, participating, tt.wantPath, tt.wantParticipating)
+			}
+		})
+	}
+
+	if _, err := c.ListNotifications(context.Background(), NotificationOptions{Repo: "acme"}); err == nil {
+		t.Error("ListNotifications() with repo \"acme\" succeeded, want an error")
+	}
+}
+
+func TestListNotificationsConditional(t *testing.T) {
+	const lastModified = "Mon, 02 Mar 2026 10:00:00 GMT"
+	var mu sync.Mutex
+	var ifModifiedSince, ifNoneMatch []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ifModifiedSince = append(ifModifiedSince, r.Header.Get("If-Modified-Since"))
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		mu.Unlock()
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		w.Header().Set("ETag", `"abc"`)
+		fmt.Fprint(w, `[{"id":"1","subject":{"type":"Issue","title":"t"},"repository":{"full_name":"acme/api"}}]`)
+	}))
+	t.Cleanup(srv.Close)
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{client: client}
+
+	since := time.Now().Add(-time.Hour).Truncate(time.Second)
+	opts := NotificationOptions{Since: since, Repo: "acme/api"}
+
+	// The first listing has no validators to send, and records GitHub's
+	cond := &Conditional{}
+	items, err := c.ListNotifications(WithConditional(context.Background(), cond), opts)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("ListNotifications() = %d items, %v; want 1 item", len(items), err)
+	}
+	if want := (Conditional{ETag: `"abc"`, LastModified: lastModified, Since: since}); *cond != want {
+		t.Errorf("Conditional after listing = %+v, want %+v", *cond, want)
+	}
+
+	// Polling again with them is answered 304 Not Modified
+	items, err = c.ListNotifications(WithConditional(context.Background(), cond), opts)
+	if err != nil || len(items) != 0 || !cond.NotModified {
+		t.Errorf("ListNotifications() = %d items, %v, NotModified %v; want no items, not modified", len(items), err, cond.NotModified)
+	}
+
+	// The ETag is only sent for the since it was returned for
+	cond = &Conditional{ETag: `"abc"`, LastModified: lastModified, Since: since}
+	later := opts
+	later.Since = since.Add(time.Minute)
+	if _, err := c.ListNotifications(WithConditional(context.Background(), cond), later); err != nil {
+		t.Fatal(err)
+	}
+
+	wantIMS := []string{"", lastModified, lastModified}
+	wantINM := []string{"", `"abc"`, ""}
+	if !slices.Equal(ifModifiedSince, wantIMS) || !slices.Equal(ifNoneMatch, wantINM) {
+		t.Errorf("If-Modified-Since = %q, If-None-Match = %q; want %q and %q", ifModifiedSince, ifNoneMatch, wantIMS, wantINM)
+	}
+}
@@ -2,8 +2,11 @@ package ghclient
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +22,111 @@ type OrphanedSearchOptions struct {
 	StaleDays                 int
 	ConsecutiveAuthorComments int
 	MaxPerRepo                int
+	// Rules are the conditions for each repository, keyed by lowercased
+	// "owner/repo"; repositories not listed use DefaultOrphanedRules.
+	Rules map[string]OrphanedRules
+	// TriageLabels mark a contribution as triaged for NoTriageLabel.
+	TriageLabels []string
+	// TeamLogins are users counted as the team besides the repository's
+	// members, owners, and collaborators.
+	TeamLogins []string
+}
+
+// OrphanedRules picks the conditions that make a contribution orphaned. A
+// contribution is orphaned when it meets every enabled condition.
+type OrphanedRules struct {
+	// ExternalAuthor requires the author to be outside the team.
+	ExternalAuthor bool
+	// NoTeamResponse requires the team to have been quiet for StaleDays, or
+	// the author to have left ConsecutiveAuthorComments unanswered.
+	NoTeamResponse bool
+	// NoAssignee requires nobody to be assigned.
+	NoAssignee bool
+	// NoTriageLabel requires none of TriageLabels, or no labels at all when
+	// TriageLabels is empty.
+	NoTriageLabel bool
+}
+
+// DefaultOrphanedRules flags external contributions the team hasn't
+// responded to.
+var DefaultOrphanedRules = OrphanedRules{ExternalAuthor: true, NoTeamResponse: true}
+
+// rulesFor returns the conditions for repo ("owner/repo").
+func (o OrphanedSearchOptions) rulesFor(repo string) OrphanedRules {
+	if rules, ok := o.Rules[strings.ToLower(repo)]; ok {
+		return rules
+	}
+	return DefaultOrphanedRules
+}
+
+// Settings returns a fingerprint of everything besides the repositories
+// and since that decides what counts as orphaned, so a cached list can be
+// told apart from one found under different rules or a different team.
+func (o OrphanedSearchOptions) Settings() string {
+	team := slices.Clone(o.TeamLogins)
+	for i := range team {
+		team[i] = strings.ToLower(team[i])
+	}
+	slices.Sort(team)
+	data, _ := json.Marshal(struct {
+		StaleDays                 int
+		ConsecutiveAuthorComments int
+		MaxPerRepo                int
+		Rules                     map[string]OrphanedRules
+		TriageLabels              []string
+		TeamLogins                []string
+	}{o.StaleDays, o.ConsecutiveAuthorComments, o.MaxPerRepo, o.Rules, o.TriageLabels, slices.Compact(team)})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// orphanedFacts are what the rules look at for one contribution.
+type orphanedFacts struct {
+	external   bool
+	noResponse bool
+	assigned   bool
+	triaged    bool
+}
+
+// matches reports whether f meets every condition r enables.
+func (r OrphanedRules) matches(f orphanedFacts) bool {
+	return (!r.ExternalAuthor || f.external) &&
+		(!r.NoTeamResponse || f.noResponse) &&
+		(!r.NoAssignee || !f.assigned) &&
+		(!r.NoTriageLabel || !f.triaged)
+}
+
+// orphanedTeam holds the logins, lowercased, counted as the team besides
+// the repository's members, owners, and collaborators.
+type orphanedTeam map[string]bool
+
+func newOrphanedTeam(logins []string) orphanedTeam {
+	team := make(orphanedTeam, len(logins))
+	for _, login := range logins {
+		team[strings.ToLower(login)] = true
+	}
+	return team
+}
+
+// has reports whether the actor with association is on the team.
+func (t orphanedTeam) has(actor *actorRef, association string) bool {
+	return model.IsTeamMember(association) || (actor != nil && t[strings.ToLower(actor.Login)])
+}
+
+// triaged reports whether labels include one of triageLabels, or any label
+// when triageLabels is empty.
+func triaged(labels, triageLabels []string) bool {
+	if len(triageLabels) == 0 {
+		return len(labels) > 0
+	}
+	for _, label := range labels {
+		for _, t := range triageLabels {
+			if strings.EqualFold(label, t) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Default values for orphaned contribution detection
@@ -118,16 +226,24 @@ func (c *Client) ListOrphanedContributions(ctx context.Context, opts OrphanedSea
 		allItems = append(allItems, items...)
 	}
 
+	if c.Unauthorized() {
+		return allItems, ErrUnauthorized
+	}
 	return allItems, nil
 }
 
 // fetchOrphanedForRepo fetches orphaned contributions for a single repository
 func (c *Client) fetchOrphanedForRepo(ctx context.Context, owner, repo string, opts OrphanedSearchOptions) ([]model.Item, error) {
-	query := c.queries.BuildOrphanedQuery(owner, repo)
-	respData, err := c.executeGraphQL(ctx, query, c.token)
+	respData, gqlErrs, err := c.executeForSchema(ctx, c.token, func(gaps schemaGaps) (graphqlQuery, error) {
+		return c.queries.BuildOrphanedQuery(owner, repo, gaps)
+	})
 	if err != nil {
 		return nil, err
 	}
+	if c.sso.recordSAMLFailures(gqlErrs, map[string]string{"repository": owner}) > 0 {
+		log.Debug("skipping orphaned contributions for SSO-protected repo", "repo", owner+"/"+repo)
+		return nil, nil
+	}
 
 	return parseOrphanedResponse(respData, owner, repo, opts)
 }
@@ -228,6 +344,8 @@ func parseOrphanedResponse(data json.RawMessage, owner, repo string, opts Orphan
 	}
 
 	fullName := owner + "/" + repo
+	rules := opts.rulesFor(fullName)
+	team := newOrphanedTeam(opts.TeamLogins)
 	var items []model.Item
 
 	// Process issues
@@ -236,18 +354,8 @@ func parseOrphanedResponse(data json.RawMessage, owner, repo string, opts Orphan
 			continue
 		}
 
-		// Skip if author is a team member
-		if model.IsTeamMember(issue.AuthorAssociation) {
-			continue
-		}
-
 		// Analyze comment pattern
-		lastTeam, consecutive := analyzeComments(issue.Comments.Nodes, issue.Author.Login)
-
-		// Check if orphaned based on criteria
-		if !isOrphaned(issue.UpdatedAt, lastTeam, consecutive, opts) {
-			continue
-		}
+		lastTeam, consecutive := analyzeComments(issue.Comments.Nodes, issue.Author.Login, team)
 
 		// Extract assignee logins
 		var assignees []string
@@ -261,6 +369,16 @@ func parseOrphanedResponse(data json.RawMessage, owner, repo string, opts Orphan
 			labels = append(labels, l.Name)
 		}
 
+		// Check if orphaned based on the repository's rules
+		if !rules.matches(orphanedFacts{
+			external:   !team.has(issue.Author, issue.AuthorAssociation),
+			noResponse: isOrphaned(issue.UpdatedAt, lastTeam, consecutive, opts),
+			assigned:   len(assignees) > 0,
+			triaged:    triaged(labels, opts.TriageLabels),
+		}) {
+			continue
+		}
+
 		items = append(items, model.Item{
 			ID:        fmt.Sprintf("orphaned-%s-%d", fullName, issue.Number),
 			Reason:    model.ReasonOrphaned,
@@ -301,14 +419,9 @@ func parseOrphanedResponse(data json.RawMessage, owner, repo string, opts Orphan
 			continue
 		}
 
-		// Skip if author is a team member
-		if model.IsTeamMember(pr.AuthorAssociation) {
-			continue
-		}
-
 		// Analyze comment pattern and reviews
-		lastTeamComment, consecutive := analyzeComments(pr.Comments.Nodes, pr.Author.Login)
-		lastTeamReview := analyzeReviews(pr.Reviews.Nodes)
+		lastTeamComment, consecutive := analyzeComments(pr.Comments.Nodes, pr.Author.Login, team)
+		lastTeamReview := analyzeReviews(pr.Reviews.Nodes, team)
 
 		// Take the most recent team activity
 		lastTeam := lastTeamComment
@@ -316,14 +429,6 @@ func parseOrphanedResponse(data json.RawMessage, owner, repo string, opts Orphan
 			lastTeam = lastTeamReview
 		}
 
-		// Check if orphaned based on criteria
-		if !isOrphaned(pr.UpdatedAt, lastTeam, consecutive, opts) {
-			continue
-		}
-
-		// Determine review state from reviews
-		reviewState := determineReviewState(pr.Reviews.Nodes, pr.ReviewDecision)
-
 		// Extract assignee logins
 		var assignees []string
 		for _, a := range pr.Assignees.Nodes {
@@ -336,6 +441,19 @@ func parseOrphanedResponse(data json.RawMessage, owner, repo string, opts Orphan
 			labels = append(labels, l.Name)
 		}
 
+		// Check if orphaned based on the repository's rules
+		if !rules.matches(orphanedFacts{
+			external:   !team.has(pr.Author, pr.AuthorAssociation),
+			noResponse: isOrphaned(pr.UpdatedAt, lastTeam, consecutive, opts),
+			assigned:   len(assignees) > 0,
+			triaged:    triaged(labels, opts.TriageLabels),
+		}) {
+			continue
+		}
+
+		// Determine review state from reviews
+		reviewState := determineReviewState(pr.Reviews.Nodes, pr.ReviewDecision)
+
 		items = append(items, model.Item{
 			ID:        fmt.Sprintf("orphaned-%s-%d", fullName, pr.Number),
 			Reason:    model.ReasonOrphaned,
@@ -381,8 +499,9 @@ func parseOrphanedResponse(data json.RawMessage, owner, repo string, opts Orphan
 // analyzeComments analyzes the comment pattern to find last team activity
 // and count consecutive comments from the original author.
 // Bot comments (logins ending in [bot]) are skipped so automated replies
-// don't break the consecutive-author-comment count.
-func analyzeComments(comments []commentNode, originalAuthor string) (*time.Time, int) {
+// don't break the consecutive-author-comment count. The author's own
+// comments are never team activity, even when they are on the team.
+func analyzeComments(comments []commentNode, originalAuthor string, team orphanedTeam) (*time.Time, int) {
 	var lastTeamActivity *time.Time
 	consecutiveAuthor := 0
 	foundNonAuthor := false
@@ -399,7 +518,7 @@ func analyzeComments(comments []commentNode, originalAuthor string) (*time.Time,
 			continue
 		}
 
-		isTeam := model.IsTeamMember(c.AuthorAssociation)
+		isTeam := c.Author.Login != originalAuthor && team.has(c.Author, c.AuthorAssociation)
 
 		// Track last team activity
 		if isTeam {
@@ -423,7 +542,7 @@ func analyzeComments(comments []commentNode, originalAuthor string) (*time.Time,
 }
 
 // analyzeReviews finds the most recent team review
-func analyzeReviews(reviews []reviewNode) *time.Time {
+func analyzeReviews(reviews []reviewNode, team orphanedTeam) *time.Time {
 	var lastTeamReview *time.Time
 
 	for _, r := range reviews {
@@ -431,7 +550,7 @@ func analyzeReviews(reviews []reviewNode) *time.Time {
 			continue
 		}
 
-		if model.IsTeamMember(r.AuthorAssociation) {
+		if team.has(r.Author, r.AuthorAssociation) {
 			if lastTeamReview == nil || r.SubmittedAt.After(*lastTeamReview) {
 				t := r.SubmittedAt
 				lastTeamReview = &t
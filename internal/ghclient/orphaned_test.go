@@ -1,6 +1,8 @@
 package ghclient
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -15,7 +17,7 @@ func TestAnalyzeComments_SkipsBots(t *testing.T) {
 
 	// Without bot skipping, the consecutive count would be 1 (only the last comment).
 	// With bot skipping, codecov[bot] is ignored so both contributor comments are consecutive.
-	_, consecutive := analyzeComments(comments, "contributor")
+	_, consecutive := analyzeComments(comments, "contributor", nil)
 	if consecutive != 2 {
 		t.Errorf("analyzeComments() consecutive = %d, want 2 (bot should be skipped)", consecutive)
 	}
@@ -28,7 +30,7 @@ func TestAnalyzeComments_BotDoesNotCountAsTeam(t *testing.T) {
 		{Author: &actorRef{Login: "dependabot[bot]"}, AuthorAssociation: "NONE", CreatedAt: now.Add(-1 * time.Hour)},
 	}
 
-	lastTeam, _ := analyzeComments(comments, "contributor")
+	lastTeam, _ := analyzeComments(comments, "contributor", nil)
 	if lastTeam != nil {
 		t.Error("bot comment should not count as team activity")
 	}
@@ -42,7 +44,7 @@ func TestAnalyzeComments_TeamBreaksConsecutive(t *testing.T) {
 		{Author: &actorRef{Login: "contributor"}, AuthorAssociation: "NONE", CreatedAt: now.Add(-1 * time.Hour)},
 	}
 
-	lastTeam, consecutive := analyzeComments(comments, "contributor")
+	lastTeam, consecutive := analyzeComments(comments, "contributor", nil)
 	if consecutive != 1 {
 		t.Errorf("analyzeComments() consecutive = %d, want 1 (team comment should break streak)", consecutive)
 	}
@@ -59,7 +61,7 @@ func TestAnalyzeComments_AllBots(t *testing.T) {
 		{Author: &actorRef{Login: "codecov[bot]"}, AuthorAssociation: "NONE", CreatedAt: now.Add(-1 * time.Hour)},
 	}
 
-	_, consecutive := analyzeComments(comments, "contributor")
+	_, consecutive := analyzeComments(comments, "contributor", nil)
 	if consecutive != 1 {
 		t.Errorf("analyzeComments() consecutive = %d, want 1", consecutive)
 	}
@@ -70,3 +72,100 @@ func TestDefaultConsecutiveAuthorComments(t *testing.T) {
 		t.Errorf("defaultConsecutiveAuthorComments = %d, want 3", defaultConsecutiveAuthorComments)
 	}
 }
+
+func TestAnalyzeComments_TeamLogins(t *testing.T) {
+	now := time.Now()
+	comments := []commentNode{
+		{Author: &actorRef{Login: "contributor"}, AuthorAssociation: "NONE", CreatedAt: now.Add(-2 * time.Hour)},
+		{Author: &actorRef{Login: "Helper"}, AuthorAssociation: "CONTRIBUTOR", CreatedAt: now.Add(-1 * time.Hour)},
+	}
+
+	if lastTeam, _ := analyzeComments(comments, "contributor", nil); lastTeam != nil {
+		t.Errorf("analyzeComments() lastTeam = %v, want nil without a team list", lastTeam)
+	}
+	if lastTeam, _ := analyzeComments(comments, "contributor", newOrphanedTeam([]string{"helper"})); lastTeam == nil {
+		t.Error("analyzeComments() lastTeam = nil, want the listed team member's comment")
+	}
+}
+
+func TestParseOrphanedResponseRules(t *testing.T) {
+	old := time.Now().Add(-30 * 24 * time.Hour).Format(time.RFC3339)
+	recent := time.Now().Format(time.RFC3339)
+	data := json.RawMessage(`{"repository": {"issues": {"nodes": [
+		{"number": 1, "updatedAt": "` + old + `", "author": {"login": "outsider"}, "authorAssociation": "NONE"},
+		{"number": 2, "updatedAt": "` + old + `", "author": {"login": "member"}, "authorAssociation": "MEMBER"},
+		{"number": 3, "updatedAt": "` + old + `", "author": {"login": "outsider"}, "authorAssociation": "NONE",
+		 "assignees": {"nodes": [{"login": "member"}]}},
+		{"number": 4, "updatedAt": "` + old + `", "author": {"login": "outsider"}, "authorAssociation": "NONE",
+		 "labels": {"nodes": [{"name": "Triaged"}]}},
+		{"number": 5, "updatedAt": "` + recent + `", "author": {"login": "outsider"}, "authorAssociation": "NONE"},
+		{"number": 6, "updatedAt": "` + old + `", "author": {"login": "friend"}, "authorAssociation": "CONTRIBUTOR"}
+	]}, "pullRequests": {"nodes": []}}}`)
+
+	tests := []struct {
+		name  string
+		rules OrphanedRules
+		want  []int
+	}{
+		{"defaults", DefaultOrphanedRules, []int{1, 3, 4}},
+		{"unassigned", OrphanedRules{ExternalAuthor: true, NoTeamResponse: true, NoAssignee: true}, []int{1, 4}},
+		{"untriaged", OrphanedRules{ExternalAuthor: true, NoTeamResponse: true, NoTriageLabel: true}, []int{1, 3}},
+		{"any author", OrphanedRules{NoTeamResponse: true}, []int{1, 2, 3, 4, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := OrphanedSearchOptions{
+				StaleDays:                 7,
+				ConsecutiveAuthorComments: 3,
+				Rules:                     map[string]OrphanedRules{"acme/api": tt.rules},
+				TriageLabels:              []string{"triaged"},
+				TeamLogins:                []string{"Friend"},
+			}
+			items, err := parseOrphanedResponse(data, "acme", "api", opts)
+			if err != nil {
+				t.Fatalf("parseOrphanedResponse() error = %v", err)
+			}
+			var got []int
+			for _, item := range items {
+				got = append(got, item.Number)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("orphaned = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrphanedSearchOptionsSettings(t *testing.T) {
+	base := OrphanedSearchOptions{
+		Repos:        []string{"acme/api"},
+		StaleDays:    7,
+		TriageLabels: []string{"triaged"},
+		TeamLogins:   []string{"alice", "bob"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(o *OrphanedSearchOptions)
+		same   bool
+	}{
+		{"other repos and since", func(o *OrphanedSearchOptions) { o.Repos = nil; o.Since = time.Now() }, true},
+		{"team in another order", func(o *OrphanedSearchOptions) { o.TeamLogins = []string{"Bob", "alice"} }, true},
+		{"stale days", func(o *OrphanedSearchOptions) { o.StaleDays = 14 }, false},
+		{"rules", func(o *OrphanedSearchOptions) {
+			o.Rules = map[string]OrphanedRules{"acme/api": {NoAssignee: true}}
+		}, false},
+		{"triage labels", func(o *OrphanedSearchOptions) { o.TriageLabels = []string{"accepted"} }, false},
+		{"team", func(o *OrphanedSearchOptions) { o.TeamLogins = []string{"alice"} }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := base
+			tt.modify(&o)
+			if got := o.Settings() == base.Settings(); got != tt.same {
+				t.Errorf("Settings() unchanged = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
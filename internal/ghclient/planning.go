@@ -0,0 +1,110 @@
+package ghclient
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// projectFields are the optional fields holding the project boards an item
+// is on. Reading them needs the read:project scope, which GitHub otherwise
+// fails the whole query for, so they are only fetched when asked for (see
+// WithProjectStatus) and dropped once GitHub refuses them (see
+// executeForSchema).
+var projectFields = []string{"PullRequest.projectItems", "Issue.projectItems"}
+
+// WithProjectStatus fetches the project boards each issue and PR is on and
+// its Status there, which needs the read:project scope.
+func WithProjectStatus(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.projectStatus = enabled
+	}
+}
+
+// queryGaps returns the fields to leave out of enrichment queries: those
+// the server lacks, and the project fields unless they were asked for and
+// the token may read them.
+func (c *Client) queryGaps(gaps schemaGaps) schemaGaps {
+	if c.projectStatus && !c.schema.projectsDenied.Load() {
+		return gaps
+	}
+	leaveOut := make(schemaGaps, len(gaps)+len(projectFields))
+	for field := range gaps {
+		leaveOut[field] = true
+	}
+	for _, field := range projectFields {
+		leaveOut[field] = true
+	}
+	return leaveOut
+}
+
+// denyProjects stops selecting the project fields after GitHub rejected a
+// query for them, reporting whether this call did so. The warning is
+// logged once per client.
+func (c *Client) denyProjects() bool {
+	if c.schema.projectsDenied.Swap(true) {
+		return false
+	}
+	log.Warn("token lacks the read:project scope; project board scores are skipped", "hint", "add read:project to the token or remove project_column_scores")
+	return true
+}
+
+// hasProjectScopeError reports whether GitHub rejected a query because
+// the token lacks the read:project scope.
+func hasProjectScopeError(errs []graphqlError) bool {
+	for _, e := range errs {
+		if e.Type == "INSUFFICIENT_SCOPES" && strings.Contains(e.Message, "read:project") {
+			return true
+		}
+	}
+	return false
+}
+
+// milestoneData is the milestone selected for an issue or PR.
+type milestoneData struct {
+	Title string     `json:"title"`
+	DueOn *time.Time `json:"dueOn"`
+}
+
+// model returns the milestone, or nil when the item has none.
+func (m *milestoneData) model() *model.Milestone {
+	if m == nil || m.Title == "" {
+		return nil
+	}
+	return &model.Milestone{Title: m.Title, DueOn: m.DueOn}
+}
+
+// projectItemsData is the project boards selected for an issue or PR, with
+// the value of each board's Status field. It is nil when the query left
+// them out.
+type projectItemsData struct {
+	Nodes []struct {
+		Project struct {
+			Title string `json:"title"`
+		} `json:"project"`
+		Status *struct {
+			Name string `json:"name"`
+		} `json:"fieldValueByName"`
+	} `json:"nodes"`
+}
+
+// model returns the boards the item is on.
+func (p *projectItemsData) model() []model.ProjectItem {
+	if p == nil {
+		return nil
+	}
+	var items []model.ProjectItem
+	for _, node := range p.Nodes {
+		if node.Project.Title == "" {
+			continue
+		}
+		item := model.ProjectItem{Project: node.Project.Title}
+		if node.Status != nil {
+			item.Status = node.Status.Name
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,161 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestParsePlanning(t *testing.T) {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		fields        string
+		wantMilestone *model.Milestone
+		wantProjects  []model.ProjectItem
+	}{
+		{
+			name:          "milestone and project status",
+			fields:        `"milestone": {"title": "v2", "dueOn": "2026-11-01T00:00:00Z"}, "projectItems": {"nodes": [{"project": {"title": "Roadmap"}, "fieldValueByName": {"name": "In Review"}}, {"project": {"title": "Triage"}, "fieldValueByName": null}]}`,
+			wantMilestone: &model.Milestone{Title: "v2", DueOn: &due},
+			wantProjects:  []model.ProjectItem{{Project: "Roadmap", Status: "In Review"}, {Project: "Triage"}},
+		},
+		{
+			name:          "milestone without a due date",
+			fields:        `"milestone": {"title": "someday", "dueOn": null}`,
+			wantMilestone: &model.Milestone{Title: "someday"},
+		},
+		{
+			name:   "neither",
+			fields: `"milestone": null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prs, err := parsePRResponse(json.RawMessage(`{"pr0": {"pullRequest": {"number": 1, `+tt.fields+`}}}`),
+				[]enrichmentItem{{index: 0, owner: "acme", repo: "web", isPR: true}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := prs[0].Milestone; !reflect.DeepEqual(got, tt.wantMilestone) {
+				t.Errorf("PR Milestone = %+v, want %+v", got, tt.wantMilestone)
+			}
+			if got := prs[0].Projects; !reflect.DeepEqual(got, tt.wantProjects) {
+				t.Errorf("PR Projects = %+v, want %+v", got, tt.wantProjects)
+			}
+			if wantFetched := strings.Contains(tt.fields, "projectItems"); prs[0].ProjectsFetched != wantFetched {
+				t.Errorf("PR ProjectsFetched = %v, want %v", prs[0].ProjectsFetched, wantFetched)
+			}
+
+			issues, err := parseIssueResponse(json.RawMessage(`{"issue0": {"issue": {"number": 1, `+tt.fields+`}}}`),
+				[]enrichmentItem{{index: 0, owner: "acme", repo: "web"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := issues[0].Milestone; !reflect.DeepEqual(got, tt.wantMilestone) {
+				t.Errorf("issue Milestone = %+v, want %+v", got, tt.wantMilestone)
+			}
+			if got := issues[0].Projects; !reflect.DeepEqual(got, tt.wantProjects) {
+				t.Errorf("issue Projects = %+v, want %+v", got, tt.wantProjects)
+			}
+		})
+	}
+}
+
+func TestProjectStatusQueried(t *testing.T) {
+	q := mustLoadQueries(t)
+	items := []BatchItem{{Alias: "pr0", Owner: "acme", Repo: "web", Number: 1}}
+
+	for _, enabled := range []bool{false, true} {
+		c := &Client{queries: q}
+		WithProjectStatus(enabled)(c)
+		for _, build := range []func(schemaGaps) (graphqlQuery, error){
+			func(g schemaGaps) (graphqlQuery, error) { return q.BuildPRBatchQuery(items, g) },
+			func(g schemaGaps) (graphqlQuery, error) { return q.BuildIssueBatchQuery(items, g) },
+		} {
+			query := mustBuild(t)(build(c.queryGaps(nil)))
+			if got := strings.Contains(query.Query, "projectItems"); got != enabled {
+				t.Errorf("WithProjectStatus(%v): query selects projectItems = %v", enabled, got)
+			}
+			if !strings.Contains(query.Query, "milestone") {
+				t.Errorf("WithProjectStatus(%v): query should select milestone", enabled)
+			}
+		}
+	}
+
+	// The server lacking a field still leaves it out
+	c := &Client{queries: q, projectStatus: true}
+	query := mustBuild(t)(q.BuildIssueBatchQuery(items, c.queryGaps(schemaGaps{"Issue.projectItems": true})))
+	if strings.Contains(query.Query, "projectItems") {
+		t.Error("query selects projectItems the server lacks")
+	}
+}
+
+func TestEnrichWithoutProjectScope(t *testing.T) {
+	// The token lacks read:project, so GitHub rejects any query selecting
+	// projectItems
+	var batches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(req.Query, "query RateLimit"):
+			_, _ = w.Write([]byte(`{"data": {"rateLimit": null}}`))
+		case strings.Contains(req.Query, "projectItems"):
+			batches.Add(1)
+			_, _ = w.Write([]byte(`{"data": null, "errors": [{
+				"type": "INSUFFICIENT_SCOPES",
+				"message": "Your token has not been granted the required scopes to execute this query. The 'title' field requires one of the following scopes: ['read:project'], but your token has only been granted the: ['repo'] scopes."
+			}]}`))
+		default:
+			batches.Add(1)
+			_, _ = w.Write([]byte(`{"data": {"pr0": {"pullRequest": {"number": 2, "state": "OPEN", "additions": 5}}}}`))
+		}
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	c := &Client{
+		queries:       mustLoadQueries(t),
+		graphqlHTTP:   &http.Client{Transport: redirectTransport{target: target}},
+		projectStatus: true,
+	}
+	newItems := func() []model.Item {
+		return []model.Item{{ID: "2", Repository: model.Repository{FullName: "org/repo"}, Subject: model.Subject{Type: model.SubjectPullRequest, URL: "https://api.github.com/repos/org/repo/pulls/2"}}}
+	}
+
+	items := newItems()
+	report, err := c.EnrichItemsGraphQL(context.Background(), items, "token", nil)
+	if err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if report.Enriched != 1 || len(report.Errors) != 0 {
+		t.Errorf("Enriched = %d, Errors = %v; want the retried batch to succeed", report.Enriched, report.Errors)
+	}
+	if items[0].ProjectsFetched {
+		t.Error("ProjectsFetched set though the retry left the boards out")
+	}
+	if batches.Load() != 2 {
+		t.Errorf("batches = %d, want 2", batches.Load())
+	}
+
+	// Later batches leave the boards out from the start
+	if _, err := c.EnrichItemsGraphQL(context.Background(), newItems(), "token", nil); err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if batches.Load() != 3 {
+		t.Errorf("batches = %d, want one more", batches.Load())
+	}
+}
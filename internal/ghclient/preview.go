@@ -0,0 +1,182 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// previewComments is the number of recent comments in a preview.
+const previewComments = 5
+
+// ItemPreview fetches what the TUI's detail pane shows of an issue or pull
+// request: its body, labels, assignees, last few comments, and for pull
+// requests the review status and the checks on the head commit.
+func (c *Client) ItemPreview(ctx context.Context, owner, repo string, number int) (*model.Preview, error) {
+	respData, gqlErrs, err := c.executeForSchema(ctx, c.token, func(gaps schemaGaps) (graphqlQuery, error) {
+		return c.queries.BuildPreviewQuery(owner, repo, number, previewComments, gaps)
+	})
+	if err != nil {
+		return nil, err
+	}
+	preview, err := parsePreviewResponse(respData)
+	if err != nil {
+		return nil, err
+	}
+	if preview == nil {
+		if len(gqlErrs) > 0 {
+			return nil, fmt.Errorf("failed to get %s/%s#%d: %s", owner, repo, number, gqlErrs[0].Message)
+		}
+		return nil, fmt.Errorf("%s/%s#%d not found", owner, repo, number)
+	}
+	return preview, nil
+}
+
+// previewActor is the author of a comment or review; nil for deleted users.
+type previewActor struct {
+	Login string `json:"login"`
+}
+
+// parsePreviewResponse converts a preview query response, returning nil
+// when the repository or item could not be read.
+func parsePreviewResponse(data json.RawMessage) (*model.Preview, error) {
+	var resp struct {
+		Repository *struct {
+			Item *struct {
+				Body   string `json:"body"`
+				Labels struct {
+					Nodes []struct {
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"labels"`
+				Assignees struct {
+					Nodes []struct {
+						Login string `json:"login"`
+					} `json:"nodes"`
+				} `json:"assignees"`
+				Comments struct {
+					Nodes []struct {
+						Author    *previewActor `json:"author"`
+						Body      string        `json:"body"`
+						CreatedAt time.Time     `json:"createdAt"`
+					} `json:"nodes"`
+				} `json:"comments"`
+				ReviewDecision string `json:"reviewDecision"`
+				LatestReviews  struct {
+					Nodes []struct {
+						Author *previewActor `json:"author"`
+						State  string        `json:"state"`
+					} `json:"nodes"`
+				} `json:"latestReviews"`
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								Contexts struct {
+									Nodes []checkContext `json:"nodes"`
+								} `json:"contexts"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"issueOrPullRequest"`
+		} `json:"repository"`
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty preview response")
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse preview response: %w", err)
+	}
+	if resp.Repository == nil || resp.Repository.Item == nil {
+		return nil, nil
+	}
+	item := resp.Repository.Item
+
+	preview := &model.Preview{Body: item.Body}
+	for _, l := range item.Labels.Nodes {
+		preview.Labels = append(preview.Labels, l.Name)
+	}
+	for _, a := range item.Assignees.Nodes {
+		preview.Assignees = append(preview.Assignees, a.Login)
+	}
+	for _, c := range item.Comments.Nodes {
+		preview.Comments = append(preview.Comments, model.Comment{
+			Author:    actorLogin(c.Author),
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	if item.ReviewDecision != "" {
+		preview.ReviewDecision = mapReviewDecision(item.ReviewDecision)
+	}
+	for _, r := range item.LatestReviews.Nodes {
+		preview.Reviews = append(preview.Reviews, model.Review{
+			Author: actorLogin(r.Author),
+			State:  strings.ToLower(r.State),
+		})
+	}
+	for _, commit := range item.Commits.Nodes {
+		if commit.Commit.StatusCheckRollup == nil {
+			continue
+		}
+		for _, ctx := range commit.Commit.StatusCheckRollup.Contexts.Nodes {
+			if check, ok := ctx.check(); ok {
+				preview.Checks = append(preview.Checks, check)
+			}
+		}
+	}
+	return preview, nil
+}
+
+// checkContext is a CheckRun (name, status, conclusion) or a StatusContext
+// (context, state) in a status check rollup.
+type checkContext struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	Context    string `json:"context"`
+	State      string `json:"state"`
+}
+
+// check converts the context to a model.Check, reporting false for
+// contexts of other types.
+func (c checkContext) check() (model.Check, bool) {
+	switch {
+	case c.Name != "":
+		state := model.CIStatusPending
+		if c.Status == "COMPLETED" {
+			switch c.Conclusion {
+			case "SUCCESS", "NEUTRAL", "SKIPPED":
+				state = model.CIStatusSuccess
+			default:
+				state = model.CIStatusFailure
+			}
+		}
+		return model.Check{Name: c.Name, State: state}, true
+	case c.Context != "":
+		state := model.CIStatusPending
+		switch c.State {
+		case "SUCCESS":
+			state = model.CIStatusSuccess
+		case "FAILURE", "ERROR":
+			state = model.CIStatusFailure
+		}
+		return model.Check{Name: c.Context, State: state}, true
+	}
+	return model.Check{}, false
+}
+
+// actorLogin returns the login of a, or "ghost" for deleted users as
+// GitHub shows them.
+func actorLogin(a *previewActor) string {
+	if a == nil {
+		return "ghost"
+	}
+	return a.Login
+}
@@ -0,0 +1,85 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestParsePreviewResponse(t *testing.T) {
+	data := json.RawMessage(`{"repository": {"issueOrPullRequest": {
+		"body": "Fixes the flaky test",
+		"labels": {"nodes": [{"name": "bug"}, {"name": "ci"}]},
+		"assignees": {"nodes": [{"login": "alice"}]},
+		"comments": {"nodes": [
+			{"author": {"login": "bob"}, "body": "LGTM", "createdAt": "2026-01-02T03:04:05Z"},
+			{"author": null, "body": "+1", "createdAt": "2026-01-03T03:04:05Z"}
+		]},
+		"reviewDecision": "CHANGES_REQUESTED",
+		"latestReviews": {"nodes": [{"author": {"login": "carol"}, "state": "CHANGES_REQUESTED"}]},
+		"commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {"nodes": [
+			{"name": "build", "status": "COMPLETED", "conclusion": "SUCCESS"},
+			{"name": "lint", "status": "COMPLETED", "conclusion": "FAILURE"},
+			{"name": "test", "status": "IN_PROGRESS", "conclusion": null},
+			{"context": "ci/legacy", "state": "ERROR"},
+			{}
+		]}}}}]}
+	}}}`)
+
+	got, err := parsePreviewResponse(data)
+	if err != nil {
+		t.Fatalf("parsePreviewResponse() error = %v", err)
+	}
+	want := &model.Preview{
+		Body:      "Fixes the flaky test",
+		Labels:    []string{"bug", "ci"},
+		Assignees: []string{"alice"},
+		Comments: []model.Comment{
+			{Author: "bob", Body: "LGTM", CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+			{Author: "ghost", Body: "+1", CreatedAt: time.Date(2026, 1, 3, 3, 4, 5, 0, time.UTC)},
+		},
+		ReviewDecision: model.ReviewStateChangesRequested,
+		Reviews:        []model.Review{{Author: "carol", State: "changes_requested"}},
+		Checks: []model.Check{
+			{Name: "build", State: model.CIStatusSuccess},
+			{Name: "lint", State: model.CIStatusFailure},
+			{Name: "test", State: model.CIStatusPending},
+			{Name: "ci/legacy", State: model.CIStatusFailure},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePreviewResponse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParsePreviewResponseIssue(t *testing.T) {
+	data := json.RawMessage(`{"repository": {"issueOrPullRequest": {
+		"body": "It crashes",
+		"labels": {"nodes": []},
+		"assignees": {"nodes": []},
+		"comments": {"nodes": []}
+	}}}`)
+
+	got, err := parsePreviewResponse(data)
+	if err != nil {
+		t.Fatalf("parsePreviewResponse() error = %v", err)
+	}
+	if got.Body != "It crashes" || got.ReviewDecision != "" || len(got.Checks) != 0 {
+		t.Errorf("parsePreviewResponse() = %+v, want body only", got)
+	}
+}
+
+func TestParsePreviewResponseMissing(t *testing.T) {
+	for _, data := range []string{`{"repository": null}`, `{"repository": {"issueOrPullRequest": null}}`} {
+		got, err := parsePreviewResponse(json.RawMessage(data))
+		if err != nil {
+			t.Fatalf("parsePreviewResponse(%s) error = %v", data, err)
+		}
+		if got != nil {
+			t.Errorf("parsePreviewResponse(%s) = %+v, want nil", data, got)
+		}
+	}
+}
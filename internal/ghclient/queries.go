@@ -11,54 +11,90 @@ import (
 //go:embed queries/*.graphql
 var queryFiles embed.FS
 
-// queries holds parsed GraphQL query templates.
+// graphqlQuery is a GraphQL document and the variables sent with it.
+// Values that come from users or GitHub (owners, repository names, logins)
+// are always passed as variables, never written into the document.
+type graphqlQuery struct {
+	Query     string
+	Variables map[string]any
+}
+
+// queries holds the embedded GraphQL documents. The enrichment fragments,
+// orphaned, and preview queries are templates that leave out fields the
+// server's schema lacks.
 type queries struct {
-	orphanedTemplate string
-	prBatchTemplate  *template.Template
-	issBatchTemplate *template.Template
+	orphaned    *template.Template
+	prFields    *template.Template
+	issueFields *template.Template
+	stateFields string
+	teams       string
+	preview     *template.Template
+	members     string
+	codeOwners  string
+	schemaProbe string
 }
 
-// loadQueries reads embedded GraphQL files and parses templates.
+// loadQueries reads the embedded GraphQL files.
 func loadQueries() (*queries, error) {
-	data, err := queryFiles.ReadFile("queries/orphaned.graphql")
-	if err != nil {
-		return nil, fmt.Errorf("loading orphaned.graphql: %w", err)
+	var q queries
+	var orphaned, prFields, issueFields, preview string
+	for name, dst := range map[string]*string{
+		"orphaned":     &orphaned,
+		"pr_fields":    &prFields,
+		"issue_fields": &issueFields,
+		"state_fields": &q.stateFields,
+		"teams":        &q.teams,
+		"item_preview": &preview,
+		"team_members": &q.members,
+		"codeowners":   &q.codeOwners,
+		"schema_probe": &q.schemaProbe,
+	} {
+		data, err := queryFiles.ReadFile("queries/" + name + ".graphql")
+		if err != nil {
+			return nil, fmt.Errorf("loading %s.graphql: %w", name, err)
+		}
+		*dst = stripComments(string(data))
 	}
 
-	prData, err := queryFiles.ReadFile("queries/pr_batch_item.graphql")
-	if err != nil {
-		return nil, fmt.Errorf("loading pr_batch_item.graphql: %w", err)
+	var err error
+	if q.orphaned, err = template.New("orphaned").Parse(orphaned); err != nil {
+		return nil, fmt.Errorf("parsing orphaned.graphql: %w", err)
 	}
-	prTmpl, err := template.New("pr_batch_item").Parse(string(prData))
-	if err != nil {
-		return nil, fmt.Errorf("parsing pr_batch_item.graphql: %w", err)
+	if q.prFields, err = template.New("pr_fields").Parse(prFields); err != nil {
+		return nil, fmt.Errorf("parsing pr_fields.graphql: %w", err)
 	}
-
-	issueData, err := queryFiles.ReadFile("queries/issue_batch_item.graphql")
-	if err != nil {
-		return nil, fmt.Errorf("loading issue_batch_item.graphql: %w", err)
+	if q.issueFields, err = template.New("issue_fields").Parse(issueFields); err != nil {
+		return nil, fmt.Errorf("parsing issue_fields.graphql: %w", err)
 	}
-	issTmpl, err := template.New("issue_batch_item").Parse(string(issueData))
-	if err != nil {
-		return nil, fmt.Errorf("parsing issue_batch_item.graphql: %w", err)
+	if q.preview, err = template.New("item_preview").Parse(preview); err != nil {
+		return nil, fmt.Errorf("parsing item_preview.graphql: %w", err)
 	}
+	return &q, nil
+}
 
-	return &queries{
-		orphanedTemplate: string(data),
-		prBatchTemplate:  prTmpl,
-		issBatchTemplate: issTmpl,
-	}, nil
+// stripComments removes the comment lines describing a query file, which
+// GitHub would otherwise receive with every request.
+func stripComments(doc string) string {
+	var lines []string
+	for _, line := range strings.Split(doc, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
 }
 
-// BuildOrphanedQuery builds the GraphQL query for fetching orphaned contributions.
-// Since GitHub's GraphQL API doesn't support variables in the same way as a client library,
-// we inline the owner/repo values directly.
-func (q *queries) BuildOrphanedQuery(owner, repo string) string {
-	query := strings.Replace(q.orphanedTemplate, "$owner: String!, $repo: String!", "", 1)
-	query = strings.ReplaceAll(query, "$owner", fmt.Sprintf(`"%s"`, owner))
-	query = strings.ReplaceAll(query, "$repo", fmt.Sprintf(`"%s"`, repo))
-	query = strings.Replace(query, "query OrphanedContributions()", "query", 1)
-	return query
+// BuildOrphanedQuery builds the GraphQL query for fetching orphaned
+// contributions, without the fields in gaps.
+func (q *queries) BuildOrphanedQuery(owner, repo string, gaps schemaGaps) (graphqlQuery, error) {
+	var buf bytes.Buffer
+	if err := q.orphaned.Execute(&buf, gaps); err != nil {
+		return graphqlQuery{}, fmt.Errorf("failed to execute orphaned template: %w", err)
+	}
+	return graphqlQuery{
+		Query:     buf.String(),
+		Variables: map[string]any{"owner": owner, "repo": repo},
+	}, nil
 }
 
 // BatchItem represents the parameters for a single item in a batch query.
@@ -69,40 +105,132 @@ type BatchItem struct {
 	Number int
 }
 
-// BuildPRBatchQuery builds a GraphQL query for multiple PRs using aliases.
-func (q *queries) BuildPRBatchQuery(items []BatchItem) (string, error) {
-	var sb strings.Builder
-	sb.WriteString("query {\n")
+// BuildPRBatchQuery builds a GraphQL query for multiple PRs using aliases,
+// without the fields in gaps.
+func (q *queries) BuildPRBatchQuery(items []BatchItem, gaps schemaGaps) (graphqlQuery, error) {
+	var buf bytes.Buffer
+	if err := q.prFields.Execute(&buf, gaps); err != nil {
+		return graphqlQuery{}, fmt.Errorf("failed to execute PR template: %w", err)
+	}
+	return buildBatchQuery(items, "pullRequest", "PRFields", buf.String()), nil
+}
 
-	for _, item := range items {
-		var buf bytes.Buffer
-		if err := q.prBatchTemplate.Execute(&buf, item); err != nil {
-			return "", fmt.Errorf("failed to execute PR template for %s: %w", item.Alias, err)
-		}
-		sb.WriteString("  ")
-		sb.WriteString(strings.ReplaceAll(buf.String(), "\n", "\n  "))
-		sb.WriteString("\n")
+// BuildIssueBatchQuery builds a GraphQL query for multiple Issues using
+// aliases, without the fields in gaps.
+func (q *queries) BuildIssueBatchQuery(items []BatchItem, gaps schemaGaps) (graphqlQuery, error) {
+	var buf bytes.Buffer
+	if err := q.issueFields.Execute(&buf, gaps); err != nil {
+		return graphqlQuery{}, fmt.Errorf("failed to execute Issue template: %w", err)
 	}
+	return buildBatchQuery(items, "issue", "IssueFields", buf.String()), nil
+}
 
-	sb.WriteString("}")
-	return sb.String(), nil
+// BuildStateBatchQuery builds a GraphQL query for the state of multiple
+// issues or PRs using aliases.
+func (q *queries) BuildStateBatchQuery(items []BatchItem) graphqlQuery {
+	return buildBatchQuery(items, "issueOrPullRequest", "StateFields", q.stateFields)
 }
 
-// BuildIssueBatchQuery builds a GraphQL query for multiple Issues using aliases.
-func (q *queries) BuildIssueBatchQuery(items []BatchItem) (string, error) {
-	var sb strings.Builder
-	sb.WriteString("query {\n")
+// rateLimitField selects what a query cost and the GraphQL quota left.
+// Selecting it costs nothing.
+const rateLimitField = "  rateLimit {\n    cost\n    limit\n    remaining\n    resetAt\n  }\n"
+
+// BuildRateLimitQuery builds a GraphQL query for the quota left.
+func (q *queries) BuildRateLimitQuery() graphqlQuery {
+	return graphqlQuery{Query: "query RateLimit {\n" + rateLimitField + "}\n"}
+}
+
+// buildBatchQuery builds a query selecting field of each item's repository
+// under the item's alias, spreading the named fragment so its fields are
+// sent once rather than once per item. Each repository gets one
+// owner/name variable pair however many of its items are in the batch,
+// and each item a number variable. The query also selects the quota left.
+func buildBatchQuery(items []BatchItem, field, fragmentName, fragment string) graphqlQuery {
+	var defs []string
+	var body strings.Builder
+	vars := make(map[string]any, len(items)*3)
+	repoVars := make(map[string]int)
 
-	for _, item := range items {
-		var buf bytes.Buffer
-		if err := q.issBatchTemplate.Execute(&buf, item); err != nil {
-			return "", fmt.Errorf("failed to execute Issue template for %s: %w", item.Alias, err)
+	for i, item := range items {
+		repoKey := item.Owner + "/" + item.Repo
+		r, ok := repoVars[repoKey]
+		if !ok {
+			r = len(repoVars)
+			repoVars[repoKey] = r
+			vars[fmt.Sprintf("owner%d", r)] = item.Owner
+			vars[fmt.Sprintf("name%d", r)] = item.Repo
+			defs = append(defs, fmt.Sprintf("$owner%d: String!", r), fmt.Sprintf("$name%d: String!", r))
 		}
-		sb.WriteString("  ")
-		sb.WriteString(strings.ReplaceAll(buf.String(), "\n", "\n  "))
-		sb.WriteString("\n")
+		vars[fmt.Sprintf("number%d", i)] = item.Number
+		defs = append(defs, fmt.Sprintf("$number%d: Int!", i))
+
+		fmt.Fprintf(&body, "  %s: repository(owner: $owner%d, name: $name%d) {\n", item.Alias, r, r)
+		fmt.Fprintf(&body, "    %s(number: $number%d) {\n      ...%s\n    }\n  }\n", field, i, fragmentName)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("query")
+	if len(defs) > 0 {
+		sb.WriteString("(" + strings.Join(defs, ", ") + ")")
+	}
+	sb.WriteString(" {\n")
+	sb.WriteString(body.String())
+	sb.WriteString(rateLimitField)
+	sb.WriteString("}\n")
+	if len(items) > 0 {
+		sb.WriteString("\n" + fragment)
 	}
+	return graphqlQuery{Query: sb.String(), Variables: vars}
+}
+
+// BuildSchemaProbeQuery builds the GraphQL query listing the fields of the
+// types whose optional fields the enrichment queries use.
+func (q *queries) BuildSchemaProbeQuery() graphqlQuery {
+	return graphqlQuery{Query: q.schemaProbe}
+}
 
-	sb.WriteString("}")
-	return sb.String(), nil
+// BuildTeamsQuery builds the GraphQL query for the teams login belongs to.
+func (q *queries) BuildTeamsQuery(login string) graphqlQuery {
+	return graphqlQuery{
+		Query:     q.teams,
+		Variables: map[string]any{"login": login},
+	}
+}
+
+// BuildTeamMembersQuery builds the GraphQL query for a page of the members
+// of org's team slug, starting after the cursor after (empty for the first).
+func (q *queries) BuildTeamMembersQuery(org, slug, after string) graphqlQuery {
+	vars := map[string]any{"org": org, "slug": slug, "after": nil}
+	if after != "" {
+		vars["after"] = after
+	}
+	return graphqlQuery{Query: q.members, Variables: vars}
+}
+
+// BuildCodeOwnersQuery builds the GraphQL query for the CODEOWNERS file of
+// owner/repo.
+func (q *queries) BuildCodeOwnersQuery(owner, repo string) graphqlQuery {
+	return graphqlQuery{
+		Query:     q.codeOwners,
+		Variables: map[string]any{"owner": owner, "repo": repo},
+	}
+}
+
+// BuildPreviewQuery builds the GraphQL query for the detail pane preview of
+// an issue or PR, with its last comments comments, without the fields in
+// gaps.
+func (q *queries) BuildPreviewQuery(owner, repo string, number, comments int, gaps schemaGaps) (graphqlQuery, error) {
+	var buf bytes.Buffer
+	if err := q.preview.Execute(&buf, gaps); err != nil {
+		return graphqlQuery{}, fmt.Errorf("failed to execute preview template: %w", err)
+	}
+	return graphqlQuery{
+		Query: buf.String(),
+		Variables: map[string]any{
+			"owner":    owner,
+			"repo":     repo,
+			"number":   number,
+			"comments": comments,
+		},
+	}, nil
 }
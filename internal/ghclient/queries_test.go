@@ -14,21 +14,44 @@ func mustLoadQueries(t *testing.T) *queries {
 	return q
 }
 
+// mustBuild returns a func that fails t if building a query failed.
+func mustBuild(t *testing.T) func(graphqlQuery, error) graphqlQuery {
+	t.Helper()
+	return func(query graphqlQuery, err error) graphqlQuery {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("building query: %v", err)
+		}
+		return query
+	}
+}
+
+// assertVariables checks that query passes want as variables.
+func assertVariables(t *testing.T, query graphqlQuery, want map[string]any) {
+	t.Helper()
+	for name, value := range want {
+		if got, ok := query.Variables[name]; !ok || got != value {
+			t.Errorf("variable %s = %v, want %v", name, got, value)
+		}
+	}
+}
+
 func TestBuildOrphanedQuery(t *testing.T) {
 	q := mustLoadQueries(t)
-	query := q.BuildOrphanedQuery("testowner", "testrepo")
+	query := mustBuild(t)(q.BuildOrphanedQuery("testowner", "testrepo", nil))
 
-	// Verify owner and repo are substituted
-	if !strings.Contains(query, `"testowner"`) {
-		t.Error("query should contain owner")
+	assertVariables(t, query, map[string]any{"owner": "testowner", "repo": "testrepo"})
+	if strings.Contains(query.Query, "testowner") {
+		t.Error("owner should be passed as a variable, not in the query")
 	}
-	if !strings.Contains(query, `"testrepo"`) {
-		t.Error("query should contain repo")
+	if strings.Contains(query.Query, "#") {
+		t.Error("query should not include the file's comments")
 	}
 
 	// Verify required fields are present
 	requiredFields := []string{
-		"repository(",
+		"query OrphanedContributions($owner: String!, $repo: String!)",
+		"repository(owner: $owner, name: $repo)",
 		"issues(",
 		"pullRequests(",
 		"number",
@@ -44,7 +67,7 @@ func TestBuildOrphanedQuery(t *testing.T) {
 	}
 
 	for _, field := range requiredFields {
-		if !strings.Contains(query, field) {
+		if !strings.Contains(query.Query, field) {
 			t.Errorf("query should contain %q", field)
 		}
 	}
@@ -55,44 +78,40 @@ func TestBuildPRBatchQuery(t *testing.T) {
 	items := []BatchItem{
 		{Alias: "pr0", Owner: "owner1", Repo: "repo1", Number: 123},
 		{Alias: "pr1", Owner: "owner2", Repo: "repo2", Number: 456},
+		{Alias: "pr2", Owner: "owner1", Repo: "repo1", Number: 789},
 	}
 
-	query, err := q.BuildPRBatchQuery(items)
-	if err != nil {
-		t.Fatalf("BuildPRBatchQuery failed: %v", err)
-	}
+	query := mustBuild(t)(q.BuildPRBatchQuery(items, nil))
 
 	// Verify query structure
-	if !strings.HasPrefix(query, "query {") {
-		t.Error("query should start with 'query {'")
-	}
-	if !strings.HasSuffix(strings.TrimSpace(query), "}") {
-		t.Error("query should end with '}'")
+	if !strings.HasPrefix(query.Query, "query($owner0: String!, $name0: String!, $number0: Int!, $owner1: String!, $name1: String!, $number1: Int!, $number2: Int!) {") {
+		t.Errorf("query should declare one owner/name pair per repository and a number per item, got:\n%s", query.Query)
 	}
 
-	// Verify aliases and values
-	if !strings.Contains(query, "pr0: repository(") {
-		t.Error("query should contain pr0 alias")
-	}
-	if !strings.Contains(query, "pr1: repository(") {
-		t.Error("query should contain pr1 alias")
-	}
-	if !strings.Contains(query, `"owner1"`) {
-		t.Error("query should contain owner1")
-	}
-	if !strings.Contains(query, `"repo2"`) {
-		t.Error("query should contain repo2")
-	}
-	if !strings.Contains(query, "number: 123") {
-		t.Error("query should contain number 123")
+	// Verify aliases and variables; items in the same repository share
+	// its variables
+	for _, want := range []string{
+		"pr0: repository(owner: $owner0, name: $name0) {\n    pullRequest(number: $number0) {\n      ...PRFields",
+		"pr1: repository(owner: $owner1, name: $name1) {\n    pullRequest(number: $number1) {\n      ...PRFields",
+		"pr2: repository(owner: $owner0, name: $name0) {\n    pullRequest(number: $number2) {\n      ...PRFields",
+	} {
+		if !strings.Contains(query.Query, want) {
+			t.Errorf("query should contain %q", want)
+		}
 	}
-	if !strings.Contains(query, "number: 456") {
-		t.Error("query should contain number 456")
+	assertVariables(t, query, map[string]any{
+		"owner0": "owner1", "name0": "repo1",
+		"owner1": "owner2", "name1": "repo2",
+		"number0": 123, "number1": 456, "number2": 789,
+	})
+
+	// The fragment is sent once for the whole batch
+	if n := strings.Count(query.Query, "fragment PRFields on PullRequest {"); n != 1 {
+		t.Errorf("query defines PRFields %d times, want 1", n)
 	}
 
 	// Verify required PR fields
 	requiredFields := []string{
-		"pullRequest(",
 		"number",
 		"state",
 		"additions",
@@ -108,7 +127,7 @@ func TestBuildPRBatchQuery(t *testing.T) {
 	}
 
 	for _, field := range requiredFields {
-		if !strings.Contains(query, field) {
+		if !strings.Contains(query.Query, field) {
 			t.Errorf("query should contain %q", field)
 		}
 	}
@@ -120,33 +139,17 @@ func TestBuildIssueBatchQuery(t *testing.T) {
 		{Alias: "issue0", Owner: "myorg", Repo: "myrepo", Number: 789},
 	}
 
-	query, err := q.BuildIssueBatchQuery(items)
-	if err != nil {
-		t.Fatalf("BuildIssueBatchQuery failed: %v", err)
-	}
+	query := mustBuild(t)(q.BuildIssueBatchQuery(items, nil))
 
-	// Verify query structure
-	if !strings.HasPrefix(query, "query {") {
-		t.Error("query should start with 'query {'")
-	}
-
-	// Verify alias and values
-	if !strings.Contains(query, "issue0: repository(") {
+	// Verify alias and variables
+	if !strings.Contains(query.Query, "issue0: repository(owner: $owner0, name: $name0) {\n    issue(number: $number0) {\n      ...IssueFields") {
 		t.Error("query should contain issue0 alias")
 	}
-	if !strings.Contains(query, `"myorg"`) {
-		t.Error("query should contain myorg")
-	}
-	if !strings.Contains(query, `"myrepo"`) {
-		t.Error("query should contain myrepo")
-	}
-	if !strings.Contains(query, "number: 789") {
-		t.Error("query should contain number 789")
-	}
+	assertVariables(t, query, map[string]any{"owner0": "myorg", "name0": "myrepo", "number0": 789})
 
 	// Verify required Issue fields
 	requiredFields := []string{
-		"issue(",
+		"fragment IssueFields on Issue {",
 		"number",
 		"state",
 		"createdAt",
@@ -155,38 +158,209 @@ func TestBuildIssueBatchQuery(t *testing.T) {
 		"author",
 		"assignees(",
 		"labels(",
+		"blockedBy(",
 		"comments(",
+		"body",
 	}
 
 	for _, field := range requiredFields {
-		if !strings.Contains(query, field) {
+		if !strings.Contains(query.Query, field) {
 			t.Errorf("query should contain %q", field)
 		}
 	}
 }
 
-func TestBuildPRBatchQueryEmpty(t *testing.T) {
+func TestBuildBatchQueryLeavesOutSchemaGaps(t *testing.T) {
 	q := mustLoadQueries(t)
-	query, err := q.BuildPRBatchQuery([]BatchItem{})
-	if err != nil {
-		t.Fatalf("BuildPRBatchQuery failed: %v", err)
+	items := []BatchItem{{Alias: "pr0", Owner: "acme", Repo: "web", Number: 1}}
+
+	tests := []struct {
+		name    string
+		build   func(schemaGaps) (graphqlQuery, error)
+		gaps    schemaGaps
+		missing []string
+		kept    []string
+	}{
+		{
+			name: "PR without latestReviews and statusCheckRollup",
+			build: func(g schemaGaps) (graphqlQuery, error) {
+				return q.BuildPRBatchQuery(items, g)
+			},
+			gaps:    schemaGaps{"PullRequest.latestReviews": true, "Commit.statusCheckRollup": true},
+			missing: []string{"latestReviews", "statusCheckRollup"},
+			kept:    []string{"closingIssuesReferences(", "reviewDecision", "commits(last: 1)", "committedDate"},
+		},
+		{
+			name: "PR without closingIssuesReferences",
+			build: func(g schemaGaps) (graphqlQuery, error) {
+				return q.BuildPRBatchQuery(items, g)
+			},
+			gaps:    schemaGaps{"PullRequest.closingIssuesReferences": true},
+			missing: []string{"closingIssuesReferences"},
+			kept:    []string{"latestReviews(", "statusCheckRollup", "timelineItems("},
+		},
+		{
+			name: "orphaned without statusCheckRollup",
+			build: func(g schemaGaps) (graphqlQuery, error) {
+				return q.BuildOrphanedQuery("acme", "web", g)
+			},
+			gaps:    schemaGaps{"Commit.statusCheckRollup": true},
+			missing: []string{"statusCheckRollup", "commits(last: 1)"},
+			kept:    []string{"reviews(last: 5)", "authorAssociation"},
+		},
+		{
+			name: "preview without latestReviews and statusCheckRollup",
+			build: func(g schemaGaps) (graphqlQuery, error) {
+				return q.BuildPreviewQuery("acme", "web", 1, 5, g)
+			},
+			gaps:    schemaGaps{"PullRequest.latestReviews": true, "Commit.statusCheckRollup": true},
+			missing: []string{"latestReviews", "statusCheckRollup"},
+			kept:    []string{"reviewDecision", "comments(last: $comments)"},
+		},
+		{
+			name: "issue without blockedBy",
+			build: func(g schemaGaps) (graphqlQuery, error) {
+				return q.BuildIssueBatchQuery(items, g)
+			},
+			gaps:    schemaGaps{"Issue.blockedBy": true},
+			missing: []string{"blockedBy"},
+			kept:    []string{"labels(", "comments("},
+		},
 	}
 
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := mustBuild(t)(tt.build(tt.gaps))
+			for _, field := range tt.missing {
+				if strings.Contains(query.Query, field) {
+					t.Errorf("query should leave out %q:\n%s", field, query.Query)
+				}
+			}
+			for _, field := range tt.kept {
+				if !strings.Contains(query.Query, field) {
+					t.Errorf("query should keep %q", field)
+				}
+			}
+			if strings.Contains(query.Query, "{{") {
+				t.Errorf("query contains template syntax:\n%s", query.Query)
+			}
+		})
+	}
+}
+
+func TestBuildBatchQueryKeepsValuesOutOfQuery(t *testing.T) {
+	q := mustLoadQueries(t)
+	repo := `web") { id } evil: viewer { login } x: repository(owner: "a", name: "b`
+	query := mustBuild(t)(q.BuildIssueBatchQuery([]BatchItem{
+		{Alias: "issue0", Owner: "acme", Repo: repo, Number: 1},
+	}, nil))
+
+	if strings.Contains(query.Query, "evil") || strings.Contains(query.Query, "acme") {
+		t.Errorf("repository values leaked into the query:\n%s", query.Query)
+	}
+	assertVariables(t, query, map[string]any{"owner0": "acme", "name0": repo})
+}
+
+func TestBuildStateBatchQuery(t *testing.T) {
+	q := mustLoadQueries(t)
+	query := q.BuildStateBatchQuery([]BatchItem{
+		{Alias: "ref0", Owner: "myorg", Repo: "myrepo", Number: 7},
+	})
+
+	for _, want := range []string{
+		"ref0: repository(owner: $owner0, name: $name0)",
+		"issueOrPullRequest(number: $number0)",
+		"...StateFields",
+		"fragment StateFields on IssueOrPullRequest {",
+		"state",
+	} {
+		if !strings.Contains(query.Query, want) {
+			t.Errorf("query should contain %q", want)
+		}
+	}
+	assertVariables(t, query, map[string]any{"owner0": "myorg", "name0": "myrepo", "number0": 7})
+}
+
+func TestBuildTeamsQuery(t *testing.T) {
+	q := mustLoadQueries(t)
+	query := q.BuildTeamsQuery("octocat")
+
+	for _, want := range []string{"query Teams($login: String!)", "viewer", "teams(first: 100, userLogins: [$login])", "combinedSlug"} {
+		if !strings.Contains(query.Query, want) {
+			t.Errorf("query should contain %q", want)
+		}
+	}
+	assertVariables(t, query, map[string]any{"login": "octocat"})
+}
+
+func TestBuildTeamMembersQuery(t *testing.T) {
+	q := mustLoadQueries(t)
+
+	first := q.BuildTeamMembersQuery("acme", "maintainers", "")
+	for _, want := range []string{"organization(login: $org)", "team(slug: $slug)", "members(first: 100, membership: ALL, after: $after)"} {
+		if !strings.Contains(first.Query, want) {
+			t.Errorf("query should contain %q", want)
+		}
+	}
+	assertVariables(t, first, map[string]any{"org": "acme", "slug": "maintainers", "after": nil})
+
+	next := q.BuildTeamMembersQuery("acme", "maintainers", "Y3Vyc29y")
+	assertVariables(t, next, map[string]any{"after": "Y3Vyc29y"})
+}
+
+func TestBuildCodeOwnersQuery(t *testing.T) {
+	q := mustLoadQueries(t)
+	query := q.BuildCodeOwnersQuery("spiffcs", "triage")
+
+	for _, want := range []string{
+		"repository(owner: $owner, name: $repo)",
+		`object(expression: "HEAD:.github/CODEOWNERS")`,
+		`object(expression: "HEAD:CODEOWNERS")`,
+		`object(expression: "HEAD:docs/CODEOWNERS")`,
+	} {
+		if !strings.Contains(query.Query, want) {
+			t.Errorf("query should contain %q", want)
+		}
+	}
+	assertVariables(t, query, map[string]any{"owner": "spiffcs", "repo": "triage"})
+}
+
+func TestBuildPreviewQuery(t *testing.T) {
+	q := mustLoadQueries(t)
+	query := mustBuild(t)(q.BuildPreviewQuery("spiffcs", "triage", 42, 5, nil))
+
+	for _, want := range []string{
+		"repository(owner: $owner, name: $repo)",
+		"issueOrPullRequest(number: $number)",
+		"comments(last: $comments)",
+		"statusCheckRollup",
+	} {
+		if !strings.Contains(query.Query, want) {
+			t.Errorf("query should contain %q", want)
+		}
+	}
+	assertVariables(t, query, map[string]any{"owner": "spiffcs", "repo": "triage", "number": 42, "comments": 5})
+}
+
+func TestBuildPRBatchQueryEmpty(t *testing.T) {
+	q := mustLoadQueries(t)
+	query := mustBuild(t)(q.BuildPRBatchQuery([]BatchItem{}, nil))
+
 	// Empty batch should still be valid query structure
-	if !strings.Contains(query, "query {") {
+	if !strings.Contains(query.Query, "query {") {
 		t.Error("empty batch should produce valid query structure")
 	}
+	if len(query.Variables) != 0 {
+		t.Errorf("empty batch has variables %v", query.Variables)
+	}
 }
 
 func TestBuildIssueBatchQueryEmpty(t *testing.T) {
 	q := mustLoadQueries(t)
-	query, err := q.BuildIssueBatchQuery([]BatchItem{})
-	if err != nil {
-		t.Fatalf("BuildIssueBatchQuery failed: %v", err)
-	}
+	query := mustBuild(t)(q.BuildIssueBatchQuery([]BatchItem{}, nil))
 
 	// Empty batch should still be valid query structure
-	if !strings.Contains(query, "query {") {
+	if !strings.Contains(query.Query, "query {") {
 		t.Error("empty batch should produce valid query structure")
 	}
 }
@@ -0,0 +1,109 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// referencePattern matches a reference to an issue or pull request:
+// "#123", "owner/repo#123", or a github.com issue or pull request URL.
+var referencePattern = regexp.MustCompile(
+	`https://github\.com/([\w.-]+/[\w.-]+)/(?:issues|pull)/(\d+)|(?:^|[^\w/#])([\w.-]+/[\w.-]+)?#(\d+)\b`)
+
+// mentionedRefs returns every issue and PR text refers to, as
+// "owner/repo#123". Bare "#123" references are taken to be in repo.
+func mentionedRefs(text, repo string) []string {
+	var refs []string
+	for _, m := range referencePattern.FindAllStringSubmatch(text, -1) {
+		switch {
+		case m[1] != "":
+			refs = append(refs, m[1]+"#"+m[2])
+		case m[3] != "":
+			refs = append(refs, m[3]+"#"+m[4])
+		default:
+			refs = append(refs, repo+"#"+m[4])
+		}
+	}
+	return refs
+}
+
+// ReleasePRs are the pull requests of one repository that matter to a
+// release branch.
+type ReleasePRs struct {
+	// Open are the open PRs targeting the branch.
+	Open []model.Item
+	// Merged are the PRs merged into other branches in the window, which
+	// may need backporting.
+	Merged []model.Item
+	// Referenced holds the PRs, as lowercased "owner/repo#123", mentioned
+	// by PRs targeting the branch in the window, such as the originals of
+	// backports.
+	Referenced map[string]bool
+}
+
+// ListReleasePRs fetches the open PRs of repo ("owner/name") targeting
+// base, and the PRs merged elsewhere and mentioned by PRs targeting base
+// since since.
+func (c *Client) ListReleasePRs(ctx context.Context, repo, base string, since time.Time) (ReleasePRs, error) {
+	var prs ReleasePRs
+	day := since.UTC().Format("2006-01-02")
+
+	open, err := c.searchAll(ctx, fmt.Sprintf("is:pr is:open repo:%s base:%s", repo, base), releasePRToItem)
+	if err != nil {
+		return prs, fmt.Errorf("failed to search for PRs targeting %s in %s: %w", base, repo, err)
+	}
+	prs.Open = open
+
+	// Backports are only read for what they mention, not listed
+	backports, err := c.searchIssues(ctx, fmt.Sprintf("is:pr repo:%s base:%s updated:>=%s", repo, base, day))
+	if err != nil {
+		return prs, fmt.Errorf("failed to search for backports to %s in %s: %w", base, repo, err)
+	}
+	prs.Referenced = make(map[string]bool)
+	for _, issue := range backports {
+		for _, ref := range mentionedRefs(issue.GetTitle()+"\n"+issue.GetBody(), repo) {
+			prs.Referenced[strings.ToLower(ref)] = true
+		}
+	}
+
+	merged, err := c.searchAll(ctx, fmt.Sprintf("is:pr is:merged repo:%s -base:%s merged:>=%s", repo, base, day), mergedPRToItem)
+	if err != nil {
+		return prs, fmt.Errorf("failed to search for merged PRs in %s: %w", repo, err)
+	}
+	prs.Merged = merged
+	return prs, nil
+}
+
+// releasePRToItem converts a search result for an open PR targeting a
+// release branch.
+func releasePRToItem(issue *gh.Issue) model.Item {
+	return issueToItem(issue,
+		fmt.Sprintf("release-%d", issue.GetID()),
+		model.ReasonSubscribed,
+		model.SubjectPullRequest,
+		&model.PRDetails{Draft: issue.GetDraft()},
+	)
+}
+
+// mergedPRToItem converts a search result for a merged PR, which the
+// search API reports as a closed issue.
+func mergedPRToItem(issue *gh.Issue) model.Item {
+	item := issueToItem(issue,
+		fmt.Sprintf("merged-%d", issue.GetID()),
+		model.ReasonSubscribed,
+		model.SubjectPullRequest,
+		&model.PRDetails{Merged: true},
+	)
+	item.State = model.StateMerged
+	if closed := issue.GetClosedAt(); !closed.IsZero() {
+		at := closed.Time
+		item.ClosedAt = &at
+	}
+	return item
+}
@@ -0,0 +1,30 @@
+package ghclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMentionedRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"none", "Backport the fix", nil},
+		{"cherry-pick title", "[release/1.2] Fix crash (#123)", []string{"acme/api#123"}},
+		{"start of text", "#7 backport", []string{"acme/api#7"}},
+		{"other repo", "Backport of acme/web#40", []string{"acme/web#40"}},
+		{"url", "Backports https://github.com/acme/api/pull/41", []string{"acme/api#41"}},
+		{"several", "Backport #1 and #2", []string{"acme/api#1", "acme/api#2"}},
+		{"not a reference", "issue#5 and v1.2#3", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mentionedRefs(tt.text, "acme/api"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mentionedRefs(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/log"
+)
+
+// HTTPPolicy controls timeouts and retries for every request to GitHub,
+// REST and GraphQL alike.
+type HTTPPolicy struct {
+	// Timeout bounds each attempt; zero means no timeout.
+	Timeout time.Duration
+	// MaxRetries is how many times a failed request is retried.
+	MaxRetries int
+	// Backoff is the wait before the first retry. Each later retry waits
+	// twice as long, up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultHTTPPolicy returns the policy used when none is configured.
+func DefaultHTTPPolicy() HTTPPolicy {
+	p, _ := NewHTTPPolicy(config.DefaultHTTPSettings())
+	return p
+}
+
+// NewHTTPPolicy parses the HTTP settings from the config.
+func NewHTTPPolicy(settings config.HTTPSettings) (HTTPPolicy, error) {
+	parse := func(name, value string) (time.Duration, error) {
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("invalid http %s %q: expected a duration such as 30s", name, value)
+		}
+		return d, nil
+	}
+
+	var p HTTPPolicy
+	var err error
+	if p.Timeout, err = parse("timeout", settings.Timeout); err != nil {
+		return HTTPPolicy{}, err
+	}
+	if p.Backoff, err = parse("retry_backoff", settings.RetryBackoff); err != nil {
+		return HTTPPolicy{}, err
+	}
+	if p.MaxBackoff, err = parse("retry_max_backoff", settings.RetryMaxBackoff); err != nil {
+		return HTTPPolicy{}, err
+	}
+	if settings.MaxRetries < 0 {
+		return HTTPPolicy{}, fmt.Errorf("invalid http max_retries %d: must not be negative", settings.MaxRetries)
+	}
+	p.MaxRetries = settings.MaxRetries
+	return p, nil
+}
+
+// WithHTTPPolicy sets the timeout and retry policy for REST and GraphQL
+// requests. Defaults to DefaultHTTPPolicy.
+func WithHTTPPolicy(p HTTPPolicy) ClientOption {
+	return func(c *Client) {
+		c.httpPolicy = p
+	}
+}
+
+// backoff returns the wait before retry number attempt (starting at 1),
+// with jitter so concurrent requests don't retry in lockstep.
+func (p HTTPPolicy) backoff(attempt int, jitter func() float64) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, p.MaxBackoff)
+	// Wait between half and all of the backoff
+	return d/2 + time.Duration(jitter()*float64(d/2))
+}
+
+// retryableKey marks a request context as safe to retry even though its
+// method is not idempotent.
+type retryableKey struct{}
+
+// withRetryable marks requests made with ctx as safe to retry, such as
+// GraphQL queries, which are sent as POST but change nothing.
+func withRetryable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryableKey{}, true)
+}
+
+// retryTransport applies an HTTPPolicy: each attempt gets its own timeout,
+// and idempotent requests that fail with a network error or a 5xx status
+// are retried. Rate limit responses are left to rateLimitTransport.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy HTTPPolicy
+	// sleep and jitter are replaced in tests.
+	sleep  func(context.Context, time.Duration) error
+	jitter func() float64
+}
+
+func newRetryTransport(base http.RoundTripper, policy HTTPPolicy) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{base: base, policy: policy, sleep: sleepContext, jitter: rand.Float64}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := t.policy.MaxRetries
+	if !isRetryable(req) {
+		retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.attempt(req)
+		if attempt >= retries || !shouldRetry(req.Context(), resp, err) {
+			return resp, err
+		}
+
+		wait := t.policy.backoff(attempt+1, t.jitter)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok && after <= t.policy.MaxBackoff {
+				wait = after
+			}
+			// Free the connection before waiting
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		}
+		log.Debug("retrying GitHub request", "url", req.URL.Path, "attempt", attempt+1, "wait", wait, "error", retryReason(resp, err))
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt sends req once, bounded by the policy's timeout. The timeout
+// keeps running while the caller reads the body, and ends when it closes.
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if t.policy.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.policy.Timeout)
+	}
+	r := req.Clone(ctx)
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+			return nil, fmt.Errorf("request timed out after %s: %w", t.policy.Timeout, err)
+		}
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// isRetryable reports whether sending req twice is harmless.
+func isRetryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	retryable, _ := req.Context().Value(retryableKey{}).(bool)
+	return retryable
+}
+
+// shouldRetry reports whether a failed attempt is worth repeating: network
+// errors, timeouts, and server errors are, but not errors from a canceled
+// run or from triage itself.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, ErrRateLimited) && !errors.Is(err, ErrUnauthorized) && !errors.Is(err, errNoInteraction)
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the wait a Retry-After header asks for, in seconds.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
+
+func retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnClose ends an attempt's timeout once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
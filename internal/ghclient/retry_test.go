@@ -0,0 +1,197 @@
+package ghclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+)
+
+func TestNewHTTPPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings config.HTTPSettings
+		want     HTTPPolicy
+		wantErr  string
+	}{
+		{
+			"defaults",
+			config.DefaultHTTPSettings(),
+			HTTPPolicy{Timeout: 30 * time.Second, MaxRetries: 2, Backoff: time.Second, MaxBackoff: 10 * time.Second},
+			"",
+		},
+		{
+			"no timeout or retries",
+			config.HTTPSettings{Timeout: "0s", MaxRetries: 0, RetryBackoff: "500ms", RetryMaxBackoff: "2s"},
+			HTTPPolicy{Backoff: 500 * time.Millisecond, MaxBackoff: 2 * time.Second},
+			"",
+		},
+		{"bad timeout", config.HTTPSettings{Timeout: "30", RetryBackoff: "1s", RetryMaxBackoff: "1s"}, HTTPPolicy{}, "invalid http timeout"},
+		{"negative backoff", config.HTTPSettings{Timeout: "1s", RetryBackoff: "-1s", RetryMaxBackoff: "1s"}, HTTPPolicy{}, "invalid http retry_backoff"},
+		{"negative retries", config.HTTPSettings{Timeout: "1s", MaxRetries: -1, RetryBackoff: "1s", RetryMaxBackoff: "1s"}, HTTPPolicy{}, "max_retries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewHTTPPolicy(tt.settings)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewHTTPPolicy() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("NewHTTPPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPPolicyBackoff(t *testing.T) {
+	p := HTTPPolicy{Backoff: time.Second, MaxBackoff: 10 * time.Second}
+	full := func() float64 { return 1 }
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		if got := p.backoff(attempt+1, full); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempt+1, got, want)
+		}
+	}
+	if got := p.backoff(1, func() float64 { return 0 }); got != 500*time.Millisecond {
+		t.Errorf("backoff with no jitter = %s, want half the backoff", got)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		retryable  bool
+		statuses   []int
+		retryAfter string
+		wantCalls  int
+		wantStatus int
+		wantWaits  []time.Duration
+	}{
+		{"GET retried after server error", http.MethodGet, false, []int{503, 200}, "", 2, 200, []time.Duration{time.Second}},
+		{"GET gives up after max retries", http.MethodGet, false, []int{502, 502, 502, 502}, "", 3, 502, []time.Duration{time.Second, 2 * time.Second}},
+		{"client error not retried", http.MethodGet, false, []int{404, 200}, "", 1, 404, nil},
+		{"POST not retried", http.MethodPost, false, []int{500, 200}, "", 1, 500, nil},
+		{"GraphQL query retried", http.MethodPost, true, []int{500, 200}, "", 2, 200, []time.Duration{time.Second}},
+		{"Retry-After honored", http.MethodGet, false, []int{503, 200}, "3", 2, 200, []time.Duration{3 * time.Second}},
+		{"long Retry-After ignored", http.MethodGet, false, []int{503, 200}, "600", 2, 200, []time.Duration{time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var bodies []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[len(bodies)-1])
+			}))
+			defer srv.Close()
+
+			var waits []time.Duration
+			rt := newRetryTransport(http.DefaultTransport, HTTPPolicy{MaxRetries: 2, Backoff: time.Second, MaxBackoff: 10 * time.Second})
+			rt.jitter = func() float64 { return 1 }
+			rt.sleep = func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			ctx := context.Background()
+			if tt.retryable {
+				ctx = withRetryable(ctx)
+			}
+			req, err := http.NewRequestWithContext(ctx, tt.method, srv.URL, strings.NewReader("query"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := (&http.Client{Transport: rt}).Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(bodies) != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", len(bodies), tt.wantCalls)
+			}
+			for i, b := range bodies {
+				if b != "query" {
+					t.Errorf("attempt %d body = %q, want it resent", i+1, b)
+				}
+			}
+			if len(waits) != len(tt.wantWaits) {
+				t.Fatalf("waits = %v, want %v", waits, tt.wantWaits)
+			}
+			for i := range waits {
+				if waits[i] != tt.wantWaits[i] {
+					t.Errorf("waits = %v, want %v", waits, tt.wantWaits)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportTimeout(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		if n == 1 {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	rt := newRetryTransport(http.DefaultTransport, HTTPPolicy{Timeout: 50 * time.Millisecond, MaxRetries: 1})
+	rt.sleep = func(context.Context, time.Duration) error { return nil }
+
+	resp, err := (&http.Client{Transport: rt}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v, want the timed out attempt retried", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || string(body) != "ok" {
+		t.Errorf("body = %q, %v", body, err)
+	}
+
+	rt.policy.MaxRetries = 0
+	mu.Lock()
+	calls = 0
+	mu.Unlock()
+	_, err = (&http.Client{Transport: rt}).Get(srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Errorf("Get() error = %v, want a timeout", err)
+	}
+}
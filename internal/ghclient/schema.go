@@ -0,0 +1,193 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/spiffcs/triage/internal/log"
+)
+
+// optionalFields are the "Type.field" names the enrichment, orphaned, and
+// preview queries use that older GitHub Enterprise Server releases lack.
+// Each is wrapped in a {{if .Has}} block in its query file.
+var optionalFields = []string{
+	"PullRequest.latestReviews",
+	"PullRequest.closingIssuesReferences",
+	"Commit.statusCheckRollup",
+	"Issue.blockedBy",
+	"PullRequest.projectItems",
+	"Issue.projectItems",
+}
+
+// schemaGaps holds the optional fields the server's schema lacks. A nil
+// schemaGaps assumes github.com's schema, where every field exists.
+type schemaGaps map[string]bool
+
+// Has reports whether the server's schema has field, given as "Type.field".
+// The query templates call it to leave out missing fields.
+func (g schemaGaps) Has(field string) bool {
+	return !g[field]
+}
+
+// equal reports whether g and o lack the same fields.
+func (g schemaGaps) equal(o schemaGaps) bool {
+	if len(g) != len(o) {
+		return false
+	}
+	for field := range g {
+		if !o[field] {
+			return false
+		}
+	}
+	return true
+}
+
+// schemaState records what the schema probe found. The zero value assumes
+// the full schema until a query fails on a field the server doesn't have.
+type schemaState struct {
+	mu     sync.Mutex
+	probed bool
+	gaps   schemaGaps
+	// projectsDenied is set once GitHub refused the project fields for
+	// lack of the read:project scope (see queryGaps).
+	projectsDenied atomic.Bool
+}
+
+// current returns the gaps queries should leave out.
+func (s *schemaState) current() schemaGaps {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gaps
+}
+
+// executeForSchema runs the query build returns for the server's known
+// schema gaps. When the server rejects a field it doesn't have, the schema
+// is probed once and the query rebuilt without the missing fields, so an
+// older GitHub Enterprise Server loses those fields rather than the whole
+// batch. Likewise a token without the read:project scope loses the project
+// fields.
+func (c *Client) executeForSchema(ctx context.Context, token string, build func(schemaGaps) (graphqlQuery, error)) (json.RawMessage, []graphqlError, error) {
+	gaps := c.schema.current()
+	for {
+		projects := c.projectStatus && !c.schema.projectsDenied.Load()
+		query, err := build(gaps)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, gqlErrs, err := c.executeGraphQL(ctx, query, token)
+		if err == nil && projects && hasProjectScopeError(gqlErrs) {
+			// Another batch may have denied them first; retry either way,
+			// since this query still selected them
+			c.denyProjects()
+			continue
+		}
+		if err != nil || !hasUndefinedField(gqlErrs) {
+			return data, gqlErrs, err
+		}
+
+		probed, probeErr := c.probeSchema(ctx, token)
+		if probeErr != nil {
+			log.Debug("GraphQL schema probe failed", "error", probeErr)
+			return data, gqlErrs, nil
+		}
+		if probed.equal(gaps) {
+			// The probe found nothing new to leave out, so a retry
+			// would fail the same way
+			return data, gqlErrs, nil
+		}
+		gaps = probed
+	}
+}
+
+// probeSchema asks the server which optional fields it has, once per
+// client. Concurrent batches wait for the first probe rather than each
+// sending their own, and a failed probe is not repeated.
+func (c *Client) probeSchema(ctx context.Context, token string) (schemaGaps, error) {
+	c.schema.mu.Lock()
+	defer c.schema.mu.Unlock()
+	if c.schema.probed {
+		return c.schema.gaps, nil
+	}
+	c.schema.probed = true
+
+	data, gqlErrs, err := c.executeGraphQL(ctx, c.queries.BuildSchemaProbeQuery(), token)
+	if err != nil {
+		return nil, err
+	}
+	if len(gqlErrs) > 0 {
+		return nil, fmt.Errorf("schema probe: %s", gqlErrs[0].Message)
+	}
+	gaps, err := parseSchemaProbe(data)
+	if err != nil {
+		return nil, err
+	}
+
+	c.schema.gaps = gaps
+	if len(gaps) > 0 {
+		missing := make([]string, 0, len(gaps))
+		for field := range gaps {
+			missing = append(missing, field)
+		}
+		sort.Strings(missing)
+		log.Info("GitHub server lacks some GraphQL fields; enrichment will leave them out", "fields", missing)
+	}
+	return gaps, nil
+}
+
+// parseSchemaProbe returns the optional fields missing from the types
+// listed in a schema probe response.
+func parseSchemaProbe(data json.RawMessage) (schemaGaps, error) {
+	type typeFields struct {
+		Fields []struct {
+			Name string `json:"name"`
+		} `json:"fields"`
+	}
+	var resp struct {
+		PullRequest *typeFields `json:"pullRequest"`
+		Commit      *typeFields `json:"commit"`
+		Issue       *typeFields `json:"issue"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse schema probe: %w", err)
+	}
+
+	have := make(map[string]bool)
+	for typeName, t := range map[string]*typeFields{
+		"PullRequest": resp.PullRequest,
+		"Commit":      resp.Commit,
+		"Issue":       resp.Issue,
+	} {
+		if t == nil {
+			continue
+		}
+		for _, f := range t.Fields {
+			have[typeName+"."+f.Name] = true
+		}
+	}
+
+	var gaps schemaGaps
+	for _, field := range optionalFields {
+		if !have[field] {
+			if gaps == nil {
+				gaps = make(schemaGaps)
+			}
+			gaps[field] = true
+		}
+	}
+	return gaps, nil
+}
+
+// hasUndefinedField reports whether the server rejected a query for
+// selecting a field its schema doesn't have.
+func hasUndefinedField(errs []graphqlError) bool {
+	for _, e := range errs {
+		if e.Extensions.Code == "undefinedField" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestParseSchemaProbe(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want schemaGaps
+	}{
+		{
+			name: "full schema",
+			data: `{
+				"pullRequest": {"fields": [{"name": "latestReviews"}, {"name": "closingIssuesReferences"}, {"name": "projectItems"}]},
+				"commit": {"fields": [{"name": "statusCheckRollup"}]},
+				"issue": {"fields": [{"name": "blockedBy"}, {"name": "projectItems"}]}
+			}`,
+			want: nil,
+		},
+		{
+			name: "older server",
+			data: `{
+				"pullRequest": {"fields": [{"name": "closingIssuesReferences"}, {"name": "projectItems"}]},
+				"commit": {"fields": [{"name": "oid"}]},
+				"issue": {"fields": [{"name": "blockedBy"}, {"name": "projectItems"}]}
+			}`,
+			want: schemaGaps{"PullRequest.latestReviews": true, "Commit.statusCheckRollup": true},
+		},
+		{
+			name: "unknown type",
+			data: `{
+				"pullRequest": {"fields": [{"name": "latestReviews"}, {"name": "closingIssuesReferences"}, {"name": "projectItems"}]},
+				"commit": {"fields": [{"name": "statusCheckRollup"}]},
+				"issue": null
+			}`,
+			want: schemaGaps{"Issue.blockedBy": true, "Issue.projectItems": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSchemaProbe(json.RawMessage(tt.data))
+			if err != nil {
+				t.Fatalf("parseSchemaProbe() error = %v", err)
+			}
+			if !got.equal(tt.want) {
+				t.Errorf("parseSchemaProbe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichLeavesOutFieldsTheServerLacks(t *testing.T) {
+	// The server is an older release without latestReviews or
+	// statusCheckRollup, and rejects queries selecting them
+	var probes, batches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(req.Query, "query RateLimit"):
+			_, _ = w.Write([]byte(`{"data": {"rateLimit": null}}`))
+		case strings.Contains(req.Query, "__type("):
+			probes.Add(1)
+			_, _ = w.Write([]byte(`{"data": {
+				"pullRequest": {"fields": [{"name": "number"}, {"name": "closingIssuesReferences"}]},
+				"commit": {"fields": [{"name": "oid"}]},
+				"issue": {"fields": [{"name": "blockedBy"}, {"name": "projectItems"}]}
+			}}`))
+		case strings.Contains(req.Query, "latestReviews") || strings.Contains(req.Query, "statusCheckRollup"):
+			batches.Add(1)
+			_, _ = w.Write([]byte(`{"errors": [{
+				"message": "Field 'latestReviews' doesn't exist on type 'PullRequest'",
+				"extensions": {"code": "undefinedField", "typeName": "PullRequest", "fieldName": "latestReviews"}
+			}]}`))
+		default:
+			batches.Add(1)
+			_, _ = w.Write([]byte(`{"data": {"pr0": {"pullRequest": {"number": 2, "state": "OPEN", "additions": 5}}}}`))
+		}
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	c := &Client{
+		queries:     mustLoadQueries(t),
+		graphqlHTTP: &http.Client{Transport: redirectTransport{target: target}},
+	}
+	newItems := func() []model.Item {
+		return []model.Item{{ID: "2", Repository: model.Repository{FullName: "org/repo"}, Subject: model.Subject{Type: model.SubjectPullRequest, URL: "https://api.github.com/repos/org/repo/pulls/2"}}}
+	}
+
+	items := newItems()
+	report, err := c.EnrichItemsGraphQL(context.Background(), items, "token", nil)
+	if err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if report.Enriched != 1 || len(report.Errors) != 0 {
+		t.Errorf("Enriched = %d, Errors = %v; want the retried batch to succeed", report.Enriched, report.Errors)
+	}
+	if pr, ok := items[0].Details.(*model.PRDetails); !ok || pr.Additions != 5 {
+		t.Errorf("Details = %#v, want PR details from the retried query", items[0].Details)
+	}
+	if probes.Load() != 1 || batches.Load() != 2 {
+		t.Errorf("probes = %d, batches = %d; want 1 probe and 2 batch requests", probes.Load(), batches.Load())
+	}
+
+	// Later batches leave the missing fields out from the start
+	if _, err := c.EnrichItemsGraphQL(context.Background(), newItems(), "token", nil); err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if probes.Load() != 1 || batches.Load() != 3 {
+		t.Errorf("probes = %d, batches = %d; want no second probe and one more batch request", probes.Load(), batches.Load())
+	}
+}
+
+func TestItemPreviewLeavesOutFieldsTheServerLacks(t *testing.T) {
+	var previews atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.Contains(req.Query, "__type("):
+			_, _ = w.Write([]byte(`{"data": {
+				"pullRequest": {"fields": [{"name": "number"}]},
+				"commit": {"fields": [{"name": "oid"}]},
+				"issue": {"fields": [{"name": "number"}]}
+			}}`))
+		case strings.Contains(req.Query, "statusCheckRollup"):
+			previews.Add(1)
+			_, _ = w.Write([]byte(`{"errors": [{
+				"message": "Field 'statusCheckRollup' doesn't exist on type 'Commit'",
+				"extensions": {"code": "undefinedField", "typeName": "Commit", "fieldName": "statusCheckRollup"}
+			}]}`))
+		default:
+			previews.Add(1)
+			_, _ = w.Write([]byte(`{"data": {"repository": {"issueOrPullRequest": {"body": "hello", "reviewDecision": "APPROVED"}}}}`))
+		}
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	c := &Client{
+		token:       "token",
+		queries:     mustLoadQueries(t),
+		graphqlHTTP: &http.Client{Transport: redirectTransport{target: target}},
+	}
+	preview, err := c.ItemPreview(context.Background(), "org", "repo", 2)
+	if err != nil {
+		t.Fatalf("ItemPreview() error = %v", err)
+	}
+	if preview.Body != "hello" {
+		t.Errorf("Body = %q, want the retried query's", preview.Body)
+	}
+	if previews.Load() != 2 {
+		t.Errorf("previews = %d, want the rejected query and its retry", previews.Load())
+	}
+}
@@ -0,0 +1,166 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// searchResultLimit is the most results GitHub's search API returns for a
+// query, however many match.
+const searchResultLimit = 1000
+
+// minSearchWindow is the narrowest creation-date range a search is split
+// into. Results in a narrower range than this that still exceed the limit
+// can't be reached.
+const minSearchWindow = time.Minute
+
+// searchEpoch predates every issue and PR on GitHub. It is where the first
+// date range starts when a search has to be split.
+var searchEpoch = time.Date(2007, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+// TruncatedSearch is a search that matched more results than GitHub would
+// return, even after splitting it by creation date.
+type TruncatedSearch struct {
+	Query string
+	// Missing is how many matching results were not returned.
+	Missing int
+}
+
+// truncationState records searches that came back incomplete since they
+// were last taken.
+type truncationState struct {
+	mu      sync.Mutex
+	missing map[string]int // query -> results not returned
+}
+
+// TruncatedSearches returns the searches that could not return every
+// matching result since the last call, sorted by query, and forgets them,
+// so each fetch of a long-running process reports only its own.
+func (c *Client) TruncatedSearches() []TruncatedSearch {
+	c.truncation.mu.Lock()
+	defer c.truncation.mu.Unlock()
+
+	searches := make([]TruncatedSearch, 0, len(c.truncation.missing))
+	for q, n := range c.truncation.missing {
+		searches = append(searches, TruncatedSearch{Query: q, Missing: n})
+	}
+	sort.Slice(searches, func(i, j int) bool { return searches[i].Query < searches[j].Query })
+	c.truncation.missing = nil
+	return searches
+}
+
+// recordTruncatedSearch records that missing results of query could not be
+// fetched.
+func (c *Client) recordTruncatedSearch(query string, missing int) {
+	log.Warn("search matched more results than GitHub returns", "query", query, "missing", missing)
+
+	c.truncation.mu.Lock()
+	defer c.truncation.mu.Unlock()
+	if c.truncation.missing == nil {
+		c.truncation.missing = make(map[string]int)
+	}
+	c.truncation.missing[query] += missing
+}
+
+// searchAll runs an issue search and converts every result, most recently
+// updated first. A query matching more than searchResultLimit results is
+// split into creation-date ranges that each fit, so the list is complete.
+func (c *Client) searchAll(ctx context.Context, query string, convert func(*gh.Issue) model.Item) ([]model.Item, error) {
+	issues, err := c.searchIssues(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]model.Item, 0, len(issues))
+	for _, issue := range issues {
+		items = append(items, convert(issue))
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].UpdatedAt.After(items[j].UpdatedAt) })
+	return items, nil
+}
+
+// searchIssues runs an issue search like searchAll, returning every result
+// once, unconverted.
+func (c *Client) searchIssues(ctx context.Context, query string) ([]*gh.Issue, error) {
+	issues, err := c.searchRange(ctx, query, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ranges share their boundary, so an issue created exactly there is
+	// returned twice
+	seen := make(map[int64]bool, len(issues))
+	unique := issues[:0]
+	for _, issue := range issues {
+		if seen[issue.GetID()] {
+			continue
+		}
+		seen[issue.GetID()] = true
+		unique = append(unique, issue)
+	}
+	return unique, nil
+}
+
+// searchRange returns the issues matching query that were created between
+// from and to, inclusive. Zero bounds leave the query unrestricted.
+func (c *Client) searchRange(ctx context.Context, query string, from, to time.Time) ([]*gh.Issue, error) {
+	q := query
+	if !from.IsZero() {
+		q = fmt.Sprintf("%s created:%s..%s", query, from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+	}
+	opts := &gh.SearchOptions{
+		Sort:  "updated",
+		Order: "desc",
+		ListOptions: gh.ListOptions{
+			PerPage: restPageSize,
+		},
+	}
+
+	var issues []*gh.Issue
+	for {
+		result, resp, err := c.client.Search.Issues(ctx, q, opts)
+		if err != nil {
+			return nil, err
+		}
+		total := result.GetTotal()
+
+		// Too many results to page through: search each half of the range
+		if opts.Page == 0 && total > searchResultLimit {
+			if from.IsZero() {
+				from, to = searchEpoch, time.Now()
+			}
+			if to.Sub(from) >= 2*minSearchWindow {
+				mid := from.Add(to.Sub(from) / 2)
+				log.Debug("splitting search", "query", query, "total", total, "from", from, "to", to)
+				newer, err := c.searchRange(ctx, query, mid, to)
+				if err != nil {
+					return nil, err
+				}
+				older, err := c.searchRange(ctx, query, from, mid)
+				if err != nil {
+					return nil, err
+				}
+				return append(newer, older...), nil
+			}
+		}
+
+		issues = append(issues, result.Issues...)
+		if result.GetIncompleteResults() {
+			log.Warn("GitHub search timed out; results may be incomplete", "query", q)
+		}
+		if resp.NextPage == 0 {
+			if missing := total - len(issues); missing > 0 {
+				c.recordTruncatedSearch(query, missing)
+			}
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, nil
+}
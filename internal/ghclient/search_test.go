@@ -0,0 +1,147 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+var createdQualifier = regexp.MustCompile(`created:(\S+)\.\.(\S+)`)
+
+// searchServer serves a search over issues created at the given times. Like
+// GitHub, it reports the full total_count but returns at most
+// searchResultLimit results, and honors created:from..to qualifiers.
+func searchServer(t *testing.T, created []time.Time) (*Client, func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		var matched []int
+		from, to := time.Time{}, time.Now().Add(time.Hour)
+		if m := createdQualifier.FindStringSubmatch(r.URL.Query().Get("q")); m != nil {
+			from, _ = time.Parse(time.RFC3339, m[1])
+			to, _ = time.Parse(time.RFC3339, m[2])
+		}
+		for i, c := range created {
+			if !c.Before(from) && !c.After(to) {
+				matched = append(matched, i)
+			}
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		start, end := (page-1)*restPageSize, min(page*restPageSize, len(matched), searchResultLimit)
+		if end < min(len(matched), searchResultLimit) {
+			next := *r.URL
+			q := next.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = q.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+		}
+
+		var issues []map[string]any
+		for _, i := range matched[min(start, end):end] {
+			issues = append(issues, map[string]any{
+				"id":         i + 1,
+				"number":     i + 1,
+				"created_at": created[i],
+				"updated_at": created[i],
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"total_count": len(matched), "items": issues})
+	}))
+	t.Cleanup(srv.Close)
+
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return &Client{client: client}, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestSearchAll(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	spread := func(n int, step time.Duration) []time.Time {
+		times := make([]time.Time, n)
+		for i := range times {
+			times[i] = start.Add(time.Duration(i) * step)
+		}
+		return times
+	}
+	same := func(n int) []time.Time {
+		times := make([]time.Time, n)
+		for i := range times {
+			times[i] = start
+		}
+		return times
+	}
+
+	tests := []struct {
+		name         string
+		created      []time.Time
+		wantItems    int
+		wantMissing  int
+		wantRequests int
+	}{
+		{"under the limit", spread(250, time.Hour), 250, 0, 3},
+		{"split by creation date", spread(2500, time.Hour), 2500, 0, 0},
+		{"too many created at once", same(1200), 1000, 200, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, requests := searchServer(t, tt.created)
+
+			items, err := c.searchAll(context.Background(), "is:pr is:open author:me", func(issue *gh.Issue) model.Item {
+				return model.Item{ID: strconv.FormatInt(issue.GetID(), 10), UpdatedAt: issue.GetUpdatedAt().Time}
+			})
+			if err != nil {
+				t.Fatalf("searchAll() error = %v", err)
+			}
+
+			if len(items) != tt.wantItems {
+				t.Errorf("got %d items, want %d", len(items), tt.wantItems)
+			}
+			for i := 1; i < len(items); i++ {
+				if items[i].UpdatedAt.After(items[i-1].UpdatedAt) {
+					t.Fatalf("items not sorted by update time at %d", i)
+				}
+			}
+			if tt.wantRequests > 0 && requests() != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", requests(), tt.wantRequests)
+			}
+
+			truncated := c.TruncatedSearches()
+			if tt.wantMissing == 0 {
+				if len(truncated) != 0 {
+					t.Errorf("TruncatedSearches() = %v, want none", truncated)
+				}
+				return
+			}
+			if len(truncated) != 1 || truncated[0].Missing != tt.wantMissing || truncated[0].Query != "is:pr is:open author:me" {
+				t.Errorf("TruncatedSearches() = %v, want %d missing", truncated, tt.wantMissing)
+			}
+			// Reported once, so the next fetch starts clean
+			if again := c.TruncatedSearches(); len(again) != 0 {
+				t.Errorf("second TruncatedSearches() = %v, want none", again)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package ghclient
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ssoHeader is set by GitHub when a token has not been authorized for an
+// organization that enforces SAML single sign-on.
+const ssoHeader = "X-GitHub-SSO"
+
+// SSOOrg is an organization whose SAML SSO the token has not been
+// authorized for. Its repositories are skipped rather than failing the run.
+type SSOOrg struct {
+	Org string
+	// URL is where the user can authorize the token for Org.
+	URL string
+}
+
+// ssoState records organizations that rejected a client's token for SSO.
+type ssoState struct {
+	mu   sync.Mutex
+	orgs map[string]string // org -> authorization URL
+}
+
+// SSORequired returns the organizations that rejected the client's token
+// for SAML SSO, sorted by name. They are kept per Client, so a client
+// built later, such as after the token was authorized, tries them again.
+func (c *Client) SSORequired() []SSOOrg {
+	return c.sso.required()
+}
+
+// required returns the organizations recorded, sorted by name.
+func (s *ssoState) required() []SSOOrg {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orgs := make([]SSOOrg, 0, len(s.orgs))
+	for org, u := range s.orgs {
+		orgs = append(orgs, SSOOrg{Org: org, URL: u})
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Org < orgs[j].Org })
+	return orgs
+}
+
+// record records that org rejected the token for SSO. A URL taken from
+// GitHub's response is preferred over the generic one.
+func (s *ssoState) record(org, authURL string) {
+	if s == nil || org == "" {
+		return
+	}
+	org = strings.ToLower(org)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.orgs == nil {
+		s.orgs = make(map[string]string)
+	}
+	if authURL == "" {
+		if _, ok := s.orgs[org]; ok {
+			return
+		}
+		authURL = ssoTokenSettingsURL
+	}
+	s.orgs[org] = authURL
+}
+
+// ssoTokenSettingsURL is where classic tokens are authorized for SSO
+// ("Configure SSO"), used when GitHub does not provide a specific
+// authorization URL.
+const ssoTokenSettingsURL = "https://github.com/settings/tokens"
+
+// recordResponse records an SSO rejection from a REST or GraphQL
+// response. GitHub sends "required; url=<authorization url>" when the
+// requested resource belongs to an org the token isn't authorized for.
+func (s *ssoState) recordResponse(req *http.Request, resp *http.Response) {
+	authURL, required := parseSSOHeader(resp.Header.Get(ssoHeader))
+	if !required {
+		return
+	}
+	org := ssoOrgFromURL(authURL)
+	if org == "" && req != nil {
+		org = ownerFromRepoPath(req.URL.Path)
+	}
+	s.record(org, authURL)
+}
+
+// parseSSOHeader parses the X-GitHub-SSO header, returning the
+// authorization URL and whether SSO authorization is required. Other
+// values, such as "partial-results; organizations=1,2", report false.
+func parseSSOHeader(value string) (authURL string, required bool) {
+	parts := strings.Split(value, ";")
+	if strings.TrimSpace(parts[0]) != "required" {
+		return "", false
+	}
+	for _, p := range parts[1:] {
+		if u, ok := strings.CutPrefix(strings.TrimSpace(p), "url="); ok {
+			authURL = u
+		}
+	}
+	return authURL, true
+}
+
+// ssoOrgFromURL extracts the org from an authorization URL of the form
+// https://github.com/orgs/<org>/sso?authorization_request=...
+func ssoOrgFromURL(authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "orgs" {
+		return parts[1]
+	}
+	return ""
+}
+
+// ownerFromRepoPath extracts the owner from a REST path of the form
+// /repos/<owner>/<repo>/...
+func ownerFromRepoPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "repos" {
+		return parts[1]
+	}
+	return ""
+}
+
+// isSAMLFailure reports whether a GraphQL error was caused by the org's
+// SAML enforcement rather than a real failure.
+func isSAMLFailure(e graphqlError) bool {
+	return e.Extensions.SAMLFailure || strings.Contains(e.Message, "SAML enforcement")
+}
+
+// recordSAMLFailures records SSO rejections reported as GraphQL errors and
+// returns how many there were. owners maps the first element of each
+// error's path (the query alias) to the repository owner queried under it.
+func (s *ssoState) recordSAMLFailures(errs []graphqlError, owners map[string]string) int {
+	n := 0
+	for _, e := range errs {
+		if !isSAMLFailure(e) {
+			continue
+		}
+		n++
+		if len(e.Path) == 0 {
+			continue
+		}
+		alias, _ := e.Path[0].(string)
+		s.record(owners[alias], "")
+	}
+	return n
+}
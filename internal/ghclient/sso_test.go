@@ -0,0 +1,157 @@
+package ghclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestParseSSOHeader(t *testing.T) {
+	tests := []struct {
+		value        string
+		wantURL      string
+		wantRequired bool
+	}{
+		{"", "", false},
+		{"partial-results; organizations=21955855,20582480", "", false},
+		{"required; url=https://github.com/orgs/acme/sso?authorization_request=abc", "https://github.com/orgs/acme/sso?authorization_request=abc", true},
+		{"required", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			gotURL, gotRequired := parseSSOHeader(tt.value)
+			if gotURL != tt.wantURL || gotRequired != tt.wantRequired {
+				t.Errorf("parseSSOHeader(%q) = (%q, %v), want (%q, %v)", tt.value, gotURL, gotRequired, tt.wantURL, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestRecordSSOResponse(t *testing.T) {
+	var state ssoState
+	req := httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/Other/repo/issues/1", nil)
+	for _, header := range []string{
+		"required; url=https://github.com/orgs/acme/sso?authorization_request=abc",
+		"required",
+		"partial-results; organizations=1",
+	} {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set(ssoHeader, header)
+		state.recordResponse(req, resp)
+	}
+
+	got := state.required()
+	want := []SSOOrg{
+		{Org: "acme", URL: "https://github.com/orgs/acme/sso?authorization_request=abc"},
+		{Org: "other", URL: ssoTokenSettingsURL},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SSORequired() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("SSORequired()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// redirectTransport sends every request to target, keeping the path.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestEnrichSkipsSSOProtectedOrgs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"data": {
+				"issue0": null,
+				"issue1": {"issue": {"number": 2, "state": "OPEN"}}
+			},
+			"errors": [{
+				"type": "FORBIDDEN",
+				"path": ["issue0"],
+				"extensions": {"saml_failure": true},
+				"message": "Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization."
+			}]
+		}`))
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	c := &Client{
+		queries:     mustLoadQueries(t),
+		graphqlHTTP: &http.Client{Transport: redirectTransport{target: target}},
+	}
+	items := []model.Item{
+		{ID: "1", Repository: model.Repository{FullName: "acme/private"}, Subject: model.Subject{Type: model.SubjectIssue, URL: "https://api.github.com/repos/acme/private/issues/1"}},
+		{ID: "2", Repository: model.Repository{FullName: "open/public"}, Subject: model.Subject{Type: model.SubjectIssue, URL: "https://api.github.com/repos/open/public/issues/2"}},
+	}
+
+	report, err := c.EnrichItemsGraphQL(context.Background(), items, "token", nil)
+	if err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if report.Enriched != 1 || items[1].Details == nil {
+		t.Errorf("enriched = %d, want the non-SSO item enriched", report.Enriched)
+	}
+	if items[0].Details != nil {
+		t.Error("expected the SSO-protected item to stay unenriched")
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Kind != ItemErrorSSO {
+		t.Errorf("report.Errors = %v, want one SSO error", report.Errors)
+	}
+	if got := c.SSORequired(); len(got) != 1 || got[0].Org != "acme" {
+		t.Errorf("SSORequired() = %v, want acme", got)
+	}
+	// A client built later, e.g. once the token is authorized, starts over
+	if got := (&Client{}).SSORequired(); len(got) != 0 {
+		t.Errorf("new client SSORequired() = %v, want none", got)
+	}
+}
+
+func TestEnrichMarksInaccessibleRepos(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"data": {"issue0": null, "issue1": {"issue": null}},
+			"errors": [
+				{"type": "NOT_FOUND", "path": ["issue0"], "message": "Could not resolve to a Repository with the name 'acme/secret'."},
+				{"type": "NOT_FOUND", "path": ["issue1", "issue"], "message": "Could not resolve to an Issue with the number of 9."}
+			]
+		}`))
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	c := &Client{
+		queries:     mustLoadQueries(t),
+		graphqlHTTP: &http.Client{Transport: redirectTransport{target: target}},
+	}
+	items := []model.Item{
+		{ID: "1", Repository: model.Repository{FullName: "acme/secret"}, Subject: model.Subject{Type: model.SubjectIssue, URL: "https://api.github.com/repos/acme/secret/issues/1"}},
+		{ID: "2", Repository: model.Repository{FullName: "open/public"}, Subject: model.Subject{Type: model.SubjectIssue, URL: "https://api.github.com/repos/open/public/issues/9"}},
+	}
+
+	if _, err := c.EnrichItemsGraphQL(context.Background(), items, "token", nil); err != nil {
+		t.Fatalf("EnrichItemsGraphQL() error = %v", err)
+	}
+	if !items[0].Inaccessible {
+		t.Error("expected the unreadable repo's item to be marked inaccessible")
+	}
+	if items[1].Inaccessible {
+		t.Error("a missing issue in a readable repo is not an access problem")
+	}
+}
@@ -0,0 +1,33 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+// Unwatch stops watching a repository, so it only notifies the user about
+// threads they participate in or are mentioned in ("Participating and
+// @mentions" on GitHub).
+func (c *Client) Unwatch(ctx context.Context, owner, repo string) error {
+	return c.mutate("unwatch", owner+"/"+repo, "", func() error {
+		_, err := c.client.Activity.DeleteRepositorySubscription(ctx, owner, repo)
+		if err != nil {
+			return fmt.Errorf("failed to unwatch %s/%s: %w", owner, repo, err)
+		}
+		return nil
+	})
+}
+
+// IgnoreRepo ignores a repository, so it sends the user no notifications
+// at all, not even mentions.
+func (c *Client) IgnoreRepo(ctx context.Context, owner, repo string) error {
+	return c.mutate("ignore", owner+"/"+repo, "", func() error {
+		_, _, err := c.client.Activity.SetRepositorySubscription(ctx, owner, repo, &gh.Subscription{Ignored: gh.Bool(true)})
+		if err != nil {
+			return fmt.Errorf("failed to ignore %s/%s: %w", owner, repo, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,57 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestRepoSubscriptions(t *testing.T) {
+	var method, path string
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, body = r.Method, r.URL.Path, nil
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ignored": true}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{client: client}
+
+	if err := c.Unwatch(context.Background(), "acme", "api"); err != nil {
+		t.Fatalf("Unwatch() error = %v", err)
+	}
+	if method != http.MethodDelete || path != "/repos/acme/api/subscription" {
+		t.Errorf("Unwatch() sent %s %s", method, path)
+	}
+
+	if err := c.IgnoreRepo(context.Background(), "acme", "api"); err != nil {
+		t.Fatalf("IgnoreRepo() error = %v", err)
+	}
+	if method != http.MethodPut || path != "/repos/acme/api/subscription" || body["ignored"] != true {
+		t.Errorf("IgnoreRepo() sent %s %s %v", method, path, body)
+	}
+
+	// Dry run sends nothing
+	method = ""
+	dry := &Client{client: client, dryRun: true}
+	if err := dry.Unwatch(context.Background(), "acme", "api"); !errors.Is(err, ErrDryRun) {
+		t.Errorf("Unwatch() in dry run error = %v, want ErrDryRun", err)
+	}
+	if method != "" {
+		t.Errorf("Unwatch() in dry run sent %s", method)
+	}
+}
@@ -0,0 +1,111 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// UserTeams returns the teams login belongs to, as lowercased
+// "org/team-slug", across the organizations the token can see. Without
+// the read:org scope GitHub returns no organizations, and so no teams.
+func (c *Client) UserTeams(ctx context.Context, login string) ([]string, error) {
+	query := c.queries.BuildTeamsQuery(login)
+	respData, _, err := c.executeGraphQL(ctx, query, c.token)
+	if err != nil {
+		return nil, err
+	}
+	return parseTeamsResponse(respData)
+}
+
+// parseTeamsResponse returns the team slugs in a teams query response.
+func parseTeamsResponse(data json.RawMessage) ([]string, error) {
+	var resp struct {
+		Viewer struct {
+			Organizations struct {
+				Nodes []struct {
+					Teams struct {
+						Nodes []struct {
+							CombinedSlug string `json:"combinedSlug"`
+						} `json:"nodes"`
+					} `json:"teams"`
+				} `json:"nodes"`
+			} `json:"organizations"`
+		} `json:"viewer"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse teams response: %w", err)
+	}
+
+	var teams []string
+	for _, org := range resp.Viewer.Organizations.Nodes {
+		for _, team := range org.Teams.Nodes {
+			if team.CombinedSlug != "" {
+				teams = append(teams, strings.ToLower(team.CombinedSlug))
+			}
+		}
+	}
+	return teams, nil
+}
+
+// TeamMembers returns the logins, lowercased, of the members of org's team
+// slug and its child teams. It fails when the team doesn't exist or the
+// token can't see it.
+func (c *Client) TeamMembers(ctx context.Context, org, slug string) ([]string, error) {
+	var members []string
+	after := ""
+	for {
+		query := c.queries.BuildTeamMembersQuery(org, slug, after)
+		respData, _, err := c.executeGraphQL(ctx, query, c.token)
+		if err != nil {
+			return nil, err
+		}
+		page, next, err := parseTeamMembersResponse(respData)
+		if err != nil {
+			return nil, fmt.Errorf("team %s/%s: %w", org, slug, err)
+		}
+		members = append(members, page...)
+		if next == "" {
+			return members, nil
+		}
+		after = next
+	}
+}
+
+// parseTeamMembersResponse returns the member logins in a team members
+// query response, and the cursor of the next page, empty on the last one.
+func parseTeamMembersResponse(data json.RawMessage) ([]string, string, error) {
+	var resp struct {
+		Organization *struct {
+			Team *struct {
+				Members struct {
+					Nodes []struct {
+						Login string `json:"login"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"members"`
+			} `json:"team"`
+		} `json:"organization"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, "", fmt.Errorf("failed to parse team members response: %w", err)
+	}
+	if resp.Organization == nil || resp.Organization.Team == nil {
+		return nil, "", errors.New("team not found, or not visible without the read:org scope")
+	}
+
+	members := resp.Organization.Team.Members
+	logins := make([]string, 0, len(members.Nodes))
+	for _, m := range members.Nodes {
+		logins = append(logins, strings.ToLower(m.Login))
+	}
+	if !members.PageInfo.HasNextPage {
+		return logins, "", nil
+	}
+	return logins, members.PageInfo.EndCursor, nil
+}
@@ -0,0 +1,81 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseTeamsResponse(t *testing.T) {
+	data := json.RawMessage(`{"viewer": {"organizations": {"nodes": [
+		{"teams": {"nodes": [{"combinedSlug": "acme/Platform"}, {"combinedSlug": "acme/release"}]}},
+		{"teams": {"nodes": []}},
+		{"teams": {"nodes": [{"combinedSlug": "widgets/maintainers"}]}}
+	]}}}`)
+
+	teams, err := parseTeamsResponse(data)
+	if err != nil {
+		t.Fatalf("parseTeamsResponse() error = %v", err)
+	}
+	want := []string{"acme/platform", "acme/release", "widgets/maintainers"}
+	if !reflect.DeepEqual(teams, want) {
+		t.Errorf("parseTeamsResponse() = %v, want %v", teams, want)
+	}
+}
+
+func TestParseTeamsResponseNoOrganizations(t *testing.T) {
+	teams, err := parseTeamsResponse(json.RawMessage(`{"viewer": {"organizations": {"nodes": []}}}`))
+	if err != nil {
+		t.Fatalf("parseTeamsResponse() error = %v", err)
+	}
+	if len(teams) != 0 {
+		t.Errorf("parseTeamsResponse() = %v, want none", teams)
+	}
+}
+
+func TestParseTeamMembersResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantLogs []string
+		wantNext string
+		wantErr  bool
+	}{
+		{
+			name:     "last page",
+			data:     `{"organization": {"team": {"members": {"nodes": [{"login": "Alice"}, {"login": "bob"}], "pageInfo": {"hasNextPage": false, "endCursor": "abc"}}}}}`,
+			wantLogs: []string{"alice", "bob"},
+		},
+		{
+			name:     "more pages",
+			data:     `{"organization": {"team": {"members": {"nodes": [{"login": "carol"}], "pageInfo": {"hasNextPage": true, "endCursor": "abc"}}}}}`,
+			wantLogs: []string{"carol"},
+			wantNext: "abc",
+		},
+		{
+			name:    "unknown team",
+			data:    `{"organization": {"team": null}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown organization",
+			data:    `{"organization": null}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logins, next, err := parseTeamMembersResponse(json.RawMessage(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTeamMembersResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(logins, tt.wantLogs) || next != tt.wantNext {
+				t.Errorf("parseTeamMembersResponse() = %v, %q, want %v, %q", logins, next, tt.wantLogs, tt.wantNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// UpstreamWatch declares that work in Blocks is waiting on Repo. The wait
+// is over when Repo publishes the release tagged Release, publishes any
+// release after the one tagged After, or closes issue or PR Number.
+type UpstreamWatch struct {
+	Repo    string
+	Blocks  string
+	Release string
+	After   string
+	Number  int
+}
+
+// ListResolvedUpstreams checks each watch and returns an item for every
+// one whose blocker has resolved. Watches still waiting are left out.
+func (c *Client) ListResolvedUpstreams(ctx context.Context, watches []UpstreamWatch) ([]model.Item, error) {
+	var items []model.Item
+	for _, w := range watches {
+		item, resolved, err := c.checkUpstream(ctx, w)
+		if err != nil {
+			if errors.Is(err, ErrRateLimited) {
+				return items, err
+			}
+			log.Debug("failed to check upstream", "repo", w.Repo, "error", err)
+			continue
+		}
+		if resolved {
+			items = append(items, item)
+		}
+	}
+
+	if c.Unauthorized() {
+		return items, ErrUnauthorized
+	}
+	return items, nil
+}
+
+// checkUpstream reports whether w's blocker has resolved and, if so,
+// returns the item raised for it.
+func (c *Client) checkUpstream(ctx context.Context, w UpstreamWatch) (model.Item, bool, error) {
+	owner, repo, ok := strings.Cut(w.Repo, "/")
+	if !ok || owner == "" || repo == "" {
+		return model.Item{}, false, fmt.Errorf("invalid upstream repo %q", w.Repo)
+	}
+
+	if w.Number > 0 {
+		issue, _, err := c.client.Issues.Get(ctx, owner, repo, w.Number)
+		if err != nil {
+			return model.Item{}, false, fmt.Errorf("failed to get %s#%d: %w", w.Repo, w.Number, err)
+		}
+		if issue.GetState() != model.StateClosed {
+			return model.Item{}, false, nil
+		}
+		subjectType := model.SubjectIssue
+		if issue.IsPullRequest() {
+			subjectType = model.SubjectPullRequest
+		}
+		ref := fmt.Sprintf("%s#%d", w.Repo, w.Number)
+		title := fmt.Sprintf("Upstream %s closed: %s", ref, issue.GetTitle())
+		return upstreamItem(w, ref, title, subjectType, issue.GetHTMLURL(), issue.GetClosedAt().Time), true, nil
+	}
+
+	var release *gh.RepositoryRelease
+	var err error
+	if w.Release != "" {
+		release, _, err = c.client.Repositories.GetReleaseByTag(ctx, owner, repo, w.Release)
+	} else {
+		release, _, err = c.client.Repositories.GetLatestRelease(ctx, owner, repo)
+	}
+	if err != nil {
+		// No such release yet
+		var errResp *gh.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+			return model.Item{}, false, nil
+		}
+		return model.Item{}, false, fmt.Errorf("failed to get release of %s: %w", w.Repo, err)
+	}
+	if release.GetDraft() {
+		return model.Item{}, false, nil
+	}
+	if w.Release == "" {
+		newer, err := c.releasedAfter(ctx, owner, repo, release, w.After)
+		if err != nil {
+			return model.Item{}, false, err
+		}
+		if !newer {
+			return model.Item{}, false, nil
+		}
+	}
+	tag := release.GetTagName()
+	title := fmt.Sprintf("Upstream %s released %s", w.Repo, tag)
+	return upstreamItem(w, w.Repo+"@"+tag, title, model.SubjectRelease, release.GetHTMLURL(), release.GetPublishedAt().Time), true, nil
+}
+
+// releasedAfter reports whether release is newer than the one tagged
+// after. Version tags are compared as versions, so a watch isn't resolved
+// by an older or rolled back release; other tags by when the two releases
+// were published. A release counts as newer when after was never
+// published.
+func (c *Client) releasedAfter(ctx context.Context, owner, repo string, release *gh.RepositoryRelease, after string) (bool, error) {
+	tag := release.GetTagName()
+	if tag == after {
+		return false, nil
+	}
+	if cmp, ok := compareVersionTags(tag, after); ok {
+		return cmp > 0, nil
+	}
+
+	prev, _, err := c.client.Repositories.GetReleaseByTag(ctx, owner, repo, after)
+	if err != nil {
+		var errResp *gh.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get release %s of %s/%s: %w", after, owner, repo, err)
+	}
+	return release.GetPublishedAt().After(prev.GetPublishedAt().Time), nil
+}
+
+// compareVersionTags compares two version tags such as v1.2.3, 1.2, or
+// v2.0.0-rc.1, returning -1, 0, or 1 as a is older, the same, or newer. ok
+// is false when either tag isn't a version. A pre-release is older than
+// its release, and pre-releases compare by their suffix.
+func compareVersionTags(a, b string) (cmp int, ok bool) {
+	va, ok := parseVersionTag(a)
+	if !ok {
+		return 0, false
+	}
+	vb, ok := parseVersionTag(b)
+	if !ok {
+		return 0, false
+	}
+	for i := range va.core {
+		if va.core[i] != vb.core[i] {
+			if va.core[i] < vb.core[i] {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	switch {
+	case va.pre == vb.pre:
+		return 0, true
+	case va.pre == "":
+		return 1, true
+	case vb.pre == "":
+		return -1, true
+	case va.pre < vb.pre:
+		return -1, true
+	default:
+		return 1, true
+	}
+}
+
+// versionTag is a parsed version tag; missing minor and patch numbers
+// are zero.
+type versionTag struct {
+	core [3]int
+	pre  string
+}
+
+// parseVersionTag parses tag, reporting whether it is a version.
+func parseVersionTag(tag string) (versionTag, bool) {
+	v := strings.TrimPrefix(tag, "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return versionTag{}, false
+	}
+	var t versionTag
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return versionTag{}, false
+		}
+		t.core[i] = n
+	}
+	t.pre = pre
+	return t, true
+}
+
+// upstreamItem builds the item raised in Blocks when the blocker ref
+// resolved at the given time.
+func upstreamItem(w UpstreamWatch, ref, title string, subjectType model.SubjectType, htmlURL string, at time.Time) model.Item {
+	_, name, _ := strings.Cut(w.Blocks, "/")
+	return model.Item{
+		ID:        "upstream-" + ref + "-" + w.Blocks,
+		Reason:    model.ReasonUpstream,
+		Unread:    true,
+		UpdatedAt: at,
+		Repository: model.Repository{
+			Name:     name,
+			FullName: w.Blocks,
+			HTMLURL:  "https://github.com/" + w.Blocks,
+		},
+		Subject: model.Subject{
+			Title: title,
+			Type:  subjectType,
+		},
+		URL:     htmlURL,
+		HTMLURL: htmlURL,
+	}
+}
@@ -0,0 +1,161 @@
+package ghclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func upstreamServer(t *testing.T) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/lib/releases/tags/v2.0.0":
+			_, _ = w.Write([]byte(`{"tag_name":"v2.0.0","html_url":"https://github.com/acme/lib/releases/tag/v2.0.0","published_at":"2026-10-01T12:00:00Z"}`))
+		case "/repos/acme/lib/releases/latest":
+			_, _ = w.Write([]byte(`{"tag_name":"v2.0.0","published_at":"2026-10-01T12:00:00Z"}`))
+		case "/repos/acme/tools/releases/latest":
+			_, _ = w.Write([]byte(`{"tag_name":"nightly-b","published_at":"2026-10-01T12:00:00Z"}`))
+		case "/repos/acme/tools/releases/tags/nightly-a":
+			_, _ = w.Write([]byte(`{"tag_name":"nightly-a","published_at":"2026-09-01T12:00:00Z"}`))
+		case "/repos/acme/tools/releases/tags/nightly-c":
+			_, _ = w.Write([]byte(`{"tag_name":"nightly-c","published_at":"2026-10-05T12:00:00Z"}`))
+		case "/repos/acme/api/issues/412":
+			_, _ = w.Write([]byte(`{"number":412,"state":"closed","title":"Add batch endpoint","html_url":"https://github.com/acme/api/pull/412","closed_at":"2026-10-02T08:00:00Z","pull_request":{"url":"x"}}`))
+		case "/repos/acme/api/issues/413":
+			_, _ = w.Write([]byte(`{"number":413,"state":"open","title":"Still going"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return &Client{client: client}
+}
+
+func TestListResolvedUpstreams(t *testing.T) {
+	c := upstreamServer(t)
+
+	tests := []struct {
+		name      string
+		watch     UpstreamWatch
+		wantTitle string
+		wantType  model.SubjectType
+	}{
+		{
+			name:      "release published",
+			watch:     UpstreamWatch{Repo: "acme/lib", Blocks: "acme/app", Release: "v2.0.0"},
+			wantTitle: "Upstream acme/lib released v2.0.0",
+			wantType:  model.SubjectRelease,
+		},
+		{
+			name:  "release not published yet",
+			watch: UpstreamWatch{Repo: "acme/lib", Blocks: "acme/app", Release: "v3.0.0"},
+		},
+		{
+			name:      "release after tag",
+			watch:     UpstreamWatch{Repo: "acme/lib", Blocks: "acme/app", After: "v1.9.0"},
+			wantTitle: "Upstream acme/lib released v2.0.0",
+			wantType:  model.SubjectRelease,
+		},
+		{
+			name:  "latest release is still the one waited past",
+			watch: UpstreamWatch{Repo: "acme/lib", Blocks: "acme/app", After: "v2.0.0"},
+		},
+		{
+			name:  "latest release is older than the one waited past",
+			watch: UpstreamWatch{Repo: "acme/lib", Blocks: "acme/app", After: "v2.1.0"},
+		},
+		{
+			name:      "non-version tag published later",
+			watch:     UpstreamWatch{Repo: "acme/tools", Blocks: "acme/app", After: "nightly-a"},
+			wantTitle: "Upstream acme/tools released nightly-b",
+			wantType:  model.SubjectRelease,
+		},
+		{
+			name:  "non-version tag published earlier",
+			watch: UpstreamWatch{Repo: "acme/tools", Blocks: "acme/app", After: "nightly-c"},
+		},
+		{
+			name:  "no releases",
+			watch: UpstreamWatch{Repo: "acme/empty", Blocks: "acme/app"},
+		},
+		{
+			name:      "PR closed",
+			watch:     UpstreamWatch{Repo: "acme/api", Blocks: "acme/web", Number: 412},
+			wantTitle: "Upstream acme/api#412 closed: Add batch endpoint",
+			wantType:  model.SubjectPullRequest,
+		},
+		{
+			name:  "issue still open",
+			watch: UpstreamWatch{Repo: "acme/api", Blocks: "acme/web", Number: 413},
+		},
+		{
+			name:  "invalid repo",
+			watch: UpstreamWatch{Repo: "acme", Blocks: "acme/web", Number: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := c.ListResolvedUpstreams(context.Background(), []UpstreamWatch{tt.watch})
+			if err != nil {
+				t.Fatalf("ListResolvedUpstreams() error = %v", err)
+			}
+			if tt.wantTitle == "" {
+				if len(items) != 0 {
+					t.Errorf("got %+v, want nothing while still waiting", items)
+				}
+				return
+			}
+			if len(items) != 1 {
+				t.Fatalf("got %d items, want 1", len(items))
+			}
+			item := items[0]
+			if item.Subject.Title != tt.wantTitle || item.Subject.Type != tt.wantType {
+				t.Errorf("subject = %+v, want %q (%s)", item.Subject, tt.wantTitle, tt.wantType)
+			}
+			if item.Reason != model.ReasonUpstream || item.Repository.FullName != tt.watch.Blocks {
+				t.Errorf("reason = %s, repo = %s, want upstream in %s", item.Reason, item.Repository.FullName, tt.watch.Blocks)
+			}
+			if item.UpdatedAt.IsZero() {
+				t.Error("UpdatedAt is zero, want when the blocker resolved")
+			}
+		})
+	}
+}
+
+func TestCompareVersionTags(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{"v2.0.0", "v1.9.0", 1, true},
+		{"v1.10.0", "v1.9.0", 1, true},
+		{"v1.9.0", "v1.10.0", -1, true},
+		{"1.2", "v1.2.0", 0, true},
+		{"v2.0.0", "v2.0.0-rc.1", 1, true},
+		{"v2.0.0-rc.2", "v2.0.0-rc.1", 1, true},
+		{"v2.0.0+build.5", "v2.0.0", 0, true},
+		{"nightly-b", "v1.0.0", 0, false},
+		{"v1.0.0", "release-2026", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			got, ok := compareVersionTags(tt.a, tt.b)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("compareVersionTags(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
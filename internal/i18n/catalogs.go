@@ -0,0 +1,65 @@
+package i18n
+
+// catalogs holds the labels for each locale. Labels are shown in
+// fixed-width columns, so translations should stay close to the English
+// length; longer ones are truncated.
+var catalogs = map[string]map[Key]string{
+	"en": {
+		HeaderPriority:   "Priority",
+		HeaderType:       "Type",
+		HeaderAuthor:     "Author",
+		HeaderAssigned:   "Assigned",
+		HeaderCI:         "CI",
+		HeaderRepository: "Repository",
+		HeaderTitle:      "Title",
+		HeaderStatus:     "Status",
+		HeaderSignal:     "Signal",
+		HeaderYou:        "You",
+		HeaderAge:        "Age",
+
+		PriorityUrgent:    "Urgent",
+		PriorityImportant: "Important",
+		PriorityQuickWin:  "Quick Win",
+		PriorityNotable:   "Notable",
+		PriorityFYI:       "FYI",
+		PriorityArchive:   "Archive",
+	},
+	"de": {
+		HeaderPriority:   "Priorität",
+		HeaderType:       "Typ",
+		HeaderAuthor:     "Autor",
+		HeaderAssigned:   "Zugewiesen",
+		HeaderRepository: "Repository",
+		HeaderTitle:      "Titel",
+		HeaderStatus:     "Status",
+		HeaderSignal:     "Signal",
+		HeaderYou:        "Du",
+		HeaderAge:        "Alter",
+
+		PriorityUrgent:    "Dringend",
+		PriorityImportant: "Wichtig",
+		PriorityQuickWin:  "Schnell",
+		PriorityNotable:   "Relevant",
+		PriorityFYI:       "Info",
+		PriorityArchive:   "Archiv",
+	},
+	"es": {
+		HeaderPriority:   "Prioridad",
+		HeaderType:       "Tipo",
+		HeaderAuthor:     "Autor",
+		HeaderAssigned:   "Asignado",
+		HeaderRepository: "Repositorio",
+		HeaderTitle:      "Título",
+		HeaderStatus:     "Estado",
+		HeaderSignal:     "Señal",
+		HeaderYou:        "Tú",
+		HeaderAge:        "Edad",
+
+		PriorityUrgent:    "Urgente",
+		PriorityImportant: "Importante",
+		PriorityQuickWin:  "Fácil",
+		PriorityNotable:   "Destacado",
+		PriorityFYI:       "Info",
+		PriorityArchive:   "Archivo",
+	},
+}
@@ -0,0 +1,129 @@
+// Package i18n translates triage's user-facing labels. The locale is chosen
+// once at startup from config or the environment; labels without a
+// translation fall back to English.
+package i18n
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Key identifies a translatable label.
+type Key string
+
+// Column headers
+const (
+	HeaderPriority   Key = "header.priority"
+	HeaderType       Key = "header.type"
+	HeaderAuthor     Key = "header.author"
+	HeaderAssigned   Key = "header.assigned"
+	HeaderCI         Key = "header.ci"
+	HeaderRepository Key = "header.repository"
+	HeaderTitle      Key = "header.title"
+	HeaderStatus     Key = "header.status"
+	HeaderSignal     Key = "header.signal"
+	HeaderYou        Key = "header.you"
+	HeaderAge        Key = "header.age"
+)
+
+// Priority names
+const (
+	PriorityUrgent    Key = "priority.urgent"
+	PriorityImportant Key = "priority.important"
+	PriorityQuickWin  Key = "priority.quick_win"
+	PriorityNotable   Key = "priority.notable"
+	PriorityFYI       Key = "priority.fyi"
+	PriorityArchive   Key = "priority.archive"
+)
+
+// DefaultLocale is the locale used when none is configured or the
+// configured one has no catalog.
+const DefaultLocale = "en"
+
+var (
+	mu     sync.RWMutex
+	locale = DefaultLocale
+)
+
+// Initialize selects the locale used by T. An empty configured locale is
+// taken from LC_ALL, LC_MESSAGES, or LANG. It returns the locale selected.
+func Initialize(configured string) string {
+	selected := Detect(configured, os.Getenv)
+
+	mu.Lock()
+	defer mu.Unlock()
+	locale = selected
+	return selected
+}
+
+// Detect picks the catalog for the configured locale, or for the first
+// locale set in the environment when configured is empty. Locales such as
+// "de_DE.UTF-8" match on their language; anything without a catalog
+// selects DefaultLocale.
+func Detect(configured string, getenv func(string) string) string {
+	candidates := []string{configured}
+	if strings.TrimSpace(configured) == "" {
+		candidates = []string{getenv("LC_ALL"), getenv("LC_MESSAGES"), getenv("LANG")}
+	}
+	for _, c := range candidates {
+		name := normalize(c)
+		if name == "" {
+			continue
+		}
+		if _, ok := catalogs[name]; ok {
+			return name
+		}
+		lang, _, _ := strings.Cut(name, "_")
+		if _, ok := catalogs[lang]; ok {
+			return lang
+		}
+		// The first locale set decides, as it does for other programs
+		return DefaultLocale
+	}
+	return DefaultLocale
+}
+
+// normalize reduces a POSIX locale such as "pt_BR.UTF-8@euro" to "pt_br".
+// The "C" and "POSIX" locales normalize to "".
+func normalize(s string) string {
+	s = strings.TrimSpace(s)
+	s, _, _ = strings.Cut(s, ".")
+	s, _, _ = strings.Cut(s, "@")
+	s = strings.ToLower(strings.ReplaceAll(s, "-", "_"))
+	if s == "c" || s == "posix" {
+		return ""
+	}
+	return s
+}
+
+// Locale returns the selected locale.
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// Locales returns the locales with a catalog, sorted.
+func Locales() []string {
+	names := make([]string, 0, len(catalogs))
+	for name := range catalogs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// T returns the label for key in the selected locale.
+func T(key Key) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if msg, ok := catalogs[locale][key]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[DefaultLocale][key]; ok {
+		return msg
+	}
+	return string(key)
+}
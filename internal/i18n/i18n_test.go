@@ -0,0 +1,52 @@
+package i18n
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		env        map[string]string
+		want       string
+	}{
+		{"configured", "de", map[string]string{"LANG": "es_ES.UTF-8"}, "de"},
+		{"configured with region", "es-MX", nil, "es"},
+		{"LANG", "", map[string]string{"LANG": "de_DE.UTF-8"}, "de"},
+		{"LC_ALL wins", "", map[string]string{"LC_ALL": "es_ES", "LANG": "de_DE.UTF-8"}, "es"},
+		{"POSIX locale is skipped", "", map[string]string{"LC_ALL": "C", "LANG": "de_DE.UTF-8"}, "de"},
+		{"no catalog", "", map[string]string{"LANG": "fr_FR.UTF-8", "LC_MESSAGES": ""}, DefaultLocale},
+		{"unset", "", nil, DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(tt.configured, func(k string) string { return tt.env[k] })
+			if got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { Initialize(DefaultLocale) })
+
+	Initialize("de")
+	if got := T(PriorityUrgent); got != "Dringend" {
+		t.Errorf("T(PriorityUrgent) = %q, want Dringend", got)
+	}
+	// Missing translations fall back to English
+	if got := T(HeaderCI); got != "CI" {
+		t.Errorf("T(HeaderCI) = %q, want the English label", got)
+	}
+}
+
+func TestCatalogsCoverEnglish(t *testing.T) {
+	for locale, messages := range catalogs {
+		for key := range messages {
+			if _, ok := catalogs[DefaultLocale][key]; !ok {
+				t.Errorf("%s translates %q, which has no English label", locale, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package menubar renders prioritized items as the output of an xbar or
+// SwiftBar plugin: counts in the menu bar and the top items in its
+// dropdown, each opening on GitHub.
+package menubar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// titleWidth is the most columns of an item's title shown in the dropdown.
+const titleWidth = 60
+
+// Options controls the plugin output.
+type Options struct {
+	// Limit is how many items the dropdown lists; 0 lists them all
+	Limit int
+	// Command is the triage binary "Open triage" runs in a terminal; empty
+	// leaves that entry out
+	Command string
+}
+
+// Render returns the plugin output for items, which are listed in the
+// order given under their priority.
+func Render(items []triage.PrioritizedItem, opts Options) string {
+	var b strings.Builder
+	b.WriteString(barTitle(items) + "\n---\n")
+	if len(items) == 0 {
+		b.WriteString("Nothing needs your attention\n")
+	}
+
+	shown := items
+	if opts.Limit > 0 && len(shown) > opts.Limit {
+		shown = shown[:opts.Limit]
+	}
+	var current triage.PriorityLevel
+	for _, item := range shown {
+		if item.Priority != current {
+			current = item.Priority
+			fmt.Fprintf(&b, "%s (%d)\n", current.Display(), count(items, current))
+		}
+		ref := escape(format.ItemRef(item.Repository.FullName, item.Number))
+		line := ref + " " + escape(title(item))
+		action := escape(format.Sanitize(item.ActionNeeded))
+		if url := link(item); url != "" {
+			fmt.Fprintf(&b, "%s | href=%s\n", line, url)
+			if action != "" {
+				fmt.Fprintf(&b, "%s: %s | href=%s alternate=true\n", action, ref, url)
+			}
+		} else {
+			b.WriteString(line + "\n")
+		}
+	}
+
+	b.WriteString("---\n")
+	if opts.Command != "" {
+		open := "Open triage"
+		if more := len(items) - len(shown); more > 0 {
+			open = fmt.Sprintf("%d more in triage", more)
+		}
+		fmt.Fprintf(&b, "%s | bash=%q terminal=true\n", open, opts.Command)
+	}
+	b.WriteString("Refresh | refresh=true\n")
+	return b.String()
+}
+
+// Failed returns the plugin output when items could not be fetched, so the
+// bar shows something is wrong instead of going blank.
+func Failed(err error) string {
+	return "triage ⚠\n---\n" +
+		escape("Error: "+format.Sanitize(err.Error())) + " | color=red\n" +
+		"---\nRefresh | refresh=true\n"
+}
+
+// barTitle is the menu bar text: the urgent items and all items, e.g.
+// "2 urgent · 9".
+func barTitle(items []triage.PrioritizedItem) string {
+	if len(items) == 0 {
+		return "✓"
+	}
+	if urgent := count(items, triage.PriorityUrgent); urgent > 0 {
+		return fmt.Sprintf("%d urgent · %d | color=red", urgent, len(items))
+	}
+	return fmt.Sprintf("%d", len(items))
+}
+
+func count(items []triage.PrioritizedItem, p triage.PriorityLevel) int {
+	n := 0
+	for _, item := range items {
+		if item.Priority == p {
+			n++
+		}
+	}
+	return n
+}
+
+// escape keeps text from being read as the plugin's parameters, which
+// follow the first "|" on a line.
+func escape(s string) string {
+	return strings.ReplaceAll(s, "|", "¦")
+}
+
+// title returns the item's title, cut to fit the dropdown.
+func title(item triage.PrioritizedItem) string {
+	t, _ := format.TruncateToWidth(format.Sanitize(item.Subject.Title), titleWidth)
+	return t
+}
+
+// link returns the web URL of an item, falling back to its repository.
+// URLs with spaces would end the parameter early, so they are left out.
+func link(item triage.PrioritizedItem) string {
+	url := format.ItemURL(item.HTMLURL, item.Repository.HTMLURL)
+	if strings.ContainsAny(url, " |") {
+		return ""
+	}
+	return url
+}
@@ -0,0 +1,90 @@
+package menubar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func menubarItems() []triage.PrioritizedItem {
+	return []triage.PrioritizedItem{
+		{Priority: triage.PriorityUrgent, ActionNeeded: "Review PR", Item: model.Item{
+			Number:     12,
+			HTMLURL:    "https://github.com/acme/api/pull/12",
+			Subject:    model.Subject{Title: "Split a | b"},
+			Repository: model.Repository{FullName: "acme/api"},
+		}},
+		{Priority: triage.PriorityUrgent, Item: model.Item{
+			Number:     13,
+			HTMLURL:    "https://github.com/acme/api/pull/13",
+			Subject:    model.Subject{Title: "Bump deps"},
+			Repository: model.Repository{FullName: "acme/api"},
+		}},
+		{Priority: triage.PriorityFYI, Item: model.Item{
+			Subject:    model.Subject{Title: "Flaky test\x1b[2J"},
+			Repository: model.Repository{FullName: "acme/web", HTMLURL: "https://github.com/acme/web"},
+		}},
+	}
+}
+
+func TestRender(t *testing.T) {
+	want := "2 urgent · 3 | color=red\n" +
+		"---\n" +
+		"Urgent (2)\n" +
+		"acme/api#12 Split a ¦ b | href=https://github.com/acme/api/pull/12\n" +
+		"Review PR: acme/api#12 | href=https://github.com/acme/api/pull/12 alternate=true\n" +
+		"acme/api#13 Bump deps | href=https://github.com/acme/api/pull/13\n" +
+		"FYI (1)\n" +
+		"acme/web Flaky test | href=https://github.com/acme/web\n" +
+		"---\n" +
+		"Open triage | bash=\"/usr/local/bin/triage\" terminal=true\n" +
+		"Refresh | refresh=true\n"
+	if got := Render(menubarItems(), Options{Command: "/usr/local/bin/triage"}); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderLimit(t *testing.T) {
+	got := Render(menubarItems(), Options{Limit: 1, Command: "triage"})
+	if strings.Contains(got, "acme/api#13") || strings.Contains(got, "FYI") {
+		t.Errorf("Render() with a limit of 1 listed more:\n%s", got)
+	}
+	// The header counts every item of the priority, listed or not
+	if !strings.Contains(got, "Urgent (2)\n") {
+		t.Errorf("Render() header should count both urgent items:\n%s", got)
+	}
+	if !strings.Contains(got, "2 more in triage | bash=\"triage\" terminal=true\n") {
+		t.Errorf("Render() should offer the rest in triage:\n%s", got)
+	}
+}
+
+func TestRenderBarTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []triage.PrioritizedItem
+		want  string
+	}{
+		{"empty", nil, "✓"},
+		{"urgent", menubarItems(), "2 urgent · 3 | color=red"},
+		{"no urgent", menubarItems()[2:], "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, _ := strings.Cut(Render(tt.items, Options{}), "\n")
+			if got != tt.want {
+				t.Errorf("bar title = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailed(t *testing.T) {
+	want := "triage ⚠\n---\nError: bad ¦ token | color=red\n---\nRefresh | refresh=true\n"
+	if got := Failed(errors.New("bad | token")); got != want {
+		t.Errorf("Failed() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,190 @@
+// Package migrate upgrades the files triage persists (config, resolved
+// store, cache) when their on-disk formats change between releases.
+//
+// Each package that owns a persisted format declares its own migrations as
+// a Target; this package only plans and applies them. File targets are
+// backed up before they are rewritten so a bad migration can be undone by
+// hand.
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Target is something triage persists whose format can change.
+type Target interface {
+	// Name identifies the target in output (e.g. "config").
+	Name() string
+	// Plan reports the pending migration, or nil when the target is
+	// missing or already current.
+	Plan() (*Plan, error)
+	// Apply performs a plan previously returned by Plan.
+	Apply(p *Plan) error
+}
+
+// Plan describes a pending migration.
+type Plan struct {
+	Target string
+	Path   string
+	From   int
+	To     int
+	Steps  []string // human-readable description of each step
+	Backup string   // where the original is saved before rewriting; "" if none
+}
+
+// Step upgrades a file's contents by one format version.
+type Step struct {
+	To          int // version produced by this step
+	Description string
+	Apply       func(data []byte) ([]byte, error)
+}
+
+// File is a Target for a single versioned file.
+type File struct {
+	name   string
+	path   string
+	detect func(data []byte) (int, error)
+	steps  []Step
+}
+
+// NewFile creates a file target. detect reads the format version from the
+// file's contents (0 for files written before versioning). Steps must be
+// ordered by their To version.
+func NewFile(name, path string, detect func(data []byte) (int, error), steps ...Step) *File {
+	return &File{name: name, path: path, detect: detect, steps: steps}
+}
+
+// Name implements Target.
+func (f *File) Name() string {
+	return f.name
+}
+
+// Latest returns the newest format version this build understands.
+func (f *File) Latest() int {
+	if len(f.steps) == 0 {
+		return 0
+	}
+	return f.steps[len(f.steps)-1].To
+}
+
+// Plan implements Target.
+func (f *File) Plan() (*Plan, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", f.name, err)
+	}
+
+	from, err := f.detect(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: reading format version of %s: %w", f.name, f.path, err)
+	}
+	if from > f.Latest() {
+		return nil, fmt.Errorf("%s: %s uses format version %d, but this triage only understands up to %d; upgrade triage",
+			f.name, f.path, from, f.Latest())
+	}
+
+	var steps []string
+	for _, s := range f.pending(from) {
+		steps = append(steps, s.Description)
+	}
+	if len(steps) == 0 {
+		return nil, nil
+	}
+
+	return &Plan{
+		Target: f.name,
+		Path:   f.path,
+		From:   from,
+		To:     f.Latest(),
+		Steps:  steps,
+		Backup: fmt.Sprintf("%s.v%d.bak", f.path, from),
+	}, nil
+}
+
+// Apply implements Target. The original file is copied to p.Backup before
+// the migrated contents atomically replace it.
+func (f *File) Apply(p *Plan) error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.name, err)
+	}
+	original, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.name, err)
+	}
+
+	data := original
+	for _, s := range f.pending(p.From) {
+		data, err = s.Apply(data)
+		if err != nil {
+			return fmt.Errorf("%s: migrating to version %d: %w", f.name, s.To, err)
+		}
+	}
+
+	if p.Backup != "" {
+		if err := os.WriteFile(p.Backup, original, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("%s: writing backup: %w", f.name, err)
+		}
+	}
+	return writeAtomic(f.path, data, info.Mode().Perm())
+}
+
+func (f *File) pending(from int) []Step {
+	var steps []Step
+	for _, s := range f.steps {
+		if s.To > from {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+// Run plans every target and, unless dryRun is set, applies the plans. It
+// returns the plans that were (or, in dry-run mode, would be) applied.
+func Run(targets []Target, dryRun bool) ([]Plan, error) {
+	var plans []Plan
+	for _, t := range targets {
+		p, err := t.Plan()
+		if err != nil {
+			return plans, err
+		}
+		if p == nil {
+			continue
+		}
+		if !dryRun {
+			if err := t.Apply(p); err != nil {
+				return plans, err
+			}
+		}
+		plans = append(plans, *p)
+	}
+	return plans, nil
+}
+
+// writeAtomic writes data to a temporary file next to path and renames it
+// into place so readers never see a partially written file.
+func writeAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,93 @@
+package migrate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testFile is a versioned file whose first line is "vN".
+func testFile(path string) *File {
+	detect := func(data []byte) (int, error) {
+		line, _, _ := bytes.Cut(data, []byte("\n"))
+		return strconv.Atoi(strings.TrimPrefix(string(line), "v"))
+	}
+	bump := func(to int) Step {
+		return Step{
+			To:          to,
+			Description: "bump to v" + strconv.Itoa(to),
+			Apply: func(data []byte) ([]byte, error) {
+				_, rest, _ := bytes.Cut(data, []byte("\n"))
+				return append([]byte("v"+strconv.Itoa(to)+"\n"), rest...), nil
+			},
+		}
+	}
+	return NewFile("test", path, detect, bump(1), bump(2))
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string // "" means the file does not exist
+		dryRun    bool
+		wantPlan  bool
+		wantSteps int
+		wantFile  string
+		wantErr   string
+	}{
+		{name: "missing file", content: ""},
+		{name: "current", content: "v2\nbody\n", wantFile: "v2\nbody\n"},
+		{name: "from v0", content: "v0\nbody\n", wantPlan: true, wantSteps: 2, wantFile: "v2\nbody\n"},
+		{name: "from v1", content: "v1\nbody\n", wantPlan: true, wantSteps: 1, wantFile: "v2\nbody\n"},
+		{name: "dry run", content: "v0\nbody\n", dryRun: true, wantPlan: true, wantSteps: 2, wantFile: "v0\nbody\n"},
+		{name: "newer than build", content: "v3\nbody\n", wantErr: "only understands up to 2", wantFile: "v3\nbody\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state")
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			plans, err := Run([]Target{testFile(path)}, tt.dryRun)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if got := len(plans) == 1; got != tt.wantPlan {
+				t.Fatalf("got %d plans, want plan=%v", len(plans), tt.wantPlan)
+			}
+			if tt.wantPlan && len(plans[0].Steps) != tt.wantSteps {
+				t.Errorf("plan steps = %v, want %d", plans[0].Steps, tt.wantSteps)
+			}
+
+			if tt.content == "" {
+				return
+			}
+			got, _ := os.ReadFile(path)
+			if string(got) != tt.wantFile {
+				t.Errorf("file = %q, want %q", got, tt.wantFile)
+			}
+
+			if tt.wantPlan {
+				backup, err := os.ReadFile(plans[0].Backup)
+				switch {
+				case tt.dryRun && err == nil:
+					t.Error("dry run wrote a backup")
+				case !tt.dryRun && string(backup) != tt.content:
+					t.Errorf("backup = %q (err %v), want original %q", backup, err, tt.content)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Details is an interface for type-specific details.
 // Use type assertions to access PRDetails or IssueDetails.
@@ -10,22 +13,76 @@ type Details interface {
 
 // PRDetails contains PR-specific enriched information
 type PRDetails struct {
-	Merged             bool       `json:"merged,omitempty"`
-	MergedAt           *time.Time `json:"mergedAt,omitempty"`
-	Additions          int        `json:"additions,omitempty"`
-	Deletions          int        `json:"deletions,omitempty"`
-	ChangedFiles       int        `json:"changedFiles,omitempty"`
-	ReviewState        string     `json:"reviewState,omitempty"` // approved, changes_requested, pending
-	ReviewComments     int        `json:"reviewComments,omitempty"`
-	Mergeable          bool       `json:"mergeable,omitempty"`
-	CIStatus           string     `json:"ciStatus,omitempty"` // success, failure, pending
-	Draft              bool       `json:"draft,omitempty"`
-	RequestedReviewers []string   `json:"requestedReviewers,omitempty"`
-	LatestReviewer     string     `json:"latestReviewer,omitempty"`
+	Merged       bool       `json:"merged,omitempty"`
+	MergedAt     *time.Time `json:"mergedAt,omitempty"`
+	Additions    int        `json:"additions,omitempty"`
+	Deletions    int        `json:"deletions,omitempty"`
+	ChangedFiles int        `json:"changedFiles,omitempty"`
+	// Files are the paths the PR changes, up to the first 100.
+	Files              []string `json:"files,omitempty"`
+	ReviewState        string   `json:"reviewState,omitempty"` // approved, changes_requested, pending
+	ReviewComments     int      `json:"reviewComments,omitempty"`
+	Mergeable          bool     `json:"mergeable,omitempty"`
+	CIStatus           string   `json:"ciStatus,omitempty"` // success, failure, pending
+	Draft              bool     `json:"draft,omitempty"`
+	RequestedReviewers []string `json:"requestedReviewers,omitempty"`
+	// RequestedTeams are the teams asked for a review, as "org/team-slug".
+	RequestedTeams []string `json:"requestedTeams,omitempty"`
+	LatestReviewer string   `json:"latestReviewer,omitempty"`
+	// ReviewEvents are the recent review requests to users and submitted
+	// reviews, oldest first.
+	ReviewEvents []ReviewEvent `json:"reviewEvents,omitempty"`
+	// LinkedIssues are the issues the PR closes when merged, as
+	// "owner/repo#123".
+	LinkedIssues []string `json:"linkedIssues,omitempty"`
+	// OwnsChanges says whether a PR asking one of the user's teams for a
+	// review changes files CODEOWNERS gives the user or their teams. It is
+	// nil when that wasn't checked or couldn't be told.
+	OwnsChanges *bool `json:"ownsChanges,omitempty"`
 }
 
 func (*PRDetails) isDetails() {}
 
+// ReviewEventKind says whether a ReviewEvent is a request or a review.
+type ReviewEventKind string
+
+const (
+	ReviewEventRequested ReviewEventKind = "requested"
+	ReviewEventReviewed  ReviewEventKind = "reviewed"
+)
+
+// ReviewEvent is a review being requested from a user, or a user
+// submitting a review.
+type ReviewEvent struct {
+	Kind  ReviewEventKind `json:"kind"`
+	Login string          `json:"login"`
+	At    time.Time       `json:"at"`
+}
+
+// ReviewResponse returns when login's review was last requested and when
+// they first reviewed after that. requested is zero if login was never
+// requested directly; reviewed is nil while the request is pending.
+// Logins compare case-insensitively.
+func (d *PRDetails) ReviewResponse(login string) (requested time.Time, reviewed *time.Time) {
+	for _, e := range d.ReviewEvents {
+		if !strings.EqualFold(e.Login, login) {
+			continue
+		}
+		switch e.Kind {
+		case ReviewEventRequested:
+			if e.At.After(requested) {
+				requested, reviewed = e.At, nil
+			}
+		case ReviewEventReviewed:
+			if !requested.IsZero() && reviewed == nil && !e.At.Before(requested) {
+				at := e.At
+				reviewed = &at
+			}
+		}
+	}
+	return requested, reviewed
+}
+
 // IssueDetails contains issue-specific enriched information
 type IssueDetails struct {
 	LastCommenter string `json:"lastCommenter,omitempty"`
@@ -39,6 +96,81 @@ func (i *Item) IsPR() bool {
 	return i.Type == ItemTypePullRequest
 }
 
+// Title returns the item's subject title.
+// This is a convenience method for templates and callers that don't care
+// where the title is stored.
+func (i *Item) Title() string {
+	return i.Subject.Title
+}
+
+// PendingReviewRequest returns when login's review was requested on a PR
+// they haven't reviewed since. ok is false for issues, for PRs that never
+// asked login directly, and once login has reviewed.
+func (i *Item) PendingReviewRequest(login string) (requested time.Time, ok bool) {
+	pr := i.PRDetails()
+	if pr == nil {
+		return time.Time{}, false
+	}
+	requested, reviewed := pr.ReviewResponse(login)
+	if requested.IsZero() || reviewed != nil {
+		return time.Time{}, false
+	}
+	return requested, true
+}
+
+// TeamReviewRequest reports whether a review request reached login only
+// through a team, so someone else on the team could pick it up. It is false
+// when login was asked directly, and when the PR's reviewers are unknown.
+func (i *Item) TeamReviewRequest(login string) bool {
+	pr := i.PRDetails()
+	if i.Reason != ReasonReviewRequested || pr == nil || len(pr.RequestedTeams) == 0 {
+		return false
+	}
+	for _, r := range pr.RequestedReviewers {
+		if strings.EqualFold(r, login) {
+			return false
+		}
+	}
+	return true
+}
+
+// RequestsReviewFromTeam reports whether the PR asks one of teams
+// ("org/team-slug") for a review without asking login directly, whatever
+// the reason login was notified.
+func (i *Item) RequestsReviewFromTeam(login string, teams []string) bool {
+	pr := i.PRDetails()
+	if pr == nil || len(teams) == 0 {
+		return false
+	}
+	for _, r := range pr.RequestedReviewers {
+		if strings.EqualFold(r, login) {
+			return false
+		}
+	}
+	for _, requested := range pr.RequestedTeams {
+		for _, team := range teams {
+			if strings.EqualFold(requested, team) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WaitingSince is when the item started waiting on login: the pending
+// review request if there is one, otherwise the last human activity, or
+// the last update when that is unknown. A PR that CI or bots keep updating
+// still counts as waiting from the request.
+func (i *Item) WaitingSince(login string) time.Time {
+	if requested, ok := i.PendingReviewRequest(login); ok {
+		return requested
+	}
+	if i.LastHumanActivityAt != nil {
+		return *i.LastHumanActivityAt
+	}
+	return i.UpdatedAt
+}
+
 // PRDetails returns the PRDetails if this is a PR, nil otherwise.
 func (i *Item) PRDetails() *PRDetails {
 	if i.Details == nil {
@@ -48,6 +180,17 @@ func (i *Item) PRDetails() *PRDetails {
 	return pr
 }
 
+// CommentsSince counts the recent comments posted after t.
+func (i *Item) CommentsSince(t time.Time) int {
+	n := 0
+	for _, at := range i.CommentTimes {
+		if at.After(t) {
+			n++
+		}
+	}
+	return n
+}
+
 // IssueDetails returns the IssueDetails if this is an issue, nil otherwise.
 func (i *Item) IssueDetails() *IssueDetails {
 	if i.Details == nil {
@@ -27,6 +27,11 @@ const (
 	// external contributions that appear to be waiting for maintainer response.
 	// This is not a GitHub API reason.
 	ReasonOrphaned ItemReason = "orphaned"
+
+	// ReasonUpstream is a synthetic reason created by triage for work that
+	// was waiting on another repository's release, issue, or PR, raised
+	// once that blocker resolves. This is not a GitHub API reason.
+	ReasonUpstream ItemReason = "upstream"
 )
 
 // GitHub API reasons not yet implemented:
@@ -50,6 +55,7 @@ var AllItemReasons = []ItemReason{
 	ReasonCIActivity,
 	ReasonManual,
 	ReasonOrphaned,
+	ReasonUpstream,
 }
 
 // SubjectType represents the type of notification subject
@@ -93,6 +99,12 @@ const (
 	ItemTypePullRequest ItemType = "pull_request"
 )
 
+// Reminder is a reminder the user set on an item with triage remind.
+type Reminder struct {
+	At   time.Time `json:"at"`
+	Note string    `json:"note,omitempty"`
+}
+
 // Item represents a GitHub notification with enriched context
 type Item struct {
 	ID         string     `json:"id"`
@@ -117,13 +129,67 @@ type Item struct {
 	Labels       []string   `json:"labels,omitempty"`
 	CommentCount int        `json:"commentCount,omitempty"`
 
+	// BlockedBy are the open issues and PRs blocking this one, as
+	// "owner/repo#123": GitHub's "blocked by" relationships for issues,
+	// "depends on #123" or "blocked by #123" in a PR's description, and the
+	// PR a stacked PR's base branch belongs to.
+	BlockedBy []string `json:"blockedBy,omitempty"`
+
+	// Blocks are the items listed alongside this one that it blocks, as
+	// "owner/repo#123". They are found when the items are prioritized
+	// together.
+	Blocks []string `json:"blocks,omitempty"`
+
+	// FormFields are the answers of an issue created from an issue form,
+	// by field label, e.g. "Severity": "High". Enrichment keeps the short
+	// answers; filtering narrows them to the fields configured for the repo.
+	FormFields map[string]string `json:"formFields,omitempty"`
+
+	// Milestone is the milestone the issue or PR belongs to, if any.
+	Milestone *Milestone `json:"milestone,omitempty"`
+
+	// Projects are the project boards the issue or PR is on, fetched only
+	// when project_column_scores is configured.
+	Projects []ProjectItem `json:"projects,omitempty"`
+	// ProjectsFetched is set when enrichment asked for the project boards,
+	// so a cached item fetched without them isn't taken as on none.
+	ProjectsFetched bool `json:"projectsFetched,omitempty"`
+
 	// Orphaned detection (common to both)
 	AuthorAssociation         string     `json:"authorAssociation,omitempty"`
 	LastTeamActivityAt        *time.Time `json:"lastTeamActivityAt,omitempty"`
 	ConsecutiveAuthorComments int        `json:"consecutiveAuthorComments,omitempty"`
 
+	// ActivityBy is when each participant last commented, reviewed, or
+	// pushed, as far as enrichment saw, keyed by login.
+	ActivityBy map[string]time.Time `json:"activityBy,omitempty"`
+
+	// LastHumanActivityAt is when someone other than a bot last opened,
+	// commented on, reviewed, or pushed to the item; nil when unknown, in
+	// which case UpdatedAt stands in.
+	LastHumanActivityAt *time.Time `json:"lastHumanActivityAt,omitempty"`
+
+	// CommentTimes are when the most recent human comments were posted,
+	// oldest first, as far as enrichment saw (at most the last 20).
+	CommentTimes []time.Time `json:"commentTimes,omitempty"`
+
+	// LastInteractionAt is when the current user last commented on,
+	// reviewed, or opened the item; nil if they never have.
+	LastInteractionAt *time.Time `json:"lastInteractionAt,omitempty"`
+
+	// Reminder is set while a reminder the user set on the item is due.
+	Reminder *Reminder `json:"reminder,omitempty"`
+
 	// Type-specific details (interface)
 	Details Details `json:"details,omitempty"`
+
+	// Inaccessible is set when the token can't read the item's repository.
+	// The item is kept, unenriched, instead of being dropped.
+	Inaccessible bool `json:"inaccessible,omitempty"`
+
+	// Stale is set when an offline run shows the item from a cache entry
+	// past its TTL, so it may have changed on GitHub since.
+	Stale bool `json:"stale,omitempty"`
 }
 
 // Repository represents a GitHub repository
@@ -174,3 +240,38 @@ func (i *Item) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// IsNotification reports whether the item came from the notifications API,
+// whose items keep GitHub's numeric thread ID as their ID. Items found by
+// searches and synthetic items such as orphaned contributions carry
+// prefixed IDs and have no thread to mark read.
+func (i *Item) IsNotification() bool {
+	if i.ID == "" {
+		return false
+	}
+	for _, r := range i.ID {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Chained reports whether the item is blocked by, or blocks, other items.
+func (i *Item) Chained() bool {
+	return len(i.BlockedBy) > 0 || len(i.Blocks) > 0
+}
+
+// Milestone is the milestone an issue or PR belongs to.
+type Milestone struct {
+	Title string     `json:"title"`
+	DueOn *time.Time `json:"dueOn,omitempty"`
+}
+
+// ProjectItem is where an issue or PR sits on a GitHub project board.
+type ProjectItem struct {
+	Project string `json:"project"`
+	// Status is the board's Status field, e.g. "In Review"; empty when
+	// the item has none
+	Status string `json:"status,omitempty"`
+}
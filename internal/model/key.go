@@ -0,0 +1,33 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Key identifies what an item is about, the same whichever source it came
+// from: a PR seen as a notification, as a review request, and as one of
+// the user's own PRs shares one key, where their IDs differ. Local state
+// such as resolved items is stored by key.
+//
+// Issues and PRs share numbering within a repository and are keyed
+// "owner/repo#123". Discussions, numbered separately, are keyed
+// "owner/repo/discussions#12". Items without a number, such as releases,
+// are keyed by their ID.
+func (i *Item) Key() string {
+	number := i.Number
+	// A release's URL ends in its ID, not a number
+	if number == 0 && i.Subject.URL != "" && i.Subject.Type != SubjectRelease {
+		number, _ = strconv.Atoi(path.Base(i.Subject.URL))
+	}
+	if number <= 0 || i.Repository.FullName == "" {
+		return i.ID
+	}
+	repo := strings.ToLower(i.Repository.FullName)
+	if i.Subject.Type == SubjectDiscussion {
+		return fmt.Sprintf("%s/discussions#%d", repo, number)
+	}
+	return fmt.Sprintf("%s#%d", repo, number)
+}
@@ -0,0 +1,29 @@
+package model
+
+// Preview is what the list's detail pane shows of an issue or pull
+// request beyond its row: the description, people, recent comments, and
+// for pull requests the review status and CI checks.
+type Preview struct {
+	Body      string    `json:"body"`
+	Labels    []string  `json:"labels,omitempty"`
+	Assignees []string  `json:"assignees,omitempty"`
+	Comments  []Comment `json:"comments,omitempty"` // most recent, oldest first
+
+	// ReviewDecision is one of the ReviewState constants, empty for issues
+	// and for PRs whose repository doesn't require reviews.
+	ReviewDecision string   `json:"reviewDecision,omitempty"`
+	Reviews        []Review `json:"reviews,omitempty"`
+	Checks         []Check  `json:"checks,omitempty"`
+}
+
+// Review is a reviewer's latest review of a pull request.
+type Review struct {
+	Author string `json:"author"`
+	State  string `json:"state"` // approved, changes_requested, commented, dismissed
+}
+
+// Check is a CI check run or commit status on a pull request's head commit.
+type Check struct {
+	Name  string `json:"name"`
+	State string `json:"state"` // a CIStatus constant
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Comment is a single comment on an issue or pull request.
+type Comment struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Thread is the conversation on an issue or pull request: the opening
+// post plus a window of its most recent comments.
+type Thread struct {
+	Repository string    `json:"repository"` // owner/repo
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	Author     string    `json:"author"`
+	State      string    `json:"state"`
+	HTMLURL    string    `json:"htmlUrl"`
+	Labels     []string  `json:"labels,omitempty"`
+	IsPR       bool      `json:"isPR"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Comments   []Comment `json:"comments,omitempty"`
+}
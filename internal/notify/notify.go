@@ -0,0 +1,136 @@
+// Package notify posts the triage digest to chat services.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/digest"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// Digest is the rendered report a Notifier posts.
+type Digest struct {
+	Subject string
+	// Text is Markdown, which every supported service renders.
+	Text string
+	// HTML is a fragment, without a document around it.
+	HTML string
+}
+
+// NewDigest renders items as a Digest.
+func NewDigest(items []triage.PrioritizedItem) Digest {
+	return Digest{
+		Subject: digest.Subject(items),
+		Text:    digest.Text(items),
+		HTML:    digest.HTMLBody(items),
+	}
+}
+
+// Notifier posts a digest to one service.
+type Notifier interface {
+	// Name identifies the service in messages and the --only flag.
+	Name() string
+	Notify(ctx context.Context, d Digest) error
+}
+
+// Option configures the notifiers created by FromSettings.
+type Option func(*options)
+
+type options struct {
+	client *http.Client
+	getenv func(string) string
+}
+
+// WithHTTPClient sets the HTTP client used to post. Defaults to a client
+// with a 30 second timeout.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) {
+		o.client = c
+	}
+}
+
+// WithGetenv sets how secrets named in the config are looked up. Defaults
+// to os.Getenv.
+func WithGetenv(fn func(string) string) Option {
+	return func(o *options) {
+		o.getenv = fn
+	}
+}
+
+// FromSettings returns a Notifier for each configured service, in a fixed
+// order.
+func FromSettings(settings config.NotifierSettings, opts ...Option) ([]Notifier, error) {
+	o := newOptions(opts)
+	var notifiers []Notifier
+	if settings.Discord != nil {
+		n, err := newDiscord(*settings.Discord, o)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+	if settings.Matrix != nil {
+		n, err := newMatrix(*settings.Matrix, o)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+	if settings.Teams != nil {
+		n, err := newTeams(*settings.Teams, o)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+	return notifiers, nil
+}
+
+// postJSON sends payload as JSON and fails unless the service answers
+// with a 2xx status.
+func postJSON(ctx context.Context, client *http.Client, method, url string, header http.Header, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.getenv == nil {
+		o.getenv = os.Getenv
+	}
+	return o
+}
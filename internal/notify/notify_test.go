@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+)
+
+// request is what a fake service received.
+type request struct {
+	method string
+	path   string
+	auth   string
+	body   map[string]any
+}
+
+// fakeService records the last request and answers with status.
+func fakeService(t *testing.T, status int) (*httptest.Server, *request) {
+	t.Helper()
+	got := &request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.method = r.Method
+		got.path = r.URL.EscapedPath()
+		got.auth = r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &got.body); err != nil {
+			t.Errorf("request body is not JSON: %s", data)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"error":"nope"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+var testDigest = Digest{
+	Subject: "Triage: 1 item",
+	Text:    "## Urgent (1)\n\n- acme/api#12 Add pagination\n",
+	HTML:    "<h2>Urgent (1)</h2>\n",
+}
+
+func TestFromSettings(t *testing.T) {
+	env := func(string) string { return "" }
+
+	tests := []struct {
+		name      string
+		settings  config.NotifierSettings
+		wantNames []string
+		wantErr   string
+	}{
+		{"none", config.NotifierSettings{}, nil, ""},
+		{
+			"all",
+			config.NotifierSettings{
+				Discord: &config.DiscordNotifier{WebhookURL: "https://discord.example"},
+				Matrix:  &config.MatrixNotifier{Homeserver: "https://matrix.example", RoomID: "!r:example", TokenEnv: "TOKEN"},
+				Teams:   &config.TeamsNotifier{WebhookURL: "https://teams.example"},
+			},
+			[]string{"discord", "matrix", "teams"},
+			"",
+		},
+		{"discord without url", config.NotifierSettings{Discord: &config.DiscordNotifier{}}, nil, "webhook_url or webhook_url_env is required"},
+		{"teams without url", config.NotifierSettings{Teams: &config.TeamsNotifier{}}, nil, "webhook_url or webhook_url_env is required"},
+		{"discord url from env", config.NotifierSettings{Discord: &config.DiscordNotifier{WebhookURLEnv: "DISCORD_URL"}}, []string{"discord"}, ""},
+		{
+			"teams url env empty",
+			config.NotifierSettings{Teams: &config.TeamsNotifier{WebhookURL: "https://teams.example", WebhookURLEnv: "MISSING"}},
+			nil,
+			"$MISSING is empty",
+		},
+		{"matrix without room", config.NotifierSettings{Matrix: &config.MatrixNotifier{Homeserver: "https://m"}}, nil, "room_id are required"},
+		{
+			"matrix without token",
+			config.NotifierSettings{Matrix: &config.MatrixNotifier{Homeserver: "https://m", RoomID: "!r", TokenEnv: "MISSING"}},
+			nil,
+			"$MISSING is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := env
+			if tt.wantErr == "" {
+				getenv = func(string) string { return "secret" }
+			}
+			notifiers, err := FromSettings(tt.settings, WithGetenv(getenv))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FromSettings() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromSettings() error = %v", err)
+			}
+			var names []string
+			for _, n := range notifiers {
+				names = append(names, n.Name())
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("FromSettings() names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestDiscord(t *testing.T) {
+	srv, got := fakeService(t, http.StatusNoContent)
+	n, err := newDiscord(config.DiscordNotifier{WebhookURL: srv.URL + "/api/webhooks/1/abc"}, newOptions(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	long := testDigest
+	long.Text = strings.Repeat("x", 3000)
+	if err := n.Notify(context.Background(), long); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got.method != http.MethodPost || got.path != "/api/webhooks/1/abc" {
+		t.Errorf("request = %s %s", got.method, got.path)
+	}
+	content, _ := got.body["content"].(string)
+	if !strings.HasPrefix(content, "**Triage: 1 item**\n\n") {
+		t.Errorf("content = %q", content)
+	}
+	if n := len([]rune(content)); n != discordMaxContent {
+		t.Errorf("content length = %d, want it cut to %d", n, discordMaxContent)
+	}
+	if _, ok := got.body["allowed_mentions"]; !ok {
+		t.Error("mentions are not disabled")
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	srv, got := fakeService(t, http.StatusOK)
+	n, err := newMatrix(config.MatrixNotifier{Homeserver: srv.URL + "/", RoomID: "!room:example.org", TokenEnv: "TOKEN"},
+		newOptions([]Option{WithGetenv(func(string) string { return "secret" })}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.now = func() time.Time { return time.Unix(0, 42) }
+	if err := n.Notify(context.Background(), testDigest); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got.method != http.MethodPut {
+		t.Errorf("method = %s, want PUT", got.method)
+	}
+	if want := "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/triage-42"; got.path != want {
+		t.Errorf("path = %s, want %s", got.path, want)
+	}
+	if got.auth != "Bearer secret" {
+		t.Errorf("Authorization = %q", got.auth)
+	}
+	if got.body["formatted_body"] != "<h1>Triage: 1 item</h1>\n<h2>Urgent (1)</h2>\n" {
+		t.Errorf("formatted_body = %q", got.body["formatted_body"])
+	}
+	if body, _ := got.body["body"].(string); !strings.Contains(body, "acme/api#12") {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestTeams(t *testing.T) {
+	srv, got := fakeService(t, http.StatusAccepted)
+	n, err := newTeams(config.TeamsNotifier{WebhookURL: srv.URL}, newOptions(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Notify(context.Background(), testDigest); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	data, _ := json.Marshal(got.body)
+	for _, want := range []string{`"contentType":"application/vnd.microsoft.card.adaptive"`, `"type":"AdaptiveCard"`, `acme/api#12`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("payload missing %s: %s", want, data)
+		}
+	}
+}
+
+func TestNotifyReportsFailure(t *testing.T) {
+	srv, _ := fakeService(t, http.StatusBadRequest)
+	n, err := newTeams(config.TeamsNotifier{WebhookURL: srv.URL}, newOptions(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = n.Notify(context.Background(), testDigest)
+	if err == nil || !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("Notify() error = %v, want the status and response", err)
+	}
+}
@@ -0,0 +1,148 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+)
+
+// discordMaxContent is the longest message Discord accepts, in characters.
+const discordMaxContent = 2000
+
+// Discord posts the digest to a Discord channel webhook.
+type Discord struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func newDiscord(cfg config.DiscordNotifier, o options) (*Discord, error) {
+	webhookURL, err := resolveWebhookURL("discord", cfg.WebhookURL, cfg.WebhookURLEnv, o)
+	if err != nil {
+		return nil, err
+	}
+	return &Discord{webhookURL: webhookURL, client: o.client}, nil
+}
+
+// resolveWebhookURL returns the webhook URL of service, read from the
+// environment variable named by env when it is set.
+func resolveWebhookURL(service, webhookURL, env string, o options) (string, error) {
+	if env != "" {
+		webhookURL = o.getenv(env)
+		if webhookURL == "" {
+			return "", fmt.Errorf("notifiers.%s is configured but $%s is empty", service, env)
+		}
+	}
+	if webhookURL == "" {
+		return "", fmt.Errorf("notifiers.%s.webhook_url or webhook_url_env is required", service)
+	}
+	return webhookURL, nil
+}
+
+// Name implements Notifier.
+func (d *Discord) Name() string { return "discord" }
+
+// Notify implements Notifier. Digests longer than Discord allows are cut
+// short.
+func (d *Discord) Notify(ctx context.Context, digest Digest) error {
+	content := fmt.Sprintf("**%s**\n\n%s", digest.Subject, digest.Text)
+	if runes := []rune(content); len(runes) > discordMaxContent {
+		content = string(runes[:discordMaxContent-1]) + "…"
+	}
+	payload := map[string]any{
+		"content": content,
+		// Never ping anyone a title happens to mention
+		"allowed_mentions": map[string]any{"parse": []string{}},
+	}
+	return postJSON(ctx, d.client, http.MethodPost, d.webhookURL, nil, payload)
+}
+
+// Matrix posts the digest to a Matrix room.
+type Matrix struct {
+	homeserver string
+	roomID     string
+	token      string
+	client     *http.Client
+	// now makes transaction IDs unique per message
+	now func() time.Time
+}
+
+func newMatrix(cfg config.MatrixNotifier, o options) (*Matrix, error) {
+	if cfg.Homeserver == "" || cfg.RoomID == "" {
+		return nil, errors.New("notifiers.matrix.homeserver and notifiers.matrix.room_id are required")
+	}
+	token := o.getenv(cfg.TokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("notifiers.matrix is configured but $%s is empty", cfg.TokenEnv)
+	}
+	return &Matrix{
+		homeserver: strings.TrimSuffix(cfg.Homeserver, "/"),
+		roomID:     cfg.RoomID,
+		token:      token,
+		client:     o.client,
+		now:        time.Now,
+	}, nil
+}
+
+// Name implements Notifier.
+func (m *Matrix) Name() string { return "matrix" }
+
+// Notify implements Notifier, sending the HTML digest with the Markdown
+// as its plain text fallback.
+func (m *Matrix) Notify(ctx context.Context, digest Digest) error {
+	txnID := fmt.Sprintf("triage-%d", m.now().UnixNano())
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		m.homeserver, url.PathEscape(m.roomID), txnID)
+	payload := map[string]any{
+		"msgtype":        "m.notice",
+		"body":           digest.Subject + "\n\n" + digest.Text,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": fmt.Sprintf("<h1>%s</h1>\n%s", html.EscapeString(digest.Subject), digest.HTML),
+	}
+	header := http.Header{"Authorization": {"Bearer " + m.token}}
+	return postJSON(ctx, m.client, http.MethodPut, endpoint, header, payload)
+}
+
+// Teams posts the digest to a Microsoft Teams incoming webhook or
+// workflow as an Adaptive Card.
+type Teams struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func newTeams(cfg config.TeamsNotifier, o options) (*Teams, error) {
+	webhookURL, err := resolveWebhookURL("teams", cfg.WebhookURL, cfg.WebhookURLEnv, o)
+	if err != nil {
+		return nil, err
+	}
+	return &Teams{webhookURL: webhookURL, client: o.client}, nil
+}
+
+// Name implements Notifier.
+func (t *Teams) Name() string { return "teams" }
+
+// Notify implements Notifier.
+func (t *Teams) Notify(ctx context.Context, digest Digest) error {
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body": []map[string]any{
+			{"type": "TextBlock", "text": digest.Subject, "weight": "Bolder", "size": "Medium", "wrap": true},
+			{"type": "TextBlock", "text": digest.Text, "wrap": true},
+		},
+	}
+	payload := map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+	return postJSON(ctx, t.client, http.MethodPost, t.webhookURL, nil, payload)
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// CSVFormatter formats output as CSV with a header row.
+type CSVFormatter struct {
+	// Fields selects the columns (see selectFields). Defaults to DefaultFields.
+	Fields []string
+}
+
+// Format outputs prioritized items as CSV
+func (f *CSVFormatter) Format(items []triage.PrioritizedItem, w io.Writer) error {
+	fields := f.Fields
+	if len(fields) == 0 {
+		fields = DefaultFields
+	}
+
+	rows, err := selectFields(items, fields)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(fields); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = csvValue(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvValue stringifies a decoded JSON value for a CSV cell.
+// Lists are joined with semicolons; nested objects use Go's default formatting.
+func csvValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		// JSON numbers decode as float64; print integers without a fraction
+		if val == float64(int64(val)) {
+			return fmt.Sprintf("%d", int64(val))
+		}
+		return fmt.Sprintf("%g", val)
+	case []any:
+		parts := make([]string, len(val))
+		for i, p := range val {
+			parts[i] = csvValue(p)
+		}
+		return strings.Join(parts, ";")
+	default:
+		return fmt.Sprint(val)
+	}
+}
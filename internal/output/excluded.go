@@ -0,0 +1,62 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// ColRule is the width of the rule column in the excluded section.
+const ColRule = 32
+
+// FormatExcluded writes the items filters removed below the list, each
+// with the rule that removed it. The table rows are dimmed; with plain,
+// they are labeled lines like PlainFormatter's.
+func FormatExcluded(excluded []triage.Exclusion, w io.Writer, plain bool) error {
+	if len(excluded) == 0 {
+		return nil
+	}
+
+	if plain {
+		for i, e := range excluded {
+			fields := []string{
+				fmt.Sprintf("Excluded %d of %d", i+1, len(excluded)),
+				"Rule: " + e.Rule,
+				"Item: " + itemRef(e.Item),
+				"Title: " + format.Sanitize(e.Item.Subject.Title),
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(fields, plainSeparator)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	dim := color.New(color.Faint)
+	if _, err := fmt.Fprintf(w, "\n%s\n", dim.Sprintf("Excluded (%d)", len(excluded))); err != nil {
+		return err
+	}
+	for _, e := range excluded {
+		rule, ruleWidth := format.TruncateToWidth(format.Sanitize(e.Rule), ColRule)
+		ref, refWidth := format.TruncateToWidth(itemRef(e.Item), ColRepo)
+		title, _ := format.TruncateToWidth(format.Sanitize(e.Item.Subject.Title), ColTitle)
+		line := fmt.Sprintf("%s  %s  %s",
+			format.PadRight(rule, ruleWidth, ColRule),
+			format.PadRight(ref, refWidth, ColRepo),
+			title)
+		if _, err := fmt.Fprintln(w, dim.Sprint(line)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// itemRef returns "owner/repo#123", or the repository alone for items
+// without a number.
+func itemRef(item triage.PrioritizedItem) string {
+	return format.ItemRef(item.Repository.FullName, item.Number)
+}
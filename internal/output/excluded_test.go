@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestFormatExcluded(t *testing.T) {
+	excluded := []triage.Exclusion{
+		{
+			Item: triage.PrioritizedItem{Item: model.Item{
+				Number:     12,
+				Repository: model.Repository{FullName: "acme/noisy"},
+				Subject:    model.Subject{Title: "Bump deps"},
+			}},
+			Rule: "exclude_repos: acme/noisy",
+		},
+		{
+			Item: triage.PrioritizedItem{Item: model.Item{
+				Repository: model.Repository{FullName: "acme/api"},
+				Subject:    model.Subject{Title: "Release v1.2"},
+			}},
+			Rule: "resolved",
+		},
+	}
+
+	tests := []struct {
+		name  string
+		plain bool
+		want  []string
+	}{
+		{
+			name: "table",
+			want: []string{
+				"",
+				"Excluded (2)",
+				"exclude_repos: acme/noisy         acme/noisy#12               Bump deps",
+				"resolved                          acme/api                    Release v1.2",
+			},
+		},
+		{
+			name:  "plain",
+			plain: true,
+			want: []string{
+				"Excluded 1 of 2; Rule: exclude_repos: acme/noisy; Item: acme/noisy#12; Title: Bump deps",
+				"Excluded 2 of 2; Rule: resolved; Item: acme/api; Title: Release v1.2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := FormatExcluded(excluded, &buf, tt.plain); err != nil {
+				t.Fatalf("FormatExcluded() error: %v", err)
+			}
+			if got, want := buf.String(), strings.Join(tt.want, "\n")+"\n"; got != want {
+				t.Errorf("FormatExcluded() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := FormatExcluded(nil, &buf, false); err != nil || buf.Len() != 0 {
+		t.Errorf("FormatExcluded(nil) wrote %q, err %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// fieldAliases maps short field names to their JSON paths.
+var fieldAliases = map[string]string{
+	"repo":  "repository.fullName",
+	"title": "subject.title",
+	"url":   "htmlUrl",
+}
+
+// DefaultFields are the fields used by CSV output when none are selected.
+var DefaultFields = []string{"score", "priority", "repo", "number", "title", "url"}
+
+// ParseFields splits a comma-separated field list, dropping empty entries.
+func ParseFields(s string) []string {
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// selectFields extracts the requested fields from each item. A field is
+// either an alias (see fieldAliases) or a dot-separated path into the item's
+// JSON representation, e.g. "repository.fullName" or "details.ciStatus".
+// Path segments match JSON keys case-insensitively; missing paths yield nil.
+func selectFields(items []triage.PrioritizedItem, fields []string) ([][]any, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var generic []map[string]any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	paths := make([][]string, len(fields))
+	for i, f := range fields {
+		path := f
+		if alias, ok := fieldAliases[strings.ToLower(f)]; ok {
+			path = alias
+		}
+		paths[i] = strings.Split(path, ".")
+	}
+
+	rows := make([][]any, len(generic))
+	for i, obj := range generic {
+		row := make([]any, len(paths))
+		for j, path := range paths {
+			row[j] = lookupPath(obj, path)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// lookupPath walks a decoded JSON object along path.
+func lookupPath(obj map[string]any, path []string) any {
+	var cur any = obj
+	for _, key := range path {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = lookupKey(m, key)
+	}
+	return cur
+}
+
+// lookupKey returns m[key], falling back to a case-insensitive match.
+func lookupKey(m map[string]any, key string) any {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return nil
+}
+
+// fieldObject is a JSON object whose keys keep the order the user asked for.
+type fieldObject struct {
+	keys   []string
+	values []any
+}
+
+// MarshalJSON implements json.Marshaler, preserving key order.
+func (o fieldObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", k, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,192 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func fieldTestItems() []triage.PrioritizedItem {
+	return []triage.PrioritizedItem{
+		{
+			Item: model.Item{
+				Number:     42,
+				Type:       model.ItemTypePullRequest,
+				HTMLURL:    "https://github.com/owner/repo/pull/42",
+				Repository: model.Repository{FullName: "owner/repo"},
+				Subject:    model.Subject{Title: "Fix bug"},
+				Labels:     []string{"bug", "p1"},
+				Details:    &model.PRDetails{CIStatus: "failure"},
+			},
+			Priority: triage.PriorityUrgent,
+			Score:    120,
+		},
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"single", "score", []string{"score"}},
+		{"trims and drops blanks", " score, ,repo ,", []string{"score", "repo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseFields(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFields(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONFormatterFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		want   string
+	}{
+		{
+			name:   "aliases keep requested order",
+			fields: []string{"score", "priority", "repo", "number", "title", "url"},
+			want:   `[{"score":120,"priority":"urgent","repo":"owner/repo","number":42,"title":"Fix bug","url":"https://github.com/owner/repo/pull/42"}]` + "\n",
+		},
+		{
+			name:   "nested path",
+			fields: []string{"number", "details.ciStatus"},
+			want:   `[{"number":42,"details.ciStatus":"failure"}]` + "\n",
+		},
+		{
+			name:   "case-insensitive path",
+			fields: []string{"Repository.FULLNAME"},
+			want:   `[{"Repository.FULLNAME":"owner/repo"}]` + "\n",
+		},
+		{
+			name:   "missing path is null",
+			fields: []string{"details.nope"},
+			want:   `[{"details.nope":null}]` + "\n",
+		},
+		{
+			name:   "list value",
+			fields: []string{"labels"},
+			want:   `[{"labels":["bug","p1"]}]` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := &JSONFormatter{Fields: tt.fields}
+			if err := f.Format(fieldTestItems(), &buf); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONFormatterEscapesTerminalControls(t *testing.T) {
+	title := "\x1b[2Jspoof\u009b\u202e\x7f"
+	items := fieldTestItems()
+	items[0].Subject.Title = title
+
+	var buf bytes.Buffer
+	f := &JSONFormatter{Fields: []string{"title"}}
+	if err := f.Format(items, &buf); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	want := `[{"title":"\u001b[2Jspoof\u009b\u202e\u007f"}]` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+
+	// Escaping leaves the value itself unchanged
+	var decoded []map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded[0]["title"] != title {
+		t.Errorf("decoded title = %q, want %q", decoded[0]["title"], title)
+	}
+}
+
+func TestJSONFormatterEnvelope(t *testing.T) {
+	tests := []struct {
+		name   string
+		items  []triage.PrioritizedItem
+		errors []RunError
+		want   string
+	}{
+		{
+			name:  "complete run",
+			items: fieldTestItems(),
+			want:  `{"items":[{"number":42}],"errors":[]}`,
+		},
+		{
+			name:  "degraded run",
+			items: nil,
+			errors: []RunError{
+				{Source: "notifications", Message: "notifications: timeout"},
+				{Source: "enrichment", Message: "items could not be enriched: 2 not found", Count: 2},
+			},
+			want: `{"items":[],"errors":[` +
+				`{"source":"notifications","message":"notifications: timeout"},` +
+				`{"source":"enrichment","message":"items could not be enriched: 2 not found","count":2}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := NewFormatterWithWeights(FormatJSON, config.ScoreWeights{}, "", WithFields([]string{"number"}), WithEnvelope(tt.errors))
+			if err := f.Format(tt.items, &buf); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want+"\n" {
+				t.Errorf("Format() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVFormatter(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		want   string
+	}{
+		{
+			name: "default fields",
+			want: "score,priority,repo,number,title,url\n" +
+				"120,urgent,owner/repo,42,Fix bug,https://github.com/owner/repo/pull/42\n",
+		},
+		{
+			name:   "lists and missing values",
+			fields: []string{"number", "labels", "details.nope"},
+			want:   "number,labels,details.nope\n42,bug;p1,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := &CSVFormatter{Fields: tt.fields}
+			if err := f.Format(fieldTestItems(), &buf); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
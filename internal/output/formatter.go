@@ -1,9 +1,11 @@
 package output
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/format"
 	"github.com/spiffcs/triage/internal/triage"
 )
 
@@ -17,15 +19,57 @@ const (
 	ColRepo     = 26
 	ColTitle    = 40
 	ColStatus   = 20
+	ColYou      = 8
 	ColAge      = 5
+
+	// ColPriorityMarker is the extra Priority width taken by a status
+	// marker prefix such as "[U] ".
+	ColPriorityMarker = 4
+)
+
+// CI status glyphs. The marker set uses shapes that stay distinct without
+// color.
+const (
+	CISuccessGlyph = "✓"
+	CIFailureGlyph = "✗"
+	CIPendingGlyph = "○"
+
+	CISuccessMarker = "+"
+	CIFailureMarker = "X"
+	CIPendingMarker = "~"
 )
 
+// Truncation holds how the table and TUI shorten values that don't fit
+// their column.
+type Truncation struct {
+	Repository format.Truncation
+	Author     format.Truncation
+}
+
+// NewTruncation parses the configured truncation modes.
+func NewTruncation(settings config.TruncationSettings) (Truncation, error) {
+	repo, err := format.ParseTruncation(settings.Repository)
+	if err != nil {
+		return Truncation{}, fmt.Errorf("truncation.repository: %w", err)
+	}
+	author, err := format.ParseTruncation(settings.Author)
+	if err != nil {
+		return Truncation{}, fmt.Errorf("truncation.author: %w", err)
+	}
+	return Truncation{Repository: repo, Author: author}, nil
+}
+
 // Format represents the output format
 type Format string
 
 const (
-	FormatTable Format = "table"
-	FormatJSON  Format = "json"
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatJSONL    Format = "jsonl"
+	FormatTemplate Format = "template"
+	FormatCSV      Format = "csv"
+	FormatPlain    Format = "plain"
+	FormatMarkdown Format = "markdown"
 )
 
 // Formatter defines the interface for output formatters
@@ -33,11 +77,82 @@ type Formatter interface {
 	Format(items []triage.PrioritizedItem, w io.Writer) error
 }
 
+// FormatterOption is a functional option for configuring formatters.
+type FormatterOption func(*formatterOptions)
+
+type formatterOptions struct {
+	fields        []string
+	quick         bool
+	statusMarkers bool
+	truncation    Truncation
+	envelope      bool
+	errors        []RunError
+}
+
+// WithFields selects the fields emitted by the JSON, JSONL, and CSV
+// formatters.
+func WithFields(fields []string) FormatterOption {
+	return func(o *formatterOptions) {
+		o.fields = fields
+	}
+}
+
+// WithEnvelope wraps JSON output in an object with the items and errors,
+// the problems that left the run incomplete.
+func WithEnvelope(errors []RunError) FormatterOption {
+	return func(o *formatterOptions) {
+		o.envelope = true
+		o.errors = errors
+	}
+}
+
+// WithQuickMode tells the table formatter that items were not enriched, so
+// columns built from enrichment data are marked unavailable.
+func WithQuickMode(quick bool) FormatterOption {
+	return func(o *formatterOptions) {
+		o.quick = quick
+	}
+}
+
+// WithStatusMarkers prefixes priorities in the table with letter markers
+// so they can be told apart without color.
+func WithStatusMarkers(markers bool) FormatterOption {
+	return func(o *formatterOptions) {
+		o.statusMarkers = markers
+	}
+}
+
+// WithTruncation sets how the table shortens long values in each column.
+func WithTruncation(truncation Truncation) FormatterOption {
+	return func(o *formatterOptions) {
+		o.truncation = truncation
+	}
+}
+
 // NewFormatterWithWeights creates a formatter with custom score weights
-func NewFormatterWithWeights(format Format, weights config.ScoreWeights, currentUser string) Formatter {
+func NewFormatterWithWeights(format Format, weights config.ScoreWeights, currentUser string, opts ...FormatterOption) Formatter {
+	var o formatterOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	switch format {
 	case FormatJSON:
-		return &JSONFormatter{}
+		return &JSONFormatter{Fields: o.fields, Envelope: o.envelope, Errors: o.errors}
+	case FormatJSONL:
+		return &JSONLFormatter{Fields: o.fields}
+	case FormatCSV:
+		return &CSVFormatter{Fields: o.fields}
+	case FormatMarkdown:
+		return &MarkdownFormatter{}
+	case FormatPlain:
+		return &PlainFormatter{
+			PRSizeXS:    weights.PRSizeXS,
+			PRSizeS:     weights.PRSizeS,
+			PRSizeM:     weights.PRSizeM,
+			PRSizeL:     weights.PRSizeL,
+			CurrentUser: currentUser,
+		}
 	default:
 		return &TableFormatter{
 			HotTopicThreshold: weights.HotTopicThreshold,
@@ -46,6 +161,9 @@ func NewFormatterWithWeights(format Format, weights config.ScoreWeights, current
 			PRSizeM:           weights.PRSizeM,
 			PRSizeL:           weights.PRSizeL,
 			CurrentUser:       currentUser,
+			Quick:             o.quick,
+			StatusMarkers:     o.statusMarkers,
+			Truncation:        o.truncation,
 		}
 	}
 }
@@ -1,22 +1,102 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
+	"unicode/utf8"
 
+	"github.com/spiffcs/triage/internal/format"
 	"github.com/spiffcs/triage/internal/triage"
 )
 
 // JSONFormatter formats output as JSON
 type JSONFormatter struct {
 	Pretty bool
+	// Fields trims each item to the selected fields (see selectFields).
+	// When empty, items are emitted in full.
+	Fields []string
+	// Envelope wraps the items in an object alongside Errors, instead of
+	// emitting them as a bare array.
+	Envelope bool
+	Errors   []RunError
+}
+
+// RunError is a problem that left a run's results incomplete, such as a
+// source that couldn't be fetched or items that couldn't be enriched.
+type RunError struct {
+	Source  string `json:"source"`
+	Message string `json:"message"`
+	// Count is how many items were affected, when known.
+	Count int `json:"count,omitempty"`
+}
+
+// envelope is the JSON output of JSONFormatter with Envelope set. Errors
+// is empty, never null, when the run was complete.
+type envelope struct {
+	Items  any        `json:"items"`
+	Errors []RunError `json:"errors"`
 }
 
 // Format outputs prioritized items as JSON
 func (f *JSONFormatter) Format(items []triage.PrioritizedItem, w io.Writer) error {
-	encoder := json.NewEncoder(w)
+	var v any = items
+	if items == nil {
+		v = []triage.PrioritizedItem{}
+	}
+	if len(f.Fields) > 0 {
+		rows, err := selectFields(items, f.Fields)
+		if err != nil {
+			return err
+		}
+		objects := make([]fieldObject, len(rows))
+		for i, row := range rows {
+			objects[i] = fieldObject{keys: f.Fields, values: row}
+		}
+		v = objects
+	}
+	if f.Envelope {
+		v = envelope{Items: v, Errors: append([]RunError{}, f.Errors...)}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
 	if f.Pretty {
 		encoder.SetIndent("", "  ")
 	}
-	return encoder.Encode(items)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := w.Write(escapeTerminalControls(buf.Bytes()))
+	return err
+}
+
+// escapeTerminalControls escapes the characters in encoded JSON that
+// encoding/json leaves raw but a terminal would act on: DEL, C1 controls,
+// and bidi overrides. They can only appear inside strings, so escaping
+// them leaves the decoded values unchanged and makes the JSON safe to
+// print.
+func escapeTerminalControls(b []byte) []byte {
+	isRaw := func(r rune) bool {
+		// C0 controls inside strings are already escaped, and the ones
+		// outside are the encoder's own newlines
+		return r >= 0x7f && format.IsTerminalControl(r)
+	}
+	if !bytes.ContainsFunc(b, isRaw) {
+		return b
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(b))
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if isRaw(r) {
+			fmt.Fprintf(&out, `\u%04x`, r)
+		} else {
+			out.Write(b[:size])
+		}
+		b = b[size:]
+	}
+	return out.Bytes()
 }
@@ -0,0 +1,43 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// JSONLFormatter formats output as JSON Lines: one object per item on its
+// own line. Unlike JSON, output from several calls can be concatenated, so
+// items can be written as they become ready.
+type JSONLFormatter struct {
+	// Fields trims each item to the selected fields (see selectFields).
+	// When empty, items are emitted in full.
+	Fields []string
+}
+
+// Format outputs one line of JSON per prioritized item
+func (f *JSONLFormatter) Format(items []triage.PrioritizedItem, w io.Writer) error {
+	var rows [][]any
+	if len(f.Fields) > 0 {
+		var err error
+		if rows, err = selectFields(items, f.Fields); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for i := range items {
+		var v any = items[i]
+		if rows != nil {
+			v = fieldObject{keys: f.Fields, values: rows[i]}
+		}
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(escapeTerminalControls(buf.Bytes()))
+	return err
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestJSONLFormatter(t *testing.T) {
+	second := fieldTestItems()[0]
+	second.Number = 43
+	second.Subject.Title = "Add \u202efeature"
+	items := append(fieldTestItems(), second)
+
+	tests := []struct {
+		name   string
+		items  []triage.PrioritizedItem
+		fields []string
+		want   string
+	}{
+		{
+			name:   "one line per item",
+			items:  items,
+			fields: []string{"number", "title"},
+			want:   `{"number":42,"title":"Fix bug"}` + "\n" + `{"number":43,"title":"Add \u202efeature"}` + "\n",
+		},
+		{
+			name:  "no items writes nothing",
+			items: nil,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := &JSONLFormatter{Fields: tt.fields}
+			if err := f.Format(tt.items, &buf); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONLFormatterFullItems(t *testing.T) {
+	// Calls append to one another, as when items are written in chunks
+	var buf bytes.Buffer
+	f := &JSONLFormatter{}
+	for range 2 {
+		if err := f.Format(fieldTestItems(), &buf); err != nil {
+			t.Fatalf("Format() error = %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var item map[string]any
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			t.Fatalf("line %q is not an object: %v", line, err)
+		}
+		if item["number"] != 42.0 || item["priority"] != "urgent" {
+			t.Errorf("decoded item = #%v %v, want #42 urgent", item["number"], item["priority"])
+		}
+	}
+}
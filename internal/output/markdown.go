@@ -0,0 +1,77 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// MarkdownFormatter writes items as a Markdown task list under a heading
+// per priority, most pressing first, for pasting into an issue or notes.
+type MarkdownFormatter struct{}
+
+// markdownEscaper escapes the characters in titles that Markdown would
+// read as formatting or links.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+)
+
+// Format outputs prioritized items as Markdown
+func (f *MarkdownFormatter) Format(items []triage.PrioritizedItem, w io.Writer) error {
+	if len(items) == 0 {
+		_, err := fmt.Fprintln(w, "No notifications found.")
+		return err
+	}
+
+	first := true
+	for _, p := range triage.AllPriorityLevels {
+		var group []triage.PrioritizedItem
+		for _, item := range items {
+			if item.Priority == p {
+				group = append(group, item)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		var b strings.Builder
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+		fmt.Fprintf(&b, "## %s (%d)\n\n", p.Display(), len(group))
+		for _, item := range group {
+			fmt.Fprintf(&b, "- [ ] %s %s", markdownRef(item), markdownEscaper.Replace(format.Sanitize(item.Subject.Title)))
+			if action := format.Sanitize(item.ActionNeeded); action != "" {
+				fmt.Fprintf(&b, " — %s", markdownEscaper.Replace(action))
+			}
+			b.WriteString("\n")
+		}
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markdownRef returns "owner/repo#123" linked to the item, or to its
+// repository when the item has no URL.
+func markdownRef(item triage.PrioritizedItem) string {
+	ref := markdownEscaper.Replace(itemRef(item))
+	url := format.ItemURL(item.HTMLURL, item.Repository.HTMLURL)
+	if url == "" {
+		return ref
+	}
+	return fmt.Sprintf("[%s](%s)", ref, url)
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestMarkdownFormatter(t *testing.T) {
+	items := []triage.PrioritizedItem{
+		{
+			Item: model.Item{
+				Number:     12,
+				HTMLURL:    "https://github.com/acme/api/pull/12",
+				Repository: model.Repository{FullName: "acme/api"},
+				Subject:    model.Subject{Title: "Add [beta] *pagination*"},
+			},
+			Priority:     triage.PriorityUrgent,
+			ActionNeeded: "Review PR",
+		},
+		{
+			Item: model.Item{
+				Repository: model.Repository{FullName: "acme/web", HTMLURL: "https://github.com/acme/web"},
+				Subject:    model.Subject{Title: "Flaky test"},
+			},
+			Priority: triage.PriorityFYI,
+		},
+		{
+			Item: model.Item{
+				Number:     3,
+				HTMLURL:    "https://github.com/acme/api/issues/3",
+				Repository: model.Repository{FullName: "acme/api"},
+				Subject:    model.Subject{Title: "Crash on start"},
+			},
+			Priority: triage.PriorityUrgent,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&MarkdownFormatter{}).Format(items, &buf); err != nil {
+		t.Fatalf("Format() error: %v", err)
+	}
+	want := "## Urgent (2)\n\n" +
+		"- [ ] [acme/api#12](https://github.com/acme/api/pull/12) Add \\[beta\\] \\*pagination\\* — Review PR\n" +
+		"- [ ] [acme/api#3](https://github.com/acme/api/issues/3) Crash on start\n" +
+		"\n" +
+		"## FYI (1)\n\n" +
+		"- [ ] [acme/web](https://github.com/acme/web) Flaky test\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := (&MarkdownFormatter{}).Format(nil, &buf); err != nil || buf.String() != "No notifications found.\n" {
+		t.Errorf("Format(nil) = %q, err %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,170 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// PlainFormatter writes one line per item as labeled fields ("Priority:
+// Urgent; Repository: ..."), with no color, box drawing, or column
+// padding, so the output reads well with a screen reader.
+type PlainFormatter struct {
+	PRSizeXS    int
+	PRSizeS     int
+	PRSizeM     int
+	PRSizeL     int
+	CurrentUser string
+}
+
+// plainSeparator separates the fields of a line.
+const plainSeparator = "; "
+
+// Format outputs prioritized items as labeled lines
+func (f *PlainFormatter) Format(items []triage.PrioritizedItem, w io.Writer) error {
+	if len(items) == 0 {
+		_, err := fmt.Fprintln(w, "No notifications found.")
+		return err
+	}
+
+	for i, item := range items {
+		fields := append([]string{fmt.Sprintf("Item %d of %d", i+1, len(items))}, f.fields(item)...)
+		if _, err := fmt.Fprintln(w, strings.Join(fields, plainSeparator)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fields returns the labeled fields of an item, leaving out those with
+// nothing to say.
+func (f *PlainFormatter) fields(item triage.PrioritizedItem) []string {
+	n := item.Item
+	var fields []string
+	// Values from GitHub may carry escape sequences aimed at the terminal
+	add := func(label, value string) {
+		if value = format.Sanitize(value); value != "" {
+			fields = append(fields, label+": "+value)
+		}
+	}
+
+	add("Priority", item.Priority.Display())
+	kind := "issue"
+	if n.IsPR() || n.Subject.Type == model.SubjectPullRequest {
+		kind = "pull request"
+	}
+	add("Type", kind)
+	add("Repository", n.Repository.FullName)
+	if n.Number > 0 {
+		add("Number", fmt.Sprintf("%d", n.Number))
+	}
+	add("Title", n.Subject.Title)
+	reason := strings.ReplaceAll(string(n.Reason), "_", " ")
+	if n.TeamReviewRequest(f.CurrentUser) {
+		reason += " (team)"
+	}
+	add("Reason", reason)
+	switch {
+	case n.State == "merged" || (n.PRDetails() != nil && n.PRDetails().Merged):
+		add("State", "merged")
+	case n.State == "closed":
+		add("State", "closed")
+	}
+	if n.Inaccessible {
+		add("Access", "repository not readable with this token")
+	}
+	if n.Stale {
+		add("Cache", "stale; shown offline from an expired cache entry")
+	}
+	if len(n.BlockedBy) > 0 {
+		add("Blocked by", strings.Join(n.BlockedBy, ", "))
+	}
+	if len(n.Blocks) > 0 {
+		add("Blocks", strings.Join(n.Blocks, ", "))
+	}
+	if m := n.Milestone; m != nil {
+		milestone := m.Title
+		if m.DueOn != nil {
+			milestone += " (due " + m.DueOn.Format("2006-01-02") + ")"
+		}
+		add("Milestone", milestone)
+	}
+	for _, p := range n.Projects {
+		if p.Status != "" {
+			add("Project", p.Project+": "+p.Status)
+		} else {
+			add("Project", p.Project)
+		}
+	}
+	if item.Resurfaced {
+		add("Resurfaced", "new activity since you marked it done")
+	}
+	if r := n.Reminder; r != nil {
+		note := r.Note
+		if note == "" {
+			note = "due"
+		}
+		add("Reminder", note)
+	}
+
+	if pr := n.PRDetails(); pr != nil {
+		add("Review", plainReviewState(pr.ReviewState))
+		if pr.Additions+pr.Deletions > 0 {
+			size := format.CalculatePRSize(pr.Additions, pr.Deletions, format.PRSizeThresholds{
+				XS: f.PRSizeXS, S: f.PRSizeS, M: f.PRSizeM, L: f.PRSizeL,
+			})
+			add("Size", fmt.Sprintf("%s, %d additions, %d deletions", size.Size, pr.Additions, pr.Deletions))
+		}
+		add("CI", plainCIStatus(pr.CIStatus))
+	}
+	if n.CommentCount > 0 {
+		add("Comments", fmt.Sprintf("%d", n.CommentCount))
+	}
+	if len(n.Assignees) > 0 {
+		add("Assigned", strings.Join(n.Assignees, ", "))
+	}
+
+	add("Waiting", format.FormatAgeWords(time.Since(n.WaitingSince(f.CurrentUser))))
+	if n.LastInteractionAt != nil {
+		add("You", format.FormatAgeWords(time.Since(*n.LastInteractionAt))+" ago")
+	}
+	if n.HTMLURL != "" {
+		add("Link", n.HTMLURL)
+	} else {
+		add("Link", n.Repository.HTMLURL)
+	}
+	return fields
+}
+
+// plainReviewState describes a PR's review state in words.
+func plainReviewState(state string) string {
+	switch state {
+	case model.ReviewStateApproved:
+		return "approved"
+	case model.ReviewStateChangesRequested:
+		return "changes requested"
+	case model.ReviewStatePending, model.ReviewStateReviewRequired, model.ReviewStateReviewed:
+		return "review needed"
+	default:
+		return ""
+	}
+}
+
+// plainCIStatus describes a PR's CI status in words.
+func plainCIStatus(status string) string {
+	switch status {
+	case model.CIStatusSuccess:
+		return "passing"
+	case model.CIStatusFailure:
+		return "failing"
+	case model.CIStatusPending:
+		return "pending"
+	default:
+		return ""
+	}
+}
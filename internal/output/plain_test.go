@@ -0,0 +1,52 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestPlainFormatter(t *testing.T) {
+	items := []triage.PrioritizedItem{
+		{Priority: triage.PriorityUrgent, Item: model.Item{
+			Reason:     model.ReasonReviewRequested,
+			Type:       model.ItemTypePullRequest,
+			Number:     12,
+			HTMLURL:    "https://github.com/acme/api/pull/12",
+			Subject:    model.Subject{Title: "Add pagination", Type: model.SubjectPullRequest},
+			Repository: model.Repository{FullName: "acme/api"},
+			UpdatedAt:  time.Now().Add(-3 * time.Hour),
+			Details: &model.PRDetails{
+				ReviewState: model.ReviewStateApproved,
+				CIStatus:    model.CIStatusFailure,
+				Additions:   40,
+				Deletions:   10,
+			},
+		}},
+		{Priority: triage.PriorityFYI, Item: model.Item{
+			Reason:     model.ReasonSubscribed,
+			Subject:    model.Subject{Title: "Flaky test", Type: model.SubjectIssue},
+			Repository: model.Repository{FullName: "acme/web"},
+			UpdatedAt:  time.Now().Add(-2 * 24 * time.Hour),
+		}},
+	}
+
+	var buf strings.Builder
+	f := &PlainFormatter{PRSizeXS: 10, PRSizeS: 50, PRSizeM: 200, PRSizeL: 500}
+	if err := f.Format(items, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Item 1 of 2; Priority: Urgent; Type: pull request; Repository: acme/api; Number: 12; " +
+		"Title: Add pagination; Reason: review requested; Review: approved; " +
+		"Size: S, 40 additions, 10 deletions; CI: failing; Waiting: 3 hours; " +
+		"Link: https://github.com/acme/api/pull/12\n" +
+		"Item 2 of 2; Priority: FYI; Type: issue; Repository: acme/web; Title: Flaky test; " +
+		"Reason: subscribed; Waiting: 2 days\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+}
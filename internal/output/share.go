@@ -0,0 +1,42 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// shareFactors is how many of the largest parts of the score a share
+// snippet names.
+const shareFactors = 3
+
+// Share returns a Markdown snippet introducing an item, for handing it to
+// a teammate in chat: a link and the title, the priority and the action
+// needed, and the largest parts of its score as why it matters. It keeps
+// to the Markdown that Slack also renders when pasted.
+func Share(e triage.Explanation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", markdownRef(e.PrioritizedItem), markdownEscaper.Replace(format.Sanitize(e.Subject.Title)))
+	fmt.Fprintf(&b, "*%s*", e.Priority.Display())
+	if action := format.Sanitize(e.ActionNeeded); action != "" {
+		fmt.Fprintf(&b, " — %s", markdownEscaper.Replace(action))
+	}
+	b.WriteString("\n")
+
+	factors := slices.DeleteFunc(slices.Clone(e.Factors), func(f triage.ScoreFactor) bool { return f.Points <= 0 })
+	slices.SortStableFunc(factors, func(a, b triage.ScoreFactor) int { return cmp.Compare(b.Points, a.Points) })
+	var why []string
+	for _, f := range factors[:min(len(factors), shareFactors)] {
+		// Reasons read as words, e.g. "review requested notification"
+		name := strings.ReplaceAll(format.Sanitize(f.Name), "_", " ")
+		why = append(why, fmt.Sprintf("%s (+%d)", markdownEscaper.Replace(name), f.Points))
+	}
+	if len(why) > 0 {
+		fmt.Fprintf(&b, "Why it matters: %s\n", strings.Join(why, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestShare(t *testing.T) {
+	item := triage.PrioritizedItem{
+		Item: model.Item{
+			Number:     12,
+			HTMLURL:    "https://github.com/acme/api/pull/12",
+			Repository: model.Repository{FullName: "acme/api"},
+			Subject:    model.Subject{Title: "Add *pagination*"},
+		},
+		Priority:     triage.PriorityUrgent,
+		ActionNeeded: "Review PR",
+	}
+	e := triage.Explanation{
+		PrioritizedItem: item,
+		Factors: []triage.ScoreFactor{
+			{Name: "review_requested notification", Points: 100},
+			{Name: "old (5 days)", Points: 10},
+			{Name: "draft", Points: -20},
+			{Name: "hot topic (12 comments)", Points: 15},
+			{Name: "small PR", Points: 5},
+		},
+	}
+
+	// The largest positive parts of the score, most first
+	want := "[acme/api#12](https://github.com/acme/api/pull/12) Add \\*pagination\\*\n" +
+		"*Urgent* — Review PR\n" +
+		"Why it matters: review requested notification (+100), hot topic (12 comments) (+15), old (5 days) (+10)\n"
+	if got := Share(e); got != want {
+		t.Errorf("Share() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Nothing to explain leaves the line out
+	want = "[acme/api#12](https://github.com/acme/api/pull/12) Add \\*pagination\\*\n" +
+		"*Urgent* — Review PR\n"
+	if got := Share(triage.Explanation{PrioritizedItem: item}); got != want {
+		t.Errorf("Share() without factors =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/i18n"
 	"github.com/spiffcs/triage/internal/log"
 	"github.com/spiffcs/triage/internal/model"
 	"github.com/spiffcs/triage/internal/triage"
@@ -23,8 +24,21 @@ type TableFormatter struct {
 	PRSizeM           int
 	PRSizeL           int
 	CurrentUser       string
+	// Quick marks the columns that need enrichment data as unavailable.
+	Quick bool
+	// StatusMarkers prefixes priorities with a letter marker such as "[U]".
+	StatusMarkers bool
+	// Truncation sets how long values are shortened in each column.
+	Truncation Truncation
 }
 
+// Columns that depend on enrichment are headed with unavailableMark in
+// quick mode and explained by quickModeNote below the table.
+const (
+	unavailableMark = "*"
+	quickModeNote   = "* not available in quick mode; run without --quick for assignees, review state, PR size, and your last comment or review"
+)
+
 // hyperlink creates a clickable terminal hyperlink using OSC 8
 // Format: \033]8;;URL\033\\TEXT\033]8;;\033\\
 func hyperlink(text, url string) string {
@@ -32,7 +46,8 @@ func hyperlink(text, url string) string {
 	if !term.IsTerminal(int(os.Stdout.Fd())) {
 		return text
 	}
-	return fmt.Sprintf("\033]8;;%s\033\\%s\033]8;;\033\\", url, text)
+	// A control character in the URL would end the sequence early
+	return fmt.Sprintf("\033]8;;%s\033\\%s\033]8;;\033\\", format.Sanitize(url), text)
 }
 
 // Format outputs prioritized items as a table
@@ -45,17 +60,30 @@ func (f *TableFormatter) Format(items []triage.PrioritizedItem, w io.Writer) err
 	}
 
 	// Header (↗ indicates column is clickable)
-	if _, err := fmt.Fprintf(w, "%-*s  %-*s  %-*s  %-*s  %-*s  %-*s  %s\n",
-		ColPriority, "Priority",
-		ColType, "Type",
-		ColAssigned, "Assigned",
-		ColRepo, "Repository ↗",
-		ColTitle, "Title ↗",
-		ColStatus, "Status",
-		"Age"); err != nil {
+	assignedHeader, statusHeader, youHeader := i18n.T(i18n.HeaderAssigned), i18n.T(i18n.HeaderStatus), i18n.T(i18n.HeaderYou)
+	if f.Quick {
+		assignedHeader += unavailableMark
+		statusHeader += unavailableMark
+		youHeader += unavailableMark
+	}
+	// Age is the last column, so it isn't padded
+	ageHeader, _ := format.TruncateToWidth(i18n.T(i18n.HeaderAge), ColAge)
+	priorityWidth := ColPriority
+	if f.StatusMarkers {
+		priorityWidth += ColPriorityMarker
+	}
+	if _, err := fmt.Fprintf(w, "%s  %s  %s  %s  %s  %s  %s  %s\n",
+		header(i18n.T(i18n.HeaderPriority), priorityWidth),
+		header(i18n.T(i18n.HeaderType), ColType),
+		header(assignedHeader, ColAssigned),
+		header(i18n.T(i18n.HeaderRepository)+" ↗", ColRepo),
+		header(i18n.T(i18n.HeaderTitle)+" ↗", ColTitle),
+		header(statusHeader, ColStatus),
+		header(youHeader, ColYou),
+		ageHeader); err != nil {
 		log.Trace("write error", "location", "header", "error", err)
 	}
-	separatorLen := ColPriority + ColType + ColAssigned + ColRepo + ColTitle + ColStatus + ColAge + 14
+	separatorLen := priorityWidth + ColType + ColAssigned + ColRepo + ColTitle + ColStatus + ColYou + ColAge + 16
 	if _, err := fmt.Fprintln(w, strings.Repeat("-", separatorLen)); err != nil {
 		log.Trace("write error", "location", "separator", "error", err)
 	}
@@ -70,8 +98,12 @@ func (f *TableFormatter) Format(items []triage.PrioritizedItem, w io.Writer) err
 			typeStr = "PR"
 		}
 
-		// Build title with icon prefix
-		title := n.Subject.Title
+		// Build title with icon prefix. Titles, repos, and logins come
+		// from GitHub, so strip anything that could drive the terminal
+		title := format.Sanitize(n.Subject.Title)
+		if n.Reminder != nil {
+			title = format.ReminderTitle(title, format.Sanitize(n.Reminder.Note))
+		}
 
 		// Determine icon using shared logic
 		var titleIcon string
@@ -83,6 +115,10 @@ func (f *TableFormatter) Format(items []triage.PrioritizedItem, w io.Writer) err
 			CurrentUser:       f.CurrentUser,
 			CommentCount:      n.CommentCount,
 			IsPR:              isPR,
+			Inaccessible:      n.Inaccessible,
+			Resurfaced:        item.Resurfaced,
+			ReminderDue:       n.Reminder != nil,
+			Stale:             n.Stale,
 		}
 		if issueDetails := n.IssueDetails(); issueDetails != nil {
 			iconInput.LastCommenter = issueDetails.LastCommenter
@@ -96,6 +132,18 @@ func (f *TableFormatter) Format(items []triage.PrioritizedItem, w io.Writer) err
 		case format.IconQuickWin:
 			titleIcon = color.YellowString(format.QuickWinIcon) + " "
 			iconDisplayWidth = format.IconWidth
+		case format.IconLocked:
+			titleIcon = format.LockedIcon + " "
+			iconDisplayWidth = format.IconWidth
+		case format.IconResurfaced:
+			titleIcon = format.ResurfacedIcon + " "
+			iconDisplayWidth = format.IconWidth
+		case format.IconReminder:
+			titleIcon = format.ReminderIcon + " "
+			iconDisplayWidth = format.IconWidth
+		case format.IconStale:
+			titleIcon = format.StaleIcon + " "
+			iconDisplayWidth = format.IconWidth
 		default:
 			titleIcon = "   " // 3 spaces
 			iconDisplayWidth = format.IconWidth
@@ -122,8 +170,8 @@ func (f *TableFormatter) Format(items []triage.PrioritizedItem, w io.Writer) err
 		}
 
 		// Truncate repo if too long
-		repo := n.Repository.FullName
-		repo, visibleRepoLen := format.TruncateToWidth(repo, ColRepo)
+		repo := format.Sanitize(n.Repository.FullName)
+		repo, visibleRepoLen := format.Truncate(repo, ColRepo, f.Truncation.Repository)
 
 		// Create hyperlinked repo and pad it
 		repoURL := n.Repository.HTMLURL
@@ -147,15 +195,30 @@ func (f *TableFormatter) Format(items []triage.PrioritizedItem, w io.Writer) err
 
 		// Format priority with color and pad
 		coloredPriority := colorPriority(item.Priority)
-		priorityStr := format.PadRight(coloredPriority, format.DisplayWidth(coloredPriority), ColPriority)
+		if f.StatusMarkers {
+			coloredPriority = item.Priority.Marker() + " " + coloredPriority
+		}
+		priorityStr := format.PadRight(coloredPriority, format.DisplayWidth(coloredPriority), priorityWidth)
 
 		// Format assigned column using shared logic
 		assigned := formatAssigned(&n, ColAssigned)
+		// Build status column (review state, PR size, or comment count)
+		statusRes := f.formatStatus(n)
+		if n.Chained() {
+			statusRes.text, statusRes.visibleWidth = format.ChainStatus(statusRes.text, statusRes.visibleWidth)
+		}
+		// When the current user last commented, reviewed, or opened it
+		you := formatLastInteraction(n.LastInteractionAt)
+		// Orphaned items carry their own details; everything else needs
+		// enrichment, which quick mode skips
+		if f.Quick && n.Reason != model.ReasonOrphaned {
+			assigned = "-"
+			statusRes = statusResult{"-", 1}
+			you = "-"
+		}
 		assignedWidth := format.DisplayWidth(assigned)
 		assigned = format.PadRight(assigned, assignedWidth, ColAssigned)
 
-		// Build status column (review state, PR size, or comment count)
-		statusRes := f.formatStatus(n)
 		statusText := statusRes.text
 		statusWidth := statusRes.visibleWidth
 		if statusWidth > ColStatus {
@@ -164,22 +227,30 @@ func (f *TableFormatter) Format(items []triage.PrioritizedItem, w io.Writer) err
 		}
 		statusText = format.PadRight(statusText, statusWidth, ColStatus)
 
-		// Calculate age using shared logic
-		age := format.FormatAge(time.Since(n.UpdatedAt))
+		// Calculate age using shared logic; pending review requests age
+		// from the request
+		age := format.FormatAge(time.Since(n.WaitingSince(f.CurrentUser)))
 
-		if _, err := fmt.Fprintf(w, "%s  %-*s  %s  %s  %s  %s  %s\n",
+		if _, err := fmt.Fprintf(w, "%s  %s  %s  %s  %s  %s  %s  %s\n",
 			priorityStr,
-			ColType, typeStr,
+			format.PadRight(typeStr, format.DisplayWidth(typeStr), ColType),
 			assigned,
 			linkedRepo,
 			linkedTitle,
 			statusText,
+			format.PadRight(you, format.DisplayWidth(you), ColYou),
 			age,
 		); err != nil {
 			log.Trace("write error", "location", "row", "error", err)
 		}
 	}
 
+	if f.Quick {
+		if _, err := fmt.Fprintf(w, "\n%s\n", quickModeNote); err != nil {
+			log.Trace("write error", "location", "quick mode note", "error", err)
+		}
+	}
+
 	return nil
 }
 
@@ -231,23 +302,23 @@ func (f *TableFormatter) formatStatus(n model.Item) statusResult {
 		if len(textParts) > 0 {
 			text := strings.Join(textParts, " ")
 			plain := strings.Join(plainParts, " ")
-			return statusResult{text, len(plain)}
+			return statusResult{text, format.DisplayWidth(plain)}
 		}
 	}
 
 	// For issues or PRs without specific status, show comment activity
 	if n.CommentCount > 0 {
 		text := fmt.Sprintf("%d comments", n.CommentCount)
-		return statusResult{text, len(text)}
+		return statusResult{text, format.DisplayWidth(text)}
 	}
 
 	// For items with assignees but no comments, show "assign"
 	if len(n.Assignees) > 0 {
-		return statusResult{"assign", 6}
+		return statusResult{"assign", format.DisplayWidth("assign")}
 	}
 
 	reason := string(n.Reason)
-	return statusResult{reason, len(reason)}
+	return statusResult{reason, format.DisplayWidth(reason)}
 }
 
 // colorPRSize returns a colored string for the PR size
@@ -262,19 +333,38 @@ func colorPRSize(size format.PRSize) string {
 	}
 }
 
+// colorPriority returns the priority's name, cut to fit the Priority
+// column, in its color.
 func colorPriority(p triage.PriorityLevel) string {
+	name, _ := format.TruncateToWidth(p.Display(), ColPriority)
 	switch p {
 	case triage.PriorityUrgent:
-		return color.RedString("Urgent")
+		return color.RedString(name)
 	case triage.PriorityImportant:
-		return color.YellowString("Important")
+		return color.YellowString(name)
 	case triage.PriorityQuickWin:
-		return color.GreenString("Quick Win")
+		return color.GreenString(name)
 	case triage.PriorityNotable:
-		return color.CyanString("Notable")
+		return color.CyanString(name)
 	default:
-		return color.WhiteString("FYI")
+		return color.WhiteString(name)
+	}
+}
+
+// header cuts a column header to the column width and pads it, so
+// translated headers keep the columns aligned.
+func header(label string, width int) string {
+	label, labelWidth := format.TruncateToWidth(label, width)
+	return format.PadRight(label, labelWidth, width)
+}
+
+// formatLastInteraction formats when the current user last interacted
+// with an item, e.g. "5d ago", or "─" if they never have.
+func formatLastInteraction(at *time.Time) string {
+	if at == nil {
+		return "─"
 	}
+	return format.FormatAgo(time.Since(*at))
 }
 
 // formatAssigned returns the assigned user for display
@@ -291,7 +381,7 @@ func formatAssigned(n *model.Item, maxWidth int) string {
 		input.RequestedReviewers = pr.RequestedReviewers
 	}
 
-	assigned := format.Assigned(input)
+	assigned := format.Sanitize(format.Assigned(input))
 	if assigned == "" {
 		return "─"
 	}
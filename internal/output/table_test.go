@@ -254,3 +254,198 @@ func TestIconPrecedenceAndAlignment(t *testing.T) {
 		})
 	}
 }
+
+func TestQuickModeMarksUnavailableColumns(t *testing.T) {
+	items := []triage.PrioritizedItem{
+		{Item: model.Item{
+			Reason:     model.ReasonReviewRequested,
+			Subject:    model.Subject{Title: "Review me", Type: model.SubjectPullRequest},
+			Repository: model.Repository{FullName: "owner/repo"},
+			Assignees:  []string{"someone"},
+			Details:    &model.PRDetails{ReviewState: model.ReviewStatePending},
+		}},
+		{Item: model.Item{
+			Reason:       model.ReasonOrphaned,
+			Subject:      model.Subject{Title: "Orphaned", Type: model.SubjectIssue},
+			Repository:   model.Repository{FullName: "owner/repo"},
+			CommentCount: 3,
+		}},
+	}
+
+	var buf strings.Builder
+	if err := (&TableFormatter{Quick: true}).Format(items, &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	lines := strings.Split(out, "\n")
+	if !strings.Contains(lines[0], "Assigned*") || !strings.Contains(lines[0], "Status*") || !strings.Contains(lines[0], "You*") {
+		t.Errorf("header should mark unavailable columns: %q", lines[0])
+	}
+	if strings.Contains(lines[2], "REVIEW") || strings.Contains(lines[2], "someone") {
+		t.Errorf("enrichment columns should be blanked in quick mode: %q", lines[2])
+	}
+	if !strings.Contains(lines[3], "3 comments") {
+		t.Errorf("orphaned items keep their own details: %q", lines[3])
+	}
+	if !strings.Contains(out, quickModeNote) {
+		t.Errorf("output should explain the mark:\n%s", out)
+	}
+}
+
+func TestLastInteractionColumn(t *testing.T) {
+	touched := time.Now().Add(-5 * 24 * time.Hour)
+	items := []triage.PrioritizedItem{
+		{Item: model.Item{
+			Subject:           model.Subject{Title: "Replied", Type: model.SubjectIssue},
+			Repository:        model.Repository{FullName: "owner/repo"},
+			UpdatedAt:         time.Now().Add(-2 * time.Hour),
+			LastInteractionAt: &touched,
+		}},
+		{Item: model.Item{
+			Subject:    model.Subject{Title: "Untouched", Type: model.SubjectIssue},
+			Repository: model.Repository{FullName: "owner/repo"},
+			UpdatedAt:  time.Now().Add(-2 * time.Hour),
+		}},
+	}
+
+	var buf strings.Builder
+	if err := (&TableFormatter{}).Format(items, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+
+	if !strings.Contains(lines[0], "You") {
+		t.Errorf("header should have a You column: %q", lines[0])
+	}
+	if !strings.Contains(lines[2], "5d ago") {
+		t.Errorf("row should show when you last interacted: %q", lines[2])
+	}
+	if strings.Contains(lines[3], "ago") {
+		t.Errorf("untouched item should show no interaction: %q", lines[3])
+	}
+}
+
+func TestStatusMarkers(t *testing.T) {
+	items := []triage.PrioritizedItem{
+		{Priority: triage.PriorityImportant, Item: model.Item{
+			Subject:    model.Subject{Title: "Needs a look", Type: model.SubjectIssue},
+			Repository: model.Repository{FullName: "owner/repo"},
+			UpdatedAt:  time.Now().Add(-2 * time.Hour),
+		}},
+	}
+
+	var buf strings.Builder
+	if err := (&TableFormatter{StatusMarkers: true}).Format(items, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+
+	if !strings.HasPrefix(lines[2], "[I] Important  ") {
+		t.Errorf("row should start with the marked priority and keep the column gap: %q", lines[2])
+	}
+	if header := lines[0]; strings.Index(header, "Type") != strings.Index(lines[2], "ISS") {
+		t.Errorf("header and row columns should line up:\n%q\n%q", header, lines[2])
+	}
+}
+
+func TestRepositoryTruncation(t *testing.T) {
+	items := []triage.PrioritizedItem{
+		{Priority: triage.PriorityFYI, Item: model.Item{
+			Subject:    model.Subject{Title: "Bump deps", Type: model.SubjectPullRequest},
+			Repository: model.Repository{FullName: "kubernetes-sigs/cluster-api-provider-aws"},
+			UpdatedAt:  time.Now().Add(-2 * time.Hour),
+		}},
+	}
+
+	tests := []struct {
+		name string
+		mode format.Truncation
+		want string
+	}{
+		{"end", format.TruncateEnd, "kubernetes-sigs/cluster..."},
+		{"middle", format.TruncateMiddle, "kubernetes-...provider-aws"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			f := &TableFormatter{Truncation: Truncation{Repository: tt.mode}}
+			if err := f.Format(items, &buf); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("table should show the repository as %q:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestWideCharacterAlignment(t *testing.T) {
+	updated := time.Now().Add(-3 * time.Hour)
+	item := func(title, repo, assignee string) triage.PrioritizedItem {
+		return triage.PrioritizedItem{Priority: triage.PriorityNotable, Item: model.Item{
+			Subject:    model.Subject{Title: title, Type: model.SubjectIssue},
+			Repository: model.Repository{FullName: repo},
+			Assignees:  []string{assignee},
+			UpdatedAt:  updated,
+		}}
+	}
+	items := []triage.PrioritizedItem{
+		item("Fix the login layout", "owner/repo", "octocat"),
+		item("a修复登录页面的布局问题并更新相关的文档和测试用例", "owner/日本語リポジトリ名前", "octocat"),
+		item("⚠️ breaking change ❤️ for 🐛 hunters everywhere on earth", "owner/repo", "octocat"),
+		item("한국어 제목", "owner/repo", "octocat-with-a-long-name"),
+	}
+
+	var buf strings.Builder
+	if err := (&TableFormatter{}).Format(items, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	rows := lines[2:]
+
+	want := format.DisplayWidth(rows[0])
+	for _, row := range rows[1:] {
+		if got := format.DisplayWidth(row); got != want {
+			t.Errorf("row is %d columns wide, want %d like the others:\n%s\n%s", got, want, rows[0], row)
+		}
+	}
+}
+
+func TestFormattersSanitizeExternalText(t *testing.T) {
+	items := []triage.PrioritizedItem{
+		{Priority: triage.PriorityUrgent, Item: model.Item{
+			Subject:    model.Subject{Title: "\x1b[2J\x1b[Hreal title\nfake row", Type: model.SubjectIssue},
+			Repository: model.Repository{FullName: "owner/\x1b[31mrepo"},
+			Assignees:  []string{"\u202eresu"},
+			UpdatedAt:  time.Now().Add(-2 * time.Hour),
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+	}{
+		{"table", &TableFormatter{}},
+		{"plain", &PlainFormatter{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			if err := tt.formatter.Format(items, &buf); err != nil {
+				t.Fatal(err)
+			}
+			out := buf.String()
+			for _, r := range out {
+				if r != '\n' && format.IsTerminalControl(r) {
+					t.Fatalf("output contains %U:\n%q", r, out)
+				}
+			}
+			if !strings.Contains(out, "real title fake row") || !strings.Contains(out, "owner/repo") {
+				t.Errorf("output should keep the text around the escapes:\n%s", out)
+			}
+		})
+	}
+}
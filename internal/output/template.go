@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// TemplateFormatter renders each item through a Go text/template, one item
+// per line. The template is executed against a *triage.PrioritizedItem, so
+// all item fields and methods (e.g. {{.Title}}) are available.
+type TemplateFormatter struct {
+	tmpl *template.Template
+}
+
+// templateFuncs are helpers available to user templates.
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"age": func(t time.Time) string {
+		return format.FormatAge(time.Since(t))
+	},
+	// sanitize strips escape sequences and control characters from text
+	// that came from GitHub, as the table does
+	"sanitize": format.Sanitize,
+}
+
+// NewTemplateFormatter parses text into a TemplateFormatter.
+func NewTemplateFormatter(text string) (*TemplateFormatter, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("template output requires --template")
+	}
+	tmpl, err := template.New("item").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return &TemplateFormatter{tmpl: tmpl}, nil
+}
+
+// Format renders every item through the template. A trailing newline is
+// added after each item unless the template already ends with one.
+func (f *TemplateFormatter) Format(items []triage.PrioritizedItem, w io.Writer) error {
+	var buf bytes.Buffer
+	for i := range items {
+		buf.Reset()
+		if err := f.tmpl.Execute(&buf, &items[i]); err != nil {
+			return fmt.Errorf("rendering template for %s: %w", items[i].Repository.FullName, err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestTemplateFormatter(t *testing.T) {
+	items := []triage.PrioritizedItem{
+		{
+			Item: model.Item{
+				Number:     42,
+				Repository: model.Repository{FullName: "owner/repo"},
+				Subject:    model.Subject{Title: "Fix bug"},
+				Labels:     []string{"bug", "p1"},
+			},
+			Priority: triage.PriorityUrgent,
+			Score:    120,
+		},
+		{
+			Item: model.Item{
+				Number:     7,
+				Repository: model.Repository{FullName: "owner/other"},
+				Subject:    model.Subject{Title: "Docs"},
+			},
+			Priority: triage.PriorityFYI,
+			Score:    10,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "fields and methods",
+			template: "{{.Priority}} {{.Repository.FullName}}#{{.Number}} {{.Title}}",
+			want:     "urgent owner/repo#42 Fix bug\nfyi owner/other#7 Docs\n",
+		},
+		{
+			name:     "explicit newline is not doubled",
+			template: "{{.Number}}\n",
+			want:     "42\n7\n",
+		},
+		{
+			name:     "helper funcs",
+			template: "{{upper (printf \"%s\" .Priority)}} {{join .Labels \",\"}}",
+			want:     "URGENT bug,p1\nFYI \n",
+		},
+		{
+			name:     "sanitize strips escapes",
+			template: "{{sanitize (printf \"\\x1b[2J%s\\n!\" .Title)}}",
+			want:     "Fix bug !\nDocs !\n",
+		},
+		{
+			name:     "unknown field fails",
+			template: "{{.NoSuchField}}",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewTemplateFormatter(tt.template)
+			if err != nil {
+				t.Fatalf("NewTemplateFormatter: %v", err)
+			}
+			var buf bytes.Buffer
+			err = f.Format(items, &buf)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Format: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateFormatterErrors(t *testing.T) {
+	if _, err := NewTemplateFormatter(""); err == nil {
+		t.Error("expected error for empty template")
+	}
+	if _, err := NewTemplateFormatter("{{.Number"); err == nil {
+		t.Error("expected error for malformed template")
+	}
+}
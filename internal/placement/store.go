@@ -0,0 +1,119 @@
+// Package placement records the TUI pane the user moved each item to,
+// overriding the automatic classification without touching GitHub.
+package placement
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/spiffcs/triage/internal/log"
+)
+
+// Version is the current on-disk format of the placement store.
+const Version = 1
+
+const storeName = "placement.json"
+
+// storeFile is the on-disk layout of the placement store.
+type storeFile struct {
+	Version int               `json:"version"`
+	Panes   map[string]string `json:"panes"`
+}
+
+// Store holds the pane each moved item belongs in, keyed by
+// model.Item.Key. Panes are named by the TUI.
+type Store struct {
+	path  string
+	panes map[string]string
+	mu    sync.RWMutex
+}
+
+// NewStore opens the placement store in the user cache directory.
+func NewStore() (*Store, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreFromPath(filepath.Join(cacheDir, "triage", storeName))
+}
+
+// NewStoreFromPath opens the placement store at the given file path.
+func NewStoreFromPath(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		path:  path,
+		panes: make(map[string]string),
+	}
+	if err := s.load(); err != nil {
+		log.Debug("could not load placement store, starting fresh", "error", err)
+	}
+	return s, nil
+}
+
+// load reads the placements from disk
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Version != Version {
+		return fmt.Errorf("unsupported placement store version %d", file.Version)
+	}
+	if file.Panes != nil {
+		s.panes = file.Panes
+	}
+	return nil
+}
+
+// save writes the placements to disk
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(storeFile{Version: Version, Panes: s.panes}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Move records that the item with the given key belongs in pane.
+func (s *Store) Move(key, pane string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.panes[key] = pane
+	return s.save()
+}
+
+// Reset returns the item with the given key to its automatic pane. It
+// reports whether the item had been moved.
+func (s *Store) Reset(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.panes[key]; !ok {
+		return false, nil
+	}
+	delete(s.panes, key)
+	return true, s.save()
+}
+
+// Pane returns the pane the item with the given key was moved to, if any.
+func (s *Store) Pane(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pane, ok := s.panes[key]
+	return pane, ok
+}
@@ -0,0 +1,41 @@
+package placement
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "placement.json")
+	store, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatalf("NewStoreFromPath() error: %v", err)
+	}
+	if err := store.Move("acme/api#12", "assigned"); err != nil {
+		t.Fatalf("Move() error: %v", err)
+	}
+	if err := store.Move("acme/api#12", "queue"); err != nil {
+		t.Fatalf("Move() error: %v", err)
+	}
+
+	reopened, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatalf("NewStoreFromPath() error: %v", err)
+	}
+	if pane, ok := reopened.Pane("acme/api#12"); !ok || pane != "queue" {
+		t.Errorf("Pane() = %q, %v; want the last pane moved to", pane, ok)
+	}
+	if _, ok := reopened.Pane("acme/api#13"); ok {
+		t.Error("Pane() found an item that was never moved")
+	}
+
+	if found, err := reopened.Reset("acme/api#12"); err != nil || !found {
+		t.Errorf("Reset() = %v, %v; want true, nil", found, err)
+	}
+	if found, _ := reopened.Reset("acme/api#12"); found {
+		t.Error("Reset() found an item already reset")
+	}
+	if _, ok := reopened.Pane("acme/api#12"); ok {
+		t.Error("Pane() found an item after Reset()")
+	}
+}
@@ -0,0 +1,74 @@
+package resolved
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/spiffcs/triage/internal/migrate"
+)
+
+const storeName = "resolved.json"
+
+// storeDir returns the directory holding the resolved store.
+func storeDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "triage"), nil
+}
+
+// MigrationTarget returns the default resolved store as a migration target.
+func MigrationTarget() (migrate.Target, error) {
+	dir, err := storeDir()
+	if err != nil {
+		return nil, err
+	}
+	return MigrationTargetFromPath(filepath.Join(dir, storeName)), nil
+}
+
+// MigrationTargetFromPath returns the resolved store at path as a
+// migration target.
+func MigrationTargetFromPath(path string) migrate.Target {
+	return migrate.NewFile("resolved", path, detectVersion, migrate.Step{
+		To:          1,
+		Description: "wrap entries in a versioned envelope",
+		Apply: func(data []byte) ([]byte, error) {
+			var entries map[string]ResolvedEntry
+			if len(bytes.TrimSpace(data)) > 0 {
+				if err := json.Unmarshal(data, &entries); err != nil {
+					return nil, err
+				}
+			}
+			if entries == nil {
+				entries = make(map[string]ResolvedEntry)
+			}
+			return json.MarshalIndent(storeFile{Version: 1, Entries: entries}, "", "  ")
+		},
+	})
+}
+
+// detectVersion reads the format version of a resolved store file. Version
+// 0 files are a bare map keyed by item ID, so they have no "version" key
+// holding a number.
+func detectVersion(data []byte) (int, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return 0, nil
+	}
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return 0, err
+	}
+	raw, ok := probe["version"]
+	if !ok {
+		return 0, nil
+	}
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		// A legacy entry that happens to be keyed "version"
+		return 0, nil
+	}
+	return version, nil
+}
@@ -10,9 +10,25 @@ import (
 	"github.com/spiffcs/triage/internal/log"
 )
 
+// Version is the current on-disk format of the resolved store.
+const Version = 1
+
+// storeFile is the on-disk layout of the resolved store. Version 0 files
+// were a bare map of entries.
+type storeFile struct {
+	Version int                      `json:"version"`
+	Entries map[string]ResolvedEntry `json:"entries"`
+}
+
 // ResolvedEntry represents when an item was marked as resolved
 type ResolvedEntry struct {
+	// ResolvedAt is the item's last update when it was resolved; later
+	// activity brings it back
 	ResolvedAt time.Time `json:"resolvedAt"`
+
+	// MarkedAt is when the item was resolved. Entries written by earlier
+	// versions don't have it.
+	MarkedAt time.Time `json:"markedAt,omitzero"`
 }
 
 // Store manages persistence of resolved items
@@ -39,17 +55,16 @@ func NewStoreFromPath(path string) (*Store, error) {
 
 // NewStore creates a new resolved items store
 func NewStore() (*Store, error) {
-	cacheDir, err := os.UserCacheDir()
+	dir, err := storeDir()
 	if err != nil {
 		return nil, err
 	}
 
-	dir := filepath.Join(cacheDir, "triage")
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, err
 	}
 
-	path := filepath.Join(dir, "resolved.json")
+	path := filepath.Join(dir, storeName)
 	s := &Store{
 		path:    path,
 		entries: make(map[string]ResolvedEntry),
@@ -72,12 +87,28 @@ func (s *Store) load() error {
 		return err
 	}
 
-	return json.Unmarshal(data, &s.entries)
+	version, err := detectVersion(data)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		// Not yet migrated; read the legacy layout directly
+		return json.Unmarshal(data, &s.entries)
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Entries != nil {
+		s.entries = file.Entries
+	}
+	return nil
 }
 
 // save writes the resolved entries to disk
 func (s *Store) save() error {
-	data, err := json.MarshalIndent(s.entries, "", "  ")
+	data, err := json.MarshalIndent(storeFile{Version: Version, Entries: s.entries}, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -85,33 +116,52 @@ func (s *Store) save() error {
 	return os.WriteFile(s.path, data, 0644)
 }
 
-// Resolve marks an item as resolved with the given updatedAt timestamp
-func (s *Store) Resolve(notificationID string, updatedAt time.Time) error {
+// Resolve marks the item with the given key as resolved with the given
+// updatedAt timestamp
+func (s *Store) Resolve(key string, updatedAt time.Time) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.entries[notificationID] = ResolvedEntry{
+	s.entries[key] = ResolvedEntry{
 		ResolvedAt: updatedAt,
+		MarkedAt:   time.Now(),
+	}
+
+	return s.save()
+}
+
+// ResolveAll marks every item in updatedAt, keyed by item key, as resolved
+// with its updatedAt timestamp, writing the store once
+func (s *Store) ResolveAll(updatedAt map[string]time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, at := range updatedAt {
+		s.entries[key] = ResolvedEntry{
+			ResolvedAt: at,
+			MarkedAt:   now,
+		}
 	}
 
 	return s.save()
 }
 
 // Unresolve removes an item from the resolved list
-func (s *Store) Unresolve(notificationID string) error {
+func (s *Store) Unresolve(key string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	delete(s.entries, notificationID)
+	delete(s.entries, key)
 	return s.save()
 }
 
 // ShouldShow returns true if the item should be shown (not resolved or has new activity)
-func (s *Store) ShouldShow(notificationID string, currentUpdatedAt time.Time) bool {
+func (s *Store) ShouldShow(key string, currentUpdatedAt time.Time) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	entry, exists := s.entries[notificationID]
+	entry, exists := s.entries[key]
 	if !exists {
 		return true
 	}
@@ -121,14 +171,54 @@ func (s *Store) ShouldShow(notificationID string, currentUpdatedAt time.Time) bo
 }
 
 // IsResolved returns true if the item is currently marked as resolved
-func (s *Store) IsResolved(notificationID string) bool {
+func (s *Store) IsResolved(key string) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, exists := s.entries[notificationID]
+	_, exists := s.entries[key]
 	return exists
 }
 
+// ResolvedSince returns when each item resolved at or after t was
+// resolved, by key.
+func (s *Store) ResolvedSince(t time.Time) map[string]time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	recent := make(map[string]time.Time)
+	for key, entry := range s.entries {
+		if !entry.MarkedAt.IsZero() && !entry.MarkedAt.Before(t) {
+			recent[key] = entry.MarkedAt
+		}
+	}
+	return recent
+}
+
+// Rekey moves entries recorded under an item's ID, as earlier versions
+// did, to the item's key. ids maps item IDs to keys; an entry already
+// recorded under the key wins.
+func (s *Store) Rekey(ids map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for id, key := range ids {
+		entry, ok := s.entries[id]
+		if !ok || id == key {
+			continue
+		}
+		if _, exists := s.entries[key]; !exists {
+			s.entries[key] = entry
+		}
+		delete(s.entries, id)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return s.save()
+}
+
 // Count returns the number of resolved items
 func (s *Store) Count() int {
 	s.mu.RLock()
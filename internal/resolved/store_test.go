@@ -92,3 +92,155 @@ func TestResolvedEntry(t *testing.T) {
 		t.Error("expected non-zero ResolvedAt")
 	}
 }
+
+func TestMigrateLegacyStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resolved.json")
+	legacy := `{"123": {"resolvedAt": "2024-01-02T03:04:05Z"}}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Legacy files are still readable before migrating
+	store, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !store.IsResolved("123") {
+		t.Fatal("expected legacy entry to load")
+	}
+
+	target := MigrationTargetFromPath(path)
+	plan, err := target.Plan()
+	if err != nil || plan == nil {
+		t.Fatalf("Plan() = %v, %v; want a pending migration", plan, err)
+	}
+	if plan.From != 0 || plan.To != Version {
+		t.Errorf("plan = v%d -> v%d, want v0 -> v%d", plan.From, plan.To, Version)
+	}
+	if err := target.Apply(plan); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+
+	store, err = NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !store.IsResolved("123") {
+		t.Error("expected entry to survive migration")
+	}
+	if plan, err := target.Plan(); err != nil || plan != nil {
+		t.Errorf("Plan() after migration = %v, %v; want nothing pending", plan, err)
+	}
+}
+
+func TestRekey(t *testing.T) {
+	store, err := NewStoreFromPath(filepath.Join(t.TempDir(), "resolved.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+	for id, at := range map[string]time.Time{
+		"review-requested-99": older,
+		"12345":               older,
+		"acme/api#7":          newer,
+		"release-1":           older,
+	} {
+		if err := store.Resolve(id, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = store.Rekey(map[string]string{
+		"review-requested-99": "acme/api#3",
+		"12345":               "acme/api#7", // already recorded under its key
+		"not-resolved":        "acme/api#8",
+	})
+	if err != nil {
+		t.Fatalf("Rekey() error = %v", err)
+	}
+
+	for _, id := range []string{"review-requested-99", "12345", "acme/api#8"} {
+		if store.IsResolved(id) {
+			t.Errorf("IsResolved(%q) = true after Rekey", id)
+		}
+	}
+	for _, key := range []string{"acme/api#3", "acme/api#7", "release-1"} {
+		if !store.IsResolved(key) {
+			t.Errorf("IsResolved(%q) = false after Rekey", key)
+		}
+	}
+	// The entry already under the key, resolved later, is kept
+	if store.ShouldShow("acme/api#7", older.Add(time.Minute)) {
+		t.Error("Rekey() replaced the entry already recorded under the key")
+	}
+
+	// The move is saved
+	reloaded, err := NewStoreFromPath(store.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.IsResolved("acme/api#3") || reloaded.IsResolved("review-requested-99") {
+		t.Error("Rekey() was not saved")
+	}
+}
+
+func TestResolvedSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resolved.json")
+	// One entry from a version that didn't record when items were resolved
+	legacy := `{"version": 1, "entries": {"acme/api#1": {"resolvedAt": "2024-01-01T00:00:00Z"}}}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now()
+	if err := store.Resolve("acme/api#2", before.Add(-48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	recent := store.ResolvedSince(before.Add(-time.Minute))
+	if len(recent) != 1 {
+		t.Fatalf("ResolvedSince() = %v, want only acme/api#2", recent)
+	}
+	if at, ok := recent["acme/api#2"]; !ok || at.Before(before) {
+		t.Errorf("ResolvedSince() = %v, want acme/api#2 resolved just now", recent)
+	}
+	if got := store.ResolvedSince(time.Now().Add(time.Minute)); len(got) != 0 {
+		t.Errorf("ResolvedSince(future) = %v, want none", got)
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	store, err := NewStoreFromPath(filepath.Join(t.TempDir(), "resolved.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+	err = store.ResolveAll(map[string]time.Time{
+		"acme/api#1": older,
+		"acme/api#2": newer,
+	})
+	if err != nil {
+		t.Fatalf("ResolveAll() error = %v", err)
+	}
+
+	reloaded, err := NewStoreFromPath(store.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Count() != 2 {
+		t.Errorf("Count() = %d after ResolveAll, want 2", reloaded.Count())
+	}
+	// Each item keeps its own timestamp
+	if !reloaded.ShouldShow("acme/api#1", older.Add(time.Minute)) {
+		t.Error("acme/api#1 hidden after an update")
+	}
+	if reloaded.ShouldShow("acme/api#2", older.Add(time.Minute)) {
+		t.Error("acme/api#2 shown without an update")
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/codeowners"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+	"golang.org/x/sync/errgroup"
+)
+
+// codeOwnersConcurrency is the number of CODEOWNERS files looked up at
+// once.
+const codeOwnersConcurrency = 5
+
+// checkCodeOwners sets OwnsChanges on the open PRs among items that ask one
+// of the user's teams for a review, so requests reaching everyone on a large
+// team rank by whether they touch code the user owns. A PR owns changes when
+// CODEOWNERS gives the user or one of their teams a file it changes. It is
+// left unset when the repository has no CODEOWNERS file, or when none of the
+// files listed are owned but the PR changes more than were listed.
+func (s *ItemService) checkCodeOwners(ctx context.Context, items []model.Item) {
+	teams := s.userTeams()
+	if len(teams) == 0 {
+		return
+	}
+	who := append([]string{s.currentUser}, teams...)
+
+	var checked []int
+	var repos []string
+	for i := range items {
+		n := &items[i]
+		pr := n.PRDetails()
+		if pr == nil || len(pr.Files) == 0 || n.State != model.StateOpen || !n.RequestsReviewFromTeam(s.currentUser, teams) ||
+			!s.scoresCodeOwners(n.Repository.FullName) {
+			continue
+		}
+		checked = append(checked, i)
+		if repo := strings.ToLower(n.Repository.FullName); !slices.Contains(repos, repo) {
+			repos = append(repos, repo)
+		}
+	}
+	if len(checked) == 0 {
+		return
+	}
+
+	// Each repository is a round trip on a cold cache; look them up
+	// together rather than holding up the chunk for each in turn
+	files := make(map[string]*codeowners.File, len(repos))
+	var mu sync.Mutex
+	var g errgroup.Group
+	g.SetLimit(codeOwnersConcurrency)
+	for _, repo := range repos {
+		g.Go(func() error {
+			f := s.codeOwners(ctx, repo)
+			mu.Lock()
+			files[repo] = f
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	for _, i := range checked {
+		n := &items[i]
+		f := files[strings.ToLower(n.Repository.FullName)]
+		if f == nil {
+			continue
+		}
+
+		pr := n.PRDetails()
+		owned := slices.ContainsFunc(pr.Files, func(path string) bool { return f.OwnedBy(path, who) })
+		if !owned && pr.ChangedFiles > len(pr.Files) {
+			continue
+		}
+		pr.OwnsChanges = &owned
+	}
+}
+
+// scoresCodeOwners reports whether ownership changes the score of repo's
+// items, under its repo_overrides weights when it has them.
+func (s *ItemService) scoresCodeOwners(repo string) bool {
+	w := s.weights
+	if repoWeights, ok := s.weights.RepoWeights[strings.ToLower(repo)]; ok {
+		w = repoWeights
+	}
+	return w.CodeOwnersBonus != 0 || w.CodeOwnersPenalty != 0
+}
+
+// codeOwners returns the parsed CODEOWNERS file of repo ("owner/repo"),
+// cached for cache.CodeOwnersTTL. When a lookup fails the last known file
+// is used. It returns nil when the repository has no CODEOWNERS file or it
+// can't be found.
+func (s *ItemService) codeOwners(ctx context.Context, repo string) *codeowners.File {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil
+	}
+
+	var cached string
+	if s.cache != nil {
+		text, fetchedAt, ok := s.cache.CodeOwners(repo)
+		if ok && (s.offline || time.Since(fetchedAt) <= cache.CodeOwnersTTL) {
+			return parseCodeOwners(text)
+		}
+		cached = text
+	}
+	if s.offline {
+		return nil
+	}
+
+	text, err := s.fetcher.CodeOwners(ctx, owner, name)
+	if err != nil {
+		log.Debug("could not look up CODEOWNERS", "repo", repo, "error", err)
+		return parseCodeOwners(cached)
+	}
+	if s.cache != nil {
+		if err := s.cache.SetCodeOwners(repo, text); err != nil {
+			log.Debug("failed to cache CODEOWNERS", "repo", repo, "error", err)
+		}
+	}
+	return parseCodeOwners(text)
+}
+
+// parseCodeOwners parses a CODEOWNERS file, returning nil for none.
+func parseCodeOwners(text string) *codeowners.File {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	return codeowners.Parse(text)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// ownersFetcher returns fixed teams and CODEOWNERS files by "owner/repo",
+// failing for repositories it doesn't know, and counts the file lookups.
+type ownersFetcher struct {
+	ghclient.GitHubFetcher
+	teams   []string
+	files   map[string]string
+	lookups atomic.Int32
+}
+
+func (f *ownersFetcher) UserTeams(context.Context, string) ([]string, error) {
+	return f.teams, nil
+}
+
+func (f *ownersFetcher) CodeOwners(_ context.Context, owner, repo string) (string, error) {
+	f.lookups.Add(1)
+	text, ok := f.files[owner+"/"+repo]
+	if !ok {
+		return "", fmt.Errorf("%s/%s not found", owner, repo)
+	}
+	return text, nil
+}
+
+func TestCheckCodeOwners(t *testing.T) {
+	c := newTestCache(t)
+	fetcher := &ownersFetcher{
+		teams: []string{"acme/everyone", "acme/api"},
+		files: map[string]string{
+			"acme/app":  "* @acme/everyone\n/web/ @acme/frontend\n/api/ @acme/api\n",
+			"acme/docs": "",
+		},
+	}
+	svc := New(fetcher, c, "me", time.Now())
+	ctx := context.Background()
+	if _, err := svc.UserTeams(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	pr := func(repo string, teams []string, files ...string) model.Item {
+		return model.Item{
+			Type:       model.ItemTypePullRequest,
+			State:      model.StateOpen,
+			Reason:     model.ReasonReviewRequested,
+			Repository: model.Repository{FullName: repo},
+			Details:    &model.PRDetails{RequestedTeams: teams, Files: files, ChangedFiles: len(files)},
+		}
+	}
+	direct := pr("acme/app", []string{"acme/everyone"}, "web/app.js")
+	direct.PRDetails().RequestedReviewers = []string{"me"}
+	truncated := pr("acme/app", []string{"acme/everyone"}, "web/app.js")
+	truncated.PRDetails().ChangedFiles = 150
+	closed := pr("acme/app", []string{"acme/api"}, "api/server.go")
+	closed.State = model.StateClosed
+
+	items := []model.Item{
+		pr("acme/app", []string{"acme/api"}, "web/app.js", "api/server.go"),
+		pr("acme/app", []string{"acme/everyone"}, "web/app.js", "web/style.css"),
+		pr("acme/app", []string{"acme/other"}, "api/server.go"),
+		direct,
+		truncated,
+		closed,
+		pr("acme/docs", []string{"acme/everyone"}, "guide.md"),
+		pr("acme/gone", []string{"acme/everyone"}, "main.go"),
+	}
+	svc.checkCodeOwners(ctx, items)
+
+	owned, notOwned := true, false
+	want := []*bool{&owned, &notOwned, nil, nil, nil, nil, nil, nil}
+	for i, w := range want {
+		got := items[i].PRDetails().OwnsChanges
+		if (got == nil) != (w == nil) || (got != nil && *got != *w) {
+			t.Errorf("item %d OwnsChanges = %v, want %v", i, describe(got), describe(w))
+		}
+	}
+	if got := fetcher.lookups.Load(); got != 3 {
+		t.Errorf("looked up %d CODEOWNERS files, want one per repository", got)
+	}
+
+	// Files are cached, including a repository having none
+	svc.checkCodeOwners(ctx, items)
+	if got := fetcher.lookups.Load(); got != 4 {
+		t.Errorf("looked up %d CODEOWNERS files, want only the failed one again", got)
+	}
+}
+
+func TestCheckCodeOwnersRepoWeights(t *testing.T) {
+	c := newTestCache(t)
+	fetcher := &ownersFetcher{
+		teams: []string{"acme/api"},
+		files: map[string]string{
+			"acme/app":  "/api/ @acme/api\n",
+			"acme/docs": "* @acme/api\n",
+		},
+	}
+	// Ownership only scores in acme/app, through its repo_overrides
+	weights := config.DefaultScoreWeights()
+	weights.CodeOwnersBonus, weights.CodeOwnersPenalty = 0, 0
+	app := weights
+	app.CodeOwnersBonus = 20
+	weights.RepoWeights = map[string]config.ScoreWeights{"acme/app": app}
+
+	svc := New(fetcher, c, "me", time.Now(), WithScoreWeights(weights))
+	ctx := context.Background()
+	if _, err := svc.UserTeams(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	items := []model.Item{
+		{
+			Type: model.ItemTypePullRequest, State: model.StateOpen, Reason: model.ReasonReviewRequested,
+			Repository: model.Repository{FullName: "Acme/App"},
+			Details:    &model.PRDetails{RequestedTeams: []string{"acme/api"}, Files: []string{"api/server.go"}, ChangedFiles: 1},
+		},
+		{
+			Type: model.ItemTypePullRequest, State: model.StateOpen, Reason: model.ReasonReviewRequested,
+			Repository: model.Repository{FullName: "acme/docs"},
+			Details:    &model.PRDetails{RequestedTeams: []string{"acme/api"}, Files: []string{"guide.md"}, ChangedFiles: 1},
+		},
+	}
+	svc.checkCodeOwners(ctx, items)
+
+	if got := items[0].PRDetails().OwnsChanges; got == nil || !*got {
+		t.Errorf("acme/app OwnsChanges = %s, want true under its repo weights", describe(got))
+	}
+	if got := items[1].PRDetails().OwnsChanges; got != nil {
+		t.Errorf("acme/docs OwnsChanges = %s, want unset when ownership doesn't score", describe(got))
+	}
+	if got := fetcher.lookups.Load(); got != 1 {
+		t.Errorf("looked up %d CODEOWNERS files, want only acme/app's", got)
+	}
+}
+
+func describe(b *bool) string {
+	if b == nil {
+		return "nil"
+	}
+	return fmt.Sprint(*b)
+}
+
+// gatedOwnersFetcher holds each CODEOWNERS lookup until every repository's
+// has started.
+type gatedOwnersFetcher struct {
+	ownersFetcher
+	started chan struct{}
+	want    int
+}
+
+func (f *gatedOwnersFetcher) CodeOwners(ctx context.Context, owner, repo string) (string, error) {
+	f.started <- struct{}{}
+	for len(f.started) < f.want {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+	return f.ownersFetcher.CodeOwners(ctx, owner, repo)
+}
+
+func TestCheckCodeOwnersLooksUpReposTogether(t *testing.T) {
+	repos := []string{"acme/app", "acme/web", "acme/api"}
+	fetcher := &gatedOwnersFetcher{
+		ownersFetcher: ownersFetcher{teams: []string{"acme/everyone"}, files: map[string]string{}},
+		started:       make(chan struct{}, len(repos)),
+		want:          len(repos),
+	}
+	var items []model.Item
+	for _, repo := range repos {
+		fetcher.files[repo] = "* @acme/everyone\n"
+		items = append(items, model.Item{
+			Type:       model.ItemTypePullRequest,
+			State:      model.StateOpen,
+			Reason:     model.ReasonReviewRequested,
+			Repository: model.Repository{FullName: repo},
+			Details:    &model.PRDetails{RequestedTeams: []string{"acme/everyone"}, Files: []string{"main.go"}, ChangedFiles: 1},
+		})
+	}
+	svc := New(fetcher, nil, "me", time.Now())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := svc.UserTeams(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	svc.checkCodeOwners(ctx, items)
+	for i := range items {
+		if got := items[i].PRDetails().OwnsChanges; got == nil || !*got {
+			t.Errorf("item %d OwnsChanges = %v, want true", i, describe(got))
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// Typical request latencies, used to turn request counts into a rough
+// run time.
+const (
+	restRequestLatency    = 500 * time.Millisecond
+	graphqlRequestLatency = 1500 * time.Millisecond
+)
+
+// API identifies which GitHub rate limit a request counts against.
+type API string
+
+const (
+	APICore    API = "core"
+	APISearch  API = "search"
+	APIGraphQL API = "graphql"
+)
+
+// SourceEstimate is the predicted cost of fetching one data source.
+type SourceEstimate struct {
+	Name string
+	API  API
+	// Items is how many items the source returned on the previous run.
+	Items int
+	// Cached is set when the cached list is fresh enough to be reused, so
+	// the source costs little or nothing.
+	Cached bool
+	Cost   ghclient.Cost
+}
+
+// Estimate is the predicted cost of a full run, based on what the previous
+// run left in the cache.
+type Estimate struct {
+	// Baseline is when the newest cached list was written; zero when there
+	// is no previous run to go on and every source is assumed to fit on a
+	// single page.
+	Baseline time.Time
+	Sources  []SourceEstimate
+	// EnrichItems is how many items have no fresh cached details.
+	EnrichItems int
+	Enrich      ghclient.Cost
+	// FetchDuration is how long fetching the sources should take.
+	FetchDuration time.Duration
+}
+
+// Duration is how long the run should take if quota allows: the sources
+// are fetched in parallel, then enrichment runs.
+func (e Estimate) Duration() time.Duration {
+	return e.FetchDuration + time.Duration(e.Enrich.Rounds)*graphqlRequestLatency
+}
+
+// Requests returns how many requests the run makes against api, and for
+// GraphQL how many rate-limit points they use.
+func (e Estimate) Requests(api API) (requests, points int) {
+	for _, src := range e.Sources {
+		if src.API == api {
+			requests += src.Cost.Requests
+			points += src.Cost.Points
+		}
+	}
+	if api == APIGraphQL {
+		requests += e.Enrich.Requests
+		points += e.Enrich.Points
+	}
+	return requests, points
+}
+
+// Estimate predicts what a full run will cost without calling GitHub. Item
+// counts come from the lists cached by the previous run; sources whose
+// cached list is still fresh are counted as (nearly) free, as are items
+// whose details are cached.
+func (s *ItemService) Estimate(opts FetchOptions) Estimate {
+	var est Estimate
+	lists := make(map[cache.ListType][]model.Item)
+
+	source := func(name string, listType cache.ListType, api API, listOpts cache.ListOptions, cost func(items int) ghclient.Cost) {
+		src := SourceEstimate{Name: name, API: api}
+		if s.cache != nil {
+			if entry, ok := s.cache.LastList(s.currentUser, listType); ok {
+				src.Items = len(entry.Items)
+				lists[listType] = entry.Items
+				if entry.CachedAt.After(est.Baseline) {
+					est.Baseline = entry.CachedAt
+				}
+			}
+			_, src.Cached = s.cache.GetList(s.currentUser, listType, listOpts)
+		}
+		switch {
+		case !src.Cached:
+			src.Cost = cost(src.Items)
+		case listType == cache.ListTypeNotifications:
+			// A fresh notification list is topped up with one request
+			src.Cost = ghclient.NotificationsCost(0)
+		}
+		est.Sources = append(est.Sources, src)
+	}
+
+	sinceOpts := cache.ListOptions{SinceTime: s.since}
+	source("notifications", cache.ListTypeNotifications, APICore, sinceOpts, ghclient.NotificationsCost)
+	source("review PRs", cache.ListTypeReviewRequested, APISearch, cache.ListOptions{}, ghclient.SearchCost)
+	source("authored PRs", cache.ListTypeAuthored, APISearch, cache.ListOptions{}, ghclient.SearchCost)
+	source("assigned issues", cache.ListTypeAssignedIssues, APISearch, cache.ListOptions{}, ghclient.SearchCost)
+	source("assigned PRs", cache.ListTypeAssignedPRs, APISearch, cache.ListOptions{}, ghclient.SearchCost)
+	if len(opts.OrphanedRepos) > 0 {
+		orphanedOpts := cache.ListOptions{SinceTime: s.since, Repos: opts.OrphanedRepos}
+		source("orphaned", cache.ListTypeOrphaned, APIGraphQL, orphanedOpts, func(int) ghclient.Cost {
+			return ghclient.OrphanedCost(len(opts.OrphanedRepos))
+		})
+	}
+
+	prs, issues := s.uncachedDetails(lists[cache.ListTypeNotifications], lists[cache.ListTypeReviewRequested], lists[cache.ListTypeAuthored])
+	est.EnrichItems = prs + issues
+	if est.EnrichItems > 0 {
+		est.Enrich = ghclient.EnrichmentCost(prs, issues)
+	}
+
+	for _, src := range est.Sources {
+		latency := restRequestLatency
+		if src.API == APIGraphQL {
+			latency = graphqlRequestLatency
+		}
+		est.FetchDuration = max(est.FetchDuration, time.Duration(src.Cost.Rounds)*latency)
+	}
+
+	return est
+}
+
+// NotificationCount returns about how many notifications UnreadItems will
+// list, asking GitHub with a single request per listing. It is 0 when the cached list
+// can be reused, since only notifications newer than it are then fetched,
+// and when offline, since nothing is fetched.
+func (s *ItemService) NotificationCount(ctx context.Context, includeRead bool) (int, error) {
+	if s.offline {
+		return 0, nil
+	}
+	if s.cache != nil {
+		if _, ok := s.cache.GetList(s.currentUser, cache.ListTypeNotifications, s.notificationListOptions(includeRead)); ok {
+			return 0, nil
+		}
+	}
+	if len(s.repos) == 0 {
+		return s.fetcher.CountNotifications(ctx, s.notificationOptions(s.since, includeRead, ""))
+	}
+	total := 0
+	for _, repo := range s.repos {
+		n, err := s.fetcher.CountNotifications(ctx, s.notificationOptions(s.since, includeRead, repo))
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// uncachedDetails counts the distinct PRs and issues across lists that
+// Enrich would have to query: those without fresh cached details, outside
+// repos recently found unreadable.
+func (s *ItemService) uncachedDetails(lists ...[]model.Item) (prs, issues int) {
+	var skipRepos map[string]bool
+	if s.cache != nil {
+		skipRepos = s.cache.InaccessibleRepos()
+	}
+
+	seen := make(map[cache.Key]bool)
+	for _, l := range lists {
+		for i := range l {
+			key, ok := buildCacheKey(&l[i])
+			if !ok || seen[key] || skipRepos[key.RepoFullName] {
+				continue
+			}
+			seen[key] = true
+			if s.cache != nil {
+				if _, cached := s.cachedDetails(s.cache, key, l[i].UpdatedAt); cached {
+					continue
+				}
+			}
+			if key.SubjectType == model.SubjectPullRequest {
+				prs++
+			} else {
+				issues++
+			}
+		}
+	}
+	return prs, issues
+}
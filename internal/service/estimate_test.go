@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestEstimate(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	c, err := cache.NewCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Now().Add(-24 * time.Hour)
+	var notifications []model.Item
+	for i := 1; i <= 150; i++ {
+		url := fmt.Sprintf("https://api.github.com/repos/org/repo/issues/%d", i)
+		notifications = append(notifications, makeFetchItem("org/repo", i, model.SubjectIssue, url, false))
+	}
+	// One item's details are already cached
+	cachedKey, _ := buildCacheKey(&notifications[0])
+	if err := c.Set(cachedKey, notifications[0].UpdatedAt, &notifications[0]); err != nil {
+		t.Fatal(err)
+	}
+	// A review request that is also a notification is enriched once
+	review := makeFetchItem("org/repo", 2, model.SubjectIssue, notifications[1].Subject.URL, false)
+
+	mustSetList(t, c, cache.ListTypeNotifications, notifications, time.Now(), since)
+	mustSetList(t, c, cache.ListTypeReviewRequested, []model.Item{review}, time.Now().Add(-time.Hour), time.Time{})
+	mustSetList(t, c, cache.ListTypeAuthored, nil, time.Now(), time.Time{})
+
+	svc := New(nil, c, "me", since)
+	est := svc.Estimate(FetchOptions{})
+
+	byName := make(map[string]SourceEstimate)
+	for _, src := range est.Sources {
+		byName[src.Name] = src
+	}
+	if src := byName["notifications"]; !src.Cached || src.Items != 150 || src.Cost.Requests != 1 {
+		t.Errorf("notifications = %+v, want a fresh list topped up with one request", src)
+	}
+	if src := byName["review PRs"]; src.Cached || src.Cost.Requests != 1 {
+		t.Errorf("review PRs = %+v, want a stale list searched again", src)
+	}
+	if src := byName["authored PRs"]; !src.Cached || src.Cost.Requests != 0 {
+		t.Errorf("authored PRs = %+v, want a fresh list reused for free", src)
+	}
+	if src := byName["assigned issues"]; src.Cached || src.Cost.Requests != 1 {
+		t.Errorf("assigned issues = %+v, want one page assumed without a cached list", src)
+	}
+	if _, ok := byName["orphaned"]; ok {
+		t.Error("orphaned should not be estimated without configured repos")
+	}
+
+	if est.EnrichItems != 149 {
+		t.Errorf("EnrichItems = %d, want 149 (150 distinct items, one cached)", est.EnrichItems)
+	}
+	if want := ghclient.EnrichmentCost(0, 149); est.Enrich != want {
+		t.Errorf("Enrich = %+v, want %+v", est.Enrich, want)
+	}
+	if requests, _ := est.Requests(APISearch); requests != 3 {
+		t.Errorf("search requests = %d, want 3", requests)
+	}
+	if est.Duration() <= est.FetchDuration {
+		t.Error("expected enrichment to add to the run time")
+	}
+}
+
+// countFetcher reports a fixed notification count.
+type countFetcher struct {
+	ghclient.GitHubFetcher
+	count int
+	calls int
+}
+
+func (f *countFetcher) CountNotifications(context.Context, ghclient.NotificationOptions) (int, error) {
+	f.calls++
+	return f.count, nil
+}
+
+func TestNotificationCount(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	c, err := cache.NewCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := time.Now().Add(-365 * 24 * time.Hour)
+	fetcher := &countFetcher{count: 4800}
+	svc := New(fetcher, c, "me", since)
+
+	got, err := svc.NotificationCount(context.Background(), false)
+	if err != nil || got != 4800 {
+		t.Errorf("NotificationCount() = %d, %v, want 4800 without a cached list", got, err)
+	}
+
+	mustSetList(t, c, cache.ListTypeNotifications, nil, time.Now(), since)
+	got, err = svc.NotificationCount(context.Background(), false)
+	if err != nil || got != 0 || fetcher.calls != 1 {
+		t.Errorf("NotificationCount() = %d, %v after %d calls, want 0 from the cached list", got, err, fetcher.calls)
+	}
+}
+
+func mustSetList(t *testing.T, c *cache.Cache, listType cache.ListType, items []model.Item, cachedAt, since time.Time) {
+	t.Helper()
+	if err := c.SetList("me", listType, &cache.ListCacheEntry{Items: items, CachedAt: cachedAt, SinceTime: since}); err != nil {
+		t.Fatal(err)
+	}
+}
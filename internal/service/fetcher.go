@@ -4,11 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 
 	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/log"
 	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -25,6 +31,16 @@ type FetchOptions struct {
 	ConsecutiveComments      int
 	IncludeReadNotifications bool
 	MaxItemsPerRepo          int
+	// OrphanedRules are the orphaned conditions per lowercased repo.
+	OrphanedRules        map[string]ghclient.OrphanedRules
+	OrphanedTriageLabels []string
+	OrphanedTeam         []string
+	// OrphanedTeams are GitHub teams, as "org/team-slug", whose members
+	// count as the team alongside OrphanedTeam.
+	OrphanedTeams   []string
+	UpstreamWatches []ghclient.UpstreamWatch
+	// Teams fetches the teams the user belongs to, for team-aware scoring.
+	Teams bool
 }
 
 // FetchResult contains all data fetched from GitHub.
@@ -35,13 +51,55 @@ type FetchResult struct {
 	AssignedIssues []model.Item
 	AssignedPRs    []model.Item
 	Orphaned       []model.Item
-	RateLimited    bool
+	Upstream       []model.Item
+	// Teams are the teams the user belongs to, as "org/team-slug", when
+	// FetchOptions.Teams is set.
+	Teams       []string
+	RateLimited bool
+	// Unauthorized is set when GitHub rejected the token during the fetch.
+	// Sources fetched before the rejection are kept.
+	Unauthorized bool
+	// Truncated are the searches past GitHub's result cap, which left out
+	// some of their results.
+	Truncated []ghclient.TruncatedSearch
+	// Failed holds the error of each source that could not be fetched, by
+	// source name. Sources canceled because another one failed, or by the
+	// deadline, are included with the reason.
+	Failed map[string]error
 }
 
 // TotalFetched returns the total number of items fetched across all sources.
 func (r *FetchResult) TotalFetched() int {
 	return len(r.Notifications) + len(r.ReviewPRs) + len(r.AuthoredPRs) +
-		len(r.AssignedIssues) + len(r.AssignedPRs) + len(r.Orphaned)
+		len(r.AssignedIssues) + len(r.AssignedPRs) + len(r.Orphaned) + len(r.Upstream)
+}
+
+// InaccessibleRepos returns the sorted names of repositories whose items
+// were kept unenriched because the token can't read them.
+func (r *FetchResult) InaccessibleRepos() []string {
+	repos := make(map[string]bool)
+	for _, source := range [][]model.Item{r.Notifications, r.ReviewPRs, r.AuthoredPRs, r.AssignedIssues, r.AssignedPRs, r.Orphaned} {
+		for _, item := range source {
+			if item.Inaccessible {
+				repos[item.Repository.FullName] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(repos))
+}
+
+// OnlyRepos drops items outside repos (owner/repo) from every source, so
+// they are neither enriched nor listed.
+func (r *FetchResult) OnlyRepos(repos []string) {
+	keep := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		keep[strings.ToLower(repo)] = true
+	}
+	for _, source := range []*[]model.Item{&r.Notifications, &r.ReviewPRs, &r.AuthoredPRs, &r.AssignedIssues, &r.AssignedPRs, &r.Orphaned, &r.Upstream} {
+		*source = slices.DeleteFunc(*source, func(item model.Item) bool {
+			return !keep[strings.ToLower(item.Repository.FullName)]
+		})
+	}
 }
 
 // MergeStats contains the counts of items added during merge operations.
@@ -51,6 +109,7 @@ type MergeStats struct {
 	AssignedIssuesAdded int
 	AssignedPRsAdded    int
 	OrphanedAdded       int
+	UpstreamAdded       int
 }
 
 // Merge combines all sources into a single deduplicated list.
@@ -77,6 +136,9 @@ func (r *FetchResult) Merge() ([]model.Item, MergeStats) {
 	if len(r.Orphaned) > 0 {
 		merged, stats.OrphanedAdded = deduplicateOrphaned(merged, r.Orphaned)
 	}
+	// Upstream items are raised by triage itself, so nothing else lists them
+	merged = append(merged, r.Upstream...)
+	stats.UpstreamAdded = len(r.Upstream)
 
 	return merged, stats
 }
@@ -105,7 +167,13 @@ func (f *Fetcher) reportProgress(completed, total int, source string) {
 func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult, error) {
 	totalFetches := 5
 	if len(opts.OrphanedRepos) > 0 {
-		totalFetches = 6
+		totalFetches++
+	}
+	if len(opts.UpstreamWatches) > 0 {
+		totalFetches++
+	}
+	if opts.Teams {
+		totalFetches++
 	}
 
 	var completedFetches int32
@@ -124,11 +192,54 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 	result := &FetchResult{}
 	var mu sync.Mutex
 
-	g, gctx := errgroup.WithContext(ctx)
+	// A rejected token fails the source and, through the errgroup, cancels
+	// the others; record it so callers can explain why.
+	recordAuthFailure := func(err error) {
+		if ghclient.IsAuthError(err) {
+			mu.Lock()
+			result.Unauthorized = true
+			mu.Unlock()
+		}
+	}
+
+	g, groupCtx := errgroup.WithContext(ctx)
+
+	// Each source is traced as its own span. A source cut short still
+	// failed: without it the list is partial.
+	goSource := func(source string, fetch func(gctx context.Context) error) {
+		g.Go(func() error {
+			sctx, span := telemetry.Start(groupCtx, "fetch "+source, attribute.String("triage.source", source))
+			err := fetch(sctx)
+			telemetry.End(span, err)
+			if err != nil {
+				reason := err
+				if errors.Is(err, context.Canceled) {
+					if cause := context.Cause(groupCtx); cause != nil && !errors.Is(cause, context.Canceled) {
+						reason = fmt.Errorf("%w: %v", groupCtx.Err(), cause)
+					}
+				}
+				mu.Lock()
+				if result.Failed == nil {
+					result.Failed = make(map[string]error)
+				}
+				result.Failed[source] = reason
+				mu.Unlock()
+			}
+			return err
+		})
+	}
 
 	// Fetch notifications (with caching)
-	g.Go(func() error {
+	goSource("notifications", func(gctx context.Context) error {
 		startSource("notifications")
+		// A long window spans many pages; report each one
+		gctx = ghclient.WithPageProgress(gctx, func(fetched, total int) {
+			if total > 0 {
+				startSource(fmt.Sprintf("notifications page %d/%d", fetched, total))
+			} else {
+				startSource(fmt.Sprintf("notifications page %d", fetched))
+			}
+		})
 		notifResult, err := f.svc.UnreadItems(gctx, opts.IncludeReadNotifications)
 		if err != nil {
 			if errors.Is(err, ghclient.ErrRateLimited) {
@@ -138,6 +249,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 				completeSource("notifications")
 				return nil
 			}
+			recordAuthFailure(err)
 			completeSource("notifications")
 			return fmt.Errorf("notifications: %w", err)
 		}
@@ -149,7 +261,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 	})
 
 	// Fetch review-requested PRs
-	g.Go(func() error {
+	goSource("review PRs", func(gctx context.Context) error {
 		startSource("review PRs")
 		prs, _, err := f.svc.ReviewRequestedPRs(gctx)
 		if err != nil {
@@ -160,6 +272,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 				completeSource("review PRs")
 				return nil
 			}
+			recordAuthFailure(err)
 			completeSource("review PRs")
 			return fmt.Errorf("review-requested PRs: %w", err)
 		}
@@ -171,7 +284,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 	})
 
 	// Fetch authored PRs
-	g.Go(func() error {
+	goSource("authored PRs", func(gctx context.Context) error {
 		startSource("authored PRs")
 		prs, _, err := f.svc.AuthoredPRs(gctx)
 		if err != nil {
@@ -182,6 +295,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 				completeSource("authored PRs")
 				return nil
 			}
+			recordAuthFailure(err)
 			completeSource("authored PRs")
 			return fmt.Errorf("authored PRs: %w", err)
 		}
@@ -193,7 +307,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 	})
 
 	// Fetch assigned issues
-	g.Go(func() error {
+	goSource("assigned issues", func(gctx context.Context) error {
 		startSource("assigned issues")
 		issues, _, err := f.svc.AssignedIssues(gctx)
 		if err != nil {
@@ -204,6 +318,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 				completeSource("assigned issues")
 				return nil
 			}
+			recordAuthFailure(err)
 			completeSource("assigned issues")
 			return fmt.Errorf("assigned issues: %w", err)
 		}
@@ -215,7 +330,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 	})
 
 	// Fetch assigned PRs
-	g.Go(func() error {
+	goSource("assigned PRs", func(gctx context.Context) error {
 		startSource("assigned PRs")
 		prs, _, err := f.svc.AssignedPRs(gctx)
 		if err != nil {
@@ -226,6 +341,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 				completeSource("assigned PRs")
 				return nil
 			}
+			recordAuthFailure(err)
 			completeSource("assigned PRs")
 			return fmt.Errorf("assigned PRs: %w", err)
 		}
@@ -238,7 +354,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 
 	// Fetch orphaned contributions (if configured)
 	if len(opts.OrphanedRepos) > 0 {
-		g.Go(func() error {
+		goSource("orphaned", func(gctx context.Context) error {
 			startSource("orphaned")
 			maxPerRepo := opts.MaxItemsPerRepo
 			if maxPerRepo <= 0 {
@@ -249,6 +365,9 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 				StaleDays:                 opts.StaleDays,
 				ConsecutiveAuthorComments: opts.ConsecutiveComments,
 				MaxPerRepo:                maxPerRepo,
+				Rules:                     opts.OrphanedRules,
+				TriageLabels:              opts.OrphanedTriageLabels,
+				TeamLogins:                append(slices.Clone(opts.OrphanedTeam), f.svc.TeamRoster(gctx, opts.OrphanedTeams)...),
 			}
 			orphaned, _, err := f.svc.OrphanedContributions(gctx, searchOpts)
 			if err != nil {
@@ -259,6 +378,7 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 					completeSource("orphaned")
 					return nil
 				}
+				recordAuthFailure(err)
 				completeSource("orphaned")
 				return fmt.Errorf("orphaned contributions: %w", err)
 			}
@@ -270,7 +390,57 @@ func (f *Fetcher) FetchAll(ctx context.Context, opts FetchOptions) (*FetchResult
 		})
 	}
 
+	// Check upstream blockers (if configured)
+	if len(opts.UpstreamWatches) > 0 {
+		goSource("upstream", func(gctx context.Context) error {
+			startSource("upstream")
+			upstream, err := f.svc.ResolvedUpstreams(gctx, opts.UpstreamWatches)
+			if err != nil {
+				if errors.Is(err, ghclient.ErrRateLimited) {
+					mu.Lock()
+					result.RateLimited = true
+					mu.Unlock()
+					completeSource("upstream")
+					return nil
+				}
+				recordAuthFailure(err)
+				completeSource("upstream")
+				return fmt.Errorf("upstream: %w", err)
+			}
+			mu.Lock()
+			result.Upstream = upstream
+			mu.Unlock()
+			completeSource("upstream")
+			return nil
+		})
+	}
+
+	// Fetch team memberships (if requested). They only adjust scores, so
+	// a failure, such as a token without read:org, doesn't fail the fetch.
+	if opts.Teams {
+		goSource("teams", func(gctx context.Context) error {
+			startSource("teams")
+			teams, err := f.svc.UserTeams(gctx)
+			if err != nil {
+				log.Warn("could not fetch team memberships, team review requests won't be boosted", "error", err)
+				completeSource("teams")
+				return nil
+			}
+			mu.Lock()
+			result.Teams = teams
+			mu.Unlock()
+			completeSource("teams")
+			return nil
+		})
+	}
+
 	err := g.Wait()
+	// Sources that fell back to cache on a rejected token don't return an
+	// error, so also check whether any request was rejected.
+	if f.svc.Unauthorized() {
+		result.Unauthorized = true
+	}
+	result.Truncated = f.svc.truncatedSearches()
 	return result, err
 }
 
@@ -1,9 +1,15 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
+	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/ghclient"
 	"github.com/spiffcs/triage/internal/model"
 )
 
@@ -65,6 +71,29 @@ func TestFetchResult_TotalFetched(t *testing.T) {
 	}
 }
 
+func TestFetchResult_OnlyRepos(t *testing.T) {
+	result := &FetchResult{
+		Notifications: []model.Item{
+			makeFetchItem("acme/api", 1, model.SubjectIssue, "", true),
+			makeFetchItem("acme/web", 2, model.SubjectIssue, "", true),
+		},
+		ReviewPRs: []model.Item{makeFetchItem("Acme/API", 3, model.SubjectPullRequest, "", true)},
+		Orphaned:  []model.Item{makeFetchItem("acme/web", 4, model.SubjectIssue, "", true)},
+	}
+
+	result.OnlyRepos([]string{"acme/api", "acme/cli"})
+
+	if len(result.Notifications) != 1 || result.Notifications[0].Number != 1 {
+		t.Errorf("Notifications = %+v, want only acme/api#1", result.Notifications)
+	}
+	if len(result.ReviewPRs) != 1 {
+		t.Errorf("ReviewPRs = %+v, want the PR matched regardless of case", result.ReviewPRs)
+	}
+	if len(result.Orphaned) != 0 {
+		t.Errorf("Orphaned = %+v, want none", result.Orphaned)
+	}
+}
+
 func TestFetchResult_Merge(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -296,3 +325,72 @@ func TestDeduplicateOrphaned(t *testing.T) {
 		t.Errorf("last item = %s, want %s", gotKey, want)
 	}
 }
+
+// revokedFetcher serves review-requested PRs, then behaves as if the token
+// was revoked for every other source.
+type revokedFetcher struct {
+	ghclient.GitHubFetcher
+	reviewPRs []model.Item
+}
+
+var errBadCredentials = &gh.ErrorResponse{
+	Response: &http.Response{StatusCode: http.StatusUnauthorized},
+	Message:  "Bad credentials",
+}
+
+func (f *revokedFetcher) Unauthorized() bool { return true }
+
+func (f *revokedFetcher) TruncatedSearches() []ghclient.TruncatedSearch { return nil }
+
+func (f *revokedFetcher) ListReviewRequestedPRs(context.Context, string) ([]model.Item, error) {
+	return f.reviewPRs, nil
+}
+
+func (f *revokedFetcher) ListUnreadNotifications(context.Context, time.Time) ([]model.Item, error) {
+	return nil, errBadCredentials
+}
+
+func (f *revokedFetcher) ListAuthoredPRs(context.Context, string) ([]model.Item, error) {
+	return nil, fmt.Errorf("wrapped: %w", ghclient.ErrUnauthorized)
+}
+
+func (f *revokedFetcher) ListAssignedIssues(ctx context.Context, _ string) ([]model.Item, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (f *revokedFetcher) ListAssignedPRs(ctx context.Context, _ string) ([]model.Item, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestFetchAll_RevokedToken(t *testing.T) {
+	review := makeFetchItem("org/repo", 1, model.SubjectPullRequest, "url1", true)
+	svc := New(&revokedFetcher{reviewPRs: []model.Item{review}}, nil, "testuser", time.Now().Add(-time.Hour))
+
+	result, err := NewFetcher(svc, nil).FetchAll(context.Background(), FetchOptions{})
+	if err == nil {
+		t.Fatal("expected an error from the rejected sources")
+	}
+	if !result.Unauthorized {
+		t.Error("expected Unauthorized to be set")
+	}
+	if len(result.ReviewPRs) != 1 {
+		t.Errorf("ReviewPRs = %d, want the 1 fetched before the rejection", len(result.ReviewPRs))
+	}
+	if result.Failed["notifications"] == nil || result.Failed["authored PRs"] == nil {
+		t.Errorf("Failed = %v, want the rejected sources", result.Failed)
+	}
+	// Sources canceled by the rejection failed too, saying why
+	for _, source := range []string{"assigned issues", "assigned PRs"} {
+		err := result.Failed[source]
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Failed[%q] = %v, want it canceled", source, err)
+		} else if err.Error() == context.Canceled.Error() {
+			t.Errorf("Failed[%q] = %v, want the reason it was canceled", source, err)
+		}
+	}
+	if result.Failed["review PRs"] != nil {
+		t.Errorf("Failed[review PRs] = %v, want it fetched", result.Failed["review PRs"])
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/log"
+)
+
+// ResolveUser returns the login token authenticates as. The login is
+// cached in c (nil disables caching) for cache.IdentityTTL, saving a
+// request on every run; refresh looks it up again regardless.
+func ResolveUser(ctx context.Context, fetcher ghclient.GitHubFetcher, c *cache.Cache, token string, refresh bool) (string, error) {
+	if c != nil && !refresh {
+		if login, fetchedAt, ok := c.Login(token); ok && login != "" && time.Since(fetchedAt) <= cache.IdentityTTL {
+			return login, nil
+		}
+	}
+
+	login, err := fetcher.AuthenticatedUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	if c != nil {
+		if err := c.SetLogin(token, login); err != nil {
+			log.Debug("failed to cache login", "error", err)
+		}
+	}
+	return login, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/ghclient"
+)
+
+// identityFetcher returns a fixed login and teams, failing once err is
+// set, and counts the lookups.
+type identityFetcher struct {
+	ghclient.GitHubFetcher
+	login       string
+	teams       []string
+	err         error
+	userLookups int
+	teamLookups int
+}
+
+func (f *identityFetcher) AuthenticatedUser(context.Context) (string, error) {
+	f.userLookups++
+	return f.login, f.err
+}
+
+func (f *identityFetcher) UserTeams(context.Context, string) ([]string, error) {
+	f.teamLookups++
+	return f.teams, f.err
+}
+
+func newTestCache(t *testing.T) *cache.Cache {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	c, err := cache.NewCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestResolveUser(t *testing.T) {
+	c := newTestCache(t)
+	fetcher := &identityFetcher{login: "octocat"}
+	ctx := context.Background()
+
+	for range 2 {
+		login, err := ResolveUser(ctx, fetcher, c, "token", false)
+		if err != nil || login != "octocat" {
+			t.Fatalf("ResolveUser() = %q, %v; want octocat", login, err)
+		}
+	}
+	if fetcher.userLookups != 1 {
+		t.Errorf("looked up the user %d times, want the cached login reused", fetcher.userLookups)
+	}
+
+	// Another token, and refresh, look the login up again
+	fetcher.login = "hubot"
+	if login, _ := ResolveUser(ctx, fetcher, c, "other-token", false); login != "hubot" {
+		t.Errorf("ResolveUser() for another token = %q, want hubot", login)
+	}
+	if login, _ := ResolveUser(ctx, fetcher, c, "token", true); login != "hubot" {
+		t.Errorf("ResolveUser() with refresh = %q, want hubot", login)
+	}
+	if fetcher.userLookups != 3 {
+		t.Errorf("looked up the user %d times, want 3", fetcher.userLookups)
+	}
+
+	// Without a cache every call looks the login up, and errors pass through
+	fetcher.err = errors.New("bad credentials")
+	if _, err := ResolveUser(ctx, fetcher, nil, "token", false); err == nil {
+		t.Error("ResolveUser() without a cache should return the lookup error")
+	}
+}
+
+func TestUserTeamsCached(t *testing.T) {
+	c := newTestCache(t)
+	fetcher := &identityFetcher{teams: []string{"acme/maintainers"}}
+	ctx := context.Background()
+	want := []string{"acme/maintainers"}
+
+	svc := New(fetcher, c, "octocat", time.Now())
+	for range 2 {
+		if got, err := svc.UserTeams(ctx); err != nil || !slices.Equal(got, want) {
+			t.Fatalf("UserTeams() = %v, %v; want %v", got, err, want)
+		}
+	}
+	if fetcher.teamLookups != 1 {
+		t.Errorf("looked up teams %d times, want the cached ones reused", fetcher.teamLookups)
+	}
+
+	// A refresh looks them up again, and keeps the cached ones when that fails
+	fetcher.err = errors.New("token lacks read:org")
+	refreshed := New(fetcher, c, "octocat", time.Now(), WithRefreshIdentity(true))
+	if got, err := refreshed.UserTeams(ctx); err != nil || !slices.Equal(got, want) {
+		t.Errorf("UserTeams() after a failed refresh = %v, %v; want %v", got, err, want)
+	}
+	if fetcher.teamLookups != 2 {
+		t.Errorf("looked up teams %d times, want 2", fetcher.teamLookups)
+	}
+
+	// With nothing cached, the error is returned
+	if _, err := New(fetcher, nil, "octocat", time.Now()).UserTeams(ctx); err == nil {
+		t.Error("UserTeams() without a cache should return the lookup error")
+	}
+}
@@ -3,20 +3,32 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/spiffcs/triage/config"
 	"github.com/spiffcs/triage/internal/cache"
 	"github.com/spiffcs/triage/internal/ghclient"
 	"github.com/spiffcs/triage/internal/log"
 	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/telemetry"
+	"github.com/spiffcs/triage/internal/triage"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // FetchStats records which data sources were served from cache.
 // Populated as Get* methods are called; safe for concurrent access.
 type FetchStats struct {
-	NotifFromCache       bool
-	NotifNewCount        int
+	NotifFromCache bool
+	NotifNewCount  int
+	// NotifNotModified is set when GitHub reported no new notifications
+	// since the last poll, which cost no rate limit
+	NotifNotModified     bool
 	ReviewFromCache      bool
 	AuthoredFromCache    bool
 	AssignedFromCache    bool
@@ -48,20 +60,106 @@ type ItemService struct {
 	cache       *cache.Cache
 	currentUser string
 	since       time.Time
+	// weights order items for enrichment (see prioritizeForEnrichment).
+	weights config.ScoreWeights
+	// participating limits notifications to threads the user takes part in.
+	participating bool
+	// repos limits notifications to these repositories (owner/repo).
+	repos []string
+	// refreshIdentity looks up team memberships instead of using the
+	// cached ones.
+	refreshIdentity bool
+	// offline serves everything from the cache, however old, and never
+	// calls the fetcher.
+	offline bool
+	// projectStatus needs cached details to include the project boards
+	// (see WithProjectStatus).
+	projectStatus bool
 
 	statsMu    sync.Mutex
 	fetchStats FetchStats
+
+	// teams are the user's teams as UserTeams last found them, whose
+	// review requests are checked against CODEOWNERS during enrichment.
+	teamsMu sync.Mutex
+	teams   []string
+}
+
+// Option is a functional option for configuring an ItemService.
+type Option func(*ItemService)
+
+// WithScoreWeights sets the weights used to decide which items to enrich
+// first. Defaults to config.DefaultScoreWeights.
+func WithScoreWeights(w config.ScoreWeights) Option {
+	return func(s *ItemService) {
+		s.weights = w
+	}
+}
+
+// WithParticipating limits notifications to threads the user takes part
+// in: ones they authored, commented on, were assigned, or were mentioned or
+// asked to review in.
+func WithParticipating(participating bool) Option {
+	return func(s *ItemService) {
+		s.participating = participating
+	}
+}
+
+// WithRepos limits notifications to the given repositories (owner/repo),
+// each listed through its own notifications endpoint instead of filtering
+// the whole listing.
+func WithRepos(repos ...string) Option {
+	return func(s *ItemService) {
+		s.repos = repos
+	}
+}
+
+// WithRefreshIdentity looks up the user's team memberships even when
+// cached ones haven't expired.
+func WithRefreshIdentity(refresh bool) Option {
+	return func(s *ItemService) {
+		s.refreshIdentity = refresh
+	}
+}
+
+// WithOffline builds everything from the cache instead of GitHub: lists
+// and details are used whatever their age, with items from expired entries
+// marked Stale, and the fetcher is never called, so it may be nil.
+func WithOffline(offline bool) Option {
+	return func(s *ItemService) {
+		s.offline = offline
+	}
+}
+
+// SetSince moves the start of the notification window, for services that
+// fetch again later, such as a client polling on an interval. It must not
+// be called during a fetch.
+func (s *ItemService) SetSince(since time.Time) {
+	s.since = since
+}
+
+// WithProjectStatus treats cached details fetched without project boards
+// as stale, for clients that fetch them (see ghclient.WithProjectStatus).
+func WithProjectStatus(enabled bool) Option {
+	return func(s *ItemService) {
+		s.projectStatus = enabled
+	}
 }
 
 // New creates a new ItemService with the given fetcher and cache.
 // If cache is nil, caching is disabled.
-func New(fetcher ghclient.GitHubFetcher, c *cache.Cache, currentUser string, since time.Time) *ItemService {
-	return &ItemService{
+func New(fetcher ghclient.GitHubFetcher, c *cache.Cache, currentUser string, since time.Time, opts ...Option) *ItemService {
+	s := &ItemService{
 		fetcher:     fetcher,
 		cache:       c,
 		currentUser: currentUser,
 		since:       since,
+		weights:     config.DefaultScoreWeights(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CurrentUser returns the authenticated user's username.
@@ -69,6 +167,21 @@ func (s *ItemService) CurrentUser() string {
 	return s.currentUser
 }
 
+// truncatedSearches returns the searches cut short by GitHub's result cap
+// since the last call. Offline services search nothing.
+func (s *ItemService) truncatedSearches() []ghclient.TruncatedSearch {
+	if s.fetcher == nil {
+		return nil
+	}
+	return s.fetcher.TruncatedSearches()
+}
+
+// Unauthorized reports whether GitHub has rejected the token since the
+// service's fetcher was created. Offline services never reach GitHub.
+func (s *ItemService) Unauthorized() bool {
+	return s.fetcher != nil && s.fetcher.Unauthorized()
+}
+
 // Stats returns cache statistics recorded during fetches.
 func (s *ItemService) Stats() FetchStats {
 	s.statsMu.Lock()
@@ -91,6 +204,77 @@ func (s *ItemService) recordCachedAt(t time.Time) {
 	s.statsMu.Unlock()
 }
 
+// offlineList returns the last cached list of listType whatever its age,
+// narrowed to opts, marking its items stale once the list is past its TTL,
+// and records it with mark. A list never cached, or cached for a narrower
+// scope than opts asks for, is empty.
+func (s *ItemService) offlineList(listType cache.ListType, opts cache.ListOptions, mark func(*FetchStats)) []model.Item {
+	if s.cache == nil {
+		return nil
+	}
+	entry, ok := s.cache.LastList(s.currentUser, listType)
+	if !ok {
+		log.Debug("nothing cached to show offline", "list", listType)
+		return nil
+	}
+	if !offlineCovers(entry, opts) {
+		log.Warn("the cached list was fetched for another scope; run once online with the same --since, --repo, and --participating to use it offline",
+			"list", listType, "cachedSince", entry.SinceTime, "cachedRepos", entry.Repos, "cachedParticipating", entry.Participating)
+		return nil
+	}
+	entry.Items = offlineNarrow(listType, entry.Items, opts)
+	s.recordStat(mark)
+	s.recordCachedAt(entry.CachedAt)
+	if time.Since(entry.CachedAt) > cache.TTLForListType(listType) {
+		for i := range entry.Items {
+			entry.Items[i].Stale = true
+		}
+	}
+	return entry.Items
+}
+
+// offlineCovers reports whether entry holds everything opts asks for, so
+// narrowing it gives what fetching with opts would have: it lists the same
+// or more repositories, goes back as far, and was listed the same way
+// with or without participating and read notifications. Like GetList,
+// since is compared by hour.
+func offlineCovers(entry *cache.ListCacheEntry, opts cache.ListOptions) bool {
+	if opts.Participating != entry.Participating || opts.IncludeRead != entry.IncludeRead {
+		return false
+	}
+	if opts.SinceTime.Truncate(time.Hour).Before(entry.SinceTime.Truncate(time.Hour)) {
+		return false
+	}
+	if len(entry.Repos) == 0 {
+		return true
+	}
+	if len(opts.Repos) == 0 {
+		return false
+	}
+	for _, repo := range opts.Repos {
+		if !slices.ContainsFunc(entry.Repos, func(r string) bool { return strings.EqualFold(r, repo) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// offlineNarrow keeps the items of a cached list in the repositories opts
+// names, and for notifications those updated since opts.SinceTime.
+func offlineNarrow(listType cache.ListType, items []model.Item, opts cache.ListOptions) []model.Item {
+	narrowed := items[:0]
+	for _, item := range items {
+		if len(opts.Repos) > 0 && !slices.ContainsFunc(opts.Repos, func(r string) bool { return strings.EqualFold(r, item.Repository.FullName) }) {
+			continue
+		}
+		if listType == cache.ListTypeNotifications && item.UpdatedAt.Before(opts.SinceTime) {
+			continue
+		}
+		narrowed = append(narrowed, item)
+	}
+	return narrowed
+}
+
 // ItemFetchResult contains the result of a cached item fetch.
 type ItemFetchResult struct {
 	Items     []model.Item
@@ -101,6 +285,10 @@ type ItemFetchResult struct {
 // ReviewRequestedPRs fetches PRs with caching support.
 // Returns (items, fromCache, error).
 func (s *ItemService) ReviewRequestedPRs(ctx context.Context) ([]model.Item, bool, error) {
+	if s.offline {
+		return s.offlineList(cache.ListTypeReviewRequested, cache.ListOptions{}, func(st *FetchStats) { st.ReviewFromCache = true }), true, nil
+	}
+
 	// Check cache first
 	if s.cache != nil {
 		if entry, ok := s.cache.GetList(s.currentUser, cache.ListTypeReviewRequested, cache.ListOptions{}); ok {
@@ -138,6 +326,10 @@ func (s *ItemService) ReviewRequestedPRs(ctx context.Context) ([]model.Item, boo
 // AuthoredPRs fetches authored PRs with caching support.
 // Returns (items, fromCache, error).
 func (s *ItemService) AuthoredPRs(ctx context.Context) ([]model.Item, bool, error) {
+	if s.offline {
+		return s.offlineList(cache.ListTypeAuthored, cache.ListOptions{}, func(st *FetchStats) { st.AuthoredFromCache = true }), true, nil
+	}
+
 	// Check cache first
 	if s.cache != nil {
 		if entry, ok := s.cache.GetList(s.currentUser, cache.ListTypeAuthored, cache.ListOptions{}); ok {
@@ -175,6 +367,10 @@ func (s *ItemService) AuthoredPRs(ctx context.Context) ([]model.Item, bool, erro
 // AssignedIssues fetches assigned issues with caching support.
 // Returns (items, fromCache, error).
 func (s *ItemService) AssignedIssues(ctx context.Context) ([]model.Item, bool, error) {
+	if s.offline {
+		return s.offlineList(cache.ListTypeAssignedIssues, cache.ListOptions{}, func(st *FetchStats) { st.AssignedFromCache = true }), true, nil
+	}
+
 	// Check cache first
 	if s.cache != nil {
 		if entry, ok := s.cache.GetList(s.currentUser, cache.ListTypeAssignedIssues, cache.ListOptions{}); ok {
@@ -212,6 +408,10 @@ func (s *ItemService) AssignedIssues(ctx context.Context) ([]model.Item, bool, e
 // AssignedPRs fetches assigned PRs with caching support.
 // Returns (items, fromCache, error).
 func (s *ItemService) AssignedPRs(ctx context.Context) ([]model.Item, bool, error) {
+	if s.offline {
+		return s.offlineList(cache.ListTypeAssignedPRs, cache.ListOptions{}, func(st *FetchStats) { st.AssignedPRsFromCache = true }), true, nil
+	}
+
 	// Check cache first
 	if s.cache != nil {
 		if entry, ok := s.cache.GetList(s.currentUser, cache.ListTypeAssignedPRs, cache.ListOptions{}); ok {
@@ -246,19 +446,92 @@ func (s *ItemService) AssignedPRs(ctx context.Context) ([]model.Item, bool, erro
 	return prs, false, nil
 }
 
-// UnreadItems fetches items with incremental caching.
-// It returns cached items merged with any new ones since the last fetch.
-// listNotifications calls the appropriate notification fetcher based on includeRead.
-func (s *ItemService) listNotifications(ctx context.Context, since time.Time, includeRead bool) ([]model.Item, error) {
-	if includeRead {
-		return s.fetcher.ListAllNotifications(ctx, since)
+// notificationListing is the result of listing notifications.
+type notificationListing struct {
+	items []model.Item
+	// validators are what to make the next poll conditional on, keyed by
+	// repository ("" for all)
+	validators map[string]cache.Validators
+	// notModified is set when GitHub reported every listing unchanged
+	notModified bool
+}
+
+// listNotifications calls the appropriate notification fetcher based on
+// includeRead. Each listing is a conditional request on its entry in
+// validators, so listings with nothing new cost no rate limit.
+func (s *ItemService) listNotifications(ctx context.Context, since time.Time, includeRead bool, validators map[string]cache.Validators) (*notificationListing, error) {
+	listing := &notificationListing{validators: make(map[string]cache.Validators), notModified: true}
+	list := func(repo string, fetch func(ctx context.Context) ([]model.Item, error)) error {
+		v := validators[repo]
+		cond := &ghclient.Conditional{ETag: v.ETag, LastModified: v.LastModified, Since: v.Since}
+		items, err := fetch(ghclient.WithConditional(ctx, cond))
+		if err != nil {
+			return err
+		}
+		if cond.NotModified {
+			listing.validators[repo] = v
+			return nil
+		}
+		listing.notModified = false
+		listing.items = append(listing.items, items...)
+		listing.validators[repo] = cache.Validators{ETag: cond.ETag, LastModified: cond.LastModified, Since: cond.Since}
+		return nil
 	}
-	return s.fetcher.ListUnreadNotifications(ctx, since)
+
+	if len(s.repos) > 0 {
+		for _, repo := range s.repos {
+			err := list(repo, func(ctx context.Context) ([]model.Item, error) {
+				return s.fetcher.ListNotifications(ctx, s.notificationOptions(since, includeRead, repo))
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return listing, nil
+	}
+	err := list("", func(ctx context.Context) ([]model.Item, error) {
+		if s.participating {
+			return s.fetcher.ListNotifications(ctx, s.notificationOptions(since, includeRead, ""))
+		}
+		if includeRead {
+			return s.fetcher.ListAllNotifications(ctx, since)
+		}
+		return s.fetcher.ListUnreadNotifications(ctx, since)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// notificationOptions describes a notification listing since the given
+// time, of repo or of every repository when it is empty.
+func (s *ItemService) notificationOptions(since time.Time, includeRead bool, repo string) ghclient.NotificationOptions {
+	return ghclient.NotificationOptions{
+		All:           includeRead,
+		Since:         since,
+		Participating: s.participating,
+		Repo:          repo,
+		Types:         []model.SubjectType{model.SubjectIssue, model.SubjectPullRequest},
+	}
+}
+
+// notificationListOptions describes the notification listing to look up
+// in the cache, with or without read notifications.
+func (s *ItemService) notificationListOptions(includeRead bool) cache.ListOptions {
+	return cache.ListOptions{SinceTime: s.since, Participating: s.participating, Repos: s.repos, IncludeRead: includeRead}
 }
 
+// UnreadItems fetches items with incremental caching.
+// It returns cached items merged with any new ones since the last fetch.
 func (s *ItemService) UnreadItems(ctx context.Context, includeRead bool) (*ItemFetchResult, error) {
 	result := &ItemFetchResult{}
-	opts := cache.ListOptions{SinceTime: s.since}
+	if s.offline {
+		result.Items = s.offlineList(cache.ListTypeNotifications, s.notificationListOptions(includeRead), func(st *FetchStats) { st.NotifFromCache = true })
+		result.FromCache = true
+		return result, nil
+	}
+	opts := s.notificationListOptions(includeRead)
 
 	// Check if rate limited - return cached data if available
 	if ghclient.IsRateLimited() {
@@ -278,7 +551,7 @@ func (s *ItemService) UnreadItems(ctx context.Context, includeRead bool) (*ItemF
 	if s.cache != nil {
 		if entry, ok := s.cache.GetList(s.currentUser, cache.ListTypeNotifications, opts); ok {
 			// Fetch only NEW notifications since last fetch
-			newItems, err := s.listNotifications(ctx, entry.LastFetchTime, includeRead)
+			listing, err := s.listNotifications(ctx, entry.LastFetchTime, includeRead, entry.Validators)
 			if err != nil {
 				// Return cached on error
 				log.Debug("failed to fetch new items, using cache", "error", err)
@@ -290,6 +563,7 @@ func (s *ItemService) UnreadItems(ctx context.Context, includeRead bool) (*ItemF
 			}
 
 			// Merge: new items replace old ones by ID
+			newItems := listing.items
 			merged := mergeCachedItems(entry.Items, newItems, s.since, includeRead)
 			result.Items = merged
 			result.FromCache = true
@@ -297,16 +571,28 @@ func (s *ItemService) UnreadItems(ctx context.Context, includeRead bool) (*ItemF
 			s.recordStat(func(st *FetchStats) {
 				st.NotifFromCache = true
 				st.NotifNewCount = len(newItems)
+				st.NotifNotModified = listing.notModified
 			})
 			s.recordCachedAt(entry.CachedAt)
 
+			// When nothing changed, the next poll asks for the same since,
+			// so the listing's ETag still applies
+			lastFetch := time.Now()
+			if listing.notModified {
+				lastFetch = entry.LastFetchTime
+			}
+
 			// Update cache with merged result
 			if err := s.cache.SetList(s.currentUser, cache.ListTypeNotifications, &cache.ListCacheEntry{
 				Items:         merged,
 				CachedAt:      time.Now(),
-				LastFetchTime: time.Now(),
+				LastFetchTime: lastFetch,
 				SinceTime:     s.since,
+				Participating: s.participating,
+				IncludeRead:   includeRead,
+				Repos:         s.repos,
 				Version:       cache.Version,
+				Validators:    listing.validators,
 			}); err != nil {
 				log.Debug("failed to update item cache", "error", err)
 			}
@@ -316,10 +602,11 @@ func (s *ItemService) UnreadItems(ctx context.Context, includeRead bool) (*ItemF
 	}
 
 	// No cache - full fetch
-	items, err := s.listNotifications(ctx, s.since, includeRead)
+	listing, err := s.listNotifications(ctx, s.since, includeRead, nil)
 	if err != nil {
 		return nil, err
 	}
+	items := listing.items
 
 	result.Items = items
 	result.FromCache = false
@@ -331,7 +618,11 @@ func (s *ItemService) UnreadItems(ctx context.Context, includeRead bool) (*ItemF
 			CachedAt:      time.Now(),
 			LastFetchTime: time.Now(),
 			SinceTime:     s.since,
+			Participating: s.participating,
+			IncludeRead:   includeRead,
+			Repos:         s.repos,
 			Version:       cache.Version,
+			Validators:    listing.validators,
 		}); err != nil {
 			log.Debug("failed to cache items", "error", err)
 		}
@@ -346,7 +637,6 @@ func (s *ItemService) OrphanedContributions(ctx context.Context, opts ghclient.O
 	if len(opts.Repos) == 0 {
 		return nil, false, nil
 	}
-
 	// Use service's since if opts.Since is zero
 	since := opts.Since
 	if since.IsZero() {
@@ -356,6 +646,10 @@ func (s *ItemService) OrphanedContributions(ctx context.Context, opts ghclient.O
 	cacheOpts := cache.ListOptions{
 		SinceTime: since,
 		Repos:     opts.Repos,
+		Settings:  opts.Settings(),
+	}
+	if s.offline {
+		return s.offlineList(cache.ListTypeOrphaned, cacheOpts, func(st *FetchStats) { st.OrphanedFromCache = true }), true, nil
 	}
 
 	// Try cache first
@@ -380,6 +674,7 @@ func (s *ItemService) OrphanedContributions(ctx context.Context, opts ghclient.O
 			CachedAt:  time.Now(),
 			SinceTime: since,
 			Repos:     opts.Repos,
+			Settings:  cacheOpts.Settings,
 			Version:   cache.Version,
 		}); cacheErr != nil {
 			log.Debug("failed to cache orphaned list", "error", cacheErr)
@@ -389,11 +684,129 @@ func (s *ItemService) OrphanedContributions(ctx context.Context, opts ghclient.O
 	return orphaned, false, nil
 }
 
+// ResolvedUpstreams returns an item for each watched upstream release,
+// issue, or PR that has resolved. It is not cached: the point is to notice
+// the change as soon as it happens.
+func (s *ItemService) ResolvedUpstreams(ctx context.Context, watches []ghclient.UpstreamWatch) ([]model.Item, error) {
+	if len(watches) == 0 || s.offline {
+		return nil, nil
+	}
+	return s.fetcher.ListResolvedUpstreams(ctx, watches)
+}
+
+// UserTeams returns the teams the current user belongs to, as
+// "org/team-slug". They are cached for cache.IdentityTTL unless the
+// service was created WithRefreshIdentity. When a lookup fails the last
+// known teams are used, with a warning.
+func (s *ItemService) UserTeams(ctx context.Context) ([]string, error) {
+	var cached []string
+	var haveCached bool
+	if s.cache != nil {
+		var fetchedAt time.Time
+		cached, fetchedAt, haveCached = s.cache.UserTeams(s.currentUser)
+		if haveCached && (s.offline || (!s.refreshIdentity && time.Since(fetchedAt) <= cache.IdentityTTL)) {
+			return s.setTeams(cached), nil
+		}
+	}
+	if s.offline {
+		return nil, nil
+	}
+
+	teams, err := s.fetcher.UserTeams(ctx, s.currentUser)
+	if err != nil {
+		if haveCached {
+			log.Warn("could not look up team memberships, using the last known ones", "error", err, "teams", len(cached))
+			return s.setTeams(cached), nil
+		}
+		return nil, err
+	}
+	if s.cache != nil {
+		if err := s.cache.SetUserTeams(s.currentUser, teams); err != nil {
+			log.Debug("failed to cache team memberships", "error", err)
+		}
+	}
+	return s.setTeams(teams), nil
+}
+
+// setTeams records the user's teams for enrichment and returns them.
+func (s *ItemService) setTeams(teams []string) []string {
+	s.teamsMu.Lock()
+	defer s.teamsMu.Unlock()
+	s.teams = teams
+	return teams
+}
+
+// userTeams returns the teams recorded by setTeams.
+func (s *ItemService) userTeams() []string {
+	s.teamsMu.Lock()
+	defer s.teamsMu.Unlock()
+	return s.teams
+}
+
+// TeamRoster returns the members of teams, given as "org/team-slug". Each
+// team's membership is cached for cache.TeamMembersTTL. When a lookup fails
+// the last known members are used, with a warning, so orphaned detection
+// doesn't quietly start treating the team as outsiders.
+func (s *ItemService) TeamRoster(ctx context.Context, teams []string) []string {
+	if len(teams) == 0 {
+		return nil
+	}
+	c := s.cache
+	if c == nil {
+		var cacheErr error
+		c, cacheErr = cache.NewCache()
+		if cacheErr != nil {
+			log.Debug("cache unavailable", "error", cacheErr)
+		} else {
+			defer c.Close()
+		}
+	}
+
+	var roster []string
+	for _, team := range teams {
+		org, slug, ok := strings.Cut(team, "/")
+		if !ok || org == "" || slug == "" {
+			log.Warn("ignoring orphaned team, expected org/team-slug", "team", team)
+			continue
+		}
+
+		var cached []string
+		if c != nil {
+			members, fetchedAt, ok := c.TeamMembers(team)
+			if ok && (s.offline || time.Since(fetchedAt) <= cache.TeamMembersTTL) {
+				roster = append(roster, members...)
+				continue
+			}
+			cached = members
+		}
+		if s.offline {
+			continue
+		}
+		members, err := s.fetcher.TeamMembers(ctx, org, slug)
+		if err != nil {
+			log.Warn("could not look up team members", "team", team, "error", err, "cached", len(cached))
+			roster = append(roster, cached...)
+			continue
+		}
+		if c != nil {
+			if err := c.SetTeamMembers(team, members); err != nil {
+				log.Debug("failed to cache team members", "team", team, "error", err)
+			}
+		}
+		roster = append(roster, members...)
+	}
+	return roster
+}
+
 // EnrichResult contains stats from an enrichment run.
 type EnrichResult struct {
-	CacheHits int // Items served from cache
-	Enriched  int // Items enriched via GraphQL
-	Failed    int // Items that could not be enriched
+	CacheHits    int // Items served from cache
+	Enriched     int // Items enriched via GraphQL
+	Failed       int // Items that could not be enriched
+	Inaccessible int // Items kept unenriched because the token can't read their repo
+	// Errors explains each item GraphQL returned without details; Index
+	// refers to the items passed to Enrich.
+	Errors []ghclient.ItemError
 }
 
 // Enrich enriches items using GraphQL batch queries with caching.
@@ -406,12 +819,25 @@ func (s *ItemService) Enrich(ctx context.Context, items []model.Item, onProgress
 		c, cacheErr = cache.NewCache()
 		if cacheErr != nil {
 			log.Debug("cache unavailable", "error", cacheErr)
+		} else {
+			defer c.Close()
 		}
 	}
 
+	if s.offline {
+		return s.enrichOffline(c, items, onProgress), nil
+	}
+
 	total := len(items)
 	var cacheHits int64
 
+	// Repos that recently failed for lack of read access aren't retried
+	var skipRepos map[string]bool
+	if c != nil {
+		skipRepos = c.InaccessibleRepos()
+	}
+	inaccessible := 0
+
 	// First pass: check cache and build list of items needing enrichment
 	uncachedItems := make([]model.Item, 0, len(items))
 	uncachedIndices := make([]int, 0, len(items))
@@ -420,22 +846,8 @@ func (s *ItemService) Enrich(ctx context.Context, items []model.Item, onProgress
 		if c != nil {
 			key, ok := buildCacheKey(&items[i])
 			if ok {
-				if cachedItem, cacheOk := c.Get(key, items[i].UpdatedAt); cacheOk {
-					// Copy enriched data from cached item
-					items[i].Type = cachedItem.Type
-					items[i].Number = cachedItem.Number
-					items[i].State = cachedItem.State
-					items[i].HTMLURL = cachedItem.HTMLURL
-					items[i].CreatedAt = cachedItem.CreatedAt
-					items[i].ClosedAt = cachedItem.ClosedAt
-					items[i].Author = cachedItem.Author
-					items[i].Assignees = cachedItem.Assignees
-					items[i].Labels = cachedItem.Labels
-					items[i].CommentCount = cachedItem.CommentCount
-					items[i].AuthorAssociation = cachedItem.AuthorAssociation
-					items[i].LastTeamActivityAt = cachedItem.LastTeamActivityAt
-					items[i].ConsecutiveAuthorComments = cachedItem.ConsecutiveAuthorComments
-					items[i].Details = cachedItem.Details
+				if cachedItem, cacheOk := s.cachedDetails(c, key, items[i].UpdatedAt); cacheOk {
+					copyEnrichment(&items[i], cachedItem)
 					cacheHits++
 					// Report each cache hit individually for smooth progress
 					if onProgress != nil {
@@ -445,6 +857,14 @@ func (s *ItemService) Enrich(ctx context.Context, items []model.Item, onProgress
 				}
 			}
 		}
+		if skipRepos[items[i].Repository.FullName] {
+			items[i].Inaccessible = true
+			inaccessible++
+			if onProgress != nil {
+				onProgress(1, total)
+			}
+			continue
+		}
 		uncachedItems = append(uncachedItems, items[i])
 		uncachedIndices = append(uncachedIndices, i)
 	}
@@ -454,13 +874,14 @@ func (s *ItemService) Enrich(ctx context.Context, items []model.Item, onProgress
 	}
 
 	if len(uncachedItems) == 0 {
-		return EnrichResult{CacheHits: int(cacheHits)}, nil
+		return EnrichResult{CacheHits: int(cacheHits), Inaccessible: inaccessible}, nil
 	}
 
 	// Use GraphQL for batch enrichment (uses GraphQL quota, not Core API)
 	log.Debug("enriching via GraphQL", "count", len(uncachedItems))
+	s.prioritizeForEnrichment(uncachedItems, uncachedIndices)
 
-	enriched, err := s.fetcher.EnrichItemsGraphQL(ctx, uncachedItems, s.fetcher.Token(), func(delta, batchTotal int) {
+	report, err := s.fetcher.EnrichItemsGraphQL(ctx, uncachedItems, s.fetcher.Token(), func(delta, batchTotal int) {
 		if onProgress != nil {
 			// Pass through the delta (number of items just processed)
 			onProgress(delta, total)
@@ -470,9 +891,21 @@ func (s *ItemService) Enrich(ctx context.Context, items []model.Item, onProgress
 	if err != nil {
 		log.Debug("GraphQL enrichment error", "error", err)
 	}
+	// Keep whatever was enriched, but let the caller know the token was
+	// rejected so it can stop and tell the user once.
+	var authErr error
+	if ghclient.IsAuthError(err) {
+		authErr = ghclient.ErrUnauthorized
+	}
 
 	// Copy enriched data back to original slice and cache results
+	var newlyInaccessible, readable []string
 	for i, origIdx := range uncachedIndices {
+		if uncachedItems[i].Inaccessible {
+			newlyInaccessible = append(newlyInaccessible, uncachedItems[i].Repository.FullName)
+		} else if uncachedItems[i].Details != nil && !slices.Contains(readable, uncachedItems[i].Repository.FullName) {
+			readable = append(readable, uncachedItems[i].Repository.FullName)
+		}
 		items[origIdx] = uncachedItems[i]
 		// Log what we're copying back
 		n := &items[origIdx]
@@ -505,14 +938,316 @@ func (s *ItemService) Enrich(ctx context.Context, items []model.Item, onProgress
 		}
 	}
 
-	failed := len(uncachedItems) - enriched
-	log.Debug("GraphQL enrichment complete", "enriched", enriched, "failed", failed, "total", len(uncachedItems))
+	// Remember repos the token can't read so later runs skip them, and
+	// forget those it read again
+	if c != nil {
+		if err := c.UpdateInaccessible(newlyInaccessible, readable); err != nil {
+			log.Debug("failed to record inaccessible repos", "error", err)
+		}
+	}
+	inaccessible += len(newlyInaccessible)
+
+	itemErrs := make([]ghclient.ItemError, len(report.Errors))
+	for i, e := range report.Errors {
+		e.Index = uncachedIndices[e.Index]
+		itemErrs[i] = e
+	}
+
+	enriched := report.Enriched
+	failed := len(uncachedItems) - enriched - len(newlyInaccessible)
+	log.Debug("GraphQL enrichment complete", "enriched", enriched, "failed", failed, "inaccessible", inaccessible, "total", len(uncachedItems))
 
 	return EnrichResult{
-		CacheHits: int(cacheHits),
-		Enriched:  enriched,
-		Failed:    failed,
-	}, nil
+		CacheHits:    int(cacheHits),
+		Enriched:     enriched,
+		Failed:       failed,
+		Inaccessible: inaccessible,
+		Errors:       itemErrs,
+	}, authErr
+}
+
+// enrichOffline copies cached details onto items however old they are,
+// marking items stale when their entry is past its TTL or older than the
+// item. Items with nothing cached stay unenriched.
+func (s *ItemService) enrichOffline(c *cache.Cache, items []model.Item, onProgress func(completed, total int)) EnrichResult {
+	var result EnrichResult
+	for i := range items {
+		if onProgress != nil {
+			onProgress(1, len(items))
+		}
+		key, ok := buildCacheKey(&items[i])
+		if c == nil || !ok {
+			continue
+		}
+		cached, fresh, ok := c.Peek(key, items[i].UpdatedAt)
+		if !ok {
+			continue
+		}
+		copyEnrichment(&items[i], cached)
+		items[i].Stale = items[i].Stale || !fresh
+		result.CacheHits++
+	}
+	if missing := len(items) - result.CacheHits; missing > 0 {
+		log.Info("no cached details to show offline", "count", missing, "total", len(items))
+	}
+	return result
+}
+
+// EnrichAll enriches several lists of items (e.g. notifications and
+// review-requested PRs) in a single pass, so they are prioritized against
+// each other rather than competing for the GraphQL quota. An item that
+// appears in more than one list is queried once, ranked by its most
+// important copy, and its details are shared with the other copies (which
+// count as cache hits). Items are updated in place; error indices refer to
+// the lists concatenated in order.
+func (s *ItemService) EnrichAll(ctx context.Context, onProgress func(completed, total int), lists ...[]model.Item) (EnrichResult, error) {
+	return s.EnrichStream(ctx, 0, onProgress, nil, lists...)
+}
+
+// EnrichStream is EnrichAll for callers that use items as they become
+// ready instead of once every list is done. Items are enriched in chunks
+// of chunkSize, most important first, and after each chunk onChunk gets
+// the items finished in it, one slice per list in list order. Copies of an
+// item in several lists finish in the same chunk. A chunkSize of zero
+// enriches everything in one chunk. Smaller chunks arrive sooner but send
+// fewer GraphQL batches at once.
+func (s *ItemService) EnrichStream(ctx context.Context, chunkSize int, onProgress func(completed, total int), onChunk func(done [][]model.Item), lists ...[]model.Item) (EnrichResult, error) {
+	var all []model.Item
+	for _, l := range lists {
+		all = append(all, l...)
+	}
+	if len(all) == 0 {
+		return EnrichResult{}, nil
+	}
+	total := len(all)
+
+	h := triage.NewHeuristics(s.currentUser, s.weights, nil)
+	var unique []model.Item
+	var origin []int                // unique index -> position in all
+	copyOf := make([]int, len(all)) // position in all -> unique index
+	seen := make(map[cache.Key]int)
+	for i := range all {
+		if key, ok := buildCacheKey(&all[i]); ok {
+			if u, dup := seen[key]; dup {
+				copyOf[i] = u
+				if h.ForItem(&all[i]).Score(&all[i]) > h.ForItem(&unique[u]).Score(&unique[u]) {
+					unique[u] = all[i]
+					origin[u] = i
+				}
+				continue
+			}
+			seen[key] = len(unique)
+		}
+		copyOf[i] = len(unique)
+		unique = append(unique, all[i])
+		origin = append(origin, i)
+	}
+	copies := make([][]int, len(unique)) // unique index -> positions in all
+	for i, u := range copyOf {
+		copies[u] = append(copies[u], i)
+	}
+
+	// Chunks are taken most important first; Enrich orders within each
+	order := make([]int, len(unique))
+	for u := range order {
+		order[u] = u
+	}
+	if chunkSize <= 0 || chunkSize >= len(unique) {
+		chunkSize = len(unique)
+	} else {
+		scores := make([]int, len(unique))
+		for u := range unique {
+			scores[u] = h.ForItem(&unique[u]).Score(&unique[u])
+		}
+		sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+	}
+
+	ctx, span := telemetry.Start(ctx, "enrich",
+		attribute.Int("triage.items", total),
+		attribute.Int("triage.items.unique", len(unique)))
+	var result EnrichResult
+	var err error
+	for start := 0; start < len(order); start += chunkSize {
+		chunk := order[start:min(start+chunkSize, len(order))]
+		items := make([]model.Item, len(chunk))
+		for j, u := range chunk {
+			items[j] = unique[u]
+		}
+		chunkResult, chunkErr := s.Enrich(ctx, items, func(delta, _ int) {
+			if onProgress != nil {
+				onProgress(delta, total)
+			}
+		})
+		if chunkErr != nil {
+			err = chunkErr
+		}
+		result.CacheHits += chunkResult.CacheHits
+		result.Enriched += chunkResult.Enriched
+		result.Failed += chunkResult.Failed
+		result.Inaccessible += chunkResult.Inaccessible
+		for _, e := range chunkResult.Errors {
+			e.Index = origin[chunk[e.Index]]
+			result.Errors = append(result.Errors, e)
+		}
+
+		var done []int
+		dups := 0
+		for j, u := range chunk {
+			for _, i := range copies[u] {
+				if origin[u] == i {
+					all[i] = items[j]
+				} else {
+					copyEnrichment(&all[i], &items[j])
+					all[i].Inaccessible = items[j].Inaccessible
+					dups++
+				}
+				done = append(done, i)
+			}
+		}
+		if dups > 0 {
+			result.CacheHits += dups
+			if onProgress != nil {
+				onProgress(dups, total)
+			}
+		}
+		slices.Sort(done)
+		s.finishChunk(ctx, all, done, lists, onChunk)
+	}
+	span.SetAttributes(
+		attribute.Int("triage.cache_hits", result.CacheHits),
+		attribute.Int("triage.failed", result.Failed))
+	telemetry.End(span, err)
+	return result, err
+}
+
+// finishChunk refreshes the blockers of the items at positions done in
+// all, checks their code ownership, copies them back to the lists they
+// came from, and hands them to onChunk.
+func (s *ItemService) finishChunk(ctx context.Context, all []model.Item, done []int, lists [][]model.Item, onChunk func([][]model.Item)) {
+	items := make([]model.Item, len(done))
+	for j, i := range done {
+		items[j] = all[i]
+	}
+	s.refreshBlockers(ctx, items)
+	s.checkCodeOwners(ctx, items)
+
+	chunks := make([][]model.Item, len(lists))
+	offset, l := 0, 0
+	for j, i := range done {
+		all[i] = items[j]
+		for i >= offset+len(lists[l]) {
+			offset += len(lists[l])
+			l++
+		}
+		lists[l][i-offset] = items[j]
+		chunks[l] = append(chunks[l], items[j])
+	}
+	if onChunk != nil {
+		onChunk(chunks)
+	}
+}
+
+// copyEnrichment copies the fields filled in by enrichment from src to dst.
+func copyEnrichment(dst, src *model.Item) {
+	dst.Type = src.Type
+	dst.Number = src.Number
+	dst.State = src.State
+	dst.HTMLURL = src.HTMLURL
+	dst.CreatedAt = src.CreatedAt
+	dst.ClosedAt = src.ClosedAt
+	dst.Author = src.Author
+	dst.Assignees = src.Assignees
+	dst.Labels = src.Labels
+	dst.CommentCount = src.CommentCount
+	dst.AuthorAssociation = src.AuthorAssociation
+	dst.LastTeamActivityAt = src.LastTeamActivityAt
+	dst.ConsecutiveAuthorComments = src.ConsecutiveAuthorComments
+	dst.ActivityBy = src.ActivityBy
+	dst.BlockedBy = src.BlockedBy
+	dst.FormFields = src.FormFields
+	dst.Milestone = src.Milestone
+	dst.Projects = src.Projects
+	dst.ProjectsFetched = src.ProjectsFetched
+	dst.Details = src.Details
+}
+
+// refreshBlockers drops blockers that have closed since they were
+// recorded, so items leave the Blocked pane without waiting for their own
+// details to change. Blockers among items are judged by the item's state;
+// the rest are looked up, unless offline. Blockers whose state can't be
+// found are kept.
+func (s *ItemService) refreshBlockers(ctx context.Context, items []model.Item) {
+	states := make(map[string]string)
+	for i := range items {
+		if n := &items[i]; n.Number > 0 && n.State != "" {
+			states[fmt.Sprintf("%s#%d", n.Repository.FullName, n.Number)] = n.State
+		}
+	}
+
+	var lookup []string
+	for i := range items {
+		for _, ref := range items[i].BlockedBy {
+			if _, known := states[ref]; !known && !slices.Contains(lookup, ref) {
+				lookup = append(lookup, ref)
+			}
+		}
+	}
+	if len(lookup) > 0 && !s.offline {
+		found, err := s.fetcher.IssueStates(ctx, lookup, s.fetcher.Token())
+		if err != nil {
+			log.Debug("could not look up blocker states", "error", err)
+		}
+		maps.Copy(states, found)
+	}
+
+	for i := range items {
+		n := &items[i]
+		if len(n.BlockedBy) == 0 {
+			continue
+		}
+		var open []string
+		for _, ref := range n.BlockedBy {
+			if state := states[ref]; state == "" || state == model.StateOpen {
+				open = append(open, ref)
+			}
+		}
+		n.BlockedBy = open
+	}
+}
+
+// prioritizeForEnrichment reorders items (and their original indices) so
+// the most important are enriched first, scored on what the notification
+// alone says (mostly its reason). If the GraphQL quota runs out partway,
+// review requests and mentions keep their details and FYI items go without.
+func (s *ItemService) prioritizeForEnrichment(items []model.Item, indices []int) {
+	h := triage.NewHeuristics(s.currentUser, s.weights, nil)
+	order := make([]int, len(items))
+	scores := make([]int, len(items))
+	for i := range items {
+		order[i] = i
+		scores[i] = h.ForItem(&items[i]).Score(&items[i])
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	sortedItems := make([]model.Item, len(items))
+	sortedIndices := make([]int, len(indices))
+	for i, j := range order {
+		sortedItems[i] = items[j]
+		sortedIndices[i] = indices[j]
+	}
+	copy(items, sortedItems)
+	copy(indices, sortedIndices)
+}
+
+// cachedDetails returns the fresh cached details for key from c. Details
+// cached without project boards are left for enrichment to fetch again
+// when the service needs them.
+func (s *ItemService) cachedDetails(c *cache.Cache, key cache.Key, updatedAt time.Time) (*model.Item, bool) {
+	item, ok := c.Get(key, updatedAt)
+	if !ok || (s.projectStatus && !item.ProjectsFetched) {
+		return nil, false
+	}
+	return item, true
 }
 
 // buildCacheKey creates a cache key from an item.
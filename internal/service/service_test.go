@@ -0,0 +1,551 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// quotaFetcher enriches only the first quota items it is asked for, as if
+// the GraphQL quota ran out partway through.
+type quotaFetcher struct {
+	ghclient.GitHubFetcher
+	quota int
+	order []string
+}
+
+func (f *quotaFetcher) Token() string { return "token" }
+
+func (f *quotaFetcher) EnrichItemsGraphQL(_ context.Context, items []model.Item, _ string, _ func(int, int)) (ghclient.EnrichReport, error) {
+	var report ghclient.EnrichReport
+	for i := range items {
+		f.order = append(f.order, items[i].ID)
+		if i < f.quota {
+			items[i].Details = &model.IssueDetails{}
+			report.Enriched++
+			continue
+		}
+		report.Errors = append(report.Errors, ghclient.ItemError{Index: i, Kind: ghclient.ItemErrorRateLimited})
+	}
+	return report, ghclient.ErrRateLimited
+}
+
+func TestEnrichAll_MostImportantFirst(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	item := func(id string, number int, reason model.ItemReason) model.Item {
+		it := makeFetchItem("org/repo", number, model.SubjectIssue, fmt.Sprintf("https://api.github.com/repos/org/repo/issues/%d", number), false)
+		it.ID = id
+		it.Reason = reason
+		it.UpdatedAt = time.Now()
+		return it
+	}
+	notifications := []model.Item{item("fyi", 1, model.ReasonSubscribed), item("mention", 2, model.ReasonMention)}
+	reviewPRs := []model.Item{item("review", 3, model.ReasonReviewRequested)}
+
+	fetcher := &quotaFetcher{quota: 2}
+	svc := New(fetcher, nil, "me", time.Now().Add(-time.Hour))
+	result, err := svc.EnrichAll(context.Background(), nil, notifications, reviewPRs)
+	if err != nil {
+		t.Fatalf("EnrichAll() error = %v, want rate limiting reported per item", err)
+	}
+
+	wantOrder := []string{"review", "mention", "fyi"}
+	for i, id := range wantOrder {
+		if i >= len(fetcher.order) || fetcher.order[i] != id {
+			t.Fatalf("enrichment order = %v, want %v", fetcher.order, wantOrder)
+		}
+	}
+
+	// Results land back in the caller's slices, in their original order
+	if reviewPRs[0].Details == nil || notifications[1].Details == nil {
+		t.Error("expected the review request and mention to be enriched")
+	}
+	if notifications[0].Details != nil {
+		t.Error("expected the FYI item to be left for when quota returns")
+	}
+	if result.Enriched != 2 || len(result.Errors) != 1 || result.Errors[0].Index != 0 {
+		t.Errorf("result = %+v, want 2 enriched and the FYI item (index 0) rate limited", result)
+	}
+}
+
+func TestEnrichAll_SharesDetailsAcrossLists(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	url := "https://api.github.com/repos/org/repo/pulls/7"
+	notification := makeFetchItem("org/repo", 7, model.SubjectPullRequest, url, false)
+	notification.ID = "thread"
+	notification.Reason = model.ReasonSubscribed
+	reviewPR := makeFetchItem("org/repo", 7, model.SubjectPullRequest, url, false)
+	reviewPR.ID = "search"
+	reviewPR.Reason = model.ReasonReviewRequested
+
+	notifications := []model.Item{notification}
+	reviewPRs := []model.Item{reviewPR}
+
+	fetcher := &quotaFetcher{quota: 10}
+	svc := New(fetcher, nil, "me", time.Now().Add(-time.Hour))
+	result, err := svc.EnrichAll(context.Background(), nil, notifications, reviewPRs)
+	if err != nil {
+		t.Fatalf("EnrichAll() error = %v", err)
+	}
+
+	if len(fetcher.order) != 1 || fetcher.order[0] != "search" {
+		t.Errorf("queried %v, want only the review-requested copy", fetcher.order)
+	}
+	if notifications[0].Details == nil || reviewPRs[0].Details == nil {
+		t.Error("expected both copies to be enriched")
+	}
+	if notifications[0].ID != "thread" || notifications[0].Reason != model.ReasonSubscribed {
+		t.Errorf("notification copy lost its own identity: %+v", notifications[0])
+	}
+	if result.Enriched != 1 || result.CacheHits != 1 {
+		t.Errorf("result = %+v, want 1 enriched and 1 cache hit", result)
+	}
+}
+
+func TestEnrichStream_Chunks(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	item := func(id string, number int, reason model.ItemReason) model.Item {
+		it := makeFetchItem("org/repo", number, model.SubjectIssue, fmt.Sprintf("https://api.github.com/repos/org/repo/issues/%d", number), false)
+		it.ID = id
+		it.Reason = reason
+		it.UpdatedAt = time.Now()
+		return it
+	}
+	notifications := []model.Item{item("fyi", 1, model.ReasonSubscribed), item("thread", 2, model.ReasonSubscribed)}
+	reviewPRs := []model.Item{item("review", 2, model.ReasonReviewRequested)}
+
+	fetcher := &quotaFetcher{quota: 10}
+	svc := New(fetcher, nil, "me", time.Now().Add(-time.Hour))
+	var chunks [][]string
+	result, err := svc.EnrichStream(context.Background(), 1, nil, func(done [][]model.Item) {
+		if len(done) != 2 {
+			t.Fatalf("chunk has %d lists, want 2", len(done))
+		}
+		var ids []string
+		for _, list := range done {
+			for _, it := range list {
+				if it.Details == nil {
+					t.Errorf("%s handed over before it was enriched", it.ID)
+				}
+				ids = append(ids, it.ID)
+			}
+		}
+		chunks = append(chunks, ids)
+	}, notifications, reviewPRs)
+	if err != nil {
+		t.Fatalf("EnrichStream() error = %v", err)
+	}
+
+	// The review request comes first, together with its notification copy
+	want := [][]string{{"thread", "review"}, {"fyi"}}
+	if !slices.EqualFunc(chunks, want, slices.Equal) {
+		t.Errorf("chunks = %v, want %v", chunks, want)
+	}
+	if notifications[0].Details == nil || notifications[1].Details == nil || reviewPRs[0].Details == nil {
+		t.Error("expected every item to be enriched in place")
+	}
+	if result.Enriched != 2 || result.CacheHits != 1 {
+		t.Errorf("result = %+v, want 2 enriched and 1 cache hit", result)
+	}
+}
+
+// stateFetcher answers blocker state lookups from states.
+type stateFetcher struct {
+	ghclient.GitHubFetcher
+	states map[string]string
+	asked  []string
+}
+
+func (f *stateFetcher) Token() string { return "token" }
+
+func (f *stateFetcher) IssueStates(_ context.Context, refs []string, _ string) (map[string]string, error) {
+	f.asked = append(f.asked, refs...)
+	found := make(map[string]string)
+	for _, ref := range refs {
+		if s, ok := f.states[ref]; ok {
+			found[ref] = s
+		}
+	}
+	return found, nil
+}
+
+func TestRefreshBlockers(t *testing.T) {
+	fetcher := &stateFetcher{states: map[string]string{
+		"org/repo#10": model.StateOpen,
+		"org/repo#11": model.StateClosed,
+	}}
+	svc := New(fetcher, nil, "me", time.Now().Add(-time.Hour))
+
+	blocker := model.Item{Repository: model.Repository{FullName: "org/repo"}, Number: 1, State: model.StateMerged}
+	blocked := model.Item{
+		Repository: model.Repository{FullName: "org/repo"},
+		Number:     2,
+		State:      model.StateOpen,
+		BlockedBy:  []string{"org/repo#1", "org/repo#10", "org/repo#11", "org/repo#12"},
+	}
+	items := []model.Item{blocker, blocked}
+	svc.refreshBlockers(context.Background(), items)
+
+	// #1 is among the items and merged; #11 closed; #12 unknown is kept
+	if got, want := items[1].BlockedBy, []string{"org/repo#10", "org/repo#12"}; !slices.Equal(got, want) {
+		t.Errorf("BlockedBy = %v, want %v", got, want)
+	}
+	if want := []string{"org/repo#10", "org/repo#11", "org/repo#12"}; !slices.Equal(fetcher.asked, want) {
+		t.Errorf("looked up %v, want %v", fetcher.asked, want)
+	}
+
+	// Nothing to look up when no item is blocked
+	fetcher.asked = nil
+	svc.refreshBlockers(context.Background(), []model.Item{blocker})
+	if len(fetcher.asked) != 0 {
+		t.Errorf("looked up %v with no blockers", fetcher.asked)
+	}
+}
+
+// scopeFetcher records the options notifications were listed with.
+type scopeFetcher struct {
+	ghclient.GitHubFetcher
+	opts []ghclient.NotificationOptions
+}
+
+func (f *scopeFetcher) ListUnreadNotifications(_ context.Context, since time.Time) ([]model.Item, error) {
+	f.opts = append(f.opts, ghclient.NotificationOptions{Since: since})
+	return nil, nil
+}
+
+func (f *scopeFetcher) ListNotifications(_ context.Context, opts ghclient.NotificationOptions) ([]model.Item, error) {
+	f.opts = append(f.opts, opts)
+	return nil, nil
+}
+
+func TestUnreadItemsScope(t *testing.T) {
+	fetcher := &scopeFetcher{}
+	since := time.Now().Add(-time.Hour)
+
+	if _, err := New(fetcher, nil, "me", since).UnreadItems(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(fetcher, nil, "me", since, WithParticipating(true)).UnreadItems(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(fetcher, nil, "me", since, WithRepos("acme/api")).UnreadItems(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fetcher.opts) != 3 {
+		t.Fatalf("listed %d times, want 3", len(fetcher.opts))
+	}
+	if fetcher.opts[0].Participating {
+		t.Error("default listing was limited to participating threads")
+	}
+	if got := fetcher.opts[1]; !got.Participating || !got.All || !got.Since.Equal(since) {
+		t.Errorf("participating listing = %+v, want participating, read included, since %v", got, since)
+	}
+	if got := fetcher.opts[2]; got.Repo != "acme/api" || got.Participating || got.All {
+		t.Errorf("single-repo listing = %+v, want unread acme/api notifications", got)
+	}
+}
+
+// roundTripFunc serves requests with a function instead of the network.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestUnreadItemsNotModified(t *testing.T) {
+	const lastModified = "Mon, 02 Mar 2026 10:00:00 GMT"
+	updated := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	var requests, notModified int
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		resp := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Request: r, Body: io.NopCloser(strings.NewReader(""))}
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			notModified++
+			resp.StatusCode = http.StatusNotModified
+			return resp, nil
+		}
+		resp.Header.Set("Last-Modified", lastModified)
+		resp.Body = io.NopCloser(strings.NewReader(`[{"id":"1","unread":true,"subject":{"type":"Issue","title":"t","url":"https://api.github.com/repos/acme/api/issues/1"},"repository":{"full_name":"acme/api"},"updated_at":"` + updated + `"}]`))
+		return resp, nil
+	})
+	client, err := ghclient.NewClient(context.Background(), "token", ghclient.WithTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestCache(t)
+	svc := New(client, c, "me", time.Now().Add(-time.Hour))
+
+	// The first run lists everything and keeps GitHub's Last-Modified
+	if _, err := svc.UnreadItems(context.Background(), false); err != nil {
+		t.Fatalf("UnreadItems() error = %v", err)
+	}
+	first, ok := c.GetList("me", cache.ListTypeNotifications, svc.notificationListOptions(false))
+	if !ok || first.Validators[""].LastModified != lastModified {
+		t.Fatalf("cached validators = %+v, want the listing's Last-Modified", first.Validators)
+	}
+
+	// The next poll is answered 304 and keeps the cached items
+	result, err := svc.UnreadItems(context.Background(), false)
+	if err != nil {
+		t.Fatalf("UnreadItems() error = %v", err)
+	}
+	if len(result.Items) != 1 || result.NewCount != 0 || !svc.Stats().NotifNotModified {
+		t.Errorf("UnreadItems() = %d items, %d new, NotifNotModified %v; want the cached item, not modified",
+			len(result.Items), result.NewCount, svc.Stats().NotifNotModified)
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("requests = %d (%d not modified), want 2 with the second not modified", requests, notModified)
+	}
+
+	// Polls keep asking for the same since while nothing changes
+	second, _ := c.GetList("me", cache.ListTypeNotifications, svc.notificationListOptions(false))
+	if !second.LastFetchTime.Equal(first.LastFetchTime) {
+		t.Errorf("LastFetchTime moved from %v to %v on an unchanged poll", first.LastFetchTime, second.LastFetchTime)
+	}
+}
+
+// rosterFetcher returns team members by "org/slug", failing for teams it
+// doesn't know, and counts the lookups.
+type rosterFetcher struct {
+	ghclient.GitHubFetcher
+	teams   map[string][]string
+	lookups int
+}
+
+func (f *rosterFetcher) TeamMembers(_ context.Context, org, slug string) ([]string, error) {
+	f.lookups++
+	members, ok := f.teams[org+"/"+slug]
+	if !ok {
+		return nil, fmt.Errorf("team %s/%s not found", org, slug)
+	}
+	return members, nil
+}
+
+func TestTeamRoster(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	fetcher := &rosterFetcher{teams: map[string][]string{
+		"acme/maintainers": {"alice", "bob"},
+		"acme/release":     {"carol"},
+	}}
+	svc := New(fetcher, nil, "me", time.Now())
+	ctx := context.Background()
+
+	got := svc.TeamRoster(ctx, []string{"acme/maintainers", "not-a-slug", "acme/release"})
+	if want := []string{"alice", "bob", "carol"}; !slices.Equal(got, want) {
+		t.Errorf("TeamRoster() = %v, want %v", got, want)
+	}
+	if fetcher.lookups != 2 {
+		t.Errorf("looked up %d teams, want 2 (malformed entries are skipped)", fetcher.lookups)
+	}
+
+	// Membership is cached, and kept when a later lookup fails
+	fetcher.teams = nil
+	got = svc.TeamRoster(ctx, []string{"acme/maintainers"})
+	if want := []string{"alice", "bob"}; !slices.Equal(got, want) {
+		t.Errorf("cached TeamRoster() = %v, want %v", got, want)
+	}
+	if fetcher.lookups != 2 {
+		t.Errorf("looked up %d teams, want the cached roster reused", fetcher.lookups)
+	}
+	if got := svc.TeamRoster(ctx, []string{"acme/unknown"}); len(got) != 0 {
+		t.Errorf("TeamRoster() for an unknown team = %v, want none", got)
+	}
+}
+
+func TestOffline(t *testing.T) {
+	c := newTestCache(t)
+	now := time.Now()
+	issue := func(number int, updated time.Time) model.Item {
+		it := makeFetchItem("org/repo", number, model.SubjectIssue, fmt.Sprintf("https://api.github.com/repos/org/repo/issues/%d", number), false)
+		it.ID = fmt.Sprint(number)
+		it.UpdatedAt = updated
+		return it
+	}
+
+	// Notifications cached past their TTL, review requests within it
+	if err := c.SetList("me", cache.ListTypeNotifications, &cache.ListCacheEntry{
+		Items:    []model.Item{issue(1, now.Add(-time.Hour)), issue(2, now.Add(-time.Hour)), issue(3, now.Add(-time.Hour))},
+		CachedAt: now.Add(-2 * cache.NotificationsCacheTTL),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetList("me", cache.ListTypeReviewRequested, &cache.ListCacheEntry{
+		Items:    []model.Item{issue(4, now)},
+		CachedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Issue 1's details are current; issue 2 was updated after its were cached
+	for number, updated := range map[int]time.Time{1: now.Add(-time.Hour), 4: now, 2: now.Add(-2 * time.Hour)} {
+		key := cache.Key{RepoFullName: "org/repo", SubjectType: model.SubjectIssue, Number: number}
+		if err := c.Set(key, updated, &model.Item{Type: model.ItemTypeIssue, Details: &model.IssueDetails{}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A nil fetcher panics if anything tries to reach GitHub
+	svc := New(nil, c, "me", now.Add(-24*time.Hour), WithOffline(true))
+	ctx := context.Background()
+	if n, err := svc.NotificationCount(ctx, false); err != nil || n != 0 {
+		t.Errorf("NotificationCount() = %d, %v; want 0 offline", n, err)
+	}
+	unread, err := svc.UnreadItems(ctx, false)
+	if err != nil {
+		t.Fatalf("UnreadItems() error = %v", err)
+	}
+	reviews, _, err := svc.ReviewRequestedPRs(ctx)
+	if err != nil {
+		t.Fatalf("ReviewRequestedPRs() error = %v", err)
+	}
+	if authored, _, err := svc.AuthoredPRs(ctx); err != nil || len(authored) != 0 {
+		t.Errorf("AuthoredPRs() = %v, %v; want nothing, since nothing was cached", authored, err)
+	}
+	if len(unread.Items) != 3 || !unread.FromCache || len(reviews) != 1 {
+		t.Fatalf("offline lists = %d notifications, %d reviews; want the cached 3 and 1", len(unread.Items), len(reviews))
+	}
+
+	result, err := svc.EnrichAll(ctx, nil, unread.Items, reviews)
+	if err != nil {
+		t.Fatalf("EnrichAll() error = %v", err)
+	}
+	if result.CacheHits != 3 || result.Enriched != 0 {
+		t.Errorf("EnrichAll() = %+v, want 3 cache hits and nothing fetched", result)
+	}
+	if unread.Items[0].Details == nil || unread.Items[1].Details == nil {
+		t.Error("items 1 and 2 lack details, want their cached ones, however old")
+	}
+	if unread.Items[2].Details != nil {
+		t.Error("item 3 has details, want it unenriched with nothing cached")
+	}
+	for _, it := range unread.Items {
+		if !it.Stale {
+			t.Errorf("notification %s not stale, want every item of an expired list marked", it.ID)
+		}
+	}
+	if reviews[0].Stale || reviews[0].Details == nil {
+		t.Errorf("review request = stale %v, details %v; want fresh cached details", reviews[0].Stale, reviews[0].Details)
+	}
+	if stats := svc.Stats(); !stats.NotifFromCache || !stats.ReviewFromCache {
+		t.Errorf("Stats() = %+v, want notifications and reviews from cache", stats)
+	}
+}
+
+func TestCachedDetailsWithProjectStatus(t *testing.T) {
+	c := newTestCache(t)
+	item := makeFetchItem("org/repo", 1, model.SubjectIssue, "https://api.github.com/repos/org/repo/issues/1", false)
+	key, _ := buildCacheKey(&item)
+	if err := c.Set(key, item.UpdatedAt, &item); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := New(nil, c, "me", time.Time{}).cachedDetails(c, key, item.UpdatedAt); !ok {
+		t.Error("cachedDetails() missed without project status")
+	}
+	svc := New(nil, c, "me", time.Time{}, WithProjectStatus(true))
+	if _, ok := svc.cachedDetails(c, key, item.UpdatedAt); ok {
+		t.Error("cachedDetails() returned details cached without project boards")
+	}
+
+	item.ProjectsFetched = true
+	if err := c.Set(key, item.UpdatedAt, &item); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := svc.cachedDetails(c, key, item.UpdatedAt); !ok {
+		t.Error("cachedDetails() missed details cached with project boards")
+	}
+}
+
+func TestOfflineScope(t *testing.T) {
+	now := time.Now()
+	item := func(repo string, number int, updated time.Time) model.Item {
+		it := makeFetchItem(repo, number, model.SubjectIssue, fmt.Sprintf("https://api.github.com/repos/%s/issues/%d", repo, number), false)
+		it.UpdatedAt = updated
+		return it
+	}
+	cached := []model.Item{
+		item("org/api", 1, now.Add(-time.Hour)),
+		item("org/web", 2, now.Add(-time.Hour)),
+		item("org/api", 3, now.Add(-5*24*time.Hour)),
+	}
+
+	tests := []struct {
+		name   string
+		entry  cache.ListCacheEntry
+		opts   []Option
+		since  time.Time
+		wantNs []int
+	}{
+		{
+			name:   "full list narrowed to a repo and a shorter since",
+			entry:  cache.ListCacheEntry{SinceTime: now.Add(-7 * 24 * time.Hour)},
+			opts:   []Option{WithRepos("org/api")},
+			since:  now.Add(-24 * time.Hour),
+			wantNs: []int{1},
+		},
+		{
+			name:   "same scope",
+			entry:  cache.ListCacheEntry{SinceTime: now.Add(-7 * 24 * time.Hour), Participating: true},
+			opts:   []Option{WithParticipating(true)},
+			since:  now.Add(-7 * 24 * time.Hour),
+			wantNs: []int{1, 2, 3},
+		},
+		{
+			name:  "longer since than cached",
+			entry: cache.ListCacheEntry{SinceTime: now.Add(-24 * time.Hour)},
+			since: now.Add(-7 * 24 * time.Hour),
+		},
+		{
+			name:  "single-repo list for a full run",
+			entry: cache.ListCacheEntry{Repos: []string{"org/api"}},
+			since: now.Add(-24 * time.Hour),
+		},
+		{
+			name:  "participating list for a full run",
+			entry: cache.ListCacheEntry{Participating: true},
+			since: now.Add(-24 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCache(t)
+			entry := tt.entry
+			entry.Items = slices.Clone(cached)
+			entry.CachedAt = now
+			if err := c.SetList("me", cache.ListTypeNotifications, &entry); err != nil {
+				t.Fatal(err)
+			}
+
+			svc := New(nil, c, "me", tt.since, append(tt.opts, WithOffline(true))...)
+			unread, err := svc.UnreadItems(context.Background(), false)
+			if err != nil {
+				t.Fatalf("UnreadItems() error = %v", err)
+			}
+			var got []int
+			for _, it := range unread.Items {
+				got = append(got, it.Number)
+			}
+			if !slices.Equal(got, tt.wantNs) {
+				t.Errorf("offline notifications = %v, want %v", got, tt.wantNs)
+			}
+		})
+	}
+}
@@ -6,14 +6,18 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/ghclient"
 )
 
 // tokenGuidance is the shared setup instructions included in all token errors.
 // The Notifications API requires a classic token — fine-grained tokens do not
 // support it (https://docs.github.com/en/rest/activity/notifications).
-const tokenGuidance = `triage only reads data — it never writes, comments, or modifies anything.
+const tokenGuidance = `triage only reads data unless you explicitly ask it to write (e.g. triage edit).
 However, GitHub's Notifications API requires a classic token with broad scopes.
 
 Recommended — use the GitHub CLI to manage credentials securely:
@@ -80,3 +84,53 @@ This may be caused by:
 
 GitHub said: %s`, ghErr.Message)
 }
+
+// TokenRejected returns guidance for a token GitHub started rejecting
+// partway through a run, typically because it was revoked or expired.
+func TokenRejected() error {
+	return errors.New(`GitHub rejected your token partway through this run (it may have been revoked or expired)
+
+Results fetched before the rejection were kept; the remaining sources and
+details were skipped.
+
+To fix:
+  - If using gh CLI: run "gh auth login"
+  - If using a classic token: check expiration at https://github.com/settings/tokens
+Then run triage again.`)
+}
+
+// SSORequired returns guidance for organizations that enforce SAML SSO and
+// have not authorized the token. Their repositories were skipped.
+func SSORequired(orgs []ghclient.SSOOrg) error {
+	var sb strings.Builder
+	sb.WriteString("Some organizations require SAML SSO authorization for your token; their items were skipped.\n\nTo fix:\n")
+	for _, o := range orgs {
+		fmt.Fprintf(&sb, "  - authorize your token for org %s at %s\n", o.Org, o.URL)
+	}
+	sb.WriteString("Then run triage again.")
+	return errors.New(sb.String())
+}
+
+// SearchTruncated returns a summary of searches that matched more results
+// than GitHub returns, even after splitting them by creation date.
+func SearchTruncated(searches []ghclient.TruncatedSearch) error {
+	var sb strings.Builder
+	sb.WriteString("Some searches matched more results than GitHub returns, so these items are missing:\n")
+	for _, s := range searches {
+		fmt.Fprintf(&sb, "  - %d results of %q\n", s.Missing, s.Query)
+	}
+	sb.WriteString("GitHub search returns at most 1000 results per query, and these could not be split any further.")
+	return errors.New(sb.String())
+}
+
+// ReposInaccessible returns a summary of repositories the token can't
+// read. Their items are still listed, unenriched and marked locked.
+func ReposInaccessible(repos []string) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Your token can't read %d repositories; their items are shown with %s and scored without details:\n", len(repos), format.LockedIcon)
+	for _, r := range repos {
+		fmt.Fprintf(&sb, "  - %s\n", r)
+	}
+	fmt.Fprintf(&sb, "They will be retried after %d minutes. If you should have access, check the token's scopes at https://github.com/settings/tokens", int(cache.InaccessibleRepoTTL.Minutes()))
+	return errors.New(sb.String())
+}
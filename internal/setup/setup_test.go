@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	gh "github.com/google/go-github/v57/github"
+	"github.com/spiffcs/triage/internal/ghclient"
 )
 
 func TestTokenMissing(t *testing.T) {
@@ -112,3 +113,30 @@ func TestTokenInvalid_NonGitHubError(t *testing.T) {
 		t.Errorf("TokenInvalid(non-github) should preserve original error, got:\n%s", msg)
 	}
 }
+
+func TestTokenRejected(t *testing.T) {
+	msg := TokenRejected().Error()
+	for _, want := range []string{"partway through", "gh auth login", "settings/tokens", "were kept"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("TokenRejected() should contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestSearchTruncated(t *testing.T) {
+	msg := SearchTruncated([]ghclient.TruncatedSearch{{Query: "is:pr is:open author:me", Missing: 200}}).Error()
+	want := `200 results of "is:pr is:open author:me"`
+	if !strings.Contains(msg, want) {
+		t.Errorf("SearchTruncated() should contain %q, got:\n%s", want, msg)
+	}
+}
+
+func TestSSORequired(t *testing.T) {
+	msg := SSORequired([]ghclient.SSOOrg{
+		{Org: "acme", URL: "https://github.com/orgs/acme/sso?authorization_request=abc"},
+	}).Error()
+	want := "authorize your token for org acme at https://github.com/orgs/acme/sso?authorization_request=abc"
+	if !strings.Contains(msg, want) {
+		t.Errorf("SSORequired() should contain %q, got:\n%s", want, msg)
+	}
+}
@@ -0,0 +1,119 @@
+// Package slo tracks how quickly the user reviews PRs after being asked,
+// against a personal response-time objective.
+package slo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// atRiskFraction is how much of the target a pending request may use up
+// before it is flagged as about to breach.
+const atRiskFraction = 0.75
+
+// Policy is the review response-time objective.
+type Policy struct {
+	// Target is how soon a review should follow the request; zero disables
+	// tracking.
+	Target time.Duration
+	// Window is how far back completed reviews count towards the stats.
+	Window time.Duration
+}
+
+// NewPolicy parses the configured review SLO settings.
+func NewPolicy(settings config.ReviewSLOSettings) (Policy, error) {
+	var p Policy
+	if t := strings.TrimSpace(settings.Target); t != "" && !strings.EqualFold(t, "off") {
+		d, err := duration.ParseDuration(t)
+		if err != nil || d <= 0 {
+			return Policy{}, fmt.Errorf("invalid review_slo target %q: expected a duration such as 24h, or off", settings.Target)
+		}
+		p.Target = d
+	}
+	d, err := duration.ParseDuration(strings.TrimSpace(settings.Window))
+	if err != nil || d <= 0 {
+		return Policy{}, fmt.Errorf("invalid review_slo window %q: expected a duration such as 30d", settings.Window)
+	}
+	p.Window = d
+	return p, nil
+}
+
+// Enabled reports whether the policy has a target.
+func (p Policy) Enabled() bool {
+	return p.Target > 0
+}
+
+// Status is where a pending review request stands against the target.
+type Status int
+
+const (
+	// StatusNone means the item has no pending request for the user, or
+	// the policy is disabled.
+	StatusNone Status = iota
+	// StatusOK means the request is well within the target.
+	StatusOK
+	// StatusAtRisk means most of the target has been used up.
+	StatusAtRisk
+	// StatusBreached means the target has passed.
+	StatusBreached
+)
+
+// Status classifies a request that has been waiting for the given time.
+func (p Policy) Status(waiting time.Duration) Status {
+	switch {
+	case !p.Enabled():
+		return StatusNone
+	case waiting >= p.Target:
+		return StatusBreached
+	case float64(waiting) >= atRiskFraction*float64(p.Target):
+		return StatusAtRisk
+	default:
+		return StatusOK
+	}
+}
+
+// ItemStatus classifies item's pending review request for login, if any.
+func (p Policy) ItemStatus(item *model.Item, login string, now time.Time) Status {
+	if item.State != model.StateOpen && item.State != "" {
+		return StatusNone
+	}
+	requested, ok := item.PendingReviewRequest(login)
+	if !ok {
+		return StatusNone
+	}
+	return p.Status(now.Sub(requested))
+}
+
+// Stats summarizes the user's completed review responses.
+type Stats struct {
+	// Count is the number of reviews in the window.
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+}
+
+// computeStats returns the stats for the given response times.
+func computeStats(responses []time.Duration) Stats {
+	if len(responses) == 0 {
+		return Stats{}
+	}
+	sorted := slices.Clone(responses)
+	slices.Sort(sorted)
+	return Stats{
+		Count: len(sorted),
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted values.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
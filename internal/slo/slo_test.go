@@ -0,0 +1,106 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings config.ReviewSLOSettings
+		want     Policy
+		wantErr  bool
+	}{
+		{"defaults", config.DefaultReviewSLOSettings(), Policy{Target: 24 * time.Hour, Window: 30 * 24 * time.Hour}, false},
+		{"off", config.ReviewSLOSettings{Target: "off", Window: "2w"}, Policy{Window: 14 * 24 * time.Hour}, false},
+		{"bad target", config.ReviewSLOSettings{Target: "soon", Window: "30d"}, Policy{}, true},
+		{"bad window", config.ReviewSLOSettings{Target: "1d", Window: "0d"}, Policy{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPolicy(tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NewPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyStatus(t *testing.T) {
+	p := Policy{Target: 24 * time.Hour}
+	tests := []struct {
+		waiting time.Duration
+		want    Status
+	}{
+		{2 * time.Hour, StatusOK},
+		{18 * time.Hour, StatusAtRisk},
+		{30 * time.Hour, StatusBreached},
+	}
+	for _, tt := range tests {
+		if got := p.Status(tt.waiting); got != tt.want {
+			t.Errorf("Status(%v) = %v, want %v", tt.waiting, got, tt.want)
+		}
+	}
+	if got := (Policy{}).Status(48 * time.Hour); got != StatusNone {
+		t.Errorf("disabled policy Status() = %v, want StatusNone", got)
+	}
+}
+
+func TestItemStatus(t *testing.T) {
+	now := time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)
+	p := Policy{Target: 24 * time.Hour}
+	pr := func(events ...model.ReviewEvent) *model.Item {
+		return &model.Item{Type: model.ItemTypePullRequest, State: model.StateOpen, Details: &model.PRDetails{ReviewEvents: events}}
+	}
+	requested := func(login string, ago time.Duration) model.ReviewEvent {
+		return model.ReviewEvent{Kind: model.ReviewEventRequested, Login: login, At: now.Add(-ago)}
+	}
+	reviewed := func(login string, ago time.Duration) model.ReviewEvent {
+		return model.ReviewEvent{Kind: model.ReviewEventReviewed, Login: login, At: now.Add(-ago)}
+	}
+
+	tests := []struct {
+		name string
+		item *model.Item
+		want Status
+	}{
+		{"pending and breached", pr(requested("Octocat", 30*time.Hour)), StatusBreached},
+		{"pending and at risk", pr(requested("octocat", 20*time.Hour)), StatusAtRisk},
+		{"reviewed", pr(requested("octocat", 30*time.Hour), reviewed("octocat", 2*time.Hour)), StatusNone},
+		{"re-requested after review", pr(requested("octocat", 60*time.Hour), reviewed("octocat", 50*time.Hour), requested("octocat", time.Hour)), StatusOK},
+		{"someone else requested", pr(requested("hubot", 30*time.Hour)), StatusNone},
+		{"issue", &model.Item{Type: model.ItemTypeIssue, Details: &model.IssueDetails{}}, StatusNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.ItemStatus(tt.item, "octocat", now); got != tt.want {
+				t.Errorf("ItemStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	var responses []time.Duration
+	for i := 1; i <= 10; i++ {
+		responses = append(responses, time.Duration(11-i)*time.Hour)
+	}
+
+	got := computeStats(responses)
+	want := Stats{Count: 10, P50: 5 * time.Hour, P90: 9 * time.Hour}
+	if got != want {
+		t.Errorf("computeStats() = %+v, want %+v", got, want)
+	}
+	if got := computeStats(nil); got != (Stats{}) {
+		t.Errorf("computeStats(nil) = %+v, want zero", got)
+	}
+}
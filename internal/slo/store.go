@@ -0,0 +1,154 @@
+package slo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// Version is the current on-disk format of the review history.
+const Version = 1
+
+const storeName = "reviews.json"
+
+// retention is how long completed reviews are kept, independent of the
+// configured window, so widening the window has history to draw on.
+const retention = 365 * 24 * time.Hour
+
+// storeFile is the on-disk layout of the review history.
+type storeFile struct {
+	Version int               `json:"version"`
+	Samples map[string]Sample `json:"samples"`
+}
+
+// Sample is one completed review request.
+type Sample struct {
+	RequestedAt time.Time `json:"requestedAt"`
+	ReviewedAt  time.Time `json:"reviewedAt"`
+}
+
+// Response is how long the review took.
+func (s Sample) Response() time.Duration {
+	return s.ReviewedAt.Sub(s.RequestedAt)
+}
+
+// Store keeps the user's completed review responses across runs. Review
+// requests only show up while they are recent, so history is kept locally.
+type Store struct {
+	path    string
+	samples map[string]Sample
+	mu      sync.RWMutex
+}
+
+// NewStore opens the review history in the user cache directory.
+func NewStore() (*Store, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreFromPath(filepath.Join(cacheDir, "triage", storeName))
+}
+
+// NewStoreFromPath opens the review history at the given file path.
+func NewStoreFromPath(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		path:    path,
+		samples: make(map[string]Sample),
+	}
+	if err := s.load(); err != nil {
+		log.Debug("could not load review history, starting fresh", "error", err)
+	}
+	return s, nil
+}
+
+// load reads the samples from disk
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Version != Version {
+		return fmt.Errorf("unsupported review history version %d", file.Version)
+	}
+	if file.Samples != nil {
+		s.samples = file.Samples
+	}
+	return nil
+}
+
+// save writes the samples to disk
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(storeFile{Version: Version, Samples: s.samples}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Record adds login's completed review requests among items to the
+// history and drops samples past retention. Each request is recorded once.
+func (s *Store) Record(items []model.Item, login string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for i := range items {
+		pr := items[i].PRDetails()
+		if pr == nil {
+			continue
+		}
+		requested, reviewed := pr.ReviewResponse(login)
+		if requested.IsZero() || reviewed == nil {
+			continue
+		}
+		key := fmt.Sprintf("%s@%d", activity.Key(&items[i]), requested.Unix())
+		if _, ok := s.samples[key]; ok {
+			continue
+		}
+		s.samples[key] = Sample{RequestedAt: requested, ReviewedAt: *reviewed}
+		changed = true
+	}
+	for key, sample := range s.samples {
+		if now.Sub(sample.ReviewedAt) > retention {
+			delete(s.samples, key)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return s.save()
+}
+
+// Stats summarizes the reviews completed within window before now.
+func (s *Store) Stats(window time.Duration, now time.Time) Stats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var responses []time.Duration
+	for _, sample := range s.samples {
+		if now.Sub(sample.ReviewedAt) <= window {
+			responses = append(responses, sample.Response())
+		}
+	}
+	return computeStats(responses)
+}
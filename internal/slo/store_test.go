@@ -0,0 +1,58 @@
+package slo
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestStoreRecordsEachReviewOnce(t *testing.T) {
+	now := time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "reviews.json")
+	store, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reviewedPR := func(number int, requestedAgo, reviewedAgo time.Duration) model.Item {
+		return model.Item{
+			Number:     number,
+			Type:       model.ItemTypePullRequest,
+			Repository: model.Repository{FullName: "acme/api"},
+			Details: &model.PRDetails{ReviewEvents: []model.ReviewEvent{
+				{Kind: model.ReviewEventRequested, Login: "octocat", At: now.Add(-requestedAgo)},
+				{Kind: model.ReviewEventReviewed, Login: "octocat", At: now.Add(-reviewedAgo)},
+			}},
+		}
+	}
+	items := []model.Item{
+		reviewedPR(1, 10*time.Hour, 8*time.Hour),
+		reviewedPR(2, 48*time.Hour, 24*time.Hour),
+		// Outside a one-week window
+		reviewedPR(3, 20*24*time.Hour, 19*24*time.Hour),
+		// Still pending
+		{Number: 4, Type: model.ItemTypePullRequest, Repository: model.Repository{FullName: "acme/api"},
+			Details: &model.PRDetails{ReviewEvents: []model.ReviewEvent{
+				{Kind: model.ReviewEventRequested, Login: "octocat", At: now.Add(-time.Hour)},
+			}}},
+	}
+
+	// Seeing the same PRs on a later run doesn't count them twice
+	for range 2 {
+		if err := store.Record(items, "octocat", now); err != nil {
+			t.Fatalf("Record() error: %v", err)
+		}
+	}
+
+	reopened, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := reopened.Stats(7*24*time.Hour, now)
+	want := Stats{Count: 2, P50: 2 * time.Hour, P90: 24 * time.Hour}
+	if got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
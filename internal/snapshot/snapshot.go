@@ -0,0 +1,139 @@
+// Package snapshot remembers the state of each item at the end of a run,
+// so the next run can summarize what changed in between.
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// Entry is what a run saw of one item.
+type Entry struct {
+	Priority    triage.PriorityLevel `json:"priority"`
+	ReviewState string               `json:"reviewState,omitempty"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+
+	// Resolved is set for items marked resolved; Hidden when they were
+	// also left out of the list for having no activity since.
+	Resolved bool `json:"resolved,omitempty"`
+	Hidden   bool `json:"hidden,omitempty"`
+}
+
+// State is the entries of one run, keyed by item key (see model.Item.Key).
+type State map[string]Entry
+
+// Capture records the state of items. store may be nil.
+func Capture(items []triage.PrioritizedItem, store *resolved.Store) State {
+	state := make(State, len(items))
+	for _, item := range items {
+		e := Entry{
+			Priority:  item.Priority,
+			UpdatedAt: item.UpdatedAt,
+		}
+		if pr := item.PRDetails(); pr != nil {
+			e.ReviewState = pr.ReviewState
+		}
+		key := item.Key()
+		if store != nil && store.IsResolved(key) {
+			e.Resolved = true
+			e.Hidden = !store.ShouldShow(key, item.UpdatedAt)
+		}
+		state[key] = e
+	}
+	return state
+}
+
+// Rekey moves entries that earlier versions recorded under an item's ID to
+// the item's key. ids maps item IDs to keys; an entry already recorded
+// under the key wins.
+func (s State) Rekey(ids map[string]string) {
+	for id, key := range ids {
+		e, ok := s[id]
+		if !ok || id == key {
+			continue
+		}
+		if _, exists := s[key]; !exists {
+			s[key] = e
+		}
+		delete(s, id)
+	}
+}
+
+// Delta counts what changed between two runs.
+type Delta struct {
+	NewUrgent int // items urgent now that weren't before
+	Approved  int // PRs approved since
+	Reopened  int // resolved items back in the list with new activity
+}
+
+// Compare reports what changed from prev to cur.
+func Compare(prev, cur State) Delta {
+	var d Delta
+	for id, c := range cur {
+		p, seen := prev[id]
+		if c.Hidden {
+			continue
+		}
+		if c.Priority == triage.PriorityUrgent && (!seen || p.Priority != triage.PriorityUrgent) {
+			d.NewUrgent++
+		}
+		// An empty previous review state means the PR wasn't enriched
+		// (--quick), not that it had no reviews
+		if seen && c.ReviewState == model.ReviewStateApproved &&
+			p.ReviewState != "" && p.ReviewState != model.ReviewStateApproved {
+			d.Approved++
+		}
+	}
+	d.Reopened = len(Resurfaced(prev, cur))
+	return d
+}
+
+// Resurfaced returns the keys of resolved items that came back into the
+// list with activity since the previous run.
+func Resurfaced(prev, cur State) map[string]bool {
+	keys := make(map[string]bool)
+	for key, c := range cur {
+		if !c.Resolved || c.Hidden {
+			continue
+		}
+		if p, seen := prev[key]; !seen || p.Hidden || c.UpdatedAt.After(p.UpdatedAt) {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// Empty reports whether nothing changed.
+func (d Delta) Empty() bool {
+	return d == Delta{}
+}
+
+// String summarizes the delta in one line, e.g. "+4 new urgent, 2 PRs got
+// approved, 1 item you resolved has new activity (reopened in list)". It
+// is empty when nothing changed.
+func (d Delta) String() string {
+	var parts []string
+	if d.NewUrgent > 0 {
+		parts = append(parts, fmt.Sprintf("+%d new urgent", d.NewUrgent))
+	}
+	if d.Approved > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s got approved", d.Approved, plural(d.Approved, "PR", "PRs")))
+	}
+	if d.Reopened > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s you resolved %s new activity (reopened in list)",
+			d.Reopened, plural(d.Reopened, "item", "items"), plural(d.Reopened, "has", "have")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,266 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+func TestCapture(t *testing.T) {
+	now := time.Now()
+	store, err := resolved.NewStoreFromPath(filepath.Join(t.TempDir(), "resolved.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Resolve("quiet", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Resolve("active", now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	pr := triage.PrioritizedItem{Priority: triage.PriorityImportant}
+	pr.ID = "pr"
+	pr.UpdatedAt = now
+	pr.Details = &model.PRDetails{ReviewState: model.ReviewStateApproved}
+	quiet := triage.PrioritizedItem{Priority: triage.PriorityFYI}
+	quiet.ID = "quiet"
+	quiet.UpdatedAt = now
+	active := triage.PrioritizedItem{Priority: triage.PriorityFYI}
+	active.ID = "active"
+	active.UpdatedAt = now
+
+	state := Capture([]triage.PrioritizedItem{pr, quiet, active}, store)
+
+	if got := state["pr"]; got.ReviewState != model.ReviewStateApproved || got.Priority != triage.PriorityImportant || got.Resolved {
+		t.Errorf("pr = %+v", got)
+	}
+	if got := state["quiet"]; !got.Resolved || !got.Hidden {
+		t.Errorf("quiet = %+v, want resolved and hidden", got)
+	}
+	if got := state["active"]; !got.Resolved || got.Hidden {
+		t.Errorf("active = %+v, want resolved and shown", got)
+	}
+
+	if got := Capture([]triage.PrioritizedItem{quiet}, nil)["quiet"]; got.Resolved {
+		t.Errorf("without a resolved store, quiet = %+v", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		prev State
+		cur  State
+		want Delta
+	}{
+		{
+			name: "newly urgent",
+			prev: State{"a": {Priority: triage.PriorityImportant}},
+			cur: State{
+				"a": {Priority: triage.PriorityUrgent},
+				"b": {Priority: triage.PriorityUrgent},
+			},
+			want: Delta{NewUrgent: 2},
+		},
+		{
+			name: "still urgent",
+			prev: State{"a": {Priority: triage.PriorityUrgent}},
+			cur:  State{"a": {Priority: triage.PriorityUrgent}},
+		},
+		{
+			name: "hidden urgent is not counted",
+			cur:  State{"a": {Priority: triage.PriorityUrgent, Resolved: true, Hidden: true}},
+		},
+		{
+			name: "approved",
+			prev: State{"a": {ReviewState: model.ReviewStatePending}},
+			cur:  State{"a": {ReviewState: model.ReviewStateApproved}},
+			want: Delta{Approved: 1},
+		},
+		{
+			name: "already approved",
+			prev: State{"a": {ReviewState: model.ReviewStateApproved}},
+			cur:  State{"a": {ReviewState: model.ReviewStateApproved}},
+		},
+		{
+			name: "unknown previous review state",
+			prev: State{"a": {}},
+			cur:  State{"a": {ReviewState: model.ReviewStateApproved}},
+		},
+		{
+			name: "new PR already approved",
+			cur:  State{"a": {ReviewState: model.ReviewStateApproved}},
+		},
+		{
+			name: "resolved item reopened",
+			prev: State{"a": {Resolved: true, Hidden: true, UpdatedAt: earlier}},
+			cur:  State{"a": {Resolved: true, UpdatedAt: now}},
+			want: Delta{Reopened: 1},
+		},
+		{
+			name: "resolved during the last run then active",
+			prev: State{"a": {UpdatedAt: earlier}},
+			cur:  State{"a": {Resolved: true, UpdatedAt: now}},
+			want: Delta{Reopened: 1},
+		},
+		{
+			name: "reopened item already shown last run",
+			prev: State{"a": {Resolved: true, UpdatedAt: now}},
+			cur:  State{"a": {Resolved: true, UpdatedAt: now}},
+		},
+		{
+			name: "resolved item still quiet",
+			prev: State{"a": {Resolved: true, Hidden: true, UpdatedAt: earlier}},
+			cur:  State{"a": {Resolved: true, Hidden: true, UpdatedAt: earlier}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(tt.prev, tt.cur); got != tt.want {
+				t.Errorf("Compare() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResurfaced(t *testing.T) {
+	now := time.Now()
+	prev := State{
+		"back":  {Resolved: true, Hidden: true, UpdatedAt: now.Add(-time.Hour)},
+		"shown": {Resolved: true, UpdatedAt: now},
+	}
+	cur := State{
+		"back":  {Resolved: true, UpdatedAt: now},
+		"shown": {Resolved: true, UpdatedAt: now},
+		"quiet": {Resolved: true, Hidden: true, UpdatedAt: now},
+		"open":  {UpdatedAt: now},
+	}
+
+	got := Resurfaced(prev, cur)
+	if len(got) != 1 || !got["back"] {
+		t.Errorf("Resurfaced() = %v, want only back", got)
+	}
+}
+
+func TestDeltaString(t *testing.T) {
+	tests := []struct {
+		delta Delta
+		want  string
+	}{
+		{Delta{}, ""},
+		{Delta{NewUrgent: 4, Approved: 2, Reopened: 1}, "+4 new urgent, 2 PRs got approved, 1 item you resolved has new activity (reopened in list)"},
+		{Delta{Approved: 1}, "1 PR got approved"},
+		{Delta{Reopened: 3}, "3 items you resolved have new activity (reopened in list)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.delta.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.delta, got, tt.want)
+		}
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	store, err := NewStoreFromPath(filepath.Join(t.TempDir(), "snapshot.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, ok, err := store.Load(); err != nil || ok {
+		t.Fatalf("Load() on a new store: ok = %v, err = %v", ok, err)
+	}
+
+	takenAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	state := State{"a": {Priority: triage.PriorityUrgent, ReviewState: model.ReviewStatePending, UpdatedAt: takenAt}}
+	if err := store.Save(state, takenAt); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	got, gotTakenAt, ok, err := store.Load()
+	if err != nil || !ok {
+		t.Fatalf("Load(): ok = %v, err = %v", ok, err)
+	}
+	if !gotTakenAt.Equal(takenAt) {
+		t.Errorf("takenAt = %v, want %v", gotTakenAt, takenAt)
+	}
+	if e := got["a"]; e.Priority != triage.PriorityUrgent || e.ReviewState != model.ReviewStatePending || !e.UpdatedAt.Equal(takenAt) {
+		t.Errorf("entry = %+v", e)
+	}
+}
+
+func TestScopeFile(t *testing.T) {
+	if got := scopeFile(""); got != storeName {
+		t.Errorf("scopeFile(\"\") = %q, want %q", got, storeName)
+	}
+	repo := scopeFile("repos=acme/api")
+	if repo == storeName || repo != scopeFile("repos=acme/api") {
+		t.Errorf("scopeFile() = %q, want a stable file of its own", repo)
+	}
+	if repo == scopeFile("repos=acme/web") {
+		t.Error("different scopes share a snapshot file")
+	}
+}
+
+func TestCaptureKeysAcrossSources(t *testing.T) {
+	now := time.Now()
+	store, err := resolved.NewStoreFromPath(filepath.Join(t.TempDir(), "resolved.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Resolved when the PR arrived as a notification
+	if err := store.Resolve("acme/api#12", now); err != nil {
+		t.Fatal(err)
+	}
+
+	// This run it arrives as a review request
+	pr := triage.PrioritizedItem{Priority: triage.PriorityUrgent}
+	pr.ID = "review-requested-9001"
+	pr.Number = 12
+	pr.Repository.FullName = "acme/api"
+	pr.UpdatedAt = now.Add(-time.Minute)
+
+	state := Capture([]triage.PrioritizedItem{pr}, store)
+	if got, ok := state["acme/api#12"]; !ok || !got.Resolved || !got.Hidden {
+		t.Errorf("state = %+v, want the PR keyed acme/api#12, resolved and hidden", state)
+	}
+}
+
+func TestStateRekey(t *testing.T) {
+	prev := State{
+		"thread-1":   {Priority: triage.PriorityUrgent},
+		"acme/api#2": {Priority: triage.PriorityFYI},
+		"thread-2":   {Priority: triage.PriorityUrgent},
+		"release-1":  {Priority: triage.PriorityFYI},
+	}
+	prev.Rekey(map[string]string{
+		"thread-1": "acme/api#1",
+		"thread-2": "acme/api#2",
+	})
+
+	want := State{
+		"acme/api#1": {Priority: triage.PriorityUrgent},
+		"acme/api#2": {Priority: triage.PriorityFYI},
+		"release-1":  {Priority: triage.PriorityFYI},
+	}
+	if len(prev) != len(want) {
+		t.Fatalf("after Rekey state = %+v, want %+v", prev, want)
+	}
+	for key, e := range want {
+		if prev[key] != e {
+			t.Errorf("state[%q] = %+v, want %+v", key, prev[key], e)
+		}
+	}
+
+	// Nothing to move in a state loaded from no previous run
+	var empty State
+	empty.Rekey(map[string]string{"thread-1": "acme/api#1"})
+}
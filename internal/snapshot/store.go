@@ -0,0 +1,91 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Version is the current on-disk format of the snapshot store.
+const Version = 1
+
+const storeName = "snapshot.json"
+
+// storeFile is the on-disk layout of the snapshot store.
+type storeFile struct {
+	Version int       `json:"version"`
+	TakenAt time.Time `json:"takenAt"`
+	Entries State     `json:"entries"`
+}
+
+// Store holds the state saved by the previous run.
+type Store struct {
+	path string
+}
+
+// NewStore opens the snapshot store in the user cache directory for runs
+// of scope, which describes what the run fetched. Each scope keeps its own
+// snapshot, so a run narrowed to one repository isn't compared with, or
+// taken as the last of, runs over everything. The empty scope, for
+// unnarrowed runs, is kept in snapshot.json.
+func NewStore(scope string) (*Store, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreFromPath(filepath.Join(cacheDir, "triage", scopeFile(scope)))
+}
+
+// scopeFile returns the file name holding the snapshot of scope.
+func scopeFile(scope string) string {
+	if scope == "" {
+		return storeName
+	}
+	sum := sha256.Sum256([]byte(scope))
+	return "snapshot-" + hex.EncodeToString(sum[:6]) + ".json"
+}
+
+// NewStoreFromPath opens the snapshot store at the given file path.
+func NewStoreFromPath(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return &Store{path: path}, nil
+}
+
+// Load returns the state saved by the previous run and when it was taken.
+// ok is false when there is no previous run.
+func (s *Store) Load() (state State, takenAt time.Time, ok bool, err error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, time.Time{}, false, nil
+		}
+		return nil, time.Time{}, false, err
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, time.Time{}, false, err
+	}
+	if file.Version != Version {
+		return nil, time.Time{}, false, fmt.Errorf("unsupported snapshot version %d", file.Version)
+	}
+	if file.Entries == nil {
+		file.Entries = make(State)
+	}
+	return file.Entries, file.TakenAt, true, nil
+}
+
+// Save replaces the saved state with state, taken at takenAt.
+func (s *Store) Save(state State, takenAt time.Time) error {
+	data, err := json.MarshalIndent(storeFile{Version: Version, TakenAt: takenAt, Entries: state}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
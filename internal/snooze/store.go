@@ -0,0 +1,220 @@
+// Package snooze tracks items the user has set aside until a later time.
+// A snoozed item is hidden until the snooze ends or it has new activity; a
+// reminder brings an item back, with a note, once it is due.
+package snooze
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spiffcs/triage/internal/log"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// Version is the current on-disk format of the snooze store.
+const Version = 1
+
+const storeName = "snooze.json"
+
+// storeFile is the on-disk layout of the snooze store.
+type storeFile struct {
+	Version   int                       `json:"version"`
+	Reminders map[string]model.Reminder `json:"reminders"`
+	Snoozes   map[string]Snooze         `json:"snoozes,omitempty"`
+}
+
+// Snooze hides an item until a set time.
+type Snooze struct {
+	Until time.Time `json:"until"`
+
+	// UpdatedAt is the item's last update when it was snoozed; later
+	// activity ends the snooze early
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Store holds the snoozes and reminders set on items, keyed by
+// model.Item.Key.
+type Store struct {
+	path      string
+	reminders map[string]model.Reminder
+	snoozes   map[string]Snooze
+	mu        sync.RWMutex
+}
+
+// NewStore opens the snooze store in the user cache directory.
+func NewStore() (*Store, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreFromPath(filepath.Join(cacheDir, "triage", storeName))
+}
+
+// NewStoreFromPath opens the snooze store at the given file path.
+func NewStoreFromPath(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		path:      path,
+		reminders: make(map[string]model.Reminder),
+		snoozes:   make(map[string]Snooze),
+	}
+	if err := s.load(); err != nil {
+		log.Debug("could not load snooze store, starting fresh", "error", err)
+	}
+	return s, nil
+}
+
+// load reads the entries from disk
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Version != Version {
+		return fmt.Errorf("unsupported snooze store version %d", file.Version)
+	}
+	if file.Reminders != nil {
+		s.reminders = file.Reminders
+	}
+	if file.Snoozes != nil {
+		s.snoozes = file.Snoozes
+	}
+	return nil
+}
+
+// save writes the entries to disk
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(storeFile{Version: Version, Reminders: s.reminders, Snoozes: s.snoozes}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Snooze hides the item with the given key until until, or until it is
+// updated after updatedAt. Snoozes that have ended are dropped.
+func (s *Store) Snooze(key string, until, updatedAt time.Time) error {
+	return s.SnoozeAll(map[string]time.Time{key: updatedAt}, until)
+}
+
+// SnoozeAll snoozes every item in updatedAt, keyed by item key, until until,
+// writing the store once.
+func (s *Store) SnoozeAll(updatedAt map[string]time.Time, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, sn := range s.snoozes {
+		if !sn.Until.After(now) {
+			delete(s.snoozes, k)
+		}
+	}
+	for key, at := range updatedAt {
+		s.snoozes[key] = Snooze{Until: until, UpdatedAt: at}
+	}
+	return s.save()
+}
+
+// Snoozed reports whether the item with the given key, last updated at
+// currentUpdatedAt, is snoozed at now.
+func (s *Store) Snoozed(key string, currentUpdatedAt, now time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sn, ok := s.snoozes[key]
+	return ok && sn.Until.After(now) && !currentUpdatedAt.After(sn.UpdatedAt)
+}
+
+// ShouldShow returns true unless the item is snoozed now. It lets the store
+// be used with triage.FilterResolved.
+func (s *Store) ShouldShow(key string, currentUpdatedAt time.Time) bool {
+	return !s.Snoozed(key, currentUpdatedAt, time.Now())
+}
+
+// Remind sets a reminder on the item with the given key, replacing any
+// reminder already set on it.
+func (s *Store) Remind(key string, at time.Time, note string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reminders[key] = model.Reminder{At: at, Note: note}
+	return s.save()
+}
+
+// Clear removes the reminder on the item with the given key. It reports
+// whether there was one.
+func (s *Store) Clear(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.reminders[key]; !ok {
+		return false, nil
+	}
+	delete(s.reminders, key)
+	return true, s.save()
+}
+
+// Due returns the reminder on the item with the given key if it is due at now.
+func (s *Store) Due(key string, now time.Time) (model.Reminder, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.reminders[key]
+	if !ok || r.At.After(now) {
+		return model.Reminder{}, false
+	}
+	return r, true
+}
+
+// Entry is a reminder and the key of the item it is set on.
+type Entry struct {
+	Key string
+	model.Reminder
+}
+
+// Reminders returns every reminder, soonest first.
+func (s *Store) Reminders() []Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]Entry, 0, len(s.reminders))
+	for key, r := range s.reminders {
+		entries = append(entries, Entry{Key: key, Reminder: r})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].At.Equal(entries[j].At) {
+			return entries[i].At.Before(entries[j].At)
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+// Apply sets Reminder on each item with a reminder due at now, and clears
+// it on the others. store may be nil.
+func Apply(items []model.Item, store *Store, now time.Time) {
+	for i := range items {
+		items[i].Reminder = nil
+		if store == nil {
+			continue
+		}
+		if r, ok := store.Due(items[i].Key(), now); ok {
+			items[i].Reminder = &r
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package snooze
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestStoreReminders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snooze.json")
+	store, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatalf("NewStoreFromPath() error: %v", err)
+	}
+
+	now := time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)
+	if err := store.Remind("acme/api#12", now.Add(48*time.Hour), "ping after release"); err != nil {
+		t.Fatalf("Remind() error: %v", err)
+	}
+	if err := store.Remind("acme/api#3", now.Add(time.Hour), ""); err != nil {
+		t.Fatalf("Remind() error: %v", err)
+	}
+
+	reopened, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatalf("NewStoreFromPath() error: %v", err)
+	}
+	entries := reopened.Reminders()
+	if len(entries) != 2 || entries[0].Key != "acme/api#3" || entries[1].Note != "ping after release" {
+		t.Fatalf("Reminders() = %+v, want acme/api#3 then acme/api#12 with its note", entries)
+	}
+
+	if _, ok := reopened.Due("acme/api#12", now); ok {
+		t.Error("Due() reported a reminder before its time")
+	}
+	if r, ok := reopened.Due("acme/api#12", now.Add(48*time.Hour)); !ok || r.Note != "ping after release" {
+		t.Errorf("Due() = %+v, %v at the reminder's time; want it with its note", r, ok)
+	}
+
+	if found, err := reopened.Clear("acme/api#12"); err != nil || !found {
+		t.Errorf("Clear() = %v, %v; want true, nil", found, err)
+	}
+	if found, _ := reopened.Clear("acme/api#12"); found {
+		t.Error("Clear() found a reminder already cleared")
+	}
+	if _, ok := reopened.Due("acme/api#12", now.Add(72*time.Hour)); ok {
+		t.Error("Due() reported a cleared reminder")
+	}
+}
+
+func TestApply(t *testing.T) {
+	store, err := NewStoreFromPath(filepath.Join(t.TempDir(), "snooze.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)
+	if err := store.Remind("acme/api#1", now.Add(-time.Minute), "due"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remind("acme/api#2", now.Add(time.Minute), "later"); err != nil {
+		t.Fatal(err)
+	}
+
+	items := []model.Item{
+		{Number: 1, Repository: model.Repository{FullName: "acme/api"}},
+		{Number: 2, Repository: model.Repository{FullName: "acme/api"}},
+		{Number: 3, Repository: model.Repository{FullName: "acme/api"}, Reminder: &model.Reminder{Note: "stale"}},
+	}
+	Apply(items, store, now)
+
+	if items[0].Reminder == nil || items[0].Reminder.Note != "due" {
+		t.Errorf("due reminder = %+v, want the note", items[0].Reminder)
+	}
+	if items[1].Reminder != nil {
+		t.Error("Apply() set a reminder that isn't due")
+	}
+	if items[2].Reminder != nil {
+		t.Error("Apply() kept a reminder the store doesn't have")
+	}
+
+	Apply(items, nil, now)
+	if items[0].Reminder != nil {
+		t.Error("Apply() without a store kept a reminder")
+	}
+}
+
+func TestStoreSnoozes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snooze.json")
+	store, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	updated := now.Add(-time.Hour)
+	if err := store.Snooze("acme/api#12", now.Add(4*time.Hour), updated); err != nil {
+		t.Fatalf("Snooze() error: %v", err)
+	}
+
+	reopened, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		key     string
+		updated time.Time
+		at      time.Time
+		want    bool
+	}{
+		{"snoozed", "acme/api#12", updated, now, true},
+		{"not snoozed", "acme/api#13", updated, now, false},
+		{"snooze ended", "acme/api#12", updated, now.Add(5 * time.Hour), false},
+		{"new activity", "acme/api#12", now, now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reopened.Snoozed(tt.key, tt.updated, tt.at); got != tt.want {
+				t.Errorf("Snoozed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if reopened.ShouldShow("acme/api#12", updated) {
+		t.Error("ShouldShow() = true for a snoozed item")
+	}
+
+	// Ended snoozes are dropped on the next write
+	if err := reopened.Snooze("acme/api#1", now.Add(-time.Minute), updated); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Snooze("acme/api#2", now.Add(time.Hour), updated); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reopened.snoozes["acme/api#1"]; ok {
+		t.Error("Snooze() kept a snooze that had ended")
+	}
+}
+
+func TestStoreSnoozeAll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snooze.json")
+	store, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	older := now.Add(-2 * time.Hour)
+	newer := now.Add(-time.Hour)
+	err = store.SnoozeAll(map[string]time.Time{
+		"acme/api#1": older,
+		"acme/api#2": newer,
+	}, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SnoozeAll() error = %v", err)
+	}
+
+	reopened, err := NewStoreFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reopened.Snoozed("acme/api#1", older, now) || !reopened.Snoozed("acme/api#2", newer, now) {
+		t.Error("SnoozeAll() did not snooze every item")
+	}
+	// Each item keeps its own timestamp
+	if reopened.Snoozed("acme/api#1", newer, now) {
+		t.Error("acme/api#1 still snoozed after an update")
+	}
+}
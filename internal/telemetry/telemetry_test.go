@@ -0,0 +1,127 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"nothing set", nil, false},
+		{"endpoint", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, true},
+		{"traces endpoint", map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:4318/v1/traces"}, true},
+		{
+			"sdk disabled",
+			map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318", "OTEL_SDK_DISABLED": "TRUE"},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_SDK_DISABLED"} {
+				t.Setenv(key, tt.env[key])
+			}
+			if got := Enabled(); got != tt.want {
+				t.Errorf("Enabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetupDisabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	before := otel.GetTracerProvider()
+	shutdown, err := Setup(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+	if otel.GetTracerProvider() != before {
+		t.Error("Setup() installed a tracer provider without an endpoint")
+	}
+}
+
+func TestSetupExports(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", srv.URL)
+	t.Setenv("OTEL_SDK_DISABLED", "")
+
+	before := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(before) })
+	shutdown, err := Setup(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	_, span := Start(context.Background(), "triage list")
+	End(span, nil)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 1 || paths[0] != "/v1/traces" {
+		t.Errorf("exported to %v, want one request to /v1/traces", paths)
+	}
+}
+
+func TestStartAndEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	before := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(before) })
+
+	ctx, parent := Start(context.Background(), "triage list")
+	_, ok := Start(ctx, "fetch notifications", attribute.String("triage.source", "notifications"))
+	End(ok, nil)
+	_, failed := Start(ctx, "graphql batch")
+	End(failed, errors.New("boom"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("ended %d spans, want 3", len(spans))
+	}
+	fetch, batch, root := spans[0], spans[1], spans[2]
+	if fetch.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Error("child span is not parented to the root span")
+	}
+	if got := fetch.Attributes(); len(got) != 1 || got[0].Value.AsString() != "notifications" {
+		t.Errorf("fetch attributes = %v", got)
+	}
+	if fetch.Status().Code != codes.Unset {
+		t.Errorf("successful span status = %v", fetch.Status())
+	}
+	if batch.Status().Code != codes.Error || batch.Status().Description != "boom" {
+		t.Errorf("failed span status = %v, want error boom", batch.Status())
+	}
+	if len(batch.Events()) != 1 {
+		t.Errorf("failed span recorded %d events, want the error", len(batch.Events()))
+	}
+}
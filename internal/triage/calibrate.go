@@ -0,0 +1,146 @@
+package triage
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spiffcs/triage/config"
+)
+
+const (
+	// minCalibrationItems is the fewest items a threshold is suggested from;
+	// smaller samples say more about the week than about the weights.
+	minCalibrationItems = 10
+
+	// urgentShareLimit is the share of items in Urgent past which Urgent
+	// stops meaning anything, and urgentShareTarget the share a suggested
+	// important_promotion_threshold aims for.
+	urgentShareLimit  = 50
+	urgentShareTarget = 20
+
+	// fyiShareLimit is the share of items left in FYI past which too little
+	// is promoted, and promotedShareTarget the share a suggested
+	// fyi_promotion_threshold lifts to Notable or above.
+	fyiShareLimit       = 80
+	promotedShareTarget = 30
+)
+
+// Calibration describes how a set of scored items spreads across the
+// priority levels and where the promotion thresholds fall among them.
+type Calibration struct {
+	Total int
+
+	// Counts holds the number of items at each priority level
+	Counts map[PriorityLevel]int
+
+	// Median, P90, and Max summarize the raw scores
+	Median int
+	P90    int
+	Max    int
+
+	Thresholds  []ThresholdPosition
+	Suggestions []ThresholdSuggestion
+}
+
+// ThresholdPosition is a promotion threshold and how many items score at
+// or above it.
+type ThresholdPosition struct {
+	Key   string // Config key under scoring
+	Value int
+	Above int
+}
+
+// ThresholdSuggestion proposes a new value for a promotion threshold.
+type ThresholdSuggestion struct {
+	Key       string
+	Current   int
+	Suggested int
+	Reason    string
+}
+
+// Share returns n as a whole percentage of the calibrated items.
+func (c Calibration) Share(n int) int {
+	if c.Total == 0 {
+		return 0
+	}
+	return n * 100 / c.Total
+}
+
+// Calibrate reports the distribution of items scored with weights, and
+// suggests promotion thresholds when too many items land in Urgent or too
+// few rise above FYI. Scores must be raw, not normalized.
+func Calibrate(items []PrioritizedItem, weights config.ScoreWeights) Calibration {
+	cal := Calibration{
+		Total:  len(items),
+		Counts: make(map[PriorityLevel]int),
+	}
+	if len(items) == 0 {
+		return cal
+	}
+
+	// Scores from highest to lowest
+	scores := make([]int, len(items))
+	for i, item := range items {
+		scores[i] = item.Score
+		cal.Counts[item.Priority]++
+	}
+	slices.Sort(scores)
+	slices.Reverse(scores)
+	cal.Max = scores[0]
+	cal.Median = scores[len(scores)/2]
+	cal.P90 = scores[len(scores)/10]
+
+	for _, t := range []struct {
+		key   string
+		value int
+	}{
+		{"fyi_promotion_threshold", weights.FYIPromotionThreshold},
+		{"notable_promotion_threshold", weights.NotablePromotionThreshold},
+		{"important_promotion_threshold", weights.ImportantPromotionThreshold},
+	} {
+		cal.Thresholds = append(cal.Thresholds, ThresholdPosition{Key: t.key, Value: t.value, Above: countAtLeast(scores, t.value)})
+	}
+
+	if cal.Total < minCalibrationItems {
+		return cal
+	}
+
+	if urgent := cal.Share(cal.Counts[PriorityUrgent]); urgent > urgentShareLimit {
+		// The lowest threshold that at most the target share scores at or above
+		suggested := scores[cal.Total*urgentShareTarget/100] + 1
+		if suggested > weights.ImportantPromotionThreshold {
+			cal.Suggestions = append(cal.Suggestions, ThresholdSuggestion{
+				Key:       "important_promotion_threshold",
+				Current:   weights.ImportantPromotionThreshold,
+				Suggested: suggested,
+				Reason:    fmt.Sprintf("%d%% of items are Urgent; this keeps about %d%% there by score", urgent, urgentShareTarget),
+			})
+		}
+	}
+
+	if fyi := cal.Share(cal.Counts[PriorityFYI]); fyi > fyiShareLimit {
+		suggested := scores[cal.Total*promotedShareTarget/100]
+		if suggested > 0 && suggested < weights.FYIPromotionThreshold {
+			cal.Suggestions = append(cal.Suggestions, ThresholdSuggestion{
+				Key:       "fyi_promotion_threshold",
+				Current:   weights.FYIPromotionThreshold,
+				Suggested: suggested,
+				Reason:    fmt.Sprintf("%d%% of items are FYI; this promotes about %d%% to Notable or above", fyi, promotedShareTarget),
+			})
+		}
+	}
+
+	return cal
+}
+
+// countAtLeast counts the scores, sorted highest first, at or above threshold.
+func countAtLeast(scores []int, threshold int) int {
+	n := 0
+	for _, s := range scores {
+		if s < threshold {
+			break
+		}
+		n++
+	}
+	return n
+}
@@ -0,0 +1,93 @@
+package triage
+
+import (
+	"testing"
+
+	"github.com/spiffcs/triage/config"
+)
+
+// scoredItems returns one item per score, all at the given priority.
+func scoredItems(priority PriorityLevel, scores ...int) []PrioritizedItem {
+	items := make([]PrioritizedItem, len(scores))
+	for i, s := range scores {
+		items[i] = PrioritizedItem{Score: s, Priority: priority}
+	}
+	return items
+}
+
+func TestCalibrate(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+
+	tests := []struct {
+		name      string
+		items     []PrioritizedItem
+		wantKey   string
+		wantValue int
+	}{
+		{
+			name: "balanced",
+			items: append(append(scoredItems(PriorityUrgent, 120, 110),
+				scoredItems(PriorityNotable, 50, 40, 36)...),
+				scoredItems(PriorityFYI, 30, 20, 10, 10, 10)...),
+		},
+		{
+			name: "mostly urgent",
+			items: append(scoredItems(PriorityUrgent, 300, 250, 200, 180, 160, 150, 140, 120, 110),
+				scoredItems(PriorityFYI, 10)...),
+			wantKey:   "important_promotion_threshold",
+			wantValue: 201,
+		},
+		{
+			name:      "mostly FYI",
+			items:     append(scoredItems(PriorityFYI, 30, 25, 20, 15, 10, 10, 10, 10, 10), scoredItems(PriorityNotable, 40)...),
+			wantKey:   "fyi_promotion_threshold",
+			wantValue: 20,
+		},
+		{
+			name:  "too few items to suggest",
+			items: scoredItems(PriorityUrgent, 300, 250, 200),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := Calibrate(tt.items, weights)
+			if cal.Total != len(tt.items) {
+				t.Errorf("Total = %d, want %d", cal.Total, len(tt.items))
+			}
+			if len(cal.Thresholds) != 3 {
+				t.Errorf("got %d thresholds, want 3", len(cal.Thresholds))
+			}
+			if tt.wantKey == "" {
+				if len(cal.Suggestions) != 0 {
+					t.Errorf("Suggestions = %+v, want none", cal.Suggestions)
+				}
+				return
+			}
+			if len(cal.Suggestions) != 1 {
+				t.Fatalf("Suggestions = %+v, want one for %s", cal.Suggestions, tt.wantKey)
+			}
+			if s := cal.Suggestions[0]; s.Key != tt.wantKey || s.Suggested != tt.wantValue {
+				t.Errorf("suggestion = %s: %d, want %s: %d", s.Key, s.Suggested, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestCalibrateThresholdPositions(t *testing.T) {
+	cal := Calibrate(scoredItems(PriorityFYI, 100, 60, 59, 35, 0), config.DefaultScoreWeights())
+
+	want := map[string]int{
+		"fyi_promotion_threshold":       4,
+		"notable_promotion_threshold":   2,
+		"important_promotion_threshold": 1,
+	}
+	for _, th := range cal.Thresholds {
+		if th.Above != want[th.Key] {
+			t.Errorf("%s: %d items above, want %d", th.Key, th.Above, want[th.Key])
+		}
+	}
+	if cal.Max != 100 || cal.Median != 59 {
+		t.Errorf("Max = %d, Median = %d, want 100 and 59", cal.Max, cal.Median)
+	}
+}
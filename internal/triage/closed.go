@@ -0,0 +1,54 @@
+package triage
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// ClosedAt returns when the item was closed or merged, or nil if it is
+// open or the time is unknown.
+func ClosedAt(n *model.Item) *time.Time {
+	if n.State != model.StateClosed && n.State != model.StateMerged {
+		return nil
+	}
+	if n.ClosedAt != nil {
+		return n.ClosedAt
+	}
+	if pr := n.PRDetails(); pr != nil {
+		return pr.MergedAt
+	}
+	return nil
+}
+
+// ClosedSince returns the items closed or merged after since that
+// currentUser was involved in, most recently closed first. Being involved
+// means authoring, being assigned, having commented or reviewed, or being
+// notified for any reason other than watching the repository.
+func ClosedSince(items []model.Item, currentUser string, since time.Time) []model.Item {
+	var closed []model.Item
+	for _, n := range items {
+		at := ClosedAt(&n)
+		if at == nil || at.Before(since) || !involved(&n, currentUser) {
+			continue
+		}
+		closed = append(closed, n)
+	}
+	sort.SliceStable(closed, func(i, j int) bool {
+		return ClosedAt(&closed[i]).After(*ClosedAt(&closed[j]))
+	})
+	return closed
+}
+
+// involved reports whether currentUser took part in the item rather than
+// only watching it.
+func involved(n *model.Item, currentUser string) bool {
+	switch n.Reason {
+	case model.ReasonSubscribed, model.ReasonCIActivity, model.ReasonStateChange:
+	default:
+		return true
+	}
+	return strings.EqualFold(n.Author, currentUser) || n.LastInteractionAt != nil || isAssignedTo(n, currentUser)
+}
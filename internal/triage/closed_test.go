@@ -0,0 +1,39 @@
+package triage
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestClosedSince(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+	day := 24 * time.Hour
+
+	items := []model.Item{
+		{ID: "merged", Reason: model.ReasonReviewRequested, State: model.StateMerged, ClosedAt: ago(2 * day)},
+		{ID: "closed", Reason: model.ReasonAuthor, State: model.StateClosed, ClosedAt: ago(day)},
+		{ID: "merged without closedAt", Reason: model.ReasonMention, State: model.StateMerged, Type: model.ItemTypePullRequest,
+			Details: &model.PRDetails{Merged: true, MergedAt: ago(3 * day)}},
+		{ID: "too old", Reason: model.ReasonAuthor, State: model.StateClosed, ClosedAt: ago(10 * day)},
+		{ID: "open", Reason: model.ReasonAuthor, State: model.StateOpen},
+		{ID: "only watching", Reason: model.ReasonSubscribed, State: model.StateClosed, ClosedAt: ago(day)},
+		{ID: "commented while watching", Reason: model.ReasonSubscribed, State: model.StateClosed, ClosedAt: ago(4 * day), LastInteractionAt: ago(5 * day)},
+		{ID: "assigned", Reason: model.ReasonStateChange, State: model.StateClosed, ClosedAt: ago(5 * day), Assignees: []string{"TestUser"}},
+	}
+
+	var got []string
+	for _, n := range ClosedSince(items, "testuser", now.Add(-7*day)) {
+		got = append(got, n.ID)
+	}
+	want := []string{"closed", "merged", "merged without closedAt", "commented while watching", "assigned"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ClosedSince() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package triage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// DefaultBurstWindow is how close together one person's updates to a
+// repository must be to count as a single burst.
+const DefaultBurstWindow = time.Hour
+
+// ClusterOptions configures how Cluster groups related items.
+type ClusterOptions struct {
+	// CurrentUser's own activity never forms a burst.
+	CurrentUser string
+	// BurstWindow is the largest gap between updates in one burst;
+	// DefaultBurstWindow when zero.
+	BurstWindow time.Duration
+}
+
+// Cluster groups related items: pull requests with the issues they close,
+// and bursts of activity by one person in one repository, such as a run of
+// issues filed or commented on within the hour. It returns the group key
+// of every item that has a relative in items; items on their own are left
+// out. Keys are the ID of the group's first item.
+func Cluster(items []PrioritizedItem, opts ClusterOptions) map[string]string {
+	window := opts.BurstWindow
+	if window <= 0 {
+		window = DefaultBurstWindow
+	}
+
+	parent := make([]int, len(items))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) {
+		ra, rb := find(a), find(b)
+		// The earlier item becomes the root, so keys are stable
+		if rb < ra {
+			ra, rb = rb, ra
+		}
+		parent[rb] = ra
+	}
+
+	// Pull requests and the issues they close
+	byRef := make(map[string]int, len(items))
+	for i, item := range items {
+		if item.Number > 0 {
+			byRef[itemRef(item.Repository.FullName, item.Number)] = i
+		}
+	}
+	for i, item := range items {
+		pr := item.PRDetails()
+		if pr == nil {
+			continue
+		}
+		for _, ref := range pr.LinkedIssues {
+			if j, ok := byRef[strings.ToLower(ref)]; ok {
+				union(i, j)
+			}
+		}
+	}
+
+	// Bursts: runs of updates by the same person in the same repository
+	type update struct {
+		index int
+		at    time.Time
+	}
+	bursts := make(map[string][]update)
+	for i, item := range items {
+		who, at := lastActor(item.Item)
+		if who == "" || strings.EqualFold(who, opts.CurrentUser) {
+			continue
+		}
+		key := strings.ToLower(item.Repository.FullName) + " " + strings.ToLower(who)
+		bursts[key] = append(bursts[key], update{i, at})
+	}
+	for _, updates := range bursts {
+		sort.Slice(updates, func(a, b int) bool { return updates[a].at.Before(updates[b].at) })
+		for k := 1; k < len(updates); k++ {
+			if updates[k].at.Sub(updates[k-1].at) <= window {
+				union(updates[k-1].index, updates[k].index)
+			}
+		}
+	}
+
+	size := make(map[int]int)
+	for i := range items {
+		size[find(i)]++
+	}
+	groups := make(map[string]string)
+	for i, item := range items {
+		if root := find(i); size[root] > 1 {
+			groups[item.ID] = items[root].ID
+		}
+	}
+	return groups
+}
+
+// itemRef returns the "owner/repo#123" reference of an issue or pull
+// request, lowercased for matching.
+func itemRef(fullName string, number int) string {
+	return strings.ToLower(fmt.Sprintf("%s#%d", fullName, number))
+}
+
+// lastActor returns who last commented on, reviewed, or pushed to the
+// item and when, falling back to its author when enrichment saw no
+// activity. The login is empty when neither is known.
+func lastActor(n model.Item) (string, time.Time) {
+	var who string
+	var at time.Time
+	for login, t := range n.ActivityBy {
+		// Break ties by login so the result doesn't depend on map order
+		if t.After(at) || (t.Equal(at) && login < who) {
+			who, at = login, t
+		}
+	}
+	if who == "" && n.Author != "" && !n.CreatedAt.IsZero() {
+		return n.Author, n.CreatedAt
+	}
+	return who, at
+}
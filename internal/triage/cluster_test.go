@@ -0,0 +1,106 @@
+package triage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestCluster(t *testing.T) {
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	issue := func(id, repo string, number int) PrioritizedItem {
+		return PrioritizedItem{Item: model.Item{
+			ID: id, Number: number, Type: model.ItemTypeIssue,
+			Repository: model.Repository{FullName: repo},
+		}}
+	}
+	pr := func(id, repo string, number int, linked ...string) PrioritizedItem {
+		item := issue(id, repo, number)
+		item.Type = model.ItemTypePullRequest
+		item.Details = &model.PRDetails{LinkedIssues: linked}
+		return item
+	}
+	by := func(item PrioritizedItem, login string, after time.Duration) PrioritizedItem {
+		item.ActivityBy = map[string]time.Time{login: base.Add(after)}
+		return item
+	}
+
+	tests := []struct {
+		name  string
+		items []PrioritizedItem
+		want  map[string]string
+	}{
+		{
+			name: "pull request with the issue it closes",
+			items: []PrioritizedItem{
+				issue("a", "acme/api", 12),
+				pr("b", "acme/api", 13, "Acme/API#12"),
+				issue("c", "acme/api", 14),
+			},
+			want: map[string]string{"a": "a", "b": "a"},
+		},
+		{
+			name: "linked issue in another repository",
+			items: []PrioritizedItem{
+				pr("a", "acme/web", 5, "acme/api#12"),
+				issue("b", "acme/api", 12),
+			},
+			want: map[string]string{"a": "a", "b": "a"},
+		},
+		{
+			name: "burst by one person in one repository",
+			items: []PrioritizedItem{
+				by(issue("a", "acme/api", 1), "alice", 0),
+				by(issue("b", "acme/api", 2), "alice", 40*time.Minute),
+				by(issue("c", "acme/api", 3), "alice", 80*time.Minute),
+				by(issue("d", "acme/api", 4), "alice", 5*time.Hour),
+			},
+			want: map[string]string{"a": "a", "b": "a", "c": "a"},
+		},
+		{
+			name: "different people or repositories are not a burst",
+			items: []PrioritizedItem{
+				by(issue("a", "acme/api", 1), "alice", 0),
+				by(issue("b", "acme/api", 2), "bob", time.Minute),
+				by(issue("c", "acme/web", 3), "alice", time.Minute),
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "your own activity is not a burst",
+			items: []PrioritizedItem{
+				by(issue("a", "acme/api", 1), "Me", 0),
+				by(issue("b", "acme/api", 2), "me", time.Minute),
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "author stands in without activity",
+			items: []PrioritizedItem{
+				{Item: model.Item{ID: "a", Author: "alice", CreatedAt: base, Repository: model.Repository{FullName: "acme/api"}}},
+				{Item: model.Item{ID: "b", Author: "alice", CreatedAt: base.Add(time.Minute), Repository: model.Repository{FullName: "acme/api"}}},
+			},
+			want: map[string]string{"a": "a", "b": "a"},
+		},
+		{
+			name: "links and bursts merge into one group",
+			items: []PrioritizedItem{
+				by(issue("a", "acme/api", 1), "alice", 0),
+				pr("b", "acme/api", 2, "acme/api#3"),
+				by(issue("c", "acme/api", 3), "alice", time.Minute),
+			},
+			want: map[string]string{"a": "a", "b": "a", "c": "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Cluster(tt.items, ClusterOptions{CurrentUser: "me"})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cluster() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
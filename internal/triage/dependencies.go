@@ -0,0 +1,46 @@
+package triage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// dependents maps the key of each item blocking others among items to the
+// items it blocks, as "owner/repo#123" in the order listed. Blockers
+// outside items aren't mapped: nothing listed waits on them.
+func dependents(items []model.Item) map[string][]string {
+	blocks := make(map[string][]string)
+	for i := range items {
+		n := &items[i]
+		if len(n.BlockedBy) == 0 {
+			continue
+		}
+		ref := n.Key()
+		if n.Number > 0 {
+			ref = fmt.Sprintf("%s#%d", n.Repository.FullName, n.Number)
+		}
+		for _, blocker := range n.BlockedBy {
+			key := strings.ToLower(blocker)
+			if !slices.Contains(blocks[key], ref) {
+				blocks[key] = append(blocks[key], ref)
+			}
+		}
+	}
+	return blocks
+}
+
+// linkDependents sets the Blocks of each item to the items among them it
+// blocks, so the base of a chain of dependent work is raised while the rest
+// wait on it.
+func linkDependents(items []model.Item) []model.Item {
+	blocks := dependents(items)
+	linked := make([]model.Item, len(items))
+	for i, n := range items {
+		n.Blocks = blocks[n.Key()]
+		linked[i] = n
+	}
+	return linked
+}
@@ -0,0 +1,127 @@
+package triage
+
+import (
+	"path"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// diffKind is what a changed file is, judged from its path alone.
+type diffKind int
+
+const (
+	diffKindCode diffKind = iota
+	diffKindDocs
+	diffKindTests
+	diffKindDependencies
+)
+
+// dependencyFiles are manifests and lockfiles that dependency bumps change.
+var dependencyFiles = map[string]bool{
+	"go.mod":              true,
+	"go.sum":              true,
+	"package.json":        true,
+	"package-lock.json":   true,
+	"npm-shrinkwrap.json": true,
+	"yarn.lock":           true,
+	"pnpm-lock.yaml":      true,
+	"bun.lockb":           true,
+	"cargo.toml":          true,
+	"cargo.lock":          true,
+	"gemfile":             true,
+	"gemfile.lock":        true,
+	"pipfile":             true,
+	"pipfile.lock":        true,
+	"poetry.lock":         true,
+	"uv.lock":             true,
+	"pyproject.toml":      true,
+	"composer.json":       true,
+	"composer.lock":       true,
+	"pom.xml":             true,
+	"build.gradle":        true,
+	"build.gradle.kts":    true,
+	"gradle.lockfile":     true,
+	"mix.exs":             true,
+	"mix.lock":            true,
+	"package.swift":       true,
+	"package.resolved":    true,
+	"podfile.lock":        true,
+	"pubspec.yaml":        true,
+	"pubspec.lock":        true,
+}
+
+// docExtensions are prose formats.
+var docExtensions = map[string]bool{
+	".md":       true,
+	".mdx":      true,
+	".markdown": true,
+	".rst":      true,
+	".adoc":     true,
+	".txt":      true,
+}
+
+// docFiles are conventional project documents that may have no extension.
+var docFiles = map[string]bool{
+	"license":      true,
+	"notice":       true,
+	"authors":      true,
+	"contributors": true,
+	"changelog":    true,
+}
+
+// classifyPath reports what kind of change touching p is. Dependency files
+// are checked first so a requirements.txt isn't mistaken for prose.
+func classifyPath(p string) diffKind {
+	p = strings.ToLower(p)
+	base := path.Base(p)
+	dirs := strings.Split(path.Dir(p), "/")
+
+	if dependencyFiles[base] || (strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt")) {
+		return diffKindDependencies
+	}
+	for _, d := range dirs {
+		if d == "vendor" || d == "node_modules" {
+			return diffKindDependencies
+		}
+	}
+
+	ext := path.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	if strings.HasSuffix(stem, "_test") || strings.HasSuffix(stem, ".test") || strings.HasSuffix(stem, ".spec") ||
+		(ext == ".py" && strings.HasPrefix(stem, "test_")) {
+		return diffKindTests
+	}
+	for _, d := range dirs {
+		switch d {
+		case "test", "tests", "__tests__", "spec", "testdata":
+			return diffKindTests
+		}
+	}
+
+	if docExtensions[ext] || docFiles[stem] {
+		return diffKindDocs
+	}
+	for _, d := range dirs {
+		if d == "docs" || d == "doc" {
+			return diffKindDocs
+		}
+	}
+
+	return diffKindCode
+}
+
+// prDiffKind reports the kind every file in the PR shares, or
+// diffKindCode when they differ or not every path was fetched.
+func prDiffKind(pr *model.PRDetails) diffKind {
+	if len(pr.Files) == 0 || len(pr.Files) != pr.ChangedFiles {
+		return diffKindCode
+	}
+	kind := classifyPath(pr.Files[0])
+	for _, f := range pr.Files[1:] {
+		if classifyPath(f) != kind {
+			return diffKindCode
+		}
+	}
+	return kind
+}
@@ -0,0 +1,63 @@
+package triage
+
+import (
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestClassifyPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want diffKind
+	}{
+		{"README.md", diffKindDocs},
+		{"docs/guide/setup.html", diffKindDocs},
+		{"LICENSE", diffKindDocs},
+		{"CHANGELOG.rst", diffKindDocs},
+		{"internal/triage/heuristics_test.go", diffKindTests},
+		{"src/components/Button.test.tsx", diffKindTests},
+		{"spec/models/user_spec.rb", diffKindTests},
+		{"tests/test_parser.py", diffKindTests},
+		{"internal/ghclient/testdata/pr.json", diffKindTests},
+		{"go.mod", diffKindDependencies},
+		{"web/package-lock.json", diffKindDependencies},
+		{"requirements-dev.txt", diffKindDependencies},
+		{"Cargo.lock", diffKindDependencies},
+		{"vendor/github.com/acme/lib/lib.go", diffKindDependencies},
+		{"internal/triage/heuristics.go", diffKindCode},
+		{"Makefile", diffKindCode},
+		{".github/workflows/ci.yaml", diffKindCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := classifyPath(tt.path); got != tt.want {
+				t.Errorf("classifyPath(%q) = %d, want %d", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPRDiffKind(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   *model.PRDetails
+		want diffKind
+	}{
+		{"docs only", &model.PRDetails{ChangedFiles: 2, Files: []string{"README.md", "docs/usage.md"}}, diffKindDocs},
+		{"dependency bump", &model.PRDetails{ChangedFiles: 2, Files: []string{"go.mod", "go.sum"}}, diffKindDependencies},
+		{"docs and code", &model.PRDetails{ChangedFiles: 2, Files: []string{"README.md", "main.go"}}, diffKindCode},
+		{"docs and tests", &model.PRDetails{ChangedFiles: 2, Files: []string{"README.md", "main_test.go"}}, diffKindCode},
+		{"more files than fetched", &model.PRDetails{ChangedFiles: 150, Files: []string{"README.md"}}, diffKindCode},
+		{"files not fetched", &model.PRDetails{ChangedFiles: 3}, diffKindCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prDiffKind(tt.pr); got != tt.want {
+				t.Errorf("prDiffKind() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package triage
 
 import (
+	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/spiffcs/triage/config"
@@ -13,48 +16,95 @@ type Engine struct {
 	heuristics *Heuristics
 }
 
+// EngineOption configures an Engine.
+type EngineOption func(*Engine)
+
+// WithTeams sets the teams the current user belongs to, as "org/team-slug",
+// so PRs asking one of them for a review score higher.
+func WithTeams(teams []string) EngineOption {
+	return func(e *Engine) {
+		e.heuristics.Teams = teams
+		for _, h := range e.heuristics.repos {
+			h.Teams = teams
+		}
+	}
+}
+
 // NewEngine creates a new priority engine with the given weights and labels
-func NewEngine(currentUser string, weights config.ScoreWeights, quickWinLabels []string) *Engine {
-	return &Engine{
+func NewEngine(currentUser string, weights config.ScoreWeights, quickWinLabels []string, opts ...EngineOption) *Engine {
+	e := &Engine{
 		heuristics: NewHeuristics(currentUser, weights, quickWinLabels),
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
-// Prioritize scores and sorts notifications by priority
+// Prioritize scores and sorts notifications by priority. Items blocking
+// others among them are linked to those first (see model.Item.Blocks).
 func (e *Engine) Prioritize(items []model.Item) []PrioritizedItem {
 	pItems := make([]PrioritizedItem, 0, len(items))
 
-	for _, n := range items {
-		score := e.heuristics.Score(&n)
-		priority := e.heuristics.Priority(&n, score)
-		action := e.heuristics.Action(&n)
-
-		pItems = append(pItems, PrioritizedItem{
-			Item:         n,
-			Score:        score,
-			Priority:     priority,
-			ActionNeeded: action,
-		})
+	for _, n := range linkDependents(items) {
+		pItems = append(pItems, e.prioritize(n))
 	}
+	SortByPriority(pItems)
 
-	// Sort by priority first, then by score descending within each priority
-	priorityOrder := map[PriorityLevel]int{
-		PriorityUrgent:    0,
-		PriorityImportant: 1,
-		PriorityQuickWin:  2,
-		PriorityNotable:   3,
-		PriorityFYI:       4,
+	return pItems
+}
+
+// Rescore scores already prioritized items again, such as after the
+// weights changed, keeping whether they resurfaced, and sorts them as
+// Prioritize does.
+func (e *Engine) Rescore(items []PrioritizedItem) []PrioritizedItem {
+	plain := make([]model.Item, len(items))
+	for i, item := range items {
+		plain[i] = item.Item
+	}
+	rescored := make([]PrioritizedItem, 0, len(items))
+	for i, n := range linkDependents(plain) {
+		p := e.prioritize(n)
+		p.Resurfaced = items[i].Resurfaced
+		rescored = append(rescored, p)
 	}
+	SortByPriority(rescored)
 
-	sort.Slice(pItems, func(i, j int) bool {
-		pi, pj := priorityOrder[pItems[i].Priority], priorityOrder[pItems[j].Priority]
+	return rescored
+}
+
+// SortByPriority sorts items by priority first, then by score descending
+// within each priority.
+func SortByPriority(items []PrioritizedItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		pi, pj := items[i].Priority.Rank(), items[j].Priority.Rank()
 		if pi != pj {
 			return pi < pj
 		}
-		return pItems[i].Score > pItems[j].Score
+		return items[i].Score > items[j].Score
 	})
+}
 
-	return pItems
+// prioritize scores a single item.
+func (e *Engine) prioritize(n model.Item) PrioritizedItem {
+	h := e.heuristics.ForItem(&n)
+	score := h.Score(&n)
+	priority := h.Priority(&n, score)
+	// Severity labels raise the priority the score gave
+	if floor, ok := h.severityFloor(&n); ok && floor.Rank() < priority.Rank() {
+		priority = floor
+	}
+	action := h.Action(&n)
+	if h.Weights.NormalizeScores {
+		score = h.NormalizeScore(score)
+	}
+
+	return PrioritizedItem{
+		Item:         n,
+		Score:        score,
+		Priority:     priority,
+		ActionNeeded: action,
+	}
 }
 
 // FilterByPriority filters items by a specific priority level
@@ -68,26 +118,71 @@ func FilterByPriority(items []PrioritizedItem, targetPriority PriorityLevel) []P
 	return filtered
 }
 
-// FilterByReason filters items by notification reason
-func FilterByReason(items []PrioritizedItem, reasons []model.ItemReason) []PrioritizedItem {
-	if len(reasons) == 0 {
-		return items
+// FilterByMinPriority keeps items at or above the given priority level.
+func FilterByMinPriority(items []PrioritizedItem, minPriority PriorityLevel) []PrioritizedItem {
+	filtered := make([]PrioritizedItem, 0, len(items))
+	for _, item := range items {
+		if item.Priority.Rank() <= minPriority.Rank() {
+			filtered = append(filtered, item)
+		}
 	}
+	return filtered
+}
 
-	reasonSet := make(map[model.ItemReason]bool, len(reasons))
-	for _, r := range reasons {
-		reasonSet[r] = true
+// FilterByReason keeps items whose reason is in include (or every item when
+// include is empty), then drops those whose reason is in exclude.
+func FilterByReason(items []PrioritizedItem, include, exclude []model.ItemReason) []PrioritizedItem {
+	if len(include) == 0 && len(exclude) == 0 {
+		return items
 	}
 
 	filtered := make([]PrioritizedItem, 0, len(items))
 	for _, item := range items {
-		if reasonSet[item.Reason] {
-			filtered = append(filtered, item)
+		if len(include) > 0 && !slices.Contains(include, item.Reason) {
+			continue
+		}
+		if slices.Contains(exclude, item.Reason) {
+			continue
 		}
+		filtered = append(filtered, item)
 	}
 	return filtered
 }
 
+// ParseReasons parses reason filter values such as "review_requested,mention"
+// or "!subscribed". Each value may hold several comma-separated reasons; a
+// leading "!" excludes the reason instead of including it.
+func ParseReasons(values []string) (include, exclude []model.ItemReason, err error) {
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			negated := strings.HasPrefix(part, "!")
+			reason := model.ItemReason(strings.ToLower(strings.TrimSpace(strings.TrimPrefix(part, "!"))))
+			if !slices.Contains(model.AllItemReasons, reason) {
+				return nil, nil, fmt.Errorf("unknown reason %q (valid reasons: %s)", reason, validReasons())
+			}
+			if negated {
+				exclude = append(exclude, reason)
+			} else {
+				include = append(include, reason)
+			}
+		}
+	}
+	return include, exclude, nil
+}
+
+// validReasons lists the reasons accepted by ParseReasons.
+func validReasons() string {
+	names := make([]string, len(model.AllItemReasons))
+	for i, r := range model.AllItemReasons {
+		names[i] = string(r)
+	}
+	return strings.Join(names, ", ")
+}
+
 // FilterOutMerged removes notifications for merged PRs
 func FilterOutMerged(items []PrioritizedItem) []PrioritizedItem {
 	filtered := make([]PrioritizedItem, 0, len(items))
@@ -114,6 +209,12 @@ func FilterOutClosed(items []PrioritizedItem) []PrioritizedItem {
 	return filtered
 }
 
+// FilterOutArchived removes items in the Archive priority
+func FilterOutArchived(items []PrioritizedItem) []PrioritizedItem {
+	kept, _ := Exclude(items, Archived)
+	return kept
+}
+
 // FilterByType filters items by subject type (pr, issue)
 func FilterByType(items []PrioritizedItem, subjectType model.SubjectType) []PrioritizedItem {
 	filtered := make([]PrioritizedItem, 0, len(items))
@@ -142,22 +243,50 @@ func FilterByRepo(items []PrioritizedItem, repo string) []PrioritizedItem {
 
 // ResolvedChecker is an interface for checking if items should be shown
 type ResolvedChecker interface {
-	ShouldShow(notificationID string, currentUpdatedAt time.Time) bool
+	ShouldShow(key string, currentUpdatedAt time.Time) bool
 }
 
-// FilterResolved filters out items that have been resolved and haven't had new activity
+// FilterResolved filters out items that have been resolved and haven't had new activity.
+// Items with a due reminder are kept.
 func FilterResolved(items []PrioritizedItem, store ResolvedChecker) []PrioritizedItem {
 	if store == nil {
 		return items
 	}
+	kept, _ := Exclude(items, Hidden(store, "resolved"))
+	return kept
+}
 
-	filtered := make([]PrioritizedItem, 0, len(items))
-	for _, item := range items {
-		if store.ShouldShow(item.ID, item.UpdatedAt) {
-			filtered = append(filtered, item)
+// ResurfaceChecker reports which items were marked resolved as well as
+// whether they should be shown.
+type ResurfaceChecker interface {
+	ResolvedChecker
+	IsResolved(key string) bool
+}
+
+// MarkResurfaced sets Resurfaced on items marked resolved that have had new
+// activity since. It updates items in place and returns them.
+func MarkResurfaced(items []PrioritizedItem, store ResurfaceChecker) []PrioritizedItem {
+	if store == nil {
+		return items
+	}
+	for i := range items {
+		key := items[i].Key()
+		items[i].Resurfaced = store.IsResolved(key) && store.ShouldShow(key, items[i].UpdatedAt)
+	}
+	return items
+}
+
+// LegacyIDs maps the ID of each item to its key, for moving local state
+// that earlier versions recorded under item IDs. Items keyed by their ID
+// are left out.
+func LegacyIDs(items []PrioritizedItem) map[string]string {
+	ids := make(map[string]string, len(items))
+	for i := range items {
+		if key := items[i].Key(); key != items[i].ID {
+			ids[items[i].ID] = key
 		}
 	}
-	return filtered
+	return ids
 }
 
 // FilterByExcludedAuthors removes items authored by users in the exclude list.
@@ -166,29 +295,8 @@ func FilterByExcludedAuthors(items []PrioritizedItem, excludedAuthors []string)
 	if len(excludedAuthors) == 0 {
 		return items
 	}
-
-	// Build a set for O(1) lookup
-	excludeSet := make(map[string]bool, len(excludedAuthors))
-	for _, author := range excludedAuthors {
-		excludeSet[author] = true
-	}
-
-	filtered := make([]PrioritizedItem, 0, len(items))
-	for _, item := range items {
-		// Skip items without author (can't determine author)
-		if item.Author == "" {
-			filtered = append(filtered, item)
-			continue
-		}
-
-		// Skip if author is in the exclude list
-		if excludeSet[item.Author] {
-			continue
-		}
-
-		filtered = append(filtered, item)
-	}
-	return filtered
+	kept, _ := Exclude(items, ExcludedAuthors(excludedAuthors))
+	return kept
 }
 
 // FilterByExcludedRepos removes items from repositories in the exclude list.
@@ -196,21 +304,8 @@ func FilterByExcludedRepos(items []PrioritizedItem, excludedRepos []string) []Pr
 	if len(excludedRepos) == 0 {
 		return items
 	}
-
-	// Build a set for O(1) lookup
-	excludeSet := make(map[string]bool, len(excludedRepos))
-	for _, repo := range excludedRepos {
-		excludeSet[repo] = true
-	}
-
-	filtered := make([]PrioritizedItem, 0, len(items))
-	for _, item := range items {
-		if excludeSet[item.Repository.FullName] {
-			continue
-		}
-		filtered = append(filtered, item)
-	}
-	return filtered
+	kept, _ := Exclude(items, ExcludedRepos(excludedRepos))
+	return kept
 }
 
 // FilterByGreenCI keeps only PRs with passing CI status.
@@ -236,8 +331,9 @@ func FilterByGreenCI(items []PrioritizedItem) []PrioritizedItem {
 }
 
 // FilterOutUnenriched removes PR and Issue notifications that couldn't be enriched.
-// This typically indicates the item is deleted, inaccessible, or the user lost access.
-// Non-PR/Issue types (Release, Discussion) are kept since they don't require enrichment.
+// This typically indicates the item is deleted or the user lost access.
+// Non-PR/Issue types (Release, Discussion) are kept since they don't require enrichment,
+// as are items in repositories the token can't read, which are shown as locked.
 // Returns the filtered list and the number of items that were dropped.
 func FilterOutUnenriched(items []PrioritizedItem) ([]PrioritizedItem, int) {
 	filtered := make([]PrioritizedItem, 0, len(items))
@@ -251,8 +347,9 @@ func FilterOutUnenriched(items []PrioritizedItem) ([]PrioritizedItem, int) {
 			continue
 		}
 
-		// Keep PR/Issue items that were successfully enriched
-		if item.Details != nil {
+		// Keep PR/Issue items that were successfully enriched, and upstream
+		// items, which triage raises itself and never enriches
+		if item.Details != nil || item.Inaccessible || item.Reason == model.ReasonUpstream {
 			filtered = append(filtered, item)
 		} else {
 			dropped++
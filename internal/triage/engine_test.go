@@ -1,8 +1,12 @@
 package triage
 
 import (
+	"maps"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/spiffcs/triage/config"
 	"github.com/spiffcs/triage/internal/model"
 )
 
@@ -58,6 +62,62 @@ func makePrioritizedItem(id string, reason model.ItemReason, subjectType model.S
 	}
 }
 
+func TestRescore(t *testing.T) {
+	mention := makeItem("1", model.ReasonMention, model.SubjectIssue, &testItemOpts{State: "open"})
+	subscribed := makeItem("2", model.ReasonSubscribed, model.SubjectIssue, &testItemOpts{State: "open"})
+
+	weights := config.DefaultScoreWeights()
+	items := NewEngine("me", weights, nil).Prioritize([]model.Item{subscribed, mention})
+	if items[0].ID != "1" {
+		t.Fatalf("Prioritize() put %s first, want the mention", items[0].ID)
+	}
+	items[1].Resurfaced = true
+
+	// Subscriptions outweighing mentions reverse the order
+	weights.Subscribed = weights.Mention * 10
+	weights.MentionIsUrgent = false
+	rescored := NewEngine("me", weights, nil).Rescore(items)
+	if got := []string{rescored[0].ID, rescored[1].ID}; !slices.Equal(got, []string{"2", "1"}) {
+		t.Errorf("Rescore() order = %v, want [2 1]", got)
+	}
+	if rescored[0].Score <= items[1].Score {
+		t.Errorf("Rescore() score = %d, want it above %d", rescored[0].Score, items[1].Score)
+	}
+	if !rescored[0].Resurfaced || rescored[1].Resurfaced {
+		t.Error("Rescore() did not keep which item resurfaced")
+	}
+}
+
+func TestPrioritizeLinksDependents(t *testing.T) {
+	base := makeItemWithRepo("1", model.ReasonSubscribed, model.SubjectPullRequest, &testItemOpts{State: "open"}, "Org/App")
+	base.Number = 10
+	top := makeItemWithRepo("2", model.ReasonSubscribed, model.SubjectPullRequest, &testItemOpts{State: "open"}, "Org/App")
+	top.Number = 11
+	top.BlockedBy = []string{"Org/App#10", "org/other#5"}
+	other := makeItemWithRepo("3", model.ReasonSubscribed, model.SubjectIssue, &testItemOpts{State: "open"}, "org/app")
+	other.Number = 12
+	other.BlockedBy = []string{"org/app#10"}
+
+	items := NewEngine("me", config.DefaultScoreWeights(), nil).Prioritize([]model.Item{top, other, base})
+	if items[0].ID != "1" {
+		t.Fatalf("Prioritize() put %s first, want the base of the stack", items[0].ID)
+	}
+	if want := []string{"Org/App#11", "org/app#12"}; !slices.Equal(items[0].Blocks, want) {
+		t.Errorf("Blocks = %v, want %v", items[0].Blocks, want)
+	}
+	for _, item := range items[1:] {
+		if len(item.Blocks) > 0 {
+			t.Errorf("item %s Blocks = %v, want none", item.ID, item.Blocks)
+		}
+	}
+
+	// Rescoring finds the links again once the blocked item is gone
+	rescored := NewEngine("me", config.DefaultScoreWeights(), nil).Rescore(items[:1])
+	if len(rescored[0].Blocks) > 0 {
+		t.Errorf("Rescore() Blocks = %v, want none", rescored[0].Blocks)
+	}
+}
+
 func TestFilterByPriority(t *testing.T) {
 	items := []PrioritizedItem{
 		makePrioritizedItem("1", model.ReasonReviewRequested, model.SubjectPullRequest, PriorityUrgent, nil),
@@ -109,6 +169,39 @@ func TestFilterByPriority(t *testing.T) {
 	}
 }
 
+func TestFilterByMinPriority(t *testing.T) {
+	items := []PrioritizedItem{
+		makePrioritizedItem("1", model.ReasonReviewRequested, model.SubjectPullRequest, PriorityUrgent, nil),
+		makePrioritizedItem("2", model.ReasonSubscribed, model.SubjectIssue, PriorityFYI, nil),
+		makePrioritizedItem("3", model.ReasonMention, model.SubjectIssue, PriorityQuickWin, nil),
+		makePrioritizedItem("4", model.ReasonAuthor, model.SubjectPullRequest, PriorityImportant, nil),
+		makePrioritizedItem("5", model.ReasonSubscribed, model.SubjectIssue, PriorityArchive, nil),
+	}
+
+	tests := []struct {
+		minimum PriorityLevel
+		wantIDs []string
+	}{
+		{PriorityUrgent, []string{"1"}},
+		{PriorityImportant, []string{"1", "4"}},
+		{PriorityQuickWin, []string{"1", "3", "4"}},
+		{PriorityFYI, []string{"1", "2", "3", "4"}},
+		{PriorityArchive, []string{"1", "2", "3", "4", "5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.minimum), func(t *testing.T) {
+			var got []string
+			for _, item := range FilterByMinPriority(items, tt.minimum) {
+				got = append(got, item.ID)
+			}
+			if !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("FilterByMinPriority(%s) = %v, want %v", tt.minimum, got, tt.wantIDs)
+			}
+		})
+	}
+}
+
 func TestFilterByReason(t *testing.T) {
 	items := []PrioritizedItem{
 		makePrioritizedItem("1", model.ReasonReviewRequested, model.SubjectPullRequest, PriorityUrgent, nil),
@@ -119,34 +212,45 @@ func TestFilterByReason(t *testing.T) {
 
 	tests := []struct {
 		name    string
-		reasons []model.ItemReason
+		include []model.ItemReason
+		exclude []model.ItemReason
 		wantIDs []string
 	}{
 		{
 			name:    "filter by single reason",
-			reasons: []model.ItemReason{model.ReasonReviewRequested},
+			include: []model.ItemReason{model.ReasonReviewRequested},
 			wantIDs: []string{"1"},
 		},
 		{
 			name:    "filter by multiple reasons",
-			reasons: []model.ItemReason{model.ReasonReviewRequested, model.ReasonMention},
+			include: []model.ItemReason{model.ReasonReviewRequested, model.ReasonMention},
 			wantIDs: []string{"1", "3"},
 		},
+		{
+			name:    "exclude reasons",
+			exclude: []model.ItemReason{model.ReasonSubscribed, model.ReasonCIActivity},
+			wantIDs: []string{"1", "3", "4"},
+		},
+		{
+			name:    "include and exclude",
+			include: []model.ItemReason{model.ReasonReviewRequested, model.ReasonMention},
+			exclude: []model.ItemReason{model.ReasonMention},
+			wantIDs: []string{"1"},
+		},
 		{
 			name:    "empty reasons returns all",
-			reasons: []model.ItemReason{},
+			include: []model.ItemReason{},
 			wantIDs: []string{"1", "2", "3", "4"},
 		},
 		{
 			name:    "nil reasons returns all",
-			reasons: nil,
 			wantIDs: []string{"1", "2", "3", "4"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FilterByReason(items, tt.reasons)
+			got := FilterByReason(items, tt.include, tt.exclude)
 			if len(got) != len(tt.wantIDs) {
 				t.Errorf("FilterByReason() returned %d items, want %d", len(got), len(tt.wantIDs))
 				return
@@ -160,6 +264,50 @@ func TestFilterByReason(t *testing.T) {
 	}
 }
 
+func TestParseReasons(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		wantInclude []model.ItemReason
+		wantExclude []model.ItemReason
+		wantErr     bool
+	}{
+		{
+			name:        "comma-separated",
+			values:      []string{"review_requested, mention"},
+			wantInclude: []model.ItemReason{model.ReasonReviewRequested, model.ReasonMention},
+		},
+		{
+			name:        "negated",
+			values:      []string{"!subscribed,!ci_activity"},
+			wantExclude: []model.ItemReason{model.ReasonSubscribed, model.ReasonCIActivity},
+		},
+		{
+			name:        "mixed across values",
+			values:      []string{"Mention", "!subscribed"},
+			wantInclude: []model.ItemReason{model.ReasonMention},
+			wantExclude: []model.ItemReason{model.ReasonSubscribed},
+		},
+		{
+			name:    "unknown reason",
+			values:  []string{"!subscribd"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			include, exclude, err := ParseReasons(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReasons() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(include, tt.wantInclude) || !slices.Equal(exclude, tt.wantExclude) {
+				t.Errorf("ParseReasons() = %v, %v; want %v, %v", include, exclude, tt.wantInclude, tt.wantExclude)
+			}
+		})
+	}
+}
+
 func TestFilterOutMerged(t *testing.T) {
 	items := []PrioritizedItem{
 		makePrioritizedItem("1", model.ReasonAuthor, model.SubjectPullRequest, PriorityImportant, &testItemOpts{Merged: true}),
@@ -203,6 +351,18 @@ func TestFilterOutClosed(t *testing.T) {
 	}
 }
 
+func TestFilterOutArchived(t *testing.T) {
+	items := []PrioritizedItem{
+		makePrioritizedItem("1", model.ReasonAuthor, model.SubjectPullRequest, PriorityArchive, nil),
+		makePrioritizedItem("2", model.ReasonSubscribed, model.SubjectIssue, PriorityFYI, nil),
+	}
+
+	got := FilterOutArchived(items)
+	if len(got) != 1 || got[0].ID != "2" {
+		t.Errorf("FilterOutArchived() = %v, want only item 2", got)
+	}
+}
+
 // Helper to create a prioritized item with repo
 func makePrioritizedItemWithRepo(id string, reason model.ItemReason, subjectType model.SubjectType, priority PriorityLevel, opts *testItemOpts, repo string) PrioritizedItem {
 	return PrioritizedItem{
@@ -458,11 +618,14 @@ func TestFilterOutUnenriched(t *testing.T) {
 		makePrioritizedItem("3", model.ReasonSubscribed, model.SubjectIssue, PriorityFYI, &testItemOpts{State: "open"}),               // Issue with Details - kept
 		makePrioritizedItem("4", model.ReasonSubscribed, model.SubjectIssue, PriorityFYI, nil),                                        // Issue without Details - filtered
 		makePrioritizedItem("5", model.ReasonSubscribed, model.SubjectRelease, PriorityFYI, nil),                                      // Release without Details - kept (different type)
+		makePrioritizedItem("6", model.ReasonSubscribed, model.SubjectIssue, PriorityFYI, nil),                                        // Issue in unreadable repo - kept (locked)
+		makePrioritizedItem("7", model.ReasonUpstream, model.SubjectIssue, PriorityImportant, nil),                                    // Upstream issue closed - kept (never enriched)
 	}
+	items[5].Inaccessible = true
 
 	got, dropped := FilterOutUnenriched(items)
 
-	wantIDs := []string{"1", "3", "5"}
+	wantIDs := []string{"1", "3", "5", "6", "7"}
 	if len(got) != len(wantIDs) {
 		t.Errorf("FilterOutUnenriched() returned %d items, want %d", len(got), len(wantIDs))
 		return
@@ -476,3 +639,85 @@ func TestFilterOutUnenriched(t *testing.T) {
 		t.Errorf("FilterOutUnenriched() dropped %d items, want 2", dropped)
 	}
 }
+
+type fakeResolved struct {
+	resolvedAt map[string]time.Time
+}
+
+func (f fakeResolved) ShouldShow(id string, updatedAt time.Time) bool {
+	at, ok := f.resolvedAt[id]
+	return !ok || updatedAt.After(at)
+}
+
+func (f fakeResolved) IsResolved(id string) bool {
+	_, ok := f.resolvedAt[id]
+	return ok
+}
+
+func TestMarkResurfaced(t *testing.T) {
+	now := time.Now()
+	store := fakeResolved{resolvedAt: map[string]time.Time{
+		"back":  now.Add(-time.Hour),
+		"quiet": now,
+	}}
+	items := []PrioritizedItem{
+		{Item: model.Item{ID: "back", UpdatedAt: now}},
+		{Item: model.Item{ID: "quiet", UpdatedAt: now}},
+		{Item: model.Item{ID: "open", UpdatedAt: now}},
+	}
+
+	got := MarkResurfaced(items, store)
+	want := map[string]bool{"back": true, "quiet": false, "open": false}
+	for _, item := range got {
+		if item.Resurfaced != want[item.ID] {
+			t.Errorf("%s: Resurfaced = %v, want %v", item.ID, item.Resurfaced, want[item.ID])
+		}
+	}
+
+	if got := MarkResurfaced(items, nil); len(got) != len(items) {
+		t.Errorf("MarkResurfaced(nil store) changed the items")
+	}
+}
+
+func TestFilterResolvedAcrossSources(t *testing.T) {
+	now := time.Now()
+	// Resolved when the PR arrived as a notification
+	store := fakeResolved{resolvedAt: map[string]time.Time{"acme/api#12": now}}
+
+	pr := model.Item{
+		ID:         "review-requested-9001",
+		Number:     12,
+		Repository: model.Repository{FullName: "Acme/API"},
+		Subject:    model.Subject{Type: model.SubjectPullRequest},
+		UpdatedAt:  now.Add(-time.Minute),
+	}
+	if got := FilterResolved([]PrioritizedItem{{Item: pr}}, store); len(got) != 0 {
+		t.Errorf("FilterResolved() = %d items, want the PR resolved under another source hidden", len(got))
+	}
+
+	// A due reminder brings it back without new activity
+	pr.Reminder = &model.Reminder{At: now, Note: "ping after release"}
+	if got := FilterResolved([]PrioritizedItem{{Item: pr}}, store); len(got) != 1 {
+		t.Errorf("FilterResolved() = %d items, want the resolved PR with a due reminder kept", len(got))
+	}
+}
+
+func TestLegacyIDs(t *testing.T) {
+	items := []PrioritizedItem{
+		{Item: model.Item{ID: "12345", Repository: model.Repository{FullName: "acme/api"},
+			Subject: model.Subject{Type: model.SubjectPullRequest, URL: "https://api.github.com/repos/acme/api/pulls/7"}}},
+		{Item: model.Item{ID: "67890", Repository: model.Repository{FullName: "acme/api"},
+			Subject: model.Subject{Type: model.SubjectDiscussion, URL: "https://api.github.com/repos/acme/api/discussions/7"}}},
+		{Item: model.Item{ID: "555", Repository: model.Repository{FullName: "acme/api"},
+			Subject: model.Subject{Type: model.SubjectRelease, URL: "https://api.github.com/repos/acme/api/releases/4242"}}},
+	}
+
+	got := LegacyIDs(items)
+	want := map[string]string{
+		"12345": "acme/api#7",
+		"67890": "acme/api/discussions#7",
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("LegacyIDs() = %v, want %v", got, want)
+	}
+}
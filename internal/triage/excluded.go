@@ -0,0 +1,80 @@
+package triage
+
+// Exclusion is an item a filter removed, with the rule that removed it.
+type Exclusion struct {
+	Item PrioritizedItem
+	Rule string
+}
+
+// ExclusionRule returns the rule that removes item, or "" to keep it.
+type ExclusionRule func(item PrioritizedItem) string
+
+// Exclude splits items into those every rule keeps and those one of them
+// removes, each paired with the first rule that removed it.
+func Exclude(items []PrioritizedItem, rules ...ExclusionRule) (kept []PrioritizedItem, excluded []Exclusion) {
+	kept = make([]PrioritizedItem, 0, len(items))
+	for _, item := range items {
+		rule := ""
+		for _, r := range rules {
+			if rule = r(item); rule != "" {
+				break
+			}
+		}
+		if rule == "" {
+			kept = append(kept, item)
+		} else {
+			excluded = append(excluded, Exclusion{Item: item, Rule: rule})
+		}
+	}
+	return kept, excluded
+}
+
+// ExcludedAuthors removes items authored by one of authors, as
+// exclude_authors does. Items without an author are kept.
+func ExcludedAuthors(authors []string) ExclusionRule {
+	excludeSet := make(map[string]bool, len(authors))
+	for _, author := range authors {
+		excludeSet[author] = true
+	}
+	return func(item PrioritizedItem) string {
+		if item.Author != "" && excludeSet[item.Author] {
+			return "exclude_authors: " + item.Author
+		}
+		return ""
+	}
+}
+
+// ExcludedRepos removes items in one of repos (owner/name), as
+// exclude_repos does.
+func ExcludedRepos(repos []string) ExclusionRule {
+	excludeSet := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		excludeSet[repo] = true
+	}
+	return func(item PrioritizedItem) string {
+		if excludeSet[item.Repository.FullName] {
+			return "exclude_repos: " + item.Repository.FullName
+		}
+		return ""
+	}
+}
+
+// Hidden removes the items store hides, such as those marked done or
+// snoozed without new activity since, reporting them as rule. Items with
+// a due reminder are kept.
+func Hidden(store ResolvedChecker, rule string) ExclusionRule {
+	return func(item PrioritizedItem) string {
+		if item.Reminder == nil && !store.ShouldShow(item.Key(), item.UpdatedAt) {
+			return rule
+		}
+		return ""
+	}
+}
+
+// Archived removes items in the Archive priority.
+func Archived(item PrioritizedItem) string {
+	if item.Priority == PriorityArchive {
+		return "archived: scoring.archive_after_days"
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package triage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// hiddenKeys hides the items with the given keys.
+type hiddenKeys map[string]bool
+
+func (h hiddenKeys) ShouldShow(key string, _ time.Time) bool { return !h[key] }
+
+func TestExclude(t *testing.T) {
+	item := func(id, repo, author string, priority PriorityLevel) PrioritizedItem {
+		return PrioritizedItem{
+			Item: model.Item{
+				ID:         id,
+				Author:     author,
+				Repository: model.Repository{FullName: repo},
+			},
+			Priority: priority,
+		}
+	}
+	reminded := item("reminded", "acme/api", "", PriorityNotable)
+	reminded.Reminder = &model.Reminder{}
+	items := []PrioritizedItem{
+		item("kept", "acme/api", "alice", PriorityUrgent),
+		item("bot", "acme/noisy", "renovate[bot]", PriorityFYI),
+		item("noisy", "acme/noisy", "", PriorityFYI),
+		item("done", "acme/api", "bob", PriorityNotable),
+		item("old", "acme/api", "bob", PriorityArchive),
+		reminded,
+	}
+
+	kept, excluded := Exclude(items,
+		ExcludedAuthors([]string{"renovate[bot]"}),
+		ExcludedRepos([]string{"acme/noisy"}),
+		Hidden(hiddenKeys{"done": true, "reminded": true}, "marked done"),
+		Archived,
+	)
+
+	var keptIDs []string
+	for _, item := range kept {
+		keptIDs = append(keptIDs, item.ID)
+	}
+	if want := []string{"kept", "reminded"}; !reflect.DeepEqual(keptIDs, want) {
+		t.Errorf("kept = %v, want %v", keptIDs, want)
+	}
+
+	rules := make(map[string]string)
+	for _, e := range excluded {
+		rules[e.Item.ID] = e.Rule
+	}
+	want := map[string]string{
+		// The first rule that matches is reported
+		"bot":   "exclude_authors: renovate[bot]",
+		"noisy": "exclude_repos: acme/noisy",
+		"done":  "marked done",
+		"old":   "archived: scoring.archive_after_days",
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("excluded = %v, want %v", rules, want)
+	}
+}
@@ -0,0 +1,79 @@
+package triage
+
+import (
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// ScoreFactor is one part of an item's score.
+type ScoreFactor struct {
+	// Name describes the factor, e.g. "hot topic (12 comments)".
+	Name string
+	// Setting is the config path of the weight the points come from, e.g.
+	// ["scoring", "hot_topic_bonus"].
+	Setting []string
+	Points  int
+}
+
+// scoreSheet collects the factors of a score.
+type scoreSheet []ScoreFactor
+
+// add records a factor, unless it adds nothing.
+func (s *scoreSheet) add(name string, points int, setting ...string) {
+	if points == 0 {
+		return
+	}
+	*s = append(*s, ScoreFactor{Name: name, Setting: setting, Points: points})
+}
+
+// total sums the points of every factor.
+func (s scoreSheet) total() int {
+	total := 0
+	for _, f := range s {
+		total += f.Points
+	}
+	return total
+}
+
+// baseScoreSetting returns the base_scores key that scores reason.
+func baseScoreSetting(reason model.ItemReason) string {
+	switch reason {
+	case model.ReasonReviewRequested, model.ReasonMention, model.ReasonTeamMention,
+		model.ReasonAuthor, model.ReasonAssign, model.ReasonComment,
+		model.ReasonStateChange, model.ReasonSubscribed, model.ReasonCIActivity:
+		return string(reason)
+	case model.ReasonUpstream:
+		return string(model.ReasonAssign)
+	default:
+		return string(model.ReasonSubscribed)
+	}
+}
+
+// Explanation breaks down how an item was prioritized.
+type Explanation struct {
+	PrioritizedItem
+	// Factors are the parts of the raw score, before any normalization.
+	Factors []ScoreFactor
+	// RawScore is the score the factors add up to, never below zero.
+	RawScore int
+	// ScorePriority is the priority the score alone gives; Priority differs
+	// when a severity label raised it.
+	ScorePriority PriorityLevel
+	// RepoWeights is set when the item's repository has its own weights
+	// under repo_overrides.
+	RepoWeights bool
+}
+
+// Explain scores n like Prioritize and returns how it got its score and
+// priority.
+func (e *Engine) Explain(n model.Item) Explanation {
+	h := e.heuristics.ForItem(&n)
+	factors := h.Factors(&n)
+	raw := max(scoreSheet(factors).total(), 0)
+	return Explanation{
+		PrioritizedItem: e.prioritize(n),
+		Factors:         factors,
+		RawScore:        raw,
+		ScorePriority:   h.Priority(&n, raw),
+		RepoWeights:     h != e.heuristics,
+	}
+}
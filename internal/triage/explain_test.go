@@ -0,0 +1,85 @@
+package triage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestFactors(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	weights.LabelScores = map[string]int{"security": 40}
+	h := NewHeuristics("testuser", weights, nil)
+
+	n := &model.Item{
+		Reason:       model.ReasonMention,
+		Type:         model.ItemTypeIssue,
+		State:        model.StateOpen,
+		UpdatedAt:    time.Now(),
+		Labels:       []string{"Security"},
+		CommentCount: 12,
+		Details:      &model.IssueDetails{},
+	}
+
+	want := []ScoreFactor{
+		{Name: "mention notification", Setting: []string{"base_scores", "mention"}, Points: weights.Mention},
+		{Name: "label Security", Setting: []string{"label_scores", "security"}, Points: 40},
+		{Name: "open", Setting: []string{"scoring", "open_state_bonus"}, Points: weights.OpenStateBonus},
+		{Name: "hot topic (12 comments)", Setting: []string{"scoring", "hot_topic_bonus"}, Points: weights.HotTopicBonus},
+	}
+	got := h.Factors(n)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Factors() = %+v, want %+v", got, want)
+	}
+	if sum := scoreSheet(got).total(); sum != h.Score(n) {
+		t.Errorf("factors add up to %d, Score() = %d", sum, h.Score(n))
+	}
+}
+
+func TestFactorsTeamReviewRequest(t *testing.T) {
+	h := NewHeuristics("testuser", config.DefaultScoreWeights(), nil)
+	n := &model.Item{
+		Reason:    model.ReasonReviewRequested,
+		Type:      model.ItemTypePullRequest,
+		UpdatedAt: time.Now(),
+		Details:   &model.PRDetails{RequestedReviewers: []string{"platform"}, RequestedTeams: []string{"acme/platform"}},
+	}
+
+	base := h.Factors(n)[0]
+	if got := base.Setting; !reflect.DeepEqual(got, []string{"base_scores", "team_review_requested"}) {
+		t.Errorf("base factor setting = %v, want base_scores.team_review_requested", got)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	weights.SeverityFloors = map[string]string{"p0": "urgent"}
+	repo := weights
+	repo.Subscribed = 40
+	weights.RepoWeights = map[string]config.ScoreWeights{"acme/api": repo}
+	e := NewEngine("testuser", weights, nil)
+
+	n := model.Item{
+		Reason:     model.ReasonSubscribed,
+		Repository: model.Repository{FullName: "acme/api"},
+		Labels:     []string{"p0"},
+		UpdatedAt:  time.Now(),
+	}
+	got := e.Explain(n)
+
+	if !got.RepoWeights {
+		t.Error("RepoWeights = false, want true for a repository with its own weights")
+	}
+	if got.RawScore != 40 || got.Score != 40 {
+		t.Errorf("RawScore, Score = %d, %d, want 40, 40", got.RawScore, got.Score)
+	}
+	if got.ScorePriority == PriorityUrgent || got.Priority != PriorityUrgent {
+		t.Errorf("ScorePriority, Priority = %s, %s, want the severity label to raise it to urgent", got.ScorePriority, got.Priority)
+	}
+	if p := e.Prioritize([]model.Item{n})[0]; p.Score != got.Score || p.Priority != got.Priority || p.ActionNeeded != got.ActionNeeded {
+		t.Errorf("Explain() = %+v, want it to match Prioritize() %+v", got.PrioritizedItem, p)
+	}
+}
@@ -0,0 +1,83 @@
+package triage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormFieldFilter keeps items whose issue form answered Field with Value.
+type FormFieldFilter struct {
+	Field string
+	Value string
+}
+
+// ParseFormFieldFilters parses --form-field values such as "Severity=high".
+func ParseFormFieldFilters(values []string) ([]FormFieldFilter, error) {
+	filters := make([]FormFieldFilter, 0, len(values))
+	for _, v := range values {
+		field, value, ok := strings.Cut(v, "=")
+		field, value = strings.TrimSpace(field), strings.TrimSpace(value)
+		if !ok || field == "" || value == "" {
+			return nil, fmt.Errorf("invalid form field filter %q, want field=value", v)
+		}
+		filters = append(filters, FormFieldFilter{Field: field, Value: value})
+	}
+	return filters, nil
+}
+
+// FilterByFormFields keeps items matching every filter. Fields and answers
+// match case-insensitively.
+func FilterByFormFields(items []PrioritizedItem, filters []FormFieldFilter) []PrioritizedItem {
+	if len(filters) == 0 {
+		return items
+	}
+
+	filtered := make([]PrioritizedItem, 0, len(items))
+	for _, item := range items {
+		matches := true
+		for _, f := range filters {
+			if answer, ok := formField(item.FormFields, f.Field); !ok || !strings.EqualFold(answer, f.Value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// SelectFormFields narrows each item's form fields to those configured for
+// its repository, keyed by lowercased full name, dropping the rest.
+func SelectFormFields(items []PrioritizedItem, fields map[string][]string) []PrioritizedItem {
+	for i := range items {
+		if len(items[i].FormFields) == 0 {
+			continue
+		}
+		var kept map[string]string
+		for _, field := range fields[strings.ToLower(items[i].Repository.FullName)] {
+			if answer, ok := formField(items[i].FormFields, field); ok {
+				if kept == nil {
+					kept = make(map[string]string)
+				}
+				kept[field] = answer
+			}
+		}
+		items[i].FormFields = kept
+	}
+	return items
+}
+
+// formField looks up a form field by label, ignoring case.
+func formField(fields map[string]string, label string) (string, bool) {
+	if answer, ok := fields[label]; ok {
+		return answer, true
+	}
+	for l, answer := range fields {
+		if strings.EqualFold(l, label) {
+			return answer, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,75 @@
+package triage
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestParseFormFieldFilters(t *testing.T) {
+	got, err := ParseFormFieldFilters([]string{"Severity=high", " Affected version = 1.4 "})
+	if err != nil {
+		t.Fatalf("ParseFormFieldFilters() error: %v", err)
+	}
+	want := []FormFieldFilter{{"Severity", "high"}, {"Affected version", "1.4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFormFieldFilters() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"Severity", "=high", "Severity="} {
+		if _, err := ParseFormFieldFilters([]string{bad}); err == nil {
+			t.Errorf("ParseFormFieldFilters(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFilterByFormFields(t *testing.T) {
+	item := func(id string, fields map[string]string) PrioritizedItem {
+		return PrioritizedItem{Item: model.Item{ID: id, FormFields: fields}}
+	}
+	items := []PrioritizedItem{
+		item("high", map[string]string{"Severity": "High", "Version": "1.4"}),
+		item("low", map[string]string{"Severity": "Low"}),
+		item("no form", nil),
+	}
+
+	ids := func(items []PrioritizedItem) []string {
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+	if got := ids(FilterByFormFields(items, []FormFieldFilter{{"severity", "high"}})); !slices.Equal(got, []string{"high"}) {
+		t.Errorf("FilterByFormFields() = %v, want [high]", got)
+	}
+	if got := ids(FilterByFormFields(items, []FormFieldFilter{{"Severity", "High"}, {"Version", "2.0"}})); len(got) != 0 {
+		t.Errorf("FilterByFormFields() = %v, want every filter to match", got)
+	}
+	if got := FilterByFormFields(items, nil); len(got) != len(items) {
+		t.Errorf("FilterByFormFields() with no filters kept %d items, want %d", len(got), len(items))
+	}
+}
+
+func TestSelectFormFields(t *testing.T) {
+	items := []PrioritizedItem{
+		{Item: model.Item{
+			Repository: model.Repository{FullName: "Acme/App"},
+			FormFields: map[string]string{"severity": "High", "Logs": "none"},
+		}},
+		{Item: model.Item{
+			Repository: model.Repository{FullName: "acme/other"},
+			FormFields: map[string]string{"Severity": "Low"},
+		}},
+	}
+
+	got := SelectFormFields(items, map[string][]string{"acme/app": {"Severity", "Version"}})
+	if want := map[string]string{"Severity": "High"}; !reflect.DeepEqual(got[0].FormFields, want) {
+		t.Errorf("SelectFormFields() kept %v, want %v", got[0].FormFields, want)
+	}
+	if got[1].FormFields != nil {
+		t.Errorf("SelectFormFields() kept %v for a repo with no fields configured", got[1].FormFields)
+	}
+}
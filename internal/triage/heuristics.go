@@ -1,6 +1,9 @@
 package triage
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -8,43 +11,131 @@ import (
 	"github.com/spiffcs/triage/internal/model"
 )
 
+// HotTopicVelocityWindow is how far back comments count towards the
+// hot topic velocity signal.
+const HotTopicVelocityWindow = 48 * time.Hour
+
 // Heuristics implements rule-based priority scoring
 type Heuristics struct {
 	Weights        config.ScoreWeights
 	CurrentUser    string
 	QuickWinLabels []string
+	// Teams are the teams the current user belongs to, as "org/team-slug"
+	Teams []string
+
+	// repos scores the items of repositories with their own weights,
+	// keyed by lowercased full name
+	repos map[string]*Heuristics
 }
 
 // NewHeuristics creates a new heuristics scorer with the given weights and labels
 func NewHeuristics(currentUser string, weights config.ScoreWeights, quickWinLabels []string) *Heuristics {
-	return &Heuristics{
+	h := &Heuristics{
 		Weights:        weights,
 		CurrentUser:    currentUser,
 		QuickWinLabels: quickWinLabels,
 	}
+	if len(weights.RepoWeights) > 0 {
+		h.repos = make(map[string]*Heuristics, len(weights.RepoWeights))
+		for repo, w := range weights.RepoWeights {
+			h.repos[repo] = &Heuristics{Weights: w, CurrentUser: currentUser, QuickWinLabels: quickWinLabels}
+		}
+	}
+	return h
+}
+
+// ForItem returns the heuristics that score n: those of its repository
+// when repo_overrides configures it, and h otherwise.
+func (h *Heuristics) ForItem(n *model.Item) *Heuristics {
+	if repo, ok := h.repos[strings.ToLower(n.Repository.FullName)]; ok {
+		return repo
+	}
+	return h
 }
 
 // Score calculates the priority score for an item
 func (h *Heuristics) Score(n *model.Item) int {
-	base := h.baseScore(n.Reason)
-	score := base
+	score := 0
+	for _, f := range h.Factors(n) {
+		score += f.Points
+	}
+	return max(score, 0)
+}
+
+// Factors returns the parts of an item's score, starting with the base
+// score of its reason. Modifiers that add nothing are left out, and the
+// score never drops below zero however negative the sum.
+func (h *Heuristics) Factors(n *model.Item) []ScoreFactor {
+	base, setting := h.baseScore(n.Reason), baseScoreSetting(n.Reason)
+	if n.TeamReviewRequest(h.CurrentUser) {
+		base, setting = h.Weights.TeamReviewRequested, "team_review_requested"
+	}
+	sheet := scoreSheet{{Name: string(n.Reason) + " notification", Setting: []string{"base_scores", setting}, Points: base}}
+
+	h.labelFactors(&sheet, n)
+	h.formFieldFactors(&sheet, n)
+	if h.teamRequest(n) {
+		sheet.add("review requested from your team", h.Weights.TeamReviewBonus, "scoring", "team_review_bonus")
+	}
+	// A request to a large team is only yours if it touches code you own
+	if pr := n.PRDetails(); pr != nil && pr.OwnsChanges != nil {
+		if *pr.OwnsChanges {
+			sheet.add("changes code you own", h.Weights.CodeOwnersBonus, "scoring", "codeowners_bonus")
+		} else {
+			sheet.add("changes no code you own", h.Weights.CodeOwnersPenalty, "scoring", "codeowners_penalty")
+		}
+	}
+	if n.Reminder != nil {
+		sheet.add("reminder due", h.Weights.ReminderBonus, "scoring", "reminder_bonus")
+	}
+	// Work waiting on an open dependency can't move yet, while the base of
+	// a stack holds up everything above it
+	if len(n.BlockedBy) > 0 {
+		sheet.add("blocked by "+strings.Join(n.BlockedBy, ", "), h.Weights.BlockedPenalty, "scoring", "blocked_penalty")
+	} else if len(n.Blocks) > 0 {
+		sheet.add("unblocks "+strings.Join(n.Blocks, ", "), h.Weights.UnblocksBonus, "scoring", "unblocks_bonus")
+	}
+	h.planningFactors(&sheet, n)
 
 	// Apply modifiers based on enriched details
 	if n.Details != nil {
-		score += h.detailModifiers(n)
+		h.detailFactors(&sheet, n)
 	}
 
 	// Age modifier - older unread items get priority boost, scaled by base score
 	// so low-priority items (e.g. subscribed=10) can't accumulate enough age
-	// bonus to outrank high-priority items (e.g. team_mention=85).
-	age := time.Since(n.UpdatedAt)
+	// bonus to outrank high-priority items (e.g. team_mention=85). A pending
+	// review request ages from when it was made, not from the last push.
+	age := time.Since(n.WaitingSince(h.CurrentUser))
 	daysOld := int(age.Hours() / 24)
 	if daysOld > 0 {
 		rawBonus := min(daysOld*h.Weights.OldUnreadBonus, h.Weights.MaxAgeBonus)
-		score += rawBonus * base / 100
+		sheet.add(fmt.Sprintf("waiting %d days", daysOld), rawBonus*base/100, "scoring", "old_unread_bonus")
 	}
 
-	return max(score, 0)
+	return sheet
+}
+
+// NormalizeScore maps a raw score onto 0-100 so scores read the same under
+// any weights. Each band between promotion thresholds takes a quarter of
+// the scale: Notable starts at 25, Important at 50, and Urgent at 75. Twice
+// the Urgent threshold or more is 100.
+func (h *Heuristics) NormalizeScore(score int) int {
+	bounds := []int{
+		0,
+		h.Weights.FYIPromotionThreshold,
+		h.Weights.NotablePromotionThreshold,
+		h.Weights.ImportantPromotionThreshold,
+		2 * h.Weights.ImportantPromotionThreshold,
+	}
+	for i := 1; i < len(bounds); i++ {
+		lo, hi := bounds[i-1], bounds[i]
+		if score >= hi || hi <= lo {
+			continue
+		}
+		return max((i-1)*25+(score-lo)*25/(hi-lo), 0)
+	}
+	return 100
 }
 
 func (h *Heuristics) baseScore(reason model.ItemReason) int {
@@ -57,7 +148,8 @@ func (h *Heuristics) baseScore(reason model.ItemReason) int {
 		return h.Weights.TeamMention
 	case model.ReasonAuthor:
 		return h.Weights.Author
-	case model.ReasonAssign:
+	case model.ReasonAssign, model.ReasonUpstream:
+		// Work unblocked upstream is work the user set out to do
 		return h.Weights.Assign
 	case model.ReasonComment:
 		return h.Weights.Comment
@@ -72,72 +164,76 @@ func (h *Heuristics) baseScore(reason model.ItemReason) int {
 	}
 }
 
-func (h *Heuristics) detailModifiers(n *model.Item) int {
-	modifier := 0
-
+func (h *Heuristics) detailFactors(sheet *scoreSheet, n *model.Item) {
 	// State modifiers
 	switch n.State {
 	case "open":
-		modifier += h.Weights.OpenStateBonus
+		sheet.add("open", h.Weights.OpenStateBonus, "scoring", "open_state_bonus")
 	case "closed", "merged":
-		modifier += h.Weights.ClosedStatePenalty
+		sheet.add(n.State, h.Weights.ClosedStatePenalty, "scoring", "closed_state_penalty")
 	}
 
-	// Hot topic - many comments indicate active discussion
+	// Hot topic - many comments overall, or a burst of recent ones,
+	// indicate active discussion. The bonuses don't stack, so a long but
+	// quiet thread can't outrank one that is flaring up now.
+	hotTopic := 0
+	hotTopicName, hotTopicSetting := "", ""
 	if n.CommentCount > h.Weights.HotTopicThreshold {
-		modifier += h.Weights.HotTopicBonus
+		hotTopic = h.Weights.HotTopicBonus
+		hotTopicName, hotTopicSetting = fmt.Sprintf("hot topic (%d comments)", n.CommentCount), "hot_topic_bonus"
+	}
+	if recent := n.CommentsSince(time.Now().Add(-HotTopicVelocityWindow)); recent > h.Weights.HotTopicVelocityThreshold && h.Weights.HotTopicVelocityBonus > hotTopic {
+		hotTopic = h.Weights.HotTopicVelocityBonus
+		hotTopicName, hotTopicSetting = fmt.Sprintf("flaring discussion (%d comments in 48h)", recent), "hot_topic_velocity_bonus"
 	}
+	sheet.add(hotTopicName, hotTopic, "scoring", hotTopicSetting)
 
 	// Low-hanging fruit detection
 	if h.isLowHangingFruit(n) {
-		modifier += h.Weights.LowHangingBonus
+		sheet.add("quick win", h.Weights.LowHangingBonus, "scoring", "low_hanging_bonus")
 	}
 
 	// Author-specific modifiers for their own PRs
 	if n.Author == h.CurrentUser && n.IsPR() {
 		if pr := n.PRDetails(); pr != nil {
-			modifier += h.authoredPRModifiers(n, pr)
+			h.authoredPRFactors(sheet, n, pr)
 		}
 	}
-
-	return modifier
 }
 
-// authoredPRModifiers calculates score modifiers for user's own PRs
-func (h *Heuristics) authoredPRModifiers(n *model.Item, pr *model.PRDetails) int {
-	modifier := 0
-
+// authoredPRFactors adds the score modifiers for user's own PRs
+func (h *Heuristics) authoredPRFactors(sheet *scoreSheet, n *model.Item, pr *model.PRDetails) {
 	// PR is approved and ready to merge - urgent action needed!
 	if pr.ReviewState == model.ReviewStateApproved {
-		modifier += h.Weights.ApprovedPRBonus
+		sheet.add("approved", h.Weights.ApprovedPRBonus, "pr", "approved_bonus")
 		if pr.Mergeable {
-			modifier += h.Weights.MergeablePRBonus
+			sheet.add("mergeable", h.Weights.MergeablePRBonus, "pr", "mergeable_bonus")
 		}
 	}
 
 	// PR has changes requested - needs work
 	if pr.ReviewState == model.ReviewStateChangesRequested {
-		modifier += h.Weights.ChangesRequestedBonus
+		sheet.add("changes requested", h.Weights.ChangesRequestedBonus, "pr", "changes_requested_bonus")
 	}
 
 	// PR has review comments - might need response
 	if pr.ReviewComments > 0 {
-		modifier += min(pr.ReviewComments*h.Weights.ReviewCommentBonus, h.Weights.ReviewCommentMaxBonus)
+		sheet.add(fmt.Sprintf("%d review comments", pr.ReviewComments),
+			min(pr.ReviewComments*h.Weights.ReviewCommentBonus, h.Weights.ReviewCommentMaxBonus), "pr", "review_comment_bonus")
 	}
 
 	// Stale PR - no activity after threshold, needs a kick
 	daysSinceUpdate := int(time.Since(n.UpdatedAt).Hours() / 24)
 	if daysSinceUpdate >= h.Weights.StalePRThresholdDays {
 		daysOverThreshold := daysSinceUpdate - h.Weights.StalePRThresholdDays + 1
-		modifier += min(daysOverThreshold*h.Weights.StalePRBonusPerDay, h.Weights.StalePRMaxBonus)
+		sheet.add(fmt.Sprintf("stale for %d days", daysSinceUpdate),
+			min(daysOverThreshold*h.Weights.StalePRBonusPerDay, h.Weights.StalePRMaxBonus), "pr", "stale_bonus_per_day")
 	}
 
 	// Draft PR - lower priority (not ready for review yet)
 	if pr.Draft {
-		modifier += h.Weights.DraftPRPenalty
+		sheet.add("draft", h.Weights.DraftPRPenalty, "pr", "draft_penalty")
 	}
-
-	return modifier
 }
 
 // normalizeLabel converts a label to a normalized form for comparison
@@ -146,6 +242,94 @@ func normalizeLabel(s string) string {
 	return strings.ToLower(strings.ReplaceAll(s, "-", " "))
 }
 
+// labelFactors adds the configured score of each of the item's labels.
+// Like quick win labels, hyphens and spaces are equivalent, but the whole
+// label must match.
+func (h *Heuristics) labelFactors(sheet *scoreSheet, n *model.Item) {
+	if len(h.Weights.LabelScores) == 0 {
+		return
+	}
+	for _, label := range n.Labels {
+		labelNorm := normalizeLabel(label)
+		for _, target := range slices.Sorted(maps.Keys(h.Weights.LabelScores)) {
+			if labelNorm == normalizeLabel(target) {
+				sheet.add("label "+label, h.Weights.LabelScores[target], "label_scores", target)
+			}
+		}
+	}
+}
+
+// planningFactors adds the points for an open item's milestone coming due
+// and for the project board columns the item is in. A column counts once
+// however many boards the item is in it on.
+func (h *Heuristics) planningFactors(sheet *scoreSheet, n *model.Item) {
+	if n.State == model.StateClosed || n.State == model.StateMerged {
+		return
+	}
+	if m := n.Milestone; m != nil && m.DueOn != nil {
+		left := time.Until(*m.DueOn)
+		if left <= time.Duration(h.Weights.MilestoneDueSoonDays)*24*time.Hour {
+			due := "overdue"
+			switch days := int(left.Hours() / 24); {
+			case left >= 24*time.Hour:
+				due = fmt.Sprintf("due in %d days", days)
+			case left >= 0:
+				due = "due today"
+			}
+			sheet.add(fmt.Sprintf("milestone %s %s", m.Title, due), h.Weights.MilestoneDueSoonBonus, "scoring", "milestone_due_soon_bonus")
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range n.Projects {
+		column := strings.ToLower(p.Status)
+		points, ok := h.Weights.ProjectColumnScores[column]
+		if !ok || seen[column] {
+			continue
+		}
+		seen[column] = true
+		sheet.add(fmt.Sprintf("%s on %s", p.Status, p.Project), points, "project_column_scores", column)
+	}
+}
+
+// formFieldFactors adds the configured points of each answer in the item's
+// issue form, for its repository.
+func (h *Heuristics) formFieldFactors(sheet *scoreSheet, n *model.Item) {
+	repo := strings.ToLower(n.Repository.FullName)
+	scores := h.Weights.FormFieldScores[repo]
+	if len(scores) == 0 {
+		return
+	}
+	for _, label := range slices.Sorted(maps.Keys(n.FormFields)) {
+		answer := n.FormFields[label]
+		sheet.add(fmt.Sprintf("form field %s: %s", label, answer),
+			scores[strings.ToLower(label)][strings.ToLower(answer)], "issue_forms", repo, "scores", label)
+	}
+}
+
+// severityFloor returns the highest priority set by the item's severity
+// labels. Labels match like label scores; unknown priorities are ignored.
+func (h *Heuristics) severityFloor(n *model.Item) (PriorityLevel, bool) {
+	var floor PriorityLevel
+	found := false
+	for _, label := range n.Labels {
+		labelNorm := normalizeLabel(label)
+		for target, name := range h.Weights.SeverityFloors {
+			if labelNorm != normalizeLabel(target) {
+				continue
+			}
+			p, err := ParsePriority(name)
+			if err != nil {
+				continue
+			}
+			if !found || p.Rank() < floor.Rank() {
+				floor, found = p, true
+			}
+		}
+	}
+	return floor, found
+}
+
 // isLowHangingFruit detects items that are likely quick wins
 func (h *Heuristics) isLowHangingFruit(n *model.Item) bool {
 	// Check for configured quick win labels
@@ -163,6 +347,16 @@ func (h *Heuristics) isLowHangingFruit(n *model.Item) bool {
 		if pr.ChangedFiles <= h.Weights.SmallPRMaxFiles && (pr.Additions+pr.Deletions) <= h.Weights.SmallPRMaxLines {
 			return true
 		}
+
+		// So are PRs that only touch docs, tests, or dependencies
+		switch prDiffKind(pr) {
+		case diffKindDocs:
+			return h.Weights.DocsOnlyPRIsQuickWin
+		case diffKindTests:
+			return h.Weights.TestsOnlyPRIsQuickWin
+		case diffKindDependencies:
+			return h.Weights.DependencyBumpIsQuickWin
+		}
 	}
 
 	return false
@@ -172,6 +366,23 @@ func (h *Heuristics) isLowHangingFruit(n *model.Item) bool {
 func (h *Heuristics) Priority(n *model.Item, score int) PriorityLevel {
 	reason := n.Reason
 
+	// Items nobody has touched in a long time stop competing for attention
+	if h.isArchived(n) {
+		return PriorityArchive
+	}
+
+	// A review requested from one of the user's teams can be picked up by
+	// someone else, so it ranks below every review requested from them
+	if n.TeamReviewRequest(h.CurrentUser) {
+		return h.teamReviewPriority(n, score)
+	}
+
+	// Blocked work can't be finished however pressing it looks, so it ranks
+	// by score alone, below Urgent
+	if len(n.BlockedBy) > 0 {
+		return h.blockedPriority(score)
+	}
+
 	// Urgent: review requests (if enabled)
 	if reason == model.ReasonReviewRequested && h.Weights.ReviewRequestedIsUrgent {
 		return PriorityUrgent
@@ -205,8 +416,8 @@ func (h *Heuristics) Priority(n *model.Item, score int) PriorityLevel {
 		return PriorityQuickWin
 	}
 
-	// Important: author notifications, assignments
-	if reason == model.ReasonAuthor || reason == model.ReasonAssign || reason == model.ReasonTeamMention {
+	// Important: author notifications, assignments, unblocked work
+	if reason == model.ReasonAuthor || reason == model.ReasonAssign || reason == model.ReasonTeamMention || reason == model.ReasonUpstream {
 		return PriorityImportant
 	}
 
@@ -224,12 +435,66 @@ func (h *Heuristics) Priority(n *model.Item, score int) PriorityLevel {
 	return PriorityFYI
 }
 
+// isArchived reports whether the item has had no activity for longer than
+// the archive horizon. Activity is the last human activity when known.
+func (h *Heuristics) isArchived(n *model.Item) bool {
+	if h.Weights.ArchiveAfterDays <= 0 {
+		return false
+	}
+	last := n.UpdatedAt
+	if n.LastHumanActivityAt != nil {
+		last = *n.LastHumanActivityAt
+	}
+	return time.Since(last) > time.Duration(h.Weights.ArchiveAfterDays)*24*time.Hour
+}
+
+// teamReviewPriority is Priority for team review requests. They are never
+// Urgent, so review requests made to the user directly always come first.
+func (h *Heuristics) teamReviewPriority(n *model.Item, score int) PriorityLevel {
+	if h.isLowHangingFruit(n) {
+		return PriorityQuickWin
+	}
+	if score >= h.Weights.NotablePromotionThreshold {
+		return PriorityImportant
+	}
+	if score >= h.Weights.FYIPromotionThreshold {
+		return PriorityNotable
+	}
+	return PriorityFYI
+}
+
+// blockedPriority ranks an item blocked by an open dependency by its score
+// alone, skipping the urgency triggers and quick wins.
+func (h *Heuristics) blockedPriority(score int) PriorityLevel {
+	if score >= h.Weights.NotablePromotionThreshold {
+		return PriorityImportant
+	}
+	if score >= h.Weights.FYIPromotionThreshold {
+		return PriorityNotable
+	}
+	return PriorityFYI
+}
+
+// teamRequest reports whether a PR the user was notified about for another
+// reason, such as a team mention, asks one of their teams for a review.
+// Review request notifications are scored by TeamReviewRequest instead.
+func (h *Heuristics) teamRequest(n *model.Item) bool {
+	return n.Reason != model.ReasonReviewRequested && n.RequestsReviewFromTeam(h.CurrentUser, h.Teams)
+}
+
 // Action suggests what action the user should take
 func (h *Heuristics) Action(n *model.Item) string {
 	reason := n.Reason
 
+	if h.teamRequest(n) && n.State != model.StateClosed && n.State != model.StateMerged {
+		return "Review PR (team request)"
+	}
+
 	switch reason {
 	case model.ReasonReviewRequested:
+		if n.TeamReviewRequest(h.CurrentUser) {
+			return "Review PR (team request)"
+		}
 		return "Review PR"
 	case model.ReasonMention:
 		return "Respond to mention"
@@ -248,6 +513,8 @@ func (h *Heuristics) Action(n *model.Item) string {
 		return "Check state change"
 	case model.ReasonSubscribed:
 		return "Review activity (subscribed)"
+	case model.ReasonUpstream:
+		return "Resume work unblocked upstream"
 	default:
 		return "Review notification"
 	}
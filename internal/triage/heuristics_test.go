@@ -1,6 +1,8 @@
 package triage
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,6 +69,76 @@ func TestAgeBonusScalesByBase(t *testing.T) {
 	}
 }
 
+func TestAgeFromReviewRequest(t *testing.T) {
+	h := NewHeuristics("testuser", config.DefaultScoreWeights(), config.DefaultQuickWinLabels())
+
+	// CI pushes keep UpdatedAt fresh, but the request has been waiting a week
+	reviewRequested := func(events ...model.ReviewEvent) *model.Item {
+		return &model.Item{
+			Reason:    model.ReasonReviewRequested,
+			Type:      model.ItemTypePullRequest,
+			UpdatedAt: time.Now(),
+			Details:   &model.PRDetails{ReviewEvents: events},
+		}
+	}
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	requested := model.ReviewEvent{Kind: model.ReviewEventRequested, Login: "testuser", At: weekAgo}
+
+	fresh := h.Score(reviewRequested())
+	pending := h.Score(reviewRequested(requested))
+	reviewed := h.Score(reviewRequested(requested,
+		model.ReviewEvent{Kind: model.ReviewEventReviewed, Login: "testuser", At: time.Now().Add(-time.Hour)}))
+
+	if pending <= fresh {
+		t.Errorf("week-old request (%d) should outscore a fresh one (%d)", pending, fresh)
+	}
+	if reviewed != fresh {
+		t.Errorf("reviewed request (%d) should age from updatedAt like a fresh one (%d)", reviewed, fresh)
+	}
+}
+
+func TestHotTopic(t *testing.T) {
+	h := NewHeuristics("testuser", config.DefaultScoreWeights(), config.DefaultQuickWinLabels())
+
+	comments := func(n int, ago time.Duration) []time.Time {
+		times := make([]time.Time, n)
+		for i := range times {
+			times[i] = time.Now().Add(-ago)
+		}
+		return times
+	}
+	item := func(count int, times []time.Time) *model.Item {
+		return &model.Item{
+			Reason:       model.ReasonSubscribed,
+			UpdatedAt:    time.Now(),
+			CommentCount: count,
+			CommentTimes: times,
+			Details:      &model.IssueDetails{},
+		}
+	}
+	quiet := h.Score(item(2, nil))
+
+	tests := []struct {
+		name string
+		item *model.Item
+		want int
+	}{
+		{"quiet", item(2, comments(2, time.Hour)), 0},
+		{"long but quiet megathread", item(300, comments(20, 30*24*time.Hour)), 15},
+		{"flaring", item(6, comments(6, time.Hour)), 20},
+		{"old comments don't count", item(6, comments(6, 72*time.Hour)), 0},
+		{"bonuses don't stack", item(300, comments(20, time.Hour)), 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.Score(tt.item) - quiet; got != tt.want {
+				t.Errorf("hot topic bonus = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsLowHangingFruit(t *testing.T) {
 	h := NewHeuristics("testuser", config.DefaultScoreWeights(), config.DefaultQuickWinLabels())
 
@@ -141,6 +213,30 @@ func TestIsLowHangingFruit(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "large docs-only PR is low hanging fruit",
+			item: &model.Item{
+				Type: model.ItemTypePullRequest,
+				Details: &model.PRDetails{
+					ChangedFiles: 8,
+					Additions:    400,
+					Files:        []string{"README.md", "docs/a.md", "docs/b.md", "docs/c.md", "docs/d.md", "docs/e.md", "docs/f.md", "docs/g.md"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "large PR mixing tests and code is not low hanging fruit",
+			item: &model.Item{
+				Type: model.ItemTypePullRequest,
+				Details: &model.PRDetails{
+					ChangedFiles: 6,
+					Additions:    400,
+					Files:        []string{"a.go", "a_test.go", "b_test.go", "c_test.go", "d_test.go", "e_test.go"},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "no matching labels",
 			item: &model.Item{
@@ -575,3 +671,474 @@ func TestAction(t *testing.T) {
 		})
 	}
 }
+
+func TestIsLowHangingFruitToggles(t *testing.T) {
+	bump := &model.Item{
+		Type: model.ItemTypePullRequest,
+		Details: &model.PRDetails{
+			ChangedFiles: 6,
+			Additions:    900,
+			Deletions:    700,
+			Files:        []string{"go.mod", "go.sum", "web/package.json", "web/package-lock.json", "docs/go.mod", "docs/go.sum"},
+		},
+	}
+
+	weights := config.DefaultScoreWeights()
+	if !NewHeuristics("testuser", weights, nil).isLowHangingFruit(bump) {
+		t.Error("isLowHangingFruit() = false for a dependency bump, want true")
+	}
+
+	weights.DependencyBumpIsQuickWin = false
+	if NewHeuristics("testuser", weights, nil).isLowHangingFruit(bump) {
+		t.Error("isLowHangingFruit() = true with dependency bumps disabled, want false")
+	}
+}
+
+func TestSeverityFloors(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	weights.SeverityFloors = map[string]string{"p0": "urgent", "sev-2": "important", "p3": "notable", "p9": "critical"}
+	e := NewEngine("testuser", weights, nil)
+
+	tests := []struct {
+		name   string
+		reason model.ItemReason
+		labels []string
+		want   PriorityLevel
+	}{
+		{"no severity label", model.ReasonSubscribed, []string{"bug"}, PriorityFYI},
+		{"raises to urgent", model.ReasonSubscribed, []string{"P0"}, PriorityUrgent},
+		{"hyphens match spaces", model.ReasonSubscribed, []string{"sev 2"}, PriorityImportant},
+		{"highest floor wins", model.ReasonSubscribed, []string{"p3", "P0"}, PriorityUrgent},
+		{"never lowers", model.ReasonAuthor, []string{"p3"}, PriorityImportant},
+		{"unknown priority ignored", model.ReasonSubscribed, []string{"p9"}, PriorityFYI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := model.Item{Reason: tt.reason, Labels: tt.labels, UpdatedAt: time.Now()}
+			if got := e.Prioritize([]model.Item{item})[0].Priority; got != tt.want {
+				t.Errorf("Priority = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoWeights(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	critical := weights
+	critical.Subscribed = 200
+	weights.RepoWeights = map[string]config.ScoreWeights{"org/critical": critical}
+	e := NewEngine("testuser", weights, nil)
+
+	items := []model.Item{
+		{ID: "other", Reason: model.ReasonSubscribed, UpdatedAt: time.Now(), Repository: model.Repository{FullName: "org/other"}},
+		{ID: "critical", Reason: model.ReasonSubscribed, UpdatedAt: time.Now(), Repository: model.Repository{FullName: "Org/Critical"}},
+	}
+	got := e.Prioritize(items)
+	if got[0].ID != "critical" || got[0].Score != 200 {
+		t.Errorf("Prioritize()[0] = %s scored %d, want critical scored with its repo's weights", got[0].ID, got[0].Score)
+	}
+	if got[1].Score != weights.Subscribed {
+		t.Errorf("Prioritize()[1].Score = %d, want %d from the top-level weights", got[1].Score, weights.Subscribed)
+	}
+}
+
+func TestFormFieldFactors(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	weights.FormFieldScores = map[string]map[string]map[string]int{
+		"acme/app": {"severity": {"critical": 50, "low": -10}},
+	}
+	h := NewHeuristics("testuser", weights, nil)
+
+	tests := []struct {
+		name   string
+		repo   string
+		fields map[string]string
+		want   int
+	}{
+		{"no form", "acme/app", nil, 0},
+		{"scored answer", "Acme/App", map[string]string{"Severity": "Critical"}, 50},
+		{"negative answer", "acme/app", map[string]string{"Severity": "low"}, -10},
+		{"unscored answer", "acme/app", map[string]string{"Severity": "medium"}, 0},
+		{"other repo", "acme/web", map[string]string{"Severity": "critical"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sheet scoreSheet
+			h.formFieldFactors(&sheet, &model.Item{Repository: model.Repository{FullName: tt.repo}, FormFields: tt.fields})
+			if got := sheet.total(); got != tt.want {
+				t.Errorf("formFieldFactors() total = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelFactors(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	weights.LabelScores = map[string]int{"security": 40, "p0": 60, "chore": -20, "needs-triage": 5}
+	h := NewHeuristics("testuser", weights, nil)
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   int
+	}{
+		{"no labels", nil, 0},
+		{"one label", []string{"Security"}, 40},
+		{"labels add up", []string{"security", "p0"}, 100},
+		{"negative score", []string{"chore"}, -20},
+		{"hyphens match spaces", []string{"needs triage"}, 5},
+		{"whole label must match", []string{"p00", "security-review"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sheet scoreSheet
+			h.labelFactors(&sheet, &model.Item{Labels: tt.labels})
+			if got := sheet.total(); got != tt.want {
+				t.Errorf("labelFactors() total = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// Label scores apply without enrichment, and can't push a score below zero
+	item := &model.Item{Reason: model.ReasonSubscribed, Labels: []string{"chore"}, UpdatedAt: time.Now()}
+	if got := h.Score(item); got != 0 {
+		t.Errorf("Score() = %d, want 0", got)
+	}
+	item.Labels = []string{"p0"}
+	if got := h.Score(item); got != weights.Subscribed+60 {
+		t.Errorf("Score() = %d, want %d", got, weights.Subscribed+60)
+	}
+}
+
+func TestReminderBonus(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	h := NewHeuristics("testuser", weights, nil)
+
+	item := &model.Item{Reason: model.ReasonSubscribed, UpdatedAt: time.Now()}
+	before := h.Score(item)
+	if p := h.Priority(item, before); p != PriorityFYI {
+		t.Fatalf("Priority() = %s without a reminder, want fyi", p)
+	}
+
+	item.Reminder = &model.Reminder{At: time.Now(), Note: "ping after release"}
+	after := h.Score(item)
+	if after != before+weights.ReminderBonus {
+		t.Errorf("Score() = %d with a due reminder, want %d", after, before+weights.ReminderBonus)
+	}
+	if p := h.Priority(item, after); p != PriorityImportant {
+		t.Errorf("Priority() = %s with a due reminder, want important", p)
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	h := NewHeuristics("testuser", weights, nil)
+	review := func() *model.Item {
+		return &model.Item{Reason: model.ReasonReviewRequested, Type: model.ItemTypePullRequest, UpdatedAt: time.Now()}
+	}
+
+	plain := review()
+	blocked := review()
+	blocked.BlockedBy = []string{"org/app#1"}
+	base := review()
+	base.Blocks = []string{"org/app#3"}
+
+	if got, want := h.Score(blocked), h.Score(plain)+weights.BlockedPenalty; got != want {
+		t.Errorf("Score() blocked = %d, want %d", got, want)
+	}
+	if got, want := h.Score(base), h.Score(plain)+weights.UnblocksBonus; got != want {
+		t.Errorf("Score() unblocking = %d, want %d", got, want)
+	}
+	// A blocked review request skips the urgency trigger
+	if p := h.Priority(blocked, h.Score(blocked)); p != PriorityImportant {
+		t.Errorf("Priority() blocked = %s, want important", p)
+	}
+	if p := h.Priority(base, h.Score(base)); p != PriorityUrgent {
+		t.Errorf("Priority() unblocking = %s, want urgent", p)
+	}
+
+	// Blocked in the middle of a stack: the penalty applies, not the bonus
+	blocked.Blocks = []string{"org/app#4"}
+	if got, want := h.Score(blocked), h.Score(plain)+weights.BlockedPenalty; got != want {
+		t.Errorf("Score() blocked and unblocking = %d, want %d", got, want)
+	}
+}
+
+func TestPlanningFactors(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	weights.ProjectColumnScores = map[string]int{"in review": 30}
+	h := NewHeuristics("testuser", weights, nil)
+	due := func(days int) *model.Milestone {
+		at := time.Now().Add(time.Duration(days)*24*time.Hour + time.Hour)
+		return &model.Milestone{Title: "v2", DueOn: &at}
+	}
+
+	tests := []struct {
+		name  string
+		item  model.Item
+		bonus int
+	}{
+		{"no milestone", model.Item{}, 0},
+		{"milestone without a due date", model.Item{Milestone: &model.Milestone{Title: "someday"}}, 0},
+		{"due soon", model.Item{Milestone: due(3)}, weights.MilestoneDueSoonBonus},
+		{"overdue", model.Item{Milestone: due(-2)}, weights.MilestoneDueSoonBonus},
+		{"due later", model.Item{Milestone: due(30)}, 0},
+		{"closed", model.Item{State: model.StateClosed, Milestone: due(1)}, 0},
+		{"configured column", model.Item{Projects: []model.ProjectItem{{Project: "Roadmap", Status: "In Review"}}}, 30},
+		{"column on two boards", model.Item{Projects: []model.ProjectItem{
+			{Project: "Roadmap", Status: "In Review"},
+			{Project: "Sprint", Status: "in review"},
+		}}, 30},
+		{"other column", model.Item{Projects: []model.ProjectItem{{Project: "Roadmap", Status: "Done"}}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.item.Reason = model.ReasonSubscribed
+			tt.item.UpdatedAt = time.Now()
+			if got, want := h.Score(&tt.item), weights.Subscribed+tt.bonus; got != want {
+				t.Errorf("Score() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestTeamReviewRequest(t *testing.T) {
+	h := NewHeuristics("testuser", config.DefaultScoreWeights(), config.DefaultQuickWinLabels())
+	request := func(reviewers, teams []string) *model.Item {
+		return &model.Item{
+			Reason:    model.ReasonReviewRequested,
+			Type:      model.ItemTypePullRequest,
+			UpdatedAt: time.Now(),
+			Details: &model.PRDetails{
+				ChangedFiles:       20,
+				Additions:          600,
+				RequestedReviewers: reviewers,
+				RequestedTeams:     teams,
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		item         *model.Item
+		wantTeam     bool
+		wantPriority PriorityLevel
+		wantAction   string
+	}{
+		{"requested directly", request([]string{"TestUser"}, nil), false, PriorityUrgent, "Review PR"},
+		{"requested directly and through a team", request([]string{"testuser", "platform"}, []string{"platform"}), false, PriorityUrgent, "Review PR"},
+		{"requested through a team", request([]string{"platform"}, []string{"platform"}), true, PriorityImportant, "Review PR (team request)"},
+		{"reviewers unknown", &model.Item{Reason: model.ReasonReviewRequested, Type: model.ItemTypePullRequest, UpdatedAt: time.Now()}, false, PriorityUrgent, "Review PR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.TeamReviewRequest(h.CurrentUser); got != tt.wantTeam {
+				t.Errorf("TeamReviewRequest() = %v, want %v", got, tt.wantTeam)
+			}
+			score := h.Score(tt.item)
+			if got := h.Priority(tt.item, score); got != tt.wantPriority {
+				t.Errorf("Priority() = %s, want %s", got, tt.wantPriority)
+			}
+			if got := h.Action(tt.item); got != tt.wantAction {
+				t.Errorf("Action() = %q, want %q", got, tt.wantAction)
+			}
+		})
+	}
+
+	direct, team := request([]string{"testuser"}, nil), request([]string{"platform"}, []string{"platform"})
+	if h.Score(team) >= h.Score(direct) {
+		t.Errorf("team request score %d should be below direct request score %d", h.Score(team), h.Score(direct))
+	}
+}
+
+func TestCodeOwnersFactors(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	h := NewHeuristics("testuser", weights, nil)
+	pr := func(owns *bool) *model.Item {
+		return &model.Item{
+			Reason:    model.ReasonReviewRequested,
+			Type:      model.ItemTypePullRequest,
+			State:     model.StateOpen,
+			UpdatedAt: time.Now(),
+			Details:   &model.PRDetails{RequestedTeams: []string{"acme/everyone"}, OwnsChanges: owns},
+		}
+	}
+	owned, notOwned := true, false
+
+	tests := []struct {
+		name    string
+		owns    *bool
+		factor  string
+		setting string
+		points  int
+	}{
+		{"owned", &owned, "changes code you own", "codeowners_bonus", weights.CodeOwnersBonus},
+		{"not owned", &notOwned, "changes no code you own", "codeowners_penalty", weights.CodeOwnersPenalty},
+		{"not checked", nil, "", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := pr(tt.owns)
+			if got, want := h.Score(item), h.Score(pr(nil))+tt.points; got != want {
+				t.Errorf("Score() = %d, want %d", got, want)
+			}
+			var found *ScoreFactor
+			for _, f := range h.Factors(item) {
+				if strings.HasPrefix(f.Name, "changes ") {
+					found = &f
+				}
+			}
+			switch {
+			case tt.factor == "" && found != nil:
+				t.Errorf("Factors() has %q, want no ownership factor", found.Name)
+			case tt.factor != "" && (found == nil || found.Name != tt.factor || !slices.Equal(found.Setting, []string{"scoring", tt.setting})):
+				t.Errorf("Factors() ownership factor = %+v, want %q from scoring.%s", found, tt.factor, tt.setting)
+			}
+		})
+	}
+}
+
+func TestTeamReviewBonus(t *testing.T) {
+	teams := []string{"acme/platform", "acme/release"}
+	pr := func(reason model.ItemReason, reviewers, requestedTeams []string) *model.Item {
+		return &model.Item{
+			Reason:    reason,
+			Type:      model.ItemTypePullRequest,
+			State:     model.StateOpen,
+			UpdatedAt: time.Now(),
+			Details: &model.PRDetails{
+				RequestedReviewers: reviewers,
+				RequestedTeams:     requestedTeams,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		item       *model.Item
+		wantBonus  bool
+		wantAction string
+	}{
+		{"team mention asking my team", pr(model.ReasonTeamMention, []string{"Platform"}, []string{"Acme/Platform"}), true, "Review PR (team request)"},
+		{"subscribed asking my team", pr(model.ReasonSubscribed, []string{"Release"}, []string{"acme/release"}), true, "Review PR (team request)"},
+		{"asking another team", pr(model.ReasonTeamMention, []string{"Docs"}, []string{"acme/docs"}), false, "Team mentioned - check if relevant"},
+		{"asking me directly too", pr(model.ReasonTeamMention, []string{"testuser", "Platform"}, []string{"acme/platform"}), false, "Team mentioned - check if relevant"},
+		{"review request scored by team_review_requested", pr(model.ReasonReviewRequested, []string{"Platform"}, []string{"acme/platform"}), false, "Review PR (team request)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			without := NewEngine("testuser", config.DefaultScoreWeights(), nil).heuristics
+			with := NewEngine("testuser", config.DefaultScoreWeights(), nil, WithTeams(teams)).heuristics
+
+			want := without.Score(tt.item)
+			if tt.wantBonus {
+				want += config.DefaultScoreWeights().TeamReviewBonus
+			}
+			if got := with.Score(tt.item); got != want {
+				t.Errorf("Score() = %d, want %d", got, want)
+			}
+			if got := with.Action(tt.item); got != tt.wantAction {
+				t.Errorf("Action() = %q, want %q", got, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestWithTeamsRepoWeights(t *testing.T) {
+	weights := config.DefaultScoreWeights()
+	weights.RepoWeights = map[string]config.ScoreWeights{"acme/api": config.DefaultScoreWeights()}
+	e := NewEngine("testuser", weights, nil, WithTeams([]string{"acme/platform"}))
+
+	n := &model.Item{
+		Reason:     model.ReasonTeamMention,
+		Type:       model.ItemTypePullRequest,
+		Repository: model.Repository{FullName: "acme/api"},
+		Details:    &model.PRDetails{RequestedTeams: []string{"acme/platform"}},
+	}
+	if h := e.heuristics.ForItem(n); !h.teamRequest(n) {
+		t.Error("repository heuristics should know the user's teams")
+	}
+}
+
+func TestArchive(t *testing.T) {
+	day := 24 * time.Hour
+	ago := func(d time.Duration) *time.Time {
+		t := time.Now().Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name         string
+		archiveAfter int
+		item         *model.Item
+		want         bool
+	}{
+		{"disabled", 0, &model.Item{Reason: model.ReasonSubscribed, UpdatedAt: time.Now().Add(-400 * day)}, false},
+		{"recently updated", 180, &model.Item{Reason: model.ReasonSubscribed, UpdatedAt: time.Now().Add(-10 * day)}, false},
+		{"untouched past the horizon", 180, &model.Item{Reason: model.ReasonSubscribed, UpdatedAt: time.Now().Add(-200 * day)}, true},
+		{"review request is archived too", 180, &model.Item{Reason: model.ReasonReviewRequested, UpdatedAt: time.Now().Add(-200 * day)}, true},
+		{"bots kept it updated", 180, &model.Item{Reason: model.ReasonAuthor, UpdatedAt: time.Now(), LastHumanActivityAt: ago(200 * day)}, true},
+		{"human activity recently", 180, &model.Item{Reason: model.ReasonAuthor, UpdatedAt: time.Now().Add(-200 * day), LastHumanActivityAt: ago(day)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights := config.DefaultScoreWeights()
+			weights.ArchiveAfterDays = tt.archiveAfter
+			h := NewHeuristics("testuser", weights, nil)
+
+			got := h.Priority(tt.item, h.Score(tt.item)) == PriorityArchive
+			if got != tt.want {
+				t.Errorf("archived = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeScore(t *testing.T) {
+	// Default thresholds: Notable 35, Important 60, Urgent 100
+	h := NewHeuristics("testuser", config.DefaultScoreWeights(), nil)
+
+	tests := []struct {
+		score int
+		want  int
+	}{
+		{0, 0},
+		{10, 7},
+		{35, 25},
+		{60, 50},
+		{80, 62},
+		{100, 75},
+		{150, 87},
+		{200, 100},
+		{500, 100},
+	}
+	for _, tt := range tests {
+		if got := h.NormalizeScore(tt.score); got != tt.want {
+			t.Errorf("NormalizeScore(%d) = %d, want %d", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestPrioritizeNormalizesScores(t *testing.T) {
+	item := makeItem("1", model.ReasonReviewRequested, model.SubjectPullRequest, nil)
+	weights := config.DefaultScoreWeights()
+	raw := NewEngine("testuser", weights, nil).Prioritize([]model.Item{item})
+
+	weights.NormalizeScores = true
+	e := NewEngine("testuser", weights, nil)
+	normalized := e.Prioritize([]model.Item{item})
+
+	if want := e.heuristics.NormalizeScore(raw[0].Score); normalized[0].Score != want {
+		t.Errorf("score = %d, want %d (raw %d)", normalized[0].Score, want, raw[0].Score)
+	}
+	if normalized[0].Priority != raw[0].Priority {
+		t.Errorf("priority = %s, want %s as for the raw score", normalized[0].Priority, raw[0].Priority)
+	}
+}
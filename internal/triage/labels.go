@@ -0,0 +1,87 @@
+package triage
+
+import (
+	"slices"
+	"strings"
+)
+
+// LabelFilter keeps items with any of the Include labels, then drops
+// those with any of the Exclude labels. Labels match case-insensitively.
+type LabelFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// ParseLabelFilter parses label filter values such as "security,bug" or
+// "!wontfix". Each value may hold several comma-separated labels; labels
+// in include with a leading "!" are excluded, as are all labels in exclude.
+func ParseLabelFilter(include, exclude []string) LabelFilter {
+	var f LabelFilter
+	split := func(values []string, add func(label string, negated bool)) {
+		for _, value := range values {
+			for _, part := range strings.Split(value, ",") {
+				part = strings.TrimSpace(part)
+				label, negated := strings.CutPrefix(part, "!")
+				if label = strings.TrimSpace(label); label != "" {
+					add(label, negated)
+				}
+			}
+		}
+	}
+	split(include, func(label string, negated bool) {
+		if negated {
+			f.Exclude = append(f.Exclude, label)
+		} else {
+			f.Include = append(f.Include, label)
+		}
+	})
+	split(exclude, func(label string, _ bool) {
+		f.Exclude = append(f.Exclude, label)
+	})
+	return f
+}
+
+// IsZero reports whether the filter keeps every item.
+func (f LabelFilter) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Matches reports whether an item with labels passes the filter.
+func (f LabelFilter) Matches(labels []string) bool {
+	has := func(targets []string) bool {
+		return slices.ContainsFunc(labels, func(label string) bool {
+			return slices.ContainsFunc(targets, func(target string) bool {
+				return strings.EqualFold(label, target)
+			})
+		})
+	}
+	if len(f.Include) > 0 && !has(f.Include) {
+		return false
+	}
+	return !has(f.Exclude)
+}
+
+// String returns the filter as ParseLabelFilter reads it, such as
+// "security,!wontfix".
+func (f LabelFilter) String() string {
+	parts := slices.Clone(f.Include)
+	for _, label := range f.Exclude {
+		parts = append(parts, "!"+label)
+	}
+	return strings.Join(parts, ",")
+}
+
+// FilterByLabels keeps items matching filter.
+func FilterByLabels(items []PrioritizedItem, filter LabelFilter) []PrioritizedItem {
+	if filter.IsZero() {
+		return items
+	}
+
+	filtered := make([]PrioritizedItem, 0, len(items))
+	for _, item := range items {
+		if filter.Matches(item.Labels) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,60 @@
+package triage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestParseLabelFilter(t *testing.T) {
+	got := ParseLabelFilter([]string{"security, bug", "!wontfix", " "}, []string{"duplicate,!invalid"})
+	want := LabelFilter{
+		Include: []string{"security", "bug"},
+		Exclude: []string{"wontfix", "duplicate", "invalid"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLabelFilter() = %+v, want %+v", got, want)
+	}
+	if s := got.String(); s != "security,bug,!wontfix,!duplicate,!invalid" {
+		t.Errorf("String() = %q", s)
+	}
+	if !ParseLabelFilter(nil, []string{""}).IsZero() {
+		t.Error("blank filter should be zero")
+	}
+}
+
+func TestFilterByLabels(t *testing.T) {
+	item := func(id string, labels ...string) PrioritizedItem {
+		return PrioritizedItem{Item: model.Item{ID: id, Labels: labels}}
+	}
+	items := []PrioritizedItem{
+		item("security", "Security", "bug"),
+		item("wontfix", "security", "wontfix"),
+		item("bug", "bug"),
+		item("unlabeled"),
+	}
+
+	tests := []struct {
+		name   string
+		filter LabelFilter
+		want   []string
+	}{
+		{"no filter", LabelFilter{}, []string{"security", "wontfix", "bug", "unlabeled"}},
+		{"include", LabelFilter{Include: []string{"security"}}, []string{"security", "wontfix"}},
+		{"any of several", LabelFilter{Include: []string{"security", "bug"}}, []string{"security", "wontfix", "bug"}},
+		{"exclude", LabelFilter{Exclude: []string{"WONTFIX"}}, []string{"security", "bug", "unlabeled"}},
+		{"include and exclude", LabelFilter{Include: []string{"security"}, Exclude: []string{"wontfix"}}, []string{"security"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, item := range FilterByLabels(items, tt.filter) {
+				got = append(got, item.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterByLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
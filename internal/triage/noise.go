@@ -0,0 +1,178 @@
+package triage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+const (
+	// minNoiseItems is the fewest notifications from a repo or author an
+	// exclusion is suggested from.
+	minNoiseItems = 5
+
+	// noiseShareLimit is the share of a repo's or author's notifications
+	// dismissed without acting, at or past which excluding it is suggested.
+	noiseShareLimit = 90
+)
+
+// ResolvedLookup reports which items were marked done.
+type ResolvedLookup interface {
+	IsResolved(key string) bool
+}
+
+// NoiseOptions configures Noise.
+type NoiseOptions struct {
+	CurrentUser string
+	// Resolved tells which items were marked done in triage; nil counts
+	// only notifications read on GitHub as dismissed.
+	Resolved ResolvedLookup
+	// ExcludedRepos and ExcludedAuthors are already excluded, and are
+	// not suggested again.
+	ExcludedRepos   []string
+	ExcludedAuthors []string
+}
+
+// NoiseStat counts the notifications from one repo, reason, or author.
+type NoiseStat struct {
+	Name      string `json:"name"`
+	Total     int    `json:"total"`
+	Acted     int    `json:"acted"`
+	Dismissed int    `json:"dismissed"`
+}
+
+// DismissedShare returns the dismissed notifications as a whole
+// percentage of the total.
+func (s NoiseStat) DismissedShare() int {
+	if s.Total == 0 {
+		return 0
+	}
+	return s.Dismissed * 100 / s.Total
+}
+
+// NoiseSuggestion proposes an exclude_repos or exclude_authors entry.
+type NoiseSuggestion struct {
+	Key    string `json:"key"` // Config key, exclude_repos or exclude_authors
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+	// Watching counts a suggested repo's notifications that came from
+	// watching it, which unwatching the repo would stop.
+	Watching int `json:"watching,omitempty"`
+}
+
+// NoiseReport describes which notifications are dismissed without being
+// acted on, by repo, reason, and author, most dismissed first.
+type NoiseReport struct {
+	Total       int               `json:"total"`
+	Acted       int               `json:"acted"`
+	Dismissed   int               `json:"dismissed"`
+	Repos       []NoiseStat       `json:"repos"`
+	Reasons     []NoiseStat       `json:"reasons"`
+	Authors     []NoiseStat       `json:"authors"`
+	Suggestions []NoiseSuggestion `json:"suggestions"`
+}
+
+// Noise reports how notifications were handled. A notification was acted
+// on when the user authored the item, or commented on, reviewed, opened, or
+// replied to it; it was dismissed when, without that, it was marked done
+// or read. The rest are still waiting and count toward neither.
+// Suggestions name repos and authors whose notifications are nearly all
+// dismissed.
+func Noise(items []model.Item, opts NoiseOptions) NoiseReport {
+	var report NoiseReport
+	repos := make(map[string]*NoiseStat)
+	reasons := make(map[string]*NoiseStat)
+	authors := make(map[string]*NoiseStat)
+	watching := make(map[string]int)
+	count := func(stats map[string]*NoiseStat, name string, acted, dismissed bool) {
+		s, ok := stats[strings.ToLower(name)]
+		if !ok {
+			s = &NoiseStat{Name: name}
+			stats[strings.ToLower(name)] = s
+		}
+		s.Total++
+		if acted {
+			s.Acted++
+		}
+		if dismissed {
+			s.Dismissed++
+		}
+	}
+
+	for i := range items {
+		n := &items[i]
+		if !n.IsNotification() {
+			continue
+		}
+		acted := n.LastInteractionAt != nil || strings.EqualFold(n.Author, opts.CurrentUser)
+		dismissed := !acted && (!n.Unread || (opts.Resolved != nil && opts.Resolved.IsResolved(n.Key())))
+
+		report.Total++
+		if acted {
+			report.Acted++
+		}
+		if dismissed {
+			report.Dismissed++
+		}
+		count(repos, n.Repository.FullName, acted, dismissed)
+		if n.Reason == model.ReasonSubscribed {
+			watching[strings.ToLower(n.Repository.FullName)]++
+		}
+		count(reasons, string(n.Reason), acted, dismissed)
+		if n.Author != "" && !strings.EqualFold(n.Author, opts.CurrentUser) {
+			count(authors, n.Author, acted, dismissed)
+		}
+	}
+
+	report.Repos = sortedNoiseStats(repos)
+	report.Reasons = sortedNoiseStats(reasons)
+	report.Authors = sortedNoiseStats(authors)
+	repoSuggestions := noiseSuggestions("exclude_repos", report.Repos, opts.ExcludedRepos)
+	for i := range repoSuggestions {
+		repoSuggestions[i].Watching = watching[strings.ToLower(repoSuggestions[i].Value)]
+	}
+	report.Suggestions = append(repoSuggestions,
+		noiseSuggestions("exclude_authors", report.Authors, opts.ExcludedAuthors)...)
+	return report
+}
+
+// sortedNoiseStats returns stats with the most dismissed first, then the
+// most notifications, then by name.
+func sortedNoiseStats(stats map[string]*NoiseStat) []NoiseStat {
+	sorted := make([]NoiseStat, 0, len(stats))
+	for _, s := range stats {
+		sorted = append(sorted, *s)
+	}
+	slices.SortFunc(sorted, func(a, b NoiseStat) int {
+		if a.Dismissed != b.Dismissed {
+			return b.Dismissed - a.Dismissed
+		}
+		if a.Total != b.Total {
+			return b.Total - a.Total
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return sorted
+}
+
+// noiseSuggestions suggests excluding the stats with enough notifications,
+// nearly all of them dismissed and none acted on, that aren't excluded yet.
+func noiseSuggestions(key string, stats []NoiseStat, excluded []string) []NoiseSuggestion {
+	var suggestions []NoiseSuggestion
+	for _, s := range stats {
+		if s.Total < minNoiseItems || s.Acted > 0 || s.DismissedShare() < noiseShareLimit {
+			continue
+		}
+		if slices.ContainsFunc(excluded, func(e string) bool { return strings.EqualFold(e, s.Name) }) {
+			continue
+		}
+		suggestions = append(suggestions, NoiseSuggestion{
+			Key:    key,
+			Value:  s.Name,
+			Reason: fmt.Sprintf("%d of %d notifications dismissed, none acted on", s.Dismissed, s.Total),
+		})
+	}
+	return suggestions
+}
@@ -0,0 +1,89 @@
+package triage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// resolvedKeys marks the listed keys done.
+type resolvedKeys map[string]bool
+
+func (r resolvedKeys) IsResolved(key string) bool { return r[key] }
+
+func TestNoise(t *testing.T) {
+	now := time.Now()
+	id := 0
+	notification := func(repo, author string, reason model.ItemReason, unread bool) model.Item {
+		id++
+		return model.Item{
+			ID:         fmt.Sprint(1000 + id),
+			Reason:     reason,
+			Unread:     unread,
+			Number:     id,
+			Author:     author,
+			Repository: model.Repository{FullName: repo},
+		}
+	}
+
+	var items []model.Item
+	// acme/infra: six notifications from a bot, read or marked done unseen
+	for i := range 6 {
+		items = append(items, notification("acme/infra", "renovate[bot]", model.ReasonSubscribed, i%2 == 0))
+	}
+	// acme/api: acted on, including the user's own PR, and one still waiting
+	commented := notification("acme/api", "alice", model.ReasonMention, false)
+	commented.LastInteractionAt = &now
+	items = append(items,
+		commented,
+		notification("acme/api", "me", model.ReasonAuthor, false),
+		notification("acme/api", "alice", model.ReasonMention, true),
+	)
+	// Items found by search aren't notifications
+	items = append(items, model.Item{ID: "review-requested-9", Reason: model.ReasonReviewRequested, Repository: model.Repository{FullName: "acme/api"}})
+
+	resolved := resolvedKeys{}
+	for _, n := range items[:6] {
+		if n.Unread {
+			resolved[n.Key()] = true
+		}
+	}
+
+	report := Noise(items, NoiseOptions{CurrentUser: "me", Resolved: resolved})
+	if report.Total != 9 || report.Acted != 2 || report.Dismissed != 6 {
+		t.Errorf("Noise() totals = %d/%d/%d, want 9 notifications, 2 acted on, 6 dismissed",
+			report.Total, report.Acted, report.Dismissed)
+	}
+
+	if len(report.Repos) != 2 || report.Repos[0].Name != "acme/infra" || report.Repos[0].DismissedShare() != 100 {
+		t.Errorf("Noise().Repos = %+v, want acme/infra first, all dismissed", report.Repos)
+	}
+	if got := report.Reasons[0]; got.Name != "subscribed" || got.Dismissed != 6 {
+		t.Errorf("Noise().Reasons[0] = %+v, want subscribed with 6 dismissed", got)
+	}
+	for _, a := range report.Authors {
+		if a.Name == "me" {
+			t.Error("Noise().Authors should leave out the current user")
+		}
+	}
+
+	want := []NoiseSuggestion{
+		{Key: "exclude_repos", Value: "acme/infra", Reason: "6 of 6 notifications dismissed, none acted on", Watching: 6},
+		{Key: "exclude_authors", Value: "renovate[bot]", Reason: "6 of 6 notifications dismissed, none acted on"},
+	}
+	if fmt.Sprint(report.Suggestions) != fmt.Sprint(want) {
+		t.Errorf("Noise().Suggestions = %+v, want %+v", report.Suggestions, want)
+	}
+
+	// Without the resolved store only read notifications are dismissed, and
+	// excluded repos aren't suggested again
+	report = Noise(items, NoiseOptions{CurrentUser: "me", ExcludedRepos: []string{"ACME/infra"}})
+	if report.Dismissed != 3 {
+		t.Errorf("Noise() without resolved items dismissed %d, want 3", report.Dismissed)
+	}
+	if len(report.Suggestions) != 0 {
+		t.Errorf("Noise().Suggestions = %+v, want none under the dismissed share", report.Suggestions)
+	}
+}
@@ -0,0 +1,50 @@
+package triage
+
+import (
+	"path"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// NeedsBackport returns the merged PRs labeled for a backport to base that
+// no PR targeting base refers to yet. A PR is labeled for a backport when
+// it has one of backportLabels, a label naming base ("release/1.2"), or a
+// backport label naming the branch's last segment ("backport-1.2").
+// referenced holds the keys, as from model.Item.Key, of the PRs mentioned
+// by PRs targeting base.
+func NeedsBackport(merged []model.Item, referenced map[string]bool, base string, backportLabels []string) []model.Item {
+	var needed []model.Item
+	for _, n := range merged {
+		if referenced[n.Key()] || !labeledForBackport(&n, base, backportLabels) {
+			continue
+		}
+		needed = append(needed, n)
+	}
+	return needed
+}
+
+// labeledForBackport reports whether the item has a label asking for a
+// backport to base.
+func labeledForBackport(n *model.Item, base string, backportLabels []string) bool {
+	branch := normalizeLabel(base)
+	version := normalizeLabel(path.Base(base))
+	for _, label := range n.Labels {
+		label = normalizeLabel(label)
+		if hasWords(label, branch) || (strings.Contains(label, "backport") && hasWords(label, version)) {
+			return true
+		}
+		for _, backport := range backportLabels {
+			if label == normalizeLabel(backport) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasWords reports whether label contains words as whole words, so a label
+// for 1.2 doesn't match 1.21.
+func hasWords(label, words string) bool {
+	return strings.Contains(" "+label+" ", " "+words+" ")
+}
@@ -0,0 +1,39 @@
+package triage
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestNeedsBackport(t *testing.T) {
+	pr := func(number int, labels ...string) model.Item {
+		return model.Item{
+			ID:         labels[0],
+			Number:     number,
+			Repository: model.Repository{FullName: "acme/api"},
+			Labels:     labels,
+		}
+	}
+	merged := []model.Item{
+		pr(1, "needs-backport"),
+		pr(2, "backport release/1.2"),
+		pr(3, "backport-1.2"),
+		pr(4, "release/1.2"),
+		pr(5, "backport-1.21"),
+		pr(6, "bug"),
+		pr(7, "Needs Backport"),
+		pr(8, "backport"),
+	}
+	referenced := map[string]bool{"acme/api#8": true}
+
+	var got []string
+	for _, n := range NeedsBackport(merged, referenced, "release/1.2", []string{"backport", "needs-backport"}) {
+		got = append(got, n.ID)
+	}
+	want := []string{"needs-backport", "backport release/1.2", "backport-1.2", "release/1.2", "Needs Backport"}
+	if !slices.Equal(got, want) {
+		t.Errorf("NeedsBackport() = %v, want %v", got, want)
+	}
+}
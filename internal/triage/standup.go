@@ -0,0 +1,97 @@
+package triage
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+// Standup is a summary of recent and current work for a standup update.
+type Standup struct {
+	// Did lists items closed, merged, or marked done in the window, most
+	// recent first
+	Did []StandupEntry
+	// Doing lists the top open items assigned to the user or urgent
+	Doing []PrioritizedItem
+	// Blocked lists the open items assigned to the user that are blocked
+	Blocked []PrioritizedItem
+}
+
+// StandupEntry is an item finished in the standup window.
+type StandupEntry struct {
+	Key   string
+	Title string // empty when the item wasn't fetched
+	// Outcome is "merged", "closed", or "done" for items marked done
+	Outcome string
+	At      time.Time
+}
+
+// BuildStandup assembles a standup from the open items in priority order,
+// the fetched items closed since since that currentUser was involved in,
+// and the items marked done by key with when they were marked. all is used
+// to find titles for items marked done. Doing holds at most limit items.
+func BuildStandup(open []PrioritizedItem, all []model.Item, marked map[string]time.Time, currentUser string, blockedLabels []string, since time.Time, limit int) Standup {
+	var s Standup
+
+	seen := make(map[string]bool)
+	for _, n := range ClosedSince(all, currentUser, since) {
+		key := n.Key()
+		seen[key] = true
+		s.Did = append(s.Did, StandupEntry{Key: key, Title: n.Subject.Title, Outcome: string(n.State), At: *ClosedAt(&n)})
+	}
+	titles := make(map[string]string, len(all))
+	for _, n := range all {
+		titles[n.Key()] = n.Subject.Title
+	}
+	for key, at := range marked {
+		if seen[key] {
+			continue
+		}
+		s.Did = append(s.Did, StandupEntry{Key: key, Title: titles[key], Outcome: "done", At: at})
+	}
+	sort.SliceStable(s.Did, func(i, j int) bool {
+		if !s.Did[i].At.Equal(s.Did[j].At) {
+			return s.Did[i].At.After(s.Did[j].At)
+		}
+		return s.Did[i].Key < s.Did[j].Key
+	})
+
+	for _, item := range open {
+		assigned := isAssignedTo(&item.Item, currentUser)
+		switch {
+		case assigned && isBlocked(&item.Item, blockedLabels):
+			s.Blocked = append(s.Blocked, item)
+		case (assigned || item.Priority == PriorityUrgent) && len(s.Doing) < limit:
+			s.Doing = append(s.Doing, item)
+		}
+	}
+	return s
+}
+
+// isAssignedTo reports whether user is among the item's assignees.
+func isAssignedTo(n *model.Item, user string) bool {
+	for _, a := range n.Assignees {
+		if strings.EqualFold(a, user) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlocked reports whether the item has open blockers or any of the
+// blocked labels, matching the TUI's blocked pane.
+func isBlocked(n *model.Item, blockedLabels []string) bool {
+	if len(n.BlockedBy) > 0 {
+		return true
+	}
+	for _, label := range n.Labels {
+		for _, blocked := range blockedLabels {
+			if strings.EqualFold(label, blocked) {
+				return true
+			}
+		}
+	}
+	return false
+}
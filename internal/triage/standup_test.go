@@ -0,0 +1,72 @@
+package triage
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestBuildStandup(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+	item := func(number int, title string) model.Item {
+		return model.Item{Number: number, Repository: model.Repository{FullName: "acme/api"}, Subject: model.Subject{Title: title}}
+	}
+
+	merged := item(1, "Add standup")
+	merged.Reason, merged.State, merged.ClosedAt = model.ReasonAuthor, model.StateMerged, ago(2*time.Hour)
+	old := item(2, "Closed last week")
+	old.Reason, old.State, old.ClosedAt = model.ReasonAuthor, model.StateClosed, ago(7*24*time.Hour)
+	done := item(3, "Marked done")
+	all := []model.Item{merged, old, done}
+	marked := map[string]time.Time{
+		done.Key():   now.Add(-time.Hour),
+		merged.Key(): now.Add(-time.Hour), // listed once, as merged
+		"acme/api#9": now.Add(-3 * time.Hour),
+	}
+
+	open := func(number int, priority PriorityLevel, assignees []string, labels ...string) PrioritizedItem {
+		n := item(number, "")
+		n.Assignees, n.Labels = assignees, labels
+		return PrioritizedItem{Item: n, Priority: priority}
+	}
+	me := []string{"TestUser"}
+	openItems := []PrioritizedItem{
+		open(10, PriorityUrgent, nil),
+		open(11, PriorityImportant, me),
+		open(12, PriorityImportant, me, "Blocked"),
+		open(13, PriorityNotable, nil),
+		open(14, PriorityFYI, me),
+		open(15, PriorityFYI, []string{"someone"}, "blocked"),
+	}
+
+	s := BuildStandup(openItems, all, marked, "testuser", []string{"blocked"}, now.Add(-24*time.Hour), 2)
+
+	var did []string
+	for _, e := range s.Did {
+		did = append(did, e.Key+" "+e.Outcome+" "+e.Title)
+	}
+	wantDid := []string{"acme/api#3 done Marked done", "acme/api#1 merged Add standup", "acme/api#9 done "}
+	if !slices.Equal(did, wantDid) {
+		t.Errorf("Did = %q, want %q", did, wantDid)
+	}
+
+	numbers := func(items []PrioritizedItem) []int {
+		var got []int
+		for _, i := range items {
+			got = append(got, i.Number)
+		}
+		return got
+	}
+	if got := numbers(s.Doing); !slices.Equal(got, []int{10, 11}) {
+		t.Errorf("Doing = %v, want [10 11]", got)
+	}
+	if got := numbers(s.Blocked); !slices.Equal(got, []int{12}) {
+		t.Errorf("Blocked = %v, want [12]", got)
+	}
+}
@@ -1,6 +1,11 @@
 package triage
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spiffcs/triage/internal/i18n"
 	"github.com/spiffcs/triage/internal/model"
 )
 
@@ -13,30 +18,92 @@ const (
 	PriorityImportant PriorityLevel = "important"
 	PriorityNotable   PriorityLevel = "notable"
 	PriorityFYI       PriorityLevel = "fyi"
+	// PriorityArchive holds items with no activity for longer than the
+	// configured horizon. They are left out unless asked for.
+	PriorityArchive PriorityLevel = "archive"
 )
 
-// Display returns a human-readable priority level
+// Display returns the priority level's name in the selected locale
 func (p PriorityLevel) Display() string {
 	switch p {
 	case PriorityUrgent:
-		return "Urgent"
+		return i18n.T(i18n.PriorityUrgent)
 	case PriorityQuickWin:
-		return "Quick Win"
+		return i18n.T(i18n.PriorityQuickWin)
 	case PriorityImportant:
-		return "Important"
+		return i18n.T(i18n.PriorityImportant)
 	case PriorityNotable:
-		return "Notable"
+		return i18n.T(i18n.PriorityNotable)
 	case PriorityFYI:
-		return "FYI"
+		return i18n.T(i18n.PriorityFYI)
+	case PriorityArchive:
+		return i18n.T(i18n.PriorityArchive)
 	default:
 		return string(p)
 	}
 }
 
+// Marker is a bracketed letter that tells the level apart without color,
+// e.g. "[U]" for urgent.
+func (p PriorityLevel) Marker() string {
+	switch p {
+	case PriorityUrgent:
+		return "[U]"
+	case PriorityImportant:
+		return "[I]"
+	case PriorityQuickWin:
+		return "[Q]"
+	case PriorityNotable:
+		return "[N]"
+	case PriorityArchive:
+		return "[A]"
+	default:
+		return "[F]"
+	}
+}
+
+// AllPriorityLevels lists the priority levels from most to least pressing.
+var AllPriorityLevels = []PriorityLevel{
+	PriorityUrgent,
+	PriorityImportant,
+	PriorityQuickWin,
+	PriorityNotable,
+	PriorityFYI,
+	PriorityArchive,
+}
+
+// Rank is the level's position in AllPriorityLevels, lower being more
+// pressing. Unknown levels rank last.
+func (p PriorityLevel) Rank() int {
+	if i := slices.Index(AllPriorityLevels, p); i >= 0 {
+		return i
+	}
+	return len(AllPriorityLevels)
+}
+
+// ParsePriority parses a priority level name such as "important" or
+// "quick-win", ignoring case and accepting "_" or " " for "-".
+func ParsePriority(s string) (PriorityLevel, error) {
+	name := strings.NewReplacer("_", "-", " ", "-").Replace(strings.ToLower(strings.TrimSpace(s)))
+	p := PriorityLevel(name)
+	if !slices.Contains(AllPriorityLevels, p) {
+		names := make([]string, len(AllPriorityLevels))
+		for i, l := range AllPriorityLevels {
+			names[i] = string(l)
+		}
+		return "", fmt.Errorf("unknown priority %q (valid priorities: %s)", s, strings.Join(names, ", "))
+	}
+	return p, nil
+}
+
 // PrioritizedItem wraps an item with priority information
 type PrioritizedItem struct {
 	model.Item                 // embedded
 	Score        int           `json:"score"`
 	Priority     PriorityLevel `json:"priority"`
 	ActionNeeded string        `json:"actionNeeded"`
+
+	// Resurfaced is set for items marked resolved that are back in the
+	// list because of new activity.
+	Resurfaced bool `json:"resurfaced,omitempty"`
 }
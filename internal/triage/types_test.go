@@ -13,6 +13,7 @@ func TestPriorityLevelDisplay(t *testing.T) {
 		{PriorityImportant, "Important"},
 		{PriorityQuickWin, "Quick Win"},
 		{PriorityFYI, "FYI"},
+		{PriorityArchive, "Archive"},
 		{PriorityLevel("unknown"), "unknown"},
 	}
 
@@ -25,3 +26,29 @@ func TestPriorityLevelDisplay(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    PriorityLevel
+		wantErr bool
+	}{
+		{"important", PriorityImportant, false},
+		{"Quick_Win", PriorityQuickWin, false},
+		{"FYI", PriorityFYI, false},
+		{"archive", PriorityArchive, false},
+		{"critical", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParsePriority(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriority(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePriority(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
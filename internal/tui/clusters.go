@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// clusterRow describes how a row relates to its group of related items.
+type clusterRow struct {
+	// size is the number of items in the row's group within the pane; 0
+	// when the row isn't grouped.
+	size     int
+	expanded bool
+	// member is set for the rows shown under an expanded group's first row.
+	member bool
+}
+
+// clusterRows folds each group of related items into the row of its first
+// item, or lists the group under it when expanded. Groups only span the
+// given items, so an item whose relatives are in other panes stands alone.
+func (m *ListModel) clusterRows(items []triage.PrioritizedItem) ([]triage.PrioritizedItem, []clusterRow) {
+	rows := make([]clusterRow, len(items))
+	if !m.clustered || len(m.clusters) == 0 {
+		return items, rows
+	}
+
+	members := make(map[string][]triage.PrioritizedItem)
+	for _, item := range items {
+		if g, ok := m.clusters[item.ID]; ok {
+			members[g] = append(members[g], item)
+		}
+	}
+
+	var out []triage.PrioritizedItem
+	rows = rows[:0]
+	seen := make(map[string]bool)
+	for _, item := range items {
+		g, ok := m.clusters[item.ID]
+		if !ok || len(members[g]) < 2 {
+			out = append(out, item)
+			rows = append(rows, clusterRow{})
+			continue
+		}
+		if seen[g] {
+			continue
+		}
+		seen[g] = true
+
+		group := members[g]
+		expanded := m.expanded[g]
+		out = append(out, group[0])
+		rows = append(rows, clusterRow{size: len(group), expanded: expanded})
+		if expanded {
+			for _, member := range group[1:] {
+				out = append(out, member)
+				rows = append(rows, clusterRow{size: len(group), expanded: true, member: true})
+			}
+		}
+	}
+	return out, rows
+}
+
+// clusterTitle prefixes a row's title to show its place in a group: the
+// number of related items folded into it, or a branch under an expanded
+// group.
+func clusterTitle(title string, row clusterRow) string {
+	switch {
+	case row.size == 0:
+		return title
+	case row.member:
+		return "  └ " + title
+	case row.expanded:
+		return "[-] " + title
+	default:
+		return fmt.Sprintf("[+%d] %s", row.size-1, title)
+	}
+}
+
+// toggleClusters turns grouping of related items on or off, keeping the
+// cursor on the selected item (or its group).
+func (m ListModel) toggleClusters() (tea.Model, tea.Cmd) {
+	var selected *triage.PrioritizedItem
+	if items := m.activeItems(); m.activeCursor() < len(items) {
+		selected = &items[m.activeCursor()]
+	}
+
+	m.clustered = !m.clustered
+	m.saveClusterPreference()
+	m.selectItemOrGroup(selected)
+
+	if m.clustered {
+		m.statusMsg = "Grouping related items"
+	} else {
+		m.statusMsg = "Showing items separately"
+	}
+	m.statusTime = time.Now()
+	return m, clearStatusAfter(2 * time.Second)
+}
+
+// inGroup reports whether the selected row belongs to a group of related
+// items while they are grouped.
+func (m ListModel) inGroup() bool {
+	if !m.clustered {
+		return false
+	}
+	item, ok := m.selectedItem()
+	if !ok {
+		return false
+	}
+	_, ok = m.clusters[item.ID]
+	return ok
+}
+
+// toggleExpanded expands or collapses the group of the selected row.
+func (m ListModel) toggleExpanded() (tea.Model, tea.Cmd) {
+	if !m.inGroup() {
+		return m, nil
+	}
+	items := m.activeItems()
+	cursor := m.activeCursor()
+	g := m.clusters[items[cursor].ID]
+
+	if m.expanded == nil {
+		m.expanded = make(map[string]bool)
+	}
+	m.expanded[g] = !m.expanded[g]
+	if !m.expanded[g] {
+		// Collapsing from a member moves the cursor to the group's row
+		m.selectItemOrGroup(&items[cursor])
+	}
+	return m, nil
+}
+
+// selectItemOrGroup moves the cursor to item, or to the row its group is
+// folded into.
+func (m *ListModel) selectItemOrGroup(item *triage.PrioritizedItem) {
+	if item == nil {
+		return
+	}
+	items := m.activeItems()
+	for i, it := range items {
+		if it.ID == item.ID {
+			m.setActiveCursor(i)
+			return
+		}
+	}
+	if g, ok := m.clusters[item.ID]; ok && m.clustered {
+		for i, it := range items {
+			if m.clusters[it.ID] == g {
+				m.setActiveCursor(i)
+				return
+			}
+		}
+	}
+	if m.activeCursor() >= len(items) && len(items) > 0 {
+		m.setActiveCursor(len(items) - 1)
+	}
+}
+
+// saveClusterPreference remembers whether related items are grouped.
+func (m *ListModel) saveClusterPreference() {
+	if m.config == nil {
+		return
+	}
+	if m.config.UI == nil {
+		m.config.UI = &config.UIPreferences{}
+	}
+	clustered := m.clustered
+	m.config.UI.ClusterRelated = &clustered
+	m.saveUIPreferences()
+}
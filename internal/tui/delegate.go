@@ -0,0 +1,289 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/internal/confirm"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// delegateMatchesShown is how many teammates the delegate prompt lists at
+// once; tab moves through the rest.
+const delegateMatchesShown = 5
+
+// Delegator assigns issues and PRs to teammates on GitHub and posts the
+// handoff comment.
+type Delegator interface {
+	AddAssignees(ctx context.Context, owner, repo string, number int, logins []string) error
+	CreateComment(ctx context.Context, owner, repo string, number int, body string) error
+}
+
+// Handoff is the data the handoff comment template is executed with.
+type Handoff struct {
+	To    string // Login the item is handed to
+	From  string // The current user
+	Title string
+	Ref   string // owner/repo#N
+	URL   string
+}
+
+// ParseHandoff parses the handoff comment template. Empty text returns
+// nil, and no comment is posted.
+func ParseHandoff(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	return template.New("handoff").Option("missingkey=error").Parse(text)
+}
+
+// WithDelegation enables handing items to a teammate with A: they are
+// assigned on GitHub with d, handoff, when set, is posted on them, and they
+// are marked done locally. roster is the logins offered; any other login
+// can be typed.
+func WithDelegation(d Delegator, roster []string, handoff *template.Template) ListOption {
+	return func(m *ListModel) {
+		m.delegator = d
+		m.roster = roster
+		m.handoff = handoff
+	}
+}
+
+// delegatePrompt picks the teammate to hand one or more items to.
+type delegatePrompt struct {
+	items    []triage.PrioritizedItem
+	input    string // filters the roster, or names a login not in it
+	selected int    // index of the highlighted match
+}
+
+// matches returns the logins in roster containing the typed text.
+func (p *delegatePrompt) matches(roster []string) []string {
+	query := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(p.input), "@"))
+	var matches []string
+	for _, login := range roster {
+		if strings.Contains(strings.ToLower(login), query) {
+			matches = append(matches, login)
+		}
+	}
+	return matches
+}
+
+// login returns the login enter delegates to: the highlighted match, or
+// the typed login when nothing in roster matches.
+func (p *delegatePrompt) login(roster []string) string {
+	if matches := p.matches(roster); len(matches) > 0 {
+		return matches[min(p.selected, len(matches)-1)]
+	}
+	return strings.TrimPrefix(strings.TrimSpace(p.input), "@")
+}
+
+// text returns the prompt as shown in the footer.
+func (p *delegatePrompt) text(roster []string) string {
+	label := "Delegate to"
+	if len(p.items) > 1 {
+		label = fmt.Sprintf("Delegate %d items to", len(p.items))
+	}
+	var b strings.Builder
+	b.WriteString(label + ": " + p.input + "_")
+
+	matches := p.matches(roster)
+	start := p.selected - p.selected%delegateMatchesShown
+	for i := start; i < min(start+delegateMatchesShown, len(matches)); i++ {
+		if i == p.selected {
+			b.WriteString("   [" + matches[i] + "]")
+		} else {
+			b.WriteString("   " + matches[i])
+		}
+	}
+	if len(matches) > delegateMatchesShown {
+		b.WriteString("   tab: next")
+	}
+	b.WriteString("   enter: delegate   esc: cancel")
+	return b.String()
+}
+
+// promptDelegate asks which teammate to hand the current or selected items
+// to.
+func (m ListModel) promptDelegate() (tea.Model, tea.Cmd) {
+	items := m.activeItems()
+	if len(items) == 0 || m.showDone || m.showTrash {
+		return m, nil
+	}
+	if m.delegator == nil {
+		m.statusMsg = "Delegating not available"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+	if marked := m.markedItems(); len(marked) > 0 {
+		m.delegating = &delegatePrompt{items: marked}
+		return m, nil
+	}
+	m.delegating = &delegatePrompt{items: items[m.activeCursor() : m.activeCursor()+1]}
+	return m, nil
+}
+
+// handleDelegateKey answers the delegate prompt
+func (m ListModel) handleDelegateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := *m.delegating
+	m.delegating = nil
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.statusMsg = "Not delegated"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	case tea.KeyEnter:
+		login := prompt.login(m.roster)
+		if login == "" {
+			// Nothing to delegate to yet
+			break
+		}
+		what := "this item"
+		if len(prompt.items) > 1 {
+			what = fmt.Sprintf("%d items", len(prompt.items))
+		}
+		return m.confirmThen(confirm.ActionDelegate, len(prompt.items),
+			fmt.Sprintf("Assign %s to @%s?", what, login),
+			"Not delegated",
+			m.delegate(prompt.items, login))
+	case tea.KeyTab, tea.KeyDown:
+		if n := len(prompt.matches(m.roster)); n > 0 {
+			prompt.selected = (prompt.selected + 1) % n
+		}
+	case tea.KeyShiftTab, tea.KeyUp:
+		if n := len(prompt.matches(m.roster)); n > 0 {
+			prompt.selected = (prompt.selected + n - 1) % n
+		}
+	case tea.KeyBackspace:
+		runes := []rune(prompt.input)
+		if len(runes) > 0 {
+			prompt.input = string(runes[:len(runes)-1])
+		}
+		prompt.selected = 0
+	case tea.KeyRunes:
+		prompt.input += string(msg.Runes)
+		prompt.selected = 0
+	}
+	m.delegating = &prompt
+	return m, nil
+}
+
+// delegatedMsg is sent once items were assigned to a teammate.
+type delegatedMsg struct {
+	to         string
+	assigned   []triage.PrioritizedItem // items now assigned to the teammate
+	at         time.Time                // when the last change was made on GitHub
+	err        error                    // items that could not be assigned
+	commentErr error                    // handoff comments that could not be posted
+}
+
+// delegate assigns items to login on GitHub and posts the handoff comment
+// on each, carrying on past failures so one bad item doesn't hold up the
+// rest.
+func (m ListModel) delegate(items []triage.PrioritizedItem, login string) tea.Cmd {
+	delegator, handoff, from := m.delegator, m.handoff, m.currentUser
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := delegatedMsg{to: login}
+		var errs, commentErrs []error
+		for _, item := range items {
+			ref, ok := refForItem(item)
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s has no issue or PR number", item.Repository.FullName))
+				continue
+			}
+			if err := delegator.AddAssignees(ctx, ref.owner, ref.repo, ref.number, []string{login}); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			msg.assigned = append(msg.assigned, item)
+			if handoff == nil {
+				continue
+			}
+			var body strings.Builder
+			err := handoff.Execute(&body, Handoff{
+				To:    login,
+				From:  from,
+				Title: item.Subject.Title,
+				Ref:   fmt.Sprintf("%s/%s#%d", ref.owner, ref.repo, ref.number),
+				URL:   item.HTMLURL,
+			})
+			if err == nil {
+				err = delegator.CreateComment(ctx, ref.owner, ref.repo, ref.number, body.String())
+			}
+			commentErrs = append(commentErrs, err)
+		}
+		msg.at = time.Now()
+		msg.err = errors.Join(errs...)
+		msg.commentErr = errors.Join(commentErrs...)
+		return msg
+	}
+}
+
+// finishDelegation marks the items handed off done. They are resolved as
+// of the handoff rather than their last update, so the assignment and
+// comment don't bring them straight back.
+func (m ListModel) finishDelegation(msg delegatedMsg) (tea.Model, tea.Cmd) {
+	if len(msg.assigned) > 0 {
+		updatedAt := make(map[string]time.Time, len(msg.assigned))
+		for _, item := range msg.assigned {
+			updatedAt[item.Key()] = msg.at
+		}
+		if err := m.resolved.ResolveAll(updatedAt); err != nil {
+			m.statusMsg = "Error: " + err.Error()
+			m.statusTime = time.Now()
+			return m, clearStatusAfter(2 * time.Second)
+		}
+		items := slices.Clone(m.items)
+		for i := range items {
+			if _, ok := updatedAt[items[i].Key()]; !ok {
+				continue
+			}
+			if items[i].Reminder != nil && m.snoozes != nil {
+				if _, err := m.snoozes.Clear(items[i].Key()); err != nil {
+					m.statusMsg = "Error: " + err.Error()
+					m.statusTime = time.Now()
+					return m, clearStatusAfter(2 * time.Second)
+				}
+			}
+			items[i].Reminder = nil
+			delete(m.marked, items[i].Key())
+		}
+		// Split the items again, moving the handed off items to done
+		m.setItems(items)
+	}
+
+	m.statusMsg = delegatedStatus(msg)
+	m.statusTime = time.Now()
+	return m, clearStatusAfter(2 * time.Second)
+}
+
+// delegatedStatus describes the outcome of delegating items for the status
+// line.
+func delegatedStatus(msg delegatedMsg) string {
+	if len(msg.assigned) == 0 {
+		if errors.Is(msg.err, ghclient.ErrDryRun) {
+			return "Dry run: not assigned to @" + msg.to
+		}
+		return "Not delegated: " + msg.err.Error()
+	}
+
+	status := "Delegated to @" + msg.to
+	if len(msg.assigned) > 1 {
+		status = fmt.Sprintf("Delegated %d items to @%s", len(msg.assigned), msg.to)
+	}
+	if msg.err != nil {
+		status += "; some not assigned: " + msg.err.Error()
+	}
+	if msg.commentErr != nil {
+		status += "; handoff comment not posted: " + msg.commentErr.Error()
+	}
+	return status
+}
@@ -54,3 +54,13 @@ type RateLimitEvent struct {
 }
 
 func (RateLimitEvent) isEvent() {}
+
+// ConfirmEvent asks the user a yes/no question before work continues, such
+// as whether to go ahead with a large fetch. The answer is sent on Reply,
+// which must be buffered; cancelling with Ctrl+C answers no.
+type ConfirmEvent struct {
+	Question string
+	Reply    chan<- bool
+}
+
+func (ConfirmEvent) isEvent() {}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// labelPrompt is the label filter being typed.
+type labelPrompt struct {
+	input string
+}
+
+// text returns the prompt as shown in the footer.
+func (p *labelPrompt) text() string {
+	return "Labels (e.g. security, !wontfix): " + p.input + "_   enter: filter   esc: cancel"
+}
+
+// promptLabelFilter asks for the labels to filter items by, starting from
+// the current filter.
+func (m ListModel) promptLabelFilter() (tea.Model, tea.Cmd) {
+	m.labelPrompt = &labelPrompt{input: m.labelFilter.String()}
+	return m, nil
+}
+
+// handleLabelKey edits the label filter prompt, applying it on enter. An
+// empty filter shows every label again.
+func (m ListModel) handleLabelKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := *m.labelPrompt
+	m.labelPrompt = nil
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		return m, nil
+	case tea.KeyEnter:
+		return m.setLabelFilter(triage.ParseLabelFilter([]string{prompt.input}, nil))
+	case tea.KeyBackspace:
+		runes := []rune(prompt.input)
+		if len(runes) > 0 {
+			prompt.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		prompt.input += string(msg.Runes)
+	}
+	m.labelPrompt = &prompt
+	return m, nil
+}
+
+// setLabelFilter shows only the items matching filter in every pane.
+func (m ListModel) setLabelFilter(filter triage.LabelFilter) (tea.Model, tea.Cmd) {
+	m.labelFilter = filter
+
+	// Reset cursor to 0 since the visible list has changed
+	m.setActiveCursor(0)
+
+	m.statusMsg = "Labels: " + m.LabelFilterLabel()
+	if filter.IsZero() {
+		m.statusMsg = "Label filter cleared"
+	}
+	m.statusTime = time.Now()
+	return m, clearStatusAfter(2 * time.Second)
+}
+
+// LabelFilterLabel returns a display label for the current label filter
+func (m ListModel) LabelFilterLabel() string {
+	if m.labelFilter.IsZero() {
+		return "labels"
+	}
+	return strings.ReplaceAll(m.labelFilter.String(), ",", ", ")
+}
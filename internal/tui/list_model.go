@@ -1,16 +1,32 @@
 package tui
 
 import (
-	"os/exec"
-	"runtime"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/browser"
+	"github.com/spiffcs/triage/internal/confirm"
+	"github.com/spiffcs/triage/internal/duration"
+	"github.com/spiffcs/triage/internal/editor"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/ghclient"
 	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/output"
+	"github.com/spiffcs/triage/internal/placement"
 	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/slo"
+	"github.com/spiffcs/triage/internal/snooze"
 	"github.com/spiffcs/triage/internal/triage"
 )
 
@@ -28,8 +44,27 @@ const (
 	paneDependabot
 	// paneOrphaned is the orphaned list pane
 	paneOrphaned
+	// paneLater is the pane of items moved there with m to deal with later;
+	// it is only shown while moving is enabled
+	paneLater
 )
 
+// movablePanes lists the panes an item can be moved to with m, by key,
+// with the names they are stored under and shown as.
+var movablePanes = []struct {
+	key   string
+	pane  pane
+	name  string
+	label string
+}{
+	{"1", paneAssigned, "assigned", "Assigned"},
+	{"2", paneBlocked, "blocked", "Blocked"},
+	{"3", paneQueue, "queue", "Queue"},
+	{"4", paneDependabot, "deps", "Deps"},
+	{"5", paneOrphaned, "orphaned", "Orphaned"},
+	{"6", paneLater, "later", "Later"},
+}
+
 // typeFilter controls which item types are displayed
 type typeFilter int
 
@@ -39,6 +74,9 @@ const (
 	typeFilterIssue                   // Show only issues
 )
 
+// trashWindow is how far back the recently resolved view reaches.
+const trashWindow = 7 * 24 * time.Hour
+
 // TUI layout constants
 const (
 	// HeaderLines is the number of lines used for the list view header.
@@ -58,6 +96,7 @@ var priorityOrder = map[triage.PriorityLevel]int{
 	triage.PriorityQuickWin:  2,
 	triage.PriorityNotable:   3,
 	triage.PriorityFYI:       4,
+	triage.PriorityArchive:   5,
 }
 
 // ciStatusOrder maps CI status to sort order (lower = higher priority for descending)
@@ -100,6 +139,9 @@ var blockedSortColumns = []SortColumn{SortUpdated, SortSize, SortAuthor, SortRep
 // dependabotSortColumns defines the cycling order for dependabot pane
 var dependabotSortColumns = []SortColumn{SortUpdated, SortSize, SortRepo, SortCI}
 
+// laterSortColumns defines the cycling order for later pane
+var laterSortColumns = queueSortColumns
+
 // Default sort columns
 const (
 	defaultQueueSortColumn      = SortPriority
@@ -107,6 +149,7 @@ const (
 	defaultAssignedSortColumn   = SortUpdated
 	defaultBlockedSortColumn    = SortUpdated
 	defaultDependabotSortColumn = SortUpdated
+	defaultLaterSortColumn      = SortPriority
 )
 
 // ListModel is the Bubble Tea model for the interactive notification list
@@ -117,12 +160,14 @@ type ListModel struct {
 	assignedItems    []triage.PrioritizedItem // Items assigned to current user
 	blockedItems     []triage.PrioritizedItem // Items with "blocked" label
 	dependabotItems  []triage.PrioritizedItem // PRs authored by dependabot
+	laterItems       []triage.PrioritizedItem // Items moved to Later
 	activePane       pane                     // Which pane is focused
 	queueCursor      int                      // Cursor for queue pane
 	orphanedCursor   int                      // Cursor for orphaned pane
 	assignedCursor   int                      // Cursor for assigned pane
 	blockedCursor    int                      // Cursor for blocked pane
 	dependabotCursor int                      // Cursor for dependabot pane
+	laterCursor      int                      // Cursor for later pane
 	showDone         bool                     // Whether to show done items instead of active
 
 	// Done (resolved) items per pane
@@ -131,6 +176,7 @@ type ListModel struct {
 	assignedDoneItems   []triage.PrioritizedItem
 	blockedDoneItems    []triage.PrioritizedItem
 	dependabotDoneItems []triage.PrioritizedItem
+	laterDoneItems      []triage.PrioritizedItem
 
 	// Cursors for done view per pane
 	queueDoneCursor      int
@@ -138,12 +184,19 @@ type ListModel struct {
 	assignedDoneCursor   int
 	blockedDoneCursor    int
 	dependabotDoneCursor int
+	laterDoneCursor      int
 	resolved             *resolved.Store
 	windowWidth          int
 	windowHeight         int
 	statusMsg            string
 	statusTime           time.Time
-	cacheMsg             string // persistent cache staleness indicator
+	cacheMsg             string          // persistent cache staleness indicator
+	runDelta             string          // changes since the previous run, shown above the tabs
+	pinned               map[string]bool // resurfaced item keys kept at the top of their pane
+	quick                bool            // items were not enriched (--quick)
+	statusMarkers        bool            // mark priorities and CI for color-blind users
+	minimalRedraw        bool            // keep status messages until the next key (see WithMinimalRedraw)
+	truncation           output.Truncation
 	quitting             bool
 	hotTopicThreshold    int
 	prSizeXS             int
@@ -151,7 +204,25 @@ type ListModel struct {
 	prSizeM              int
 	prSizeL              int
 	currentUser          string
-	typeFilter           typeFilter // Global filter: all, PRs only, or issues only
+	typeFilter           typeFilter         // Global filter: all, PRs only, or issues only
+	labelFilter          triage.LabelFilter // Global filter set with L
+
+	// Grouping of related items (see triage.Cluster): the group key of
+	// each grouped item, and the groups expanded to show their members
+	clustered bool
+	clusters  map[string]string
+	expanded  map[string]bool
+
+	// Items selected with v and V for bulk actions, by item key, and the
+	// key of the item the next V range starts from
+	marked     map[string]bool
+	markAnchor string
+
+	// Items resolved within trashWindow across all panes, most recent
+	// first, shown instead of the panes while showTrash is set
+	showTrash   bool
+	trashItems  []triage.PrioritizedItem
+	trashCursor int
 
 	// Sort state per pane
 	queueSortColumn      SortColumn
@@ -164,20 +235,148 @@ type ListModel struct {
 	blockedSortDesc      bool
 	dependabotSortColumn SortColumn
 	dependabotSortDesc   bool
+	laterSortColumn      SortColumn
+	laterSortDesc        bool
 
 	// Config for persisting preferences
 	config *config.Config
 
+	// Weights the items were scored with, unless config or a reload says
+	// otherwise; used to explain scores when sharing an item.
+	weights config.ScoreWeights
+
 	// Configurable labels for blocked pane
 	blockedLabels []string
 
 	// Authors whose PRs are routed to the Deps pane (lowercased for comparison).
 	dependencyAuthors map[string]bool
+
+	// Editor session for replying to items; nil disables the E key.
+	editor *editor.Session
+
+	// Marks notifications read on GitHub when they are marked done with d;
+	// nil leaves GitHub alone.
+	readMarker ReadMarker
+
+	// Detail pane toggled with l (or space outside a group), and the
+	// previews fetched for it by item key; nil previewer disables it.
+	showDetail bool
+	previewer  Previewer
+	previews   map[string]*previewEntry
+
+	// Records when items are opened or replied to; nil disables recording.
+	activity *activity.Store
+
+	// Snoozes set with z, and reminders set with triage remind, which are
+	// cleared when a reminded item is marked done; nil disables snoozing.
+	snoozes *snooze.Store
+
+	// Duration prompt for snoozing an item; while set, all keys go to it.
+	snoozing *snoozePrompt
+
+	// Panes items were moved to with m; nil disables moving.
+	placements *placement.Store
+
+	// Item being moved; while set, all keys go to the pane prompt.
+	moving *triage.PrioritizedItem
+
+	// Assigns items to teammates with A, offering roster and posting
+	// handoff when set; nil delegator disables delegating.
+	delegator Delegator
+	roster    []string
+	handoff   *template.Template
+
+	// Teammate prompt for delegating items; while set, all keys go to it.
+	delegating *delegatePrompt
+
+	// Label filter being typed; while set, all keys go to it.
+	labelPrompt *labelPrompt
+
+	// Review response-time objective and the user's recent performance.
+	reviewSLO   slo.Policy
+	reviewStats slo.Stats
+
+	// Confirmation policies for actions that change things on GitHub.
+	confirmations *confirm.Policies
+
+	// Action awaiting a y/n answer; while set, all keys go to the prompt.
+	pending *pendingConfirm
+
+	// Replacement item lists from a background refresh; nil when there
+	// is none.
+	updates <-chan []triage.PrioritizedItem
+
+	// Scoring weights editor opened with W, and the user's teams items
+	// are scored with; the editor needs rescoring and a config.
+	rescoring     bool
+	teams         []string
+	weightsEditor *weightsEditor
+
+	// Config files watched for changes (see WithConfigReload), their state
+	// when last checked, and the weights refreshed items are scored with
+	// once a reload changed them.
+	configReload    *configReload
+	configStamp     string
+	reloadedWeights *config.ScoreWeights
+}
+
+// pendingConfirm is an action waiting for the user to confirm it.
+type pendingConfirm struct {
+	prompt    string
+	onConfirm tea.Cmd
+	cancelMsg string
+}
+
+// snoozePrompt asks how long to snooze one or more items for.
+type snoozePrompt struct {
+	items  []triage.PrioritizedItem
+	custom bool   // typing a duration instead of picking one
+	input  string // the duration typed so far
+}
+
+// snoozeChoices are the durations offered by the snooze prompt, by key.
+var snoozeChoices = []struct {
+	key      string
+	duration time.Duration
+}{
+	{"1", time.Hour},
+	{"4", 4 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"w", 7 * 24 * time.Hour},
+}
+
+// text returns the prompt as shown in the footer.
+func (p *snoozePrompt) text() string {
+	label := "Snooze for"
+	if len(p.items) > 1 {
+		label = fmt.Sprintf("Snooze %d items for", len(p.items))
+	}
+	if p.custom {
+		return label + " (e.g. 3d, 2w): " + p.input + "_   enter: snooze   esc: cancel"
+	}
+	var b strings.Builder
+	b.WriteString(label + ":")
+	for _, c := range snoozeChoices {
+		b.WriteString("   " + c.key + ": " + format.FormatAge(c.duration))
+	}
+	b.WriteString("   c: custom   esc: cancel")
+	return b.String()
 }
 
 // ListOption is a functional option for configuring ListModel
 type ListOption func(*ListModel)
 
+// WithRescoring enables the scoring weights editor (W), which scores the
+// items again as weights change. teams are the user's teams, as
+// "org/team-slug", that the items were first scored with. The editor also
+// needs WithConfig, whose file it saves the weights to.
+func WithRescoring(teams []string) ListOption {
+	return func(m *ListModel) {
+		m.rescoring = true
+		m.teams = teams
+	}
+}
+
 // WithConfig provides the config for loading and saving UI preferences.
 func WithConfig(cfg *config.Config) ListOption {
 	return func(m *ListModel) {
@@ -192,6 +391,55 @@ func WithCacheStatus(msg string) ListOption {
 	}
 }
 
+// WithRunDelta shows a summary of what changed since the previous run
+// above the tab bar.
+func WithRunDelta(delta string) ListOption {
+	return func(m *ListModel) {
+		m.runDelta = delta
+	}
+}
+
+// WithPinned keeps the items with the given keys at the top of their pane
+// regardless of sort order. It is used for items that resurfaced since the
+// previous run.
+func WithPinned(keys map[string]bool) ListOption {
+	return func(m *ListModel) {
+		m.pinned = keys
+	}
+}
+
+// WithQuickMode marks the columns that need enrichment data as
+// unavailable, for items listed with --quick.
+func WithQuickMode(quick bool) ListOption {
+	return func(m *ListModel) {
+		m.quick = quick
+	}
+}
+
+// WithStatusMarkers adds letter and shape markers to the Priority and CI
+// columns so they can be read without color.
+func WithStatusMarkers(markers bool) ListOption {
+	return func(m *ListModel) {
+		m.statusMarkers = markers
+	}
+}
+
+// WithMinimalRedraw keeps each status message until the next key instead of
+// clearing it after a delay, which costs a redraw of its own, and has the
+// program redraw less often.
+func WithMinimalRedraw(minimal bool) ListOption {
+	return func(m *ListModel) {
+		m.minimalRedraw = minimal
+	}
+}
+
+// WithTruncation sets how long repository and author values are shortened.
+func WithTruncation(truncation output.Truncation) ListOption {
+	return func(m *ListModel) {
+		m.truncation = truncation
+	}
+}
+
 // WithBlockedLabels sets the labels used to identify blocked items.
 // If empty, the blocked pane is effectively disabled.
 func WithBlockedLabels(labels []string) ListOption {
@@ -204,25 +452,113 @@ func WithBlockedLabels(labels []string) ListOption {
 // Matching is case-insensitive.
 func WithDependencyAuthors(authors []string) ListOption {
 	return func(m *ListModel) {
-		set := make(map[string]bool, len(authors))
-		for _, a := range authors {
-			key := strings.ToLower(strings.TrimSpace(a))
-			if key == "" {
-				continue
-			}
-			set[key] = true
+		m.dependencyAuthors = authorSet(authors)
+	}
+}
+
+// authorSet returns the lowercased authors, ignoring blank entries.
+func authorSet(authors []string) map[string]bool {
+	set := make(map[string]bool, len(authors))
+	for _, a := range authors {
+		key := strings.ToLower(strings.TrimSpace(a))
+		if key == "" {
+			continue
 		}
-		m.dependencyAuthors = set
+		set[key] = true
+	}
+	return set
+}
+
+// WithEditor enables replying to the selected item from $EDITOR.
+func WithEditor(session *editor.Session) ListOption {
+	return func(m *ListModel) {
+		m.editor = session
+	}
+}
+
+// ReadMarker marks notification threads read on GitHub.
+type ReadMarker interface {
+	MarkAsRead(ctx context.Context, threadID string) error
+}
+
+// WithReadSync marks an item's notification read with marker when it is
+// marked done with d. D still marks items done without touching GitHub.
+func WithReadSync(marker ReadMarker) ListOption {
+	return func(m *ListModel) {
+		m.readMarker = marker
+	}
+}
+
+// WithPreviewer enables the detail pane, fetching previews with p.
+func WithPreviewer(p Previewer) ListOption {
+	return func(m *ListModel) {
+		m.previewer = p
+		m.previews = make(map[string]*previewEntry)
+	}
+}
+
+// WithActivityStore records opening and replying to items in store, so the
+// You column reflects them on later runs.
+func WithActivityStore(store *activity.Store) ListOption {
+	return func(m *ListModel) {
+		m.activity = store
+	}
+}
+
+// WithSnoozeStore enables snoozing items with z, recorded in store, and
+// clears the due reminder on items marked done so it doesn't bring them
+// straight back.
+func WithSnoozeStore(store *snooze.Store) ListOption {
+	return func(m *ListModel) {
+		m.snoozes = store
+	}
+}
+
+// WithPlacementStore enables moving items between panes with m, recorded
+// in store so the move outlasts the session.
+func WithPlacementStore(store *placement.Store) ListOption {
+	return func(m *ListModel) {
+		m.placements = store
+	}
+}
+
+// WithReviewSLO highlights pending review requests close to the SLO
+// target and summarizes recent response times in the footer.
+func WithReviewSLO(policy slo.Policy, stats slo.Stats) ListOption {
+	return func(m *ListModel) {
+		m.reviewSLO = policy
+		m.reviewStats = stats
+	}
+}
+
+// WithConfirmations sets the policies deciding which actions prompt before running.
+// Without it every guarded action prompts.
+func WithConfirmations(p *confirm.Policies) ListOption {
+	return func(m *ListModel) {
+		m.confirmations = p
+	}
+}
+
+// WithUpdates replaces the listed items with each list received from
+// updates, for example once a background refresh finishes. The list keeps
+// its own copy of each slice, so the sender may reuse it, but the items
+// themselves must not change once sent: their details and other pointers
+// are shared with the list.
+func WithUpdates(updates <-chan []triage.PrioritizedItem) ListOption {
+	return func(m *ListModel) {
+		m.updates = updates
 	}
 }
 
 // NewListModel creates a new list model
 func NewListModel(items []triage.PrioritizedItem, store *resolved.Store, weights config.ScoreWeights, currentUser string, opts ...ListOption) ListModel {
 	m := ListModel{
-		items:                items,
+		// A copy, so sorting and marking items done never touch the caller's slice
+		items:                slices.Clone(items),
 		resolved:             store,
 		windowWidth:          80,
 		windowHeight:         24,
+		weights:              weights,
 		hotTopicThreshold:    weights.HotTopicThreshold,
 		prSizeXS:             weights.PRSizeXS,
 		prSizeS:              weights.PRSizeS,
@@ -244,12 +580,17 @@ func NewListModel(items []triage.PrioritizedItem, store *resolved.Store, weights
 		blockedSortDesc:      true, // default: descending (most recent first)
 		dependabotSortColumn: defaultDependabotSortColumn,
 		dependabotSortDesc:   true, // default: descending (most recent first)
+		laterSortColumn:      defaultLaterSortColumn,
+		laterSortDesc:        true, // default: descending (highest priority first)
 	}
 	for _, opt := range opts {
 		opt(&m)
 	}
 	// Load sort preferences from config if available
 	m.loadSortPreferences()
+	if m.config != nil && m.config.UI != nil && m.config.UI.ClusterRelated != nil {
+		m.clustered = *m.config.UI.ClusterRelated
+	}
 	// Split items into queue and orphaned lists
 	m.splitItems()
 	return m
@@ -268,48 +609,50 @@ func (m *ListModel) splitItems() {
 	m.assignedDoneItems = nil
 	m.blockedDoneItems = nil
 	m.dependabotDoneItems = nil
+	m.laterItems = nil
+	m.laterDoneItems = nil
 
 	for _, item := range m.items {
-		resolved := m.resolved != nil && !m.resolved.ShouldShow(item.ID, item.UpdatedAt)
+		// Snoozed items stay out of every pane until the snooze ends
+		if m.snoozes != nil && item.Reminder == nil && !m.snoozes.ShouldShow(item.Key(), item.UpdatedAt) {
+			continue
+		}
+		// A due reminder brings an item back without new activity
+		resolved := m.resolved != nil && item.Reminder == nil && !m.resolved.ShouldShow(item.Key(), item.UpdatedAt)
+		item.Resurfaced = m.resolved != nil && m.resolved.IsResolved(item.Key()) && m.resolved.ShouldShow(item.Key(), item.UpdatedAt)
 
-		// Check for blocked label AND assigned to current user - blocked items don't go to other panes
-		if m.hasBlockedLabel(item) && m.isAssignedToCurrentUser(item) {
+		switch m.paneFor(item) {
+		case paneBlocked:
 			if resolved {
 				m.blockedDoneItems = append(m.blockedDoneItems, item)
 			} else {
 				m.blockedItems = append(m.blockedItems, item)
 			}
-			continue
-		}
-		// Dependency-bot PRs get their own pane
-		if m.isDependencyBot(item) {
+		case paneDependabot:
 			if resolved {
 				m.dependabotDoneItems = append(m.dependabotDoneItems, item)
 			} else {
 				m.dependabotItems = append(m.dependabotItems, item)
 			}
-			continue
-		}
-		// Check assignment - assigned items never go to orphaned
-		if m.isAssignedToCurrentUser(item) {
+		case paneAssigned:
 			if resolved {
 				m.assignedDoneItems = append(m.assignedDoneItems, item)
 			} else {
 				m.assignedItems = append(m.assignedItems, item)
 			}
-		} else if m.hasAnyAssignee(item) {
+		case paneOrphaned:
 			if resolved {
-				m.queueDoneItems = append(m.queueDoneItems, item)
+				m.orphanedDoneItems = append(m.orphanedDoneItems, item)
 			} else {
-				m.queueItems = append(m.queueItems, item)
+				m.orphanedItems = append(m.orphanedItems, item)
 			}
-		} else if item.Reason == model.ReasonOrphaned {
+		case paneLater:
 			if resolved {
-				m.orphanedDoneItems = append(m.orphanedDoneItems, item)
+				m.laterDoneItems = append(m.laterDoneItems, item)
 			} else {
-				m.orphanedItems = append(m.orphanedItems, item)
+				m.laterItems = append(m.laterItems, item)
 			}
-		} else {
+		default:
 			if resolved {
 				m.queueDoneItems = append(m.queueDoneItems, item)
 			} else {
@@ -317,12 +660,71 @@ func (m *ListModel) splitItems() {
 			}
 		}
 	}
+	m.clusters = triage.Cluster(m.items, triage.ClusterOptions{CurrentUser: m.currentUser})
+	m.splitTrash()
+
 	// Sort all lists based on configured column and direction
 	m.sortQueueItems()
 	m.sortOrphanedItems()
 	m.sortAssignedItems()
 	m.sortBlockedItems()
 	m.sortDependabotItems()
+	m.sortLaterItems()
+}
+
+// paneFor returns the pane the user moved item to, or else the pane it is
+// classified into.
+func (m *ListModel) paneFor(item triage.PrioritizedItem) pane {
+	if m.placements != nil {
+		if name, ok := m.placements.Pane(item.Key()); ok {
+			for _, p := range movablePanes {
+				if p.name == name {
+					return p.pane
+				}
+			}
+		}
+	}
+	return m.classify(item)
+}
+
+// classify returns the pane item belongs in from its labels, author, and
+// assignees.
+func (m *ListModel) classify(item triage.PrioritizedItem) pane {
+	switch {
+	// Blocked items assigned to the current user don't go to other panes
+	case m.isBlocked(item) && m.isAssignedToCurrentUser(item):
+		return paneBlocked
+	// Dependency-bot PRs get their own pane
+	case m.isDependencyBot(item):
+		return paneDependabot
+	// Assigned items never go to orphaned
+	case m.isAssignedToCurrentUser(item):
+		return paneAssigned
+	case m.hasAnyAssignee(item):
+		return paneQueue
+	case item.Reason == model.ReasonOrphaned:
+		return paneOrphaned
+	default:
+		return paneQueue
+	}
+}
+
+// splitTrash collects the hidden items resolved within trashWindow, most
+// recently resolved first.
+func (m *ListModel) splitTrash() {
+	m.trashItems = nil
+	if m.resolved == nil {
+		return
+	}
+	recent := m.resolved.ResolvedSince(time.Now().Add(-trashWindow))
+	for _, item := range m.items {
+		if _, ok := recent[item.Key()]; ok && item.Reminder == nil && !m.resolved.ShouldShow(item.Key(), item.UpdatedAt) {
+			m.trashItems = append(m.trashItems, item)
+		}
+	}
+	sort.SliceStable(m.trashItems, func(i, j int) bool {
+		return recent[m.trashItems[i].Key()].After(recent[m.trashItems[j].Key()])
+	})
 }
 
 // isDependencyBot reports whether the item is authored by a known
@@ -360,11 +762,11 @@ func (m *ListModel) hasAnyAssignee(item triage.PrioritizedItem) bool {
 	return len(item.Assignees) > 0
 }
 
-// hasBlockedLabel checks if the item has any of the configured blocked labels (case-insensitive).
-// If blockedLabels is empty, always returns false (blocked pane is disabled).
-func (m *ListModel) hasBlockedLabel(pi triage.PrioritizedItem) bool {
-	if len(m.blockedLabels) == 0 {
-		return false
+// isBlocked checks if the item has open blockers, or any of the configured
+// blocked labels (case-insensitive). Blockers stop counting once they close.
+func (m *ListModel) isBlocked(pi triage.PrioritizedItem) bool {
+	if len(pi.BlockedBy) > 0 {
+		return true
 	}
 	for _, itemLabel := range pi.Labels {
 		for _, blockedLabel := range m.blockedLabels {
@@ -412,6 +814,12 @@ func (m *ListModel) loadSortPreferences() {
 	if ui.DependabotSortDesc != nil {
 		m.dependabotSortDesc = *ui.DependabotSortDesc
 	}
+	if ui.LaterSortColumn != "" {
+		m.laterSortColumn = SortColumn(ui.LaterSortColumn)
+	}
+	if ui.LaterSortDesc != nil {
+		m.laterSortDesc = *ui.LaterSortDesc
+	}
 }
 
 // saveSortPreferences saves sort preferences to config
@@ -432,9 +840,19 @@ func (m *ListModel) saveSortPreferences() {
 	m.config.UI.BlockedSortDesc = &m.blockedSortDesc
 	m.config.UI.DependabotSortColumn = string(m.dependabotSortColumn)
 	m.config.UI.DependabotSortDesc = &m.dependabotSortDesc
-	// Save async to avoid blocking UI
+	m.config.UI.LaterSortColumn = string(m.laterSortColumn)
+	m.config.UI.LaterSortDesc = &m.laterSortDesc
+	m.saveUIPreferences()
+}
+
+// saveUIPreferences writes the UI preferences in the background, to avoid
+// blocking the UI. It writes a copy, so later changes to the config can't
+// race with the write.
+func (m *ListModel) saveUIPreferences() {
+	ui := *m.config.UI
+	prefs := &config.Config{UI: &ui}
 	go func() {
-		_ = m.config.SaveUIPreferences()
+		_ = prefs.SaveUIPreferences()
 	}()
 }
 
@@ -444,11 +862,28 @@ func (m *ListModel) sortQueueItems() {
 		return
 	}
 
-	column := m.queueSortColumn
-	desc := m.queueSortDesc
+	// Sort a copy; earlier copies of the model may share the list
+	m.queueItems = slices.Clone(m.queueItems)
+	sortByQueueColumn(m.queueItems, m.queueSortColumn, m.queueSortDesc)
+	m.pinResurfaced(m.queueItems)
+}
+
+// sortLaterItems sorts the items moved to Later by the configured column and
+// direction. Later takes the queue's columns.
+func (m *ListModel) sortLaterItems() {
+	if len(m.laterItems) == 0 {
+		return
+	}
+
+	m.laterItems = slices.Clone(m.laterItems)
+	sortByQueueColumn(m.laterItems, m.laterSortColumn, m.laterSortDesc)
+	m.pinResurfaced(m.laterItems)
+}
 
-	sort.Slice(m.queueItems, func(i, j int) bool {
-		a, b := m.queueItems[i], m.queueItems[j]
+// sortByQueueColumn sorts items in place by one of queueSortColumns.
+func sortByQueueColumn(items []triage.PrioritizedItem, column SortColumn, desc bool) {
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
 		var less bool
 
 		switch column {
@@ -537,6 +972,17 @@ func (m *ListModel) sortQueueItems() {
 	})
 }
 
+// pinResurfaced moves pinned items to the top of items, keeping the sort
+// order within pinned and unpinned items.
+func (m *ListModel) pinResurfaced(items []triage.PrioritizedItem) {
+	if len(m.pinned) == 0 {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return m.pinned[items[i].Key()] && !m.pinned[items[j].Key()]
+	})
+}
+
 // daysSinceTeamActivity calculates how many days since the last team activity on an item.
 // Falls back to CreatedAt if LastTeamActivityAt is not set (matching display logic).
 func daysSinceTeamActivity(item triage.PrioritizedItem) int {
@@ -559,6 +1005,7 @@ func (m *ListModel) sortOrphanedItems() {
 	column := m.orphanedSortColumn
 	desc := m.orphanedSortDesc
 
+	m.orphanedItems = slices.Clone(m.orphanedItems)
 	sort.Slice(m.orphanedItems, func(i, j int) bool {
 		a, b := m.orphanedItems[i], m.orphanedItems[j]
 		var less bool
@@ -645,6 +1092,7 @@ func (m *ListModel) sortOrphanedItems() {
 		}
 		return less
 	})
+	m.pinResurfaced(m.orphanedItems)
 }
 
 // sortAssignedItems sorts the assigned items by the configured column and direction.
@@ -656,6 +1104,7 @@ func (m *ListModel) sortAssignedItems() {
 	column := m.assignedSortColumn
 	desc := m.assignedSortDesc
 
+	m.assignedItems = slices.Clone(m.assignedItems)
 	sort.Slice(m.assignedItems, func(i, j int) bool {
 		a, b := m.assignedItems[i], m.assignedItems[j]
 		var less bool
@@ -735,6 +1184,7 @@ func (m *ListModel) sortAssignedItems() {
 		}
 		return less
 	})
+	m.pinResurfaced(m.assignedItems)
 }
 
 // sortBlockedItems sorts the blocked items by the configured column and direction.
@@ -746,6 +1196,7 @@ func (m *ListModel) sortBlockedItems() {
 	column := m.blockedSortColumn
 	desc := m.blockedSortDesc
 
+	m.blockedItems = slices.Clone(m.blockedItems)
 	sort.Slice(m.blockedItems, func(i, j int) bool {
 		a, b := m.blockedItems[i], m.blockedItems[j]
 		var less bool
@@ -825,6 +1276,7 @@ func (m *ListModel) sortBlockedItems() {
 		}
 		return less
 	})
+	m.pinResurfaced(m.blockedItems)
 }
 
 // sortDependabotItems sorts the dependabot items by the configured column and direction.
@@ -836,6 +1288,7 @@ func (m *ListModel) sortDependabotItems() {
 	column := m.dependabotSortColumn
 	desc := m.dependabotSortDesc
 
+	m.dependabotItems = slices.Clone(m.dependabotItems)
 	sort.Slice(m.dependabotItems, func(i, j int) bool {
 		a, b := m.dependabotItems[i], m.dependabotItems[j]
 		var less bool
@@ -898,6 +1351,7 @@ func (m *ListModel) sortDependabotItems() {
 		}
 		return less
 	})
+	m.pinResurfaced(m.dependabotItems)
 }
 
 // itemIsPR checks whether an item is a pull request using the same logic as renderRow.
@@ -905,10 +1359,20 @@ func itemIsPR(item triage.PrioritizedItem) bool {
 	return item.Type == model.ItemTypePullRequest || item.Subject.Type == model.SubjectPullRequest
 }
 
-// activeItems returns the items for the active pane, filtered by the current type filter
+// activeItems returns the rows of the active pane: its items filtered by
+// the current type and label filters, with related items folded together
+// when grouping is on
 func (m *ListModel) activeItems() []triage.PrioritizedItem {
+	items, _ := m.clusterRows(m.paneItems())
+	return items
+}
+
+// paneItems returns the items for the active pane, filtered by the current type and label filters
+func (m *ListModel) paneItems() []triage.PrioritizedItem {
 	var items []triage.PrioritizedItem
-	if m.showDone {
+	if m.showTrash {
+		items = m.trashItems
+	} else if m.showDone {
 		switch m.activePane {
 		case paneOrphaned:
 			items = m.orphanedDoneItems
@@ -918,6 +1382,8 @@ func (m *ListModel) activeItems() []triage.PrioritizedItem {
 			items = m.blockedDoneItems
 		case paneDependabot:
 			items = m.dependabotDoneItems
+		case paneLater:
+			items = m.laterDoneItems
 		default:
 			items = m.queueDoneItems
 		}
@@ -931,29 +1397,41 @@ func (m *ListModel) activeItems() []triage.PrioritizedItem {
 			items = m.blockedItems
 		case paneDependabot:
 			items = m.dependabotItems
+		case paneLater:
+			items = m.laterItems
 		default:
 			items = m.queueItems
 		}
 	}
 
-	if m.typeFilter == typeFilterAll {
+	if m.typeFilter == typeFilterAll && m.labelFilter.IsZero() {
 		return items
 	}
 
 	filtered := make([]triage.PrioritizedItem, 0, len(items))
 	for _, item := range items {
-		isPR := itemIsPR(item)
-		if m.typeFilter == typeFilterPR && isPR {
-			filtered = append(filtered, item)
-		} else if m.typeFilter == typeFilterIssue && !isPR {
+		if m.matchesFilters(item) {
 			filtered = append(filtered, item)
 		}
 	}
 	return filtered
 }
 
+// matchesFilters reports whether item passes the type and label filters.
+func (m *ListModel) matchesFilters(item triage.PrioritizedItem) bool {
+	isPR := itemIsPR(item)
+	switch {
+	case m.typeFilter == typeFilterPR && !isPR, m.typeFilter == typeFilterIssue && isPR:
+		return false
+	}
+	return m.labelFilter.Matches(item.Labels)
+}
+
 // activeCursor returns the cursor position for the active pane
 func (m *ListModel) activeCursor() int {
+	if m.showTrash {
+		return m.trashCursor
+	}
 	if m.showDone {
 		switch m.activePane {
 		case paneOrphaned:
@@ -964,6 +1442,8 @@ func (m *ListModel) activeCursor() int {
 			return m.blockedDoneCursor
 		case paneDependabot:
 			return m.dependabotDoneCursor
+		case paneLater:
+			return m.laterDoneCursor
 		default:
 			return m.queueDoneCursor
 		}
@@ -977,6 +1457,8 @@ func (m *ListModel) activeCursor() int {
 		return m.blockedCursor
 	case paneDependabot:
 		return m.dependabotCursor
+	case paneLater:
+		return m.laterCursor
 	default:
 		return m.queueCursor
 	}
@@ -984,6 +1466,10 @@ func (m *ListModel) activeCursor() int {
 
 // setActiveCursor sets the cursor position for the active pane
 func (m *ListModel) setActiveCursor(pos int) {
+	if m.showTrash {
+		m.trashCursor = pos
+		return
+	}
 	if m.showDone {
 		switch m.activePane {
 		case paneOrphaned:
@@ -994,6 +1480,8 @@ func (m *ListModel) setActiveCursor(pos int) {
 			m.blockedDoneCursor = pos
 		case paneDependabot:
 			m.dependabotDoneCursor = pos
+		case paneLater:
+			m.laterDoneCursor = pos
 		default:
 			m.queueDoneCursor = pos
 		}
@@ -1008,6 +1496,8 @@ func (m *ListModel) setActiveCursor(pos int) {
 		m.blockedCursor = pos
 	case paneDependabot:
 		m.dependabotCursor = pos
+	case paneLater:
+		m.laterCursor = pos
 	default:
 		m.queueCursor = pos
 	}
@@ -1015,14 +1505,78 @@ func (m *ListModel) setActiveCursor(pos int) {
 
 // Init implements tea.Model
 func (m ListModel) Init() tea.Cmd {
-	return nil
+	return tea.Batch(waitForItems(m.updates), m.watchConfig())
+}
+
+// ItemsMsg replaces the items the list shows. See WithUpdates.
+type ItemsMsg struct {
+	Items []triage.PrioritizedItem
+}
+
+// waitForItems delivers the next list from updates as an ItemsMsg. It
+// returns nil when there are no updates to wait for.
+func waitForItems(updates <-chan []triage.PrioritizedItem) tea.Cmd {
+	if updates == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		items, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return ItemsMsg{Items: items}
+	}
+}
+
+// setItems replaces the listed items with a copy of items, keeping the
+// selected item selected when it is still listed.
+func (m *ListModel) setItems(items []triage.PrioritizedItem) {
+	var selected *triage.PrioritizedItem
+	if active := m.activeItems(); m.activeCursor() < len(active) {
+		item := active[m.activeCursor()]
+		selected = &item
+	}
+
+	m.items = slices.Clone(items)
+	m.splitItems()
+
+	for _, c := range []struct {
+		cursor *int
+		items  []triage.PrioritizedItem
+	}{
+		{&m.queueCursor, m.queueItems},
+		{&m.orphanedCursor, m.orphanedItems},
+		{&m.assignedCursor, m.assignedItems},
+		{&m.blockedCursor, m.blockedItems},
+		{&m.dependabotCursor, m.dependabotItems},
+		{&m.laterCursor, m.laterItems},
+		{&m.queueDoneCursor, m.queueDoneItems},
+		{&m.orphanedDoneCursor, m.orphanedDoneItems},
+		{&m.assignedDoneCursor, m.assignedDoneItems},
+		{&m.blockedDoneCursor, m.blockedDoneItems},
+		{&m.dependabotDoneCursor, m.dependabotDoneItems},
+		{&m.laterDoneCursor, m.laterDoneItems},
+		{&m.trashCursor, m.trashItems},
+	} {
+		*c.cursor = max(min(*c.cursor, len(c.items)-1), 0)
+	}
+	m.preserveCursorPosition(selected)
 }
 
 // Update implements tea.Model
 func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		if m.minimalRedraw {
+			m.statusMsg = ""
+		}
+		// Moving the cursor with the detail pane shown loads the preview
+		// of the newly selected item
+		next, cmd := m.handleKey(msg)
+		if lm, ok := next.(ListModel); ok {
+			return lm, tea.Batch(cmd, lm.loadPreview())
+		}
+		return next, cmd
 
 	case tea.WindowSizeMsg:
 		m.windowWidth = msg.Width
@@ -1030,8 +1584,106 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case clearStatusMsg:
-		m.statusMsg = ""
+		if !m.minimalRedraw {
+			m.statusMsg = ""
+		}
+		return m, nil
+
+	case ItemsMsg:
+		if m.weightsEditor != nil {
+			// Score the refreshed items with the weights being edited
+			editor := *m.weightsEditor
+			editor.original = msg.Items
+			m.rescore(editor)
+			m.weightsEditor = &editor
+			return m, waitForItems(m.updates)
+		}
+		if m.reloadedWeights != nil {
+			// Items are scored with the weights the config started with
+			m.scoreWith(msg.Items, *m.reloadedWeights)
+		} else {
+			m.setItems(msg.Items)
+		}
+		return m, tea.Batch(waitForItems(m.updates), m.loadPreview())
+
+	case configCheckedMsg:
+		return m.reloadConfig(msg.stamp)
+
+	case delegatedMsg:
+		return m.finishDelegation(msg)
+
+	case sharedMsg:
+		m.statusMsg = sharedStatus(msg)
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+
+	case weightsSavedMsg:
+		m.statusMsg = "Weights saved to " + msg.path
+		if msg.err != nil {
+			m.statusMsg = "Weights applied but not saved: " + msg.err.Error()
+		}
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+
+	case previewLoadedMsg:
+		m.previews[msg.key] = &previewEntry{preview: msg.preview, err: msg.err}
 		return m, nil
+
+	case editBufferReadyMsg:
+		if msg.err != nil {
+			m.statusMsg = "Error: " + msg.err.Error()
+			m.statusTime = time.Now()
+			return m, clearStatusAfter(2 * time.Second)
+		}
+		return m, tea.ExecProcess(editor.Command(msg.path), func(err error) tea.Msg {
+			return editorClosedMsg{ref: msg.ref, path: msg.path, err: err}
+		})
+
+	case editorClosedMsg:
+		if msg.err != nil {
+			m.statusMsg = "Editor failed; draft kept at " + msg.path
+			m.statusTime = time.Now()
+			return m, clearStatusAfter(2 * time.Second)
+		}
+		reply, err := editor.ReadReply(msg.path)
+		if err != nil || reply == "" {
+			return m, m.submitReply(msg.ref, msg.path)
+		}
+		return m.confirmThen(confirm.ActionComment, 1,
+			fmt.Sprintf("Post reply to %s/%s#%d?", msg.ref.owner, msg.ref.repo, msg.ref.number),
+			"Reply not posted; draft kept at "+msg.path,
+			m.submitReply(msg.ref, msg.path))
+
+	case markedReadMsg:
+		done := "Marked as done"
+		if msg.done > 1 {
+			done = fmt.Sprintf("Marked %d as done", msg.done)
+		}
+		switch {
+		case errors.Is(msg.err, ghclient.ErrDryRun):
+			m.statusMsg = done + "; dry run: not marked read on GitHub"
+		case msg.err != nil:
+			m.statusMsg = done + "; not marked read: " + msg.err.Error()
+		default:
+			m.statusMsg = done + " and read"
+		}
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+
+	case replySubmittedMsg:
+		switch {
+		case errors.Is(msg.err, ghclient.ErrDryRun):
+			m.statusMsg = "Dry run: reply not posted, draft kept"
+		case msg.err != nil:
+			m.statusMsg = "Error: " + msg.err.Error()
+		case msg.posted:
+			m.statusMsg = "Comment posted"
+			m.recordInteraction(activity.RefKey(msg.ref.owner+"/"+msg.ref.repo, msg.ref.number), time.Now())
+		default:
+			m.statusMsg = "No reply written"
+		}
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
 	}
 
 	return m, nil
@@ -1039,13 +1691,39 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKey processes keyboard input
 func (m ListModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.pending != nil {
+		return m.handleConfirmKey(msg)
+	}
+	if m.weightsEditor != nil {
+		return m.handleWeightsKey(msg)
+	}
+	if m.snoozing != nil {
+		return m.handleSnoozeKey(msg)
+	}
+	if m.moving != nil {
+		return m.handleMoveKey(msg)
+	}
+	if m.delegating != nil {
+		return m.handleDelegateKey(msg)
+	}
+	if m.labelPrompt != nil {
+		return m.handleLabelKey(msg)
+	}
+
+	// Esc clears a selection before it quits
+	if msg.String() == "esc" && len(m.markedItems()) > 0 {
+		return m.clearMarks()
+	}
+
 	switch msg.String() {
 	case "q", "esc", "ctrl+c":
 		m.quitting = true
 		return m, tea.Quit
 
 	case "tab":
-		// Cycle through panes: Assigned -> Blocked -> Queue -> Dependabot -> Orphaned -> Assigned
+		m.showTrash = false
+		// Cycle through panes: Assigned -> Blocked -> Queue -> Dependabot -> Orphaned
+		// -> Later, when moving is enabled -> Assigned
 		switch m.activePane {
 		case paneAssigned:
 			m.activePane = paneBlocked
@@ -1057,29 +1735,47 @@ func (m ListModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.activePane = paneOrphaned
 		case paneOrphaned:
 			m.activePane = paneAssigned
+			if m.placements != nil {
+				m.activePane = paneLater
+			}
+		case paneLater:
+			m.activePane = paneAssigned
 		}
 		return m, nil
 
 	case "1":
+		m.showTrash = false
 		m.activePane = paneAssigned
 		return m, nil
 
 	case "2":
+		m.showTrash = false
 		m.activePane = paneBlocked
 		return m, nil
 
 	case "3":
+		m.showTrash = false
 		m.activePane = paneQueue
 		return m, nil
 
 	case "4":
+		m.showTrash = false
 		m.activePane = paneDependabot
 		return m, nil
 
 	case "5":
+		m.showTrash = false
 		m.activePane = paneOrphaned
 		return m, nil
 
+	case "6":
+		if m.placements == nil {
+			return m, nil
+		}
+		m.showTrash = false
+		m.activePane = paneLater
+		return m, nil
+
 	case "j", "down":
 		items := m.activeItems()
 		cursor := m.activeCursor()
@@ -1107,35 +1803,147 @@ func (m ListModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case "d":
+		if m.showTrash {
+			return m.restoreFromTrash()
+		}
 		if m.showDone {
 			return m.undoDone()
 		}
 		return m.markDone()
 
+	case "D":
+		if m.showTrash {
+			return m.restoreFromTrash()
+		}
+		if m.showDone {
+			return m.undoDone()
+		}
+		return m.markDoneLocally()
+
 	case "u":
+		if m.showTrash {
+			return m, nil
+		}
 		return m.toggleDoneView()
 
+	case "T":
+		return m.toggleTrash()
+
+	case "z":
+		return m.promptSnooze()
+
+	case "m":
+		return m.promptMove()
+
+	case "A":
+		return m.promptDelegate()
+
 	case "enter":
 		return m.openInBrowser()
 
+	case "E":
+		return m.editItem()
+
+	case "y":
+		return m.shareItems()
+
+	// The recently resolved view keeps its own order
 	case "s":
+		if m.showTrash {
+			return m, nil
+		}
 		return m.cycleSortColumn()
 
 	case "S":
+		if m.showTrash {
+			return m, nil
+		}
 		return m.toggleSortDirection()
 
 	case "r":
+		if m.showTrash {
+			return m, nil
+		}
 		return m.resetSort()
 
 	case "t":
 		return m.cycleTypeFilter()
+
+	case "L":
+		return m.promptLabelFilter()
+
+	case "c":
+		return m.toggleClusters()
+
+	case " ":
+		if m.inGroup() {
+			return m.toggleExpanded()
+		}
+		return m.toggleDetail()
+
+	case "l":
+		return m.toggleDetail()
+
+	case "W":
+		return m.openWeightsEditor()
+
+	case "v":
+		return m.toggleMark()
+
+	case "V":
+		return m.markRange()
 	}
 
 	return m, nil
 }
 
-// markDone marks the current item as done
+// confirmThen runs cmd, first asking for confirmation when the configured
+// policy for action requires it at count items. This is the TUI side of the
+// shared confirmation policy; the CLI uses confirm.Ask.
+func (m ListModel) confirmThen(action confirm.Action, count int, prompt, cancelMsg string, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if !m.confirmations.Required(action, count) {
+		return m, cmd
+	}
+	m.pending = &pendingConfirm{
+		prompt:    prompt,
+		onConfirm: cmd,
+		cancelMsg: cancelMsg,
+	}
+	return m, nil
+}
+
+// handleConfirmKey answers the pending confirmation prompt
+func (m ListModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pending := m.pending
+	m.pending = nil
+
+	if confirm.IsYes(msg.String()) {
+		return m, pending.onConfirm
+	}
+
+	m.statusMsg = pending.cancelMsg
+	m.statusTime = time.Now()
+	return m, clearStatusAfter(2 * time.Second)
+}
+
+// markDone marks the current item as done, and its notification read on
+// GitHub when read sync is enabled
 func (m ListModel) markDone() (tea.Model, tea.Cmd) {
+	return m.markItemDone(true)
+}
+
+// markDoneLocally marks the current item as done without touching GitHub
+func (m ListModel) markDoneLocally() (tea.Model, tea.Cmd) {
+	return m.markItemDone(false)
+}
+
+// markItemDone marks the current item as done, and with syncRead marks its
+// notification read when read sync is enabled
+func (m ListModel) markItemDone(syncRead bool) (tea.Model, tea.Cmd) {
+	if marked := m.markedItems(); len(marked) > 0 {
+		return m.markSelectedDone(marked, syncRead)
+	}
+
 	items := m.activeItems()
 	cursor := m.activeCursor()
 
@@ -1147,67 +1955,108 @@ func (m ListModel) markDone() (tea.Model, tea.Cmd) {
 	n := item.Item
 
 	// Resolve using the item's UpdatedAt time
-	if err := m.resolved.Resolve(n.ID, n.UpdatedAt); err != nil {
+	if err := m.resolved.Resolve(n.Key(), n.UpdatedAt); err != nil {
 		m.statusMsg = "Error: " + err.Error()
 		m.statusTime = time.Now()
 		return m, clearStatusAfter(2 * time.Second)
 	}
-
-	// Remove from the active pane's underlying (unfiltered) list.
-	// When a type filter is active, cursor indexes the filtered view,
-	// so we find the item by ID in the underlying slice.
-	removeByID := func(items []triage.PrioritizedItem, id string) []triage.PrioritizedItem {
-		for i, it := range items {
-			if it.ID == id {
-				return append(items[:i], items[i+1:]...)
-			}
+	item.Resurfaced = false
+	if n.Reminder != nil && m.snoozes != nil {
+		if _, err := m.snoozes.Clear(n.Key()); err != nil {
+			m.statusMsg = "Error: " + err.Error()
+			m.statusTime = time.Now()
+			return m, clearStatusAfter(2 * time.Second)
 		}
-		return items
 	}
+	item.Reminder = nil
+	m.moveToDone(item)
+
+	// Clamp cursor for the filtered view as well
+	filtered := m.activeItems()
+	if cursor >= len(filtered) && cursor > 0 {
+		m.setActiveCursor(len(filtered) - 1)
+	}
+
+	m.statusMsg = "Marked as done"
+	m.statusTime = time.Now()
 
+	if syncRead && m.readMarker != nil && n.IsNotification() && n.Unread {
+		m.statusMsg = "Marked as done; marking read..."
+		return m, m.markRead(1, []string{n.ID})
+	}
+	return m, clearStatusAfter(2 * time.Second)
+}
+
+// moveToDone moves item from the active pane's list to its done list and
+// the top of the recently resolved list
+func (m *ListModel) moveToDone(item triage.PrioritizedItem) {
+	n := item.Item
+
+	// Remove from the active pane's underlying (unfiltered) list.
+	// When a type filter is active, the cursor indexes the filtered view,
+	// so we find the item by ID in the underlying slice.
 	switch m.activePane {
 	case paneOrphaned:
-		m.orphanedItems = removeByID(m.orphanedItems, n.ID)
-		m.orphanedDoneItems = append(m.orphanedDoneItems, item)
+		m.orphanedItems = withoutItem(m.orphanedItems, n.ID)
+		m.orphanedDoneItems = append(slices.Clip(m.orphanedDoneItems), item)
 		if m.orphanedCursor >= len(m.orphanedItems) && m.orphanedCursor > 0 {
 			m.orphanedCursor = len(m.orphanedItems) - 1
 		}
 	case paneAssigned:
-		m.assignedItems = removeByID(m.assignedItems, n.ID)
-		m.assignedDoneItems = append(m.assignedDoneItems, item)
+		m.assignedItems = withoutItem(m.assignedItems, n.ID)
+		m.assignedDoneItems = append(slices.Clip(m.assignedDoneItems), item)
 		if m.assignedCursor >= len(m.assignedItems) && m.assignedCursor > 0 {
 			m.assignedCursor = len(m.assignedItems) - 1
 		}
 	case paneBlocked:
-		m.blockedItems = removeByID(m.blockedItems, n.ID)
-		m.blockedDoneItems = append(m.blockedDoneItems, item)
+		m.blockedItems = withoutItem(m.blockedItems, n.ID)
+		m.blockedDoneItems = append(slices.Clip(m.blockedDoneItems), item)
 		if m.blockedCursor >= len(m.blockedItems) && m.blockedCursor > 0 {
 			m.blockedCursor = len(m.blockedItems) - 1
 		}
 	case paneDependabot:
-		m.dependabotItems = removeByID(m.dependabotItems, n.ID)
-		m.dependabotDoneItems = append(m.dependabotDoneItems, item)
+		m.dependabotItems = withoutItem(m.dependabotItems, n.ID)
+		m.dependabotDoneItems = append(slices.Clip(m.dependabotDoneItems), item)
 		if m.dependabotCursor >= len(m.dependabotItems) && m.dependabotCursor > 0 {
 			m.dependabotCursor = len(m.dependabotItems) - 1
 		}
+	case paneLater:
+		m.laterItems = withoutItem(m.laterItems, n.ID)
+		m.laterDoneItems = append(slices.Clip(m.laterDoneItems), item)
+		if m.laterCursor >= len(m.laterItems) && m.laterCursor > 0 {
+			m.laterCursor = len(m.laterItems) - 1
+		}
 	default:
-		m.queueItems = removeByID(m.queueItems, n.ID)
-		m.queueDoneItems = append(m.queueDoneItems, item)
+		m.queueItems = withoutItem(m.queueItems, n.ID)
+		m.queueDoneItems = append(slices.Clip(m.queueDoneItems), item)
 		if m.queueCursor >= len(m.queueItems) && m.queueCursor > 0 {
 			m.queueCursor = len(m.queueItems) - 1
 		}
 	}
 
-	// Clamp cursor for the filtered view as well
-	filtered := m.activeItems()
-	if cursor >= len(filtered) && cursor > 0 {
-		m.setActiveCursor(len(filtered) - 1)
-	}
+	m.trashItems = append([]triage.PrioritizedItem{item}, m.trashItems...)
+}
 
-	m.statusMsg = "Marked as done"
-	m.statusTime = time.Now()
+// markRead marks the notification threads of done items read on GitHub,
+// carrying on past failures so one bad thread doesn't leave the rest unread
+func (m ListModel) markRead(done int, threadIDs []string) tea.Cmd {
+	marker := m.readMarker
+	return func() tea.Msg {
+		var errs []error
+		for _, id := range threadIDs {
+			errs = append(errs, marker.MarkAsRead(context.Background(), id))
+		}
+		return markedReadMsg{done: done, err: errors.Join(errs...)}
+	}
+}
 
-	return m, clearStatusAfter(2 * time.Second)
+// withoutItem returns items without the item with the given ID. It builds
+// a new slice rather than shifting items in place, since earlier copies of
+// the model may still share the old one.
+func withoutItem(items []triage.PrioritizedItem, id string) []triage.PrioritizedItem {
+	return slices.DeleteFunc(slices.Clone(items), func(it triage.PrioritizedItem) bool {
+		return it.ID == id
+	})
 }
 
 // toggleDoneView toggles showing done items for the current pane
@@ -1216,6 +2065,194 @@ func (m ListModel) toggleDoneView() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// promptSnooze asks how long to snooze the current item for
+func (m ListModel) promptSnooze() (tea.Model, tea.Cmd) {
+	items := m.activeItems()
+	if len(items) == 0 || m.showDone || m.showTrash {
+		return m, nil
+	}
+	if m.snoozes == nil {
+		m.statusMsg = "Snoozing not available"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+	if marked := m.markedItems(); len(marked) > 0 {
+		m.snoozing = &snoozePrompt{items: marked}
+		return m, nil
+	}
+	m.snoozing = &snoozePrompt{items: items[m.activeCursor() : m.activeCursor()+1]}
+	return m, nil
+}
+
+// handleSnoozeKey answers the snooze prompt
+func (m ListModel) handleSnoozeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := *m.snoozing
+	m.snoozing = nil
+
+	switch {
+	case msg.Type == tea.KeyEsc || msg.Type == tea.KeyCtrlC:
+		m.statusMsg = "Not snoozed"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+
+	case prompt.custom:
+		switch msg.Type {
+		case tea.KeyEnter:
+			d, err := duration.ParseDuration(strings.TrimSpace(prompt.input))
+			if err != nil || d <= 0 {
+				m.statusMsg = "Not snoozed: invalid duration " + strconv.Quote(prompt.input)
+				m.statusTime = time.Now()
+				return m, clearStatusAfter(2 * time.Second)
+			}
+			return m.snooze(prompt.items, d)
+		case tea.KeyBackspace:
+			runes := []rune(prompt.input)
+			if len(runes) > 0 {
+				prompt.input = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes:
+			prompt.input += string(msg.Runes)
+		}
+		m.snoozing = &prompt
+		return m, nil
+
+	case msg.String() == "c":
+		prompt.custom = true
+		m.snoozing = &prompt
+		return m, nil
+	}
+
+	for _, c := range snoozeChoices {
+		if msg.String() == c.key {
+			return m.snooze(prompt.items, c.duration)
+		}
+	}
+	// Any other key keeps the prompt open
+	m.snoozing = &prompt
+	return m, nil
+}
+
+// snooze hides items until d from now, or until they have new activity
+func (m ListModel) snooze(items []triage.PrioritizedItem, d time.Duration) (tea.Model, tea.Cmd) {
+	updatedAt := make(map[string]time.Time, len(items))
+	for _, item := range items {
+		updatedAt[item.Key()] = item.UpdatedAt
+	}
+	if err := m.snoozes.SnoozeAll(updatedAt, time.Now().Add(d)); err != nil {
+		m.statusMsg = "Error: " + err.Error()
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+	for key := range updatedAt {
+		delete(m.marked, key)
+	}
+
+	// Split the items again, leaving the snoozed items out
+	m.setItems(m.items)
+
+	m.statusMsg = "Snoozed for " + format.FormatAge(d)
+	if len(items) > 1 {
+		m.statusMsg = fmt.Sprintf("Snoozed %d items for %s", len(items), format.FormatAge(d))
+	}
+	m.statusTime = time.Now()
+
+	return m, clearStatusAfter(2 * time.Second)
+}
+
+// promptMove asks which pane to move the current item to
+func (m ListModel) promptMove() (tea.Model, tea.Cmd) {
+	items := m.activeItems()
+	if len(items) == 0 || m.showDone || m.showTrash {
+		return m, nil
+	}
+	if m.placements == nil {
+		m.statusMsg = "Moving not available"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+	item := items[m.activeCursor()]
+	m.moving = &item
+	return m, nil
+}
+
+// movePromptText returns the pane prompt as shown in the footer
+func movePromptText() string {
+	var b strings.Builder
+	b.WriteString("Move to:")
+	for _, p := range movablePanes {
+		b.WriteString("   " + p.key + ": " + p.label)
+	}
+	b.WriteString("   a: automatic   esc: cancel")
+	return b.String()
+}
+
+// handleMoveKey answers the pane prompt
+func (m ListModel) handleMoveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	item := *m.moving
+	m.moving = nil
+
+	var err error
+	switch key := msg.String(); key {
+	case "a":
+		var found bool
+		if found, err = m.placements.Reset(item.Key()); err == nil {
+			m.statusMsg = "Already in its automatic pane"
+			if found {
+				m.statusMsg = "Moved back to its automatic pane"
+			}
+		}
+	default:
+		m.statusMsg = "Not moved"
+		for _, p := range movablePanes {
+			if key == p.key {
+				if err = m.placements.Move(item.Key(), p.name); err == nil {
+					m.statusMsg = "Moved to " + p.label
+				}
+				break
+			}
+		}
+	}
+	if err != nil {
+		m.statusMsg = "Error: " + err.Error()
+	}
+	m.statusTime = time.Now()
+
+	// Split the items again, placing the item in its new pane
+	m.setItems(m.items)
+
+	return m, clearStatusAfter(2 * time.Second)
+}
+
+// toggleTrash shows or hides the items resolved within trashWindow
+func (m ListModel) toggleTrash() (tea.Model, tea.Cmd) {
+	m.showTrash = !m.showTrash
+	return m, nil
+}
+
+// restoreFromTrash restores the selected recently resolved item to its pane
+func (m ListModel) restoreFromTrash() (tea.Model, tea.Cmd) {
+	items := m.activeItems()
+	cursor := m.activeCursor()
+
+	if len(items) == 0 {
+		return m, nil
+	}
+
+	if err := m.resolved.Unresolve(items[cursor].Key()); err != nil {
+		m.statusMsg = "Error: " + err.Error()
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+
+	// The item may belong to any pane, so split the items again
+	m.setItems(m.items)
+
+	m.statusMsg = "Restored"
+	m.statusTime = time.Now()
+
+	return m, clearStatusAfter(2 * time.Second)
+}
+
 // undoDone restores a done item back to the active list
 func (m ListModel) undoDone() (tea.Model, tea.Cmd) {
 	items := m.activeItems()
@@ -1228,60 +2265,61 @@ func (m ListModel) undoDone() (tea.Model, tea.Cmd) {
 	item := items[cursor]
 	n := item.Item
 
-	if err := m.resolved.Unresolve(n.ID); err != nil {
+	if err := m.resolved.Unresolve(n.Key()); err != nil {
 		m.statusMsg = "Error: " + err.Error()
 		m.statusTime = time.Now()
 		return m, clearStatusAfter(2 * time.Second)
 	}
 
-	removeByID := func(items []triage.PrioritizedItem, id string) []triage.PrioritizedItem {
-		for i, it := range items {
-			if it.ID == id {
-				return append(items[:i], items[i+1:]...)
-			}
-		}
-		return items
-	}
-
 	// Remove from done list, add back to active list
 	switch m.activePane {
 	case paneOrphaned:
-		m.orphanedDoneItems = removeByID(m.orphanedDoneItems, n.ID)
-		m.orphanedItems = append(m.orphanedItems, item)
+		m.orphanedDoneItems = withoutItem(m.orphanedDoneItems, n.ID)
+		m.orphanedItems = append(slices.Clip(m.orphanedItems), item)
 		m.sortOrphanedItems()
 		if m.orphanedDoneCursor >= len(m.orphanedDoneItems) && m.orphanedDoneCursor > 0 {
 			m.orphanedDoneCursor = len(m.orphanedDoneItems) - 1
 		}
 	case paneAssigned:
-		m.assignedDoneItems = removeByID(m.assignedDoneItems, n.ID)
-		m.assignedItems = append(m.assignedItems, item)
+		m.assignedDoneItems = withoutItem(m.assignedDoneItems, n.ID)
+		m.assignedItems = append(slices.Clip(m.assignedItems), item)
 		m.sortAssignedItems()
 		if m.assignedDoneCursor >= len(m.assignedDoneItems) && m.assignedDoneCursor > 0 {
 			m.assignedDoneCursor = len(m.assignedDoneItems) - 1
 		}
 	case paneBlocked:
-		m.blockedDoneItems = removeByID(m.blockedDoneItems, n.ID)
-		m.blockedItems = append(m.blockedItems, item)
+		m.blockedDoneItems = withoutItem(m.blockedDoneItems, n.ID)
+		m.blockedItems = append(slices.Clip(m.blockedItems), item)
 		m.sortBlockedItems()
 		if m.blockedDoneCursor >= len(m.blockedDoneItems) && m.blockedDoneCursor > 0 {
 			m.blockedDoneCursor = len(m.blockedDoneItems) - 1
 		}
 	case paneDependabot:
-		m.dependabotDoneItems = removeByID(m.dependabotDoneItems, n.ID)
-		m.dependabotItems = append(m.dependabotItems, item)
+		m.dependabotDoneItems = withoutItem(m.dependabotDoneItems, n.ID)
+		m.dependabotItems = append(slices.Clip(m.dependabotItems), item)
 		m.sortDependabotItems()
 		if m.dependabotDoneCursor >= len(m.dependabotDoneItems) && m.dependabotDoneCursor > 0 {
 			m.dependabotDoneCursor = len(m.dependabotDoneItems) - 1
 		}
+	case paneLater:
+		m.laterDoneItems = withoutItem(m.laterDoneItems, n.ID)
+		m.laterItems = append(slices.Clip(m.laterItems), item)
+		m.sortLaterItems()
+		if m.laterDoneCursor >= len(m.laterDoneItems) && m.laterDoneCursor > 0 {
+			m.laterDoneCursor = len(m.laterDoneItems) - 1
+		}
 	default:
-		m.queueDoneItems = removeByID(m.queueDoneItems, n.ID)
-		m.queueItems = append(m.queueItems, item)
+		m.queueDoneItems = withoutItem(m.queueDoneItems, n.ID)
+		m.queueItems = append(slices.Clip(m.queueItems), item)
 		m.sortQueueItems()
 		if m.queueDoneCursor >= len(m.queueDoneItems) && m.queueDoneCursor > 0 {
 			m.queueDoneCursor = len(m.queueDoneItems) - 1
 		}
 	}
 
+	m.trashItems = withoutItem(m.trashItems, n.ID)
+	m.trashCursor = max(min(m.trashCursor, len(m.trashItems)-1), 0)
+
 	// Clamp cursor for the filtered view
 	filtered := m.activeItems()
 	if cursor >= len(filtered) && cursor > 0 {
@@ -1294,8 +2332,13 @@ func (m ListModel) undoDone() (tea.Model, tea.Cmd) {
 	return m, clearStatusAfter(2 * time.Second)
 }
 
-// openInBrowser opens the current item in the default browser
+// openInBrowser opens the current item, or the selected items, in the
+// default browser
 func (m ListModel) openInBrowser() (tea.Model, tea.Cmd) {
+	if marked := m.markedItems(); len(marked) > 0 {
+		return m.openSelected(marked)
+	}
+
 	items := m.activeItems()
 	cursor := m.activeCursor()
 
@@ -1318,9 +2361,135 @@ func (m ListModel) openInBrowser() (tea.Model, tea.Cmd) {
 		return m, clearStatusAfter(2 * time.Second)
 	}
 
+	m.recordInteraction(activity.Key(&item.Item), time.Now())
 	return m, openURL(url)
 }
 
+// recordInteraction notes that the user interacted with the item identified
+// by key at the given time, in the activity store and in every list
+// showing the item.
+func (m *ListModel) recordInteraction(key string, at time.Time) {
+	if key == "" {
+		return
+	}
+	if m.activity != nil {
+		// A failed write only loses the local record; nothing to show
+		_ = m.activity.Record(key, at)
+	}
+	lists := []*[]triage.PrioritizedItem{
+		&m.items,
+		&m.queueItems, &m.orphanedItems, &m.assignedItems, &m.blockedItems, &m.dependabotItems, &m.laterItems,
+		&m.queueDoneItems, &m.orphanedDoneItems, &m.assignedDoneItems, &m.blockedDoneItems, &m.dependabotDoneItems, &m.laterDoneItems,
+	}
+	for _, list := range lists {
+		// Lists are copied before changing, never updated in place
+		copied := false
+		for i := range *list {
+			if activity.Key(&(*list)[i].Item) != key {
+				continue
+			}
+			if !copied {
+				*list = slices.Clone(*list)
+				copied = true
+			}
+			(*list)[i].LastInteractionAt = &at
+		}
+	}
+}
+
+// itemRef identifies the issue or PR an edit session targets.
+type itemRef struct {
+	owner  string
+	repo   string
+	number int
+}
+
+// editBufferReadyMsg is sent once the edit buffer has been written to disk.
+type editBufferReadyMsg struct {
+	ref  itemRef
+	path string
+	err  error
+}
+
+// editorClosedMsg is sent when the external editor exits.
+type editorClosedMsg struct {
+	ref  itemRef
+	path string
+	err  error
+}
+
+// markedReadMsg is sent after the notifications of items marked done were
+// marked read.
+type markedReadMsg struct {
+	done int // items marked done
+	err  error
+}
+
+// replySubmittedMsg is sent after the reply (if any) has been posted.
+type replySubmittedMsg struct {
+	ref    itemRef
+	posted bool
+	err    error
+}
+
+// refForItem derives owner, repo, and number for an item. Items that were not
+// enriched may lack Number, so fall back to the last segment of the subject URL.
+func refForItem(item triage.PrioritizedItem) (itemRef, bool) {
+	owner, repo, ok := strings.Cut(item.Repository.FullName, "/")
+	if !ok || owner == "" || repo == "" {
+		return itemRef{}, false
+	}
+	number := item.Number
+	if number == 0 && item.Subject.URL != "" {
+		number, _ = strconv.Atoi(path.Base(item.Subject.URL))
+	}
+	if number <= 0 {
+		return itemRef{}, false
+	}
+	return itemRef{owner: owner, repo: repo, number: number}, true
+}
+
+// editItem opens the current item in $EDITOR so a reply can be written offline
+func (m ListModel) editItem() (tea.Model, tea.Cmd) {
+	items := m.activeItems()
+	cursor := m.activeCursor()
+
+	if len(items) == 0 {
+		return m, nil
+	}
+
+	if m.editor == nil {
+		m.statusMsg = "Editing not available"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+
+	ref, ok := refForItem(items[cursor])
+	if !ok {
+		m.statusMsg = "Cannot edit: item has no issue or PR number"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+
+	m.statusMsg = "Loading thread..."
+	m.statusTime = time.Now()
+
+	session := m.editor
+	return m, func() tea.Msg {
+		file, err := session.Prepare(context.Background(), ref.owner, ref.repo, ref.number)
+		return editBufferReadyMsg{ref: ref, path: file, err: err}
+	}
+}
+
+// submitReply posts any reply written in the edit buffer
+func (m ListModel) submitReply(ref itemRef, file string) tea.Cmd {
+	session := m.editor
+	return func() tea.Msg {
+		posted, err := session.Submit(context.Background(), ref.owner, ref.repo, ref.number, file)
+		return replySubmittedMsg{ref: ref, posted: posted, err: err}
+	}
+}
+
 // cycleSortColumn cycles to the next sort column for the active pane
 func (m ListModel) cycleSortColumn() (tea.Model, tea.Cmd) {
 	// Get current item to preserve cursor position
@@ -1347,6 +2516,9 @@ func (m ListModel) cycleSortColumn() (tea.Model, tea.Cmd) {
 	case paneDependabot:
 		columns = dependabotSortColumns
 		currentCol = &m.dependabotSortColumn
+	case paneLater:
+		columns = laterSortColumns
+		currentCol = &m.laterSortColumn
 	default:
 		columns = queueSortColumns
 		currentCol = &m.queueSortColumn
@@ -1373,6 +2545,8 @@ func (m ListModel) cycleSortColumn() (tea.Model, tea.Cmd) {
 		m.sortBlockedItems()
 	case paneDependabot:
 		m.sortDependabotItems()
+	case paneLater:
+		m.sortLaterItems()
 	default:
 		m.sortQueueItems()
 	}
@@ -1402,6 +2576,10 @@ func (m ListModel) cycleSortColumn() (tea.Model, tea.Cmd) {
 		if !m.dependabotSortDesc {
 			direction = "▲"
 		}
+	case paneLater:
+		if !m.laterSortDesc {
+			direction = "▲"
+		}
 	default:
 		if !m.queueSortDesc {
 			direction = "▲"
@@ -1436,6 +2614,9 @@ func (m ListModel) toggleSortDirection() (tea.Model, tea.Cmd) {
 	case paneDependabot:
 		m.dependabotSortDesc = !m.dependabotSortDesc
 		m.sortDependabotItems()
+	case paneLater:
+		m.laterSortDesc = !m.laterSortDesc
+		m.sortLaterItems()
 	default:
 		m.queueSortDesc = !m.queueSortDesc
 		m.sortQueueItems()
@@ -1463,6 +2644,9 @@ func (m ListModel) toggleSortDirection() (tea.Model, tea.Cmd) {
 	case paneDependabot:
 		col = m.dependabotSortColumn
 		desc = m.dependabotSortDesc
+	case paneLater:
+		col = m.laterSortColumn
+		desc = m.laterSortDesc
 	default:
 		col = m.queueSortColumn
 		desc = m.queueSortDesc
@@ -1504,6 +2688,10 @@ func (m ListModel) resetSort() (tea.Model, tea.Cmd) {
 		m.dependabotSortColumn = defaultDependabotSortColumn
 		m.dependabotSortDesc = true
 		m.sortDependabotItems()
+	case paneLater:
+		m.laterSortColumn = defaultLaterSortColumn
+		m.laterSortDesc = true
+		m.sortLaterItems()
 	default:
 		m.queueSortColumn = defaultQueueSortColumn
 		m.queueSortDesc = true
@@ -1527,6 +2715,8 @@ func (m ListModel) resetSort() (tea.Model, tea.Cmd) {
 		col = m.blockedSortColumn
 	case paneDependabot:
 		col = m.dependabotSortColumn
+	case paneLater:
+		col = m.laterSortColumn
 	default:
 		col = m.queueSortColumn
 	}
@@ -1617,17 +2807,14 @@ func (m ListModel) AssignedSortDesc() bool {
 	return m.assignedSortDesc
 }
 
-// filteredCount returns the number of items in a slice that match the current type filter.
+// filteredCount returns the number of items in a slice that match the current type and label filters.
 func (m ListModel) filteredCount(items []triage.PrioritizedItem) int {
-	if m.typeFilter == typeFilterAll {
+	if m.typeFilter == typeFilterAll && m.labelFilter.IsZero() {
 		return len(items)
 	}
 	count := 0
 	for _, item := range items {
-		isPR := itemIsPR(item)
-		if m.typeFilter == typeFilterPR && isPR {
-			count++
-		} else if m.typeFilter == typeFilterIssue && !isPR {
+		if m.matchesFilters(item) {
 			count++
 		}
 	}
@@ -1669,13 +2856,31 @@ func (m ListModel) DependabotCount() int {
 	return m.filteredCount(m.dependabotItems)
 }
 
+// LaterSortColumn returns the current later sort column for rendering
+func (m ListModel) LaterSortColumn() SortColumn {
+	return m.laterSortColumn
+}
+
+// LaterSortDesc returns whether later sort is descending
+func (m ListModel) LaterSortDesc() bool {
+	return m.laterSortDesc
+}
+
+// LaterCount returns the number of items moved to Later matching the current type filter.
+func (m ListModel) LaterCount() int {
+	return m.filteredCount(m.laterItems)
+}
+
 // View implements tea.Model
 func (m ListModel) View() string {
 	if m.quitting {
 		return ""
 	}
 
-	return renderListView(m)
+	if m.weightsEditor != nil {
+		return fitWindow(renderWeightsEditor(m), m.windowWidth, m.windowHeight)
+	}
+	return fitWindow(renderListView(m), m.windowWidth, m.windowHeight)
 }
 
 // clearStatusMsg is a message to clear the status
@@ -1691,20 +2896,7 @@ func clearStatusAfter(d time.Duration) tea.Cmd {
 // openURL opens a URL in the default browser
 func openURL(url string) tea.Cmd {
 	return func() tea.Msg {
-		var cmd *exec.Cmd
-
-		switch runtime.GOOS {
-		case "darwin":
-			cmd = exec.Command("open", url)
-		case "linux":
-			cmd = exec.Command("xdg-open", url)
-		case "windows":
-			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-		default:
-			return nil
-		}
-
-		_ = cmd.Start()
+		_ = browser.Open(url)
 		return nil
 	}
 }
@@ -1,15 +1,26 @@
 package tui
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/confirm"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/ghclient"
 	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/placement"
 	"github.com/spiffcs/triage/internal/resolved"
+	"github.com/spiffcs/triage/internal/slo"
+	"github.com/spiffcs/triage/internal/snooze"
 	"github.com/spiffcs/triage/internal/triage"
 )
 
@@ -312,3 +323,1587 @@ func TestSplitItemsWithResolvedItems(t *testing.T) {
 		t.Errorf("expected done item to be pr-2, got %s", m.assignedDoneItems[0].Item.ID)
 	}
 }
+
+func TestRefForItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		item       model.Item
+		wantOK     bool
+		wantNumber int
+	}{
+		{
+			name:       "enriched item uses Number",
+			item:       model.Item{Number: 12, Repository: model.Repository{FullName: "owner/repo"}},
+			wantOK:     true,
+			wantNumber: 12,
+		},
+		{
+			name: "notification falls back to subject URL",
+			item: model.Item{
+				Repository: model.Repository{FullName: "owner/repo"},
+				Subject:    model.Subject{URL: "https://api.github.com/repos/owner/repo/issues/34"},
+			},
+			wantOK:     true,
+			wantNumber: 34,
+		},
+		{
+			name:   "missing repository",
+			item:   model.Item{Number: 1},
+			wantOK: false,
+		},
+		{
+			name:   "no number available",
+			item:   model.Item{Repository: model.Repository{FullName: "owner/repo"}},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, ok := refForItem(triage.PrioritizedItem{Item: tt.item})
+			if ok != tt.wantOK {
+				t.Fatalf("refForItem ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (ref.owner != "owner" || ref.repo != "repo" || ref.number != tt.wantNumber) {
+				t.Errorf("refForItem = %+v, want owner/repo#%d", ref, tt.wantNumber)
+			}
+		})
+	}
+}
+
+func TestEditItemWithoutEditor(t *testing.T) {
+	items := []triage.PrioritizedItem{makeItem("issue-1", model.ItemTypeIssue, time.Now())}
+	m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	got := updated.(ListModel)
+	if got.statusMsg != "Editing not available" {
+		t.Errorf("expected editing-unavailable status, got %q", got.statusMsg)
+	}
+}
+
+func TestConfirmThen(t *testing.T) {
+	settings := config.DefaultConfirmationSettings()
+	settings.Delegate = "always"
+	policies, err := confirm.NewPolicies(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewListModel(nil, newTestStore(t), config.ScoreWeights{}, "testuser", WithConfirmations(policies))
+	ran := func() tea.Msg { return nil }
+
+	// comment defaults to "never": the command runs without a prompt
+	updated, cmd := m.confirmThen(confirm.ActionComment, 1, "Post?", "cancelled", ran)
+	if updated.(ListModel).pending != nil || cmd == nil {
+		t.Fatal("expected comment to run without confirmation")
+	}
+
+	// delegate set to "always": a prompt is shown and the command is held back
+	updated, cmd = m.confirmThen(confirm.ActionDelegate, 1, "Delegate?", "cancelled", ran)
+	got := updated.(ListModel)
+	if got.pending == nil || cmd != nil {
+		t.Fatal("expected delegate to wait for confirmation")
+	}
+	if !strings.Contains(got.View(), "Delegate? [y/N]") {
+		t.Error("expected confirmation prompt in view")
+	}
+
+	// Declining clears the prompt and shows the cancel message
+	declined, cmd := got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if d := declined.(ListModel); d.pending != nil || d.statusMsg != "cancelled" || cmd == nil {
+		t.Errorf("expected decline to cancel, got pending=%v status=%q", d.pending, d.statusMsg)
+	}
+
+	// Accepting returns the held command
+	accepted, cmd := got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if accepted.(ListModel).pending != nil || cmd == nil {
+		t.Error("expected confirmation to release the command")
+	}
+}
+
+func TestQuickModeMarksUnavailableColumns(t *testing.T) {
+	store := newTestStore(t)
+	items := []triage.PrioritizedItem{makeItem("pr-1", model.ItemTypePullRequest, time.Now())}
+
+	m := NewListModel(items, store, config.ScoreWeights{}, "testuser", WithQuickMode(true))
+	m.windowWidth = 160
+	m.windowHeight = 30
+	m.activePane = paneAssigned
+
+	view := m.View()
+	for _, want := range []string{"Assigned*", "Status*", quickModeNote} {
+		if !strings.Contains(view, want) {
+			t.Errorf("quick mode view missing %q", want)
+		}
+	}
+	if strings.Contains(view, "testuser") {
+		t.Error("assignees should not be shown in quick mode")
+	}
+}
+
+func TestReviewSLOSummary(t *testing.T) {
+	store := newTestStore(t)
+	item := makeItem("pr-1", model.ItemTypePullRequest, time.Now())
+	item.State = model.StateOpen
+	item.Details = &model.PRDetails{ReviewEvents: []model.ReviewEvent{
+		{Kind: model.ReviewEventRequested, Login: "testuser", At: time.Now().Add(-20 * time.Hour)},
+	}}
+
+	policy := slo.Policy{Target: 24 * time.Hour, Window: 30 * 24 * time.Hour}
+	stats := slo.Stats{Count: 12, P50: 5 * time.Hour, P90: 48 * time.Hour}
+	m := NewListModel([]triage.PrioritizedItem{item}, store, config.ScoreWeights{}, "testuser", WithReviewSLO(policy, stats))
+
+	want := "Reviews (1mo): p50 5h, p90 2d, SLO 1d, 1 at risk"
+	if got := m.reviewSLOSummary(); got != want {
+		t.Errorf("reviewSLOSummary() = %q, want %q", got, want)
+	}
+
+	off := NewListModel([]triage.PrioritizedItem{item}, store, config.ScoreWeights{}, "testuser", WithReviewSLO(slo.Policy{}, stats))
+	if got := off.reviewSLOSummary(); got != "" {
+		t.Errorf("reviewSLOSummary() with the SLO off = %q, want empty", got)
+	}
+}
+
+func TestWideCharacterAlignment(t *testing.T) {
+	store := newTestStore(t)
+	updated := time.Now().Add(-3 * time.Hour)
+	var items []triage.PrioritizedItem
+	for i, title := range []string{
+		"cursor row",
+		"Fix the login layout",
+		// One of these has a wide character across the truncation point
+		"修复登录页面的布局问题并更新相关的文档和测试用例以及其他内容",
+		"a修复登录页面的布局问题并更新相关的文档和测试用例以及其他内容",
+		"⚠️ breaking change ❤️ for 🐛 hunters everywhere on earth and beyond",
+		"한국어 제목",
+	} {
+		item := makeItem(title, model.ItemTypeIssue, updated)
+		item.Item.ID = string(rune('a' + i))
+		item.Item.Repository = model.Repository{FullName: "owner/日本語リポジトリの名前です"}
+		items = append(items, item)
+	}
+
+	m := NewListModel(items, store, config.ScoreWeights{}, "testuser")
+	m.windowWidth = 160
+	m.windowHeight = 30
+	m.activePane = paneAssigned
+
+	var rows []string
+	for _, line := range strings.Split(m.View(), "\n") {
+		// The selected row is padded to the window, so compare the others
+		if strings.Contains(line, "testuser") && !strings.HasPrefix(line, ">") {
+			rows = append(rows, line)
+		}
+	}
+	if len(rows) != len(items)-1 {
+		t.Fatalf("found %d unselected rows, want %d", len(rows), len(items)-1)
+	}
+
+	// Rows are padded to the window, so check that the Status column
+	// starts at the same place in each
+	statusColumn := func(row string) int {
+		return format.DisplayWidth(row[:strings.Index(row, "subscribed")])
+	}
+	want := statusColumn(rows[0])
+	for _, row := range rows[1:] {
+		if got := statusColumn(row); got != want {
+			t.Errorf("Status starts at column %d, want %d like the others:\n%s\n%s", got, want, rows[0], row)
+		}
+	}
+}
+
+func TestViewSanitizesExternalText(t *testing.T) {
+	store := newTestStore(t)
+	item := makeItem("issue-1", model.ItemTypeIssue, time.Now())
+	item.Subject.Title = "\x1b[2J\x1b[Hreal title\nfake row"
+	item.Repository = model.Repository{FullName: "owner/\x1b]8;;https://evil.example\arepo"}
+	item.Assignees = []string{"\u202eresutset"}
+
+	m := NewListModel([]triage.PrioritizedItem{item}, store, config.ScoreWeights{}, "testuser")
+	m.windowWidth = 160
+	m.windowHeight = 30
+	m.activePane = paneQueue
+
+	view := m.View()
+	for _, bad := range []string{"\x1b[2J", "\x1b]8", "\u202e", "title\nfake"} {
+		if strings.Contains(view, bad) {
+			t.Errorf("view contains %q", bad)
+		}
+	}
+	if !strings.Contains(view, "real title fake row") {
+		t.Errorf("view should keep the title text:\n%s", view)
+	}
+}
+
+func TestClusterKeysFollowSelection(t *testing.T) {
+	m := NewListModel(clusterItems(), newTestStore(t), config.ScoreWeights{}, "octocat")
+	m.activePane = paneQueue
+
+	press := func(key string) {
+		t.Helper()
+		updated, _ := m.Update(keyMsg(key))
+		m = updated.(ListModel)
+	}
+	selected := func() string {
+		t.Helper()
+		return m.activeItems()[m.activeCursor()].ID
+	}
+
+	// Select the issue, which sorts last while items are separate
+	press("G")
+	if got := selected(); got != "acme/api#11" {
+		t.Fatalf("selected %q, want the linked issue", got)
+	}
+
+	press("c")
+	if got, want := selected(), "acme/api#Add pagination to list endpoint"; got != want {
+		t.Errorf("after grouping, selected %q, want the row it folded into (%q)", got, want)
+	}
+	if got := len(m.activeItems()); got != 2 {
+		t.Errorf("grouped queue has %d rows, want 2", got)
+	}
+
+	press(" ")
+	press("j")
+	if got := selected(); got != "acme/api#11" {
+		t.Errorf("after expanding, the next row is %q, want the linked issue", got)
+	}
+
+	// Collapsing from a member returns to the group's row
+	press(" ")
+	if got, want := selected(), "acme/api#Add pagination to list endpoint"; got != want {
+		t.Errorf("after collapsing, selected %q, want %q", got, want)
+	}
+
+	press("c")
+	if got := len(m.activeItems()); got != 3 {
+		t.Errorf("ungrouped queue has %d rows, want 3", got)
+	}
+}
+
+func TestResurfacedItems(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+	// resurfaced was resolved before its latest update; done was not
+	if err := store.Resolve("resurfaced", now.Add(-3*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Resolve("done", now); err != nil {
+		t.Fatal(err)
+	}
+	items := []triage.PrioritizedItem{
+		makeItem("newest", model.ItemTypePullRequest, now),
+		makeItem("resurfaced", model.ItemTypePullRequest, now.Add(-2*time.Hour)),
+		makeItem("done", model.ItemTypePullRequest, now),
+	}
+
+	ids := func(items []triage.PrioritizedItem) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.ID)
+		}
+		return out
+	}
+
+	m := NewListModel(items, store, config.ScoreWeights{}, "testuser")
+	if got := ids(m.assignedItems); !slices.Equal(got, []string{"newest", "resurfaced"}) {
+		t.Fatalf("assigned = %v", got)
+	}
+	if !m.assignedItems[1].Resurfaced || m.assignedItems[0].Resurfaced {
+		t.Errorf("only the resurfaced item should be badged: %+v", m.assignedItems)
+	}
+
+	m = NewListModel(items, store, config.ScoreWeights{}, "testuser", WithPinned(map[string]bool{"resurfaced": true}))
+	if got := ids(m.assignedItems); !slices.Equal(got, []string{"resurfaced", "newest"}) {
+		t.Errorf("pinned assigned = %v, want resurfaced first", got)
+	}
+
+	// Marking it done again clears the badge
+	m.assignedCursor = 0
+	result, _ := m.markDone()
+	m = result.(ListModel)
+	if len(m.assignedDoneItems) != 2 {
+		t.Fatalf("expected 2 done items, got %d", len(m.assignedDoneItems))
+	}
+	for _, item := range m.assignedDoneItems {
+		if item.Resurfaced {
+			t.Errorf("done item %s still badged as resurfaced", item.ID)
+		}
+	}
+}
+
+func TestBlockedByRelationships(t *testing.T) {
+	now := time.Now()
+	blocked := makeItem("blocked", model.ItemTypeIssue, now)
+	blocked.Repository.FullName = "acme/api"
+	blocked.BlockedBy = []string{"acme/api#12", "acme/web#3"}
+	free := makeItem("free", model.ItemTypeIssue, now)
+
+	m := NewListModel([]triage.PrioritizedItem{blocked, free}, newTestStore(t), config.ScoreWeights{}, "testuser")
+	if len(m.blockedItems) != 1 || m.blockedItems[0].ID != "blocked" {
+		t.Fatalf("blocked pane = %v, want only the item with blockers", m.blockedItems)
+	}
+	if len(m.assignedItems) != 1 || m.assignedItems[0].ID != "free" {
+		t.Errorf("assigned pane = %v, want only the unblocked item", m.assignedItems)
+	}
+
+	if got, want := blockedTitle("Fix login", blocked.BlockedBy, "acme/api"), "[blocked by #12, acme/web#3] Fix login"; got != want {
+		t.Errorf("blockedTitle() = %q, want %q", got, want)
+	}
+	if got := blockedTitle("Fix login", nil, "acme/api"); got != "Fix login" {
+		t.Errorf("blockedTitle() without blockers = %q", got)
+	}
+}
+
+func TestItemsMsgReplacesItems(t *testing.T) {
+	now := time.Now()
+	m := NewListModel([]triage.PrioritizedItem{
+		makeItem("a", model.ItemTypeIssue, now),
+		makeItem("b", model.ItemTypeIssue, now.Add(-time.Hour)),
+		makeItem("c", model.ItemTypeIssue, now.Add(-2*time.Hour)),
+	}, newTestStore(t), config.ScoreWeights{}, "testuser")
+	m.assignedCursor = 1 // b
+
+	// A refresh drops a and adds d; b stays selected
+	result, _ := m.Update(ItemsMsg{Items: []triage.PrioritizedItem{
+		makeItem("d", model.ItemTypeIssue, now.Add(time.Hour)),
+		makeItem("c", model.ItemTypeIssue, now.Add(-2*time.Hour)),
+		makeItem("b", model.ItemTypeIssue, now.Add(-time.Hour)),
+	}})
+	m = result.(ListModel)
+
+	var ids []string
+	for _, item := range m.assignedItems {
+		ids = append(ids, item.ID)
+	}
+	if want := []string{"d", "b", "c"}; !slices.Equal(ids, want) {
+		t.Errorf("assigned items = %v, want %v", ids, want)
+	}
+	if got := m.activeItems()[m.activeCursor()].ID; got != "b" {
+		t.Errorf("selected %q after refresh, want b", got)
+	}
+
+	// A refresh that drops the selected item keeps the cursor in range
+	result, _ = m.Update(ItemsMsg{Items: []triage.PrioritizedItem{makeItem("d", model.ItemTypeIssue, now)}})
+	m = result.(ListModel)
+	if m.activeCursor() != 0 {
+		t.Errorf("cursor = %d with one item left, want 0", m.activeCursor())
+	}
+}
+
+func TestWithUpdates(t *testing.T) {
+	updates := make(chan []triage.PrioritizedItem, 1)
+	m := NewListModel(nil, newTestStore(t), config.ScoreWeights{}, "testuser", WithUpdates(updates))
+
+	updates <- []triage.PrioritizedItem{makeItem("a", model.ItemTypeIssue, time.Now())}
+	msg := m.Init()()
+	result, next := m.Update(msg)
+	m = result.(ListModel)
+	if len(m.assignedItems) != 1 {
+		t.Fatalf("got %d assigned items after an update, want 1", len(m.assignedItems))
+	}
+	if next == nil {
+		t.Fatal("Update(ItemsMsg) stopped waiting for updates")
+	}
+
+	close(updates)
+	if msg := next(); msg != nil {
+		t.Errorf("closed updates delivered %T, want nothing", msg)
+	}
+
+	if cmd := NewListModel(nil, newTestStore(t), config.ScoreWeights{}, "testuser").Init(); cmd != nil {
+		t.Error("Init() without updates returned a command")
+	}
+}
+
+// TestListDoesNotShareItems checks that the list model never writes to a
+// slice it was given or has handed to an earlier copy of itself. Run with
+// -race to catch sharing that the assertions alone can't see.
+func TestListDoesNotShareItems(t *testing.T) {
+	now := time.Now()
+	items := []triage.PrioritizedItem{
+		makeItem("old", model.ItemTypeIssue, now.Add(-time.Hour)),
+		makeItem("new", model.ItemTypeIssue, now),
+	}
+	items[0].Number, items[0].Repository.FullName = 1, "acme/api"
+
+	m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser")
+	m.recordInteraction("acme/api#1", now)
+	result, _ := m.markDone()
+	m = result.(ListModel)
+
+	if items[0].ID != "old" || items[1].ID != "new" {
+		t.Errorf("caller's items reordered to %s, %s", items[0].ID, items[1].ID)
+	}
+	if items[0].LastInteractionAt != nil {
+		t.Error("recordInteraction wrote to the caller's items")
+	}
+
+	// The sender of an update keeps using its slice while the list works
+	refresh := []triage.PrioritizedItem{
+		makeItem("a", model.ItemTypeIssue, now),
+		makeItem("b", model.ItemTypeIssue, now.Add(-time.Hour)),
+		makeItem("c", model.ItemTypeIssue, now.Add(-2*time.Hour)),
+	}
+	result, _ = m.Update(ItemsMsg{Items: refresh})
+	m = result.(ListModel)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range refresh {
+			refresh[i] = makeItem("reused", model.ItemTypeIssue, now)
+		}
+	}()
+	before := m
+	for range 3 {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		m = result.(ListModel)
+		result, _ = m.markDone()
+		m = result.(ListModel)
+		_ = m.View()
+	}
+	<-done
+
+	for _, item := range append(m.assignedItems, m.assignedDoneItems...) {
+		if item.ID == "reused" {
+			t.Fatal("the list shows items the sender changed after sending")
+		}
+	}
+	if len(before.assignedItems) != 3 {
+		t.Errorf("an earlier copy of the model lost items: %d assigned, want 3", len(before.assignedItems))
+	}
+}
+
+func TestRecentlyResolved(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+	items := []triage.PrioritizedItem{
+		makeItem("active", model.ItemTypeIssue, now),
+		makeItem("first", model.ItemTypeIssue, now.Add(-time.Hour)),
+		makeItem("second", model.ItemTypePullRequest, now.Add(-2*time.Hour)),
+		makeItem("resurfaced", model.ItemTypeIssue, now),
+	}
+	items[3].Assignees = nil // queue pane
+	for _, item := range items[1:3] {
+		if err := store.Resolve(item.Key(), item.UpdatedAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Resolve("resurfaced", now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewListModel(items, store, config.ScoreWeights{}, "testuser")
+	// Resolving from the list puts the item first
+	result, _ := m.markDone()
+	m = result.(ListModel)
+	result, _ = m.handleKey(keyMsg("T"))
+	m = result.(ListModel)
+
+	var ids []string
+	for _, item := range m.activeItems() {
+		ids = append(ids, item.ID)
+	}
+	// The resurfaced item has new activity, so it is listed, not resolved
+	if len(ids) != 3 || ids[0] != "active" || !slices.Contains(ids, "first") || !slices.Contains(ids, "second") {
+		t.Fatalf("recently resolved = %v, want active first, then first and second", ids)
+	}
+
+	// Restoring puts the item back in its pane
+	m.trashCursor = slices.Index(ids, "second")
+	result, _ = m.handleKey(keyMsg("d"))
+	m = result.(ListModel)
+	if store.IsResolved("second") {
+		t.Error("restored item is still resolved")
+	}
+	if len(m.activeItems()) != 2 {
+		t.Errorf("got %d recently resolved after restoring, want 2", len(m.activeItems()))
+	}
+	if !slices.ContainsFunc(m.assignedItems, func(it triage.PrioritizedItem) bool { return it.ID == "second" }) {
+		t.Error("restored item is not back in the assigned pane")
+	}
+
+	// Switching panes leaves the view
+	result, _ = m.handleKey(keyMsg("3"))
+	m = result.(ListModel)
+	if m.showTrash || m.activePane != paneQueue {
+		t.Error("pane key did not leave the recently resolved view")
+	}
+}
+
+func TestMarkDoneClearsDueReminder(t *testing.T) {
+	store := newTestStore(t)
+	reminders, err := snooze.NewStoreFromPath(filepath.Join(t.TempDir(), "snooze.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	item := makeItem("issue", model.ItemTypeIssue, now.Add(-time.Hour))
+	item.Number, item.Repository.FullName = 4, "acme/api"
+	if err := reminders.Remind(item.Key(), now.Add(-time.Minute), "ping after release"); err != nil {
+		t.Fatal(err)
+	}
+	// Marked done earlier, but the reminder is due
+	if err := store.Resolve(item.Key(), item.UpdatedAt); err != nil {
+		t.Fatal(err)
+	}
+	item.Reminder = &model.Reminder{At: now.Add(-time.Minute), Note: "ping after release"}
+
+	m := NewListModel([]triage.PrioritizedItem{item}, store, config.ScoreWeights{}, "testuser", WithSnoozeStore(reminders))
+	if len(m.assignedItems) != 1 || m.assignedItems[0].Resurfaced {
+		t.Fatalf("item with a due reminder: %d assigned, want 1 not marked resurfaced", len(m.assignedItems))
+	}
+
+	result, _ := m.markDone()
+	m = result.(ListModel)
+	if _, ok := reminders.Due(item.Key(), now); ok {
+		t.Error("markDone() left the due reminder")
+	}
+	if m.assignedDoneItems[0].Reminder != nil {
+		t.Error("done item still shows its reminder")
+	}
+}
+
+func TestSnooze(t *testing.T) {
+	store := newTestStore(t)
+	snoozes, err := snooze.NewStoreFromPath(filepath.Join(t.TempDir(), "snooze.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	first := makeItem("first", model.ItemTypeIssue, now.Add(-time.Hour))
+	first.Number, first.Repository.FullName = 1, "acme/api"
+	second := makeItem("second", model.ItemTypeIssue, now.Add(-2*time.Hour))
+	second.Number, second.Repository.FullName = 2, "acme/api"
+	items := []triage.PrioritizedItem{first, second}
+
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		want time.Duration
+	}{
+		{"preset", []tea.KeyMsg{keyMsg("z"), keyMsg("4")}, 4 * time.Hour},
+		{"custom", []tea.KeyMsg{keyMsg("z"), keyMsg("c"), keyMsg("3"), keyMsg("x"), {Type: tea.KeyBackspace}, keyMsg("d"), {Type: tea.KeyEnter}}, 3 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snoozes, err := snooze.NewStoreFromPath(filepath.Join(t.TempDir(), "snooze.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var tm tea.Model = NewListModel(items, store, config.ScoreWeights{}, "testuser", WithSnoozeStore(snoozes))
+			for _, k := range tt.keys {
+				tm, _ = tm.Update(k)
+			}
+			m := tm.(ListModel)
+			if m.snoozing != nil {
+				t.Fatal("snooze prompt still open")
+			}
+			if len(m.assignedItems) != 1 || m.assignedItems[0].Key() != second.Key() {
+				t.Fatalf("assigned = %d items, want only the unsnoozed one", len(m.assignedItems))
+			}
+			if !snoozes.Snoozed(first.Key(), first.UpdatedAt, time.Now().Add(tt.want-time.Minute)) {
+				t.Error("item not snoozed for the chosen duration")
+			}
+			if snoozes.Snoozed(first.Key(), first.UpdatedAt, time.Now().Add(tt.want+time.Minute)) {
+				t.Error("item snoozed past the chosen duration")
+			}
+		})
+	}
+
+	// Esc cancels without snoozing
+	var tm tea.Model = NewListModel(items, store, config.ScoreWeights{}, "testuser", WithSnoozeStore(snoozes))
+	tm, _ = tm.Update(keyMsg("z"))
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m := tm.(ListModel); m.snoozing != nil || len(m.assignedItems) != 2 {
+		t.Error("esc did not cancel the snooze")
+	}
+
+	// New activity brings a snoozed item back
+	if err := snoozes.Snooze(first.Key(), now.Add(time.Hour), first.UpdatedAt); err != nil {
+		t.Fatal(err)
+	}
+	first.UpdatedAt = now
+	m := NewListModel([]triage.PrioritizedItem{first, second}, store, config.ScoreWeights{}, "testuser", WithSnoozeStore(snoozes))
+	if len(m.assignedItems) != 2 {
+		t.Errorf("assigned = %d items, want the updated item back", len(m.assignedItems))
+	}
+}
+
+func TestMoveItem(t *testing.T) {
+	store := newTestStore(t)
+	placements, err := placement.NewStoreFromPath(filepath.Join(t.TempDir(), "placement.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fyi := makeItem("fyi", model.ItemTypeIssue, time.Now().Add(-time.Hour))
+	fyi.Number, fyi.Repository.FullName = 1, "acme/api"
+	fyi.Assignees = nil
+	items := []triage.PrioritizedItem{fyi}
+
+	var tm tea.Model = NewListModel(items, store, config.ScoreWeights{}, "testuser", WithPlacementStore(placements))
+	if m := tm.(ListModel); len(m.queueItems) != 1 {
+		t.Fatalf("queue = %d items, want the unassigned item", len(m.queueItems))
+	}
+
+	// 3 switches to the queue pane; m then 1 moves the item to Assigned
+	for _, k := range []string{"3", "m", "1"} {
+		tm, _ = tm.Update(keyMsg(k))
+	}
+	m := tm.(ListModel)
+	if m.moving != nil {
+		t.Fatal("move prompt still open")
+	}
+	if len(m.queueItems) != 0 || len(m.assignedItems) != 1 {
+		t.Fatalf("queue = %d, assigned = %d items; want the item moved to Assigned", len(m.queueItems), len(m.assignedItems))
+	}
+	if pane, _ := placements.Pane(fyi.Key()); pane != "assigned" {
+		t.Errorf("stored pane = %q, want assigned", pane)
+	}
+
+	// The move outlasts the session
+	m = NewListModel(items, store, config.ScoreWeights{}, "testuser", WithPlacementStore(placements))
+	if len(m.assignedItems) != 1 {
+		t.Fatalf("assigned = %d items after reopening, want the moved item", len(m.assignedItems))
+	}
+
+	// m then a returns it to its automatic pane
+	tm = m
+	for _, k := range []string{"1", "m", "a"} {
+		tm, _ = tm.Update(keyMsg(k))
+	}
+	if m := tm.(ListModel); len(m.queueItems) != 1 || len(m.assignedItems) != 0 {
+		t.Errorf("queue = %d, assigned = %d items; want the item back in the queue", len(m.queueItems), len(m.assignedItems))
+	}
+	if _, ok := placements.Pane(fyi.Key()); ok {
+		t.Error("placement kept after moving back to the automatic pane")
+	}
+}
+
+func TestLaterPane(t *testing.T) {
+	store := newTestStore(t)
+	placements, err := placement.NewStoreFromPath(filepath.Join(t.TempDir(), "placement.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []triage.PrioritizedItem
+	for i, id := range []string{"a", "b", "c"} {
+		item := makeItem(id, model.ItemTypeIssue, time.Now().Add(-time.Duration(i)*time.Hour))
+		item.Number, item.Repository.FullName = i+1, "acme/api"
+		item.Assignees = nil
+		items = append(items, item)
+	}
+
+	// Move a and b to Later from the queue
+	var tm tea.Model = NewListModel(items, store, config.ScoreWeights{}, "testuser", WithPlacementStore(placements))
+	for _, k := range []string{"3", "m", "6", "m", "6"} {
+		tm, _ = tm.Update(keyMsg(k))
+	}
+	m := tm.(ListModel)
+	if len(m.queueItems) != 1 || len(m.laterItems) != 2 {
+		t.Fatalf("queue = %d, later = %d items; want two moved to Later", len(m.queueItems), len(m.laterItems))
+	}
+	if !strings.Contains(renderTabBar(m), "6: Later (2)") {
+		t.Errorf("tab bar = %q, want the Later tab", renderTabBar(m))
+	}
+
+	// Later keeps its own cursor and done list, and survives a restart
+	m = NewListModel(items, store, config.ScoreWeights{}, "testuser", WithPlacementStore(placements))
+	tm = m
+	for _, k := range []string{"6", "j", "d"} {
+		tm, _ = tm.Update(keyMsg(k))
+	}
+	m = tm.(ListModel)
+	if m.activePane != paneLater || len(m.laterItems) != 1 || len(m.laterDoneItems) != 1 {
+		t.Fatalf("pane = %d, later = %d, later done = %d; want one item done in Later", m.activePane, len(m.laterItems), len(m.laterDoneItems))
+	}
+	if m.queueCursor != 0 || len(m.queueItems) != 1 {
+		t.Errorf("queue cursor = %d with %d items; Later changed the queue", m.queueCursor, len(m.queueItems))
+	}
+
+	// Tab goes from Orphaned to Later, and from Later back to Assigned
+	for _, k := range []string{"5", "tab"} {
+		tm, _ = tm.Update(keyMsg(k))
+	}
+	if m := tm.(ListModel); m.activePane != paneLater {
+		t.Errorf("tab from Orphaned went to pane %d, want Later", m.activePane)
+	}
+	tm, _ = tm.Update(keyMsg("tab"))
+	if m := tm.(ListModel); m.activePane != paneAssigned {
+		t.Errorf("tab from Later went to pane %d, want Assigned", m.activePane)
+	}
+
+	// Without moving there is no Later pane to switch to
+	tm = NewListModel(items, store, config.ScoreWeights{}, "testuser")
+	for _, k := range []string{"6", "5", "tab"} {
+		tm, _ = tm.Update(keyMsg(k))
+	}
+	if m := tm.(ListModel); m.activePane != paneAssigned || strings.Contains(renderTabBar(m), "Later") {
+		t.Errorf("pane = %d without a placement store, want Later skipped", m.activePane)
+	}
+}
+
+// fakePreviewer returns a fixed preview and records the items asked for.
+type fakePreviewer struct {
+	preview *model.Preview
+	err     error
+	asked   []string
+}
+
+func (f *fakePreviewer) ItemPreview(_ context.Context, owner, repo string, number int) (*model.Preview, error) {
+	f.asked = append(f.asked, fmt.Sprintf("%s/%s#%d", owner, repo, number))
+	return f.preview, f.err
+}
+
+// runCmds runs cmd, and the commands of any batch it returns, feeding each
+// message back into m.
+func runCmds(m ListModel, cmd tea.Cmd) ListModel {
+	if cmd == nil {
+		return m
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			m = runCmds(m, c)
+		}
+	case nil:
+	default:
+		updated, next := m.Update(msg)
+		m = runCmds(updated.(ListModel), next)
+	}
+	return m
+}
+
+// feedCmd runs cmd and feeds its message back into m, leaving the
+// commands that leads to, such as status timers, unrun.
+func feedCmd(m ListModel, cmd tea.Cmd) ListModel {
+	updated, _ := m.Update(cmd())
+	return updated.(ListModel)
+}
+
+func TestDetailPane(t *testing.T) {
+	previewer := &fakePreviewer{preview: &model.Preview{
+		Body:           "Adds cursor pagination.\n\nCloses #11",
+		Labels:         []string{"enhancement"},
+		Assignees:      []string{"octocat"},
+		Comments:       []model.Comment{{Author: "monalisa", Body: "Looks close\nOne nit", CreatedAt: time.Now().Add(-3 * time.Hour)}},
+		ReviewDecision: model.ReviewStateChangesRequested,
+		Reviews:        []model.Review{{Author: "monalisa", State: "changes_requested"}},
+		Checks:         []model.Check{{Name: "build", State: model.CIStatusSuccess}, {Name: "lint", State: model.CIStatusFailure}},
+	}}
+	m := NewListModel(snapshotItems(), newTestStore(t), config.ScoreWeights{}, "octocat", WithPreviewer(previewer))
+	m.activePane = paneQueue
+	m.windowWidth = 140
+	m.windowHeight = 40
+	height := strings.Count(m.View(), "\n")
+
+	press := func(key string) {
+		t.Helper()
+		updated, cmd := m.Update(keyMsg(key))
+		m = runCmds(updated.(ListModel), cmd)
+	}
+
+	press("l")
+	if !m.showDetail {
+		t.Fatal("l should show the detail pane")
+	}
+	view := m.View()
+	for _, want := range []string{
+		"Labels: enhancement   Assignees: octocat",
+		"Review: changes_requested (monalisa changes requested)",
+		"build",
+		"lint",
+		"Closes #11",
+		"monalisa, 3h ago: Looks close",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail pane missing %q:\n%s", want, view)
+		}
+	}
+	if got := strings.Count(view, "\n"); got != height {
+		t.Errorf("view has %d lines with the detail pane, want %d", got, height)
+	}
+
+	// Moving the cursor loads the next item's preview once
+	press("j")
+	press("k")
+	if len(previewer.asked) != 2 || previewer.asked[0] == previewer.asked[1] {
+		t.Errorf("previews fetched for %v, want each selected item once", previewer.asked)
+	}
+
+	// Space toggles the pane outside a group
+	press(" ")
+	if m.showDetail {
+		t.Error("space should hide the detail pane")
+	}
+	if strings.Contains(m.View(), "Closes #11") {
+		t.Error("hidden detail pane still rendered")
+	}
+}
+
+func TestDetailPaneErrors(t *testing.T) {
+	items := []triage.PrioritizedItem{makeItem("issue-1", model.ItemTypeIssue, time.Now())}
+
+	m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser")
+	updated, _ := m.Update(keyMsg("l"))
+	if got := updated.(ListModel).statusMsg; got != "Preview not available" {
+		t.Errorf("without a previewer, status = %q, want preview-unavailable", got)
+	}
+
+	items[0].Repository.FullName = "acme/api"
+	items[0].Number = 3
+	previewer := &fakePreviewer{err: errors.New("boom")}
+	m = NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser", WithPreviewer(previewer))
+	m.windowHeight = 40
+	updated, cmd := m.Update(keyMsg("l"))
+	m = runCmds(updated.(ListModel), cmd)
+	if view := m.View(); !strings.Contains(view, "Preview failed: boom") {
+		t.Errorf("view should show the failure:\n%s", view)
+	}
+
+	// Reopening the pane retries
+	for _, key := range []string{"l", "l"} {
+		updated, cmd = m.Update(keyMsg(key))
+		m = runCmds(updated.(ListModel), cmd)
+	}
+	if len(previewer.asked) != 2 {
+		t.Errorf("preview fetched %d times, want a retry after reopening", len(previewer.asked))
+	}
+}
+
+// fakeReadMarker records the threads marked read.
+type fakeReadMarker struct {
+	marked []string
+	err    error
+}
+
+func (f *fakeReadMarker) MarkAsRead(_ context.Context, threadID string) error {
+	f.marked = append(f.marked, threadID)
+	return f.err
+}
+
+func TestMarkDoneSyncsRead(t *testing.T) {
+	notification := makeItem("1001", model.ItemTypeIssue, time.Now())
+	notification.Unread = true
+	read := makeItem("1002", model.ItemTypeIssue, time.Now().Add(-time.Hour))
+	search := makeItem("assigned-7", model.ItemTypeIssue, time.Now().Add(-2*time.Hour))
+	search.Unread = true
+	local := makeItem("1003", model.ItemTypeIssue, time.Now().Add(-3*time.Hour))
+	local.Unread = true
+	items := []triage.PrioritizedItem{notification, read, search, local}
+
+	tests := []struct {
+		name       string
+		key        string
+		err        error
+		wantMarked []string
+		wantStatus string
+	}{
+		{name: "unread notification", key: "d", wantMarked: []string{"1001"}, wantStatus: "Marked as done and read"},
+		{name: "failure keeps it done", key: "d", err: errors.New("boom"), wantMarked: []string{"1001"}, wantStatus: "Marked as done; not marked read: boom"},
+		{name: "done locally", key: "D", wantStatus: "Marked as done"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marker := &fakeReadMarker{err: tt.err}
+			m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser", WithReadSync(marker))
+
+			updated, cmd := m.Update(keyMsg(tt.key))
+			m = updated.(ListModel)
+			if tt.wantMarked != nil {
+				if m.statusMsg != "Marked as done; marking read..." {
+					t.Fatalf("status = %q before marking read", m.statusMsg)
+				}
+				m = feedCmd(m, cmd)
+			}
+			if !slices.Equal(marker.marked, tt.wantMarked) {
+				t.Errorf("marked %v read, want %v", marker.marked, tt.wantMarked)
+			}
+			if m.statusMsg != tt.wantStatus {
+				t.Errorf("status = %q, want %q", m.statusMsg, tt.wantStatus)
+			}
+			if got := len(m.assignedItems); got != len(items)-1 {
+				t.Errorf("%d items left, want the selected one marked done", got)
+			}
+		})
+	}
+
+	// Read notifications and items found by search have nothing to mark
+	marker := &fakeReadMarker{}
+	m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser", WithReadSync(marker))
+	m.assignedCursor = 1
+	for range 2 {
+		updated, _ := m.Update(keyMsg("d"))
+		m = updated.(ListModel)
+		if m.statusMsg != "Marked as done" {
+			t.Errorf("status = %q, want nothing to mark read", m.statusMsg)
+		}
+	}
+	if len(marker.marked) != 0 {
+		t.Errorf("marked %v read, want none", marker.marked)
+	}
+
+	// The footer tells the two apart only when d marks read
+	if help := renderHelp("all", "all", false, false, false, true); !strings.Contains(help, "d: done+read   D: done") {
+		t.Errorf("help %q doesn't list D", help)
+	}
+	if help := renderHelp("all", "all", false, false, false, false); strings.Contains(help, "D: done") {
+		t.Errorf("help %q lists D without read sync", help)
+	}
+}
+
+func TestMultiSelect(t *testing.T) {
+	now := time.Now()
+	var items []triage.PrioritizedItem
+	for i, id := range []string{"2001", "2002", "2003", "2004"} {
+		item := makeItem(id, model.ItemTypeIssue, now.Add(-time.Duration(i)*time.Hour))
+		item.Unread = true
+		item.HTMLURL = "https://github.com/acme/api/issues/" + id
+		items = append(items, item)
+	}
+	policies, err := confirm.NewPolicies(config.DefaultConfirmationSettings())
+	if err != nil {
+		t.Fatal(err)
+	}
+	press := func(m ListModel, keys ...string) (ListModel, tea.Cmd) {
+		var cmd tea.Cmd
+		for _, k := range keys {
+			var updated tea.Model
+			updated, cmd = m.Update(keyMsg(k))
+			m = updated.(ListModel)
+		}
+		return m, cmd
+	}
+	ids := func(items []triage.PrioritizedItem) []string {
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	t.Run("select", func(t *testing.T) {
+		m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser")
+		tests := []struct {
+			name string
+			keys []string
+			want []string
+		}{
+			{"toggle", []string{"v", "j", "j", "v"}, []string{"2001", "2003"}},
+			{"toggle off", []string{"v", "v"}, nil},
+			{"range from the last toggle", []string{"j", "v", "j", "j", "V"}, []string{"2002", "2003", "2004"}},
+			{"range upwards", []string{"G", "v", "k", "k", "V"}, []string{"2002", "2003", "2004"}},
+			{"range without a toggle", []string{"j", "V"}, []string{"2002"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				m, _ := press(m, tt.keys...)
+				if got := ids(m.markedItems()); !slices.Equal(got, tt.want) {
+					t.Errorf("selected %v, want %v", got, tt.want)
+				}
+			})
+		}
+
+		// Esc clears the selection before it quits
+		m, _ = press(m, "v")
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		m = updated.(ListModel)
+		if m.quitting || len(m.markedItems()) != 0 {
+			t.Errorf("esc with a selection: quitting = %v, selected = %d", m.quitting, len(m.markedItems()))
+		}
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if !updated.(ListModel).quitting {
+			t.Error("esc without a selection did not quit")
+		}
+	})
+
+	t.Run("done", func(t *testing.T) {
+		store := newTestStore(t)
+		marker := &fakeReadMarker{}
+		m := NewListModel(items, store, config.ScoreWeights{}, "testuser", WithReadSync(marker), WithConfirmations(policies))
+		m, cmd := press(m, "v", "j", "j", "V", "d")
+		m = feedCmd(m, cmd)
+
+		if got := ids(m.assignedItems); !slices.Equal(got, []string{"2004"}) {
+			t.Errorf("left %v, want the unselected item", got)
+		}
+		for _, id := range []string{"2001", "2002", "2003"} {
+			if !store.IsResolved(id) {
+				t.Errorf("%s not resolved", id)
+			}
+		}
+		if want := []string{"2001", "2002", "2003"}; !slices.Equal(marker.marked, want) {
+			t.Errorf("marked %v read, want %v", marker.marked, want)
+		}
+		if want := "Marked 3 as done and read"; m.statusMsg != want {
+			t.Errorf("status = %q, want %q", m.statusMsg, want)
+		}
+		if len(m.marked) != 0 {
+			t.Errorf("%d items still selected", len(m.marked))
+		}
+		if m.assignedCursor != 0 {
+			t.Errorf("cursor = %d, want it on the last item", m.assignedCursor)
+		}
+	})
+
+	t.Run("done asks before marking many read", func(t *testing.T) {
+		marker := &fakeReadMarker{}
+		m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser", WithReadSync(marker))
+		m, _ = press(m, "v", "j", "V", "d")
+		if m.pending == nil {
+			t.Fatal("no confirmation before marking notifications read")
+		}
+		m, _ = press(m, "n")
+		if len(marker.marked) != 0 {
+			t.Errorf("marked %v read after declining", marker.marked)
+		}
+		if want := "Marked 2 as done; not marked read"; m.statusMsg != want {
+			t.Errorf("status = %q, want %q", m.statusMsg, want)
+		}
+		if len(m.assignedItems) != 2 {
+			t.Errorf("%d items left, want the selected ones done", len(m.assignedItems))
+		}
+	})
+
+	t.Run("snooze", func(t *testing.T) {
+		snoozes, err := snooze.NewStoreFromPath(filepath.Join(t.TempDir(), "snooze.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser", WithSnoozeStore(snoozes))
+		m, _ = press(m, "v", "G", "v", "z")
+		if m.snoozing == nil || !strings.HasPrefix(m.snoozing.text(), "Snooze 2 items for:") {
+			t.Fatal("no snooze prompt for the selection")
+		}
+		m, _ = press(m, "4")
+		if got := ids(m.assignedItems); !slices.Equal(got, []string{"2002", "2003"}) {
+			t.Errorf("left %v, want the unselected items", got)
+		}
+		if want := "Snoozed 2 items for 4h"; m.statusMsg != want {
+			t.Errorf("status = %q, want %q", m.statusMsg, want)
+		}
+	})
+
+	t.Run("open", func(t *testing.T) {
+		m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser")
+		m, cmd := press(m, "v", "j", "v", "enter")
+		if cmd == nil {
+			t.Fatal("no command to open the selection")
+		}
+		if want := "Opened 2 items"; m.statusMsg != want {
+			t.Errorf("status = %q, want %q", m.statusMsg, want)
+		}
+		if len(m.marked) != 0 {
+			t.Errorf("%d items still selected", len(m.marked))
+		}
+	})
+}
+
+func TestWeightsEditor(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+
+	now := time.Now()
+	mention := model.Item{ID: "1", Reason: model.ReasonMention, Type: model.ItemTypeIssue, State: "open", UpdatedAt: now, Subject: model.Subject{Title: "mention"}}
+	watched := model.Item{ID: "2", Reason: model.ReasonSubscribed, Type: model.ItemTypeIssue, State: "open", UpdatedAt: now, Subject: model.Subject{Title: "watched"}}
+	items := triage.NewEngine("testuser", config.DefaultScoreWeights(), nil).Prioritize([]model.Item{mention, watched})
+	score := func(m ListModel, id string) int {
+		for _, item := range m.items {
+			if item.ID == id {
+				return item.Score
+			}
+		}
+		t.Fatalf("item %s missing", id)
+		return 0
+	}
+	press := func(m ListModel, keys ...string) (ListModel, tea.Cmd) {
+		var cmd tea.Cmd
+		for _, k := range keys {
+			var updated tea.Model
+			updated, cmd = m.Update(keyMsg(k))
+			m = updated.(ListModel)
+		}
+		return m, cmd
+	}
+	// Moves the editor cursor to the setting with the given key
+	toSetting := func(key string) []string {
+		i := slices.IndexFunc(weightSettings, func(s weightSetting) bool { return s.key == key })
+		return slices.Repeat([]string{"j"}, i)
+	}
+
+	t.Run("not available", func(t *testing.T) {
+		m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser")
+		m, _ = press(m, "W")
+		if m.weightsEditor != nil || m.statusMsg != "Weights editor not available" {
+			t.Errorf("W without rescoring: editor open = %v, status %q", m.weightsEditor != nil, m.statusMsg)
+		}
+	})
+
+	newModel := func() ListModel {
+		return NewListModel(items, newTestStore(t), config.DefaultScoreWeights(), "testuser",
+			WithConfig(config.DefaultConfig()), WithRescoring(nil))
+	}
+	before := score(newModel(), "2")
+
+	t.Run("live rescoring", func(t *testing.T) {
+		m, _ := press(newModel(), "W")
+		m, _ = press(m, toSetting("base_scores.subscribed")...)
+		m, _ = press(m, "l", "l", "h", "l")
+		if got := score(m, "2"); got != before+10 {
+			t.Errorf("score after raising subscribed by 10 = %d, want %d", got, before+10)
+		}
+		if !m.weightsEditor.changed["base_scores.subscribed"] {
+			t.Error("subscribed not marked changed")
+		}
+		if !strings.Contains(m.View(), "base_scores.subscribed                     20 *") {
+			t.Errorf("editor doesn't show the new value:\n%s", m.View())
+		}
+
+		// Esc puts the scores back
+		m, _ = press(m, "esc")
+		if m.weightsEditor != nil || score(m, "2") != before {
+			t.Errorf("esc left the editor open or the score at %d, want %d", score(m, "2"), before)
+		}
+	})
+
+	t.Run("switch", func(t *testing.T) {
+		m, _ := press(newModel(), "W")
+		m, _ = press(m, toSetting("urgency.mention")...)
+		m, _ = press(m, " ")
+		for _, item := range m.items {
+			if item.ID == "1" && item.Priority != triage.PriorityUrgent {
+				t.Errorf("mention priority = %s after switching urgency.mention on, want urgent", item.Priority)
+			}
+		}
+	})
+
+	t.Run("save", func(t *testing.T) {
+		m, _ := press(newModel(), "W")
+		m, _ = press(m, toSetting("base_scores.subscribed")...)
+		m, cmd := press(m, "l", "enter")
+		if m.weightsEditor != nil || cmd == nil {
+			t.Fatal("enter did not close the editor and save")
+		}
+		m = feedCmd(m, cmd)
+		if want := "Weights saved to " + config.GetConfigPaths().GlobalPath; m.statusMsg != want {
+			t.Errorf("status = %q", m.statusMsg)
+		}
+		if score(m, "2") != before+5 {
+			t.Errorf("saving dropped the new score")
+		}
+		saved, err := config.LoadGlobal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := saved.GetScoreWeights().Subscribed; got != 15 {
+			t.Errorf("saved subscribed = %d, want 15", got)
+		}
+		if saved.BaseScores.Mention != nil {
+			t.Error("saved a setting that wasn't changed")
+		}
+	})
+}
+
+func TestRankShift(t *testing.T) {
+	tests := []struct {
+		before, now int
+		want        string
+	}{
+		{3, 1, "↑2"},
+		{1, 4, "↓3"},
+		{2, 2, "="},
+		{0, 5, "new"},
+	}
+	for _, tt := range tests {
+		if got := rankShift(tt.before, tt.now); got != tt.want {
+			t.Errorf("rankShift(%d, %d) = %q, want %q", tt.before, tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestConfigReload(t *testing.T) {
+	now := time.Now()
+	onHold := model.Item{ID: "1", Reason: model.ReasonSubscribed, Type: model.ItemTypeIssue, State: "open", UpdatedAt: now, Labels: []string{"on-hold"}, Assignees: []string{"testuser"}, Subject: model.Subject{Title: "on hold"}}
+	items := triage.NewEngine("testuser", config.DefaultScoreWeights(), nil).Prioritize([]model.Item{onHold})
+	subscribed := 30
+	onHoldLabels := []string{"on-hold"}
+
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		err        error
+		wantStatus string
+		wantScore  int
+		wantBlock  bool
+	}{
+		{
+			name:      "nothing shown changed",
+			cfg:       &config.Config{DefaultFormat: "json"},
+			wantScore: items[0].Score,
+		},
+		{
+			name:       "weights",
+			cfg:        &config.Config{BaseScores: &config.BaseScoreOverrides{Subscribed: &subscribed}},
+			wantStatus: "Config reloaded: weights",
+			wantScore:  items[0].Score - config.DefaultScoreWeights().Subscribed + subscribed,
+		},
+		{
+			name:       "blocked labels",
+			cfg:        &config.Config{BlockedLabels: &onHoldLabels},
+			wantStatus: "Config reloaded: blocked labels",
+			wantScore:  items[0].Score,
+			wantBlock:  true,
+		},
+		{
+			name:       "load error",
+			err:        errors.New("yaml: line 3: did not find expected key"),
+			wantStatus: "Config not reloaded: yaml: line 3: did not find expected key",
+			wantScore:  items[0].Score,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			load := func() (*config.Config, error) { return tt.cfg, tt.err }
+			m := NewListModel(items, newTestStore(t), config.DefaultScoreWeights(), "testuser",
+				WithConfig(&config.Config{}), WithBlockedLabels([]string{"blocked"}),
+				WithDependencyAuthors(config.DefaultDependencyAuthors()), WithRescoring(nil),
+				WithConfigReload(load, filepath.Join(t.TempDir(), "config.yaml")))
+
+			updated, cmd := m.Update(configCheckedMsg{stamp: "changed"})
+			m = updated.(ListModel)
+			if cmd == nil {
+				t.Error("config no longer watched after the check")
+			}
+			if m.statusMsg != tt.wantStatus {
+				t.Errorf("status = %q, want %q", m.statusMsg, tt.wantStatus)
+			}
+			if got := m.items[0].Score; got != tt.wantScore {
+				t.Errorf("score = %d, want %d", got, tt.wantScore)
+			}
+			if blocked := len(m.blockedItems) == 1; blocked != tt.wantBlock {
+				t.Errorf("in blocked pane = %v, want %v", blocked, tt.wantBlock)
+			}
+
+			// The same files aren't loaded again
+			m.statusMsg = ""
+			updated, _ = m.Update(configCheckedMsg{stamp: "changed"})
+			if status := updated.(ListModel).statusMsg; status != "" {
+				t.Errorf("unchanged files reloaded: status %q", status)
+			}
+		})
+	}
+}
+
+func TestConfigStamp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	missing := configStamp([]string{path})
+
+	if err := os.WriteFile(path, []byte("default_format: json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	written := configStamp([]string{path})
+	if written == missing {
+		t.Error("stamp unchanged after the file was created")
+	}
+
+	if err := os.WriteFile(path, []byte("default_format: table\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if configStamp([]string{path}) == written {
+		t.Error("stamp unchanged after the file was rewritten")
+	}
+}
+
+func TestLabelFilter(t *testing.T) {
+	now := time.Now()
+	labeled := func(id string, itemType model.ItemType, updated time.Time, labels ...string) triage.PrioritizedItem {
+		item := makeItem(id, itemType, updated)
+		item.Labels = labels
+		return item
+	}
+	items := []triage.PrioritizedItem{
+		labeled("security-pr", model.ItemTypePullRequest, now, "Security"),
+		labeled("security-wontfix", model.ItemTypeIssue, now.Add(-time.Hour), "security", "wontfix"),
+		labeled("wontfix", model.ItemTypeIssue, now.Add(-2*time.Hour), "wontfix"),
+		labeled("unlabeled", model.ItemTypeIssue, now.Add(-3*time.Hour)),
+	}
+	ids := func(m ListModel) []string {
+		var ids []string
+		for _, item := range m.activeItems() {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+	press := func(m ListModel, keys ...string) ListModel {
+		for _, k := range keys {
+			updated, _ := m.Update(keyMsg(k))
+			m = updated.(ListModel)
+		}
+		return m
+	}
+
+	m := NewListModel(items, newTestStore(t), config.ScoreWeights{}, "testuser")
+	m = press(m, "L", "security", "enter")
+	if want := []string{"security-pr", "security-wontfix"}; !slices.Equal(ids(m), want) {
+		t.Errorf("L security shows %v, want %v", ids(m), want)
+	}
+	if m.statusMsg != "Labels: security" || m.AssignedCount() != 2 {
+		t.Errorf("status %q, count %d", m.statusMsg, m.AssignedCount())
+	}
+
+	// The prompt starts from the current filter
+	m = press(m, "L", ",!wontfix", "enter")
+	if want := []string{"security-pr"}; !slices.Equal(ids(m), want) {
+		t.Errorf("L security,!wontfix shows %v, want %v", ids(m), want)
+	}
+
+	// Combined with the type filter
+	m = press(m, "t", "t")
+	if len(ids(m)) != 0 {
+		t.Errorf("issues labeled security but not wontfix: %v, want none", ids(m))
+	}
+	m = press(m, "t")
+
+	// Esc keeps the filter
+	m = press(m, "L", "x")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(ListModel)
+	if m.labelPrompt != nil || m.LabelFilterLabel() != "security, !wontfix" {
+		t.Errorf("after esc: prompt open = %v, filter %q", m.labelPrompt != nil, m.LabelFilterLabel())
+	}
+
+	// Clearing the prompt shows every item again
+	m = press(m, "L")
+	for range len(m.labelPrompt.input) {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = updated.(ListModel)
+	}
+	m = press(m, "enter")
+	if len(ids(m)) != len(items) || m.statusMsg != "Label filter cleared" {
+		t.Errorf("after clearing: %v, status %q", ids(m), m.statusMsg)
+	}
+}
+
+func TestResizeSequence(t *testing.T) {
+	sizes := []tea.WindowSizeMsg{
+		{Width: 140, Height: 24},
+		{Width: 40, Height: 10},
+		{Width: 20, Height: 5},
+		{Width: 1, Height: 1},
+		{Width: 0, Height: 0},
+		{Width: 200, Height: 60},
+		{Width: 60, Height: 3},
+		{Width: 140, Height: 24},
+	}
+	tests := []struct {
+		name string
+		opts []ListOption
+		keys []string
+	}{
+		{name: "queue", keys: []string{"3"}},
+		{name: "detail pane", opts: []ListOption{WithPreviewer(&fakePreviewer{preview: &model.Preview{Body: "body"}})}, keys: []string{"3", "l"}},
+		{name: "recently resolved", keys: []string{"3", "d", "T"}},
+		{name: "weights editor", opts: []ListOption{WithConfig(config.DefaultConfig()), WithRescoring(nil)}, keys: append([]string{"W"}, slices.Repeat([]string{"j"}, len(weightSettings))...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m tea.Model = NewListModel(snapshotItems(), newTestStore(t), config.DefaultScoreWeights(), "octocat", tt.opts...)
+			m, _ = m.Update(sizes[0])
+			for _, k := range tt.keys {
+				m, _ = m.Update(keyMsg(k))
+			}
+			first := m.View()
+
+			for _, size := range sizes {
+				m, _ = m.Update(size)
+				lines := strings.Split(m.View(), "\n")
+				if size.Height > 0 && len(lines) > size.Height {
+					t.Errorf("%dx%d: view is %d lines tall", size.Width, size.Height, len(lines))
+				}
+				for _, line := range lines {
+					if size.Width > 0 && format.DisplayWidth(line) > size.Width {
+						t.Errorf("%dx%d: line %q is %d columns wide", size.Width, size.Height, line, format.DisplayWidth(line))
+					}
+				}
+				if tt.name == "weights editor" && size.Height >= 10 && !strings.Contains(m.View(), "> "+weightSettings[len(weightSettings)-1].key) {
+					t.Errorf("%dx%d: selected setting scrolled out of view", size.Width, size.Height)
+				}
+			}
+
+			if got := m.View(); got != first {
+				t.Errorf("view after resizing back differs:\n%s\nwant:\n%s", got, first)
+			}
+		})
+	}
+}
+
+func TestMinimalRedrawStatus(t *testing.T) {
+	var m tea.Model = NewListModel(snapshotItems(), newTestStore(t), config.ScoreWeights{}, "octocat", WithMinimalRedraw(true))
+	m, _ = m.Update(keyMsg("s"))
+	status := m.(ListModel).statusMsg
+	if status == "" {
+		t.Fatal("sorting set no status message")
+	}
+
+	// The status stays until the next key rather than clearing on a timer
+	m, _ = m.Update(clearStatusMsg{})
+	if got := m.(ListModel).statusMsg; got != status {
+		t.Errorf("status after the clear timer = %q, want %q kept", got, status)
+	}
+	m, _ = m.Update(keyMsg("j"))
+	if got := m.(ListModel).statusMsg; got != "" {
+		t.Errorf("status after the next key = %q, want it cleared", got)
+	}
+}
+
+func TestShareItems(t *testing.T) {
+	weights := config.DefaultConfig().GetScoreWeights()
+	scored := triage.NewEngine("octocat", weights, config.DefaultQuickWinLabels()).Rescore(snapshotItems())
+	m := NewListModel(scored, newTestStore(t), weights, "octocat")
+	items := m.activeItems()
+	m.marked = map[string]bool{items[0].Key(): true}
+
+	updated, cmd := m.shareItems()
+	if cmd == nil {
+		t.Fatal("shareItems() returned no command to copy the snippet")
+	}
+	if len(updated.(ListModel).marked) != 0 {
+		t.Error("shareItems() kept the selection, want it cleared like opening")
+	}
+
+	// The snippet explains the item's score as it was scored
+	e := m.scoringEngine().Explain(items[0].Item)
+	if e.Score != items[0].Score {
+		t.Errorf("explained score = %d, want the listed %d", e.Score, items[0].Score)
+	}
+
+	tests := []struct {
+		msg  sharedMsg
+		want string
+	}{
+		{sharedMsg{count: 1}, "Copied to clipboard"},
+		{sharedMsg{count: 3}, "Copied 3 items to clipboard"},
+		{sharedMsg{count: 1, err: errors.New("no terminal")}, "Could not copy: no terminal"},
+	}
+	for _, tt := range tests {
+		got, _ := m.Update(tt.msg)
+		if status := got.(ListModel).statusMsg; status != tt.want {
+			t.Errorf("status after %+v = %q, want %q", tt.msg, status, tt.want)
+		}
+	}
+}
+
+// fakeDelegator records the assignments and comments made on GitHub.
+type fakeDelegator struct {
+	assigned []string
+	comments []string
+	err      error
+}
+
+func (f *fakeDelegator) AddAssignees(_ context.Context, owner, repo string, number int, logins []string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.assigned = append(f.assigned, fmt.Sprintf("%s/%s#%d %s", owner, repo, number, strings.Join(logins, ",")))
+	return nil
+}
+
+func (f *fakeDelegator) CreateComment(_ context.Context, _, _ string, _ int, body string) error {
+	f.comments = append(f.comments, body)
+	return nil
+}
+
+func TestDelegate(t *testing.T) {
+	policies, err := confirm.NewPolicies(config.DefaultConfirmationSettings())
+	if err != nil {
+		t.Fatal(err)
+	}
+	handoff, err := ParseHandoff("@{{.To}} could you take {{.Ref}}? — {{.From}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	updatedAt := time.Now().Add(-time.Hour)
+	item := makeItem("3001", model.ItemTypeIssue, updatedAt)
+	item.Repository.FullName = "org/app"
+	item.Number = 7
+
+	delegate := func(t *testing.T, d *fakeDelegator, keys ...string) (ListModel, *resolved.Store) {
+		t.Helper()
+		store := newTestStore(t)
+		m := NewListModel([]triage.PrioritizedItem{item}, store, config.ScoreWeights{}, "testuser",
+			WithDelegation(d, []string{"alice", "bob", "bobby"}, handoff), WithConfirmations(policies))
+		var cmd tea.Cmd
+		for _, key := range keys {
+			var updated tea.Model
+			updated, cmd = m.Update(keyMsg(key))
+			m = updated.(ListModel)
+		}
+		if cmd == nil {
+			t.Fatal("enter returned no command to delegate")
+		}
+		updated, _ := m.Update(cmd())
+		return updated.(ListModel), store
+	}
+
+	t.Run("picks a teammate", func(t *testing.T) {
+		d := &fakeDelegator{}
+		m, store := delegate(t, d, "A", "b", "o", "b", "tab", "enter")
+
+		if want := []string{"org/app#7 bobby"}; !slices.Equal(d.assigned, want) {
+			t.Errorf("assigned %v, want %v", d.assigned, want)
+		}
+		if want := []string{"@bobby could you take org/app#7? — testuser"}; !slices.Equal(d.comments, want) {
+			t.Errorf("comments = %q, want %q", d.comments, want)
+		}
+		if len(m.assignedItems) != 0 || len(m.assignedDoneItems) != 1 {
+			t.Errorf("assigned = %d, done = %d; want the item moved to done", len(m.assignedItems), len(m.assignedDoneItems))
+		}
+		// Resolved as of the handoff, so the assignment doesn't resurface it
+		if !store.IsResolved(item.Key()) || store.ShouldShow(item.Key(), time.Now().Add(-time.Second)) {
+			t.Error("item not resolved as of the handoff")
+		}
+		if want := "Delegated to @bobby"; m.statusMsg != want {
+			t.Errorf("status = %q, want %q", m.statusMsg, want)
+		}
+	})
+
+	t.Run("typed login outside the roster", func(t *testing.T) {
+		d := &fakeDelegator{}
+		_, _ = delegate(t, d, "A", "@", "c", "a", "r", "o", "l", "enter")
+		if want := []string{"org/app#7 carol"}; !slices.Equal(d.assigned, want) {
+			t.Errorf("assigned %v, want %v", d.assigned, want)
+		}
+	})
+
+	t.Run("dry run leaves the item", func(t *testing.T) {
+		d := &fakeDelegator{err: fmt.Errorf("%w: would assign", ghclient.ErrDryRun)}
+		m, store := delegate(t, d, "A", "enter")
+		if len(m.assignedItems) != 1 || store.IsResolved(item.Key()) {
+			t.Error("item resolved though it was not assigned")
+		}
+		if len(d.comments) != 0 {
+			t.Errorf("posted %q, want no handoff without the assignment", d.comments)
+		}
+		if want := "Dry run: not assigned to @alice"; m.statusMsg != want {
+			t.Errorf("status = %q, want %q", m.statusMsg, want)
+		}
+	})
+}
+
+func TestDelegatePromptText(t *testing.T) {
+	roster := []string{"alice", "bob", "carol", "dave", "erin", "frank"}
+	p := &delegatePrompt{items: make([]triage.PrioritizedItem, 2)}
+	want := "Delegate 2 items to: _   [alice]   bob   carol   dave   erin   tab: next   enter: delegate   esc: cancel"
+	if got := p.text(roster); got != want {
+		t.Errorf("text() = %q, want %q", got, want)
+	}
+
+	p.selected = 5
+	if got := p.text(roster); !strings.Contains(got, ": _   [frank]   tab") {
+		t.Errorf("text() = %q, want the page holding the highlighted match", got)
+	}
+}
@@ -2,13 +2,16 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/i18n"
 	"github.com/spiffcs/triage/internal/model"
 	"github.com/spiffcs/triage/internal/output"
+	"github.com/spiffcs/triage/internal/slo"
 	"github.com/spiffcs/triage/internal/triage"
 )
 
@@ -22,18 +25,36 @@ const tabBarLines = 3
 
 // columnVisibility tracks which optional columns should be shown based on terminal width
 type columnVisibility struct {
-	showSignal bool // Orphaned pane only - first to hide
-	showAuthor bool // Second to hide
-	showCI     bool // Third to hide
+	showYou    bool // First to hide
+	showSignal bool // Orphaned pane only - second to hide
+	showAuthor bool // Third to hide
+	showCI     bool // Fourth to hide
+
+	// statusMarkers adds letter and shape markers to Priority and CI,
+	// widening the Priority column
+	statusMarkers bool
+
+	// truncation sets how Repository and Author values are shortened
+	truncation output.Truncation
+}
+
+// priorityWidth is the width of the Priority column.
+func (v columnVisibility) priorityWidth() int {
+	if v.statusMarkers {
+		return output.ColPriority + output.ColPriorityMarker
+	}
+	return output.ColPriority
 }
 
 // calculateColumnVisibility determines which columns to show based on available width.
-// Columns are hidden in priority order: Signal (first) → Author → CI (last).
-func calculateColumnVisibility(windowWidth int, hideAssignedCI, hidePriority, showAuthor bool) columnVisibility {
+// Columns are hidden in priority order: You (first) → Signal → Author → CI (last).
+func calculateColumnVisibility(windowWidth int, hideAssignedCI, hidePriority, showAuthor, statusMarkers bool) columnVisibility {
 	vis := columnVisibility{
-		showSignal: true,
-		showAuthor: showAuthor,
-		showCI:     true,
+		showYou:       true,
+		showSignal:    true,
+		showAuthor:    showAuthor,
+		showCI:        true,
+		statusMarkers: statusMarkers,
 	}
 
 	// Calculate base width for always-visible columns using minimum flex widths
@@ -43,7 +64,7 @@ func calculateColumnVisibility(windowWidth int, hideAssignedCI, hidePriority, sh
 
 	// Add priority column if shown
 	if !hidePriority {
-		baseWidth += output.ColPriority + 2
+		baseWidth += vis.priorityWidth() + 2
 	}
 
 	// Add assigned column width for assigned/blocked/queue panes
@@ -84,6 +105,21 @@ func calculateColumnVisibility(windowWidth int, hideAssignedCI, hidePriority, sh
 		}
 	}
 
+	// You only shows once every other optional column fits
+	optional := 0
+	if vis.showCI {
+		optional += ciWidth
+	}
+	if vis.showAuthor {
+		optional += authorWidth
+	}
+	if hideAssignedCI && vis.showSignal {
+		optional += signalWidth
+	}
+	if windowWidth < baseWidth+optional+output.ColYou+2 {
+		vis.showYou = false
+	}
+
 	return vis
 }
 
@@ -98,7 +134,7 @@ type columnWidths struct {
 func fixedColumnsWidth(vis columnVisibility, hideAssignedCI, hidePriority bool) int {
 	fixed := 2 // cursor
 	if !hidePriority {
-		fixed += output.ColPriority + 2
+		fixed += vis.priorityWidth() + 2
 	}
 	fixed += output.ColType + 2
 	if vis.showAuthor {
@@ -114,6 +150,9 @@ func fixedColumnsWidth(vis columnVisibility, hideAssignedCI, hidePriority bool)
 	if hideAssignedCI && vis.showSignal {
 		fixed += colSignal + 2
 	}
+	if vis.showYou {
+		fixed += output.ColYou + 2
+	}
 	fixed += output.ColAge
 	// Gaps for repo (+2) and title (+2)
 	fixed += 4
@@ -140,11 +179,11 @@ func calculateColumnWidths(windowWidth int, vis columnVisibility, hideAssignedCI
 	maxTitleWidth := 0
 	maxRepoWidth := 0
 	for _, item := range items {
-		tw := format.DisplayWidth(item.Subject.Title) + format.IconWidth
+		tw := format.DisplayWidth(format.Sanitize(item.Subject.Title)) + format.IconWidth
 		if tw > maxTitleWidth {
 			maxTitleWidth = tw
 		}
-		rw := format.DisplayWidth(item.Repository.FullName)
+		rw := format.DisplayWidth(format.Sanitize(item.Repository.FullName))
 		if rw > maxRepoWidth {
 			maxRepoWidth = rw
 		}
@@ -187,8 +226,15 @@ func renderListView(m ListModel) string {
 		availableHeight = 0
 	}
 
-	// Get active pane's items and cursor
-	items := m.activeItems()
+	// Get active pane's items and cursor, marking grouped rows in the title
+	items, groups := m.clusterRows(m.paneItems())
+	items = slices.Clone(items)
+	for i := range items {
+		items[i].Subject.Title = clusterTitle(items[i].Subject.Title, groups[i])
+		if m.activePane == paneBlocked && !m.showTrash {
+			items[i].Subject.Title = blockedTitle(items[i].Subject.Title, items[i].BlockedBy, items[i].Repository.FullName)
+		}
+	}
 	cursor := m.activeCursor()
 
 	// Determine view flags based on active pane
@@ -196,18 +242,29 @@ func renderListView(m ListModel) string {
 	// Assigned pane: show Author AND Assigned, hide CI/Signal, hide Priority
 	// Blocked pane: show Author AND Assigned, hide CI/Signal, hide Priority (similar to Assigned)
 	// Dependabot pane: show Assigned/CI, hide Author, hide Priority
-	// Queue pane: show Assigned/CI, hide Author, show Priority
-	hideAssignedCI := m.activePane == paneOrphaned
-	hidePriority := m.activePane != paneQueue
-	showAuthor := m.activePane == paneOrphaned || m.activePane == paneAssigned || m.activePane == paneBlocked
-
-	// Render tab bar with top padding
+	// Queue and Later panes: show Assigned/CI, hide Author, show Priority
+	// Recently resolved items come from every pane, so they show what the queue does
+	view := m.activePane
+	if m.showTrash {
+		view = paneQueue
+	}
+	hideAssignedCI := view == paneOrphaned
+	hidePriority := view != paneQueue && view != paneLater
+	showAuthor := view == paneOrphaned || view == paneAssigned || view == paneBlocked
+
+	// Render tab bar, with the run delta in the top padding line
+	if m.runDelta != "" {
+		delta, _ := format.TruncateToWidth(m.runDelta, m.windowWidth)
+		b.WriteString(listCacheStyle.Render(delta))
+	}
 	b.WriteString("\n")
 	b.WriteString(renderTabBar(m))
 	b.WriteString("\n\n")
 
 	if len(items) == 0 {
-		if m.showDone {
+		if m.showTrash {
+			b.WriteString(renderTrashEmptyState())
+		} else if m.showDone {
 			b.WriteString(renderDoneEmptyState())
 		} else {
 			switch m.activePane {
@@ -219,54 +276,108 @@ func renderListView(m ListModel) string {
 				b.WriteString(renderBlockedEmptyState())
 			case paneDependabot:
 				b.WriteString(renderDependabotEmptyState())
+			case paneLater:
+				b.WriteString(renderLaterEmptyState())
 			default:
 				b.WriteString(renderEmptyState())
 			}
 		}
 		b.WriteString("\n\n")
-		b.WriteString(renderHelp(m.TypeFilterLabel(), m.showDone))
+		// A pending confirmation or label filter must stay visible even
+		// when the pane is empty
+		if m.pending != nil {
+			b.WriteString(listStatusStyle.Render(m.pending.prompt + " [y/N]"))
+			b.WriteString("\n")
+		} else if m.labelPrompt != nil {
+			b.WriteString(listStatusStyle.Render(m.labelPrompt.text()))
+			b.WriteString("\n")
+		}
+		b.WriteString(renderHelp(m.TypeFilterLabel(), m.LabelFilterLabel(), m.showDone, m.showTrash, m.clustered, m.readMarker != nil))
 		return b.String()
 	}
 
 	// Calculate column visibility based on terminal width
-	vis := calculateColumnVisibility(m.windowWidth, hideAssignedCI, hidePriority, showAuthor)
+	vis := calculateColumnVisibility(m.windowWidth, hideAssignedCI, hidePriority, showAuthor, m.statusMarkers)
+	vis.truncation = m.truncation
 	cw := calculateColumnWidths(m.windowWidth, vis, hideAssignedCI, hidePriority, items)
 
 	// Render header
-	b.WriteString(renderHeader(hideAssignedCI, hidePriority, vis, cw))
+	// Orphaned items carry their own details, so only the other panes
+	// lose columns in quick mode
+	quick := m.quick && !hideAssignedCI
+	b.WriteString(renderHeader(hideAssignedCI, hidePriority, quick, vis, cw))
 	b.WriteString("\n")
 	b.WriteString(renderSeparator(m.windowWidth))
 	b.WriteString("\n")
 
+	// The detail pane takes the lower part of the list area
+	detailHeight := m.detailHeight(availableHeight)
+	listHeight := availableHeight - detailHeight
+
 	// Calculate scroll window
-	start, end := calculateScrollWindow(cursor, len(items), availableHeight)
+	start, end := calculateScrollWindow(cursor, len(items), listHeight)
 
 	// Render visible items
 	for i := start; i < end; i++ {
 		selected := i == cursor
-		b.WriteString(renderRow(items[i], selected, m.hotTopicThreshold, m.prSizeXS, m.prSizeS, m.prSizeM, m.prSizeL, m.currentUser, hideAssignedCI, hidePriority, vis, cw, m.windowWidth))
+		reviewStatus := m.reviewSLO.ItemStatus(&items[i].Item, m.currentUser, time.Now())
+		marked := !m.showDone && !m.showTrash && m.marked[items[i].Key()]
+		b.WriteString(renderRow(items[i], selected, marked, m.hotTopicThreshold, m.prSizeXS, m.prSizeS, m.prSizeM, m.prSizeL, m.currentUser, hideAssignedCI, hidePriority, quick, reviewStatus, vis, cw, m.windowWidth))
 		b.WriteString("\n")
 	}
 
 	// Pad remaining space so the help text stays pinned to the bottom
 	renderedRows := end - start
-	for i := renderedRows; i < availableHeight; i++ {
+	for i := renderedRows; i < listHeight; i++ {
+		b.WriteString("\n")
+	}
+	if selected, ok := m.selectedItem(); ok && detailHeight > 0 {
+		b.WriteString(renderDetailPane(m, selected, m.windowWidth, detailHeight))
 		b.WriteString("\n")
 	}
 
 	// Render footer: cache/status line above help
 	b.WriteString("\n")
-	if m.statusMsg != "" {
+	if m.pending != nil {
+		b.WriteString(listStatusStyle.Render(m.pending.prompt + " [y/N]"))
+	} else if m.snoozing != nil {
+		b.WriteString(listStatusStyle.Render(m.snoozing.text()))
+	} else if m.moving != nil {
+		b.WriteString(listStatusStyle.Render(movePromptText()))
+	} else if m.delegating != nil {
+		b.WriteString(listStatusStyle.Render(m.delegating.text(m.roster)))
+	} else if m.labelPrompt != nil {
+		b.WriteString(listStatusStyle.Render(m.labelPrompt.text()))
+	} else if m.statusMsg != "" {
 		b.WriteString(listStatusStyle.Render(m.statusMsg))
-	} else if m.cacheMsg != "" {
-		b.WriteString(listCacheStyle.Render(m.cacheMsg))
+	} else if n := len(m.markedItems()); n > 0 {
+		b.WriteString(listStatusStyle.Render(fmt.Sprintf("%d selected   d: done   z: snooze   A: delegate   enter: open   esc: clear", n)))
+	} else if note := footerNote(m.cacheMsg, quick, m.reviewSLOSummary()); note != "" {
+		b.WriteString(listCacheStyle.Render(note))
 	}
 	b.WriteString("\n")
-	b.WriteString(renderHelp(m.TypeFilterLabel(), m.showDone))
+	b.WriteString(renderHelp(m.TypeFilterLabel(), m.LabelFilterLabel(), m.showDone, m.showTrash, m.clustered, m.readMarker != nil))
 
 	return b.String()
 }
 
+// fitWindow clips each line of view to width and drops the lines past
+// height. Left to the renderer, long lines would be cut without a marker
+// and a tall view would lose its tab bar off the top. A width or height of
+// 0, as before the first WindowSizeMsg, leaves that dimension unlimited.
+func fitWindow(view string, width, height int) string {
+	lines := strings.Split(view, "\n")
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+	if width > 0 {
+		for i, line := range lines {
+			lines[i] = clip(line, width)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // renderTabBar renders the tab bar at the top of the view
 func renderTabBar(m ListModel) string {
 	sortDir := func(desc bool) string {
@@ -287,6 +398,10 @@ func renderTabBar(m ListModel) string {
 		{paneDependabot, fmt.Sprintf("[ 4: Deps (%d) %s%s ]", m.DependabotCount(), sortDir(m.DependabotSortDesc()), m.DependabotSortColumn())},
 		{paneOrphaned, fmt.Sprintf("[ 5: Orphaned (%d) %s%s ]", m.filteredCount(m.orphanedItems), sortDir(m.OrphanedSortDesc()), m.OrphanedSortColumn())},
 	}
+	// Later only holds moved items, so it is only shown while moving is enabled
+	if m.placements != nil {
+		tabs = append(tabs, tab{paneLater, fmt.Sprintf("[ 6: Later (%d) %s%s ]", m.LaterCount(), sortDir(m.LaterSortDesc()), m.LaterSortColumn())})
+	}
 
 	var parts []string
 	for _, t := range tabs {
@@ -294,16 +409,38 @@ func renderTabBar(m ListModel) string {
 		if t.pane == m.activePane && m.showDone {
 			label = strings.Replace(label, "]", " DONE ]", 1)
 		}
-		if t.pane == m.activePane {
+		if t.pane == m.activePane && !m.showTrash {
 			parts = append(parts, tabActiveStyle.Render(label))
 		} else {
 			parts = append(parts, tabInactiveStyle.Render(label))
 		}
 	}
 
+	// The recently resolved view has no number and only appears while open
+	if m.showTrash {
+		parts = append(parts, tabActiveStyle.Render(fmt.Sprintf("[ Recently resolved (%d) ]", m.filteredCount(m.trashItems))))
+	}
+
 	return strings.Join(parts, "    ")
 }
 
+// blockedTitle prefixes title with the items blocking it, e.g.
+// "[blocked by #12, other/repo#3] title". References in repo are shortened
+// to the number.
+func blockedTitle(title string, blockers []string, repo string) string {
+	if len(blockers) == 0 {
+		return title
+	}
+	refs := make([]string, len(blockers))
+	for i, ref := range blockers {
+		if number, ok := strings.CutPrefix(ref, repo+"#"); ok {
+			ref = "#" + number
+		}
+		refs[i] = ref
+	}
+	return "[blocked by " + strings.Join(refs, ", ") + "] " + title
+}
+
 // renderOrphanedEmptyState renders the empty state message for the orphaned pane
 func (m ListModel) renderOrphanedEmptyState() string {
 	// Check if orphaned repos are configured
@@ -334,11 +471,21 @@ func renderBlockedEmptyState() string {
 	return listEmptyStyle.Render("No blocked items.\nItems with the 'blocked' label will appear here.")
 }
 
+// renderLaterEmptyState renders the empty state message for the later pane
+func renderLaterEmptyState() string {
+	return listEmptyStyle.Render("Nothing moved to Later.\nPress m then 6 to move an item here.")
+}
+
 // renderDoneEmptyState renders the empty state message when viewing done items
 func renderDoneEmptyState() string {
 	return listEmptyStyle.Render("No done items.\nPress u to go back.")
 }
 
+// renderTrashEmptyState renders the empty state message when viewing recently resolved items
+func renderTrashEmptyState() string {
+	return listEmptyStyle.Render("Nothing resolved in the last 7 days.\nPress T to go back.")
+}
+
 // calculateScrollWindow determines which items to show based on cursor position
 func calculateScrollWindow(cursor, total, viewHeight int) (start, end int) {
 	if total <= viewHeight {
@@ -362,8 +509,76 @@ func calculateScrollWindow(cursor, total, viewHeight int) (start, end int) {
 	return start, end
 }
 
-// renderHeader renders the table header
-func renderHeader(hideAssignedCI, hidePriority bool, vis columnVisibility, cw columnWidths) string {
+// unavailableMark heads columns that have no data in quick mode.
+const unavailableMark = "*"
+
+// quickModeNote explains unavailableMark in the footer.
+const quickModeNote = "* not available in quick mode"
+
+// footerNote combines the persistent footer messages.
+func footerNote(cacheMsg string, quick bool, sloSummary string) string {
+	var parts []string
+	if cacheMsg != "" {
+		parts = append(parts, cacheMsg)
+	}
+	if quick {
+		parts = append(parts, quickModeNote)
+	}
+	if sloSummary != "" {
+		parts = append(parts, sloSummary)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// reviewSLOSummary describes recent review response times against the
+// SLO, e.g. "Reviews (1mo): p50 5h, p90 2d, SLO 1d, 2 at risk". It is
+// empty when the SLO is off or there is nothing to report.
+func (m ListModel) reviewSLOSummary() string {
+	if !m.reviewSLO.Enabled() {
+		return ""
+	}
+	now := time.Now()
+	atRisk := 0
+	for i := range m.items {
+		if m.reviewSLO.ItemStatus(&m.items[i].Item, m.currentUser, now) >= slo.StatusAtRisk {
+			atRisk++
+		}
+	}
+	if m.reviewStats.Count == 0 && atRisk == 0 {
+		return ""
+	}
+
+	var parts []string
+	if m.reviewStats.Count > 0 {
+		parts = append(parts, "p50 "+format.FormatAge(m.reviewStats.P50), "p90 "+format.FormatAge(m.reviewStats.P90))
+	}
+	parts = append(parts, "SLO "+format.FormatAge(m.reviewSLO.Target))
+	if atRisk > 0 {
+		parts = append(parts, fmt.Sprintf("%d at risk", atRisk))
+	}
+	return fmt.Sprintf("Reviews (%s): %s", format.FormatAge(m.reviewSLO.Window), strings.Join(parts, ", "))
+}
+
+// renderHeader renders the table header. In quick mode the columns built
+// from enrichment data are marked unavailable.
+func renderHeader(hideAssignedCI, hidePriority, quick bool, vis columnVisibility, cw columnWidths) string {
+	mark := func(label string) string {
+		if quick {
+			return label + unavailableMark
+		}
+		return label
+	}
+	// Translated headers are cut to the column width to keep alignment
+	label := func(key i18n.Key, width int) string {
+		l, _ := format.TruncateToWidth(i18n.T(key), width)
+		return l
+	}
+	// pad pads by display width, which fmt's %-*s (counting runes) gets
+	// wrong for wide characters
+	pad := func(text string, width int) string {
+		return format.PadRight(text, format.DisplayWidth(text), width)
+	}
+
 	var parts []string
 
 	// Cursor space
@@ -371,43 +586,48 @@ func renderHeader(hideAssignedCI, hidePriority bool, vis columnVisibility, cw co
 
 	// Priority column (Queue pane only)
 	if !hidePriority {
-		parts = append(parts, fmt.Sprintf("%-*s  ", output.ColPriority, "Priority"))
+		parts = append(parts, pad(label(i18n.HeaderPriority, vis.priorityWidth()), vis.priorityWidth())+"  ")
 	}
 
 	// Type column (always visible)
-	parts = append(parts, fmt.Sprintf("%-*s  ", output.ColType, "Type"))
+	parts = append(parts, pad(label(i18n.HeaderType, output.ColType), output.ColType)+"  ")
 
 	// Author column (Orphaned/Assigned/Blocked panes, if visible)
 	if vis.showAuthor {
-		parts = append(parts, fmt.Sprintf("%-*s  ", output.ColAuthor, "Author"))
+		parts = append(parts, pad(label(i18n.HeaderAuthor, output.ColAuthor), output.ColAuthor)+"  ")
 	}
 
 	// Assigned column (Assigned/Blocked/Queue panes)
 	if !hideAssignedCI {
-		parts = append(parts, fmt.Sprintf("%-*s  ", output.ColAssigned, "Assigned"))
+		parts = append(parts, pad(mark(label(i18n.HeaderAssigned, output.ColAssigned)), output.ColAssigned)+"  ")
 	}
 
 	// CI column (if visible)
 	if vis.showCI {
-		parts = append(parts, fmt.Sprintf("%-*s  ", output.ColCI, "CI"))
+		parts = append(parts, pad(mark(label(i18n.HeaderCI, output.ColCI)), output.ColCI)+"  ")
 	}
 
 	// Repository column (always visible)
-	parts = append(parts, fmt.Sprintf("%-*s  ", cw.repo, "Repository"))
+	parts = append(parts, pad(label(i18n.HeaderRepository, cw.repo), cw.repo)+"  ")
 
 	// Title column (always visible)
-	parts = append(parts, fmt.Sprintf("%-*s  ", cw.title, "Title"))
+	parts = append(parts, pad(label(i18n.HeaderTitle, cw.title), cw.title)+"  ")
 
 	// Status column (always visible)
-	parts = append(parts, fmt.Sprintf("%-*s  ", output.ColStatus, "Status"))
+	parts = append(parts, pad(mark(label(i18n.HeaderStatus, output.ColStatus)), output.ColStatus)+"  ")
 
 	// Signal column (Orphaned pane only, if visible)
 	if hideAssignedCI && vis.showSignal {
-		parts = append(parts, fmt.Sprintf("%-*s  ", colSignal, "Signal"))
+		parts = append(parts, pad(label(i18n.HeaderSignal, colSignal), colSignal)+"  ")
+	}
+
+	// You column (if visible)
+	if vis.showYou {
+		parts = append(parts, pad(mark(label(i18n.HeaderYou, output.ColYou)), output.ColYou)+"  ")
 	}
 
 	// Age column (always visible, no trailing space)
-	parts = append(parts, fmt.Sprintf("%-*s", output.ColAge, "Age"))
+	parts = append(parts, pad(label(i18n.HeaderAge, output.ColAge), output.ColAge))
 
 	return listHeaderStyle.Render(strings.Join(parts, ""))
 }
@@ -417,14 +637,20 @@ func renderSeparator(width int) string {
 	return listSeparatorStyle.Render(strings.Repeat("─", width))
 }
 
-// renderRow renders a single item row
-func renderRow(item triage.PrioritizedItem, selected bool, hotTopicThreshold, prSizeXS, prSizeS, prSizeM, prSizeL int, currentUser string, hideAssignedCI, hidePriority bool, vis columnVisibility, cw columnWidths, windowWidth int) string {
+// renderRow renders a single item row. In quick mode the columns built
+// from enrichment data show a dash.
+func renderRow(item triage.PrioritizedItem, selected, marked bool, hotTopicThreshold, prSizeXS, prSizeS, prSizeM, prSizeL int, currentUser string, hideAssignedCI, hidePriority, quick bool, reviewStatus slo.Status, vis columnVisibility, cw columnWidths, windowWidth int) string {
 	n := item.Item
 
-	// Cursor indicator
+	// Cursor indicator, with a mark on selected items
 	cursor := "  "
-	if selected {
+	switch {
+	case selected && marked:
+		cursor = applyStyle(listCursorStyle, ">*", selected)
+	case selected:
 		cursor = applyStyle(listCursorStyle, "> ", selected)
+	case marked:
+		cursor = applyStyle(listCursorStyle, " *", selected)
 	}
 
 	// Type with color
@@ -442,13 +668,17 @@ func renderRow(item triage.PrioritizedItem, selected bool, hotTopicThreshold, pr
 	priority := ""
 	if !hidePriority {
 		var priorityWidth int
-		priority, priorityWidth = renderPriority(item.Priority, selected)
-		priority = format.PadRight(priority, priorityWidth, output.ColPriority)
+		priority, priorityWidth = renderPriority(item.Priority, selected, vis.statusMarkers)
+		priority = format.PadRight(priority, priorityWidth, vis.priorityWidth())
 		priority += "  " // spacing
 	}
 
-	// Title with icon prefix using shared logic
-	title := n.Subject.Title
+	// Title with icon prefix using shared logic. Titles, repos, and logins
+	// come from GitHub, so strip anything that could drive the terminal
+	title := format.Sanitize(n.Subject.Title)
+	if n.Reminder != nil {
+		title = format.ReminderTitle(title, format.Sanitize(n.Reminder.Note))
+	}
 
 	var titleIcon string
 	var iconDisplayWidth int
@@ -459,6 +689,10 @@ func renderRow(item triage.PrioritizedItem, selected bool, hotTopicThreshold, pr
 		CurrentUser:       currentUser,
 		CommentCount:      n.CommentCount,
 		IsPR:              isPR,
+		Inaccessible:      n.Inaccessible,
+		Resurfaced:        item.Resurfaced,
+		ReminderDue:       n.Reminder != nil,
+		Stale:             n.Stale,
 	}
 	if issueDetails := n.IssueDetails(); issueDetails != nil {
 		iconInput.LastCommenter = issueDetails.LastCommenter
@@ -472,6 +706,18 @@ func renderRow(item triage.PrioritizedItem, selected bool, hotTopicThreshold, pr
 	case format.IconQuickWin:
 		titleIcon = applyStyle(listQuickWinIconStyle, format.QuickWinIcon, selected) + " "
 		iconDisplayWidth = format.IconWidth
+	case format.IconLocked:
+		titleIcon = format.LockedIcon + " "
+		iconDisplayWidth = format.IconWidth
+	case format.IconResurfaced:
+		titleIcon = format.ResurfacedIcon + " "
+		iconDisplayWidth = format.IconWidth
+	case format.IconReminder:
+		titleIcon = format.ReminderIcon + " "
+		iconDisplayWidth = format.IconWidth
+	case format.IconStale:
+		titleIcon = format.StaleIcon + " "
+		iconDisplayWidth = format.IconWidth
 	default:
 		titleIcon = "   " // 3 spaces
 		iconDisplayWidth = format.IconWidth
@@ -484,18 +730,33 @@ func renderRow(item triage.PrioritizedItem, selected bool, hotTopicThreshold, pr
 	title = format.PadRight(title, titleWidth, cw.title)
 
 	// Repository
-	repo, repoWidth := format.TruncateToWidth(n.Repository.FullName, cw.repo)
+	repo, repoWidth := format.Truncate(format.Sanitize(n.Repository.FullName), cw.repo, vis.truncation.Repository)
 	repo = format.PadRight(repo, repoWidth, cw.repo)
 
 	// Status with colors
 	status, statusWidth := renderStatus(n, prSizeXS, prSizeS, prSizeM, prSizeL, selected)
+	if n.Chained() {
+		status, statusWidth = format.ChainStatus(status, statusWidth)
+	}
+	if quick {
+		status, statusWidth = "─", 1
+	}
 	if statusWidth > output.ColStatus {
 		status, statusWidth = format.TruncateToWidth(status, output.ColStatus)
 	}
 	status = format.PadRight(status, statusWidth, output.ColStatus)
 
 	// Age using shared logic with color coding
-	age, ageWidth := renderAge(time.Since(n.UpdatedAt), selected)
+	// Pending review requests age from the request, not the last update
+	waited := time.Since(n.WaitingSince(currentUser))
+	age, ageWidth := renderAge(waited, selected)
+	// A review request close to or past the SLO overrides the age color
+	switch reviewStatus {
+	case slo.StatusAtRisk:
+		age = applyStyle(listAgeWarningStyle, format.FormatAge(waited), selected)
+	case slo.StatusBreached:
+		age = applyStyle(listAgeCriticalStyle, format.FormatAge(waited), selected)
+	}
 	age = format.PadRight(age, ageWidth, output.ColAge)
 
 	// Build row dynamically based on pane type and column visibility
@@ -512,24 +773,30 @@ func renderRow(item triage.PrioritizedItem, selected bool, hotTopicThreshold, pr
 
 	// Author column (Orphaned/Assigned/Blocked panes, if visible)
 	if vis.showAuthor {
-		author := "─"
+		author, authorWidth := "─", 1
 		if n.Author != "" {
-			author, _ = format.TruncateToWidth(n.Author, output.ColAuthor)
+			author, authorWidth = format.Truncate(format.Sanitize(n.Author), output.ColAuthor, vis.truncation.Author)
 		}
-		author = format.PadRight(author, len(author), output.ColAuthor)
+		author = format.PadRight(author, authorWidth, output.ColAuthor)
 		parts = append(parts, author+"  ")
 	}
 
 	// Assigned column (non-orphaned panes)
 	if !hideAssignedCI {
 		assigned, assignedWidth := renderAssigned(&n, selected)
+		if quick {
+			assigned, assignedWidth = "─", 1
+		}
 		assigned = format.PadRight(assigned, assignedWidth, output.ColAssigned)
 		parts = append(parts, assigned+"  ")
 	}
 
 	// CI column (if visible)
 	if vis.showCI {
-		ci, ciWidth := renderCI(&n, isPR, selected)
+		ci, ciWidth := renderCI(&n, isPR, selected, vis.statusMarkers)
+		if quick {
+			ci, ciWidth = "─", 1
+		}
 		ci = format.PadRight(ci, ciWidth, output.ColCI)
 		parts = append(parts, ci+"  ")
 	}
@@ -550,13 +817,25 @@ func renderRow(item triage.PrioritizedItem, selected bool, hotTopicThreshold, pr
 		parts = append(parts, signal+"  ")
 	}
 
+	// You column (if visible)
+	if vis.showYou {
+		you, youWidth := renderLastInteraction(n.LastInteractionAt, selected)
+		if quick {
+			you, youWidth = "─", 1
+		}
+		you = format.PadRight(you, youWidth, output.ColYou)
+		parts = append(parts, you+"  ")
+	}
+
 	// Age column (always visible)
 	parts = append(parts, age)
 
 	row := strings.Join(parts, "")
 
 	if selected {
-		return listSelectedStyle.Width(windowWidth).Render(row)
+		// Clip first: a row wider than the window would wrap onto a
+		// second line inside the highlight
+		return listSelectedStyle.Width(windowWidth).Render(clip(row, windowWidth))
 	}
 	return row
 }
@@ -586,7 +865,7 @@ func renderSignal(n *model.Item, selected bool) (string, int) {
 			coloredText = applyStyle(listSignalInfoStyle, text, selected)
 		}
 		coloredParts = append(coloredParts, coloredText)
-		plainWidth += len(text)
+		plainWidth += format.DisplayWidth(text)
 	}
 
 	// Consecutive unanswered comments - color based on count
@@ -603,40 +882,46 @@ func renderSignal(n *model.Item, selected bool) (string, int) {
 
 		if plainWidth > 0 {
 			coloredParts = append(coloredParts, ", "+coloredText)
-			plainWidth += 2 + len(text) // ", " + text
+			plainWidth += 2 + format.DisplayWidth(text) // ", " + text
 		} else {
 			coloredParts = append(coloredParts, coloredText)
-			plainWidth += len(text)
+			plainWidth += format.DisplayWidth(text)
 		}
 	}
 
 	if len(coloredParts) == 0 {
-		return applyStyle(listSignalInfoStyle, "Needs attention", selected), 15
+		text := "Needs attention"
+		return applyStyle(listSignalInfoStyle, text, selected), format.DisplayWidth(text)
 	}
 
 	return strings.Join(coloredParts, ""), plainWidth
 }
 
-// renderPriority renders the priority with appropriate styling
+// renderPriority renders the priority with appropriate styling, prefixed
+// with its marker (e.g. "[U] ") when markers is set
 // Returns the colored string and its visible width
-func renderPriority(p triage.PriorityLevel, selected bool) (string, int) {
+func renderPriority(p triage.PriorityLevel, selected, markers bool) (string, int) {
+	style := listFYIStyle
 	switch p {
 	case triage.PriorityUrgent:
-		return applyStyle(listUrgentStyle, "Urgent", selected), 6
+		style = listUrgentStyle
 	case triage.PriorityImportant:
-		return applyStyle(listImportantStyle, "Important", selected), 9
+		style = listImportantStyle
 	case triage.PriorityQuickWin:
-		return applyStyle(listQuickWinStyle, "Quick Win", selected), 9
+		style = listQuickWinStyle
 	case triage.PriorityNotable:
-		return applyStyle(listNotableStyle, "Notable", selected), 7
-	default:
-		return applyStyle(listFYIStyle, "FYI", selected), 3
+		style = listNotableStyle
+	}
+	label, _ := format.TruncateToWidth(p.Display(), output.ColPriority)
+	if markers {
+		label = p.Marker() + " " + label
 	}
+	return applyStyle(style, label, selected), format.DisplayWidth(label)
 }
 
 // renderCI renders the CI status column
 // Returns the colored string and its visible width
-func renderCI(n *model.Item, isPR bool, selected bool) (string, int) {
+func renderCI(n *model.Item, isPR, selected, markers bool) (string, int) {
 	if !isPR {
 		return "─", 1 // dash for non-PRs
 	}
@@ -644,13 +929,17 @@ func renderCI(n *model.Item, isPR bool, selected bool) (string, int) {
 	if pr == nil {
 		return "─", 1 // dash if no details
 	}
+	success, failure, pending := output.CISuccessGlyph, output.CIFailureGlyph, output.CIPendingGlyph
+	if markers {
+		success, failure, pending = output.CISuccessMarker, output.CIFailureMarker, output.CIPendingMarker
+	}
 	switch pr.CIStatus {
 	case model.CIStatusSuccess:
-		return applyStyle(listCISuccessStyle, "✓", selected), 1
+		return applyStyle(listCISuccessStyle, success, selected), 1
 	case model.CIStatusFailure:
-		return applyStyle(listCIFailureStyle, "✗", selected), 1
+		return applyStyle(listCIFailureStyle, failure, selected), 1
 	case model.CIStatusPending:
-		return applyStyle(listCIPendingStyle, "○", selected), 1
+		return applyStyle(listCIPendingStyle, pending, selected), 1
 	default:
 		return "─", 1 // dash for no CI
 	}
@@ -670,7 +959,7 @@ func renderAssigned(n *model.Item, selected bool) (string, int) {
 		input.RequestedReviewers = pr.RequestedReviewers
 	}
 
-	assigned := format.Assigned(input)
+	assigned := format.Sanitize(format.Assigned(input))
 	if assigned == "" {
 		return "─", 1
 	}
@@ -678,7 +967,7 @@ func renderAssigned(n *model.Item, selected bool) (string, int) {
 	// Truncate if needed
 	assigned = format.TruncateUsername(assigned, output.ColAssigned)
 
-	return assigned, len(assigned)
+	return assigned, format.DisplayWidth(assigned)
 }
 
 // renderStatus renders the status column with colors
@@ -690,17 +979,19 @@ func renderStatus(n model.Item, sizeXS, sizeS, sizeM, sizeL int, selected bool)
 		var coloredParts []string
 		var plainWidth int
 
+		var review string
 		switch pr.ReviewState {
 		case model.ReviewStateApproved:
-			coloredParts = append(coloredParts, applyStyle(listApprovedStyle, "+ APPROVED", selected))
-			plainWidth += 10
+			review = "+ APPROVED"
+			coloredParts = append(coloredParts, applyStyle(listApprovedStyle, review, selected))
 		case model.ReviewStateChangesRequested:
-			coloredParts = append(coloredParts, applyStyle(listChangesStyle, "! CHANGES", selected))
-			plainWidth += 9
+			review = "! CHANGES"
+			coloredParts = append(coloredParts, applyStyle(listChangesStyle, review, selected))
 		case model.ReviewStatePending, model.ReviewStateReviewRequired, model.ReviewStateReviewed:
-			coloredParts = append(coloredParts, applyStyle(listReviewStyle, "* REVIEW", selected))
-			plainWidth += 8
+			review = "* REVIEW"
+			coloredParts = append(coloredParts, applyStyle(listReviewStyle, review, selected))
 		}
+		plainWidth += format.DisplayWidth(review)
 
 		totalChanges := pr.Additions + pr.Deletions
 		if totalChanges > 0 {
@@ -718,7 +1009,7 @@ func renderStatus(n model.Item, sizeXS, sizeS, sizeM, sizeL int, selected bool)
 				plainWidth += 1 // space
 			}
 			// Calculate actual visible width of size string
-			plainWidth += len(fmt.Sprintf("%s+%d/-%d", string(sizeResult.Size), pr.Additions, pr.Deletions))
+			plainWidth += format.DisplayWidth(sizeResult.Formatted)
 		}
 
 		if len(coloredParts) > 0 {
@@ -728,11 +1019,11 @@ func renderStatus(n model.Item, sizeXS, sizeS, sizeM, sizeL int, selected bool)
 
 	if n.CommentCount > 0 {
 		text := fmt.Sprintf("%d comments", n.CommentCount)
-		return text, len(text)
+		return text, format.DisplayWidth(text)
 	}
 
 	reason := string(n.Reason)
-	return reason, len(reason)
+	return reason, format.DisplayWidth(reason)
 }
 
 // colorPRSizeTUI returns a styled string for the PR size using lipgloss
@@ -752,7 +1043,7 @@ func colorPRSizeTUI(size format.PRSize, selected bool) string {
 func renderAge(d time.Duration, selected bool) (string, int) {
 	ageStr := format.FormatAge(d)
 	days := int(d.Hours() / 24)
-	width := len(ageStr)
+	width := format.DisplayWidth(ageStr)
 
 	switch {
 	case days >= 30:
@@ -766,12 +1057,35 @@ func renderAge(d time.Duration, selected bool) (string, int) {
 	}
 }
 
-// renderHelp renders the help text with the current type filter label
-func renderHelp(filterLabel string, showDone bool) string {
+// renderLastInteraction renders when the current user last interacted with
+// an item, dimmed like other empty cells when they never have.
+// Returns colored text and visible width
+func renderLastInteraction(at *time.Time, selected bool) (string, int) {
+	if at == nil {
+		return "─", 1
+	}
+	s := format.FormatAgo(time.Since(*at))
+	return applyStyle(listAgeRecentStyle, s, selected), format.DisplayWidth(s)
+}
+
+// renderHelp renders the help text with the current type and label filter
+// labels. With syncRead, d also marks notifications read and D doesn't.
+func renderHelp(filterLabel, labelFilter string, showDone, showTrash, clustered, syncRead bool) string {
+	group := "   c: group"
+	if clustered {
+		group = "   c: ungroup   space: expand"
+	}
+	done := "   d: done"
+	if syncRead {
+		done = "   d: done+read   D: done"
+	}
+	if showTrash {
+		return listHelpStyle.Render("Tab/1-5: panes   j/k: nav   t: " + filterLabel + "   L: " + labelFilter + group + "   d: restore   T: back   l: details   enter: open   q: quit")
+	}
 	if showDone {
-		return listHelpStyle.Render("Tab/1-5: panes   j/k: nav   s/S: sort   r: reset   t: " + filterLabel + "   d: restore   u: back   enter: open   q: quit")
+		return listHelpStyle.Render("Tab/1-5: panes   j/k: nav   s/S: sort   r: reset   t: " + filterLabel + "   L: " + labelFilter + group + "   d: restore   u: back   T: recent   l: details   enter: open   q: quit")
 	}
-	return listHelpStyle.Render("Tab/1-5: panes   j/k: nav   s/S: sort   r: reset   t: " + filterLabel + "   d: done   u: show done   enter: open   q: quit")
+	return listHelpStyle.Render("Tab/1-5: panes   j/k: nav   s/S: sort   r: reset   t: " + filterLabel + "   L: " + labelFilter + group + "   v/V: select" + done + "   z: snooze   m: move   A: delegate   u: show done   T: recent   E: reply   y: share   l: details   W: weights   enter: open   q: quit")
 }
 
 // renderEmptyState renders the empty state message
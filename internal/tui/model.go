@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/internal/confirm"
 )
 
 // Model is the Bubble Tea model for the TUI progress display.
@@ -21,8 +22,27 @@ type Model struct {
 	windowHeight   int
 	rateLimited    bool
 	rateLimitReset time.Time
+	// reducedMotion draws a still spinner and progress bar (see
+	// WithReducedMotion).
+	reducedMotion bool
+	// question is waiting for a yes/no answer; no events are read until
+	// it is answered.
+	question *ConfirmEvent
 }
 
+// The progress bar is progressWidth columns wide, and narrows on terminals
+// too narrow to fit it next to a task's name.
+const (
+	progressWidth    = 25
+	minProgressWidth = 5
+	// progressChrome is the room a task line needs besides its bar: the
+	// indent, icon, task name and percentage.
+	progressChrome = 30
+)
+
+// stillSpinnerFrame stands in for the spinner when motion is reduced.
+const stillSpinnerFrame = "•"
+
 // doneMsg signals that all events have been processed.
 type doneMsg struct{}
 
@@ -36,6 +56,15 @@ func WithTasks(tasks []Task) ModelOption {
 	}
 }
 
+// WithReducedMotion replaces the spinner with a still marker and moves the
+// progress bar straight to each new value instead of animating it, so the
+// display only redraws when a task changes.
+func WithReducedMotion(reduced bool) ModelOption {
+	return func(m *Model) {
+		m.reducedMotion = reduced
+	}
+}
+
 // DefaultTasks returns the default task list for the main triage command.
 func DefaultTasks() []Task {
 	return []Task{
@@ -53,7 +82,7 @@ func NewModel(events <-chan Event, opts ...ModelOption) Model {
 
 	p := progress.New(
 		progress.WithScaledGradient("#60a5fa", "#1e3a8a"),
-		progress.WithWidth(25),
+		progress.WithWidth(progressWidth),
 		progress.WithoutPercentage(),
 	)
 
@@ -73,6 +102,9 @@ func NewModel(events <-chan Event, opts ...ModelOption) Model {
 
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
+	if m.reducedMotion {
+		return waitForEvent(m.events)
+	}
 	return tea.Batch(
 		m.spinner.Tick,
 		waitForEvent(m.events),
@@ -83,14 +115,24 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.question != nil {
+			return m.answer(msg)
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
 		}
 
 	case tea.WindowSizeMsg:
+		narrower := m.windowWidth > 0 && msg.Width < m.windowWidth
 		m.windowWidth = msg.Width
 		m.windowHeight = msg.Height
+		m.progress.Width = progressBarWidth(msg.Width)
+		if narrower {
+			// The terminal rewraps the lines already drawn at the old
+			// width, so redrawing them in place would leave fragments behind
+			return m, tea.ClearScreen
+		}
 		return m, nil
 
 	case spinner.TickMsg:
@@ -108,6 +150,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m, cmd = m.updateTask(msg)
 		return m, tea.Batch(cmd, waitForEvent(m.events))
 
+	case ConfirmEvent:
+		m.question = &msg
+		return m, nil
+
 	case DoneEvent:
 		m.done = true
 		return m, tea.Quit
@@ -125,6 +171,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// answer replies to the pending question with the key pressed. Only y
+// confirms; Ctrl+C declines and quits.
+func (m Model) answer(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	question := m.question
+	m.question = nil
+	if msg.String() == "ctrl+c" {
+		question.Reply <- false
+		return m, tea.Quit
+	}
+	question.Reply <- confirm.IsYes(msg.String())
+	return m, waitForEvent(m.events)
+}
+
 // updateTask updates a task based on a TaskEvent.
 func (m Model) updateTask(e TaskEvent) (Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -139,7 +198,9 @@ func (m Model) updateTask(e TaskEvent) (Model, tea.Cmd) {
 			}
 			if e.Progress > 0 {
 				m.tasks[i].Progress = e.Progress
-				cmd = m.progress.SetPercent(e.Progress)
+				if !m.reducedMotion {
+					cmd = m.progress.SetPercent(e.Progress)
+				}
 			}
 			if e.Error != nil {
 				m.tasks[i].Error = e.Error
@@ -157,6 +218,10 @@ func (m Model) updateTask(e TaskEvent) (Model, tea.Cmd) {
 // View renders the model.
 func (m Model) View() string {
 	var s string
+	frame := m.spinner.View()
+	if m.reducedMotion {
+		frame = stillSpinnerFrame
+	}
 
 	// Render all tasks
 	for _, task := range m.tasks {
@@ -170,15 +235,15 @@ func (m Model) View() string {
 				}
 				fallthrough
 			case StatusRunning:
-				s += fmt.Sprintf("  %s Authenticating...\n", spinnerStyle.Render(m.spinner.View()))
+				s += fmt.Sprintf("  %s Authenticating...\n", spinnerStyle.Render(frame))
 			case StatusError:
 				s += fmt.Sprintf("  %s Authenticating %s\n", iconError, errorStyle.Render(task.Error.Error()))
 			default:
-				s += task.View(m.spinner.View(), m.progress) + "\n"
+				s += task.View(frame, m.progressBar(task.Progress)) + "\n"
 			}
 			continue
 		}
-		s += task.View(m.spinner.View(), m.progress) + "\n"
+		s += task.View(frame, m.progressBar(task.Progress)) + "\n"
 	}
 
 	// Show rate limit warning if applicable
@@ -189,13 +254,34 @@ func (m Model) View() string {
 		}
 	}
 
+	if m.question != nil {
+		s += warnStyle.Render(fmt.Sprintf("\n  %s [y/N]\n", m.question.Question))
+	}
+
 	// Only show cancel hint while running
 	if !m.done {
 		s += footerStyle.Render("\n  Press Ctrl+C to cancel")
 	}
 	s += "\n"
 
-	return s
+	// Lines wider than the terminal would wrap and throw off the redraw.
+	// Height is left to the renderer, which keeps the bottom lines so a
+	// pending question stays visible.
+	return fitWindow(s, m.windowWidth, 0)
+}
+
+// progressBar renders the progress bar for a task percent done.
+func (m Model) progressBar(percent float64) string {
+	if m.reducedMotion {
+		return m.progress.ViewAs(percent)
+	}
+	return m.progress.View()
+}
+
+// progressBarWidth returns the width of the progress bar for a terminal
+// windowWidth columns wide.
+func progressBarWidth(windowWidth int) int {
+	return max(min(windowWidth-progressChrome, progressWidth), minProgressWidth)
 }
 
 // waitForEvent creates a command that waits for the next event.
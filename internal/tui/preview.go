@@ -0,0 +1,236 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/output"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// Previewer fetches what the detail pane shows of an item.
+type Previewer interface {
+	ItemPreview(ctx context.Context, owner, repo string, number int) (*model.Preview, error)
+}
+
+// minDetailHeight is the fewest list lines needed to show the detail pane
+// alongside at least a few rows.
+const minDetailHeight = 8
+
+// previewBodyLines caps how much of the description the pane shows.
+const previewBodyLines = 6
+
+// previewEntry is a preview fetched, or being fetched, for the detail pane.
+type previewEntry struct {
+	preview *model.Preview
+	err     error
+	loading bool
+}
+
+// previewLoadedMsg is sent when an item's preview has been fetched.
+type previewLoadedMsg struct {
+	key     string
+	preview *model.Preview
+	err     error
+}
+
+// toggleDetail shows or hides the detail pane.
+func (m ListModel) toggleDetail() (tea.Model, tea.Cmd) {
+	if m.previewer == nil {
+		m.statusMsg = "Preview not available"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+	m.showDetail = !m.showDetail
+	if item, ok := m.selectedItem(); ok && m.showDetail {
+		// Reopening the pane retries a preview that failed
+		if entry := m.previews[item.Key()]; entry != nil && entry.err != nil {
+			delete(m.previews, item.Key())
+		}
+	}
+	return m, nil
+}
+
+// loadPreview fetches the selected item's preview while the detail pane is
+// shown, unless it was already fetched. Previews are fetched once per run.
+func (m ListModel) loadPreview() tea.Cmd {
+	if !m.showDetail || m.previewer == nil {
+		return nil
+	}
+	item, ok := m.selectedItem()
+	if !ok {
+		return nil
+	}
+	key := item.Key()
+	if _, ok := m.previews[key]; ok {
+		return nil
+	}
+	ref, ok := refForItem(item)
+	if !ok {
+		return nil
+	}
+
+	m.previews[key] = &previewEntry{loading: true}
+	previewer := m.previewer
+	return func() tea.Msg {
+		preview, err := previewer.ItemPreview(context.Background(), ref.owner, ref.repo, ref.number)
+		return previewLoadedMsg{key: key, preview: preview, err: err}
+	}
+}
+
+// selectedItem returns the item under the cursor in the active pane.
+func (m ListModel) selectedItem() (triage.PrioritizedItem, bool) {
+	items := m.activeItems()
+	cursor := m.activeCursor()
+	if cursor < 0 || cursor >= len(items) {
+		return triage.PrioritizedItem{}, false
+	}
+	return items[cursor], true
+}
+
+// detailHeight is the number of lines the detail pane takes from the list
+// area, or 0 when it is hidden or the window is too short for it.
+func (m ListModel) detailHeight(available int) int {
+	if !m.showDetail || available < 2*minDetailHeight {
+		return 0
+	}
+	return available / 2
+}
+
+// renderDetailPane renders the selected item's preview in exactly height
+// lines, the first of which is a separator.
+func renderDetailPane(m ListModel, item triage.PrioritizedItem, width, height int) string {
+	lines := []string{renderSeparator(width)}
+	entry := m.previews[item.Key()]
+	switch {
+	case entry == nil && !hasRef(item):
+		lines = append(lines, listEmptyStyle.Render("No preview: item has no issue or PR number"))
+	case entry == nil || entry.loading:
+		lines = append(lines, listCacheStyle.Render("Loading preview..."))
+	case entry.err != nil:
+		lines = append(lines, listStatusStyle.Render(clip("Preview failed: "+format.Sanitize(entry.err.Error()), width)))
+	default:
+		for _, line := range previewLines(entry.preview, item.IsPR(), m.statusMarkers, time.Now()) {
+			lines = append(lines, clip(line, width))
+		}
+	}
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// previewLines lays out a preview as unstyled lines, apart from the
+// glyphs of the checks: people and status first, then the description,
+// then the most recent comments.
+func previewLines(p *model.Preview, isPR, markers bool, now time.Time) []string {
+	var lines []string
+	lines = append(lines, "Labels: "+listOrNone(p.Labels)+"   Assignees: "+listOrNone(p.Assignees))
+
+	if isPR {
+		review := "Review: " + orNone(p.ReviewDecision)
+		if len(p.Reviews) > 0 {
+			var reviews []string
+			for _, r := range p.Reviews {
+				reviews = append(reviews, r.Author+" "+strings.ReplaceAll(r.State, "_", " "))
+			}
+			review += " (" + strings.Join(reviews, ", ") + ")"
+		}
+		lines = append(lines, format.Sanitize(review))
+		lines = append(lines, "Checks: "+renderChecks(p.Checks, markers))
+	}
+
+	lines = append(lines, "")
+	body := previewText(p.Body)
+	if len(body) == 0 {
+		body = []string{listEmptyStyle.Render("No description provided.")}
+	}
+	if len(body) > previewBodyLines {
+		body = append(body[:previewBodyLines], "...")
+	}
+	lines = append(lines, body...)
+
+	if len(p.Comments) > 0 {
+		lines = append(lines, "", listHeaderStyle.Render("Recent comments"))
+		for _, c := range p.Comments {
+			first := ""
+			if text := previewText(c.Body); len(text) > 0 {
+				first = text[0]
+			}
+			lines = append(lines, fmt.Sprintf("  %s, %s: %s",
+				format.Sanitize(c.Author), format.FormatAgo(now.Sub(c.CreatedAt)), first))
+		}
+	}
+	return lines
+}
+
+// renderChecks lists check names, each after a glyph for its state.
+func renderChecks(checks []model.Check, markers bool) string {
+	if len(checks) == 0 {
+		return "none"
+	}
+	success, failure, pending := output.CISuccessGlyph, output.CIFailureGlyph, output.CIPendingGlyph
+	if markers {
+		success, failure, pending = output.CISuccessMarker, output.CIFailureMarker, output.CIPendingMarker
+	}
+	parts := make([]string, 0, len(checks))
+	for _, c := range checks {
+		var glyph string
+		switch c.State {
+		case model.CIStatusSuccess:
+			glyph = listCISuccessStyle.Render(success)
+		case model.CIStatusFailure:
+			glyph = listCIFailureStyle.Render(failure)
+		default:
+			glyph = listCIPendingStyle.Render(pending)
+		}
+		parts = append(parts, glyph+" "+format.Sanitize(c.Name))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// previewText returns the non-blank lines of Markdown text, trimmed and
+// sanitized for the terminal.
+func previewText(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, format.Sanitize(line))
+		}
+	}
+	return lines
+}
+
+// hasRef reports whether item has an issue or PR number to preview.
+func hasRef(item triage.PrioritizedItem) bool {
+	_, ok := refForItem(item)
+	return ok
+}
+
+// clip truncates s to width display cells.
+func clip(s string, width int) string {
+	clipped, _ := format.TruncateToWidth(s, width)
+	return clipped
+}
+
+// listOrNone joins values, sanitized, or returns "none" when empty.
+func listOrNone(values []string) string {
+	return orNone(format.Sanitize(strings.Join(values, ", ")))
+}
+
+// orNone returns s, or "none" when it is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/config"
+)
+
+// configPollInterval is how often the watched config files are checked
+// for changes.
+const configPollInterval = 2 * time.Second
+
+// configReload loads the config again when one of its files changes.
+type configReload struct {
+	load  func() (*config.Config, error)
+	paths []string
+}
+
+// WithConfigReload watches the config files at paths and, when one is
+// written, created, or removed, loads the config again with load and
+// applies the scoring weights, labels, Deps pane authors, and status
+// markers it sets to the running list. It needs WithConfig for the config
+// the list started with.
+func WithConfigReload(load func() (*config.Config, error), paths ...string) ListOption {
+	return func(m *ListModel) {
+		m.configReload = &configReload{load: load, paths: paths}
+		m.configStamp = configStamp(paths)
+	}
+}
+
+// configStamp describes the size and modification time of each file at
+// paths, so any change to them changes it.
+func configStamp(paths []string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&b, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+		} else {
+			fmt.Fprintf(&b, "%s:-;", path)
+		}
+	}
+	return b.String()
+}
+
+// configCheckedMsg carries the state of the watched config files.
+type configCheckedMsg struct {
+	stamp string
+}
+
+// watchConfig checks the watched config files after configPollInterval.
+func (m ListModel) watchConfig() tea.Cmd {
+	if m.configReload == nil || m.config == nil {
+		return nil
+	}
+	paths := m.configReload.paths
+	return tea.Tick(configPollInterval, func(time.Time) tea.Msg {
+		return configCheckedMsg{stamp: configStamp(paths)}
+	})
+}
+
+// reloadConfig loads the config again if its files changed since they
+// were last checked. While the weights editor is open the reload waits
+// until it closes, so the edits aren't replaced underneath it.
+func (m ListModel) reloadConfig(stamp string) (tea.Model, tea.Cmd) {
+	if stamp == m.configStamp || m.weightsEditor != nil {
+		return m, m.watchConfig()
+	}
+	m.configStamp = stamp
+
+	cfg, err := m.configReload.load()
+	if err != nil {
+		m.statusMsg = "Config not reloaded: " + err.Error()
+		m.statusTime = time.Now()
+		return m, tea.Batch(m.watchConfig(), clearStatusAfter(5*time.Second))
+	}
+
+	changed := m.applyConfig(cfg)
+	if len(changed) == 0 {
+		// Saving UI preferences rewrites the file without changing
+		// anything shown here
+		return m, m.watchConfig()
+	}
+	m.statusMsg = "Config reloaded: " + strings.Join(changed, ", ")
+	m.statusTime = time.Now()
+	return m, tea.Batch(m.watchConfig(), clearStatusAfter(3*time.Second))
+}
+
+// applyConfig replaces the list's config with cfg, applying the settings
+// that differ from the one it replaces, and returns what changed.
+func (m *ListModel) applyConfig(cfg *config.Config) []string {
+	previous := m.config
+	m.config = cfg
+
+	// Items can only be scored again with WithRescoring
+	var changed []string
+	weights := cfg.GetScoreWeights()
+	if m.rescoring && !reflect.DeepEqual(weights, previous.GetScoreWeights()) {
+		changed = append(changed, "weights")
+	}
+	if m.rescoring && !slices.Equal(cfg.GetQuickWinLabels(), previous.GetQuickWinLabels()) {
+		changed = append(changed, "quick win labels")
+	}
+	rescore := len(changed) > 0
+
+	if labels := cfg.GetBlockedLabels(); !slices.Equal(labels, previous.GetBlockedLabels()) {
+		m.blockedLabels = labels
+		changed = append(changed, "blocked labels")
+	}
+	if authors := cfg.GetDependencyAuthors(); !slices.Equal(authors, previous.GetDependencyAuthors()) {
+		m.dependencyAuthors = authorSet(authors)
+		changed = append(changed, "Deps pane authors")
+	}
+	if markers := cfg.GetAccessibility().StatusMarkers; markers != previous.GetAccessibility().StatusMarkers {
+		m.statusMarkers = markers
+		changed = append(changed, "status markers")
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	// Split the items into panes again, scored with the new weights
+	if rescore {
+		m.reloadedWeights = &weights
+		m.scoreWith(m.items, weights)
+	} else {
+		m.setItems(m.items)
+	}
+	return changed
+}
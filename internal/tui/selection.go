@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/confirm"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// markedItems returns the selected items shown in the active pane, in list
+// order. Selections only apply to active items, not the done or recently
+// resolved views.
+func (m ListModel) markedItems() []triage.PrioritizedItem {
+	if len(m.marked) == 0 || m.showDone || m.showTrash {
+		return nil
+	}
+	var marked []triage.PrioritizedItem
+	for _, item := range m.activeItems() {
+		if m.marked[item.Key()] {
+			marked = append(marked, item)
+		}
+	}
+	return marked
+}
+
+// toggleMark selects or deselects the current item, and starts the next
+// range from it
+func (m ListModel) toggleMark() (tea.Model, tea.Cmd) {
+	item, ok := m.selectedItem()
+	if !ok || m.showDone || m.showTrash {
+		return m, nil
+	}
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	key := item.Key()
+	if m.marked[key] {
+		delete(m.marked, key)
+	} else {
+		m.marked[key] = true
+	}
+	m.markAnchor = key
+	return m, nil
+}
+
+// markRange selects every item between the last item toggled with v and
+// the current item. Without one in the pane it selects the current item.
+func (m ListModel) markRange() (tea.Model, tea.Cmd) {
+	items := m.activeItems()
+	cursor := m.activeCursor()
+	if cursor >= len(items) || m.showDone || m.showTrash {
+		return m, nil
+	}
+
+	from := cursor
+	for i, item := range items {
+		if item.Key() == m.markAnchor {
+			from = i
+			break
+		}
+	}
+	lo, hi := min(from, cursor), max(from, cursor)
+
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	for _, item := range items[lo : hi+1] {
+		m.marked[item.Key()] = true
+	}
+	m.markAnchor = items[cursor].Key()
+	return m, nil
+}
+
+// clearMarks deselects every item
+func (m ListModel) clearMarks() (tea.Model, tea.Cmd) {
+	m.marked = nil
+	m.markAnchor = ""
+	return m, nil
+}
+
+// markSelectedDone marks the selected items as done in one write to the
+// resolved store. With syncRead, their notifications are marked read in a
+// single command, after confirmation when the mark_read_bulk policy asks
+// for it.
+func (m ListModel) markSelectedDone(items []triage.PrioritizedItem, syncRead bool) (tea.Model, tea.Cmd) {
+	updatedAt := make(map[string]time.Time, len(items))
+	for _, item := range items {
+		updatedAt[item.Key()] = item.UpdatedAt
+	}
+	if err := m.resolved.ResolveAll(updatedAt); err != nil {
+		m.statusMsg = "Error: " + err.Error()
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+
+	var threads []string
+	for _, item := range items {
+		if item.Reminder != nil && m.snoozes != nil {
+			if _, err := m.snoozes.Clear(item.Key()); err != nil {
+				m.statusMsg = "Error: " + err.Error()
+				m.statusTime = time.Now()
+				return m, clearStatusAfter(2 * time.Second)
+			}
+		}
+		if item.IsNotification() && item.Unread {
+			threads = append(threads, item.ID)
+		}
+		item.Resurfaced = false
+		item.Reminder = nil
+		m.moveToDone(item)
+		delete(m.marked, item.Key())
+	}
+
+	if cursor, n := m.activeCursor(), len(m.activeItems()); cursor >= n {
+		m.setActiveCursor(max(n-1, 0))
+	}
+
+	done := fmt.Sprintf("Marked %d as done", len(items))
+	m.statusMsg = done
+	m.statusTime = time.Now()
+
+	if !syncRead || m.readMarker == nil || len(threads) == 0 {
+		return m, clearStatusAfter(2 * time.Second)
+	}
+	return m.confirmThen(confirm.ActionMarkReadBulk, len(threads),
+		fmt.Sprintf("Mark %d notifications read on GitHub?", len(threads)),
+		done+"; not marked read",
+		m.markRead(len(items), threads))
+}
+
+// openSelected opens the selected items in the default browser
+func (m ListModel) openSelected(items []triage.PrioritizedItem) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, item := range items {
+		url := item.HTMLURL
+		if url == "" {
+			url = item.Repository.HTMLURL
+		}
+		if url == "" {
+			continue
+		}
+		m.recordInteraction(activity.Key(&item.Item), time.Now())
+		delete(m.marked, item.Key())
+		cmds = append(cmds, openURL(url))
+	}
+
+	m.statusMsg = fmt.Sprintf("Opened %d items", len(cmds))
+	if skipped := len(items) - len(cmds); skipped > 0 {
+		m.statusMsg += fmt.Sprintf("; %d without a URL", skipped)
+	}
+	m.statusTime = time.Now()
+	return m, tea.Batch(append(cmds, clearStatusAfter(2*time.Second))...)
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/clipboard"
+	"github.com/spiffcs/triage/internal/output"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// sharedMsg reports whether the share snippets of count items reached the
+// clipboard.
+type sharedMsg struct {
+	count int
+	err   error
+}
+
+// shareItems copies a Markdown snippet introducing the current item, or
+// each selected item, to the clipboard, for handing the work to a teammate
+// in chat. The snippet explains why the item matters from its score.
+func (m ListModel) shareItems() (tea.Model, tea.Cmd) {
+	items := m.markedItems()
+	if len(items) == 0 {
+		active := m.activeItems()
+		if len(active) == 0 {
+			return m, nil
+		}
+		cursor := m.activeCursor()
+		items = active[cursor : cursor+1]
+	}
+
+	engine := m.scoringEngine()
+	snippets := make([]string, len(items))
+	for i, item := range items {
+		snippets[i] = output.Share(engine.Explain(item.Item))
+		delete(m.marked, item.Key())
+	}
+	text := strings.Join(snippets, "\n")
+	count := len(items)
+	// Bubble Tea draws on stdout, so a terminal copy goes to stderr
+	return m, func() tea.Msg {
+		return sharedMsg{count: count, err: clipboard.Copy(text, os.Stderr)}
+	}
+}
+
+// sharedStatus is the status line reporting a share.
+func sharedStatus(msg sharedMsg) string {
+	if msg.err != nil {
+		return "Could not copy: " + msg.err.Error()
+	}
+	if msg.count == 1 {
+		return "Copied to clipboard"
+	}
+	return fmt.Sprintf("Copied %d items to clipboard", msg.count)
+}
+
+// scoringEngine returns an engine scoring like the one that scored the
+// items, for explaining their scores.
+func (m ListModel) scoringEngine() *triage.Engine {
+	weights := m.weights
+	quickWinLabels := config.DefaultQuickWinLabels()
+	if m.config != nil {
+		// The weights editor saves its changes here
+		weights = m.config.GetScoreWeights()
+		quickWinLabels = m.config.GetQuickWinLabels()
+	}
+	if m.reloadedWeights != nil {
+		weights = *m.reloadedWeights
+	}
+	return triage.NewEngine(m.currentUser, weights, quickWinLabels, triage.WithTeams(m.teams))
+}
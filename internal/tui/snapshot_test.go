@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/muesli/termenv"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/fake"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// Snapshot tests drive ListModel through a real tea.Program and compare the
+// final frame against golden files in testdata/. After an intentional
+// layout change, regenerate them with:
+//
+//	go test ./internal/tui -run TestSnapshot -update
+
+func init() {
+	// Render without color so golden files are stable across terminals.
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// snapshotItems returns items covering every pane. Ages are relative to
+// now and sit well inside their display buckets so frames stay stable.
+func snapshotItems() []triage.PrioritizedItem {
+	now := time.Now()
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+	day := 24 * time.Hour
+
+	pr := func(repo string, number int, title string, reason model.ItemReason, updated time.Time) model.Item {
+		return model.Item{
+			ID:         repo + "#" + title,
+			Reason:     reason,
+			UpdatedAt:  updated,
+			CreatedAt:  updated.Add(-2 * day),
+			Repository: model.Repository{FullName: repo},
+			Subject:    model.Subject{Title: title, Type: model.SubjectPullRequest},
+			Type:       model.ItemTypePullRequest,
+			Number:     number,
+			State:      "open",
+			Details:    &model.PRDetails{Additions: 40, Deletions: 10, ChangedFiles: 3, CIStatus: "success"},
+		}
+	}
+	issue := func(repo string, number int, title string, reason model.ItemReason, updated time.Time) model.Item {
+		return model.Item{
+			ID:         repo + "#" + title,
+			Reason:     reason,
+			UpdatedAt:  updated,
+			CreatedAt:  updated.Add(-2 * day),
+			Repository: model.Repository{FullName: repo},
+			Subject:    model.Subject{Title: title, Type: model.SubjectIssue},
+			Type:       model.ItemTypeIssue,
+			Number:     number,
+			State:      "open",
+			Details:    &model.IssueDetails{},
+		}
+	}
+
+	review := pr("acme/api", 12, "Add pagination to list endpoint", model.ReasonReviewRequested, ago(3*time.Hour))
+	review.Author = "hubot"
+	review.CommentCount = 4
+
+	mention := issue("acme/web", 40, "Login page crashes on Safari", model.ReasonMention, ago(26*time.Hour))
+	mention.Author = "monalisa"
+	mention.Labels = []string{"bug"}
+
+	assigned := issue("acme/web", 41, "Document the release process", model.ReasonAssign, ago(5*day))
+	assigned.Assignees = []string{"octocat"}
+	lastTouched := ago(8 * day)
+	assigned.LastInteractionAt = &lastTouched
+
+	blocked := pr("acme/api", 15, "Migrate storage layer to the v2 client with a much longer title than fits", model.ReasonAuthor, ago(2*day))
+	blocked.Author = "octocat"
+	blocked.Assignees = []string{"octocat"}
+	blocked.Labels = []string{"blocked"}
+
+	deps := pr("acme/api", 17, "Bump golang.org/x/net from 0.20.0 to 0.23.0", model.ReasonSubscribed, ago(6*time.Hour))
+	deps.Author = "dependabot[bot]"
+
+	orphaned := pr("acme/cli", 8, "Fix typo in help output", model.ReasonOrphaned, ago(10*day))
+	orphaned.Author = "newcomer"
+	orphaned.ConsecutiveAuthorComments = 3
+
+	return []triage.PrioritizedItem{
+		{Item: review, Score: 134, Priority: triage.PriorityUrgent, ActionNeeded: "Review PR"},
+		{Item: mention, Score: 101, Priority: triage.PriorityUrgent, ActionNeeded: "Respond to mention"},
+		{Item: assigned, Score: 70, Priority: triage.PriorityImportant, ActionNeeded: "Work on assigned item"},
+		{Item: blocked, Score: 90, Priority: triage.PriorityImportant, ActionNeeded: "Check PR status"},
+		{Item: deps, Score: 12, Priority: triage.PriorityQuickWin, ActionNeeded: "Review activity (subscribed)"},
+		{Item: orphaned, Score: 40, Priority: triage.PriorityNotable, ActionNeeded: "Review notification"},
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	tests := []struct {
+		name   string
+		items  []triage.PrioritizedItem
+		width  int
+		height int
+		keys   []string
+		opts   []ListOption
+	}{
+		{name: "assigned pane", items: snapshotItems(), width: 140, height: 24},
+		{name: "blocked pane", items: snapshotItems(), width: 140, height: 24, keys: []string{"tab"}},
+		{name: "queue pane", items: snapshotItems(), width: 140, height: 24, keys: []string{"3"}},
+		{name: "queue cursor moved", items: snapshotItems(), width: 140, height: 24, keys: []string{"3", "j"}},
+		{name: "queue sorted", items: snapshotItems(), width: 140, height: 24, keys: []string{"3", "s"}},
+		{name: "deps pane", items: snapshotItems(), width: 140, height: 24, keys: []string{"4"}},
+		{name: "orphaned pane", items: snapshotItems(), width: 140, height: 24, keys: []string{"5"}},
+		{name: "narrow queue", items: snapshotItems(), width: 72, height: 24, keys: []string{"3"}},
+		{name: "empty", width: 140, height: 24},
+		{name: "demo queue", items: demoItems(), width: 160, height: 30, keys: []string{"3"}},
+		{name: "queue status markers", items: snapshotItems(), width: 140, height: 24, keys: []string{"3"}, opts: []ListOption{WithStatusMarkers(true)}},
+		{name: "queue grouped", items: clusterItems(), width: 140, height: 24, keys: []string{"3", "c"}},
+		{name: "queue group expanded", items: clusterItems(), width: 140, height: 24, keys: []string{"3", "c", " "}},
+		{name: "recently resolved", items: snapshotItems(), width: 140, height: 24, keys: []string{"3", "d", "T"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]ListOption{
+				WithBlockedLabels([]string{"blocked"}),
+				WithDependencyAuthors(config.DefaultConfig().GetDependencyAuthors()),
+			}, tt.opts...)
+			m := NewListModel(tt.items, newTestStore(t), config.DefaultConfig().GetScoreWeights(), fake.DefaultUser, opts...)
+
+			tm := teatest.NewTestModel(t, m, teatest.WithInitialTermSize(tt.width, tt.height))
+			for _, key := range tt.keys {
+				tm.Send(keyMsg(key))
+			}
+			if err := tm.Quit(); err != nil {
+				t.Fatal(err)
+			}
+
+			final := tm.FinalModel(t, teatest.WithFinalTimeout(5*time.Second))
+			golden.RequireEqual(t, []byte(final.View()))
+		})
+	}
+}
+
+// clusterItems returns snapshotItems plus an issue that the review
+// request closes, so the two group together.
+func clusterItems() []triage.PrioritizedItem {
+	items := snapshotItems()
+	review := &items[0]
+	review.PRDetails().LinkedIssues = []string{"acme/api#11"}
+
+	bug := review.Item
+	bug.ID = "acme/api#11"
+	bug.Number = 11
+	bug.Type = model.ItemTypeIssue
+	bug.Reason = model.ReasonSubscribed
+	bug.Author = "monalisa"
+	bug.CommentCount = 0
+	bug.Subject = model.Subject{Title: "List endpoint returns every row at once", Type: model.SubjectIssue}
+	bug.Details = &model.IssueDetails{}
+	return append(items, triage.PrioritizedItem{Item: bug, Score: 20, Priority: triage.PriorityFYI, ActionNeeded: "Review activity (subscribed)"})
+}
+
+// demoItems returns the items shown by `triage demo --seed 1 -n 20`.
+func demoItems() []triage.PrioritizedItem {
+	weights := config.DefaultConfig().GetScoreWeights()
+	engine := triage.NewEngine(fake.DefaultUser, weights, config.DefaultConfig().GetQuickWinLabels())
+	return engine.Prioritize(fake.Items(fake.Options{Seed: 1, Count: 20}))
+}
+
+// keyMsg builds the tea.KeyMsg a terminal would deliver for key.
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+}
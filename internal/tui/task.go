@@ -1,10 +1,6 @@
 package tui
 
-import (
-	"fmt"
-
-	"github.com/charmbracelet/bubbles/progress"
-)
+import "fmt"
 
 // Task represents a single task in the TUI progress display.
 type Task struct {
@@ -26,8 +22,9 @@ func NewTask(id TaskID, name string) Task {
 	}
 }
 
-// View renders the task as a string.
-func (t Task) View(spinnerFrame string, prog progress.Model) string {
+// View renders the task as a string, with bar as its progress bar while
+// it runs.
+func (t Task) View(spinnerFrame, bar string) string {
 	icon := StatusIcon(t.Status, spinnerFrame)
 
 	var name string
@@ -41,7 +38,6 @@ func (t Task) View(spinnerFrame string, prog progress.Model) string {
 
 	// Add progress bar if we have progress
 	if t.Status == StatusRunning && t.Progress > 0 {
-		bar := prog.View()
 		percent := int(t.Progress * 100)
 		line += fmt.Sprintf(" %s %d%%", bar, percent)
 		if t.Message != "" {
@@ -4,6 +4,8 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/spiffcs/triage/config"
 	"github.com/spiffcs/triage/internal/resolved"
 	"github.com/spiffcs/triage/internal/triage"
@@ -14,7 +16,7 @@ import (
 func Run(events <-chan Event, opts ...ModelOption) error {
 	model := NewModel(events, opts...)
 	// Don't use alt screen - render inline
-	p := tea.NewProgram(model)
+	p := tea.NewProgram(model, redrawOptions(model.reducedMotion)...)
 	_, err := p.Run()
 	return err
 }
@@ -104,7 +106,41 @@ func WithError(err error) TaskEventOption {
 // RunListUI starts the interactive list UI for triaging items
 func RunListUI(items []triage.PrioritizedItem, store *resolved.Store, weights config.ScoreWeights, currentUser string, opts ...ListOption) error {
 	model := NewListModel(items, store, weights, currentUser, opts...)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(model, append(redrawOptions(model.minimalRedraw), tea.WithAltScreen())...)
 	_, err := p.Run()
 	return err
 }
+
+// Snapshot renders the list UI's first frame at width columns and height
+// rows without starting it, for sharing the list where it can't run
+// interactively. The frame keeps the TUI's colors as ANSI escapes unless
+// color is false.
+func Snapshot(items []triage.PrioritizedItem, store *resolved.Store, weights config.ScoreWeights, currentUser string, width, height int, color bool, opts ...ListOption) string {
+	// Output redirected to a file isn't a terminal, so pick the colors here
+	// instead of detecting them
+	profile := termenv.Ascii
+	if color {
+		profile = termenv.TrueColor
+	}
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(profile)
+	defer lipgloss.SetColorProfile(prev)
+
+	model := NewListModel(items, store, weights, currentUser, opts...)
+	model.windowWidth = width
+	model.windowHeight = height
+	return model.View()
+}
+
+// minimalRedrawFPS is the frame rate with minimal redraw on. Changes
+// between frames are drawn together, so a burst of progress events or a
+// held key costs one redraw.
+const minimalRedrawFPS = 10
+
+// redrawOptions returns the program options for minimal redraw.
+func redrawOptions(minimal bool) []tea.ProgramOption {
+	if !minimal {
+		return nil
+	}
+	return []tea.ProgramOption{tea.WithFPS(minimalRedrawFPS)}
+}
@@ -2,7 +2,15 @@ package tui
 
 import (
 	"errors"
+	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/fake"
+	"github.com/spiffcs/triage/internal/format"
 )
 
 func TestTaskID(t *testing.T) {
@@ -72,6 +80,49 @@ func TestDoneEvent(t *testing.T) {
 	var _ Event = event
 }
 
+func TestConfirmEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      tea.KeyMsg
+		want     bool
+		wantQuit bool
+	}{
+		{"yes", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, true, false},
+		{"anything else declines", tea.KeyMsg{Type: tea.KeyEnter}, false, false},
+		{"ctrl+c declines and quits", tea.KeyMsg{Type: tea.KeyCtrlC}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := make(chan bool, 1)
+			events := make(chan Event)
+			close(events)
+			var m tea.Model = NewModel(events)
+			m, _ = m.Update(ConfirmEvent{Question: "This will fetch ~4,800 notifications. Continue?", Reply: reply})
+			if view := m.View(); !strings.Contains(view, "~4,800 notifications. Continue? [y/N]") {
+				t.Errorf("View() = %q, want the question", view)
+			}
+
+			m, cmd := m.Update(tt.key)
+			if got := <-reply; got != tt.want {
+				t.Errorf("reply = %v, want %v", got, tt.want)
+			}
+			if strings.Contains(m.View(), "Continue?") {
+				t.Error("question still shown after it was answered")
+			}
+			if quit := cmd != nil && isQuit(cmd()); quit != tt.wantQuit {
+				t.Errorf("quit = %v, want %v", quit, tt.wantQuit)
+			}
+		})
+	}
+}
+
+// isQuit reports whether msg is the message tea.Quit produces.
+func isQuit(msg tea.Msg) bool {
+	_, ok := msg.(tea.QuitMsg)
+	return ok
+}
+
 func TestSendEvent(t *testing.T) {
 	ch := make(chan Event, 1)
 
@@ -167,3 +218,95 @@ func TestStatusIcon(t *testing.T) {
 		}
 	}
 }
+
+func TestModelResize(t *testing.T) {
+	events := make(chan Event)
+	close(events)
+	var m tea.Model = NewModel(events)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 24})
+	m, _ = m.Update(TaskEvent{Task: TaskEnrich, Status: StatusRunning, Progress: 0.5, Message: strings.Repeat("acme/api#1 ", 10)})
+	if got := m.(Model).progress.Width; got != progressWidth {
+		t.Errorf("progress width at 120 columns = %d, want %d", got, progressWidth)
+	}
+
+	tests := []struct {
+		width, height int
+		wantBar       int
+		wantClear     bool
+	}{
+		{40, 10, 10, true},
+		{20, 5, minProgressWidth, true},
+		{1, 1, minProgressWidth, true},
+		{80, 24, progressWidth, false},
+	}
+	for _, tt := range tests {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.WindowSizeMsg{Width: tt.width, Height: tt.height})
+		if got := m.(Model).progress.Width; got != tt.wantBar {
+			t.Errorf("%dx%d: progress width = %d, want %d", tt.width, tt.height, got, tt.wantBar)
+		}
+		if cleared := cmd != nil; cleared != tt.wantClear {
+			t.Errorf("%dx%d: cleared screen = %v, want %v", tt.width, tt.height, cleared, tt.wantClear)
+		}
+		for _, line := range strings.Split(m.View(), "\n") {
+			if w := format.DisplayWidth(line); w > tt.width {
+				t.Errorf("%dx%d: line %q is %d columns wide", tt.width, tt.height, line, w)
+			}
+		}
+	}
+}
+
+func TestModelReducedMotion(t *testing.T) {
+	newModel := func(reduced bool) tea.Model {
+		events := make(chan Event)
+		close(events)
+		return NewModel(events, WithReducedMotion(reduced))
+	}
+
+	// Only the animated display ticks a spinner
+	if _, ticks := newModel(false).Init()().(tea.BatchMsg); !ticks {
+		t.Error("Init() without reduced motion doesn't start the spinner")
+	}
+	if msg := newModel(true).Init()(); msg != (doneMsg{}) {
+		t.Errorf("Init() with reduced motion = %T, want only the wait for events", msg)
+	}
+
+	m := newModel(true)
+	m, _ = m.Update(TaskEvent{Task: TaskEnrich, Status: StatusRunning, Progress: 0.5})
+	view := m.View()
+	if !strings.Contains(view, stillSpinnerFrame) {
+		t.Errorf("View() = %q, want the still spinner frame", view)
+	}
+	// The bar shows the new value at once rather than animating toward it
+	if want := m.(Model).progress.ViewAs(0.5); !strings.Contains(view, want) {
+		t.Errorf("View() = %q, want the bar at 50%%", view)
+	}
+}
+
+func TestSnapshotFrame(t *testing.T) {
+	weights := config.DefaultConfig().GetScoreWeights()
+	items := snapshotItems()
+
+	plain := Snapshot(items, newTestStore(t), weights, fake.DefaultUser, 60, 12, false)
+	if strings.Contains(plain, "\x1b[") {
+		t.Errorf("plain Snapshot() has escape codes: %q", plain)
+	}
+	lines := strings.Split(plain, "\n")
+	if len(lines) > 12 {
+		t.Errorf("Snapshot() has %d lines, want at most the 12 asked for", len(lines))
+	}
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w > 60 {
+			t.Errorf("Snapshot() line %q is %d columns, want at most 60", line, w)
+		}
+	}
+
+	colored := Snapshot(items, newTestStore(t), weights, fake.DefaultUser, 60, 12, true)
+	if !strings.Contains(colored, "\x1b[") {
+		t.Error("ansi Snapshot() has no escape codes")
+	}
+	// The caller's color profile is left as it was
+	if got := lipgloss.ColorProfile(); got != termenv.Ascii {
+		t.Errorf("color profile after Snapshot() = %v, want Ascii", got)
+	}
+}
@@ -0,0 +1,326 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/format"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// weightPreviewRows is the number of top-ranked items the weights editor
+// previews.
+const weightPreviewRows = 10
+
+// weightsChromeLines is the number of lines the weights editor uses besides
+// its settings and preview rows.
+const weightsChromeLines = 7
+
+// weightSetting is a value the weights editor can change. Numbers change
+// by step; settings without a step are switched on and off.
+type weightSetting struct {
+	key    string // as written in the config file
+	step   int
+	number func(*config.ScoreWeights) *int
+	setNum func(*config.Config, int)
+	flag   func(*config.ScoreWeights) *bool
+	setOn  func(*config.Config, bool)
+}
+
+// baseScore edits a base_scores value.
+func baseScore(key string, number func(*config.ScoreWeights) *int, field func(*config.BaseScoreOverrides) **int) weightSetting {
+	return weightSetting{
+		key:    "base_scores." + key,
+		step:   5,
+		number: number,
+		setNum: func(c *config.Config, v int) {
+			if c.BaseScores == nil {
+				c.BaseScores = &config.BaseScoreOverrides{}
+			}
+			*field(c.BaseScores) = &v
+		},
+	}
+}
+
+// scoring edits a scoring value.
+func scoring(key string, step int, number func(*config.ScoreWeights) *int, field func(*config.ScoringOverrides) **int) weightSetting {
+	return weightSetting{
+		key:    "scoring." + key,
+		step:   step,
+		number: number,
+		setNum: func(c *config.Config, v int) {
+			if c.Scoring == nil {
+				c.Scoring = &config.ScoringOverrides{}
+			}
+			*field(c.Scoring) = &v
+		},
+	}
+}
+
+// urgency edits an urgency switch.
+func urgency(key string, flag func(*config.ScoreWeights) *bool, field func(*config.UrgencyOverrides) **bool) weightSetting {
+	return weightSetting{
+		key:  "urgency." + key,
+		flag: flag,
+		setOn: func(c *config.Config, on bool) {
+			if c.Urgency == nil {
+				c.Urgency = &config.UrgencyOverrides{}
+			}
+			*field(c.Urgency) = &on
+		},
+	}
+}
+
+// weightSettings are the settings the weights editor offers, in order.
+var weightSettings = []weightSetting{
+	baseScore("review_requested", func(w *config.ScoreWeights) *int { return &w.ReviewRequested }, func(o *config.BaseScoreOverrides) **int { return &o.ReviewRequested }),
+	baseScore("team_review_requested", func(w *config.ScoreWeights) *int { return &w.TeamReviewRequested }, func(o *config.BaseScoreOverrides) **int { return &o.TeamReviewRequested }),
+	baseScore("mention", func(w *config.ScoreWeights) *int { return &w.Mention }, func(o *config.BaseScoreOverrides) **int { return &o.Mention }),
+	baseScore("team_mention", func(w *config.ScoreWeights) *int { return &w.TeamMention }, func(o *config.BaseScoreOverrides) **int { return &o.TeamMention }),
+	baseScore("author", func(w *config.ScoreWeights) *int { return &w.Author }, func(o *config.BaseScoreOverrides) **int { return &o.Author }),
+	baseScore("assign", func(w *config.ScoreWeights) *int { return &w.Assign }, func(o *config.BaseScoreOverrides) **int { return &o.Assign }),
+	baseScore("comment", func(w *config.ScoreWeights) *int { return &w.Comment }, func(o *config.BaseScoreOverrides) **int { return &o.Comment }),
+	baseScore("state_change", func(w *config.ScoreWeights) *int { return &w.StateChange }, func(o *config.BaseScoreOverrides) **int { return &o.StateChange }),
+	baseScore("subscribed", func(w *config.ScoreWeights) *int { return &w.Subscribed }, func(o *config.BaseScoreOverrides) **int { return &o.Subscribed }),
+	baseScore("ci_activity", func(w *config.ScoreWeights) *int { return &w.CIActivity }, func(o *config.BaseScoreOverrides) **int { return &o.CIActivity }),
+	scoring("old_unread_bonus", 1, func(w *config.ScoreWeights) *int { return &w.OldUnreadBonus }, func(o *config.ScoringOverrides) **int { return &o.OldUnreadBonus }),
+	scoring("max_age_bonus", 5, func(w *config.ScoreWeights) *int { return &w.MaxAgeBonus }, func(o *config.ScoringOverrides) **int { return &o.MaxAgeBonus }),
+	scoring("hot_topic_bonus", 5, func(w *config.ScoreWeights) *int { return &w.HotTopicBonus }, func(o *config.ScoringOverrides) **int { return &o.HotTopicBonus }),
+	scoring("hot_topic_threshold", 1, func(w *config.ScoreWeights) *int { return &w.HotTopicThreshold }, func(o *config.ScoringOverrides) **int { return &o.HotTopicThreshold }),
+	scoring("low_hanging_bonus", 5, func(w *config.ScoreWeights) *int { return &w.LowHangingBonus }, func(o *config.ScoringOverrides) **int { return &o.LowHangingBonus }),
+	scoring("open_state_bonus", 5, func(w *config.ScoreWeights) *int { return &w.OpenStateBonus }, func(o *config.ScoringOverrides) **int { return &o.OpenStateBonus }),
+	scoring("closed_state_penalty", 5, func(w *config.ScoreWeights) *int { return &w.ClosedStatePenalty }, func(o *config.ScoringOverrides) **int { return &o.ClosedStatePenalty }),
+	scoring("fyi_promotion_threshold", 5, func(w *config.ScoreWeights) *int { return &w.FYIPromotionThreshold }, func(o *config.ScoringOverrides) **int { return &o.FYIPromotionThreshold }),
+	scoring("notable_promotion_threshold", 5, func(w *config.ScoreWeights) *int { return &w.NotablePromotionThreshold }, func(o *config.ScoringOverrides) **int { return &o.NotablePromotionThreshold }),
+	scoring("important_promotion_threshold", 5, func(w *config.ScoreWeights) *int { return &w.ImportantPromotionThreshold }, func(o *config.ScoringOverrides) **int { return &o.ImportantPromotionThreshold }),
+	urgency("review_requested", func(w *config.ScoreWeights) *bool { return &w.ReviewRequestedIsUrgent }, func(o *config.UrgencyOverrides) **bool { return &o.ReviewRequested }),
+	urgency("mention", func(w *config.ScoreWeights) *bool { return &w.MentionIsUrgent }, func(o *config.UrgencyOverrides) **bool { return &o.Mention }),
+	urgency("approved_mergeable_pr", func(w *config.ScoreWeights) *bool { return &w.ApprovedMergeablePRIsUrgent }, func(o *config.UrgencyOverrides) **bool { return &o.ApprovedMergeablePR }),
+	urgency("changes_requested_pr", func(w *config.ScoreWeights) *bool { return &w.ChangesRequestedPRIsUrgent }, func(o *config.UrgencyOverrides) **bool { return &o.ChangesRequestedPR }),
+}
+
+// weightsEditor is the state of the scoring weights editor.
+type weightsEditor struct {
+	cursor   int
+	edits    *config.Config // the values changed so far
+	changed  map[string]bool
+	weights  config.ScoreWeights      // the weights with the edits applied
+	original []triage.PrioritizedItem // the items as scored before editing
+	ranks    map[string]int           // each item's rank before editing, by key
+}
+
+// openWeightsEditor opens the scoring weights editor.
+func (m ListModel) openWeightsEditor() (tea.Model, tea.Cmd) {
+	if m.config == nil || !m.rescoring {
+		m.statusMsg = "Weights editor not available"
+		m.statusTime = time.Now()
+		return m, clearStatusAfter(2 * time.Second)
+	}
+	ranked := slices.Clone(m.items)
+	triage.SortByPriority(ranked)
+	ranks := make(map[string]int, len(ranked))
+	for i, item := range ranked {
+		ranks[item.Key()] = i + 1
+	}
+	m.weightsEditor = &weightsEditor{
+		edits:    &config.Config{},
+		changed:  make(map[string]bool),
+		weights:  m.config.GetScoreWeights(),
+		original: m.items,
+		ranks:    ranks,
+	}
+	return m, nil
+}
+
+// handleWeightsKey handles a key while the weights editor is open. Every
+// change scores the items again at once; enter saves the changes to the
+// config file and esc drops them.
+func (m ListModel) handleWeightsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	editor := *m.weightsEditor
+	setting := weightSettings[editor.cursor]
+
+	switch msg.String() {
+	case "esc", "q":
+		m.weightsEditor = nil
+		m.setItems(editor.original)
+		m.hotTopicThreshold = m.config.GetScoreWeights().HotTopicThreshold
+		if len(editor.changed) > 0 {
+			m.statusMsg = "Weights not saved"
+			m.statusTime = time.Now()
+			return m, clearStatusAfter(2 * time.Second)
+		}
+		return m, nil
+
+	case "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+
+	case "enter":
+		m.weightsEditor = nil
+		if len(editor.changed) == 0 {
+			return m, nil
+		}
+		applied := m.config.WithScoring(editor.edits)
+		m.config.BaseScores, m.config.Scoring, m.config.Urgency = applied.BaseScores, applied.Scoring, applied.Urgency
+		edits := editor.edits
+		return m, func() tea.Msg {
+			path, err := edits.SaveScoring()
+			return weightsSavedMsg{path: path, err: err}
+		}
+
+	case "j", "down":
+		editor.cursor = min(editor.cursor+1, len(weightSettings)-1)
+
+	case "k", "up":
+		editor.cursor = max(editor.cursor-1, 0)
+
+	case "l", "right", "+", "=":
+		editor = m.changeWeight(editor, setting, setting.step)
+
+	case "h", "left", "-":
+		editor = m.changeWeight(editor, setting, -setting.step)
+
+	case " ":
+		if setting.flag != nil {
+			editor = m.changeWeight(editor, setting, 0)
+		}
+	}
+
+	m.weightsEditor = &editor
+	return m, nil
+}
+
+// changeWeight changes setting by step, or switches it when it has no
+// step, and scores the items again with the result.
+func (m *ListModel) changeWeight(editor weightsEditor, setting weightSetting, step int) weightsEditor {
+	// Copy what changes, so earlier copies of the model keep their values
+	editor.edits = cloneScoring(editor.edits)
+	editor.changed = maps.Clone(editor.changed)
+
+	if setting.flag != nil {
+		setting.setOn(editor.edits, !*setting.flag(&editor.weights))
+	} else {
+		setting.setNum(editor.edits, *setting.number(&editor.weights)+step)
+	}
+	editor.changed[setting.key] = true
+	editor.weights = m.config.WithScoring(editor.edits).GetScoreWeights()
+	m.rescore(editor)
+	return editor
+}
+
+// rescore scores the items the editor started from again with its weights.
+func (m *ListModel) rescore(editor weightsEditor) {
+	m.scoreWith(editor.original, editor.weights)
+}
+
+// scoreWith lists items scored again with weights and the config's quick
+// win labels.
+func (m *ListModel) scoreWith(items []triage.PrioritizedItem, weights config.ScoreWeights) {
+	quickWinLabels := m.config.GetQuickWinLabels()
+	engine := triage.NewEngine(m.currentUser, weights, quickWinLabels, triage.WithTeams(m.teams))
+	m.setItems(engine.Rescore(items))
+	m.hotTopicThreshold = weights.HotTopicThreshold
+	m.prSizeXS = weights.PRSizeXS
+	m.prSizeS = weights.PRSizeS
+	m.prSizeM = weights.PRSizeM
+	m.prSizeL = weights.PRSizeL
+}
+
+// cloneScoring copies the scoring sections of edits.
+func cloneScoring(edits *config.Config) *config.Config {
+	clone := &config.Config{}
+	if edits.BaseScores != nil {
+		bs := *edits.BaseScores
+		clone.BaseScores = &bs
+	}
+	if edits.Scoring != nil {
+		s := *edits.Scoring
+		clone.Scoring = &s
+	}
+	if edits.Urgency != nil {
+		u := *edits.Urgency
+		clone.Urgency = &u
+	}
+	return clone
+}
+
+// weightsSavedMsg is sent after the edited weights were written to the
+// config file at path.
+type weightsSavedMsg struct {
+	path string
+	err  error
+}
+
+// renderWeightsEditor renders the weights editor: the settings, and how
+// the top items rank with them against how they ranked before.
+func renderWeightsEditor(m ListModel) string {
+	editor := m.weightsEditor
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(listHeaderStyle.Render("Scoring weights"))
+	b.WriteString("\n\n")
+
+	// The settings scroll with the cursor when the terminal is too short
+	// for all of them and a line of the ranking preview
+	start, end := calculateScrollWindow(editor.cursor, len(weightSettings), max(m.windowHeight-weightsChromeLines-1, 1))
+	for i, s := range weightSettings[start:end] {
+		i += start
+		var value string
+		if s.flag != nil {
+			value = "off"
+			if *s.flag(&editor.weights) {
+				value = "on"
+			}
+		} else {
+			value = strconv.Itoa(*s.number(&editor.weights))
+		}
+		marker := " "
+		if editor.changed[s.key] {
+			marker = "*"
+		}
+		line := fmt.Sprintf("%-38s %6s %s", s.key, value, marker)
+		if i == editor.cursor {
+			b.WriteString(listSelectedStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(listHeaderStyle.Render("Ranking preview"))
+	b.WriteString("\n")
+	ranked := slices.Clone(m.items)
+	triage.SortByPriority(ranked)
+	// The preview gives way to the settings on short terminals
+	rows := min(len(ranked), weightPreviewRows, max(m.windowHeight-(end-start)-weightsChromeLines, 1))
+	for i, item := range ranked[:rows] {
+		title, _ := format.TruncateToWidth(format.Sanitize(item.Subject.Title), max(m.windowWidth-40, 10))
+		fmt.Fprintf(&b, "  %3d %-4s %-10s %5d  %s\n", i+1, rankShift(editor.ranks[item.Key()], i+1), item.Priority.Display(), item.Score, title)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(listHelpStyle.Render("j/k: nav   h/l or -/+: change   space: on/off   enter: save to config   esc: cancel"))
+	return b.String()
+}
+
+// rankShift describes how far an item moved from rank before to rank now.
+func rankShift(before, now int) string {
+	switch {
+	case before == 0:
+		return "new"
+	case now < before:
+		return fmt.Sprintf("↑%d", before-now)
+	case now > before:
+		return fmt.Sprintf("↓%d", now-before)
+	default:
+		return "="
+	}
+}
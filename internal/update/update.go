@@ -0,0 +1,405 @@
+// Package update checks GitHub releases for newer versions of triage and
+// replaces the running binary in place. It mirrors install.sh: release
+// archives are verified against checksums.txt, and checksums.txt can
+// optionally be verified with cosign before it is trusted.
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	owner = "spiffcs"
+	repo  = "triage"
+
+	defaultAPIURL      = "https://api.github.com"
+	defaultDownloadURL = "https://github.com/" + owner + "/" + repo + "/releases/download"
+
+	checksumsFile = "checksums.txt"
+	bundleFile    = checksumsFile + ".sigstore.json"
+	binaryName    = "triage"
+
+	// maxArchiveSize bounds downloads so a bad response cannot exhaust memory.
+	maxArchiveSize = 200 << 20
+)
+
+// ErrUnsupportedPlatform is returned when no release archive is published
+// for the current OS and architecture.
+var ErrUnsupportedPlatform = errors.New("no release published for this platform")
+
+// Release is a published triage release.
+type Release struct {
+	Tag     string // e.g. "v1.2.3"
+	Version string // Tag without the leading "v"
+	URL     string // release page
+}
+
+// Updater finds and installs triage releases.
+type Updater struct {
+	httpClient  *http.Client
+	apiURL      string
+	downloadURL string
+	goos        string
+	goarch      string
+	cosign      string
+	verify      bool
+}
+
+// Option configures an Updater.
+type Option func(*Updater)
+
+// WithHTTPClient sets the HTTP client used for all requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(u *Updater) {
+		u.httpClient = c
+	}
+}
+
+// WithBaseURLs overrides the GitHub API and release download locations.
+// Intended for tests.
+func WithBaseURLs(apiURL, downloadURL string) Option {
+	return func(u *Updater) {
+		u.apiURL = strings.TrimSuffix(apiURL, "/")
+		u.downloadURL = strings.TrimSuffix(downloadURL, "/")
+	}
+}
+
+// WithPlatform overrides the OS and architecture to download for.
+func WithPlatform(goos, goarch string) Option {
+	return func(u *Updater) {
+		u.goos = goos
+		u.goarch = goarch
+	}
+}
+
+// WithSignatureVerification requires checksums.txt to be verified with
+// cosign before any archive is trusted. cosignPath defaults to "cosign".
+func WithSignatureVerification(cosignPath string) Option {
+	return func(u *Updater) {
+		u.verify = true
+		if cosignPath != "" {
+			u.cosign = cosignPath
+		}
+	}
+}
+
+// New creates an Updater for the current platform.
+func New(opts ...Option) *Updater {
+	u := &Updater{
+		httpClient:  &http.Client{Timeout: 2 * time.Minute},
+		apiURL:      defaultAPIURL,
+		downloadURL: defaultDownloadURL,
+		goos:        runtime.GOOS,
+		goarch:      runtime.GOARCH,
+		cosign:      "cosign",
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// Latest returns the most recent published release.
+func (u *Updater) Latest(ctx context.Context) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", u.apiURL, owner, repo)
+	data, err := u.get(ctx, url, 1<<20, "application/vnd.github+json")
+	if err != nil {
+		return nil, fmt.Errorf("checking latest release: %w", err)
+	}
+
+	var body struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("parsing latest release: %w", err)
+	}
+	if body.TagName == "" {
+		return nil, errors.New("latest release has no tag")
+	}
+	return &Release{
+		Tag:     body.TagName,
+		Version: strings.TrimPrefix(body.TagName, "v"),
+		URL:     body.HTMLURL,
+	}, nil
+}
+
+// ArchiveName returns the release archive name for a version and platform,
+// matching the goreleaser name template.
+func ArchiveName(version, goos, goarch string) string {
+	return fmt.Sprintf("%s_%s_%s_%s.tar.gz", binaryName, strings.TrimPrefix(version, "v"), goos, goarch)
+}
+
+// Download fetches the release archive for the configured platform,
+// verifies it, and returns the extracted triage binary.
+func (u *Updater) Download(ctx context.Context, rel *Release) ([]byte, error) {
+	if (u.goos != "linux" && u.goos != "darwin") || (u.goarch != "amd64" && u.goarch != "arm64") {
+		return nil, fmt.Errorf("%w: %s/%s", ErrUnsupportedPlatform, u.goos, u.goarch)
+	}
+
+	base := u.downloadURL + "/" + rel.Tag
+	checksums, err := u.get(ctx, base+"/"+checksumsFile, 1<<20, "")
+	if err != nil {
+		return nil, fmt.Errorf("downloading checksums: %w", err)
+	}
+
+	if u.verify {
+		bundle, err := u.get(ctx, base+"/"+bundleFile, 1<<20, "")
+		if err != nil {
+			return nil, fmt.Errorf("downloading signature bundle: %w", err)
+		}
+		if err := u.verifySignature(ctx, checksums, bundle); err != nil {
+			return nil, err
+		}
+	}
+
+	name := ArchiveName(rel.Version, u.goos, u.goarch)
+	want, err := findChecksum(checksums, name)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := u.get(ctx, base+"/"+name, maxArchiveSize, "")
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", name, err)
+	}
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+
+	return extractBinary(archive)
+}
+
+// verifySignature runs cosign verify-blob against checksums.txt using the
+// same identity constraints as install.sh.
+func (u *Updater) verifySignature(ctx context.Context, checksums, bundle []byte) error {
+	cosign, err := exec.LookPath(u.cosign)
+	if err != nil {
+		return fmt.Errorf("cosign not found (required for signature verification): %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "triage-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	checksumsPath := filepath.Join(dir, checksumsFile)
+	bundlePath := filepath.Join(dir, bundleFile)
+	if err := os.WriteFile(checksumsPath, checksums, 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(bundlePath, bundle, 0o600); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, cosign, "verify-blob", checksumsPath,
+		"--bundle", bundlePath,
+		"--certificate-identity-regexp", fmt.Sprintf("^https://github.com/%s/%s/.*", owner, repo),
+		"--certificate-oidc-issuer", "https://token.actions.githubusercontent.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("signature verification failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (u *Updater) get(ctx context.Context, url string, limit int64, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("GET %s: response larger than %d bytes", url, limit)
+	}
+	return data, nil
+}
+
+// findChecksum returns the sha256 listed for name in a checksums.txt file.
+func findChecksum(checksums []byte, name string) (string, error) {
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s not listed in %s", name, checksumsFile)
+}
+
+// extractBinary returns the triage binary from a release tar.gz.
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s binary not found in archive", binaryName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || filepath.Base(hdr.Name) != binaryName {
+			continue
+		}
+		return io.ReadAll(io.LimitReader(tr, maxArchiveSize))
+	}
+}
+
+// Replace atomically replaces the executable at path with binary. The new
+// file is written next to the old one and renamed over it, so an
+// interrupted update never leaves a partial binary behind.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s (try running with sufficient permissions): %w", filepath.Dir(path), err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op after a successful rename
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// PackageManager reports which package manager appears to own the binary
+// at path ("homebrew" or "scoop"), or "" if none. Binaries owned by a
+// package manager should be updated through it instead.
+func PackageManager(path string) string {
+	p := strings.ReplaceAll(filepath.ToSlash(path), `\`, "/")
+	lower := strings.ToLower(p)
+	switch {
+	case strings.Contains(p, "/Cellar/"), strings.Contains(p, "/homebrew/"), strings.Contains(p, "/.linuxbrew/"):
+		return "homebrew"
+	case strings.Contains(lower, "/scoop/apps/"), strings.Contains(lower, "/scoop/shims/"):
+		return "scoop"
+	default:
+		return ""
+	}
+}
+
+// UpgradeHint returns the command that updates triage through a package
+// manager returned by PackageManager.
+func UpgradeHint(manager string) string {
+	switch manager {
+	case "homebrew":
+		return "brew upgrade triage"
+	case "scoop":
+		return "scoop update triage"
+	default:
+		return ""
+	}
+}
+
+// IsDevelopment reports whether version is an unreleased build that cannot
+// be compared against releases.
+func IsDevelopment(version string) bool {
+	_, err := parseVersion(version)
+	return err != nil
+}
+
+// IsNewer reports whether latest is a newer release than current. Versions
+// that cannot be parsed are never considered newer.
+func IsNewer(current, latest string) bool {
+	c, err := parseVersion(current)
+	if err != nil {
+		return false
+	}
+	l, err := parseVersion(latest)
+	if err != nil {
+		return false
+	}
+	for i := range c.core {
+		if l.core[i] != c.core[i] {
+			return l.core[i] > c.core[i]
+		}
+	}
+	// A release is newer than a prerelease of the same version.
+	switch {
+	case c.pre == l.pre:
+		return false
+	case l.pre == "":
+		return true
+	case c.pre == "":
+		return false
+	default:
+		return l.pre > c.pre
+	}
+}
+
+type semver struct {
+	core [3]int
+	pre  string
+}
+
+func parseVersion(v string) (semver, error) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return semver{}, fmt.Errorf("invalid version %q", v)
+	}
+	var s semver
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("invalid version %q", v)
+		}
+		s.core[i] = n
+	}
+	s.pre = pre
+	return s, nil
+}
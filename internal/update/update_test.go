@@ -0,0 +1,198 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		current string
+		latest  string
+		want    bool
+	}{
+		{"1.2.3", "v1.2.4", true},
+		{"v1.2.3", "v1.3.0", true},
+		{"1.2.3", "2.0.0", true},
+		{"1.2.3", "1.2.3", false},
+		{"1.3.0", "1.2.9", false},
+		{"1.2.3-rc.1", "1.2.3", true},
+		{"1.2.3", "1.2.3-rc.1", false},
+		{"dev", "1.0.0", false},
+		{"1.0.0", "garbage", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"->"+tt.latest, func(t *testing.T) {
+			if got := IsNewer(tt.current, tt.latest); got != tt.want {
+				t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageManager(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/opt/homebrew/Cellar/triage/1.0.0/bin/triage", "homebrew"},
+		{"/home/linuxbrew/.linuxbrew/bin/triage", "homebrew"},
+		{`C:\Users\me\scoop\apps\triage\current\triage.exe`, "scoop"},
+		{"/usr/local/bin/triage", ""},
+		{"/home/me/go/bin/triage", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := PackageManager(tt.path); got != tt.want {
+				t.Errorf("PackageManager(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// releaseServer serves a fake latest-release API and release downloads.
+func releaseServer(t *testing.T, tag string, archive []byte, checksum string) *httptest.Server {
+	t.Helper()
+	name := ArchiveName(tag, "linux", "amd64")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/repos/spiffcs/triage/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"tag_name": %q, "html_url": "https://example.com/%s"}`, tag, tag)
+	})
+	mux.HandleFunc("/download/"+tag+"/checksums.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  %s\n", checksum, name)
+	})
+	mux.HandleFunc("/download/"+tag+"/"+name, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(archive)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func tarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDownload(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho new\n")
+	archive := tarGz(t, "triage", binary)
+	sum := sha256.Sum256(archive)
+	good := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name     string
+		checksum string
+		goos     string
+		wantErr  string
+	}{
+		{name: "verified", checksum: good, goos: "linux"},
+		{name: "checksum mismatch", checksum: strings.Repeat("0", 64), goos: "linux", wantErr: "checksum mismatch"},
+		{name: "unsupported platform", checksum: good, goos: "windows", wantErr: "no release published"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := releaseServer(t, "v1.4.0", archive, tt.checksum)
+			u := New(
+				WithHTTPClient(srv.Client()),
+				WithBaseURLs(srv.URL+"/api", srv.URL+"/download"),
+				WithPlatform(tt.goos, "amd64"),
+			)
+
+			rel, err := u.Latest(context.Background())
+			if err != nil {
+				t.Fatalf("Latest() error = %v", err)
+			}
+			if rel.Tag != "v1.4.0" || rel.Version != "1.4.0" {
+				t.Fatalf("Latest() = %+v, want v1.4.0", rel)
+			}
+
+			got, err := u.Download(context.Background(), rel)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Download() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Download() error = %v", err)
+			}
+			if !bytes.Equal(got, binary) {
+				t.Errorf("Download() = %q, want %q", got, binary)
+			}
+		})
+	}
+}
+
+func TestDownloadRequiresCosign(t *testing.T) {
+	u := New(WithSignatureVerification(filepath.Join(t.TempDir(), "no-cosign")))
+	err := u.verifySignature(context.Background(), []byte("x"), []byte("y"))
+	if err == nil || !strings.Contains(err.Error(), "cosign not found") {
+		t.Fatalf("verifySignature() error = %v, want cosign not found", err)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "triage")
+	if err := os.WriteFile(path, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Replace(path, []byte("new")); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new" {
+		t.Errorf("binary = %q, want %q", got, "new")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("mode = %v, want executable", info.Mode())
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected temp file to be cleaned up, found %d entries", len(entries))
+	}
+
+	if err := Replace(filepath.Join(t.TempDir(), "missing"), []byte("x")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Replace(missing) error = %v, want ErrNotExist", err)
+	}
+}
@@ -0,0 +1,224 @@
+package triage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/cache"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/service"
+	"github.com/spiffcs/triage/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultSince is how far back Client looks for notifications by default.
+const DefaultSince = 7 * 24 * time.Hour
+
+// ErrRateLimited is returned (wrapped) when GitHub rate limits a request.
+var ErrRateLimited = ghclient.ErrRateLimited
+
+// ErrUnauthorized is returned (wrapped) when GitHub rejects the token, for
+// example because it was revoked or expired during a run.
+var ErrUnauthorized = ghclient.ErrUnauthorized
+
+// ProgressFunc is called as fetch sources start and complete.
+type ProgressFunc = service.ProgressFunc
+
+// Client runs the triage pipeline against GitHub for the authenticated user.
+type Client struct {
+	svc         *service.ItemService
+	cache       *cache.Cache // nil without WithCache
+	cfg         *config.Config
+	currentUser string
+	onProgress  ProgressFunc
+	// since is how far back each Fetch looks, from when it starts.
+	since time.Duration
+}
+
+// Option is a functional option for configuring a Client.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	cfg        *config.Config
+	since      time.Duration
+	useCache   bool
+	onProgress ProgressFunc
+	transport  http.RoundTripper
+	// refreshIdentity looks up the user and their teams instead of using
+	// the cached ones.
+	refreshIdentity bool
+}
+
+// WithConfig sets the configuration used for fetching, scoring, and
+// filtering. Defaults to config.DefaultConfig().
+func WithConfig(cfg *config.Config) Option {
+	return func(o *clientOptions) {
+		o.cfg = cfg
+	}
+}
+
+// WithSince sets how far back to look for notifications. Defaults to DefaultSince.
+func WithSince(d time.Duration) Option {
+	return func(o *clientOptions) {
+		o.since = d
+	}
+}
+
+// WithCache enables or disables the on-disk list cache shared with the
+// triage CLI. Enabled by default. Enrichment details are cached regardless.
+func WithCache(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.useCache = enabled
+	}
+}
+
+// WithRefreshIdentity looks up the authenticated user and their team
+// memberships even when the on-disk cache holds ones that haven't expired.
+// Both are otherwise cached for a week with the list cache.
+func WithRefreshIdentity(refresh bool) Option {
+	return func(o *clientOptions) {
+		o.refreshIdentity = refresh
+	}
+}
+
+// WithProgress sets a callback invoked as fetch sources start and complete.
+func WithProgress(fn ProgressFunc) Option {
+	return func(o *clientOptions) {
+		o.onProgress = fn
+	}
+}
+
+// WithHTTPTransport sends all GitHub API traffic through rt, e.g. to add
+// instrumentation or to replay recorded responses in tests.
+func WithHTTPTransport(rt http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = rt
+	}
+}
+
+// New creates a Client authenticated with token and resolves the current user.
+func New(ctx context.Context, token string, opts ...Option) (*Client, error) {
+	o := clientOptions{
+		since:    DefaultSince,
+		useCache: true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.cfg == nil {
+		o.cfg = config.DefaultConfig()
+	}
+	if token == "" {
+		return nil, errors.New("a GitHub token is required")
+	}
+
+	policy, err := ghclient.NewHTTPPolicy(o.cfg.GetHTTP())
+	if err != nil {
+		return nil, err
+	}
+	ghOpts := []ghclient.ClientOption{
+		ghclient.WithHTTPPolicy(policy),
+		ghclient.WithProjectStatus(len(o.cfg.ProjectColumnScores) > 0),
+	}
+	if o.transport != nil {
+		ghOpts = append(ghOpts, ghclient.WithTransport(o.transport))
+	}
+	gh, err := ghclient.NewClient(ctx, token, ghOpts...)
+	if err != nil {
+		return nil, err
+	}
+	var c *cache.Cache
+	if o.useCache {
+		// The cache is an optimization; run without it if it cannot be opened
+		c, _ = cache.NewCache(cache.WithMaxSize(int64(o.cfg.GetCacheMaxSizeMB()) << 20))
+	}
+
+	currentUser, err := service.ResolveUser(ctx, gh, c, token, o.refreshIdentity)
+	if err != nil {
+		if c != nil {
+			_ = c.Close()
+		}
+		return nil, fmt.Errorf("failed to resolve authenticated user: %w", err)
+	}
+
+	svc := service.New(gh, c, currentUser, time.Now().Add(-o.since),
+		service.WithRefreshIdentity(o.refreshIdentity),
+		service.WithScoreWeights(o.cfg.GetScoreWeights()),
+		service.WithProjectStatus(len(o.cfg.ProjectColumnScores) > 0),
+	)
+
+	return &Client{
+		svc:         svc,
+		cache:       c,
+		cfg:         o.cfg,
+		currentUser: currentUser,
+		onProgress:  o.onProgress,
+		since:       o.since,
+	}, nil
+}
+
+// Close releases the on-disk cache. A Client can fetch any number of
+// times, e.g. on an interval, and should be closed once it is done.
+func (c *Client) Close() error {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.Close()
+}
+
+// CurrentUser returns the login of the authenticated user.
+func (c *Client) CurrentUser() string {
+	return c.currentUser
+}
+
+// Fetch queries every configured source in parallel. Rate limiting is not
+// an error: the affected sources are left empty and RateLimited is set.
+// On other errors the partial result is still returned.
+func (c *Client) Fetch(ctx context.Context) (*FetchResult, error) {
+	c.svc.SetSince(time.Now().Add(-c.since))
+	fetcher := service.NewFetcher(c.svc, c.onProgress)
+	return fetcher.FetchAll(ctx, NewFetchOptions(c.cfg))
+}
+
+// Enrich fills in PR and issue details for the fetched notifications,
+// review-requested PRs, and authored PRs in place, most important first.
+// It returns the number of items that could not be enriched alongside any
+// error.
+func (c *Client) Enrich(ctx context.Context, result *FetchResult) (int, error) {
+	res, err := c.svc.EnrichAll(ctx, nil, result.Notifications, result.ReviewPRs, result.AuthoredPRs)
+	return res.Failed, err
+}
+
+// Run fetches, enriches, prioritizes, and filters items, returning them in
+// priority order. Fetch and enrichment errors for individual sources are
+// returned alongside whatever items could still be triaged. If GitHub
+// rejects the token midway, the error wraps ErrUnauthorized and the items
+// fetched before the rejection are returned, including unenriched ones.
+// Like the CLI, Run leaves out archived items; call the stages directly to
+// keep them.
+func (c *Client) Run(ctx context.Context) ([]PrioritizedItem, error) {
+	result, fetchErr := c.Fetch(ctx)
+	if result == nil {
+		return nil, fetchErr
+	}
+	if result.Unauthorized {
+		merged, _ := result.Merge()
+		items := FilterPartial(Prioritize(merged, c.currentUser, c.cfg, result.Teams...), c.cfg)
+		return FilterOutArchived(items), errors.Join(ErrUnauthorized, fetchErr)
+	}
+	_, enrichErr := c.Enrich(ctx, result)
+
+	merged, _ := result.Merge()
+	_, span := telemetry.Start(ctx, "score", attribute.Int("triage.items", len(merged)))
+	prioritized := Prioritize(merged, c.currentUser, c.cfg, result.Teams...)
+	telemetry.End(span, nil)
+	if errors.Is(enrichErr, ErrUnauthorized) {
+		return FilterOutArchived(FilterPartial(prioritized, c.cfg)), enrichErr
+	}
+	items, _ := Filter(prioritized, c.cfg)
+	return FilterOutArchived(items), errors.Join(fetchErr, enrichErr)
+}
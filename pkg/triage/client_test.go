@@ -0,0 +1,75 @@
+package triage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/spiffcs/triage/internal/ghclient"
+)
+
+// TestRunReplay drives the whole pipeline (list, enrich, score, filter)
+// against a cassette of synthetic acme/* GitHub responses.
+func TestRunReplay(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	cassette, err := ghclient.LoadCassette("testdata/cassettes/run.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer := ghclient.NewReplayer(cassette)
+
+	ctx := context.Background()
+	c, err := New(ctx, "test-token", WithHTTPTransport(replayer), WithCache(false))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+	if c.CurrentUser() != "octocat" {
+		t.Errorf("CurrentUser() = %q, want octocat", c.CurrentUser())
+	}
+
+	items, err := c.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := map[string]string{
+		"acme/api#12": "Review PR",
+		"acme/api#15": "Merge PR",
+		"acme/web#40": "Respond to mention",
+	}
+	if len(items) != len(want) {
+		t.Fatalf("Run() returned %d items, want %d", len(items), len(want))
+	}
+	for _, item := range items {
+		ref := fmt.Sprintf("%s#%d", item.Repository.FullName, item.Number)
+		action, ok := want[ref]
+		if !ok {
+			t.Errorf("unexpected item %s", ref)
+			continue
+		}
+		if item.ActionNeeded != action {
+			t.Errorf("%s action = %q, want %q", ref, item.ActionNeeded, action)
+		}
+		if item.Details == nil {
+			t.Errorf("%s was not enriched", ref)
+		}
+		if touched := item.LastInteractionAt != nil; touched != (ref == "acme/api#12") {
+			t.Errorf("%s LastInteractionAt = %v, want set only where octocat commented", ref, item.LastInteractionAt)
+		}
+		if item.Priority != PriorityUrgent {
+			t.Errorf("%s priority = %s, want %s", ref, item.Priority, PriorityUrgent)
+		}
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i].Score > items[i-1].Score {
+			t.Errorf("items not sorted by score: %d before %d", items[i-1].Score, items[i].Score)
+		}
+	}
+
+	if unused := replayer.Unused(); len(unused) > 0 {
+		t.Errorf("recorded interactions never replayed: %v", unused)
+	}
+}
@@ -0,0 +1,170 @@
+// Package triage is the embeddable triage engine. It fetches GitHub
+// notifications and related work items, enriches them with PR and issue
+// details, scores them, and filters them the same way the triage CLI does,
+// without depending on cobra or the TUI.
+//
+// A typical caller builds a Client and calls Run:
+//
+//	c, err := triage.New(ctx, token, triage.WithConfig(cfg))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//	items, err := c.Run(ctx)
+//
+// The individual stages (Fetch, Enrich, Prioritize, Filter) are also exported
+// for callers that need to customize the pipeline.
+package triage
+
+import (
+	"strings"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/activity"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/model"
+	"github.com/spiffcs/triage/internal/service"
+	"github.com/spiffcs/triage/internal/triage"
+)
+
+// Item is a GitHub notification, PR, or issue.
+type Item = model.Item
+
+// PrioritizedItem wraps an Item with its score, priority, and suggested action.
+type PrioritizedItem = triage.PrioritizedItem
+
+// PriorityLevel is the action priority assigned to an item.
+type PriorityLevel = triage.PriorityLevel
+
+// Priority levels, from most to least pressing.
+const (
+	PriorityUrgent    = triage.PriorityUrgent
+	PriorityImportant = triage.PriorityImportant
+	PriorityQuickWin  = triage.PriorityQuickWin
+	PriorityNotable   = triage.PriorityNotable
+	PriorityFYI       = triage.PriorityFYI
+	PriorityArchive   = triage.PriorityArchive
+)
+
+// FetchResult holds the items fetched from each source. Merge combines
+// them into a single deduplicated list.
+type FetchResult = service.FetchResult
+
+// FetchOptions configures which sources Fetch queries.
+type FetchOptions = service.FetchOptions
+
+// Prioritize scores and sorts items for currentUser using the weights and
+// quick win labels from cfg, noting when currentUser last commented or
+// reviewed each. Items age from their last human activity, ignoring the
+// bot authors in cfg. PRs asking one of teams ("org/team-slug", as in
+// FetchResult.Teams) for a review score higher. A nil cfg uses the defaults.
+func Prioritize(items []Item, currentUser string, cfg *config.Config, teams ...string) []PrioritizedItem {
+	return newEngine(items, currentUser, cfg, teams).Prioritize(items)
+}
+
+// Explanation breaks down how an item got its score and priority.
+type Explanation = triage.Explanation
+
+// ScoreFactor is one part of an item's score.
+type ScoreFactor = triage.ScoreFactor
+
+// Explain scores item like Prioritize and returns the parts of its score
+// and how it got its priority.
+func Explain(item Item, currentUser string, cfg *config.Config, teams ...string) Explanation {
+	items := []Item{item}
+	return newEngine(items, currentUser, cfg, teams).Explain(items[0])
+}
+
+// newEngine notes the activity on items that scoring looks at and returns
+// the engine that scores them. A nil cfg uses the defaults.
+func newEngine(items []Item, currentUser string, cfg *config.Config, teams []string) *triage.Engine {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	activity.Apply(items, currentUser, nil)
+	activity.ApplyHuman(items, cfg.GetBotAuthors())
+	return triage.NewEngine(currentUser, cfg.GetScoreWeights(), cfg.GetQuickWinLabels(), triage.WithTeams(teams))
+}
+
+// Filter removes merged, closed, and unenriched items, then drops the
+// authors and repositories excluded in cfg and the issue form fields it
+// doesn't keep. It returns the remaining items and the number dropped
+// because they could not be enriched (typically deleted or inaccessible).
+// A nil cfg applies only the default filters.
+func Filter(items []PrioritizedItem, cfg *config.Config) ([]PrioritizedItem, int) {
+	items, unenriched := triage.FilterOutUnenriched(items)
+	return filter(items, cfg), unenriched
+}
+
+// FilterPartial is Filter for runs that were cut short, for example when
+// GitHub rejected the token midway: items that could not be enriched are
+// kept (scored on what the notification alone says) instead of dropped.
+func FilterPartial(items []PrioritizedItem, cfg *config.Config) []PrioritizedItem {
+	return filter(items, cfg)
+}
+
+func filter(items []PrioritizedItem, cfg *config.Config) []PrioritizedItem {
+	items = triage.FilterOutMerged(items)
+	items = triage.FilterOutClosed(items)
+
+	if cfg == nil {
+		return items
+	}
+	if len(cfg.ExcludeAuthors) > 0 {
+		items = triage.FilterByExcludedAuthors(items, cfg.ExcludeAuthors)
+	}
+	if len(cfg.ExcludeRepos) > 0 {
+		excluded, _ := cfg.ExpandRepos(cfg.ExcludeRepos)
+		items = triage.FilterByExcludedRepos(items, excluded)
+	}
+	return triage.SelectFormFields(items, cfg.GetIssueFormFields())
+}
+
+// FilterOutArchived removes items that had no activity for longer than
+// scoring.archive_after_days in the config.
+func FilterOutArchived(items []PrioritizedItem) []PrioritizedItem {
+	return triage.FilterOutArchived(items)
+}
+
+// NewFetchOptions builds the fetch options (orphaned repos and rules,
+// upstream watches, read notifications, per-repo limits) described by cfg. A nil cfg
+// uses the defaults.
+func NewFetchOptions(cfg *config.Config) FetchOptions {
+	if cfg == nil {
+		return FetchOptions{}
+	}
+
+	opts := FetchOptions{
+		IncludeReadNotifications: cfg.IncludeReadNotifications,
+		Teams:                    true,
+	}
+	if cfg.Orphaned != nil {
+		opts.OrphanedRepos, _ = cfg.ExpandRepos(cfg.Orphaned.Repos)
+		opts.StaleDays = cfg.Orphaned.StaleDays
+		opts.ConsecutiveComments = cfg.Orphaned.ConsecutiveAuthorComments
+		opts.MaxItemsPerRepo = cfg.Orphaned.MaxItemsPerRepo
+		opts.OrphanedTriageLabels = cfg.Orphaned.TriageLabels
+		opts.OrphanedTeam = cfg.Orphaned.Team
+		opts.OrphanedTeams = cfg.Orphaned.Teams
+		opts.OrphanedRules = make(map[string]ghclient.OrphanedRules, len(opts.OrphanedRepos))
+		for _, repo := range opts.OrphanedRepos {
+			rules := cfg.GetOrphanedRules(repo)
+			opts.OrphanedRules[strings.ToLower(repo)] = ghclient.OrphanedRules{
+				ExternalAuthor: rules.ExternalAuthor,
+				NoTeamResponse: rules.NoTeamResponse,
+				NoAssignee:     rules.NoAssignee,
+				NoTriageLabel:  rules.NoTriageLabel,
+			}
+		}
+	}
+	for _, u := range cfg.Upstream {
+		opts.UpstreamWatches = append(opts.UpstreamWatches, ghclient.UpstreamWatch{
+			Repo:    u.Repo,
+			Blocks:  u.Blocks,
+			Release: u.Release,
+			After:   u.After,
+			Number:  u.Number,
+		})
+	}
+	return opts
+}
@@ -0,0 +1,145 @@
+package triage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spiffcs/triage/config"
+	"github.com/spiffcs/triage/internal/ghclient"
+	"github.com/spiffcs/triage/internal/model"
+)
+
+func TestPrioritize(t *testing.T) {
+	items := []Item{
+		{
+			ID:        "subscribed",
+			Reason:    model.ReasonSubscribed,
+			UpdatedAt: time.Now(),
+			Subject:   model.Subject{Type: model.SubjectIssue},
+		},
+		{
+			ID:        "review",
+			Reason:    model.ReasonReviewRequested,
+			UpdatedAt: time.Now(),
+			Subject:   model.Subject{Type: model.SubjectPullRequest},
+		},
+	}
+
+	got := Prioritize(items, "me", nil)
+	if len(got) != 2 {
+		t.Fatalf("Prioritize() returned %d items, want 2", len(got))
+	}
+	if got[0].ID != "review" || got[0].Priority != PriorityUrgent {
+		t.Errorf("first item = %s (%s), want review (%s)", got[0].ID, got[0].Priority, PriorityUrgent)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	enriched := &model.IssueDetails{}
+	items := []PrioritizedItem{
+		{Item: model.Item{ID: "keep", State: "open", Author: "alice", Details: enriched,
+			Subject: model.Subject{Type: model.SubjectIssue}, Repository: model.Repository{FullName: "o/keep"}}},
+		{Item: model.Item{ID: "closed", State: "closed", Details: enriched,
+			Subject: model.Subject{Type: model.SubjectIssue}}},
+		{Item: model.Item{ID: "unenriched", State: "open",
+			Subject: model.Subject{Type: model.SubjectIssue}}},
+		{Item: model.Item{ID: "bot", State: "open", Author: "dependabot[bot]", Details: enriched,
+			Subject: model.Subject{Type: model.SubjectIssue}}},
+		{Item: model.Item{ID: "noisy", State: "open", Author: "bob", Details: enriched,
+			Subject: model.Subject{Type: model.SubjectIssue}, Repository: model.Repository{FullName: "o/noisy"}}},
+	}
+
+	tests := []struct {
+		name           string
+		cfg            *config.Config
+		wantIDs        []string
+		wantUnenriched int
+	}{
+		{
+			name:           "nil config applies default filters",
+			cfg:            nil,
+			wantIDs:        []string{"keep", "bot", "noisy"},
+			wantUnenriched: 1,
+		},
+		{
+			name: "excluded authors and repos",
+			cfg: &config.Config{
+				ExcludeAuthors: []string{"dependabot[bot]"},
+				ExcludeRepos:   []string{"o/noisy"},
+			},
+			wantIDs:        []string{"keep"},
+			wantUnenriched: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partial := FilterPartial(items, tt.cfg)
+			if len(partial) != len(tt.wantIDs)+tt.wantUnenriched {
+				t.Errorf("FilterPartial() kept %d items, want %d", len(partial), len(tt.wantIDs)+tt.wantUnenriched)
+			}
+
+			got, unenriched := Filter(items, tt.cfg)
+			if unenriched != tt.wantUnenriched {
+				t.Errorf("unenriched = %d, want %d", unenriched, tt.wantUnenriched)
+			}
+			var ids []string
+			for _, item := range got {
+				ids = append(ids, item.ID)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("Filter() = %v, want %v", ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Errorf("Filter() = %v, want %v", ids, tt.wantIDs)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNewFetchOptions(t *testing.T) {
+	noAssignee := true
+	cfg := &config.Config{
+		IncludeReadNotifications: true,
+		Orphaned: &config.OrphanedConfig{
+			Repos:                     []string{"o/r"},
+			StaleDays:                 14,
+			ConsecutiveAuthorComments: 3,
+			MaxItemsPerRepo:           20,
+			Teams:                     []string{"o/maintainers"},
+			RepoRules: map[string]*config.OrphanedRuleOverrides{
+				"O/R": {NoAssignee: &noAssignee},
+			},
+		},
+		Upstream: []config.UpstreamConfig{{Repo: "acme/lib", Blocks: "acme/app", After: "v1.4.0"}},
+	}
+
+	got := NewFetchOptions(cfg)
+	if !got.IncludeReadNotifications || len(got.OrphanedRepos) != 1 ||
+		got.StaleDays != 14 || got.ConsecutiveComments != 3 || got.MaxItemsPerRepo != 20 ||
+		len(got.OrphanedTeams) != 1 {
+		t.Errorf("NewFetchOptions() = %+v", got)
+	}
+	if len(got.UpstreamWatches) != 1 || got.UpstreamWatches[0].Repo != "acme/lib" ||
+		got.UpstreamWatches[0].Blocks != "acme/app" || got.UpstreamWatches[0].After != "v1.4.0" {
+		t.Errorf("NewFetchOptions().UpstreamWatches = %+v", got.UpstreamWatches)
+	}
+	wantRules := ghclient.OrphanedRules{ExternalAuthor: true, NoTeamResponse: true, NoAssignee: true}
+	if got.OrphanedRules["o/r"] != wantRules {
+		t.Errorf("NewFetchOptions().OrphanedRules = %+v, want o/r: %+v", got.OrphanedRules, wantRules)
+	}
+
+	if got := NewFetchOptions(nil); len(got.OrphanedRepos) != 0 || got.IncludeReadNotifications {
+		t.Errorf("NewFetchOptions(nil) = %+v, want zero value", got)
+	}
+}
+
+func TestNewRequiresToken(t *testing.T) {
+	if _, err := New(context.Background(), ""); err == nil {
+		t.Error("New() with empty token should fail")
+	}
+}